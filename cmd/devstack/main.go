@@ -0,0 +1,115 @@
+// Command devstack runs a local registry for end-to-end development: the registry built from this checkout,
+// PostgreSQL and the seed data, using the services in docker-compose.yml.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"time"
+)
+
+const (
+	// registryURL is where docker-compose.yml exposes the registry
+	registryURL = "http://localhost:8080"
+	// readyTimeout bounds how long `devstack up` waits for the registry to become healthy, including the image build
+	readyTimeout = 5 * time.Minute
+)
+
+func main() {
+	log.SetFlags(0)
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "up":
+		err = up()
+	case "down":
+		err = compose("down")
+	case "logs":
+		err = compose("logs", "--follow", "registry")
+	case "--help", "-h", "help":
+		printUsage()
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n", os.Args[1])
+		printUsage()
+		os.Exit(1)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func printUsage() {
+	_, _ = fmt.Fprintln(os.Stdout, "MCP Registry local development stack")
+	_, _ = fmt.Fprintln(os.Stdout)
+	_, _ = fmt.Fprintln(os.Stdout, "Usage:")
+	_, _ = fmt.Fprintln(os.Stdout, "  devstack <command>")
+	_, _ = fmt.Fprintln(os.Stdout)
+	_, _ = fmt.Fprintln(os.Stdout, "Commands:")
+	_, _ = fmt.Fprintln(os.Stdout, "  up      Build and start the registry and PostgreSQL in the background, and wait until the registry is healthy")
+	_, _ = fmt.Fprintln(os.Stdout, "  down    Stop the stack, keeping the database in .db")
+	_, _ = fmt.Fprintln(os.Stdout, "  logs    Follow the registry's logs")
+}
+
+// up starts the stack and waits for the registry to report healthy
+func up() error {
+	if err := compose("up", "--build", "--detach"); err != nil {
+		return err
+	}
+
+	log.Printf("Waiting for the registry at %s...", registryURL)
+	ctx, cancel := context.WithTimeout(context.Background(), readyTimeout)
+	defer cancel()
+	if err := waitHealthy(ctx); err != nil {
+		return fmt.Errorf("registry didn't become healthy, see `devstack logs`: %w", err)
+	}
+
+	log.Printf("Registry ready at %s, with PostgreSQL at localhost:5432 and the servers in data/seed.json", registryURL)
+	log.Printf("Get a token for the io.modelcontextprotocol.anonymous namespace with: curl -X POST %s/v0/auth/none", registryURL)
+	return nil
+}
+
+// waitHealthy polls the registry's health endpoint until it answers 200 OK
+func waitHealthy(ctx context.Context) error {
+	client := &http.Client{Timeout: 5 * time.Second}
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, registryURL+"/v0/health", nil)
+		if err != nil {
+			return err
+		}
+		if resp, err := client.Do(req); err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// compose runs a docker compose command against docker-compose.yml, passing its output through
+func compose(args ...string) error {
+	cmd := exec.Command("docker", append([]string{"compose"}, args...)...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("docker compose %s: %w", args[0], err)
+	}
+	return nil
+}
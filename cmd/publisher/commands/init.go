@@ -301,7 +301,7 @@ func createServerJSON(
 
 	// Create server structure
 	return apiv0.ServerJSON{
-		Schema:      "https://static.modelcontextprotocol.io/schemas/2025-07-09/server.schema.json",
+		Schema:      apiv0.SchemaURL(model.CurrentSchemaVersion),
 		Name:        name,
 		Description: description,
 		Status:      model.StatusActive,
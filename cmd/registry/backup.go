@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/backup"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/secrets"
+	"github.com/modelcontextprotocol/registry/internal/storage"
+)
+
+// backupTimeout bounds a single `registry backup` or `registry restore` run
+const backupTimeout = 30 * time.Minute
+
+// runBackup implements `registry backup`, which dumps the catalog to the backup storage, and
+// `registry restore <dump>`, which restores a dump from the backup storage, or a local file, into an empty database
+func runBackup(command string, args []string) error {
+	if (command == "backup" && len(args) != 0) || (command == "restore" && len(args) != 1) {
+		return errors.New("usage: registry backup | registry restore <dump>")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), backupTimeout)
+	defer cancel()
+
+	cfg := config.NewConfig()
+	if err := secrets.FromConfig(cfg).ResolveConfig(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to resolve secrets: %w", err)
+	}
+
+	db, err := openDatabase(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if command == "restore" {
+		data, err := os.ReadFile(args[0])
+		if errors.Is(err, os.ErrNotExist) {
+			data, err = readBackup(ctx, cfg, args[0])
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", args[0], err)
+		}
+		count, err := backup.Restore(ctx, db, bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		log.Printf("Restored %d server versions from %s", count, args[0])
+		return nil
+	}
+
+	if cfg.BackupStorageURL == "" {
+		return errors.New("BACKUP_STORAGE_URL must be set")
+	}
+	store, err := storage.New(cfg.BackupStorageURL, cfg.BackupStorageAccessKeyID, cfg.BackupStorageSecretAccessKey)
+	if err != nil {
+		return err
+	}
+
+	var dump bytes.Buffer
+	count, err := backup.Dump(ctx, db, &dump)
+	if err != nil {
+		return err
+	}
+	key := "registry-" + time.Now().UTC().Format("20060102T150405Z") + ".ndjson"
+	if err := store.Put(ctx, key, "application/x-ndjson", dump.Bytes()); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	log.Printf("Backed up %d server versions to %s", count, key)
+	return nil
+}
+
+// readBackup reads a dump from the backup storage
+func readBackup(ctx context.Context, cfg *config.Config, key string) ([]byte, error) {
+	if cfg.BackupStorageURL == "" {
+		return nil, errors.New("no such file, and BACKUP_STORAGE_URL isn't set")
+	}
+	store, err := storage.New(cfg.BackupStorageURL, cfg.BackupStorageAccessKeyID, cfg.BackupStorageSecretAccessKey)
+	if err != nil {
+		return nil, err
+	}
+	data, _, err := store.Get(ctx, key)
+	return data, err
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/importer"
+	"github.com/modelcontextprotocol/registry/internal/secrets"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// importTimeout bounds a single `registry import` run; an interrupted import resumes from its checkpoint
+const importTimeout = 2 * time.Hour
+
+// runImport implements `registry import [--checkpoint <file>] <dir|file|url>`, which validates and publishes every
+// server.json in a catalog, with the same checks as the publish endpoint
+func runImport(args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	checkpointPath := flags.String("checkpoint", "registry-import.checkpoint", "File recording the server versions already imported")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 1 {
+		return errors.New("usage: registry import [--checkpoint <file>] <dir|file|url>")
+	}
+	source := flags.Arg(0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), importTimeout)
+	defer cancel()
+
+	cfg := config.NewConfig()
+	if err := secrets.FromConfig(cfg).ResolveConfig(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to resolve secrets: %w", err)
+	}
+	if err := configureRegistryValidators(cfg, nil); err != nil {
+		return fmt.Errorf("failed to configure registry validators: %w", err)
+	}
+
+	checkpoint, err := importer.LoadCheckpoint(*checkpointPath)
+	if err != nil {
+		return fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	db, err := openDatabase(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	report, err := importer.ImportCatalog(ctx, source, service.NewRegistryService(db, cfg), checkpoint)
+	if report != nil {
+		log.Printf("Import summary: %d published, %d already published, %d imported by an earlier run, %d invalid, %d failed",
+			report.Published, report.AlreadyPublished, report.Resumed, len(report.Invalid), len(report.Failed))
+		for _, invalid := range report.Invalid {
+			log.Printf("  invalid: %s", invalid)
+		}
+		for _, failed := range report.Failed {
+			log.Printf("  failed: %s", failed)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if len(report.Failed) > 0 {
+		return fmt.Errorf("%d server versions failed to publish; run the import again to retry them", len(report.Failed))
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"flag"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -12,11 +13,21 @@ import (
 	"time"
 
 	"github.com/modelcontextprotocol/registry/internal/api"
+	"github.com/modelcontextprotocol/registry/internal/cache"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/grpcapi"
 	"github.com/modelcontextprotocol/registry/internal/importer"
+	"github.com/modelcontextprotocol/registry/internal/mirror"
+	"github.com/modelcontextprotocol/registry/internal/prober"
+	"github.com/modelcontextprotocol/registry/internal/secrets"
 	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/storage"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/internal/webhooks"
+	"go.opentelemetry.io/otel"
 )
 
 // Version info for the MCP Registry application
@@ -32,6 +43,13 @@ var (
 	GitCommit = "unknown"
 )
 
+const (
+	// transferProcessInterval is how often pending server transfers are checked for expiry or completion
+	transferProcessInterval = time.Minute
+	// blocklistRefreshInterval is how often blocklist changes made through other instances are picked up
+	blocklistRefreshInterval = time.Minute
+)
+
 func main() {
 	// Parse command line flags
 	showVersion := flag.Bool("version", false, "Display version information")
@@ -45,61 +63,111 @@ func main() {
 		return
 	}
 
-	log.Printf("Starting MCP Registry Application v%s (commit: %s)", Version, GitCommit)
+	// Apply or inspect schema migrations without starting the server
+	if flag.Arg(0) == "migrate" {
+		if err := runMigrate(flag.Args()[1:]); err != nil {
+			log.Printf("Migration failed: %v", err)
+			os.Exit(1)
+		}
+		return
+	}
 
-	var (
-		registryService service.RegistryService
-		db              database.Database
-		err             error
-	)
+	// Dump the catalog to object storage, or restore a dump, without starting the server
+	if command := flag.Arg(0); command == "backup" || command == "restore" {
+		if err := runBackup(command, flag.Args()[1:]); err != nil {
+			log.Printf("Failed to %s: %v", command, err)
+			os.Exit(1)
+		}
+		return
+	}
 
-	// Initialize configuration
-	cfg := config.NewConfig()
+	// Publish a catalog of server.json files without starting the server
+	if flag.Arg(0) == "import" {
+		if err := runImport(flag.Args()[1:]); err != nil {
+			log.Printf("Import failed: %v", err)
+			os.Exit(1)
+		}
+		return
+	}
 
-	// Initialize services based on environment
-	switch cfg.DatabaseType {
-	case config.DatabaseTypeMemory:
-		db = database.NewMemoryDB()
-	case config.DatabaseTypePostgreSQL:
-		// Use PostgreSQL for real registry service
-		// Create a context with timeout for PostgreSQL connection
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
+	log.Printf("Starting MCP Registry Application v%s (commit: %s)", Version, GitCommit)
 
-		// Connect to PostgreSQL
-		db, err = database.NewPostgreSQL(ctx, cfg.DatabaseURL)
+	// Initialize configuration, applying the settings file and checking the settings that can be reloaded
+	cfg, err := config.Reload(config.NewConfig())
+	if err != nil {
+		log.Printf("Invalid configuration: %v", err)
+		return
+	}
+
+	// Isolated registries hosted alongside the default one
+	var tenantConfigs map[string]*config.Config
+	if cfg.TenantsFile != "" {
+		tenantConfigs, err = config.LoadTenants(cfg.TenantsFile)
 		if err != nil {
-			log.Printf("Failed to connect to PostgreSQL: %v", err)
+			log.Printf("Failed to load tenants: %v", err)
 			return
 		}
+	}
 
-		// Store the PostgreSQL instance for later cleanup
-		defer func() {
-			if err := db.Close(); err != nil {
-				log.Printf("Error closing PostgreSQL connection: %v", err)
-			} else {
-				log.Println("PostgreSQL connection closed successfully")
-			}
-		}()
-	default:
-		log.Printf("Invalid database type: %s; supported types: %s, %s", cfg.DatabaseType, config.DatabaseTypeMemory, config.DatabaseTypePostgreSQL)
+	// Fetch credentials kept in secret stores rather than in the environment
+	secretsCtx, cancelSecrets := context.WithTimeout(context.Background(), time.Minute)
+	defer cancelSecrets()
+	secretResolver := secrets.FromConfig(cfg)
+	if err := secretResolver.ResolveConfig(secretsCtx, cfg); err != nil {
+		log.Printf("Failed to resolve secrets: %v", err)
+		return
+	}
+	for _, name := range config.TenantNames(tenantConfigs) {
+		if err := secretResolver.ResolveConfig(secretsCtx, tenantConfigs[name]); err != nil {
+			log.Printf("Failed to resolve secrets of tenant %s: %v", name, err)
+			return
+		}
+	}
+	if err := config.CheckTenantIsolation(cfg, tenantConfigs); err != nil {
+		log.Printf("Invalid tenants: %v", err)
 		return
 	}
 
-	registryService = service.NewRegistryService(db, cfg)
+	// Cache successful package validations to avoid upstream registry rate limits
+	validationCache, err := cache.New(cfg.RedisURL)
+	if err != nil {
+		log.Printf("Failed to initialize validation cache: %v", err)
+		return
+	}
+	defer validationCache.Close()
+	registries.ConfigureValidationCache(validationCache, cfg.ValidationCacheTTL)
 
-	// Import seed data if seed source is provided
-	if cfg.SeedFrom != "" {
-		log.Printf("Importing data from %s...", cfg.SeedFrom)
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-		defer cancel()
+	if err := configureRegistryValidators(cfg, tenantConfigs); err != nil {
+		log.Printf("Failed to configure registry validators: %v", err)
+		return
+	}
 
-		importerService := importer.NewService(db)
-		if err := importerService.ImportFromPath(ctx, cfg.SeedFrom); err != nil {
-			log.Printf("Failed to import seed data: %v", err)
-		} else {
-			log.Println("Data import completed successfully")
+	// Background jobs for every registry stop when the server shuts down
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	registryService, closeRegistry, err := startRegistry(jobsCtx, cfg, validationCache)
+	if err != nil {
+		log.Printf("Failed to start registry: %v", err)
+		return
+	}
+	defer closeRegistry()
+
+	// Apply reloadable settings of the default registry on SIGHUP and when the settings file changes
+	go watchSettings(jobsCtx, registryService)
+
+	tenants := make(map[string]api.Tenant, len(tenantConfigs))
+	for _, name := range config.TenantNames(tenantConfigs) {
+		tenantCfg := tenantConfigs[name]
+		tenantService, closeTenant, err := startRegistry(jobsCtx, tenantCfg, validationCache)
+		if err != nil {
+			log.Printf("Failed to start tenant %s: %v", name, err)
+			return
 		}
+		defer closeTenant()
+
+		log.Printf("Serving tenant %s", name)
+		tenants[name] = api.Tenant{Config: tenantCfg, Registry: tenantService}
 	}
 
 	shutdownTelemetry, metrics, err := telemetry.InitMetrics(cfg.Version)
@@ -114,8 +182,13 @@ func main() {
 		}
 	}()
 
+	if err := registries.RegisterMetrics(otel.Meter(telemetry.Namespace), telemetry.Namespace); err != nil {
+		log.Printf("Failed to initialize validator metrics: %v", err)
+		return
+	}
+
 	// Initialize HTTP server
-	server := api.NewServer(cfg, registryService, metrics)
+	server := api.NewServer(cfg, registryService, metrics, tenants)
 
 	// Start server in a goroutine so it doesn't block signal handling
 	go func() {
@@ -125,6 +198,18 @@ func main() {
 		}
 	}()
 
+	// Serve the gRPC API of the default registry alongside HTTP when configured
+	var grpcServer *grpcapi.Server
+	if cfg.GRPCAddress != "" {
+		grpcServer = grpcapi.NewServer(cfg, registryService)
+		go func() {
+			if err := grpcServer.Start(); err != nil {
+				log.Printf("Failed to start gRPC server: %v", err)
+				os.Exit(1)
+			}
+		}()
+	}
+
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 
@@ -140,6 +225,273 @@ func main() {
 	if err := server.Shutdown(sctx); err != nil {
 		log.Printf("Server forced to shutdown: %v", err)
 	}
+	if grpcServer != nil {
+		if err := grpcServer.Shutdown(sctx); err != nil {
+			log.Printf("gRPC server forced to shutdown: %v", err)
+		}
+	}
 
 	log.Println("Server exiting")
 }
+
+// startRegistry connects a registry's database, creates its service and starts its background jobs until ctx is
+// cancelled. The returned function closes the database.
+func startRegistry(ctx context.Context, cfg *config.Config, validationCache cache.Cache) (service.RegistryService, func(), error) {
+	db, err := openDatabase(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	closeDB := func() {
+		if err := db.Close(); err != nil {
+			log.Printf("Error closing database connection: %v", err)
+		}
+	}
+
+	iconStore, err := storage.New(cfg.IconStorageURL, cfg.IconStorageAccessKeyID, cfg.IconStorageSecretAccessKey)
+	if err != nil {
+		closeDB()
+		return nil, nil, fmt.Errorf("failed to initialize icon storage: %w", err)
+	}
+
+	// The validation cache connection is shared with the read cache; keys are namespaced separately
+	registryService := service.NewCachedRegistryService(db, cfg, validationCache, iconStore)
+
+	// Import seed data if seed source is provided
+	if cfg.SeedFrom != "" {
+		log.Printf("Importing data from %s...", cfg.SeedFrom)
+		importCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+		importerService := importer.NewService(db)
+		if err := importerService.ImportFromPath(importCtx, cfg.SeedFrom); err != nil {
+			log.Printf("Failed to import seed data: %v", err)
+		} else {
+			log.Println("Data import completed successfully")
+		}
+		cancel()
+	}
+
+	if err := startBackgroundJobs(ctx, cfg, db, registryService); err != nil {
+		closeDB()
+		return nil, nil, err
+	}
+	return registryService, closeDB, nil
+}
+
+// openDatabase connects to the database configured for a registry
+func openDatabase(cfg *config.Config) (database.Database, error) {
+	switch cfg.DatabaseType {
+	case config.DatabaseTypeMemory:
+		return database.NewMemoryDB(), nil
+	case config.DatabaseTypePostgreSQL:
+		// Create a context with timeout for PostgreSQL connection
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		postgres, err := database.NewPostgreSQL(ctx, cfg.DatabaseURL, cfg.DatabaseAutoMigrate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to PostgreSQL: %w", err)
+		}
+
+		// Serve public reads from a replica when configured
+		if cfg.DatabaseReadURL != "" {
+			if err := postgres.ConnectReadReplica(ctx, cfg.DatabaseReadURL, cfg.DatabaseReadMaxLag); err != nil {
+				_ = postgres.Close()
+				return nil, fmt.Errorf("failed to connect to PostgreSQL read replica: %w", err)
+			}
+		}
+		return postgres, nil
+	default:
+		return nil, fmt.Errorf("invalid database type: %s; supported types: %s, %s", cfg.DatabaseType, config.DatabaseTypeMemory, config.DatabaseTypePostgreSQL)
+	}
+}
+
+// startBackgroundJobs starts a registry's mirroring, probing, webhook, blocklist and transfer jobs until ctx is cancelled
+func startBackgroundJobs(ctx context.Context, cfg *config.Config, db database.Database, registryService service.RegistryService) error {
+	// Keep a read-only copy of the upstream registry when running as a mirror
+	if cfg.MirrorUpstreamURL != "" {
+		if cfg.MirrorSyncInterval <= 0 {
+			return fmt.Errorf("invalid mirror sync interval: %s", cfg.MirrorSyncInterval)
+		}
+		syncer, err := mirror.NewSyncer(db, cfg.MirrorUpstreamURL, cfg.MirrorUpstreamPublicKey)
+		if err != nil {
+			return fmt.Errorf("failed to configure mirror: %w", err)
+		}
+
+		log.Printf("Mirroring %s every %s", cfg.MirrorUpstreamURL, cfg.MirrorSyncInterval)
+		go syncer.Run(ctx, cfg.MirrorSyncInterval)
+	}
+
+	// Record whether published remotes respond, so clients can skip servers that are down
+	if cfg.RemoteProbeEnabled {
+		if cfg.RemoteProbeInterval <= 0 || cfg.RemoteProbeTimeout <= 0 {
+			return fmt.Errorf("invalid remote probe interval %s or timeout %s", cfg.RemoteProbeInterval, cfg.RemoteProbeTimeout)
+		}
+
+		log.Printf("Probing remotes every %s", cfg.RemoteProbeInterval)
+		remoteProber := prober.NewProber(db, validators.NewPublicHTTPClient(cfg.RemoteProbeTimeout))
+		go remoteProber.Run(ctx, cfg.RemoteProbeInterval)
+	}
+
+	// Deliver change feed events to webhook endpoints
+	if len(cfg.WebhookURLs) > 0 {
+		if cfg.WebhookInterval <= 0 || cfg.WebhookTimeout <= 0 {
+			return fmt.Errorf("invalid webhook interval %s or timeout %s", cfg.WebhookInterval, cfg.WebhookTimeout)
+		}
+
+		log.Printf("Delivering change events to %d webhooks", len(cfg.WebhookURLs))
+		dispatcher := webhooks.NewDispatcher(db, cfg.WebhookURLs, cfg.WebhookSecret, cfg.WebhookTimeout)
+		go dispatcher.Run(ctx, cfg.WebhookInterval)
+	}
+
+	// Enforce configured and admin-managed blocked names
+	go refreshBlocklist(ctx, registryService)
+
+	// Complete accepted server transfers once their grace period has passed; mirrors receive renames from upstream
+	if cfg.MirrorUpstreamURL == "" {
+		go processTransfers(ctx, registryService)
+	}
+	return nil
+}
+
+// processTransfers periodically expires and completes server transfers until ctx is cancelled
+func processTransfers(ctx context.Context, registryService service.RegistryService) {
+	ticker := time.NewTicker(transferProcessInterval)
+	defer ticker.Stop()
+
+	for {
+		completed, err := registryService.ProcessTransfers(ctx)
+		if err != nil {
+			log.Printf("Failed to process server transfers: %v", err)
+		} else if completed > 0 {
+			log.Printf("Completed %d server transfers", completed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refreshBlocklist periodically reloads the server name blocklist until ctx is cancelled
+func refreshBlocklist(ctx context.Context, registryService service.RegistryService) {
+	ticker := time.NewTicker(blocklistRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := registryService.RefreshBlocklist(ctx); err != nil {
+			log.Printf("Failed to refresh blocklist: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchSettings reloads the registry's settings whenever the process receives SIGHUP or the settings file changes,
+// until ctx is cancelled. Invalid settings are logged and the current ones kept.
+func watchSettings(ctx context.Context, registryService service.RegistryService) {
+	changed := make(chan struct{}, 1)
+	if cfg := registryService.Config(); cfg.SettingsFile != "" && cfg.SettingsPollInterval > 0 {
+		go config.WatchFile(ctx, cfg.SettingsFile, cfg.SettingsPollInterval, func() {
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		})
+	}
+
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hangup:
+		case <-changed:
+		}
+
+		next, err := config.Reload(registryService.Config())
+		if err != nil {
+			log.Printf("Failed to reload settings, keeping the current ones: %v", err)
+			continue
+		}
+		registries.ConfigureRegistryLimits(next.ValidatorTimeout, registryLimits(next))
+		if err := registryService.ReloadConfig(ctx, next); err != nil {
+			log.Printf("Failed to apply reloaded settings: %v", err)
+			continue
+		}
+		log.Println("Reloaded settings")
+	}
+}
+
+// registryLimits collects the per-registry validation limits from the configuration
+func registryLimits(cfg *config.Config) map[string]registries.RegistryLimits {
+	limits := make(map[string]registries.RegistryLimits)
+	for registryType, timeout := range cfg.ValidatorRegistryTimeouts {
+		l := limits[registryType]
+		l.Timeout = timeout
+		limits[registryType] = l
+	}
+	for registryType, concurrency := range cfg.ValidatorRegistryConcurrency {
+		l := limits[registryType]
+		l.MaxConcurrent = concurrency
+		limits[registryType] = l
+	}
+	for _, registryType := range cfg.ValidatorDisabledRegistries {
+		l := limits[registryType]
+		l.Disabled = true
+		limits[registryType] = l
+	}
+	return limits
+}
+
+// configureRegistryValidators applies credentials, network and resilience settings for upstream package registry requests
+func configureRegistryValidators(cfg *config.Config, tenantConfigs map[string]*config.Config) error {
+	ociCredentials, err := registries.LoadOCICredentials(cfg.OCIRegistryCredentials, cfg.OCIRegistryCredentialsFile)
+	if err != nil {
+		return fmt.Errorf("failed to load OCI registry credentials: %w", err)
+	}
+	registries.ConfigureOCICredentials(ociCredentials)
+	registries.ConfigureMavenMirrors(cfg.ValidatorMavenMirrorURLs)
+	registries.ConfigureNPMRegistries(cfg.ValidatorNPMRegistryURLs)
+	registries.ConfigureFileHashRecording(cfg.ValidatorFileHashMaxBytes)
+
+	if err := registries.ConfigureNPMProvenanceRoots(cfg.NPMProvenanceRootsFile); err != nil {
+		return err
+	}
+	requireProvenance := cfg.RequireNPMProvenance
+	for _, tenantCfg := range tenantConfigs {
+		requireProvenance = requireProvenance || tenantCfg.RequireNPMProvenance
+	}
+	if requireProvenance && cfg.NPMProvenanceRootsFile == "" {
+		return errors.New("REQUIRE_NPM_PROVENANCE needs NPM_PROVENANCE_ROOTS_FILE to verify attestations against")
+	}
+
+	if err := registries.ConfigureHTTPTransport(registries.HTTPTransportConfig{
+		ProxyURL:                cfg.ValidatorProxyURL,
+		CACertFile:              cfg.ValidatorCACertFile,
+		InsecureSkipVerifyHosts: cfg.ValidatorInsecureSkipVerifyHosts,
+		MaxIdleConnsPerHost:     cfg.ValidatorMaxIdleConnsPerHost,
+		IdleConnTimeout:         cfg.ValidatorIdleConnTimeout,
+		DNSCacheTTL:             cfg.ValidatorDNSCacheTTL,
+	}); err != nil {
+		return err
+	}
+
+	registries.ConfigureHTTPResilience(registries.HTTPResilienceConfig{
+		MaxRetries:       cfg.ValidatorMaxRetries,
+		RetryBaseDelay:   cfg.ValidatorRetryBaseDelay,
+		RetryMaxDelay:    cfg.ValidatorRetryMaxDelay,
+		BreakerThreshold: cfg.ValidatorBreakerThreshold,
+		BreakerCooldown:  cfg.ValidatorBreakerCooldown,
+	})
+	registries.ConfigureRegistryLimits(cfg.ValidatorTimeout, registryLimits(cfg))
+
+	return nil
+}
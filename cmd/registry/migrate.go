@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/secrets"
+)
+
+// migrateTimeout bounds a single `registry migrate` run, including waiting for another instance's migration lock
+const migrateTimeout = 10 * time.Minute
+
+// runMigrate implements `registry migrate [up|status]` against the configured PostgreSQL database
+func runMigrate(args []string) error {
+	action := "up"
+	if len(args) > 0 {
+		action = args[0]
+	}
+	if len(args) > 1 || (action != "up" && action != "status") {
+		return errors.New("usage: registry migrate [up|status]")
+	}
+
+	cfg := config.NewConfig()
+	if cfg.DatabaseType != config.DatabaseTypePostgreSQL {
+		return fmt.Errorf("migrations only apply to the %s database type, not %s", config.DatabaseTypePostgreSQL, cfg.DatabaseType)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), migrateTimeout)
+	defer cancel()
+
+	if err := secrets.FromConfig(cfg).ResolveConfig(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to resolve secrets: %w", err)
+	}
+
+	conn, err := pgx.Connect(ctx, cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to PostgreSQL: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	migrator := database.NewMigrator(conn)
+	if action == "up" {
+		return migrator.Migrate(ctx)
+	}
+
+	statuses, err := migrator.Status(ctx)
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			log.Printf("%03d %-40s pending", status.Version, status.Name)
+		} else {
+			log.Printf("%03d %-40s applied %s", status.Version, status.Name, status.AppliedAt.UTC().Format(time.RFC3339))
+		}
+	}
+	return err
+}
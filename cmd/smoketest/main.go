@@ -0,0 +1,125 @@
+// Command smoketest verifies a deployed registry end to end: it checks the registry is healthy, publishes a
+// new version of a test server through the API, and reads it back, exiting non-zero if any step fails.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// requestTimeout bounds each request the smoke test makes
+const requestTimeout = 30 * time.Second
+
+func main() {
+	log.SetFlags(0)
+
+	registryURL := flag.String("registry", "http://localhost:8080", "Base URL of the registry to test")
+	name := flag.String("name", "io.modelcontextprotocol.anonymous/smoke-test", "Name of the test server to publish, which the token must be allowed to publish")
+	flag.Parse()
+
+	// The token is read from the environment so it doesn't end up in process listings or CI logs
+	token := os.Getenv("SMOKE_TEST_TOKEN")
+	if token == "" {
+		log.Fatal("FAIL: SMOKE_TEST_TOKEN must be set to a registry JWT or API key that can publish ", *name)
+	}
+
+	client := &smokeTest{
+		baseURL: strings.TrimSuffix(*registryURL, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: requestTimeout},
+	}
+	if err := client.run(context.Background(), *name); err != nil {
+		log.Fatalf("FAIL: %v", err)
+	}
+	log.Print("PASS")
+}
+
+type smokeTest struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+func (s *smokeTest) run(ctx context.Context, name string) error {
+	if err := s.do(ctx, http.MethodGet, "/v0/health", nil, http.StatusOK, nil); err != nil {
+		return fmt.Errorf("health check: %w", err)
+	}
+	log.Print("✅ registry is healthy")
+
+	// Every run publishes a new version, since published versions can't be published again
+	server := apiv0.ServerJSON{
+		Name:        name,
+		Description: "Published by the registry's post-deploy smoke test",
+		Version:     fmt.Sprintf("0.0.%d", time.Now().Unix()),
+	}
+	var published apiv0.ServerJSON
+	if err := s.do(ctx, http.MethodPost, "/v0/publish", &server, http.StatusOK, &published); err != nil {
+		return fmt.Errorf("publish: %w", err)
+	}
+	if published.Meta == nil || published.Meta.Official == nil || published.Meta.Official.ID == "" {
+		return errors.New("publish: response has no server ID")
+	}
+	id := published.Meta.Official.ID
+	log.Printf("✅ published %s %s as %s", server.Name, server.Version, id)
+
+	var fetched apiv0.ServerJSON
+	if err := s.do(ctx, http.MethodGet, "/v0/servers/"+id, nil, http.StatusOK, &fetched); err != nil {
+		return fmt.Errorf("get server: %w", err)
+	}
+	if fetched.Name != server.Name || fetched.Version != server.Version {
+		return fmt.Errorf("get server: got %s %s, want %s %s", fetched.Name, fetched.Version, server.Name, server.Version)
+	}
+	log.Print("✅ published server reads back")
+	return nil
+}
+
+// do sends a request to the registry, checking for the wanted status and decoding the response into out if set
+func (s *smokeTest) do(ctx context.Context, method, path string, body any, wantStatus int, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != wantStatus {
+		return fmt.Errorf("%s %s returned %d: %s", method, path, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
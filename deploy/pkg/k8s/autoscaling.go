@@ -0,0 +1,70 @@
+package k8s
+
+import (
+	autoscalingv2 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/autoscaling/v2"
+	metav1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/meta/v1"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/modelcontextprotocol/registry/deploy/infra/pkg/providers"
+)
+
+// podMetric targets an average value per pod of a custom metric served by the Prometheus adapter
+func podMetric(name, averageValue string) *autoscalingv2.MetricSpecArgs {
+	return &autoscalingv2.MetricSpecArgs{
+		Type: pulumi.String("Pods"),
+		Pods: &autoscalingv2.PodsMetricSourceArgs{
+			Metric: &autoscalingv2.MetricIdentifierArgs{
+				Name: pulumi.String(name),
+			},
+			Target: &autoscalingv2.MetricTargetArgs{
+				Type:         pulumi.String("AverageValue"),
+				AverageValue: pulumi.String(averageValue),
+			},
+		},
+	}
+}
+
+// DeployRegistryAutoscaler scales the registry on request rate and p95 latency, as well as CPU. Scaling
+// on request metrics picks up load that waits on the database or package registries rather than using CPU.
+func DeployRegistryAutoscaler(ctx *pulumi.Context, cluster *providers.ProviderInfo, environment string) error {
+	_, err := autoscalingv2.NewHorizontalPodAutoscaler(ctx, "mcp-registry", &autoscalingv2.HorizontalPodAutoscalerArgs{
+		Metadata: &metav1.ObjectMetaArgs{
+			Name:      pulumi.String("mcp-registry"),
+			Namespace: pulumi.String("default"),
+			Labels: pulumi.StringMap{
+				"app":         pulumi.String("mcp-registry"),
+				"environment": pulumi.String(environment),
+			},
+		},
+		Spec: &autoscalingv2.HorizontalPodAutoscalerSpecArgs{
+			ScaleTargetRef: &autoscalingv2.CrossVersionObjectReferenceArgs{
+				ApiVersion: pulumi.String("apps/v1"),
+				Kind:       pulumi.String("Deployment"),
+				Name:       pulumi.String("mcp-registry"),
+			},
+			MinReplicas: pulumi.Int(2),
+			MaxReplicas: pulumi.Int(6),
+			Metrics: autoscalingv2.MetricSpecArray{
+				&autoscalingv2.MetricSpecArgs{
+					Type: pulumi.String("Resource"),
+					Resource: &autoscalingv2.ResourceMetricSourceArgs{
+						Name: pulumi.String("cpu"),
+						Target: &autoscalingv2.MetricTargetArgs{
+							Type:               pulumi.String("Utilization"),
+							AverageUtilization: pulumi.Int(70),
+						},
+					},
+				},
+				podMetric("mcp_registry_http_requests_per_second", "50"),
+				podMetric("mcp_registry_http_request_duration_p95", "500m"),
+			},
+			Behavior: &autoscalingv2.HorizontalPodAutoscalerBehaviorArgs{
+				// Avoid flapping when traffic dips briefly
+				ScaleDown: &autoscalingv2.HPAScalingRulesArgs{
+					StabilizationWindowSeconds: pulumi.Int(300),
+				},
+			},
+		},
+	}, pulumi.Provider(cluster.Provider))
+	return err
+}
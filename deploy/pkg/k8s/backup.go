@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apiextensions"
+	batchv1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/batch/v1"
 	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
 	"github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/helm/v3"
 	metav1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/meta/v1"
@@ -134,3 +135,99 @@ func DeployK8up(ctx *pulumi.Context, cluster *providers.ProviderInfo, environmen
 
 	return nil
 }
+
+// DeployCatalogBackup schedules `registry backup` to dump the catalog to the backup bucket every day. Unlike
+// the k8up snapshots of the database's files, dumps can be restored into any registry database with
+// `registry restore`. The GCS bucket's lifecycle rule deletes dumps after 60 days.
+func DeployCatalogBackup(ctx *pulumi.Context, cluster *providers.ProviderInfo, environment string, storage *providers.BackupStorageInfo, pgCluster *apiextensions.CustomResource) error {
+	if storage == nil {
+		ctx.Log.Info("No backup storage configured, skipping catalog backups", nil)
+		return nil
+	}
+
+	storageURL := fmt.Sprintf("s3://%s/catalog?endpoint=%s", storage.BucketName, storage.Endpoint)
+	if storage.Endpoint == "https://storage.googleapis.com" {
+		storageURL = fmt.Sprintf("gs://%s/catalog", storage.BucketName)
+	}
+
+	credential := func(key string) *corev1.EnvVarSourceArgs {
+		return &corev1.EnvVarSourceArgs{
+			SecretKeyRef: &corev1.SecretKeySelectorArgs{
+				Name: storage.Credentials.Metadata.Name(),
+				Key:  pulumi.String(key),
+			},
+		}
+	}
+
+	_, err := batchv1.NewCronJob(ctx, "registry-catalog-backup", &batchv1.CronJobArgs{
+		Metadata: &metav1.ObjectMetaArgs{
+			Name:      pulumi.String("registry-catalog-backup"),
+			Namespace: pulumi.String("default"),
+			Labels: pulumi.StringMap{
+				"app":         pulumi.String("registry-catalog-backup"),
+				"environment": pulumi.String(environment),
+			},
+		},
+		Spec: &batchv1.CronJobSpecArgs{
+			Schedule:                   pulumi.String("16 4 * * *"), // Daily at 4:16 AM, before the k8up backup
+			ConcurrencyPolicy:          pulumi.String("Forbid"),
+			SuccessfulJobsHistoryLimit: pulumi.Int(3),
+			FailedJobsHistoryLimit:     pulumi.Int(3),
+			JobTemplate: &batchv1.JobTemplateSpecArgs{
+				Spec: &batchv1.JobSpecArgs{
+					BackoffLimit: pulumi.Int(2),
+					Template: &corev1.PodTemplateSpecArgs{
+						Metadata: &metav1.ObjectMetaArgs{
+							Labels: pulumi.StringMap{
+								"app": pulumi.String("registry-catalog-backup"),
+							},
+						},
+						Spec: &corev1.PodSpecArgs{
+							RestartPolicy: pulumi.String("OnFailure"),
+							Containers: corev1.ContainerArray{
+								&corev1.ContainerArgs{
+									Name:  pulumi.String("backup"),
+									Image: pulumi.String("ghcr.io/modelcontextprotocol/registry:main"),
+									Args:  pulumi.StringArray{pulumi.String("backup")},
+									Env: corev1.EnvVarArray{
+										&corev1.EnvVarArgs{
+											Name: pulumi.String("MCP_REGISTRY_DATABASE_URL"),
+											ValueFrom: &corev1.EnvVarSourceArgs{
+												SecretKeyRef: &corev1.SecretKeySelectorArgs{
+													Name: pgCluster.Metadata.Name().ApplyT(func(name *string) string {
+														if name == nil {
+															return "registry-pg-app"
+														}
+														return *name + "-app"
+													}).(pulumi.StringOutput),
+													Key: pulumi.String("uri"),
+												},
+											},
+										},
+										&corev1.EnvVarArgs{
+											Name:  pulumi.String("MCP_REGISTRY_BACKUP_STORAGE_URL"),
+											Value: pulumi.String(storageURL),
+										},
+										&corev1.EnvVarArgs{
+											Name:      pulumi.String("MCP_REGISTRY_BACKUP_STORAGE_ACCESS_KEY_ID"),
+											ValueFrom: credential("AWS_ACCESS_KEY_ID"),
+										},
+										&corev1.EnvVarArgs{
+											Name:      pulumi.String("MCP_REGISTRY_BACKUP_STORAGE_SECRET_ACCESS_KEY"),
+											ValueFrom: credential("AWS_SECRET_ACCESS_KEY"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, pulumi.Provider(cluster.Provider), pulumi.DependsOn([]pulumi.Resource{storage.Credentials}))
+	if err != nil {
+		return fmt.Errorf("failed to create catalog backup job: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package k8s
+
+import (
+	"strconv"
+
+	v1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apps/v1"
+	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
+	"github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/helm/v3"
+	metav1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/meta/v1"
+	networkingv1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/networking/v1"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/config"
+
+	"github.com/modelcontextprotocol/registry/deploy/infra/pkg/providers"
+)
+
+// deployRegistryCanary runs a single replica of a candidate registry release alongside the stable one, and
+// has ingress-nginx send it canaryWeight percent (default 10) of the traffic for hosts. The canary only
+// receives traffic while its readiness probe passes; rolling back means unsetting canaryImage.
+func deployRegistryCanary(ctx *pulumi.Context, cluster *providers.ProviderInfo, environment string, hosts []string, podSpec *corev1.PodSpecArgs, ingressNginx *helm.Chart) error {
+	conf := config.New(ctx, "mcp-registry")
+	weight := conf.GetInt("canaryWeight")
+	if weight == 0 {
+		weight = 10
+	}
+
+	labels := pulumi.StringMap{
+		"app":         pulumi.String("mcp-registry-canary"),
+		"environment": pulumi.String(environment),
+	}
+
+	_, err := v1.NewDeployment(ctx, "mcp-registry-canary", &v1.DeploymentArgs{
+		Metadata: &metav1.ObjectMetaArgs{
+			Name:      pulumi.String("mcp-registry-canary"),
+			Namespace: pulumi.String("default"),
+			Labels:    labels,
+		},
+		Spec: &v1.DeploymentSpecArgs{
+			Replicas: pulumi.Int(1),
+			Selector: &metav1.LabelSelectorArgs{
+				MatchLabels: pulumi.StringMap{
+					"app": pulumi.String("mcp-registry-canary"),
+				},
+			},
+			Template: &corev1.PodTemplateSpecArgs{
+				Metadata: &metav1.ObjectMetaArgs{
+					Labels: pulumi.StringMap{
+						"app": pulumi.String("mcp-registry-canary"),
+					},
+				},
+				Spec: podSpec,
+			},
+		},
+	}, pulumi.Provider(cluster.Provider))
+	if err != nil {
+		return err
+	}
+
+	service, err := corev1.NewService(ctx, "mcp-registry-canary", &corev1.ServiceArgs{
+		Metadata: &metav1.ObjectMetaArgs{
+			Name:      pulumi.String("mcp-registry-canary"),
+			Namespace: pulumi.String("default"),
+			Labels:    labels,
+		},
+		Spec: &corev1.ServiceSpecArgs{
+			Selector: pulumi.StringMap{
+				"app": pulumi.String("mcp-registry-canary"),
+			},
+			Ports: corev1.ServicePortArray{
+				&corev1.ServicePortArgs{
+					Port:       pulumi.Int(80),
+					TargetPort: pulumi.Int(8080),
+					Name:       pulumi.String("http"),
+				},
+			},
+			Type: pulumi.String("ClusterIP"),
+		},
+	}, pulumi.Provider(cluster.Provider))
+	if err != nil {
+		return err
+	}
+
+	// The canary Ingress shares the stable Ingress's hosts and TLS certificate
+	rules := make(networkingv1.IngressRuleArray, 0, len(hosts))
+	for _, host := range hosts {
+		rules = append(rules, &networkingv1.IngressRuleArgs{
+			Host: pulumi.String(host),
+			Http: &networkingv1.HTTPIngressRuleValueArgs{
+				Paths: networkingv1.HTTPIngressPathArray{
+					&networkingv1.HTTPIngressPathArgs{
+						Path:     pulumi.String("/"),
+						PathType: pulumi.String("Prefix"),
+						Backend: &networkingv1.IngressBackendArgs{
+							Service: &networkingv1.IngressServiceBackendArgs{
+								Name: service.Metadata.Name().Elem(),
+								Port: &networkingv1.ServiceBackendPortArgs{
+									Number: pulumi.Int(80),
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	_, err = networkingv1.NewIngress(ctx, "mcp-registry-canary", &networkingv1.IngressArgs{
+		Metadata: &metav1.ObjectMetaArgs{
+			Name:      pulumi.String("mcp-registry-canary"),
+			Namespace: pulumi.String("default"),
+			Labels:    labels,
+			Annotations: pulumi.StringMap{
+				"kubernetes.io/ingress.class":               pulumi.String("nginx"),
+				"nginx.ingress.kubernetes.io/canary":        pulumi.String("true"),
+				"nginx.ingress.kubernetes.io/canary-weight": pulumi.String(strconv.Itoa(weight)),
+			},
+		},
+		Spec: &networkingv1.IngressSpecArgs{
+			Rules: rules,
+		},
+	}, pulumi.Provider(cluster.Provider), pulumi.DependsOnInputs(ingressNginx.Ready))
+	return err
+}
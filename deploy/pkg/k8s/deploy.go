@@ -22,7 +22,7 @@ func DeployAll(ctx *pulumi.Context, cluster *providers.ProviderInfo, backupStora
 	}
 
 	// Deploy PostgreSQL databases
-	pgCluster, err := DeployPostgresDatabases(ctx, cluster, environment)
+	pgCluster, err := DeployPostgresDatabases(ctx, cluster, environment, backupStorage)
 	if err != nil {
 		return nil, err
 	}
@@ -33,8 +33,38 @@ func DeployAll(ctx *pulumi.Context, cluster *providers.ProviderInfo, backupStora
 		return nil, err
 	}
 
+	// Schedule logical dumps of the catalog
+	err = DeployCatalogBackup(ctx, cluster, environment, backupStorage, pgCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deploy Redis cache
+	redisService, err := DeployRedis(ctx, cluster, environment)
+	if err != nil {
+		return nil, err
+	}
+
 	// Deploy MCP Registry
-	service, err = DeployMCPRegistry(ctx, cluster, environment, ingressNginx, pgCluster)
+	service, err = DeployMCPRegistry(ctx, cluster, environment, ingressNginx, pgCluster, redisService)
+	if err != nil {
+		return nil, err
+	}
+
+	// Scale the registry with its load
+	err = DeployRegistryAutoscaler(ctx, cluster, environment)
+	if err != nil {
+		return nil, err
+	}
+
+	// Restrict traffic to the registry to the ingress controller and monitoring
+	err = DeployRegistryNetworkPolicy(ctx, cluster, environment)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register the registry with the Prometheus Operator, if the cluster has one
+	err = DeployRegistryServiceMonitor(ctx, cluster, environment, service)
 	if err != nil {
 		return nil, err
 	}
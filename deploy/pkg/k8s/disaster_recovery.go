@@ -0,0 +1,112 @@
+package k8s
+
+import (
+	"fmt"
+
+	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
+	metav1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/meta/v1"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/config"
+
+	"github.com/modelcontextprotocol/registry/deploy/infra/pkg/providers"
+)
+
+// Disaster recovery roles of a stack, set with the drRole config option
+const (
+	drRolePrimary = "primary"
+	drRoleStandby = "standby"
+)
+
+// barmanObjectStore describes a WAL archive and base backups kept under the postgres/ prefix of a bucket
+func barmanObjectStore(bucket, endpoint string, credentials pulumi.StringOutput) map[string]any {
+	return map[string]any{
+		"destinationPath": fmt.Sprintf("s3://%s/postgres", bucket),
+		"endpointURL":     endpoint,
+		"s3Credentials": map[string]any{
+			"accessKeyId": map[string]any{
+				"name": credentials,
+				"key":  "AWS_ACCESS_KEY_ID",
+			},
+			"secretAccessKey": map[string]any{
+				"name": credentials,
+				"key":  "AWS_SECRET_ACCESS_KEY",
+			},
+		},
+		"wal": map[string]any{
+			"compression": "gzip",
+		},
+	}
+}
+
+// postgresDisasterRecovery returns the CloudNativePG cluster spec fields for the stack's disaster recovery role.
+// A primary archives its WAL to the backup bucket, at least every drArchiveTimeout (the RPO, default 5min).
+// A standby, in another region, bootstraps from and continuously replays the primary's archive as a read-only
+// replica cluster, until it is promoted by setting its drRole to primary. Without a role, nothing is archived.
+func postgresDisasterRecovery(ctx *pulumi.Context, cluster *providers.ProviderInfo, environment string, storage *providers.BackupStorageInfo) (map[string]any, error) {
+	conf := config.New(ctx, "mcp-registry")
+	role := conf.Get("drRole")
+	if role == "" {
+		return map[string]any{}, nil
+	}
+	if role != drRolePrimary && role != drRoleStandby {
+		return nil, fmt.Errorf("drRole must be %s or %s, got %q", drRolePrimary, drRoleStandby, role)
+	}
+	if storage == nil {
+		return nil, fmt.Errorf("drRole requires backup storage")
+	}
+
+	archiveTimeout := conf.Get("drArchiveTimeout")
+	if archiveTimeout == "" {
+		archiveTimeout = "5min"
+	}
+	spec := map[string]any{
+		"backup": map[string]any{
+			"barmanObjectStore": barmanObjectStore(storage.BucketName, storage.Endpoint, storage.Credentials.Metadata.Name().Elem()),
+			"retentionPolicy":   "14d",
+		},
+		"postgresql": map[string]any{
+			"parameters": map[string]any{
+				"archive_timeout": archiveTimeout,
+			},
+		},
+	}
+	if role == drRolePrimary {
+		return spec, nil
+	}
+
+	// The standby reads the primary region's bucket with an HMAC key of its own
+	primaryCredentials, err := corev1.NewSecret(ctx, "dr-primary-credentials", &corev1.SecretArgs{
+		Metadata: &metav1.ObjectMetaArgs{
+			Name:      pulumi.String("dr-primary-credentials"),
+			Namespace: pulumi.String("default"),
+			Labels: pulumi.StringMap{
+				"environment": pulumi.String(environment),
+			},
+		},
+		Type: pulumi.String("Opaque"),
+		StringData: pulumi.StringMap{
+			"AWS_ACCESS_KEY_ID":     conf.RequireSecret("drPrimaryAccessKeyId"),
+			"AWS_SECRET_ACCESS_KEY": conf.RequireSecret("drPrimarySecretAccessKey"),
+		},
+	}, pulumi.Provider(cluster.Provider))
+	if err != nil {
+		return nil, err
+	}
+
+	spec["externalClusters"] = []map[string]any{
+		{
+			"name":              "primary",
+			"barmanObjectStore": barmanObjectStore(conf.Require("drPrimaryBucket"), storage.Endpoint, primaryCredentials.Metadata.Name().Elem()),
+		},
+	}
+	spec["bootstrap"] = map[string]any{
+		"recovery": map[string]any{
+			"source": "primary",
+		},
+	}
+	spec["replica"] = map[string]any{
+		"enabled": true,
+		"source":  "primary",
+	}
+	return spec, nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/helm/v3"
 	metav1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/meta/v1"
 	networkingv1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/networking/v1"
+	schedulingv1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/scheduling/v1"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/config"
 	"gopkg.in/yaml.v2"
@@ -24,6 +25,25 @@ func DeployMonitoringStack(ctx *pulumi.Context, cluster *providers.ProviderInfo,
 		return err
 	}
 
+	// Schedule metrics collection ahead of ordinary workloads, so it isn't preempted when the cluster is full
+	priorityClass, err := schedulingv1.NewPriorityClass(ctx, "observability", &schedulingv1.PriorityClassArgs{
+		Metadata: &metav1.ObjectMetaArgs{
+			Name: pulumi.String("observability"),
+		},
+		Value:       pulumi.Int(100000),
+		Description: pulumi.String("Metrics collection and storage, which should outlive the workloads it observes"),
+	}, pulumi.Provider(cluster.Provider))
+	if err != nil {
+		return err
+	}
+
+	// VictoriaMetrics and VMAgent run a single replica, so their disruption budgets make node drains and
+	// autoscaler scale-downs wait for them to be moved deliberately rather than evicting them
+	podDisruptionBudget := pulumi.Map{
+		"enabled":      pulumi.Bool(true),
+		"minAvailable": pulumi.Int(1),
+	}
+
 	// Deploy VictoriaMetrics
 	_, err = helm.NewChart(ctx, "victoria-metrics", helm.ChartArgs{
 		Chart:     pulumi.String("victoria-metrics-single"),
@@ -34,7 +54,9 @@ func DeployMonitoringStack(ctx *pulumi.Context, cluster *providers.ProviderInfo,
 		},
 		Values: pulumi.Map{
 			"server": pulumi.Map{
-				"retentionPeriod": pulumi.String("14d"),
+				"retentionPeriod":     pulumi.String("14d"),
+				"priorityClassName":   priorityClass.Metadata.Name(),
+				"podDisruptionBudget": podDisruptionBudget,
 				"resources": pulumi.Map{
 					"requests": pulumi.Map{
 						"memory": pulumi.String("128Mi"),
@@ -60,6 +82,8 @@ func DeployMonitoringStack(ctx *pulumi.Context, cluster *providers.ProviderInfo,
 			Repo: pulumi.String("https://victoriametrics.github.io/helm-charts/"),
 		},
 		Values: pulumi.Map{
+			"priorityClassName":   priorityClass.Metadata.Name(),
+			"podDisruptionBudget": podDisruptionBudget,
 			"remoteWrite": pulumi.Array{
 				pulumi.Map{
 					"url": pulumi.String("http://victoria-metrics-victoria-metrics-single-server:8428/api/v1/write"),
@@ -86,6 +110,15 @@ func DeployMonitoringStack(ctx *pulumi.Context, cluster *providers.ProviderInfo,
 								"regex":         pulumi.String("mcp-registry.*"),
 								"action":        pulumi.String("keep"),
 							},
+							// Label series with their pod, which the Prometheus adapter needs to serve them as pod metrics
+							pulumi.Map{
+								"source_labels": pulumi.Array{pulumi.String("__meta_kubernetes_namespace")},
+								"target_label":  pulumi.String("namespace"),
+							},
+							pulumi.Map{
+								"source_labels": pulumi.Array{pulumi.String("__meta_kubernetes_pod_name")},
+								"target_label":  pulumi.String("pod"),
+							},
 						},
 					},
 				},
@@ -105,10 +138,75 @@ func DeployMonitoringStack(ctx *pulumi.Context, cluster *providers.ProviderInfo,
 		return err
 	}
 
+	// Serve the registry's request metrics to the HorizontalPodAutoscaler
+	err = deployPrometheusAdapter(ctx, cluster, ns)
+	if err != nil {
+		return err
+	}
+
 	// Deploy Grafana
 	return deployGrafana(ctx, cluster, ns, environment, ingressNginx)
 }
 
+// deployPrometheusAdapter serves per-pod request rate and p95 latency from VictoriaMetrics through the custom
+// metrics API, as mcp_registry_http_requests_per_second and mcp_registry_http_request_duration_p95 (in seconds)
+func deployPrometheusAdapter(ctx *pulumi.Context, cluster *providers.ProviderInfo, ns *corev1.Namespace) error {
+	podResources := pulumi.Map{
+		"overrides": pulumi.Map{
+			"namespace": pulumi.Map{"resource": pulumi.String("namespace")},
+			"pod":       pulumi.Map{"resource": pulumi.String("pod")},
+		},
+	}
+
+	_, err := helm.NewChart(ctx, "prometheus-adapter", helm.ChartArgs{
+		Chart:     pulumi.String("prometheus-adapter"),
+		Version:   pulumi.String("4.14.1"),
+		Namespace: ns.Metadata.Name().Elem(),
+		FetchArgs: helm.FetchArgs{
+			Repo: pulumi.String("https://prometheus-community.github.io/helm-charts"),
+		},
+		Values: pulumi.Map{
+			"prometheus": pulumi.Map{
+				"url":  pulumi.String("http://victoria-metrics-victoria-metrics-single-server"),
+				"port": pulumi.Int(8428),
+			},
+			"rules": pulumi.Map{
+				"default": pulumi.Bool(false),
+				"custom": pulumi.Array{
+					pulumi.Map{
+						"seriesQuery": pulumi.String(`mcp_registry_http_requests_total{namespace!="",pod!=""}`),
+						"resources":   podResources,
+						"name": pulumi.Map{
+							"matches": pulumi.String("^(.*)_total$"),
+							"as":      pulumi.String("${1}_per_second"),
+						},
+						"metricsQuery": pulumi.String(`sum(rate(<<.Series>>{<<.LabelMatchers>>}[2m])) by (<<.GroupBy>>)`),
+					},
+					pulumi.Map{
+						"seriesQuery": pulumi.String(`mcp_registry_http_request_duration_bucket{namespace!="",pod!=""}`),
+						"resources":   podResources,
+						"name": pulumi.Map{
+							"matches": pulumi.String("^(.*)_bucket$"),
+							"as":      pulumi.String("${1}_p95"),
+						},
+						"metricsQuery": pulumi.String(`histogram_quantile(0.95, sum(rate(<<.Series>>{<<.LabelMatchers>>}[5m])) by (le, <<.GroupBy>>))`),
+					},
+				},
+			},
+			"resources": pulumi.Map{
+				"requests": pulumi.Map{
+					"memory": pulumi.String("64Mi"),
+					"cpu":    pulumi.String("25m"),
+				},
+				"limits": pulumi.Map{
+					"memory": pulumi.String("128Mi"),
+				},
+			},
+		},
+	}, pulumi.Provider(cluster.Provider))
+	return err
+}
+
 func deployGrafana(ctx *pulumi.Context, cluster *providers.ProviderInfo, ns *corev1.Namespace, environment string, ingressNginx *helm.Chart) error {
 	conf := config.New(ctx, "mcp-registry")
 	grafanaSecret, err := corev1.NewSecret(ctx, "grafana-secrets", &corev1.SecretArgs{
@@ -326,3 +424,42 @@ func deployGrafana(ctx *pulumi.Context, cluster *providers.ProviderInfo, ns *cor
 	ctx.Export("grafanaUrl", pulumi.Sprintf("https://%s", grafanaHost))
 	return nil
 }
+
+// DeployRegistryServiceMonitor has a Prometheus Operator in the cluster scrape the registry's metrics, when the
+// prometheusOperator config option is set. Without it, metrics are scraped by VMAgent's pod discovery.
+func DeployRegistryServiceMonitor(ctx *pulumi.Context, cluster *providers.ProviderInfo, environment string, registryService *corev1.Service) error {
+	conf := config.New(ctx, "mcp-registry")
+	if !conf.GetBool("prometheusOperator") {
+		return nil
+	}
+
+	_, err := apiextensions.NewCustomResource(ctx, "mcp-registry-servicemonitor", &apiextensions.CustomResourceArgs{
+		ApiVersion: pulumi.String("monitoring.coreos.com/v1"),
+		Kind:       pulumi.String("ServiceMonitor"),
+		Metadata: &metav1.ObjectMetaArgs{
+			Name:      pulumi.String("mcp-registry"),
+			Namespace: registryService.Metadata.Namespace(),
+			Labels: pulumi.StringMap{
+				"app":         pulumi.String("mcp-registry"),
+				"environment": pulumi.String(environment),
+			},
+		},
+		OtherFields: map[string]any{
+			"spec": map[string]any{
+				"selector": map[string]any{
+					"matchLabels": map[string]any{
+						"app": "mcp-registry",
+					},
+				},
+				"endpoints": []map[string]any{
+					{
+						"port":     "http",
+						"path":     "/metrics",
+						"interval": "60s",
+					},
+				},
+			},
+		},
+	}, pulumi.Provider(cluster.Provider))
+	return err
+}
@@ -0,0 +1,65 @@
+package k8s
+
+import (
+	metav1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/meta/v1"
+	networkingv1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/networking/v1"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/modelcontextprotocol/registry/deploy/infra/pkg/providers"
+)
+
+// namespaceSelector selects a namespace by the name label Kubernetes sets on every namespace
+func namespaceSelector(name string) *metav1.LabelSelectorArgs {
+	return &metav1.LabelSelectorArgs{
+		MatchLabels: pulumi.StringMap{
+			"kubernetes.io/metadata.name": pulumi.String(name),
+		},
+	}
+}
+
+// DeployRegistryNetworkPolicy only admits traffic to the registry pods, stable and canary, from the
+// ingress controller, and from VMAgent in the monitoring namespace for scraping. Egress is left open,
+// as publishing validates packages against public registries whose addresses can't be listed up front.
+func DeployRegistryNetworkPolicy(ctx *pulumi.Context, cluster *providers.ProviderInfo, environment string) error {
+	_, err := networkingv1.NewNetworkPolicy(ctx, "mcp-registry", &networkingv1.NetworkPolicyArgs{
+		Metadata: &metav1.ObjectMetaArgs{
+			Name:      pulumi.String("mcp-registry"),
+			Namespace: pulumi.String("default"),
+			Labels: pulumi.StringMap{
+				"app":         pulumi.String("mcp-registry"),
+				"environment": pulumi.String(environment),
+			},
+		},
+		Spec: &networkingv1.NetworkPolicySpecArgs{
+			PodSelector: &metav1.LabelSelectorArgs{
+				MatchExpressions: metav1.LabelSelectorRequirementArray{
+					&metav1.LabelSelectorRequirementArgs{
+						Key:      pulumi.String("app"),
+						Operator: pulumi.String("In"),
+						Values:   pulumi.StringArray{pulumi.String("mcp-registry"), pulumi.String("mcp-registry-canary")},
+					},
+				},
+			},
+			PolicyTypes: pulumi.StringArray{pulumi.String("Ingress")},
+			Ingress: networkingv1.NetworkPolicyIngressRuleArray{
+				&networkingv1.NetworkPolicyIngressRuleArgs{
+					From: networkingv1.NetworkPolicyPeerArray{
+						&networkingv1.NetworkPolicyPeerArgs{
+							NamespaceSelector: namespaceSelector("ingress-nginx"),
+						},
+						&networkingv1.NetworkPolicyPeerArgs{
+							NamespaceSelector: namespaceSelector("monitoring"),
+						},
+					},
+					Ports: networkingv1.NetworkPolicyPortArray{
+						&networkingv1.NetworkPolicyPortArgs{
+							Port:     pulumi.Int(8080),
+							Protocol: pulumi.String("TCP"),
+						},
+					},
+				},
+			},
+		},
+	}, pulumi.Provider(cluster.Provider))
+	return err
+}
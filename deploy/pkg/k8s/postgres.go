@@ -11,7 +11,7 @@ import (
 )
 
 // DeployPostgresDatabases deploys the CloudNative PostgreSQL operator and PostgreSQL cluster
-func DeployPostgresDatabases(ctx *pulumi.Context, cluster *providers.ProviderInfo, environment string) (*apiextensions.CustomResource, error) {
+func DeployPostgresDatabases(ctx *pulumi.Context, cluster *providers.ProviderInfo, environment string, storage *providers.BackupStorageInfo) (*apiextensions.CustomResource, error) {
 	// Create cnpg-system namespace
 	cnpgNamespace, err := corev1.NewNamespace(ctx, "cnpg-system", &corev1.NamespaceArgs{
 		Metadata: &metav1.ObjectMetaArgs{
@@ -40,6 +40,16 @@ func DeployPostgresDatabases(ctx *pulumi.Context, cluster *providers.ProviderInf
 		return nil, err
 	}
 
+	// Archive to, or replicate from, another region when configured for disaster recovery
+	spec, err := postgresDisasterRecovery(ctx, cluster, environment, storage)
+	if err != nil {
+		return nil, err
+	}
+	spec["instances"] = 1
+	spec["storage"] = map[string]any{
+		"size": "50Gi",
+	}
+
 	// Create PostgreSQL cluster
 	pgCluster, err := apiextensions.NewCustomResource(ctx, "registry-pg", &apiextensions.CustomResourceArgs{
 		ApiVersion: pulumi.String("postgresql.cnpg.io/v1"),
@@ -53,17 +63,20 @@ func DeployPostgresDatabases(ctx *pulumi.Context, cluster *providers.ProviderInf
 			},
 		},
 		OtherFields: map[string]any{
-			"spec": map[string]any{
-				"instances": 1,
-				"storage": map[string]any{
-					"size": "50Gi",
-				},
-			},
+			"spec": spec,
 		},
 	}, pulumi.Provider(cluster.Provider), pulumi.DependsOnInputs(cloudNativePG.Ready), pulumi.RetainOnDelete(true))
 	if err != nil {
 		return nil, err
 	}
 
+	// CloudNativePG generates the application user's credentials, with a ready-made DSN under the uri key
+	ctx.Export("databaseSecret", pgCluster.Metadata.Name().ApplyT(func(name *string) string {
+		if name == nil {
+			return "registry-pg-app"
+		}
+		return *name + "-app"
+	}).(pulumi.StringOutput))
+
 	return pgCluster, nil
 }
@@ -0,0 +1,115 @@
+package k8s
+
+import (
+	v1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apps/v1"
+	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
+	metav1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/meta/v1"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/modelcontextprotocol/registry/deploy/infra/pkg/providers"
+)
+
+// DeployRedis deploys a single Redis instance used by the registry as a shared cache.
+// Nothing in Redis needs to survive a restart, so persistence is disabled and memory is capped with LRU eviction.
+func DeployRedis(ctx *pulumi.Context, cluster *providers.ProviderInfo, environment string) (*corev1.Service, error) {
+	_, err := v1.NewDeployment(ctx, "registry-redis", &v1.DeploymentArgs{
+		Metadata: &metav1.ObjectMetaArgs{
+			Name:      pulumi.String("registry-redis"),
+			Namespace: pulumi.String("default"),
+			Labels: pulumi.StringMap{
+				"app":         pulumi.String("registry-redis"),
+				"environment": pulumi.String(environment),
+			},
+		},
+		Spec: &v1.DeploymentSpecArgs{
+			Replicas: pulumi.Int(1),
+			Selector: &metav1.LabelSelectorArgs{
+				MatchLabels: pulumi.StringMap{
+					"app": pulumi.String("registry-redis"),
+				},
+			},
+			Template: &corev1.PodTemplateSpecArgs{
+				Metadata: &metav1.ObjectMetaArgs{
+					Labels: pulumi.StringMap{
+						"app": pulumi.String("registry-redis"),
+					},
+				},
+				Spec: &corev1.PodSpecArgs{
+					Containers: corev1.ContainerArray{
+						&corev1.ContainerArgs{
+							Name:  pulumi.String("redis"),
+							Image: pulumi.String("redis:7.4-alpine"),
+							Args: pulumi.StringArray{
+								pulumi.String("--save"),
+								pulumi.String(""),
+								pulumi.String("--appendonly"),
+								pulumi.String("no"),
+								pulumi.String("--maxmemory"),
+								pulumi.String("200mb"),
+								pulumi.String("--maxmemory-policy"),
+								pulumi.String("allkeys-lru"),
+							},
+							Ports: corev1.ContainerPortArray{
+								&corev1.ContainerPortArgs{
+									ContainerPort: pulumi.Int(6379),
+									Name:          pulumi.String("redis"),
+								},
+							},
+							ReadinessProbe: &corev1.ProbeArgs{
+								Exec: &corev1.ExecActionArgs{
+									Command: pulumi.StringArray{
+										pulumi.String("redis-cli"),
+										pulumi.String("ping"),
+									},
+								},
+								InitialDelaySeconds: pulumi.Int(5),
+								TimeoutSeconds:      pulumi.Int(3),
+							},
+							Resources: &corev1.ResourceRequirementsArgs{
+								Requests: pulumi.StringMap{
+									"memory": pulumi.String("128Mi"),
+									"cpu":    pulumi.String("50m"),
+								},
+								Limits: pulumi.StringMap{
+									"memory": pulumi.String("256Mi"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, pulumi.Provider(cluster.Provider))
+	if err != nil {
+		return nil, err
+	}
+
+	service, err := corev1.NewService(ctx, "registry-redis", &corev1.ServiceArgs{
+		Metadata: &metav1.ObjectMetaArgs{
+			Name:      pulumi.String("registry-redis"),
+			Namespace: pulumi.String("default"),
+			Labels: pulumi.StringMap{
+				"app":         pulumi.String("registry-redis"),
+				"environment": pulumi.String(environment),
+			},
+		},
+		Spec: &corev1.ServiceSpecArgs{
+			Selector: pulumi.StringMap{
+				"app": pulumi.String("registry-redis"),
+			},
+			Ports: corev1.ServicePortArray{
+				&corev1.ServicePortArgs{
+					Port:       pulumi.Int(6379),
+					TargetPort: pulumi.Int(6379),
+					Name:       pulumi.String("redis"),
+				},
+			},
+			Type: pulumi.String("ClusterIP"),
+		},
+	}, pulumi.Provider(cluster.Provider))
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
@@ -2,6 +2,7 @@ package k8s
 
 import (
 	"os/exec"
+	"strconv"
 	"strings"
 
 	"github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/apiextensions"
@@ -28,7 +29,7 @@ func getGitCommitHash() string {
 }
 
 // DeployMCPRegistry deploys the MCP Registry to the Kubernetes cluster
-func DeployMCPRegistry(ctx *pulumi.Context, cluster *providers.ProviderInfo, environment string, ingressNginx *helm.Chart, pgCluster *apiextensions.CustomResource) (*corev1.Service, error) {
+func DeployMCPRegistry(ctx *pulumi.Context, cluster *providers.ProviderInfo, environment string, ingressNginx *helm.Chart, pgCluster *apiextensions.CustomResource, redisService *corev1.Service) (*corev1.Service, error) {
 	conf := config.New(ctx, "mcp-registry")
 	githubClientId := conf.Require("githubClientId")
 
@@ -52,6 +53,139 @@ func DeployMCPRegistry(ctx *pulumi.Context, cluster *providers.ProviderInfo, env
 		return nil, err
 	}
 
+	// registryPodSpec runs the given registry image with its configuration
+	registryPodSpec := func(image string) *corev1.PodSpecArgs {
+		return &corev1.PodSpecArgs{
+			Containers: corev1.ContainerArray{
+				&corev1.ContainerArgs{
+					Name:            pulumi.String("mcp-registry"),
+					Image:           pulumi.String(image),
+					ImagePullPolicy: pulumi.String("Always"),
+					Ports: corev1.ContainerPortArray{
+						&corev1.ContainerPortArgs{
+							ContainerPort: pulumi.Int(8080),
+							Name:          pulumi.String("http"),
+						},
+					},
+					Env: corev1.EnvVarArray{
+						&corev1.EnvVarArgs{
+							Name: pulumi.String("MCP_REGISTRY_DATABASE_URL"),
+							ValueFrom: &corev1.EnvVarSourceArgs{
+								SecretKeyRef: &corev1.SecretKeySelectorArgs{
+									Name: pgCluster.Metadata.Name().ApplyT(func(name *string) string {
+										if name == nil {
+											return "registry-pg-app"
+										}
+										return *name + "-app"
+									}).(pulumi.StringOutput),
+									Key: pulumi.String("uri"),
+								},
+							},
+						},
+						&corev1.EnvVarArgs{
+							Name:  pulumi.String("MCP_REGISTRY_GITHUB_CLIENT_ID"),
+							Value: pulumi.String(githubClientId),
+						},
+						&corev1.EnvVarArgs{
+							Name: pulumi.String("MCP_REGISTRY_GITHUB_CLIENT_SECRET"),
+							ValueFrom: &corev1.EnvVarSourceArgs{
+								SecretKeyRef: &corev1.SecretKeySelectorArgs{
+									Name: secret.Metadata.Name(),
+									Key:  pulumi.String("GITHUB_CLIENT_SECRET"),
+								},
+							},
+						},
+						&corev1.EnvVarArgs{
+							Name: pulumi.String("MCP_REGISTRY_JWT_PRIVATE_KEY"),
+							ValueFrom: &corev1.EnvVarSourceArgs{
+								SecretKeyRef: &corev1.SecretKeySelectorArgs{
+									Name: secret.Metadata.Name(),
+									Key:  pulumi.String("JWT_PRIVATE_KEY"),
+								},
+							},
+						},
+						// Optional credentials for validating images in private OCI registries,
+						// read from a kubernetes.io/dockerconfigjson Secret if one has been created
+						&corev1.EnvVarArgs{
+							Name: pulumi.String("MCP_REGISTRY_OCI_REGISTRY_CREDENTIALS"),
+							ValueFrom: &corev1.EnvVarSourceArgs{
+								SecretKeyRef: &corev1.SecretKeySelectorArgs{
+									Name:     pulumi.String("mcp-registry-oci-credentials"),
+									Key:      pulumi.String(".dockerconfigjson"),
+									Optional: pulumi.Bool(true),
+								},
+							},
+						},
+						// Shared cache for package validation results and server reads
+						&corev1.EnvVarArgs{
+							Name: pulumi.String("MCP_REGISTRY_REDIS_URL"),
+							Value: redisService.Metadata.Name().ApplyT(func(name *string) string {
+								if name == nil {
+									return "redis://registry-redis:6379"
+								}
+								return "redis://" + *name + ":6379"
+							}).(pulumi.StringOutput),
+						},
+						&corev1.EnvVarArgs{
+							Name:  pulumi.String("MCP_REGISTRY_READ_CACHE_TTL"),
+							Value: pulumi.String("1m"),
+						},
+						// Google Cloud Identity OIDC for admin access
+						&corev1.EnvVarArgs{
+							Name:  pulumi.String("MCP_REGISTRY_OIDC_ENABLED"),
+							Value: pulumi.String("true"),
+						},
+						&corev1.EnvVarArgs{
+							Name:  pulumi.String("MCP_REGISTRY_OIDC_ISSUER"),
+							Value: pulumi.String("https://accounts.google.com"),
+						},
+						&corev1.EnvVarArgs{
+							Name:  pulumi.String("MCP_REGISTRY_OIDC_CLIENT_ID"),
+							Value: pulumi.String("32555940559.apps.googleusercontent.com"),
+						},
+						&corev1.EnvVarArgs{
+							Name:  pulumi.String("MCP_REGISTRY_OIDC_EXTRA_CLAIMS"),
+							Value: pulumi.String(`[{"hd":"modelcontextprotocol.io"}]`),
+						},
+						&corev1.EnvVarArgs{
+							Name:  pulumi.String("MCP_REGISTRY_OIDC_EDIT_PERMISSIONS"),
+							Value: pulumi.String("*"),
+						},
+						&corev1.EnvVarArgs{
+							Name:  pulumi.String("MCP_REGISTRY_OIDC_PUBLISH_PERMISSIONS"),
+							Value: pulumi.String("*"),
+						},
+					},
+					LivenessProbe: &corev1.ProbeArgs{
+						HttpGet: &corev1.HTTPGetActionArgs{
+							Path: pulumi.String("/v0/health"),
+							Port: pulumi.Int(8080),
+						},
+						InitialDelaySeconds: pulumi.Int(30),
+						TimeoutSeconds:      pulumi.Int(5),
+					},
+					ReadinessProbe: &corev1.ProbeArgs{
+						HttpGet: &corev1.HTTPGetActionArgs{
+							Path: pulumi.String("/v0/health"),
+							Port: pulumi.Int(8080),
+						},
+						InitialDelaySeconds: pulumi.Int(5),
+						TimeoutSeconds:      pulumi.Int(3),
+					},
+					Resources: &corev1.ResourceRequirementsArgs{
+						Requests: pulumi.StringMap{
+							"memory": pulumi.String("128Mi"),
+							"cpu":    pulumi.String("100m"),
+						},
+						Limits: pulumi.StringMap{
+							"memory": pulumi.String("256Mi"),
+						},
+					},
+				},
+			},
+		}
+	}
+
 	// Create Deployment
 	_, err = v1.NewDeployment(ctx, "mcp-registry", &v1.DeploymentArgs{
 		Metadata: &metav1.ObjectMetaArgs{
@@ -63,7 +197,7 @@ func DeployMCPRegistry(ctx *pulumi.Context, cluster *providers.ProviderInfo, env
 			},
 		},
 		Spec: &v1.DeploymentSpecArgs{
-			Replicas: pulumi.Int(2),
+			// Replicas are left to the HorizontalPodAutoscaler, see DeployRegistryAutoscaler
 			Selector: &metav1.LabelSelectorArgs{
 				MatchLabels: pulumi.StringMap{
 					"app": pulumi.String("mcp-registry"),
@@ -79,109 +213,7 @@ func DeployMCPRegistry(ctx *pulumi.Context, cluster *providers.ProviderInfo, env
 						"registry.modelcontextprotocol.io/deployCommit": pulumi.String(getGitCommitHash()),
 					},
 				},
-				Spec: &corev1.PodSpecArgs{
-					Containers: corev1.ContainerArray{
-						&corev1.ContainerArgs{
-							Name:            pulumi.String("mcp-registry"),
-							Image:           pulumi.String("ghcr.io/modelcontextprotocol/registry:main"),
-							ImagePullPolicy: pulumi.String("Always"),
-							Ports: corev1.ContainerPortArray{
-								&corev1.ContainerPortArgs{
-									ContainerPort: pulumi.Int(8080),
-									Name:          pulumi.String("http"),
-								},
-							},
-							Env: corev1.EnvVarArray{
-								&corev1.EnvVarArgs{
-									Name: pulumi.String("MCP_REGISTRY_DATABASE_URL"),
-									ValueFrom: &corev1.EnvVarSourceArgs{
-										SecretKeyRef: &corev1.SecretKeySelectorArgs{
-											Name: pgCluster.Metadata.Name().ApplyT(func(name *string) string {
-												if name == nil {
-													return "registry-pg-app"
-												}
-												return *name + "-app"
-											}).(pulumi.StringOutput),
-											Key: pulumi.String("uri"),
-										},
-									},
-								},
-								&corev1.EnvVarArgs{
-									Name:  pulumi.String("MCP_REGISTRY_GITHUB_CLIENT_ID"),
-									Value: pulumi.String(githubClientId),
-								},
-								&corev1.EnvVarArgs{
-									Name: pulumi.String("MCP_REGISTRY_GITHUB_CLIENT_SECRET"),
-									ValueFrom: &corev1.EnvVarSourceArgs{
-										SecretKeyRef: &corev1.SecretKeySelectorArgs{
-											Name: secret.Metadata.Name(),
-											Key:  pulumi.String("GITHUB_CLIENT_SECRET"),
-										},
-									},
-								},
-								&corev1.EnvVarArgs{
-									Name: pulumi.String("MCP_REGISTRY_JWT_PRIVATE_KEY"),
-									ValueFrom: &corev1.EnvVarSourceArgs{
-										SecretKeyRef: &corev1.SecretKeySelectorArgs{
-											Name: secret.Metadata.Name(),
-											Key:  pulumi.String("JWT_PRIVATE_KEY"),
-										},
-									},
-								},
-								// Google Cloud Identity OIDC for admin access
-								&corev1.EnvVarArgs{
-									Name:  pulumi.String("MCP_REGISTRY_OIDC_ENABLED"),
-									Value: pulumi.String("true"),
-								},
-								&corev1.EnvVarArgs{
-									Name:  pulumi.String("MCP_REGISTRY_OIDC_ISSUER"),
-									Value: pulumi.String("https://accounts.google.com"),
-								},
-								&corev1.EnvVarArgs{
-									Name:  pulumi.String("MCP_REGISTRY_OIDC_CLIENT_ID"),
-									Value: pulumi.String("32555940559.apps.googleusercontent.com"),
-								},
-								&corev1.EnvVarArgs{
-									Name:  pulumi.String("MCP_REGISTRY_OIDC_EXTRA_CLAIMS"),
-									Value: pulumi.String(`[{"hd":"modelcontextprotocol.io"}]`),
-								},
-								&corev1.EnvVarArgs{
-									Name:  pulumi.String("MCP_REGISTRY_OIDC_EDIT_PERMISSIONS"),
-									Value: pulumi.String("*"),
-								},
-								&corev1.EnvVarArgs{
-									Name:  pulumi.String("MCP_REGISTRY_OIDC_PUBLISH_PERMISSIONS"),
-									Value: pulumi.String("*"),
-								},
-							},
-							LivenessProbe: &corev1.ProbeArgs{
-								HttpGet: &corev1.HTTPGetActionArgs{
-									Path: pulumi.String("/v0/health"),
-									Port: pulumi.Int(8080),
-								},
-								InitialDelaySeconds: pulumi.Int(30),
-								TimeoutSeconds:      pulumi.Int(5),
-							},
-							ReadinessProbe: &corev1.ProbeArgs{
-								HttpGet: &corev1.HTTPGetActionArgs{
-									Path: pulumi.String("/v0/health"),
-									Port: pulumi.Int(8080),
-								},
-								InitialDelaySeconds: pulumi.Int(5),
-								TimeoutSeconds:      pulumi.Int(3),
-							},
-							Resources: &corev1.ResourceRequirementsArgs{
-								Requests: pulumi.StringMap{
-									"memory": pulumi.String("128Mi"),
-									"cpu":    pulumi.String("100m"),
-								},
-								Limits: pulumi.StringMap{
-									"memory": pulumi.String("256Mi"),
-								},
-							},
-						},
-					},
-				},
+				Spec: registryPodSpec("ghcr.io/modelcontextprotocol/registry:main"),
 			},
 		},
 	}, pulumi.Provider(cluster.Provider))
@@ -227,6 +259,23 @@ func DeployMCPRegistry(ctx *pulumi.Context, cluster *providers.ProviderInfo, env
 		hosts = append(hosts, "registry.modelcontextprotocol.io")
 	}
 
+	ingressAnnotations := pulumi.StringMap{
+		"cert-manager.io/cluster-issuer": pulumi.String("letsencrypt-prod"),
+		"kubernetes.io/ingress.class":    pulumi.String("nginx"),
+		// Lets external-dns, where it runs, create the DNS records for the hosts
+		"external-dns.alpha.kubernetes.io/hostname": pulumi.String(strings.Join(hosts, ",")),
+	}
+
+	// Limit each client's request rate at the ingress controller, unless rateLimitRps is set to 0
+	rateLimitRps := conf.GetInt("rateLimitRps")
+	if rateLimitRps == 0 && conf.Get("rateLimitRps") == "" {
+		rateLimitRps = 20
+	}
+	if rateLimitRps > 0 {
+		ingressAnnotations["nginx.ingress.kubernetes.io/limit-rps"] = pulumi.String(strconv.Itoa(rateLimitRps))
+		ingressAnnotations["nginx.ingress.kubernetes.io/limit-burst-multiplier"] = pulumi.String("5")
+	}
+
 	ingress, err := networkingv1.NewIngress(ctx, "mcp-registry", &networkingv1.IngressArgs{
 		Metadata: &metav1.ObjectMetaArgs{
 			Name:      pulumi.String("mcp-registry"),
@@ -235,10 +284,7 @@ func DeployMCPRegistry(ctx *pulumi.Context, cluster *providers.ProviderInfo, env
 				"app":         pulumi.String("mcp-registry"),
 				"environment": pulumi.String(environment),
 			},
-			Annotations: pulumi.StringMap{
-				"cert-manager.io/cluster-issuer": pulumi.String("letsencrypt-prod"),
-				"kubernetes.io/ingress.class":    pulumi.String("nginx"),
-			},
+			Annotations: ingressAnnotations,
 		},
 		Spec: &networkingv1.IngressSpecArgs{
 			Tls: networkingv1.IngressTLSArray{
@@ -278,6 +324,14 @@ func DeployMCPRegistry(ctx *pulumi.Context, cluster *providers.ProviderInfo, env
 		return nil, err
 	}
 
+	// Send a share of the traffic to a candidate release, if one is configured
+	if canaryImage := conf.Get("canaryImage"); canaryImage != "" {
+		err = deployRegistryCanary(ctx, cluster, environment, hosts, registryPodSpec(canaryImage), ingressNginx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	ctx.Export("ingressHosts", ingress.Spec.Rules().ApplyT(func(rules []networkingv1.IngressRule) []string {
 		hosts := make([]string, 0, len(rules))
 		for _, rule := range rules {
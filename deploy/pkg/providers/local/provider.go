@@ -11,6 +11,7 @@ import (
 	corev1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/core/v1"
 	metav1 "github.com/pulumi/pulumi-kubernetes/sdk/v4/go/kubernetes/meta/v1"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/config"
 
 	"github.com/modelcontextprotocol/registry/deploy/infra/pkg/providers"
 )
@@ -31,8 +32,13 @@ func getClusterName() string {
 
 // CreateCluster configures access to a local Kubernetes cluster via kubeconfig
 func (p *Provider) CreateCluster(ctx *pulumi.Context, environment string) (*providers.ProviderInfo, error) {
-	// Create Kubernetes provider for local cluster
-	k8sProvider, err := kubernetes.NewProvider(ctx, "k8s-provider", &kubernetes.ProviderArgs{})
+	// Create Kubernetes provider for local cluster, or one that writes each resource as YAML to the
+	// renderManifests directory instead of applying it, for use with kubectl or kustomize
+	providerArgs := &kubernetes.ProviderArgs{}
+	if dir := config.New(ctx, "mcp-registry").Get("renderManifests"); dir != "" {
+		providerArgs.RenderYamlToDirectory = pulumi.String(dir)
+	}
+	k8sProvider, err := kubernetes.NewProvider(ctx, "k8s-provider", providerArgs)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,112 @@
+package api_test
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/api"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+func TestCompressionMiddleware(t *testing.T) {
+	cfg := &config.Config{
+		CompressionContentTypes: []string{"application/json"},
+		CompressionMinSize:      1024,
+	}
+	largeJSON := `{"servers":[` + strings.Repeat(`{"name":"com.example/server"},`, 100) + `{}]}`
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/large", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("ETag", `"3"`)
+		_, _ = w.Write([]byte(largeJSON))
+	})
+	mux.HandleFunc("/small", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"servers":[]}`))
+	})
+	mux.HandleFunc("/binary", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(make([]byte, 4096))
+	})
+	mux.HandleFunc("/created", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(largeJSON))
+	})
+	handler := api.CompressionMiddleware(cfg)(mux)
+
+	get := func(path, acceptEncoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("brotli is preferred", func(t *testing.T) {
+		w := get("/large", "gzip, deflate, br")
+		assert.Equal(t, "br", w.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+		assert.Equal(t, `W/"3"`, w.Header().Get("ETag"))
+		body, err := io.ReadAll(brotli.NewReader(w.Body))
+		require.NoError(t, err)
+		assert.Equal(t, largeJSON, string(body))
+	})
+
+	t.Run("gzip", func(t *testing.T) {
+		w := get("/created", "gzip, br;q=0.5")
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+		reader, err := gzip.NewReader(w.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, largeJSON, string(body))
+	})
+
+	tests := []struct {
+		name           string
+		path           string
+		acceptEncoding string
+	}{
+		{name: "client doesn't accept compression", path: "/large"},
+		{name: "unsupported encoding", path: "/large", acceptEncoding: "deflate"},
+		{name: "encoding refused", path: "/large", acceptEncoding: "gzip;q=0"},
+		{name: "below the size threshold", path: "/small", acceptEncoding: "gzip"},
+		{name: "content type not allowed", path: "/binary", acceptEncoding: "gzip"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := get(tt.path, tt.acceptEncoding)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Empty(t, w.Header().Get("Content-Encoding"))
+			assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+		})
+	}
+
+	t.Run("streamed responses flushed before the threshold pass through", func(t *testing.T) {
+		streaming := api.CompressionMiddleware(cfg)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"event":1}`))
+			require.NoError(t, http.NewResponseController(w).Flush())
+		}))
+		req := httptest.NewRequest(http.MethodGet, "/events", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		w := httptest.NewRecorder()
+		streaming.ServeHTTP(w, req)
+		assert.True(t, w.Flushed)
+		assert.Empty(t, w.Header().Get("Content-Encoding"))
+		assert.Equal(t, `{"event":1}`, w.Body.String())
+	})
+}
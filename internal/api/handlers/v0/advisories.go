@@ -0,0 +1,176 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// CreateAdvisoryBody represents the request body for filing a security advisory
+type CreateAdvisoryBody struct {
+	Severity         string   `json:"severity" enum:"low,medium,high,critical"`
+	Summary          string   `json:"summary" minLength:"1" maxLength:"200" example:"Path traversal in file read tool"`
+	Description      string   `json:"description,omitempty" maxLength:"10000"`
+	AffectedVersions string   `json:"affected_versions,omitempty" doc:"Affected versions as an npm-style range; omit if every version is affected" maxLength:"200" example:"<1.4.2"`
+	Remediation      string   `json:"remediation,omitempty" maxLength:"2000" example:"Upgrade to 1.4.2 or later"`
+	Aliases          []string `json:"aliases,omitempty" doc:"Identifiers of the same issue elsewhere, such as CVE IDs" maxItems:"10" example:"[\"CVE-2025-12345\"]"`
+}
+
+// CreateAdvisoryInput represents the input for filing a security advisory
+type CreateAdvisoryInput struct {
+	Authorization string             `header:"Authorization" doc:"Registry JWT token (obtained from /v0/auth/token/github) or API key" required:"true"`
+	Name          string             `path:"name" doc:"Server name, URL-encoded (e.g. io.github.example%2Fserver)" example:"io.github.example%2Fserver"`
+	Body          CreateAdvisoryBody `body:""`
+}
+
+// ListAdvisoriesInput represents the input for listing a server's security advisories
+type ListAdvisoriesInput struct {
+	Name string `path:"name" doc:"Server name, URL-encoded (e.g. io.github.example%2Fserver)" example:"io.github.example%2Fserver"`
+}
+
+// DeleteAdvisoryInput represents the input for withdrawing a security advisory
+type DeleteAdvisoryInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token (obtained from /v0/auth/token/github) or API key" required:"true"`
+	Name          string `path:"name" doc:"Server name, URL-encoded (e.g. io.github.example%2Fserver)" example:"io.github.example%2Fserver"`
+	ID            string `path:"id" doc:"Advisory ID" format:"uuid"`
+}
+
+// AdvisoryListResponse represents the security advisories filed against a server
+type AdvisoryListResponse struct {
+	Advisories []apiv0.Advisory `json:"advisories"`
+}
+
+// RegisterAdvisoryEndpoints registers the security advisory endpoints
+func RegisterAdvisoryEndpoints(api huma.API, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-server-advisories",
+		Method:      http.MethodGet,
+		Path:        "/v0/servers/{name}/advisories",
+		Summary:     "List MCP server security advisories",
+		Description: "List every security advisory filed against a server, oldest first. Server records also carry the advisories affecting their version.",
+		Tags:        []string{"advisories"},
+	}, func(_ context.Context, input *ListAdvisoriesInput) (*Response[AdvisoryListResponse], error) {
+		advisories, err := registry.ListAdvisories(input.Name)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list advisories", err)
+		}
+
+		return &Response[AdvisoryListResponse]{
+			Body: AdvisoryListResponse{Advisories: advisories},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-server-advisory",
+		Method:        http.MethodPost,
+		Path:          "/v0/servers/{name}/advisories",
+		Summary:       "File an MCP server security advisory",
+		Description:   "File a security advisory against a server. It is attached to every version within affected_versions, including versions published later. Requires global edit permissions or publish permission for the server.",
+		Tags:          []string{"advisories"},
+		DefaultStatus: http.StatusCreated,
+		Errors:        []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *CreateAdvisoryInput) (*Response[apiv0.Advisory], error) {
+		claims, err := authorizeAdvisoryChange(ctx, jwtManager, registry, cfg, input.Authorization, input.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		advisory, err := registry.CreateAdvisory(claimsOwner(claims), input.Name, apiv0.Advisory{
+			Severity:         input.Body.Severity,
+			Summary:          input.Body.Summary,
+			Description:      input.Body.Description,
+			AffectedVersions: input.Body.AffectedVersions,
+			Remediation:      input.Body.Remediation,
+			Aliases:          input.Body.Aliases,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrInvalidAdvisory):
+				return nil, huma.Error400BadRequest(err.Error())
+			case errors.Is(err, database.ErrNotFound):
+				return nil, huma.Error404NotFound("Server not found")
+			default:
+				return nil, huma.Error500InternalServerError("Failed to create advisory", err)
+			}
+		}
+
+		return &Response[apiv0.Advisory]{
+			Body: *advisory,
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-server-advisory",
+		Method:        http.MethodDelete,
+		Path:          "/v0/servers/{name}/advisories/{id}",
+		Summary:       "Withdraw an MCP server security advisory",
+		Description:   "Withdraw a security advisory, for example one filed in error. Requires global edit permissions or publish permission for the server.",
+		Tags:          []string{"advisories"},
+		DefaultStatus: http.StatusNoContent,
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *DeleteAdvisoryInput) (*struct{}, error) {
+		if _, err := authorizeAdvisoryChange(ctx, jwtManager, registry, cfg, input.Authorization, input.Name); err != nil {
+			return nil, err
+		}
+
+		_, serverName, err := registry.GetAdvisory(input.ID)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return nil, huma.Error500InternalServerError("Failed to get advisory", err)
+		}
+		if err != nil || serverName != input.Name {
+			return nil, huma.Error404NotFound("Advisory not found")
+		}
+
+		if err := registry.DeleteAdvisory(input.ID); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Advisory not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to delete advisory", err)
+		}
+		return nil, nil
+	})
+}
+
+// authorizeAdvisoryChange checks the caller is an admin or may publish the named server, which must exist
+func authorizeAdvisoryChange(ctx context.Context, jwtManager *auth.JWTManager, registry service.RegistryService, cfg *config.Config, authHeader, name string) (*auth.JWTClaims, error) {
+	if err := rejectOnMirror(cfg); err != nil {
+		return nil, err
+	}
+
+	server, err := registry.GetLatestServerVersion(name, "")
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return nil, huma.Error404NotFound("Server not found")
+		}
+		return nil, huma.Error500InternalServerError("Failed to get server", err)
+	}
+
+	// Admins may file advisories against any server, including ones in namespaces reserved by others
+	if claims, err := validateBearerJWT(ctx, jwtManager, authHeader); err == nil &&
+		auth.HasGlobalPermission(auth.PermissionActionEdit, claims.Permissions) {
+		return claims, nil
+	}
+
+	claims, redeem, err := authorizePublish(ctx, jwtManager, registry, cfg, authHeader, "", *server)
+	if err != nil {
+		return nil, err
+	}
+	// An upload token is used up by the first change it authorizes
+	redeem()
+	return claims, nil
+}
@@ -0,0 +1,139 @@
+package v0_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdvisoryEndpoints(t *testing.T) {
+	testConfig := &config.Config{
+		JWTPrivateKey: "bb2c6b424005acd5df47a9e2c87f446def86dd740c888ea3efb825b23f7ef47c",
+	}
+	registryService := service.NewRegistryService(database.NewMemoryDB(), testConfig)
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService)
+	v0.RegisterPublishEndpoint(api, registryService, testConfig)
+	v0.RegisterAdvisoryEndpoints(api, registryService, testConfig)
+
+	tokenWith := func(user string, permissions ...auth.Permission) string {
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: user,
+			Permissions:       permissions,
+		})
+		require.NoError(t, err)
+		return token
+	}
+	owner := tokenWith("owner", auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.owner/*"})
+	admin := tokenWith("admin", auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: "*"})
+	other := tokenWith("other", auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.other/*"})
+
+	publish := func(version string) {
+		rr := doJSONRequest(t, mux, http.MethodPost, "/v0/publish", owner, apiv0.ServerJSON{
+			Name: "io.github.owner/server", Description: "A server", Version: version,
+		})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	}
+	listVersions := func(query string) map[string][]apiv0.Advisory {
+		rr := doJSONRequest(t, mux, http.MethodGet, "/v0/servers?search=io.github.owner/server"+query, "", nil)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var list apiv0.ServerListResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &list))
+		result := map[string][]apiv0.Advisory{}
+		for _, server := range list.Servers {
+			result[server.Version] = server.Meta.Advisories
+		}
+		return result
+	}
+	const advisoriesPath = "/v0/servers/io.github.owner%2Fserver/advisories"
+
+	publish("1.0.0")
+	publish("1.1.0")
+
+	body := v0.CreateAdvisoryBody{
+		Severity:         "high",
+		Summary:          "Path traversal in file read tool",
+		AffectedVersions: "<1.2.0",
+		Remediation:      "Upgrade to 1.2.0 or later",
+		Aliases:          []string{"CVE-2025-12345"},
+	}
+
+	t.Run("requires permission", func(t *testing.T) {
+		rr := doJSONRequest(t, mux, http.MethodPost, advisoriesPath, other, body)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		rr = doJSONRequest(t, mux, http.MethodPost, "/v0/servers/io.github.owner%2Fmissing/advisories", owner, body)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		invalid := body
+		invalid.AffectedVersions = "not a range"
+		rr = doJSONRequest(t, mux, http.MethodPost, advisoriesPath, owner, invalid)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	var advisory apiv0.Advisory
+	t.Run("publisher files an advisory", func(t *testing.T) {
+		rr := doJSONRequest(t, mux, http.MethodPost, advisoriesPath, owner, body)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &advisory))
+		assert.Equal(t, "high", advisory.Severity)
+		assert.NotEmpty(t, advisory.ID)
+
+		rr = doJSONRequest(t, mux, http.MethodGet, advisoriesPath, "", nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+		var list v0.AdvisoryListResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &list))
+		require.Len(t, list.Advisories, 1)
+		assert.Equal(t, advisory.ID, list.Advisories[0].ID)
+	})
+
+	t.Run("attached to affected versions", func(t *testing.T) {
+		publish("1.0.1") // published later, but still within the affected range
+		publish("1.2.0")
+
+		versions := listVersions("")
+		for _, version := range []string{"1.0.0", "1.0.1", "1.1.0"} {
+			require.Len(t, versions[version], 1, version)
+			assert.Equal(t, advisory.ID, versions[version][0].ID)
+		}
+		assert.Empty(t, versions["1.2.0"])
+
+		assert.Len(t, listVersions("&has_advisory=true"), 3)
+		unaffected := listVersions("&has_advisory=false")
+		assert.Len(t, unaffected, 1)
+		assert.Contains(t, unaffected, "1.2.0")
+	})
+
+	t.Run("admin files and withdraws an advisory", func(t *testing.T) {
+		rr := doJSONRequest(t, mux, http.MethodPost, advisoriesPath, admin, v0.CreateAdvisoryBody{
+			Severity: "critical", Summary: "Malicious package",
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var adminAdvisory apiv0.Advisory
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &adminAdvisory))
+		assert.Len(t, listVersions("")["1.2.0"], 1, "advisories without a range affect every version")
+
+		rr = doJSONRequest(t, mux, http.MethodDelete, advisoriesPath+"/"+adminAdvisory.ID, other, nil)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		rr = doJSONRequest(t, mux, http.MethodDelete, advisoriesPath+"/"+adminAdvisory.ID, admin, nil)
+		assert.Equal(t, http.StatusNoContent, rr.Code, rr.Body.String())
+		assert.Empty(t, listVersions("")["1.2.0"])
+
+		rr = doJSONRequest(t, mux, http.MethodDelete, advisoriesPath+"/"+adminAdvisory.ID, admin, nil)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -0,0 +1,214 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// APIKey describes an API key without its secret
+type APIKey struct {
+	ID        string     `json:"id" doc:"API key ID"`
+	Name      string     `json:"name" doc:"Name given to the key at creation"`
+	Prefix    string     `json:"prefix" doc:"Leading characters of the key, to help identify it"`
+	Scopes    []string   `json:"scopes" doc:"Server name patterns the key may publish"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// CreatedAPIKey is returned once, when an API key is created
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key" doc:"The API key. It is not stored and cannot be retrieved again."`
+}
+
+// APIKeyListResponse represents the list of a publisher's API keys
+type APIKeyListResponse struct {
+	APIKeys []APIKey `json:"api_keys"`
+}
+
+// CreateAPIKeyBody represents the request body for creating an API key
+type CreateAPIKeyBody struct {
+	Name          string   `json:"name" doc:"Name to identify the key" minLength:"1" maxLength:"100" example:"ci-publisher"`
+	Scopes        []string `json:"scopes" doc:"Server name patterns the key may publish, within your own publish permissions" minItems:"1" maxItems:"20" example:"[\"io.github.example/*\"]"`
+	ExpiresInDays int      `json:"expires_in_days,omitempty" doc:"Days until the key expires" default:"90" minimum:"1" maximum:"365"`
+}
+
+// CreateAPIKeyInput represents the input for creating an API key
+type CreateAPIKeyInput struct {
+	Authorization string           `header:"Authorization" doc:"Registry JWT token" required:"true"`
+	Body          CreateAPIKeyBody `body:""`
+}
+
+// ListAPIKeysInput represents the input for listing API keys
+type ListAPIKeysInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token" required:"true"`
+}
+
+// RevokeAPIKeyInput represents the input for revoking an API key
+type RevokeAPIKeyInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token" required:"true"`
+	ID            string `path:"id" doc:"API key ID (UUID)" format:"uuid"`
+}
+
+// RegisterAPIKeyEndpoints registers the API key management endpoints
+func RegisterAPIKeyEndpoints(api huma.API, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-api-key",
+		Method:        http.MethodPost,
+		Path:          "/v0/auth/api-keys",
+		Summary:       "Create API key",
+		Description:   "Create a long-lived API key for publishing without exchanging tokens. Use it as a Bearer token on /v0/publish. Its scopes must fall within the caller's own publish permissions.",
+		Tags:          []string{"auth"},
+		DefaultStatus: http.StatusCreated,
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *CreateAPIKeyInput) (*Response[CreatedAPIKey], error) {
+		claims, err := validateBearerJWT(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, scope := range input.Body.Scopes {
+			if !jwtManager.CanGrant(scope, auth.PermissionActionPublish, claims.Permissions) {
+				return nil, huma.Error403Forbidden("Scope '" + scope + "' is not within your publish permissions")
+			}
+		}
+
+		expiresInDays := input.Body.ExpiresInDays
+		if expiresInDays <= 0 {
+			expiresInDays = 90
+		}
+		expiresAt := time.Now().Add(time.Duration(expiresInDays) * 24 * time.Hour)
+
+		key, plaintext, err := registry.CreateAPIKey(claimsOwner(claims), input.Body.Name, input.Body.Scopes, expiresAt)
+		if err != nil {
+			if errors.Is(err, service.ErrTooManyAPIKeys) {
+				return nil, huma.Error400BadRequest(err.Error())
+			}
+			return nil, huma.Error500InternalServerError("Failed to create API key", err)
+		}
+
+		return &Response[CreatedAPIKey]{
+			Body: CreatedAPIKey{APIKey: toAPIKey(*key), Key: plaintext},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-api-keys",
+		Method:      http.MethodGet,
+		Path:        "/v0/auth/api-keys",
+		Summary:     "List API keys",
+		Description: "List the API keys created by the authenticated identity",
+		Tags:        []string{"auth"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ListAPIKeysInput) (*Response[APIKeyListResponse], error) {
+		claims, err := validateBearerJWT(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		keys, err := registry.ListAPIKeys(claimsOwner(claims))
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list API keys", err)
+		}
+
+		result := make([]APIKey, len(keys))
+		for i, key := range keys {
+			result[i] = toAPIKey(key)
+		}
+		return &Response[APIKeyListResponse]{
+			Body: APIKeyListResponse{APIKeys: result},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "revoke-api-key",
+		Method:        http.MethodDelete,
+		Path:          "/v0/auth/api-keys/{id}",
+		Summary:       "Revoke API key",
+		Description:   "Revoke an API key created by the authenticated identity. Revoked keys stop working immediately.",
+		Tags:          []string{"auth"},
+		DefaultStatus: http.StatusNoContent,
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *RevokeAPIKeyInput) (*struct{}, error) {
+		claims, err := validateBearerJWT(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := registry.RevokeAPIKey(claimsOwner(claims), input.ID); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("API key not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to revoke API key", err)
+		}
+
+		return nil, nil
+	})
+}
+
+// validateBearerJWT extracts and validates a Registry JWT from an Authorization header
+func validateBearerJWT(ctx context.Context, jwtManager *auth.JWTManager, authHeader string) (*auth.JWTClaims, error) {
+	const bearerPrefix = "Bearer "
+	if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		return nil, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
+	}
+	token := authHeader[len(bearerPrefix):]
+
+	claims, err := jwtManager.ValidateToken(ctx, token)
+	if err != nil {
+		return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+	}
+	return claims, nil
+}
+
+// apiKeyClaims builds the equivalent token claims for an API key, granting publish on its scopes only
+func apiKeyClaims(key *database.APIKey) *auth.JWTClaims {
+	permissions := make([]auth.Permission, len(key.Scopes))
+	for i, scope := range key.Scopes {
+		permissions[i] = auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: scope}
+	}
+	return &auth.JWTClaims{
+		AuthMethod:        auth.MethodAPIKey,
+		AuthMethodSubject: key.Owner,
+		Permissions:       permissions,
+	}
+}
+
+// claimsOwner identifies the publisher behind a set of claims, as "<auth method>:<subject>".
+// API keys act on behalf of the identity that created them.
+func claimsOwner(claims *auth.JWTClaims) string {
+	if claims.AuthMethod == auth.MethodAPIKey {
+		return claims.AuthMethodSubject
+	}
+	return string(claims.AuthMethod) + ":" + claims.AuthMethodSubject
+}
+
+func toAPIKey(key database.APIKey) APIKey {
+	return APIKey{
+		ID:        key.ID,
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Scopes:    key.Scopes,
+		CreatedAt: key.CreatedAt,
+		ExpiresAt: key.ExpiresAt,
+		RevokedAt: key.RevokedAt,
+	}
+}
@@ -0,0 +1,162 @@
+package v0_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/google/uuid"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupAPIKeyTest(t *testing.T) (*http.ServeMux, string) {
+	t.Helper()
+
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+	}
+
+	registryService := service.NewRegistryService(database.NewMemoryDB(), testConfig)
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, registryService, testConfig)
+	v0.RegisterAPIKeyEndpoints(api, registryService, testConfig)
+
+	token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "example",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.example/*"},
+		},
+	})
+	require.NoError(t, err)
+
+	return mux, token
+}
+
+func doJSONRequest(t *testing.T, mux *http.ServeMux, method, path, token string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var reader *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestAPIKeyEndpoints_Lifecycle(t *testing.T) {
+	mux, token := setupAPIKeyTest(t)
+
+	// Create a key scoped to a subset of the caller's permissions
+	rr := doJSONRequest(t, mux, http.MethodPost, "/v0/auth/api-keys", token, v0.CreateAPIKeyBody{
+		Name:   "ci",
+		Scopes: []string{"io.github.example/ci-*"},
+	})
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+	var created v0.CreatedAPIKey
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+	assert.True(t, len(created.Key) > len(service.APIKeyPrefix))
+	assert.Equal(t, service.APIKeyPrefix, created.Key[:len(service.APIKeyPrefix)])
+	assert.Equal(t, created.Key[:len(created.Prefix)], created.Prefix)
+	assert.WithinDuration(t, created.CreatedAt.AddDate(0, 0, 90), created.ExpiresAt, time.Second)
+
+	// Listing never includes the key itself
+	rr = doJSONRequest(t, mux, http.MethodGet, "/v0/auth/api-keys", token, nil)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.NotContains(t, rr.Body.String(), created.Key)
+	var list v0.APIKeyListResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &list))
+	require.Len(t, list.APIKeys, 1)
+	assert.Equal(t, created.ID, list.APIKeys[0].ID)
+
+	// The key publishes within its scopes only
+	rr = doJSONRequest(t, mux, http.MethodPost, "/v0/publish", created.Key, apiv0.ServerJSON{
+		Name:        "io.github.example/ci-server",
+		Description: "A test server",
+		Version:     "1.0.0",
+	})
+	assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	rr = doJSONRequest(t, mux, http.MethodPost, "/v0/publish", created.Key, apiv0.ServerJSON{
+		Name:        "io.github.example/other-server",
+		Description: "A test server",
+		Version:     "1.0.0",
+	})
+	assert.Equal(t, http.StatusForbidden, rr.Code, rr.Body.String())
+
+	// API keys can't manage API keys
+	rr = doJSONRequest(t, mux, http.MethodGet, "/v0/auth/api-keys", created.Key, nil)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+	// Revoked keys stop working immediately
+	rr = doJSONRequest(t, mux, http.MethodDelete, "/v0/auth/api-keys/"+created.ID, token, nil)
+	require.Equal(t, http.StatusNoContent, rr.Code, rr.Body.String())
+
+	rr = doJSONRequest(t, mux, http.MethodPost, "/v0/publish", created.Key, apiv0.ServerJSON{
+		Name:        "io.github.example/ci-server",
+		Description: "A test server",
+		Version:     "1.0.1",
+	})
+	assert.Equal(t, http.StatusUnauthorized, rr.Code, rr.Body.String())
+
+	rr = doJSONRequest(t, mux, http.MethodDelete, "/v0/auth/api-keys/"+uuid.NewString(), token, nil)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestAPIKeyEndpoints_ScopeEscalation(t *testing.T) {
+	mux, token := setupAPIKeyTest(t)
+
+	for _, scope := range []string{"io.github.other/*", "*", "io.github.*"} {
+		rr := doJSONRequest(t, mux, http.MethodPost, "/v0/auth/api-keys", token, v0.CreateAPIKeyBody{
+			Name:   "escalation",
+			Scopes: []string{scope},
+		})
+		assert.Equal(t, http.StatusForbidden, rr.Code, scope)
+	}
+}
+
+func TestAPIKeyEndpoints_RequireToken(t *testing.T) {
+	mux, _ := setupAPIKeyTest(t)
+
+	rr := doJSONRequest(t, mux, http.MethodPost, "/v0/publish", service.APIKeyPrefix+"unknown", apiv0.ServerJSON{
+		Name:        "io.github.example/ci-server",
+		Description: "A test server",
+		Version:     "1.0.0",
+	})
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+	rr = doJSONRequest(t, mux, http.MethodGet, "/v0/auth/api-keys", "not-a-token", nil)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
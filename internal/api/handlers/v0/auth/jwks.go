@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// JWKSOutput represents the JSON Web Key Set response
+type JWKSOutput struct {
+	CacheControl string `header:"Cache-Control"`
+	Body         auth.JWKS
+}
+
+// RegisterJWKSEndpoint registers the endpoint publishing the public keys used to sign Registry JWTs
+func RegisterJWKSEndpoint(api huma.API, cfg *config.Config) {
+	jwks := auth.NewJWTManager(cfg).JWKS()
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-jwks",
+		Method:      http.MethodGet,
+		Path:        "/.well-known/jwks.json",
+		Summary:     "Get Registry JWT signing keys",
+		Description: "Get the JSON Web Key Set used to sign Registry JWTs, so resource servers can validate tokens without sharing a secret. Tokens name their signing key in the kid header.",
+		Tags:        []string{"auth"},
+	}, func(_ context.Context, _ *struct{}) (*JWKSOutput, error) {
+		return &JWKSOutput{
+			// Short enough that verifiers pick up newly scheduled keys well before they activate
+			CacheControl: "public, max-age=300",
+			Body:         jwks,
+		}, nil
+	})
+}
@@ -24,4 +24,10 @@ func RegisterAuthEndpoints(api huma.API, cfg *config.Config) {
 
 	// Register anonymous authentication endpoint
 	RegisterNoneEndpoint(api, cfg)
+
+	// Register token inspection endpoint
+	RegisterWhoAmIEndpoint(api, cfg)
+
+	// Register JWT signing key set endpoint
+	RegisterJWKSEndpoint(api, cfg)
 }
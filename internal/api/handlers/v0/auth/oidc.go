@@ -7,7 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strings"
+	"sync"
 	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
@@ -25,6 +25,9 @@ type OIDCTokenExchangeInput struct {
 	}
 }
 
+// oidcSessionTTL is how long a user has to complete the authorization code flow after starting it
+const oidcSessionTTL = 5 * time.Minute
+
 // OIDCStartInput represents the input for OIDC authorization start
 type OIDCStartInput struct {
 	RedirectURI string `query:"redirect_uri" doc:"Optional redirect URI after authentication"`
@@ -47,8 +50,8 @@ type OIDCClaims struct {
 // GenericOIDCValidator defines the interface for validating OIDC tokens from any provider
 type GenericOIDCValidator interface {
 	ValidateToken(ctx context.Context, token string) (*OIDCClaims, error)
-	GetAuthorizationURL(state, nonce string, redirectURI string) string
-	ExchangeCodeForToken(ctx context.Context, code string, redirectURI string) (string, error)
+	GetAuthorizationURL(state, nonce, codeVerifier, redirectURI string) string
+	ExchangeCodeForToken(ctx context.Context, code, codeVerifier, redirectURI string) (string, error)
 }
 
 // StandardOIDCValidator validates OIDC tokens using go-oidc library
@@ -138,25 +141,26 @@ func (v *StandardOIDCValidator) ValidateToken(ctx context.Context, tokenString s
 	return oidcClaims, nil
 }
 
-// GetAuthorizationURL constructs the OIDC authorization URL using oauth2
-func (v *StandardOIDCValidator) GetAuthorizationURL(state, nonce string, redirectURI string) string {
+// GetAuthorizationURL constructs the OIDC authorization URL using oauth2, with a PKCE challenge for codeVerifier
+func (v *StandardOIDCValidator) GetAuthorizationURL(state, nonce, codeVerifier, redirectURI string) string {
 	// Update redirect URI for this request
 	config := *v.oauth2Config
 	config.RedirectURL = redirectURI
 
 	// Add nonce as additional parameter
-	authURL := config.AuthCodeURL(state, oauth2.SetAuthURLParam("nonce", nonce))
+	authURL := config.AuthCodeURL(state, oauth2.SetAuthURLParam("nonce", nonce), oauth2.S256ChallengeOption(codeVerifier))
 	return authURL
 }
 
-// ExchangeCodeForToken exchanges authorization code for ID token using oauth2
-func (v *StandardOIDCValidator) ExchangeCodeForToken(ctx context.Context, code string, redirectURI string) (string, error) {
+// ExchangeCodeForToken exchanges authorization code for ID token using oauth2, proving possession of the PKCE
+// code verifier the flow was started with
+func (v *StandardOIDCValidator) ExchangeCodeForToken(ctx context.Context, code, codeVerifier, redirectURI string) (string, error) {
 	// Update redirect URI for this exchange
 	config := *v.oauth2Config
 	config.RedirectURL = redirectURI
 
 	// Exchange authorization code for token
-	token, err := config.Exchange(ctx, code)
+	token, err := config.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
 	if err != nil {
 		return "", fmt.Errorf("failed to exchange code for token: %w", err)
 	}
@@ -175,15 +179,18 @@ type OIDCHandler struct {
 	config     *config.Config
 	jwtManager *auth.JWTManager
 	validator  GenericOIDCValidator
-	sessions   map[string]OIDCSession // In-memory state storage for now
+
+	mu       sync.Mutex
+	sessions map[string]OIDCSession // In-memory state storage for now
 }
 
 // OIDCSession stores OIDC flow state
 type OIDCSession struct {
-	State       string
-	Nonce       string
-	RedirectURI string
-	CreatedAt   time.Time
+	State        string
+	Nonce        string
+	CodeVerifier string
+	RedirectURI  string
+	CreatedAt    time.Time
 }
 
 // NewOIDCHandler creates a new OIDC handler
@@ -289,7 +296,11 @@ func (h *OIDCHandler) ExchangeToken(ctx context.Context, oidcToken string) (*aut
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate OIDC token: %w", err)
 	}
+	return h.issueToken(ctx, claims)
+}
 
+// issueToken issues a Registry JWT token for a validated OIDC identity
+func (h *OIDCHandler) issueToken(ctx context.Context, claims *OIDCClaims) (*auth.TokenResponse, error) {
 	// Validate extra claims if configured
 	if err := h.validateExtraClaims(claims); err != nil {
 		return nil, fmt.Errorf("extra claims validation failed: %w", err)
@@ -314,8 +325,12 @@ func (h *OIDCHandler) ExchangeToken(ctx context.Context, oidcToken string) (*aut
 	return tokenResponse, nil
 }
 
-// StartAuth initiates the OIDC authorization flow
+// StartAuth initiates the OIDC authorization code flow, with PKCE
 func (h *OIDCHandler) StartAuth(_ context.Context, redirectURI string) (string, error) {
+	if h.config.OIDCRedirectURL == "" {
+		return "", fmt.Errorf("OIDC_REDIRECT_URL must be set to use the authorization code flow")
+	}
+
 	// Generate state and nonce for security
 	state, err := generateRandomString(32)
 	if err != nil {
@@ -327,48 +342,60 @@ func (h *OIDCHandler) StartAuth(_ context.Context, redirectURI string) (string,
 		return "", fmt.Errorf("failed to generate nonce: %w", err)
 	}
 
-	// Store session for callback validation
+	// Store session for callback validation, dropping abandoned ones
 	session := OIDCSession{
-		State:       state,
-		Nonce:       nonce,
-		RedirectURI: redirectURI,
-		CreatedAt:   time.Now(),
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: oauth2.GenerateVerifier(),
+		RedirectURI:  redirectURI,
+		CreatedAt:    time.Now(),
+	}
+	h.mu.Lock()
+	for key, existing := range h.sessions {
+		if time.Since(existing.CreatedAt) > oidcSessionTTL {
+			delete(h.sessions, key)
+		}
 	}
 	h.sessions[state] = session
-
-	// Build callback URI - use configured base URL or default
-	callbackURI := "/v0/auth/oidc/callback"
+	h.mu.Unlock()
 
 	// Get authorization URL
-	authURL := h.validator.GetAuthorizationURL(state, nonce, callbackURI)
+	authURL := h.validator.GetAuthorizationURL(state, nonce, session.CodeVerifier, h.config.OIDCRedirectURL)
 
 	return authURL, nil
 }
 
 // HandleCallback handles the OIDC callback
 func (h *OIDCHandler) HandleCallback(ctx context.Context, code, state string) (*auth.TokenResponse, error) {
-	// Validate state and retrieve session
+	// Validate state and retrieve session, which can only be used once
+	h.mu.Lock()
 	session, exists := h.sessions[state]
+	delete(h.sessions, state)
+	h.mu.Unlock()
 	if !exists {
 		return nil, fmt.Errorf("invalid state parameter")
 	}
 
-	// Clean up session
-	delete(h.sessions, state)
-
-	// Check session expiry (5 minutes)
-	if time.Since(session.CreatedAt) > 5*time.Minute {
+	// Check session expiry
+	if time.Since(session.CreatedAt) > oidcSessionTTL {
 		return nil, fmt.Errorf("authentication session expired")
 	}
 
 	// Exchange authorization code for tokens
-	idToken, err := h.validator.ExchangeCodeForToken(ctx, code, "/v0/auth/oidc/callback")
+	idToken, err := h.validator.ExchangeCodeForToken(ctx, code, session.CodeVerifier, h.config.OIDCRedirectURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to exchange code for token: %w", err)
 	}
 
-	// Now validate the ID token and generate registry token
-	return h.ExchangeToken(ctx, idToken)
+	// Validate the ID token, check it was issued for this flow, and generate registry token
+	claims, err := h.validator.ValidateToken(ctx, idToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate OIDC token: %w", err)
+	}
+	if nonce, _ := claims.ExtraClaims["nonce"].(string); nonce != session.Nonce {
+		return nil, fmt.Errorf("ID token nonce doesn't match the authentication session")
+	}
+	return h.issueToken(ctx, claims)
 }
 
 // validateExtraClaims validates additional claims based on configuration
@@ -401,35 +428,20 @@ func (h *OIDCHandler) validateExtraClaims(claims *OIDCClaims) error {
 }
 
 // buildPermissions builds permissions based on OIDC claims and configuration
-func (h *OIDCHandler) buildPermissions(_ *OIDCClaims) []auth.Permission {
+func (h *OIDCHandler) buildPermissions(claims *OIDCClaims) []auth.Permission {
 	var permissions []auth.Permission
 
-	// Parse permission patterns from configuration
-	if h.config.OIDCPublishPerms != "" {
-		for _, pattern := range strings.Split(h.config.OIDCPublishPerms, ",") {
-			pattern = strings.TrimSpace(pattern)
-			if pattern != "" {
-				permissions = append(permissions, auth.Permission{
-					Action:          auth.PermissionActionPublish,
-					ResourcePattern: pattern,
-				})
-			}
-		}
-	}
+	// Parse permission patterns from configuration; patterns prefixed with "!" are deny rules
+	permissions = append(permissions, auth.ParsePermissions(auth.PermissionActionPublish, h.config.OIDCPublishPerms)...)
+	permissions = append(permissions, auth.ParsePermissions(auth.PermissionActionEdit, h.config.OIDCEditPerms)...)
 
-	if h.config.OIDCEditPerms != "" {
-		for _, pattern := range strings.Split(h.config.OIDCEditPerms, ",") {
-			pattern = strings.TrimSpace(pattern)
-			if pattern != "" {
-				permissions = append(permissions, auth.Permission{
-					Action:          auth.PermissionActionEdit,
-					ResourcePattern: pattern,
-				})
-			}
-		}
+	// Map the identity's claims into patterns with {claim} placeholders, e.g. one namespace per IdP group
+	claimValues := make(map[string]any, len(claims.ExtraClaims)+1)
+	for key, value := range claims.ExtraClaims {
+		claimValues[key] = value
 	}
-
-	return permissions
+	claimValues["sub"] = claims.Subject
+	return auth.ExpandClaims(permissions, claimValues)
 }
 
 // generateRandomString generates a cryptographically secure random string
@@ -2,10 +2,25 @@ package auth_test
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	registryauth "github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -14,8 +29,8 @@ import (
 // MockGenericOIDCValidator for testing
 type MockGenericOIDCValidator struct {
 	validateFunc     func(ctx context.Context, token string) (*auth.OIDCClaims, error)
-	authURLFunc      func(state, nonce, redirectURI string) string
-	exchangeCodeFunc func(ctx context.Context, code, redirectURI string) (string, error)
+	authURLFunc      func(state, nonce, codeVerifier, redirectURI string) string
+	exchangeCodeFunc func(ctx context.Context, code, codeVerifier, redirectURI string) (string, error)
 }
 
 func (m *MockGenericOIDCValidator) ValidateToken(ctx context.Context, token string) (*auth.OIDCClaims, error) {
@@ -25,16 +40,16 @@ func (m *MockGenericOIDCValidator) ValidateToken(ctx context.Context, token stri
 	return nil, fmt.Errorf("not implemented")
 }
 
-func (m *MockGenericOIDCValidator) GetAuthorizationURL(state, nonce, redirectURI string) string {
+func (m *MockGenericOIDCValidator) GetAuthorizationURL(state, nonce, codeVerifier, redirectURI string) string {
 	if m.authURLFunc != nil {
-		return m.authURLFunc(state, nonce, redirectURI)
+		return m.authURLFunc(state, nonce, codeVerifier, redirectURI)
 	}
 	return ""
 }
 
-func (m *MockGenericOIDCValidator) ExchangeCodeForToken(ctx context.Context, code, redirectURI string) (string, error) {
+func (m *MockGenericOIDCValidator) ExchangeCodeForToken(ctx context.Context, code, codeVerifier, redirectURI string) (string, error) {
 	if m.exchangeCodeFunc != nil {
-		return m.exchangeCodeFunc(ctx, code, redirectURI)
+		return m.exchangeCodeFunc(ctx, code, codeVerifier, redirectURI)
 	}
 	return "", fmt.Errorf("not implemented")
 }
@@ -128,11 +143,12 @@ func TestOIDCHandler_StartAuth(t *testing.T) {
 		OIDCIssuer:       "https://accounts.google.com",
 		OIDCClientID:     "test-client-id",
 		OIDCClientSecret: "test-secret",
+		OIDCRedirectURL:  "https://registry.example.com/v0/auth/oidc/callback",
 		JWTPrivateKey:    "deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
 	}
 
 	mockValidator := &MockGenericOIDCValidator{
-		authURLFunc: func(state, nonce, redirectURI string) string {
+		authURLFunc: func(state, nonce, _, redirectURI string) string {
 			return fmt.Sprintf("https://accounts.google.com/oauth/authorize?client_id=%s&state=%s&nonce=%s&redirect_uri=%s",
 				config.OIDCClientID, state, nonce, redirectURI)
 		},
@@ -149,6 +165,175 @@ func TestOIDCHandler_StartAuth(t *testing.T) {
 	assert.Contains(t, authURL, "client_id=test-client-id")
 	assert.Contains(t, authURL, "state=")
 	assert.Contains(t, authURL, "nonce=")
+	assert.Contains(t, authURL, "redirect_uri=https://registry.example.com/v0/auth/oidc/callback")
+
+	// The flow can't start without an absolute callback URL to send users back to
+	config.OIDCRedirectURL = ""
+	_, err = handler.StartAuth(ctx, "http://localhost:3000/callback")
+	assert.Error(t, err)
+}
+
+// testOIDCProvider is a local OpenID provider serving discovery, JWKS and token endpoints. The token endpoint
+// accepts the code "auth-code" with the PKCE verifier of the last authorization, and issues an ID token with the
+// claims last passed to issue.
+type testOIDCProvider struct {
+	*httptest.Server
+	key      *rsa.PrivateKey
+	clientID string
+
+	mu        sync.Mutex
+	challenge string
+	claims    jwt.MapClaims
+}
+
+func newTestOIDCProvider(t *testing.T, clientID string) *testOIDCProvider {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	provider := &testOIDCProvider{key: key, clientID: clientID}
+
+	writeJSON := func(w http.ResponseWriter, status int, body any) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(body)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{
+			"issuer":                                provider.URL,
+			"authorization_endpoint":                provider.URL + "/authorize",
+			"token_endpoint":                        provider.URL + "/token",
+			"jwks_uri":                              provider.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test-key",
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		provider.mu.Lock()
+		defer provider.mu.Unlock()
+
+		verifier := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+		if r.PostFormValue("code") != "auth-code" || base64.RawURLEncoding.EncodeToString(verifier[:]) != provider.challenge {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		claims := jwt.MapClaims{
+			"iss": provider.URL,
+			"aud": provider.clientID,
+			"iat": time.Now().Unix(),
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}
+		maps.Copy(claims, provider.claims)
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "test-key"
+		idToken, err := token.SignedString(key)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"access_token": "access-token", "token_type": "Bearer", "id_token": idToken})
+	})
+
+	provider.Server = httptest.NewUnstartedServer(mux)
+	provider.Start()
+	t.Cleanup(provider.Close)
+	return provider
+}
+
+// authorize records the PKCE challenge of an authorization URL, as if the user signed in, and returns the
+// flow's state and nonce
+func (p *testOIDCProvider) authorize(t *testing.T, authURL string) (string, string) {
+	t.Helper()
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+	query := parsed.Query()
+	require.Equal(t, "S256", query.Get("code_challenge_method"))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.challenge = query.Get("code_challenge")
+	return query.Get("state"), query.Get("nonce")
+}
+
+// issue sets the claims of the ID tokens the token endpoint issues
+func (p *testOIDCProvider) issue(claims jwt.MapClaims) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.claims = claims
+}
+
+func TestOIDCHandler_HandleCallback(t *testing.T) {
+	provider := newTestOIDCProvider(t, "test-client-id")
+	cfg := &config.Config{
+		OIDCEnabled:      true,
+		OIDCIssuer:       provider.URL,
+		OIDCClientID:     "test-client-id",
+		OIDCRedirectURL:  "https://registry.example.com/v0/auth/oidc/callback",
+		OIDCPublishPerms: "com.example.{groups}/*,!com.example.{quarantined}/*",
+		JWTPrivateKey:    "deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+	}
+	handler := auth.NewOIDCHandler(cfg)
+
+	// startFlow starts a flow and has the provider authorize it, returning the flow's state and nonce
+	startFlow := func(t *testing.T) (string, string) {
+		t.Helper()
+		authURL, err := handler.StartAuth(context.Background(), "http://localhost:3000/callback")
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(authURL, provider.URL+"/authorize?"), authURL)
+		return provider.authorize(t, authURL)
+	}
+
+	t.Run("exchanges the code with the flow's verifier and maps claims to permissions", func(t *testing.T) {
+		state, nonce := startFlow(t)
+		provider.issue(jwt.MapClaims{"sub": "user-1", "nonce": nonce, "groups": []string{"platform", "data"}})
+
+		response, err := handler.HandleCallback(context.Background(), "auth-code", state)
+		require.NoError(t, err)
+
+		claims, err := registryauth.NewJWTManager(cfg).ValidateToken(context.Background(), response.RegistryToken)
+		require.NoError(t, err)
+		assert.Equal(t, []registryauth.Permission{
+			{Action: registryauth.PermissionActionPublish, ResourcePattern: "com.example.platform/*"},
+			{Action: registryauth.PermissionActionPublish, ResourcePattern: "com.example.data/*"},
+			{Action: registryauth.PermissionActionPublish, ResourcePattern: "*", Deny: true},
+		}, claims.Permissions, "a deny rule whose claim is missing denies everything")
+
+		// The state can only be used once
+		_, err = handler.HandleCallback(context.Background(), "auth-code", state)
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects a code exchanged without the verifier of the authorized flow", func(t *testing.T) {
+		state, _ := startFlow(t)
+		_, nonce := startFlow(t)
+		provider.issue(jwt.MapClaims{"sub": "user-1", "nonce": nonce})
+
+		_, err := handler.HandleCallback(context.Background(), "auth-code", state)
+		assert.ErrorContains(t, err, "exchange")
+	})
+
+	t.Run("rejects an ID token issued for another flow", func(t *testing.T) {
+		state, _ := startFlow(t)
+		provider.issue(jwt.MapClaims{"sub": "user-1", "nonce": "replayed"})
+
+		_, err := handler.HandleCallback(context.Background(), "auth-code", state)
+		assert.ErrorContains(t, err, "nonce")
+	})
+
+	t.Run("rejects an unknown state", func(t *testing.T) {
+		_, err := handler.HandleCallback(context.Background(), "auth-code", "unknown")
+		assert.Error(t, err)
+	})
 }
 
 // Note: validateExtraClaims and buildPermissions are tested through ExchangeToken integration tests
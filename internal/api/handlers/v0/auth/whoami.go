@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// WhoAmIInput represents the input for inspecting a Registry JWT
+type WhoAmIInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token" required:"true"`
+	Server        string `query:"server" doc:"Optional server name to check the token's effective permissions against" example:"io.github.example/my-server"`
+}
+
+// WhoAmIBody describes the identity and permissions carried by a Registry JWT
+type WhoAmIBody struct {
+	AuthMethod        auth.Method       `json:"auth_method" doc:"How the token was obtained"`
+	AuthMethodSubject string            `json:"auth_method_sub" doc:"Identity within the auth method, e.g. a GitHub username or domain"`
+	Permissions       []auth.Permission `json:"permissions" doc:"Allow and deny rules; deny rules override any matching allow rule"`
+	ExpiresAt         time.Time         `json:"expires_at"`
+	Server            *ServerAccess     `json:"server,omitempty" doc:"Effective permissions for the requested server"`
+}
+
+// ServerAccess reports which actions a token may take on a server
+type ServerAccess struct {
+	Name       string `json:"name"`
+	CanPublish bool   `json:"can_publish"`
+	CanEdit    bool   `json:"can_edit"`
+}
+
+// RegisterWhoAmIEndpoint registers the endpoint echoing a token's identity and permissions
+func RegisterWhoAmIEndpoint(api huma.API, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "whoami",
+		Method:      http.MethodGet,
+		Path:        "/v0/auth/whoami",
+		Summary:     "Inspect Registry JWT",
+		Description: "Get the identity and permissions carried by a Registry JWT, to debug publish or edit failures. Pass server to see the effective permissions for a specific server name.",
+		Tags:        []string{"auth"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *WhoAmIInput) (*v0.Response[WhoAmIBody], error) {
+		const bearerPrefix = "Bearer "
+		authHeader := input.Authorization
+		if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+			return nil, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
+		}
+
+		claims, err := jwtManager.ValidateToken(ctx, authHeader[len(bearerPrefix):])
+		if err != nil {
+			return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+		}
+
+		body := WhoAmIBody{
+			AuthMethod:        claims.AuthMethod,
+			AuthMethodSubject: claims.AuthMethodSubject,
+			Permissions:       claims.Permissions,
+		}
+		if claims.ExpiresAt != nil {
+			body.ExpiresAt = claims.ExpiresAt.Time
+		}
+		if input.Server != "" {
+			body.Server = &ServerAccess{
+				Name:       input.Server,
+				CanPublish: jwtManager.HasPermission(input.Server, auth.PermissionActionPublish, claims.Permissions),
+				CanEdit:    jwtManager.HasPermission(input.Server, auth.PermissionActionEdit, claims.Permissions),
+			}
+		}
+
+		return &v0.Response[WhoAmIBody]{Body: body}, nil
+	})
+}
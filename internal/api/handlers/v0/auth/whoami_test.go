@@ -0,0 +1,85 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0auth "github.com/modelcontextprotocol/registry/internal/api/handlers/v0/auth"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+func TestWhoAmIEndpoint(t *testing.T) {
+	cfg := &config.Config{
+		JWTPrivateKey: "bb2c6b424005acd5df47a9e2c87f446def86dd740c888ea3efb825b23f7ef47c",
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0auth.RegisterWhoAmIEndpoint(api, cfg)
+
+	permissions := []auth.Permission{
+		{Action: auth.PermissionActionPublish, ResourcePattern: "com.example.*/*"},
+		{Action: auth.PermissionActionPublish, ResourcePattern: "com.example.internal/*", Deny: true},
+	}
+	tokenResponse, err := auth.NewJWTManager(cfg).GenerateTokenResponse(context.Background(), auth.JWTClaims{
+		AuthMethod:        auth.MethodDNS,
+		AuthMethodSubject: "example.com",
+		Permissions:       permissions,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		server      string
+		wantPublish bool
+	}{
+		{name: "no server", server: ""},
+		{name: "allowed server", server: "com.example.api/server", wantPublish: true},
+		{name: "denied server", server: "com.example.internal/server", wantPublish: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v0/auth/whoami?server="+tt.server, nil)
+			req.Header.Set("Authorization", "Bearer "+tokenResponse.RegistryToken)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			var body v0auth.WhoAmIBody
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, auth.MethodDNS, body.AuthMethod)
+			assert.Equal(t, "example.com", body.AuthMethodSubject)
+			assert.Equal(t, permissions, body.Permissions)
+			assert.Equal(t, int64(tokenResponse.ExpiresAt), body.ExpiresAt.Unix())
+
+			if tt.server == "" {
+				assert.Nil(t, body.Server)
+				return
+			}
+			require.NotNil(t, body.Server)
+			assert.Equal(t, tt.server, body.Server.Name)
+			assert.Equal(t, tt.wantPublish, body.Server.CanPublish)
+			assert.False(t, body.Server.CanEdit)
+		})
+	}
+
+	t.Run("invalid token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/auth/whoami", nil)
+		req.Header.Set("Authorization", "Bearer not-a-token")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
@@ -0,0 +1,174 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// BlockedName is a blocklist entry that no server name may match
+type BlockedName struct {
+	Pattern   string     `json:"pattern" doc:"Server name pattern such as 'com.example/*', or a word blocked anywhere in a name" example:"com.example/*"`
+	Reason    string     `json:"reason,omitempty"`
+	Source    string     `json:"source" enum:"config,admin" doc:"Whether the entry comes from MCP_REGISTRY_BLOCKED_NAMES or was added through this API"`
+	CreatedBy string     `json:"created_by,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// BlocklistResponse represents every blocklist entry
+type BlocklistResponse struct {
+	Entries []BlockedName `json:"entries"`
+}
+
+// AddBlockedNameBody represents the request body for adding a blocklist entry
+type AddBlockedNameBody struct {
+	Pattern string `json:"pattern" doc:"Server name pattern containing a slash, where '*' is a wildcard (e.g. 'com.example/*'), or a word blocked anywhere in a name" minLength:"1" maxLength:"255" example:"com.example/*"`
+	Reason  string `json:"reason,omitempty" doc:"Why the names are blocked" maxLength:"500"`
+}
+
+// AddBlockedNameInput represents the input for adding a blocklist entry
+type AddBlockedNameInput struct {
+	Authorization string             `header:"Authorization" doc:"Registry JWT token with global edit permissions" required:"true"`
+	Body          AddBlockedNameBody `body:""`
+}
+
+// ListBlockedNamesInput represents the input for listing blocklist entries
+type ListBlockedNamesInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with global edit permissions" required:"true"`
+}
+
+// RemoveBlockedNameInput represents the input for removing a blocklist entry
+type RemoveBlockedNameInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with global edit permissions" required:"true"`
+	Pattern       string `path:"pattern" doc:"Pattern to unblock, URL-encoded (e.g. com.example%2F*)"`
+}
+
+// RegisterBlocklistEndpoints registers the admin endpoints for managing blocked server names
+func RegisterBlocklistEndpoints(api huma.API, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	// requireAdmin checks the token grants edit permission on every server
+	requireAdmin := func(ctx context.Context, authHeader string) (*auth.JWTClaims, error) {
+		claims, err := validateBearerJWT(ctx, jwtManager, authHeader)
+		if err != nil {
+			return nil, err
+		}
+		if !auth.HasGlobalPermission(auth.PermissionActionEdit, claims.Permissions) {
+			return nil, huma.Error403Forbidden("Managing the blocklist requires global edit permissions")
+		}
+		return claims, nil
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-blocked-names",
+		Method:      http.MethodGet,
+		Path:        "/v0/admin/blocklist",
+		Summary:     "List blocked names",
+		Description: "List the server name patterns that can't be published (admin only)",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ListBlockedNamesInput) (*Response[BlocklistResponse], error) {
+		if _, err := requireAdmin(ctx, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		stored, err := registry.ListBlockedNames()
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list blocked names", err)
+		}
+
+		configured := registry.Config().BlockedNames
+		entries := make([]BlockedName, 0, len(configured)+len(stored))
+		for _, pattern := range configured {
+			entries = append(entries, BlockedName{Pattern: pattern, Source: "config"})
+		}
+		for _, entry := range stored {
+			entries = append(entries, toBlockedName(entry))
+		}
+
+		return &Response[BlocklistResponse]{Body: BlocklistResponse{Entries: entries}}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "add-blocked-name",
+		Method:        http.MethodPost,
+		Path:          "/v0/admin/blocklist",
+		Summary:       "Block names",
+		Description:   "Prevent server names matching a pattern from being published (admin only). Existing servers are not affected.",
+		Tags:          []string{"admin"},
+		DefaultStatus: http.StatusCreated,
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *AddBlockedNameInput) (*Response[BlockedName], error) {
+		if err := rejectOnMirror(cfg); err != nil {
+			return nil, err
+		}
+		claims, err := requireAdmin(ctx, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		entry, err := registry.AddBlockedName(claimsOwner(claims), input.Body.Pattern, input.Body.Reason)
+		if err != nil {
+			switch {
+			case errors.Is(err, database.ErrAlreadyExists):
+				return nil, huma.Error409Conflict("Pattern " + input.Body.Pattern + " is already blocked")
+			case errors.Is(err, database.ErrInvalidInput):
+				return nil, huma.Error400BadRequest(err.Error())
+			}
+			return nil, huma.Error500InternalServerError("Failed to block names", err)
+		}
+
+		return &Response[BlockedName]{Body: toBlockedName(*entry)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "remove-blocked-name",
+		Method:        http.MethodDelete,
+		Path:          "/v0/admin/blocklist/{pattern}",
+		Summary:       "Unblock names",
+		Description:   "Remove a blocklist entry added through this API (admin only). Entries from configuration can only be removed there.",
+		Tags:          []string{"admin"},
+		DefaultStatus: http.StatusNoContent,
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *RemoveBlockedNameInput) (*struct{}, error) {
+		if err := rejectOnMirror(cfg); err != nil {
+			return nil, err
+		}
+		if _, err := requireAdmin(ctx, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		if err := registry.RemoveBlockedName(input.Pattern); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Pattern " + input.Pattern + " was not added through the admin API")
+			}
+			return nil, huma.Error500InternalServerError("Failed to unblock names", err)
+		}
+
+		return nil, nil
+	})
+}
+
+func toBlockedName(entry database.BlockedName) BlockedName {
+	createdAt := entry.CreatedAt
+	return BlockedName{
+		Pattern:   entry.Pattern,
+		Reason:    entry.Reason,
+		Source:    "admin",
+		CreatedBy: entry.CreatedBy,
+		CreatedAt: &createdAt,
+	}
+}
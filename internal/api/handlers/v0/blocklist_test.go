@@ -0,0 +1,105 @@
+package v0_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlocklistEndpoints(t *testing.T) {
+	testConfig := &config.Config{
+		JWTPrivateKey: "bb2c6b424005acd5df47a9e2c87f446def86dd740c888ea3efb825b23f7ef47c",
+		BlockedNames:  []string{"io.modelcontextprotocol/*"},
+	}
+	registryService := service.NewRegistryService(database.NewMemoryDB(), testConfig)
+	require.NoError(t, registryService.RefreshBlocklist(t.Context()))
+	t.Cleanup(func() { validators.ConfigureBlocklist(nil) })
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, registryService, testConfig)
+	v0.RegisterBlocklistEndpoints(api, registryService, testConfig)
+
+	adminToken, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod:        auth.MethodOIDC,
+		AuthMethodSubject: "admin@example.com",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+			{Action: auth.PermissionActionEdit, ResourcePattern: "*"},
+		},
+	})
+	require.NoError(t, err)
+	publisherToken, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod:        auth.MethodNone,
+		AuthMethodSubject: "anonymous",
+		Permissions:       []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "*"}},
+	})
+	require.NoError(t, err)
+
+	publish := func(name string) int {
+		return doJSONRequest(t, mux, http.MethodPost, "/v0/publish", publisherToken, apiv0.ServerJSON{
+			Name: name, Description: "A server", Version: "1.0.0",
+		}).Code
+	}
+
+	t.Run("configured entries are enforced", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, publish("io.modelcontextprotocol/everything"))
+	})
+
+	t.Run("requires admin", func(t *testing.T) {
+		rr := doJSONRequest(t, mux, http.MethodPost, "/v0/admin/blocklist", publisherToken, v0.AddBlockedNameBody{Pattern: "com.example/*"})
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+
+		rr = doJSONRequest(t, mux, http.MethodGet, "/v0/admin/blocklist", "", nil)
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+
+	t.Run("add, list and remove", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, publish("com.example/existing"))
+
+		rr := doJSONRequest(t, mux, http.MethodPost, "/v0/admin/blocklist", adminToken, v0.AddBlockedNameBody{Pattern: "COM.Example/*", Reason: "Impersonation"})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var added v0.BlockedName
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &added))
+		assert.Equal(t, "com.example/*", added.Pattern)
+		assert.Equal(t, "oidc:admin@example.com", added.CreatedBy)
+
+		rr = doJSONRequest(t, mux, http.MethodPost, "/v0/admin/blocklist", adminToken, v0.AddBlockedNameBody{Pattern: "com.example/*"})
+		assert.Equal(t, http.StatusConflict, rr.Code)
+
+		rr = doJSONRequest(t, mux, http.MethodPost, "/v0/admin/blocklist", adminToken, v0.AddBlockedNameBody{Pattern: "badword"})
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		assert.Equal(t, http.StatusBadRequest, publish("com.example/new-server"))
+		assert.Equal(t, http.StatusBadRequest, publish("io.github.someone/my-BadWord-server"))
+		assert.Equal(t, http.StatusOK, publish("io.github.someone/fine-server"))
+
+		rr = doJSONRequest(t, mux, http.MethodGet, "/v0/admin/blocklist", adminToken, nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+		var list v0.BlocklistResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &list))
+		require.Len(t, list.Entries, 3)
+		assert.Equal(t, "config", list.Entries[0].Source)
+		assert.Equal(t, "admin", list.Entries[1].Source)
+		assert.Equal(t, "Impersonation", list.Entries[1].Reason)
+
+		rr = doJSONRequest(t, mux, http.MethodDelete, "/v0/admin/blocklist/com.example%2F%2A", adminToken, nil)
+		require.Equal(t, http.StatusNoContent, rr.Code, rr.Body.String())
+		assert.Equal(t, http.StatusOK, publish("com.example/new-server"))
+
+		rr = doJSONRequest(t, mux, http.MethodDelete, "/v0/admin/blocklist/io.modelcontextprotocol%2F%2A", adminToken, nil)
+		assert.Equal(t, http.StatusNotFound, rr.Code, "configured entries can't be removed through the API")
+	})
+}
@@ -0,0 +1,91 @@
+package v0
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// CategoryListResponse represents the registry's category taxonomy
+type CategoryListResponse struct {
+	Categories []service.CategoryCount `json:"categories"`
+}
+
+// CategoryServersInput represents the input for browsing the servers in a category
+type CategoryServersInput struct {
+	Category string `path:"category" doc:"Category name" example:"developer-tools"`
+	Cursor   string `query:"cursor" doc:"Pagination cursor (UUID)" format:"uuid" required:"false" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Limit    int    `query:"limit" doc:"Number of items per page" default:"30" minimum:"1" maximum:"100" example:"50"`
+	Sort     string `query:"sort" doc:"Order results by weekly installs ('downloads'), publish time, newest first ('recent'), or name ('name')" enum:"downloads,recent,name" required:"false" example:"downloads"`
+}
+
+// RegisterCategoryEndpoints registers the category browsing endpoints
+func RegisterCategoryEndpoints(api huma.API, registry service.RegistryService, cfg *config.Config) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-categories",
+		Method:      http.MethodGet,
+		Path:        "/v0/categories",
+		Summary:     "List server categories",
+		Description: "List the registry's curated category taxonomy, with the number of servers in each category",
+		Tags:        []string{"categories"},
+	}, func(_ context.Context, _ *struct{}) (*Response[CategoryListResponse], error) {
+		categories, err := registry.ListCategories()
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list categories", err)
+		}
+
+		return &Response[CategoryListResponse]{
+			Body: CategoryListResponse{Categories: categories},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-category-servers",
+		Method:      http.MethodGet,
+		Path:        "/v0/categories/{category}/servers",
+		Summary:     "List servers in a category",
+		Description: "Get a paginated list of the latest version of each server in a category, excluding deleted servers",
+		Tags:        []string{"categories"},
+		Errors:      []int{http.StatusBadRequest},
+	}, func(ctx context.Context, input *CategoryServersInput) (*Response[apiv0.ServerListResponse], error) {
+		if !slices.Contains(validators.CategoryTaxonomy(cfg), input.Category) {
+			return nil, huma.Error404NotFound("Category not found")
+		}
+		if input.Cursor != "" {
+			if _, err := uuid.Parse(input.Cursor); err != nil {
+				return nil, huma.Error400BadRequest("Invalid cursor parameter")
+			}
+		}
+
+		isLatest := true
+		filter := &database.ServerFilter{
+			Category:       &input.Category,
+			IsLatest:       &isLatest,
+			ExcludeDeleted: true,
+			Sort:           database.ServerSort(input.Sort),
+		}
+		servers, nextCursor, err := registry.List(filter, input.Cursor, input.Limit)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get registry list", err)
+		}
+		servers = ReadableServers(ctx, servers)
+
+		return &Response[apiv0.ServerListResponse]{
+			Body: apiv0.ServerListResponse{
+				Servers: servers,
+				Metadata: apiv0.Metadata{
+					NextCursor: nextCursor,
+					Count:      len(servers),
+				},
+			},
+		}, nil
+	})
+}
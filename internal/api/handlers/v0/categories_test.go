@@ -0,0 +1,97 @@
+package v0_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategoryEndpoints(t *testing.T) {
+	cfg := &config.Config{Categories: []string{"databases", "web", "security"}}
+	registryService := service.NewRegistryService(database.NewMemoryDB(), cfg)
+	for _, server := range []apiv0.ServerJSON{
+		{Name: "com.example/postgres", Version: "1.0.0", Categories: []string{"databases"}, Tags: []string{"sql"}},
+		{Name: "com.example/postgres", Version: "2.0.0", Categories: []string{"databases"}, Tags: []string{"sql"}},
+		{Name: "com.example/redis", Version: "1.0.0", Categories: []string{"databases", "web"}},
+		{Name: "com.example/removed", Version: "1.0.0", Categories: []string{"web"}, Status: model.StatusDeleted},
+		{Name: "com.example/browser", Version: "1.0.0", Tags: []string{"sql"}},
+	} {
+		server.Description = "A categorized server"
+		_, err := registryService.Publish(server)
+		require.NoError(t, err)
+	}
+
+	_, err := registryService.Publish(apiv0.ServerJSON{
+		Name: "com.example/weather", Description: "Uncategorized", Version: "1.0.0", Categories: []string{"weather"},
+	})
+	assert.ErrorIs(t, err, validators.ErrInvalidCategory)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService)
+	v0.RegisterCategoryEndpoints(api, registryService, cfg)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+	serverVersions := func(t *testing.T, w *httptest.ResponseRecorder) []string {
+		t.Helper()
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp apiv0.ServerListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		var names []string
+		for _, server := range resp.Servers {
+			names = append(names, server.Name+"@"+server.Version)
+		}
+		return names
+	}
+
+	t.Run("lists the taxonomy with counts", func(t *testing.T) {
+		w := get("/v0/categories")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp v0.CategoryListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, []service.CategoryCount{
+			{Name: "databases", ServerCount: 2},
+			{Name: "web", ServerCount: 1},
+			{Name: "security", ServerCount: 0},
+		}, resp.Categories)
+	})
+
+	t.Run("lists the latest servers in a category", func(t *testing.T) {
+		names := serverVersions(t, get("/v0/categories/databases/servers?sort=name"))
+		assert.Equal(t, []string{"com.example/postgres@2.0.0", "com.example/redis@1.0.0"}, names)
+
+		// Deleted servers are hidden
+		names = serverVersions(t, get("/v0/categories/web/servers"))
+		assert.Equal(t, []string{"com.example/redis@1.0.0"}, names)
+	})
+
+	t.Run("unknown category", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, get("/v0/categories/weather/servers").Code)
+	})
+
+	t.Run("filters the server list by category and tag", func(t *testing.T) {
+		names := serverVersions(t, get("/v0/servers?version=latest&category=web&sort=name"))
+		assert.Equal(t, []string{"com.example/redis@1.0.0", "com.example/removed@1.0.0"}, names)
+
+		names = serverVersions(t, get("/v0/servers?version=latest&tag=sql&sort=name"))
+		assert.Equal(t, []string{"com.example/browser@1.0.0", "com.example/postgres@2.0.0"}, names)
+	})
+}
@@ -0,0 +1,43 @@
+package v0
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListChangesInput represents the input for reading the change feed
+type ListChangesInput struct {
+	Since int64 `query:"since" doc:"Return changes with a sequence number greater than this (0 for the beginning of the feed)" default:"0" minimum:"0" example:"1024"`
+	Limit int   `query:"limit" doc:"Number of changes per page" default:"100" minimum:"1" maximum:"1000" example:"100"`
+}
+
+// RegisterChangesEndpoint registers the change feed endpoint
+func RegisterChangesEndpoint(api huma.API, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-changes",
+		Method:      http.MethodGet,
+		Path:        "/v0/changes",
+		Summary:     "List registry changes",
+		Description: "Get publish, update and delete events in sequence order, so downstream indexes and mirrors can sync incrementally. Pass metadata.next_since as since to fetch the next page.",
+		Tags:        []string{"changes"},
+	}, func(_ context.Context, input *ListChangesInput) (*Response[apiv0.ChangeListResponse], error) {
+		changes, nextSince, err := registry.ListChanges(input.Since, input.Limit)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get registry changes", err)
+		}
+
+		return &Response[apiv0.ChangeListResponse]{
+			Body: apiv0.ChangeListResponse{
+				Changes: changes,
+				Metadata: apiv0.ChangeMetadata{
+					NextSince: nextSince,
+					Count:     len(changes),
+				},
+			},
+		}, nil
+	})
+}
@@ -0,0 +1,98 @@
+package v0_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestChangesEndpoint(t *testing.T) {
+	registryService := service.NewRegistryService(database.NewMemoryDB(), &config.Config{EnableRegistryValidation: false})
+
+	first, err := registryService.Publish(apiv0.ServerJSON{
+		Name:        "com.example/changes-server",
+		Description: "A server with changes",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+	_, err = registryService.Publish(apiv0.ServerJSON{
+		Name:        "com.example/changes-server",
+		Description: "A server with changes",
+		Version:     "1.1.0",
+	})
+	require.NoError(t, err)
+	_, err = registryService.EditServer(first.GetID(), apiv0.ServerJSON{
+		Name:        "com.example/changes-server",
+		Description: "A server with changes",
+		Version:     "1.0.0",
+		Status:      model.StatusDeleted,
+	}, 0)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterChangesEndpoint(api, registryService)
+
+	getChanges := func(t *testing.T, query string) apiv0.ChangeListResponse {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/v0/changes"+query, nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp apiv0.ChangeListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp
+	}
+
+	t.Run("returns every change in order", func(t *testing.T) {
+		resp := getChanges(t, "")
+		require.Len(t, resp.Changes, 4)
+
+		var types []apiv0.ChangeType
+		for i, change := range resp.Changes {
+			assert.Equal(t, int64(i+1), change.Sequence)
+			types = append(types, change.Type)
+		}
+		assert.Equal(t, []apiv0.ChangeType{
+			apiv0.ChangeTypePublish,
+			apiv0.ChangeTypePublish,
+			apiv0.ChangeTypeUpdate, // 1.0.0 is no longer latest
+			apiv0.ChangeTypeDelete,
+		}, types)
+
+		// Events capture the record as of that change
+		assert.True(t, resp.Changes[0].Server.Meta.Official.IsLatest)
+		assert.False(t, resp.Changes[2].Server.Meta.Official.IsLatest)
+		assert.Equal(t, first.GetID(), resp.Changes[3].ServerID)
+		assert.Equal(t, int64(4), resp.Metadata.NextSince)
+	})
+
+	t.Run("pages with since", func(t *testing.T) {
+		resp := getChanges(t, "?limit=2")
+		require.Len(t, resp.Changes, 2)
+		assert.Equal(t, int64(2), resp.Metadata.NextSince)
+
+		resp = getChanges(t, "?limit=2&since=2")
+		require.Len(t, resp.Changes, 2)
+		assert.Equal(t, int64(3), resp.Changes[0].Sequence)
+		assert.Equal(t, int64(4), resp.Metadata.NextSince)
+
+		resp = getChanges(t, "?since=4")
+		assert.Empty(t, resp.Changes)
+		assert.Equal(t, int64(4), resp.Metadata.NextSince)
+	})
+}
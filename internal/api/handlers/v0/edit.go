@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/danielgtaylor/huma/v2"
@@ -18,6 +19,7 @@ import (
 // EditServerInput represents the input for editing a server
 type EditServerInput struct {
 	Authorization string           `header:"Authorization" doc:"Registry JWT token with edit permissions" required:"true"`
+	IfMatch       string           `header:"If-Match" doc:"Required. ETag of the revision being edited, as returned when getting the server, or * to overwrite any revision"`
 	ID            string           `path:"id" doc:"Server ID (UUID)" format:"uuid"`
 	Body          apiv0.ServerJSON `body:""`
 }
@@ -28,16 +30,23 @@ func RegisterEditEndpoints(api huma.API, registry service.RegistryService, cfg *
 
 	// Edit server endpoint
 	huma.Register(api, huma.Operation{
-		OperationID: "edit-server",
-		Method:      http.MethodPut,
-		Path:        "/v0/servers/{id}",
-		Summary:     "Edit MCP server",
-		Description: "Update an existing MCP server (admin only)",
-		Tags:        []string{"admin"},
+		OperationID:  "edit-server",
+		Method:       http.MethodPut,
+		Path:         "/v0/servers/{id}",
+		Summary:      "Edit MCP server",
+		Description:  "Update an existing MCP server (admin only). Edits must name the revision they are based on in If-Match, and fail with 409 Conflict if the server has changed since.",
+		Tags:         []string{"admin"},
+		MaxBodyBytes: publishMaxBodyBytes(cfg),
+		Errors:       []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusRequestEntityTooLarge, http.StatusPreconditionRequired},
 		Security: []map[string][]string{
 			{"bearer": {}},
 		},
-	}, func(ctx context.Context, input *EditServerInput) (*Response[apiv0.ServerJSON], error) {
+	}, func(ctx context.Context, input *EditServerInput) (*ServerDetailOutput, error) {
+		// Mirrors only accept changes from their upstream registry
+		if cfg.MirrorUpstreamURL != "" {
+			return nil, huma.Error403Forbidden("This registry is a read-only mirror of " + cfg.MirrorUpstreamURL + "; edit servers there instead")
+		}
+
 		// Extract bearer token
 		const bearerPrefix = "Bearer "
 		authHeader := input.Authorization
@@ -76,17 +85,48 @@ func RegisterEditEndpoints(api huma.API, registry service.RegistryService, cfg *
 			return nil, huma.Error400BadRequest("Cannot change status of deleted server. Deleted servers cannot be undeleted.")
 		}
 
+		if err := checkPublishLimits(input.Body, cfg); err != nil {
+			return nil, err
+		}
+
+		// Require the revision the edit is based on, so concurrent edits don't silently overwrite each other
+		revision, err := parseIfMatch(input.IfMatch)
+		if err != nil {
+			return nil, err
+		}
+
 		// Edit the server
-		updatedServer, err := registry.EditServer(input.ID, input.Body)
+		updatedServer, err := registry.EditServer(input.ID, input.Body, revision)
 		if err != nil {
 			if errors.Is(err, database.ErrNotFound) {
 				return nil, huma.Error404NotFound("Server not found")
 			}
+			if errors.Is(err, database.ErrRevisionConflict) {
+				return nil, NewError(http.StatusConflict, "Server has been changed since it was read; get it again and reapply the edit", err)
+			}
 			return nil, huma.Error400BadRequest("Failed to edit server", err)
 		}
 
-		return &Response[apiv0.ServerJSON]{
+		return &ServerDetailOutput{
+			ETag: serverETag(updatedServer),
 			Body: *updatedServer,
 		}, nil
 	})
 }
+
+// parseIfMatch returns the revision named by an If-Match header, or 0 for "*"
+func parseIfMatch(ifMatch string) (int64, error) {
+	ifMatch = strings.TrimSpace(ifMatch)
+	switch ifMatch {
+	case "":
+		return 0, huma.NewError(http.StatusPreconditionRequired, "If-Match is required: send the ETag from getting the server, or * to overwrite any revision")
+	case "*":
+		return 0, nil
+	}
+
+	revision, err := strconv.ParseInt(strings.Trim(strings.TrimPrefix(ifMatch, "W/"), `"`), 10, 64)
+	if err != nil || revision <= 0 {
+		return 0, huma.Error400BadRequest("If-Match must be a server ETag or *")
+	}
+	return revision, nil
+}
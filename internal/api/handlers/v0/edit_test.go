@@ -10,6 +10,7 @@ import (
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/auth"
@@ -87,6 +88,7 @@ func TestEditServerEndpoint(t *testing.T) {
 	testCases := []struct {
 		name           string
 		authHeader     string
+		ifMatch        string
 		requestBody    interface{}
 		serverID       string
 		expectedStatus int
@@ -116,6 +118,7 @@ func TestEditServerEndpoint(t *testing.T) {
 				},
 				Version: "1.0.1",
 			},
+			ifMatch:        `"1"`,
 			serverID:       testServerID,
 			expectedStatus: http.StatusOK,
 		},
@@ -298,6 +301,9 @@ func TestEditServerEndpoint(t *testing.T) {
 			if tc.authHeader != "" {
 				req.Header.Set("Authorization", tc.authHeader)
 			}
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
 
 			// Create response recorder
 			w := httptest.NewRecorder()
@@ -317,3 +323,88 @@ func TestEditServerEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestEditServerRevisions(t *testing.T) {
+	registryService := service.NewRegistryService(database.NewMemoryDB(), config.NewConfig())
+	published, err := registryService.Publish(apiv0.ServerJSON{
+		Name:        "io.github.domdomegg/revised-server",
+		Description: "Original description",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+	id := published.Meta.Official.ID
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	cfg := &config.Config{JWTPrivateKey: "bb2c6b424005acd5df47a9e2c87f446def86dd740c888ea3efb825b23f7ef47c"}
+	v0.RegisterServersEndpoints(api, registryService)
+	v0.RegisterEditEndpoints(api, registryService, cfg)
+
+	token, err := generateTestJWTToken(cfg, auth.JWTClaims{
+		AuthMethod: auth.MethodGitHubAT,
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionEdit, ResourcePattern: "io.github.domdomegg/*"},
+		},
+	})
+	require.NoError(t, err)
+
+	edit := func(description, ifMatch string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(apiv0.ServerJSON{
+			Name:        "io.github.domdomegg/revised-server",
+			Description: description,
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPut, "/v0/servers/"+id, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	// Reading a server returns its revision as an ETag
+	w := doJSONRequest(t, mux, http.MethodGet, "/v0/servers/"+id, "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"1"`, w.Header().Get("ETag"))
+
+	t.Run("edits without a revision are rejected", func(t *testing.T) {
+		w := edit("No revision", "")
+		assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+	})
+
+	t.Run("edits at the current revision succeed", func(t *testing.T) {
+		w := edit("First edit", `"1"`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, `"2"`, w.Header().Get("ETag"))
+
+		var updated apiv0.ServerJSON
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+		assert.Equal(t, int64(2), updated.Meta.Official.Revision)
+		assert.Equal(t, id, updated.Meta.Official.ID)
+	})
+
+	t.Run("edits at a stale revision conflict", func(t *testing.T) {
+		w := edit("Stale edit", `"1"`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, v0.ErrorCodeRevisionConflict, problemCode(t, w))
+
+		got, err := registryService.GetByID(id)
+		require.NoError(t, err)
+		assert.Equal(t, "First edit", got.Description)
+	})
+
+	t.Run("a wildcard overwrites any revision", func(t *testing.T) {
+		w := edit("Forced edit", "*")
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	})
+
+	t.Run("malformed revisions are rejected", func(t *testing.T) {
+		w := edit("Malformed", `"abc"`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,206 @@
+package v0
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ErrorDocsURL documents every error code, each under an anchor of its lowercased code
+const ErrorDocsURL = "https://github.com/modelcontextprotocol/registry/blob/main/docs/reference/api/errors.md"
+
+// ErrorCode identifies the kind of an API error, so clients can branch on it rather than on the message.
+// Errors without a more specific code get one derived from their HTTP status, e.g. NOT_FOUND.
+type ErrorCode string
+
+const (
+	ErrorCodeNameInvalidFormat          ErrorCode = "NAME_INVALID_FORMAT"
+	ErrorCodeNameMultipleSlashes        ErrorCode = "NAME_MULTIPLE_SLASHES"
+	ErrorCodeNameBlocked                ErrorCode = "NAME_BLOCKED"
+	ErrorCodeNameTooSimilar             ErrorCode = "NAME_TOO_SIMILAR"
+	ErrorCodeNameConflict               ErrorCode = "NAME_CONFLICT"
+	ErrorCodeNamespaceInvalid           ErrorCode = "NAMESPACE_INVALID"
+	ErrorCodeNamespaceReserved          ErrorCode = "NAMESPACE_RESERVED"
+	ErrorCodeVersionInvalid             ErrorCode = "VERSION_INVALID"
+	ErrorCodeVersionNotSemantic         ErrorCode = "VERSION_NOT_SEMANTIC"
+	ErrorCodeVersionExists              ErrorCode = "VERSION_EXISTS"
+	ErrorCodeMaxVersionsReached         ErrorCode = "MAX_VERSIONS_REACHED"
+	ErrorCodeRepositoryInvalid          ErrorCode = "REPOSITORY_INVALID"
+	ErrorCodeRepositoryValidationFailed ErrorCode = "REPOSITORY_VALIDATION_FAILED"
+	ErrorCodeRepositoryOwnerMismatch    ErrorCode = "REPOSITORY_OWNER_MISMATCH"
+	ErrorCodePackageInvalid             ErrorCode = "PACKAGE_INVALID"
+	ErrorCodeRegistryValidationFailed   ErrorCode = "REGISTRY_VALIDATION_FAILED"
+	ErrorCodeProvenanceValidationFailed ErrorCode = "PROVENANCE_VALIDATION_FAILED"
+	ErrorCodeFileHashMismatch           ErrorCode = "FILE_HASH_MISMATCH"
+	ErrorCodeEnvironmentVariableInvalid ErrorCode = "ENVIRONMENT_VARIABLE_INVALID"
+	ErrorCodeTransportInvalid           ErrorCode = "TRANSPORT_INVALID"
+	ErrorCodeRemoteInvalid              ErrorCode = "REMOTE_INVALID"
+	ErrorCodeCategoryInvalid            ErrorCode = "CATEGORY_INVALID"
+	ErrorCodeTagInvalid                 ErrorCode = "TAG_INVALID"
+	ErrorCodeQuotaExceeded              ErrorCode = "QUOTA_EXCEEDED"
+	ErrorCodeScanRejected               ErrorCode = "SCAN_REJECTED"
+	ErrorCodeScanFailed                 ErrorCode = "SCAN_FAILED"
+	ErrorCodeIdempotencyKeyReused       ErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	ErrorCodeIdempotencyKeyInProgress   ErrorCode = "IDEMPOTENCY_KEY_IN_PROGRESS"
+	ErrorCodePublishQueueFull           ErrorCode = "PUBLISH_QUEUE_FULL"
+	ErrorCodeRevisionConflict           ErrorCode = "REVISION_CONFLICT"
+	ErrorCodeAPIKeyInvalid              ErrorCode = "API_KEY_INVALID"
+	ErrorCodeTooManyAPIKeys             ErrorCode = "TOO_MANY_API_KEYS"
+	ErrorCodeVersionRangeInvalid        ErrorCode = "VERSION_RANGE_INVALID"
+	ErrorCodeAdvisoryInvalid            ErrorCode = "ADVISORY_INVALID"
+	ErrorCodeTransferInvalid            ErrorCode = "TRANSFER_INVALID"
+	ErrorCodeUpstreamUnavailable        ErrorCode = "UPSTREAM_UNAVAILABLE"
+)
+
+// errorCodes maps the errors the registry returns to their codes. Wrapping errors come before the errors they
+// wrap, e.g. a registry validation failure is reported as such whatever made the upstream check fail.
+var errorCodes = []struct {
+	err  error
+	code ErrorCode
+}{
+	{validators.ErrRegistryValidationFailed, ErrorCodeRegistryValidationFailed},
+	{validators.ErrProvenanceValidationFailed, ErrorCodeProvenanceValidationFailed},
+	{validators.ErrMultipleSlashesInServerName, ErrorCodeNameMultipleSlashes},
+	{validators.ErrInvalidServerNameFormat, ErrorCodeNameInvalidFormat},
+	{validators.ErrBlockedServerName, ErrorCodeNameBlocked},
+	{validators.ErrInvalidNamespaceLabel, ErrorCodeNamespaceInvalid},
+	{validators.ErrMixedScriptNamespace, ErrorCodeNamespaceInvalid},
+	{validators.ErrReservedVersionString, ErrorCodeVersionInvalid},
+	{validators.ErrVersionLooksLikeRange, ErrorCodeVersionInvalid},
+	{validators.ErrInvalidRepositoryURL, ErrorCodeRepositoryInvalid},
+	{validators.ErrInvalidSubfolderPath, ErrorCodeRepositoryInvalid},
+	{validators.ErrRepositoryNotFound, ErrorCodeRepositoryValidationFailed},
+	{validators.ErrRepositoryNotPublic, ErrorCodeRepositoryValidationFailed},
+	{validators.ErrRepositoryOwnerMismatch, ErrorCodeRepositoryOwnerMismatch},
+	{validators.ErrPackageNameHasSpaces, ErrorCodePackageInvalid},
+	{validators.ErrInvalidPackageDigest, ErrorCodePackageInvalid},
+	{validators.ErrDigestRequiresOCI, ErrorCodePackageInvalid},
+	{validators.ErrInvalidFileSHA256, ErrorCodePackageInvalid},
+	{validators.ErrUnsupportedRegistryBaseURL, ErrorCodePackageInvalid},
+	{validators.ErrMismatchedRegistryTypeAndURL, ErrorCodePackageInvalid},
+	{validators.ErrNamedArgumentNameRequired, ErrorCodePackageInvalid},
+	{validators.ErrInvalidNamedArgumentName, ErrorCodePackageInvalid},
+	{validators.ErrArgumentValueStartsWithName, ErrorCodePackageInvalid},
+	{validators.ErrArgumentDefaultStartsWithName, ErrorCodePackageInvalid},
+	{validators.ErrInvalidEnvironmentVariableName, ErrorCodeEnvironmentVariableInvalid},
+	{validators.ErrDuplicateEnvironmentVariable, ErrorCodeEnvironmentVariableInvalid},
+	{validators.ErrEnvironmentVariableDescriptionRequired, ErrorCodeEnvironmentVariableInvalid},
+	{validators.ErrStdioTransportHeaders, ErrorCodeTransportInvalid},
+	{validators.ErrPackageRuntimeRequired, ErrorCodeTransportInvalid},
+	{validators.ErrInvalidRemoteURL, ErrorCodeRemoteInvalid},
+	{validators.ErrDuplicateRemoteURL, ErrorCodeRemoteInvalid},
+	{validators.ErrTooManyCategories, ErrorCodeCategoryInvalid},
+	{validators.ErrInvalidCategory, ErrorCodeCategoryInvalid},
+	{validators.ErrTooManyTags, ErrorCodeTagInvalid},
+	{validators.ErrInvalidTag, ErrorCodeTagInvalid},
+	{registries.ErrFileHashMismatch, ErrorCodeFileHashMismatch},
+	{registries.ErrCircuitOpen, ErrorCodeUpstreamUnavailable},
+	{service.ErrSimilarServerName, ErrorCodeNameTooSimilar},
+	{service.ErrServerNameConflict, ErrorCodeNameConflict},
+	{service.ErrNamespaceReserved, ErrorCodeNamespaceReserved},
+	{service.ErrNonSemanticVersion, ErrorCodeVersionNotSemantic},
+	{service.ErrNamespaceQuotaExceeded, ErrorCodeQuotaExceeded},
+	{service.ErrSuspiciousServer, ErrorCodeScanRejected},
+	{service.ErrScanFailed, ErrorCodeScanFailed},
+	{service.ErrIdempotencyKeyReused, ErrorCodeIdempotencyKeyReused},
+	{service.ErrIdempotencyKeyInProgress, ErrorCodeIdempotencyKeyInProgress},
+	{service.ErrPublishQueueFull, ErrorCodePublishQueueFull},
+	{service.ErrInvalidAPIKey, ErrorCodeAPIKeyInvalid},
+	{service.ErrTooManyAPIKeys, ErrorCodeTooManyAPIKeys},
+	{service.ErrInvalidVersionRange, ErrorCodeVersionRangeInvalid},
+	{service.ErrInvalidAdvisory, ErrorCodeAdvisoryInvalid},
+	{service.ErrInvalidTransfer, ErrorCodeTransferInvalid},
+	{database.ErrInvalidVersion, ErrorCodeVersionExists},
+	{database.ErrMaxServersReached, ErrorCodeMaxVersionsReached},
+	{database.ErrRevisionConflict, ErrorCodeRevisionConflict},
+}
+
+// ErrorModel is the body of every API error response: an RFC 9457 problem, whose type links to the
+// documentation of its code and whose instance is the request path. The code and errors are extension members.
+type ErrorModel struct {
+	huma.ErrorModel
+	Code           ErrorCode             `json:"code" example:"NAME_MULTIPLE_SLASHES" doc:"Machine-readable error code; see the type URI for the list of codes"`
+	ValidatorCalls []apiv0.ValidatorCall `json:"validator_calls,omitempty" doc:"Requests validators sent upstream, for publishes with debug=true only"`
+}
+
+// NewError builds API errors, replacing huma.NewError. The code is that of the first of errs, or the errors
+// they wrap, with one; otherwise it is derived from the status.
+func NewError(status int, msg string, errs ...error) huma.StatusError {
+	model := &ErrorModel{ErrorModel: huma.ErrorModel{
+		Status: status,
+		Title:  http.StatusText(status),
+		Detail: msg,
+	}}
+	for _, err := range errs {
+		if err != nil {
+			model.Add(err)
+		}
+	}
+	model.Code = errorCode(status, errs)
+	model.Type = ErrorDocsURL + "#" + strings.ToLower(string(model.Code))
+	return model
+}
+
+// ProblemTransformer sets the instance of error responses to the path of the request that failed
+func ProblemTransformer(ctx huma.Context, _ string, v any) (any, error) {
+	if model, ok := v.(*ErrorModel); ok && model.Instance == "" {
+		model.Instance = ctx.URL().Path
+	}
+	return v, nil
+}
+
+// WriteProblem writes an error response from outside huma, in the same form as the API's own errors
+func WriteProblem(w http.ResponseWriter, r *http.Request, status int, detail string) {
+	model, _ := NewError(status, detail).(*ErrorModel)
+	model.Instance = r.URL.Path
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(model)
+}
+
+// errorCode returns the code of the first error in errs with one, or the code of status
+func errorCode(status int, errs []error) ErrorCode {
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		for _, entry := range errorCodes {
+			if errors.Is(err, entry.err) {
+				return entry.code
+			}
+		}
+	}
+	return StatusErrorCode(status)
+}
+
+// StatusErrorCode returns the generic code of an HTTP error status, e.g. NOT_FOUND for 404
+func StatusErrorCode(status int) ErrorCode {
+	return ErrorCode(strings.ToUpper(strings.ReplaceAll(http.StatusText(status), " ", "_")))
+}
+
+// causedDetail is an error detail that keeps the error it describes, so the response gets that error's code
+type causedDetail struct {
+	detail *huma.ErrorDetail
+	cause  error
+}
+
+func (d causedDetail) Error() string {
+	return d.detail.Error()
+}
+
+func (d causedDetail) ErrorDetail() *huma.ErrorDetail {
+	return d.detail
+}
+
+func (d causedDetail) Unwrap() error {
+	return d.cause
+}
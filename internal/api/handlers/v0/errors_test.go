@@ -0,0 +1,154 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewError(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		errs         []error
+		expectedCode v0.ErrorCode
+	}{
+		{
+			name:         "wrapped validation error",
+			status:       http.StatusBadRequest,
+			errs:         []error{fmt.Errorf("invalid name: %w", validators.ErrMultipleSlashesInServerName)},
+			expectedCode: v0.ErrorCodeNameMultipleSlashes,
+		},
+		{
+			name:         "registry validation failure wrapping an upstream error",
+			status:       http.StatusBadRequest,
+			errs:         []error{fmt.Errorf("%w for package 0: %w", validators.ErrRegistryValidationFailed, database.ErrNotFound)},
+			expectedCode: v0.ErrorCodeRegistryValidationFailed,
+		},
+		{
+			name:         "service error",
+			status:       http.StatusBadRequest,
+			errs:         []error{service.ErrNamespaceQuotaExceeded},
+			expectedCode: v0.ErrorCodeQuotaExceeded,
+		},
+		{
+			name:         "error without a code",
+			status:       http.StatusNotFound,
+			errs:         []error{fmt.Errorf("no such server")},
+			expectedCode: "NOT_FOUND",
+		},
+		{
+			name:         "no errors",
+			status:       http.StatusServiceUnavailable,
+			expectedCode: "SERVICE_UNAVAILABLE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v0.NewError(tt.status, "Request failed", tt.errs...)
+			model, ok := err.(*v0.ErrorModel)
+			require.True(t, ok)
+			assert.Equal(t, tt.status, model.GetStatus())
+			assert.Equal(t, tt.expectedCode, model.Code)
+			assert.Equal(t, v0.ErrorDocsURL+"#"+strings.ToLower(string(tt.expectedCode)), model.Type)
+			assert.Len(t, model.Errors, len(tt.errs))
+		})
+	}
+}
+
+// problemCode returns the code of an error response
+func problemCode(t *testing.T, rr *httptest.ResponseRecorder) v0.ErrorCode {
+	t.Helper()
+	var problem v0.ErrorModel
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+	return problem.Code
+}
+
+func TestPublishEndpoint_ErrorCodes(t *testing.T) {
+	original := huma.NewError
+	huma.NewError = v0.NewError
+	t.Cleanup(func() { huma.NewError = original })
+
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)}
+
+	mux := http.NewServeMux()
+	humaConfig := huma.DefaultConfig("Test API", "1.0.0")
+	humaConfig.Transformers = append(humaConfig.Transformers, v0.ProblemTransformer)
+	api := humago.New(mux, humaConfig)
+	v0.RegisterPublishEndpoint(api, service.NewRegistryService(database.NewMemoryDB(), testConfig), testConfig)
+
+	token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod:  auth.MethodNone,
+		Permissions: []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "*"}},
+	})
+	require.NoError(t, err)
+
+	body, err := json.Marshal(apiv0.ServerJSON{
+		Name:        "com.example/server",
+		Description: "Test server",
+		Version:     "latest",
+		WebsiteURL:  "not-a-url",
+	})
+	require.NoError(t, err)
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/publish", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "application/problem+json", rr.Header().Get("Content-Type"))
+
+	var problem v0.ErrorModel
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+	assert.Equal(t, v0.ErrorCodeVersionInvalid, problem.Code)
+	assert.Equal(t, "/v0/publish", problem.Instance)
+	require.Len(t, problem.Errors, 2)
+	assert.Equal(t, "body.version", problem.Errors[0].Location)
+	assert.Equal(t, "body.website_url", problem.Errors[1].Location)
+}
+
+func TestWriteProblem(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/tenants/unknown/v0/servers", nil)
+	rr := httptest.NewRecorder()
+	v0.WriteProblem(rr, req, http.StatusNotFound, "Unknown tenant: unknown")
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "application/problem+json", rr.Header().Get("Content-Type"))
+
+	var problem map[string]any
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+	assert.Equal(t, map[string]any{
+		"type":     v0.ErrorDocsURL + "#not_found",
+		"title":    "Not Found",
+		"status":   float64(http.StatusNotFound),
+		"detail":   "Unknown tenant: unknown",
+		"instance": "/tenants/unknown/v0/servers",
+		"code":     "NOT_FOUND",
+	}, problem)
+}
@@ -0,0 +1,225 @@
+package v0
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/sse"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const (
+	eventsPollInterval      = time.Second
+	eventsHeartbeatInterval = 15 * time.Second
+	eventsPageSize          = 500
+	// eventsSubscriberBuffer is how far a client may fall behind before it is disconnected.
+	// Disconnected clients reconnect with Last-Event-ID and catch up from the change feed.
+	eventsSubscriberBuffer = 256
+)
+
+// Each change type is a distinct Go type so the SSE event name can be derived from it
+type (
+	PublishEvent   apiv0.ChangeEvent
+	UpdateEvent    apiv0.ChangeEvent
+	DeprecateEvent apiv0.ChangeEvent
+	DeleteEvent    apiv0.ChangeEvent
+)
+
+// HeartbeatEvent is sent periodically so clients and proxies keep idle connections open
+type HeartbeatEvent struct {
+	Sequence int64 `json:"sequence" doc:"Sequence number of the last event sent on this connection"`
+}
+
+// EventsInput represents the input for subscribing to registry events
+type EventsInput struct {
+	Since       int64  `query:"since" doc:"Replay changes with a sequence number greater than this before streaming live events" default:"0" minimum:"0"`
+	LastEventID string `header:"Last-Event-ID" doc:"Sequence number of the last event received; sent automatically by EventSource clients when reconnecting, and takes precedence over since"`
+}
+
+// RegisterEventsEndpoint registers the server-sent events stream of registry changes
+func RegisterEventsEndpoint(api huma.API, registry service.RegistryService) {
+	hub := newChangeHub(registry)
+
+	sse.Register(api, huma.Operation{
+		OperationID: "stream-events",
+		Method:      http.MethodGet,
+		Path:        "/v0/events",
+		Summary:     "Stream registry events",
+		Description: "Stream publish, update, deprecate and delete events as server-sent events. Event IDs are change feed sequence numbers, so reconnecting clients resume where they left off.",
+		Tags:        []string{"changes"},
+	}, map[string]any{
+		string(apiv0.ChangeTypePublish):   PublishEvent{},
+		string(apiv0.ChangeTypeUpdate):    UpdateEvent{},
+		string(apiv0.ChangeTypeDeprecate): DeprecateEvent{},
+		string(apiv0.ChangeTypeDelete):    DeleteEvent{},
+		"heartbeat":                       HeartbeatEvent{},
+	}, func(ctx context.Context, input *EventsInput, send sse.Sender) {
+		last := input.Since
+		if id, err := strconv.ParseInt(input.LastEventID, 10, 64); err == nil && id >= 0 {
+			last = id
+		}
+
+		// Subscribe before replaying so nothing published during the replay is missed
+		events, unsubscribe := hub.subscribe()
+		defer unsubscribe()
+
+		for {
+			changes, nextSince, err := registry.ListChanges(last, eventsPageSize)
+			if err != nil {
+				log.Printf("Failed to replay registry events: %v", err)
+				return
+			}
+			for _, change := range changes {
+				if err := sendChangeEvent(send, change); err != nil {
+					return
+				}
+			}
+			last = nextSince
+			if len(changes) < eventsPageSize {
+				break
+			}
+		}
+
+		heartbeat := time.NewTicker(eventsHeartbeatInterval)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case change, ok := <-events:
+				if !ok {
+					// The client fell too far behind
+					return
+				}
+				if change.Sequence <= last {
+					continue // Already sent during the replay
+				}
+				if err := sendChangeEvent(send, change); err != nil {
+					return
+				}
+				last = change.Sequence
+			case <-heartbeat.C:
+				if err := send.Data(HeartbeatEvent{Sequence: last}); err != nil {
+					return
+				}
+			}
+		}
+	})
+}
+
+func sendChangeEvent(send sse.Sender, change apiv0.ChangeEvent) error {
+	var data any
+	switch change.Type {
+	case apiv0.ChangeTypePublish:
+		data = PublishEvent(change)
+	case apiv0.ChangeTypeDeprecate:
+		data = DeprecateEvent(change)
+	case apiv0.ChangeTypeDelete:
+		data = DeleteEvent(change)
+	default:
+		data = UpdateEvent(change)
+	}
+	return send(sse.Message{ID: int(change.Sequence), Data: data})
+}
+
+// changeHub polls the change feed once for all connected clients and fans new changes out to them.
+// Polling the shared feed, rather than hooking local writes, means clients see changes made through any replica.
+type changeHub struct {
+	registry    service.RegistryService
+	start       sync.Once
+	mu          sync.Mutex
+	subscribers map[chan apiv0.ChangeEvent]struct{}
+	position    int64
+}
+
+func newChangeHub(registry service.RegistryService) *changeHub {
+	return &changeHub{
+		registry:    registry,
+		subscribers: make(map[chan apiv0.ChangeEvent]struct{}),
+	}
+}
+
+// subscribe registers a new subscriber, returning its channel and a function to unsubscribe
+func (h *changeHub) subscribe() (<-chan apiv0.ChangeEvent, func()) {
+	// The hub starts lazily, skipping existing history so it only broadcasts new changes.
+	// Priming completes before anyone subscribes, so subscribers' replays cover everything up to the hub's position.
+	h.start.Do(func() {
+		h.poll(false)
+		go h.run()
+	})
+
+	ch := make(chan apiv0.ChangeEvent, eventsSubscriberBuffer)
+	h.mu.Lock()
+	h.subscribers[ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subscribers[ch]; ok {
+			delete(h.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+func (h *changeHub) run() {
+	ticker := time.NewTicker(eventsPollInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		h.poll(true)
+	}
+}
+
+// poll reads changes after the hub's position, optionally broadcasting them to subscribers
+func (h *changeHub) poll(broadcast bool) {
+	for {
+		changes, nextSince, err := h.registry.ListChanges(h.position, eventsPageSize)
+		if err != nil {
+			log.Printf("Failed to poll registry events: %v", err)
+			return
+		}
+
+		if broadcast {
+			h.broadcast(changes)
+		}
+		h.position = nextSince
+
+		if len(changes) < eventsPageSize {
+			return
+		}
+	}
+}
+
+func (h *changeHub) broadcast(changes []apiv0.ChangeEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subscribers {
+		if !deliverChanges(ch, changes) {
+			// Drop slow subscribers rather than blocking everyone else
+			delete(h.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// deliverChanges queues changes for a subscriber without blocking, reporting whether they all fit
+func deliverChanges(ch chan apiv0.ChangeEvent, changes []apiv0.ChangeEvent) bool {
+	for _, change := range changes {
+		select {
+		case ch <- change:
+		default:
+			return false
+		}
+	}
+	return true
+}
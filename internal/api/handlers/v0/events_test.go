@@ -0,0 +1,133 @@
+package v0_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+type sseEvent struct {
+	id    string
+	event string
+	data  apiv0.ChangeEvent
+}
+
+// readSSEEvents parses events from an SSE stream onto a channel until the stream ends
+func readSSEEvents(t *testing.T, resp *http.Response) <-chan sseEvent {
+	t.Helper()
+	events := make(chan sseEvent, 16)
+	go func() {
+		defer close(events)
+		scanner := bufio.NewScanner(resp.Body)
+		var current sseEvent
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "id: "):
+				current.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				current.event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				_ = json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &current.data)
+			case line == "":
+				events <- current
+				current = sseEvent{}
+			}
+		}
+	}()
+	return events
+}
+
+func nextSSEEvent(t *testing.T, events <-chan sseEvent) sseEvent {
+	t.Helper()
+	select {
+	case event, ok := <-events:
+		require.True(t, ok, "event stream closed")
+		return event
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for event")
+		return sseEvent{}
+	}
+}
+
+func TestEventsEndpoint(t *testing.T) {
+	registryService := service.NewRegistryService(database.NewMemoryDB(), &config.Config{EnableRegistryValidation: false})
+	publish := func(version string) {
+		_, err := registryService.Publish(apiv0.ServerJSON{
+			Name:        "com.example/events-server",
+			Description: "A server with events",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+	publish("1.0.0")
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterEventsEndpoint(api, registryService)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	connect := func(t *testing.T, lastEventID string) <-chan sseEvent {
+		t.Helper()
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/v0/events", nil)
+		require.NoError(t, err)
+		if lastEventID != "" {
+			req.Header.Set("Last-Event-ID", lastEventID)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		return readSSEEvents(t, resp)
+	}
+
+	t.Run("replays history then streams live events", func(t *testing.T) {
+		events := connect(t, "")
+
+		event := nextSSEEvent(t, events)
+		assert.Equal(t, "1", event.id)
+		assert.Equal(t, "publish", event.event)
+		assert.Equal(t, "1.0.0", event.data.Server.Version)
+
+		publish("1.1.0")
+
+		event = nextSSEEvent(t, events)
+		assert.Equal(t, "2", event.id)
+		assert.Equal(t, "publish", event.event)
+		assert.Equal(t, "1.1.0", event.data.Server.Version)
+
+		event = nextSSEEvent(t, events)
+		assert.Equal(t, "3", event.id)
+		assert.Equal(t, "update", event.event)
+	})
+
+	t.Run("resumes from Last-Event-ID", func(t *testing.T) {
+		events := connect(t, "2")
+
+		event := nextSSEEvent(t, events)
+		assert.Equal(t, "3", event.id)
+		assert.Equal(t, "update", event.event)
+	})
+}
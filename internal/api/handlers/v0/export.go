@@ -0,0 +1,243 @@
+package v0
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+const exportPageSize = 1000
+
+// exportWriterPool holds the write buffers of streamed exports, so concurrent downloads reuse a fixed
+// amount of memory each instead of holding the whole snapshot
+var exportWriterPool = sync.Pool{
+	New: func() any { return bufio.NewWriterSize(nil, 64<<10) },
+}
+
+// ExportOutput represents the registry snapshot export response
+type ExportOutput struct {
+	ContentType     string `header:"Content-Type"`
+	SnapshotVersion string `header:"X-Registry-Snapshot-Version" doc:"Most recent updated_at of any exported server, usable as updated_since for incremental sync"`
+	ServerCount     string `header:"X-Registry-Snapshot-Count" doc:"Number of servers in the snapshot"`
+	Signature       string `header:"X-Registry-Signature" doc:"Base64 Ed25519 signature of the response body, when export signing is configured"`
+	Body            []byte
+}
+
+// ExportPublicKeyBody describes the key used to sign registry snapshots
+type ExportPublicKeyBody struct {
+	Algorithm string `json:"algorithm" doc:"Signature algorithm" example:"ed25519"`
+	PublicKey string `json:"public_key" doc:"Base64-encoded public key"`
+}
+
+// RegisterExportEndpoints registers the bulk registry export endpoints
+func RegisterExportEndpoints(api huma.API, registry service.RegistryService, cfg *config.Config) {
+	// Snapshots are only signed when a key is configured
+	var signingKey ed25519.PrivateKey
+	if cfg.ExportSigningKey != "" {
+		var err error
+		signingKey, err = parseExportSigningKey(cfg.ExportSigningKey)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "export-registry",
+		Method:      http.MethodGet,
+		Path:        "/v0/export",
+		Summary:     "Export registry snapshot",
+		Description: "Export every server version as newline-delimited JSON, with a detached signature of the body, so mirrors and offline consumers can bootstrap in a single request",
+		Tags:        []string{"export"},
+	}, func(_ context.Context, _ *struct{}) (*ExportOutput, error) {
+		body, count, version, err := buildExportSnapshot(registry)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to export registry", err)
+		}
+
+		output := &ExportOutput{
+			ContentType:     "application/x-ndjson",
+			SnapshotVersion: version,
+			ServerCount:     strconv.Itoa(count),
+			Body:            body,
+		}
+		if signingKey != nil {
+			output.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(signingKey, body))
+		}
+		return output, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "stream-export-registry",
+		Method:      http.MethodGet,
+		Path:        "/v0/export/stream",
+		Summary:     "Stream registry snapshot",
+		Description: "Stream every server version as newline-delimited JSON with chunked transfer encoding, page by page. The snapshot version, count and signature are sent as HTTP trailers once the body is complete; the signature is Ed25519ph over the SHA-512 digest of the body.",
+		Tags:        []string{"export"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "Newline-delimited JSON snapshot, followed by the X-Registry-Snapshot-Version, X-Registry-Snapshot-Count and X-Registry-Signature trailers",
+				Content:     map[string]*huma.MediaType{"application/x-ndjson": {}},
+			},
+		},
+	}, func(_ context.Context, _ *struct{}) (*huma.StreamResponse, error) {
+		return &huma.StreamResponse{
+			Body: func(ctx huma.Context) {
+				streamExportSnapshot(ctx, registry, signingKey)
+			},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-export-public-key",
+		Method:      http.MethodGet,
+		Path:        "/v0/export/public-key",
+		Summary:     "Get export signing key",
+		Description: "Get the public key used to sign registry snapshots returned by /v0/export",
+		Tags:        []string{"export"},
+		Errors:      []int{http.StatusNotFound},
+	}, func(_ context.Context, _ *struct{}) (*Response[ExportPublicKeyBody], error) {
+		if signingKey == nil {
+			return nil, huma.Error404NotFound("Export signing is not configured")
+		}
+
+		publicKey, _ := signingKey.Public().(ed25519.PublicKey)
+		return &Response[ExportPublicKeyBody]{
+			Body: ExportPublicKeyBody{
+				Algorithm: "ed25519",
+				PublicKey: base64.StdEncoding.EncodeToString(publicKey),
+			},
+		}, nil
+	})
+}
+
+// buildExportSnapshot encodes the whole snapshot in memory, so the signature can be sent up front
+func buildExportSnapshot(registry service.RegistryService) ([]byte, int, string, error) {
+	var buf bytes.Buffer
+	count, version, err := writeExportSnapshot(&buf, registry, nil)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	return buf.Bytes(), count, version, nil
+}
+
+// streamExportSnapshot writes the snapshot to the client as it is read, one page at a time. The status
+// has been sent by the time a page fails, so errors are logged and the trailers left out, which tells
+// the client the snapshot is incomplete.
+func streamExportSnapshot(ctx huma.Context, registry service.RegistryService, signingKey ed25519.PrivateKey) {
+	ctx.SetHeader("Content-Type", "application/x-ndjson")
+	ctx.SetHeader("Trailer", "X-Registry-Snapshot-Version, X-Registry-Snapshot-Count, X-Registry-Signature")
+	ctx.SetStatus(http.StatusOK)
+
+	body := ctx.BodyWriter()
+	buf, _ := exportWriterPool.Get().(*bufio.Writer)
+	buf.Reset(body)
+	defer func() {
+		buf.Reset(nil)
+		exportWriterPool.Put(buf)
+	}()
+
+	// The body is never held in full, so it is signed with the pre-hashed Ed25519 variant
+	digest := sha512.New()
+	var w io.Writer = buf
+	if signingKey != nil {
+		w = io.MultiWriter(buf, digest)
+	}
+
+	count, version, err := writeExportSnapshot(w, registry, func() error {
+		if err := buf.Flush(); err != nil {
+			return err
+		}
+		if flusher, ok := body.(http.Flusher); ok {
+			flusher.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Failed to stream registry export: %v", err)
+		return
+	}
+
+	ctx.SetHeader("X-Registry-Snapshot-Version", version)
+	ctx.SetHeader("X-Registry-Snapshot-Count", strconv.Itoa(count))
+	if signingKey != nil {
+		signature, err := signingKey.Sign(nil, digest.Sum(nil), &ed25519.Options{Hash: crypto.SHA512})
+		if err != nil {
+			log.Printf("Failed to sign registry export: %v", err)
+			return
+		}
+		ctx.SetHeader("X-Registry-Signature", base64.StdEncoding.EncodeToString(signature))
+	}
+}
+
+// writeExportSnapshot pages through every server version and encodes one JSON document per line to w,
+// calling flush, if set, after each page. It returns the number of records and the snapshot version.
+func writeExportSnapshot(w io.Writer, registry service.RegistryService, flush func() error) (int, string, error) {
+	encoder := json.NewEncoder(w)
+
+	var (
+		count     int
+		updatedAt time.Time
+		cursor    string
+	)
+	for {
+		servers, nextCursor, err := registry.List(nil, cursor, exportPageSize)
+		if err != nil {
+			return 0, "", err
+		}
+
+		for _, server := range servers {
+			if err := encoder.Encode(server); err != nil {
+				return 0, "", err
+			}
+			count++
+			if server.Meta != nil && server.Meta.Official != nil && server.Meta.Official.UpdatedAt.After(updatedAt) {
+				updatedAt = server.Meta.Official.UpdatedAt
+			}
+		}
+		if flush != nil {
+			if err := flush(); err != nil {
+				return 0, "", err
+			}
+		}
+
+		if nextCursor == "" || len(servers) == 0 {
+			break
+		}
+		cursor = nextCursor
+	}
+
+	var version string
+	if !updatedAt.IsZero() {
+		version = updatedAt.UTC().Format(time.RFC3339Nano)
+	}
+	return count, version, nil
+}
+
+// parseExportSigningKey decodes a hex-encoded Ed25519 seed, in the same format as JWTPrivateKey
+func parseExportSigningKey(hexSeed string) (ed25519.PrivateKey, error) {
+	seed, err := hex.DecodeString(hexSeed)
+	if err != nil {
+		return nil, fmt.Errorf("ExportSigningKey must be a valid hex-encoded string: %w", err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("ExportSigningKey seed must be exactly %d bytes for Ed25519, got %d bytes", ed25519.SeedSize, len(seed))
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
@@ -0,0 +1,177 @@
+package v0_test
+
+import (
+	"bufio"
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const testExportSigningKey = "bb2c6b424005acd5df47a9e2c87f446def86dd740c888ea3efb825b23f7ef47c"
+
+func TestExportEndpoint(t *testing.T) {
+	cfg := &config.Config{EnableRegistryValidation: false, ExportSigningKey: testExportSigningKey}
+	registryService := service.NewRegistryService(database.NewMemoryDB(), cfg)
+
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		_, err := registryService.Publish(apiv0.ServerJSON{
+			Name:        "com.example/export-server",
+			Description: "An exported server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterExportEndpoints(api, registryService, cfg)
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/export", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	assert.Equal(t, "2", w.Header().Get("X-Registry-Snapshot-Count"))
+	assert.NotEmpty(t, w.Header().Get("X-Registry-Snapshot-Version"))
+
+	var versions []string
+	scanner := bufio.NewScanner(bytes.NewReader(w.Body.Bytes()))
+	for scanner.Scan() {
+		var server apiv0.ServerJSON
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &server))
+		versions = append(versions, server.Version)
+	}
+	assert.ElementsMatch(t, []string{"1.0.0", "1.1.0"}, versions)
+
+	// The signature verifies against the published key
+	keyReq := httptest.NewRequest(http.MethodGet, "/v0/export/public-key", nil)
+	keyW := httptest.NewRecorder()
+	mux.ServeHTTP(keyW, keyReq)
+	require.Equal(t, http.StatusOK, keyW.Code)
+
+	var key v0.ExportPublicKeyBody
+	require.NoError(t, json.Unmarshal(keyW.Body.Bytes(), &key))
+	assert.Equal(t, "ed25519", key.Algorithm)
+
+	publicKey, err := base64.StdEncoding.DecodeString(key.PublicKey)
+	require.NoError(t, err)
+	signature, err := base64.StdEncoding.DecodeString(w.Header().Get("X-Registry-Signature"))
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(publicKey, w.Body.Bytes(), signature))
+}
+
+func TestExportEndpoint_Unsigned(t *testing.T) {
+	cfg := &config.Config{}
+	registryService := service.NewRegistryService(database.NewMemoryDB(), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterExportEndpoints(api, registryService, cfg)
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/export", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "0", w.Header().Get("X-Registry-Snapshot-Count"))
+	assert.Empty(t, w.Header().Get("X-Registry-Signature"))
+	assert.Empty(t, w.Body.Bytes())
+
+	keyReq := httptest.NewRequest(http.MethodGet, "/v0/export/public-key", nil)
+	keyW := httptest.NewRecorder()
+	mux.ServeHTTP(keyW, keyReq)
+	assert.Equal(t, http.StatusNotFound, keyW.Code)
+}
+
+func TestExportStreamEndpoint(t *testing.T) {
+	cfg := &config.Config{EnableRegistryValidation: false, ExportSigningKey: testExportSigningKey}
+	registryService := service.NewRegistryService(database.NewMemoryDB(), cfg)
+
+	for _, version := range []string{"1.0.0", "1.1.0", "2.0.0"} {
+		_, err := registryService.Publish(apiv0.ServerJSON{
+			Name:        "com.example/streamed-server",
+			Description: "A streamed server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterExportEndpoints(api, registryService, cfg)
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/export/stream", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "application/x-ndjson", res.Header.Get("Content-Type"))
+
+	// The snapshot metadata arrives as trailers, after the body
+	assert.Empty(t, res.Header.Get("X-Registry-Snapshot-Count"))
+	assert.Equal(t, "3", res.Trailer.Get("X-Registry-Snapshot-Count"))
+	assert.NotEmpty(t, res.Trailer.Get("X-Registry-Snapshot-Version"))
+
+	// The body matches the buffered export
+	bufferedReq := httptest.NewRequest(http.MethodGet, "/v0/export", nil)
+	bufferedW := httptest.NewRecorder()
+	mux.ServeHTTP(bufferedW, bufferedReq)
+	require.Equal(t, http.StatusOK, bufferedW.Code)
+	assert.Equal(t, bufferedW.Body.String(), w.Body.String())
+	assert.Equal(t, bufferedW.Header().Get("X-Registry-Snapshot-Version"), res.Trailer.Get("X-Registry-Snapshot-Version"))
+
+	// The signature is Ed25519ph over the SHA-512 digest of the body
+	publicKey, _ := ed25519.NewKeyFromSeed(mustDecodeHex(t, testExportSigningKey)).Public().(ed25519.PublicKey)
+	signature, err := base64.StdEncoding.DecodeString(res.Trailer.Get("X-Registry-Signature"))
+	require.NoError(t, err)
+	digest := sha512.Sum512(w.Body.Bytes())
+	assert.NoError(t, ed25519.VerifyWithOptions(publicKey, digest[:], signature, &ed25519.Options{Hash: crypto.SHA512}))
+}
+
+func TestExportStreamEndpoint_Unsigned(t *testing.T) {
+	cfg := &config.Config{}
+	registryService := service.NewRegistryService(database.NewMemoryDB(), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterExportEndpoints(api, registryService, cfg)
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/export/stream", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "0", res.Trailer.Get("X-Registry-Snapshot-Count"))
+	assert.Empty(t, res.Trailer.Get("X-Registry-Signature"))
+	assert.Empty(t, w.Body.Bytes())
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
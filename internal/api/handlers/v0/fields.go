@@ -0,0 +1,112 @@
+package v0
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// serverFields are the top-level server.json fields that can be selected with ?fields=
+var serverFields = jsonFieldNames(reflect.TypeFor[apiv0.ServerJSON]())
+
+// jsonFieldNames returns the JSON names of a struct type's fields
+func jsonFieldNames(t reflect.Type) []string {
+	names := make([]string, 0, t.NumField())
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// parseFields splits a comma-separated ?fields= value, rejecting fields servers don't have. It returns nil
+// when no fields are selected.
+func parseFields(raw string) ([]string, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	var fields []string
+	for field := range strings.SplitSeq(raw, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !slices.Contains(serverFields, field) {
+			return nil, huma.Error400BadRequest("Unknown field " + field + "; selectable fields are " + strings.Join(serverFields, ", "))
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// SelectFieldsTransformer trims the servers in successful read responses down to the fields named in the
+// request's ?fields= parameter. Handlers accepting the parameter validate it with parseFields first.
+func SelectFieldsTransformer(ctx huma.Context, status string, v any) (any, error) {
+	if ctx.Method() != http.MethodGet || !strings.HasPrefix(status, "2") {
+		return v, nil
+	}
+	fields, err := parseFields(ctx.Query("fields"))
+	if err != nil || fields == nil {
+		return v, nil //nolint:nilerr // handlers have already rejected invalid fields
+	}
+
+	switch body := v.(type) {
+	case apiv0.ServerJSON:
+		return selectServerFields(&body, fields)
+	case apiv0.ServerListResponse:
+		servers, err := selectServerListFields(body.Servers, fields)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"servers": servers, "metadata": body.Metadata}, nil
+	case ServerVersionListResponse:
+		versions, err := selectServerListFields(body.Versions, fields)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"versions": versions}, nil
+	default:
+		return v, nil
+	}
+}
+
+// selectServerListFields applies selectServerFields to each server
+func selectServerListFields(servers []apiv0.ServerJSON, fields []string) ([]map[string]json.RawMessage, error) {
+	selected := make([]map[string]json.RawMessage, len(servers))
+	for i := range servers {
+		server, err := selectServerFields(&servers[i], fields)
+		if err != nil {
+			return nil, err
+		}
+		selected[i] = server
+	}
+	return selected, nil
+}
+
+// selectServerFields returns the given fields of a server as JSON, omitting any that are empty
+func selectServerFields(server *apiv0.ServerJSON, fields []string) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(server)
+	if err != nil {
+		return nil, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		if value, ok := all[field]; ok {
+			selected[field] = value
+		}
+	}
+	return selected, nil
+}
@@ -0,0 +1,504 @@
+package v0
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// graphQLSchema is the read-only GraphQL view of the registry data model
+const graphQLSchema = `
+schema {
+	query: Query
+}
+
+scalar Time
+
+type Query {
+	# Servers in the same order and with the same filters as GET /v0/servers; pass pageInfo.endCursor as after for the next page
+	servers(
+		first: Int = 30
+		after: String
+		search: String
+		version: String
+		transport: String
+		registryType: String
+		category: String
+		tag: String
+		updatedSince: Time
+	): ServerConnection!
+	# A server version by its registry ID, or null if there is none
+	server(id: ID!): Server
+	# Every version of a server, highest first, optionally within an npm-style semver range
+	serverVersions(name: String!, range: String): [Server!]!
+}
+
+type ServerConnection {
+	nodes: [Server!]!
+	pageInfo: PageInfo!
+}
+
+type PageInfo {
+	endCursor: String
+	hasNextPage: Boolean!
+}
+
+type Server {
+	id: ID
+	name: String!
+	description: String!
+	status: String
+	version: String!
+	websiteUrl: String
+	repository: Repository
+	packages: [Package!]!
+	remotes: [Transport!]!
+	categories: [String!]!
+	tags: [String!]!
+	isLatest: Boolean!
+	publishedAt: Time
+	updatedAt: Time
+}
+
+type Repository {
+	url: String!
+	source: String!
+	id: String
+	subfolder: String
+}
+
+type Package {
+	registryType: String!
+	registryBaseUrl: String
+	identifier: String!
+	version: String!
+	fileSha256: String
+	digest: String
+	runtimeHint: String
+	transport: Transport!
+	runtimeArguments: [Argument!]!
+	packageArguments: [Argument!]!
+	environmentVariables: [KeyValueInput!]!
+}
+
+type Transport {
+	type: String!
+	url: String
+	headers: [KeyValueInput!]!
+}
+
+type Argument {
+	type: String!
+	name: String
+	valueHint: String
+	isRepeated: Boolean!
+	description: String
+	isRequired: Boolean!
+	format: String
+	value: String
+	isSecret: Boolean!
+	default: String
+	choices: [String!]!
+}
+
+type KeyValueInput {
+	name: String!
+	description: String
+	isRequired: Boolean!
+	format: String
+	value: String
+	isSecret: Boolean!
+	default: String
+	choices: [String!]!
+}
+`
+
+// Bounds on GraphQL queries, so nested selections can't be used to make arbitrarily expensive requests
+const (
+	graphQLMaxDepth       = 10
+	graphQLMaxQueryLength = 16 * 1024
+	graphQLMaxPageSize    = 100
+)
+
+// GraphQLRequest is a GraphQL query with its operation name and variables
+type GraphQLRequest struct {
+	Query         string         `json:"query" doc:"GraphQL query document" minLength:"1"`
+	OperationName string         `json:"operationName,omitempty" doc:"Operation to run, if the document has several"`
+	Variables     map[string]any `json:"variables,omitempty" doc:"Values for the query's variables"`
+}
+
+// GraphQLQueryInput represents a GraphQL query sent in the query string
+type GraphQLQueryInput struct {
+	Query         string `query:"query" doc:"GraphQL query document" required:"true" example:"{ servers(first: 5, version: \"latest\") { nodes { name packages { identifier transport { type } } } } }"`
+	OperationName string `query:"operationName" doc:"Operation to run, if the document has several" required:"false"`
+	Variables     string `query:"variables" doc:"JSON object of values for the query's variables" required:"false"`
+}
+
+// GraphQLBodyInput represents a GraphQL query sent as a JSON body
+type GraphQLBodyInput struct {
+	Body GraphQLRequest
+}
+
+// GraphQLResponse is the result of a GraphQL query; query errors are reported in errors with a 200 status
+type GraphQLResponse struct {
+	Data   json.RawMessage `json:"data,omitempty"`
+	Errors []GraphQLError  `json:"errors,omitempty"`
+}
+
+// GraphQLError is an error from parsing, validating or executing a GraphQL query
+type GraphQLError struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+// RegisterGraphQLEndpoint registers the read-only GraphQL endpoint
+func RegisterGraphQLEndpoint(api huma.API, registry service.RegistryService) {
+	schema := graphql.MustParseSchema(graphQLSchema, &graphQLResolver{registry: registry},
+		graphql.MaxDepth(graphQLMaxDepth),
+		graphql.MaxQueryLength(graphQLMaxQueryLength),
+	)
+
+	description := "Run a read-only GraphQL query over servers, their packages and transports, with cursor pagination. The schema is available through introspection."
+
+	huma.Register(api, huma.Operation{
+		OperationID: "graphql-query",
+		Method:      http.MethodGet,
+		Path:        "/graphql",
+		Summary:     "Query the registry with GraphQL",
+		Description: description,
+		Tags:        []string{"graphql"},
+		Errors:      []int{http.StatusBadRequest},
+	}, func(ctx context.Context, input *GraphQLQueryInput) (*Response[GraphQLResponse], error) {
+		var variables map[string]any
+		if input.Variables != "" {
+			if err := json.Unmarshal([]byte(input.Variables), &variables); err != nil {
+				return nil, huma.Error400BadRequest("variables must be a JSON object", err)
+			}
+		}
+		return execGraphQL(ctx, schema, GraphQLRequest{Query: input.Query, OperationName: input.OperationName, Variables: variables}), nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "graphql-query-post",
+		Method:      http.MethodPost,
+		Path:        "/graphql",
+		Summary:     "Query the registry with GraphQL (POST)",
+		Description: description,
+		Tags:        []string{"graphql"},
+	}, func(ctx context.Context, input *GraphQLBodyInput) (*Response[GraphQLResponse], error) {
+		return execGraphQL(ctx, schema, input.Body), nil
+	})
+}
+
+// execGraphQL runs a query against the schema
+func execGraphQL(ctx context.Context, schema *graphql.Schema, req GraphQLRequest) *Response[GraphQLResponse] {
+	result := schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	response := GraphQLResponse{Data: result.Data}
+	for _, err := range result.Errors {
+		response.Errors = append(response.Errors, GraphQLError{Message: err.Message, Path: err.Path})
+	}
+	return &Response[GraphQLResponse]{Body: response}
+}
+
+// graphQLResolver resolves the root Query type
+type graphQLResolver struct {
+	registry service.RegistryService
+}
+
+type serversArgs struct {
+	First        int32
+	After        *string
+	Search       *string
+	Version      *string
+	Transport    *string
+	RegistryType *string
+	Category     *string
+	Tag          *string
+	UpdatedSince *graphql.Time
+}
+
+func (r *graphQLResolver) Servers(args serversArgs) (*serverConnectionResolver, error) {
+	if args.First < 1 || args.First > graphQLMaxPageSize {
+		return nil, fmt.Errorf("first must be between 1 and %d", graphQLMaxPageSize)
+	}
+
+	cursor := ""
+	if args.After != nil {
+		if _, err := uuid.Parse(*args.After); err != nil {
+			return nil, errors.New("invalid after cursor")
+		}
+		cursor = *args.After
+	}
+
+	filter := &database.ServerFilter{
+		SubstringName: args.Search,
+		Transport:     args.Transport,
+		RegistryType:  args.RegistryType,
+		Category:      args.Category,
+		Tag:           args.Tag,
+	}
+	if args.UpdatedSince != nil {
+		filter.UpdatedSince = &args.UpdatedSince.Time
+	}
+	if args.Version != nil {
+		if *args.Version == "latest" {
+			isLatest := true
+			filter.IsLatest = &isLatest
+		} else {
+			filter.Version = args.Version
+		}
+	}
+
+	servers, nextCursor, err := r.registry.List(filter, cursor, int(args.First))
+	if err != nil {
+		return nil, errors.New("failed to get registry list")
+	}
+	return &serverConnectionResolver{servers: servers, nextCursor: nextCursor}, nil
+}
+
+func (r *graphQLResolver) Server(args struct{ ID graphql.ID }) (*serverResolver, error) {
+	server, err := r.registry.GetByID(string(args.ID))
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, errors.New("failed to get server details")
+	}
+	return &serverResolver{server: *server}, nil
+}
+
+func (r *graphQLResolver) ServerVersions(args struct {
+	Name  string
+	Range *string
+}) ([]*serverResolver, error) {
+	versionRange := ""
+	if args.Range != nil {
+		versionRange = *args.Range
+	}
+
+	versions, err := r.registry.ListServerVersions(args.Name, versionRange)
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return []*serverResolver{}, nil
+	case errors.Is(err, service.ErrInvalidVersionRange):
+		return nil, err
+	case err != nil:
+		return nil, errors.New("failed to get server versions")
+	}
+	return serverResolvers(versions), nil
+}
+
+type serverConnectionResolver struct {
+	servers    []apiv0.ServerJSON
+	nextCursor string
+}
+
+func (r *serverConnectionResolver) Nodes() []*serverResolver {
+	return serverResolvers(r.servers)
+}
+
+func (r *serverConnectionResolver) PageInfo() *pageInfoResolver {
+	return &pageInfoResolver{endCursor: r.nextCursor}
+}
+
+type pageInfoResolver struct {
+	endCursor string
+}
+
+func (r *pageInfoResolver) EndCursor() *string { return optionalString(r.endCursor) }
+func (r *pageInfoResolver) HasNextPage() bool  { return r.endCursor != "" }
+
+type serverResolver struct {
+	server apiv0.ServerJSON
+}
+
+func serverResolvers(servers []apiv0.ServerJSON) []*serverResolver {
+	resolvers := make([]*serverResolver, len(servers))
+	for i, server := range servers {
+		resolvers[i] = &serverResolver{server: server}
+	}
+	return resolvers
+}
+
+func (r *serverResolver) official() *apiv0.RegistryExtensions {
+	if r.server.Meta == nil {
+		return nil
+	}
+	return r.server.Meta.Official
+}
+
+func (r *serverResolver) ID() *graphql.ID {
+	if official := r.official(); official != nil {
+		id := graphql.ID(official.ID)
+		return &id
+	}
+	return nil
+}
+
+func (r *serverResolver) Name() string        { return r.server.Name }
+func (r *serverResolver) Description() string { return r.server.Description }
+func (r *serverResolver) Status() *string     { return optionalString(string(r.server.Status)) }
+func (r *serverResolver) Version() string     { return r.server.Version }
+func (r *serverResolver) WebsiteURL() *string { return optionalString(r.server.WebsiteURL) }
+func (r *serverResolver) Categories() []string {
+	return nonNilStrings(r.server.Categories)
+}
+func (r *serverResolver) Tags() []string { return nonNilStrings(r.server.Tags) }
+
+func (r *serverResolver) Repository() *repositoryResolver {
+	if r.server.Repository == (model.Repository{}) {
+		return nil
+	}
+	return &repositoryResolver{repository: r.server.Repository}
+}
+
+func (r *serverResolver) Packages() []*packageResolver {
+	resolvers := make([]*packageResolver, len(r.server.Packages))
+	for i, pkg := range r.server.Packages {
+		resolvers[i] = &packageResolver{pkg: pkg}
+	}
+	return resolvers
+}
+
+func (r *serverResolver) Remotes() []*transportResolver {
+	resolvers := make([]*transportResolver, len(r.server.Remotes))
+	for i, transport := range r.server.Remotes {
+		resolvers[i] = &transportResolver{transport: transport}
+	}
+	return resolvers
+}
+
+func (r *serverResolver) IsLatest() bool {
+	official := r.official()
+	return official != nil && official.IsLatest
+}
+
+func (r *serverResolver) PublishedAt() *graphql.Time {
+	if official := r.official(); official != nil && !official.PublishedAt.IsZero() {
+		return &graphql.Time{Time: official.PublishedAt}
+	}
+	return nil
+}
+
+func (r *serverResolver) UpdatedAt() *graphql.Time {
+	if official := r.official(); official != nil && !official.UpdatedAt.IsZero() {
+		return &graphql.Time{Time: official.UpdatedAt}
+	}
+	return nil
+}
+
+type repositoryResolver struct {
+	repository model.Repository
+}
+
+func (r *repositoryResolver) URL() string        { return r.repository.URL }
+func (r *repositoryResolver) Source() string     { return r.repository.Source }
+func (r *repositoryResolver) ID() *string        { return optionalString(r.repository.ID) }
+func (r *repositoryResolver) Subfolder() *string { return optionalString(r.repository.Subfolder) }
+
+type packageResolver struct {
+	pkg model.Package
+}
+
+func (r *packageResolver) RegistryType() string     { return r.pkg.RegistryType }
+func (r *packageResolver) RegistryBaseURL() *string { return optionalString(r.pkg.RegistryBaseURL) }
+func (r *packageResolver) Identifier() string       { return r.pkg.Identifier }
+func (r *packageResolver) Version() string          { return r.pkg.Version }
+func (r *packageResolver) FileSha256() *string      { return optionalString(r.pkg.FileSHA256) }
+func (r *packageResolver) Digest() *string          { return optionalString(r.pkg.Digest) }
+func (r *packageResolver) RuntimeHint() *string     { return optionalString(r.pkg.RunTimeHint) }
+func (r *packageResolver) Transport() *transportResolver {
+	return &transportResolver{transport: r.pkg.Transport}
+}
+func (r *packageResolver) RuntimeArguments() []*argumentResolver {
+	return argumentResolvers(r.pkg.RuntimeArguments)
+}
+func (r *packageResolver) PackageArguments() []*argumentResolver {
+	return argumentResolvers(r.pkg.PackageArguments)
+}
+func (r *packageResolver) EnvironmentVariables() []*keyValueInputResolver {
+	return keyValueInputResolvers(r.pkg.EnvironmentVariables)
+}
+
+type transportResolver struct {
+	transport model.Transport
+}
+
+func (r *transportResolver) Type() string { return r.transport.Type }
+func (r *transportResolver) URL() *string { return optionalString(r.transport.URL) }
+func (r *transportResolver) Headers() []*keyValueInputResolver {
+	return keyValueInputResolvers(r.transport.Headers)
+}
+
+// inputResolver resolves the fields shared by arguments and key-value inputs
+type inputResolver struct {
+	input model.Input
+}
+
+func (r *inputResolver) Description() *string { return optionalString(r.input.Description) }
+func (r *inputResolver) IsRequired() bool     { return r.input.IsRequired }
+func (r *inputResolver) Format() *string      { return optionalString(string(r.input.Format)) }
+func (r *inputResolver) Value() *string       { return optionalString(r.input.Value) }
+func (r *inputResolver) IsSecret() bool       { return r.input.IsSecret }
+func (r *inputResolver) Default() *string     { return optionalString(r.input.Default) }
+func (r *inputResolver) Choices() []string    { return nonNilStrings(r.input.Choices) }
+
+type argumentResolver struct {
+	inputResolver
+	argument model.Argument
+}
+
+func argumentResolvers(arguments []model.Argument) []*argumentResolver {
+	resolvers := make([]*argumentResolver, len(arguments))
+	for i, argument := range arguments {
+		resolvers[i] = &argumentResolver{inputResolver: inputResolver{input: argument.Input}, argument: argument}
+	}
+	return resolvers
+}
+
+func (r *argumentResolver) Type() string       { return string(r.argument.Type) }
+func (r *argumentResolver) Name() *string      { return optionalString(r.argument.Name) }
+func (r *argumentResolver) ValueHint() *string { return optionalString(r.argument.ValueHint) }
+func (r *argumentResolver) IsRepeated() bool   { return r.argument.IsRepeated }
+
+type keyValueInputResolver struct {
+	inputResolver
+	name string
+}
+
+func keyValueInputResolvers(inputs []model.KeyValueInput) []*keyValueInputResolver {
+	resolvers := make([]*keyValueInputResolver, len(inputs))
+	for i, input := range inputs {
+		resolvers[i] = &keyValueInputResolver{inputResolver: inputResolver{input: input.Input}, name: input.Name}
+	}
+	return resolvers
+}
+
+func (r *keyValueInputResolver) Name() string { return r.name }
+
+// optionalString returns nil for an empty string, so unset fields resolve to null
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// nonNilStrings returns an empty slice in place of nil, for non-null list fields
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
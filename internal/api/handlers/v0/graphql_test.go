@@ -0,0 +1,169 @@
+package v0_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestGraphQLEndpoint(t *testing.T) {
+	registryService := service.NewRegistryService(database.NewMemoryDB(), &config.Config{EnableRegistryValidation: false})
+
+	var published *apiv0.ServerJSON
+	for _, name := range []string{"com.example/alpha", "com.example/bravo", "com.example/charlie"} {
+		server, err := registryService.Publish(apiv0.ServerJSON{
+			Name:        name,
+			Description: "A GraphQL test server",
+			Version:     "1.0.0",
+			Packages: []model.Package{{
+				RegistryType: model.RegistryTypeNPM,
+				Identifier:   "@example/server",
+				Version:      "1.0.0",
+				Transport:    model.Transport{Type: "stdio"},
+				EnvironmentVariables: []model.KeyValueInput{{
+					Name:               "API_KEY",
+					InputWithVariables: model.InputWithVariables{Input: model.Input{Description: "API key for the service", IsRequired: true, IsSecret: true}},
+				}},
+			}},
+		})
+		require.NoError(t, err)
+		if published == nil {
+			published = server
+		}
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterGraphQLEndpoint(api, registryService)
+
+	query := func(t *testing.T, query string, variables map[string]any) v0.GraphQLResponse {
+		t.Helper()
+		body, err := json.Marshal(v0.GraphQLRequest{Query: query, Variables: variables})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp v0.GraphQLResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp
+	}
+
+	t.Run("nested query with cursor pagination", func(t *testing.T) {
+		const serversQuery = `query($after: String) {
+			servers(first: 2, after: $after) {
+				nodes { name packages { identifier transport { type } environmentVariables { name isSecret } } }
+				pageInfo { endCursor hasNextPage }
+			}
+		}`
+		type page struct {
+			Servers struct {
+				Nodes []struct {
+					Name     string `json:"name"`
+					Packages []struct {
+						Identifier string `json:"identifier"`
+						Transport  struct {
+							Type string `json:"type"`
+						} `json:"transport"`
+						EnvironmentVariables []struct {
+							Name     string `json:"name"`
+							IsSecret bool   `json:"isSecret"`
+						} `json:"environmentVariables"`
+					} `json:"packages"`
+				} `json:"nodes"`
+				PageInfo struct {
+					EndCursor   *string `json:"endCursor"`
+					HasNextPage bool    `json:"hasNextPage"`
+				} `json:"pageInfo"`
+			} `json:"servers"`
+		}
+
+		resp := query(t, serversQuery, nil)
+		require.Empty(t, resp.Errors)
+		var first page
+		require.NoError(t, json.Unmarshal(resp.Data, &first))
+		require.Len(t, first.Servers.Nodes, 2)
+		assert.Equal(t, "stdio", first.Servers.Nodes[0].Packages[0].Transport.Type)
+		assert.Equal(t, "API_KEY", first.Servers.Nodes[0].Packages[0].EnvironmentVariables[0].Name)
+		assert.True(t, first.Servers.Nodes[0].Packages[0].EnvironmentVariables[0].IsSecret)
+		require.True(t, first.Servers.PageInfo.HasNextPage)
+
+		resp = query(t, serversQuery, map[string]any{"after": *first.Servers.PageInfo.EndCursor})
+		require.Empty(t, resp.Errors)
+		var second page
+		require.NoError(t, json.Unmarshal(resp.Data, &second))
+		require.Len(t, second.Servers.Nodes, 1)
+		assert.False(t, second.Servers.PageInfo.HasNextPage)
+
+		names := []string{first.Servers.Nodes[0].Name, first.Servers.Nodes[1].Name, second.Servers.Nodes[0].Name}
+		assert.ElementsMatch(t, []string{"com.example/alpha", "com.example/bravo", "com.example/charlie"}, names)
+	})
+
+	t.Run("server by id", func(t *testing.T) {
+		resp := query(t, `query($id: ID!) { server(id: $id) { name version isLatest repository { url } } }`,
+			map[string]any{"id": published.Meta.Official.ID})
+		require.Empty(t, resp.Errors)
+		assert.JSONEq(t, `{"server": {"name": "com.example/alpha", "version": "1.0.0", "isLatest": true, "repository": null}}`, string(resp.Data))
+
+		resp = query(t, `{ server(id: "00000000-0000-0000-0000-000000000000") { name } }`, nil)
+		require.Empty(t, resp.Errors)
+		assert.JSONEq(t, `{"server": null}`, string(resp.Data))
+	})
+
+	t.Run("server versions", func(t *testing.T) {
+		resp := query(t, `{ serverVersions(name: "com.example/bravo", range: "^1.0") { version } }`, nil)
+		require.Empty(t, resp.Errors)
+		assert.JSONEq(t, `{"serverVersions": [{"version": "1.0.0"}]}`, string(resp.Data))
+	})
+
+	t.Run("errors are reported in the response", func(t *testing.T) {
+		resp := query(t, `{ servers(first: 1000) { nodes { name } } }`, nil)
+		require.Len(t, resp.Errors, 1)
+		assert.Contains(t, resp.Errors[0].Message, "first must be between 1 and 100")
+
+		resp = query(t, `{ servers { nodes { unknownField } } }`, nil)
+		require.NotEmpty(t, resp.Errors)
+	})
+
+	t.Run("mutations are not supported", func(t *testing.T) {
+		resp := query(t, `mutation { publish(name: "com.example/delta") { name } }`, nil)
+		require.NotEmpty(t, resp.Errors)
+	})
+
+	t.Run("GET", func(t *testing.T) {
+		params := url.Values{
+			"query":     {`query($name: String!) { serverVersions(name: $name) { name } }`},
+			"variables": {`{"name": "com.example/charlie"}`},
+		}
+		req := httptest.NewRequest(http.MethodGet, "/graphql?"+params.Encode(), nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp v0.GraphQLResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.JSONEq(t, `{"serverVersions": [{"name": "com.example/charlie"}]}`, string(resp.Data))
+
+		params.Set("variables", "not json")
+		req = httptest.NewRequest(http.MethodGet, "/graphql?"+params.Encode(), nil)
+		w = httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
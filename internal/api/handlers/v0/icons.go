@@ -0,0 +1,171 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// iconContentSecurityPolicy stops an SVG icon opened directly in a browser from running scripts or loading anything
+const iconContentSecurityPolicy = "default-src 'none'; style-src 'unsafe-inline'; sandbox"
+
+// UploadServerIconInput represents the input for uploading a server's icon
+type UploadServerIconInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token (obtained from /v0/auth/token/github) or API key" required:"true"`
+	Name          string `path:"name" doc:"Server name (e.g. io.github.user/weather)"`
+	ContentType   string `header:"Content-Type" doc:"image/png or image/svg+xml; must match the uploaded image"`
+	RawBody       []byte `contentType:"image/*"`
+}
+
+// DeleteServerIconInput represents the input for removing a server's icon
+type DeleteServerIconInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token (obtained from /v0/auth/token/github) or API key" required:"true"`
+	Name          string `path:"name" doc:"Server name (e.g. io.github.user/weather)"`
+}
+
+// GetIconInput represents the input for fetching an icon image
+type GetIconInput struct {
+	File string `path:"file" doc:"Icon file name, as linked from a server's icon metadata" pattern:"^[0-9a-f]{64}\\.(png|svg)$"`
+}
+
+// IconOutput is an icon image
+type IconOutput struct {
+	ContentType           string `header:"Content-Type"`
+	CacheControl          string `header:"Cache-Control"`
+	ContentSecurityPolicy string `header:"Content-Security-Policy"`
+	Body                  []byte
+}
+
+// RegisterIconEndpoints registers the server icon upload and serving endpoints
+func RegisterIconEndpoints(api huma.API, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID:  "upload-server-icon",
+		Method:       http.MethodPut,
+		Path:         "/v0/servers/{name}/icon",
+		Summary:      "Upload an MCP server icon",
+		Description:  "Upload a PNG (at most 1024x1024) or SVG icon for every version of a server, replacing any previous one. PNGs are re-encoded and SVGs stripped of scripts and external references. Requires publish permission for the server.",
+		Tags:         []string{"servers"},
+		MaxBodyBytes: validators.MaxIconSize,
+		Errors:       []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusRequestEntityTooLarge},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *UploadServerIconInput) (*Response[apiv0.IconExtensions], error) {
+		claims, err := authorizeIconChange(ctx, jwtManager, registry, cfg, input.Authorization, input.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		icon, err := registry.SetServerIcon(claimsOwner(claims), input.Name, input.RawBody, input.ContentType)
+		if err != nil {
+			return nil, iconError(err)
+		}
+
+		return &Response[apiv0.IconExtensions]{
+			Body: *icon,
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-server-icon",
+		Method:        http.MethodDelete,
+		Path:          "/v0/servers/{name}/icon",
+		Summary:       "Remove an MCP server icon",
+		Description:   "Remove a server's icon. Requires publish permission for the server.",
+		Tags:          []string{"servers"},
+		DefaultStatus: http.StatusNoContent,
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *DeleteServerIconInput) (*struct{}, error) {
+		if _, err := authorizeIconChange(ctx, jwtManager, registry, cfg, input.Authorization, input.Name); err != nil {
+			return nil, err
+		}
+
+		if err := registry.DeleteServerIcon(input.Name); err != nil {
+			return nil, iconError(err)
+		}
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-icon",
+		Method:      http.MethodGet,
+		Path:        "/v0/icons/{file}",
+		Summary:     "Get an MCP server icon",
+		Description: "Get an uploaded icon image. Icon URLs are content-addressed, so responses can be cached indefinitely.",
+		Tags:        []string{"servers"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "Icon image",
+				Content: map[string]*huma.MediaType{
+					"image/png":     {},
+					"image/svg+xml": {},
+				},
+			},
+		},
+	}, func(_ context.Context, input *GetIconInput) (*IconOutput, error) {
+		data, contentType, err := registry.GetIcon(input.File)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Icon not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get icon", err)
+		}
+
+		return &IconOutput{
+			ContentType:           contentType,
+			CacheControl:          "public, max-age=31536000, immutable",
+			ContentSecurityPolicy: iconContentSecurityPolicy,
+			Body:                  data,
+		}, nil
+	})
+}
+
+// authorizeIconChange checks the caller may publish the named server, which must exist
+func authorizeIconChange(ctx context.Context, jwtManager *auth.JWTManager, registry service.RegistryService, cfg *config.Config, authHeader, name string) (*auth.JWTClaims, error) {
+	if err := rejectOnMirror(cfg); err != nil {
+		return nil, err
+	}
+
+	server, err := registry.GetLatestServerVersion(name, "")
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return nil, huma.Error404NotFound("Server not found")
+		}
+		return nil, huma.Error500InternalServerError("Failed to get server", err)
+	}
+
+	claims, redeem, err := authorizePublish(ctx, jwtManager, registry, cfg, authHeader, "", *server)
+	if err != nil {
+		return nil, err
+	}
+	// An upload token is used up by the first change it authorizes
+	redeem()
+	return claims, nil
+}
+
+// iconError maps icon errors to HTTP errors
+func iconError(err error) error {
+	switch {
+	case errors.Is(err, validators.ErrIconTooLarge):
+		return huma.NewError(http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, validators.ErrInvalidIcon):
+		return huma.Error400BadRequest(err.Error())
+	case errors.Is(err, database.ErrNotFound):
+		return huma.Error404NotFound("Server has no icon")
+	default:
+		return huma.Error500InternalServerError("Failed to update icon", err)
+	}
+}
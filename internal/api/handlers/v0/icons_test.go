@@ -0,0 +1,115 @@
+package v0_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerIconEndpoints(t *testing.T) {
+	testConfig := &config.Config{
+		JWTPrivateKey: "bb2c6b424005acd5df47a9e2c87f446def86dd740c888ea3efb825b23f7ef47c",
+	}
+	registryService := service.NewRegistryService(database.NewMemoryDB(), testConfig)
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService)
+	v0.RegisterPublishEndpoint(api, registryService, testConfig)
+	v0.RegisterIconEndpoints(api, registryService, testConfig)
+
+	tokenFor := func(user string) string {
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: user,
+			Permissions: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: "io.github." + user + "/*"},
+			},
+		})
+		require.NoError(t, err)
+		return token
+	}
+	owner := tokenFor("owner")
+
+	rr := doJSONRequest(t, mux, http.MethodPost, "/v0/publish", owner, apiv0.ServerJSON{
+		Name: "io.github.owner/server", Description: "A server", Version: "1.0.0",
+	})
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	upload := func(token, contentType string, data []byte) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/v0/servers/io.github.owner%2Fserver/icon", bytes.NewReader(data))
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 32, 32))))
+
+	t.Run("rejects other publishers", func(t *testing.T) {
+		rr := upload(tokenFor("someone"), "image/png", buf.Bytes())
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("rejects invalid images", func(t *testing.T) {
+		rr := upload(owner, "image/png", []byte("not an image"))
+		assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+	})
+
+	t.Run("upload and serve", func(t *testing.T) {
+		rr := upload(owner, "image/png", buf.Bytes())
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var icon apiv0.IconExtensions
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &icon))
+		assert.Equal(t, "image/png", icon.ContentType)
+		assert.Equal(t, "/v0/icons/"+icon.SHA256+".png", icon.URL)
+
+		rr = doJSONRequest(t, mux, http.MethodGet, icon.URL, "", nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "image/png", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Header().Get("Cache-Control"), "immutable")
+		_, err := png.Decode(rr.Body)
+		require.NoError(t, err)
+
+		// The icon is linked from every version of the server
+		rr = doJSONRequest(t, mux, http.MethodGet, "/v0/servers?search=io.github.owner/server", "", nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+		var list apiv0.ServerListResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &list))
+		require.Len(t, list.Servers, 1)
+		require.NotNil(t, list.Servers[0].Meta.Icon)
+		assert.Equal(t, icon.URL, list.Servers[0].Meta.Icon.URL)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		rr := doJSONRequest(t, mux, http.MethodDelete, "/v0/servers/io.github.owner%2Fserver/icon", owner, nil)
+		assert.Equal(t, http.StatusNoContent, rr.Code, rr.Body.String())
+
+		rr = doJSONRequest(t, mux, http.MethodDelete, "/v0/servers/io.github.owner%2Fserver/icon", owner, nil)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("unknown icons and servers", func(t *testing.T) {
+		rr := doJSONRequest(t, mux, http.MethodGet, "/v0/icons/"+string(bytes.Repeat([]byte("a"), 64))+".svg", "", nil)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		rr = doJSONRequest(t, mux, http.MethodDelete, "/v0/servers/io.github.owner%2Fmissing/icon", owner, nil)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
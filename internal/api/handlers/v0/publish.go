@@ -2,6 +2,8 @@ package v0
 
 import (
 	"context"
+	"errors"
+	"log"
 	"net/http"
 	"strings"
 
@@ -9,61 +11,385 @@ import (
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 )
 
 // PublishServerInput represents the input for publishing a server
 type PublishServerInput struct {
-	Authorization string           `header:"Authorization" doc:"Registry JWT token (obtained from /v0/auth/token/github)" required:"true"`
-	Body          apiv0.ServerJSON `body:""`
+	Authorization    string           `header:"Authorization" doc:"Registry JWT token (obtained from /v0/auth/token/github) or API key" required:"true"`
+	DryRun           bool             `query:"dry_run" doc:"Run all validations and return the would-be record without publishing it" default:"false"`
+	VerifyFileHashes bool             `query:"verify_file_hashes" doc:"With dry_run, also download NPM and MCPB package files to verify their hashes, as a publish does when the registry records file hashes" default:"false"`
+	Debug            bool             `query:"debug" doc:"Include the requests validators sent upstream (URL, status, duration and the start of error bodies) in the response, whether or not the publish succeeds" default:"false"`
+	IdempotencyKey   string           `header:"Idempotency-Key" doc:"Unique key for this publish; retrying the same request with the same key within 24 hours returns the original response instead of publishing again" maxLength:"255"`
+	Body             apiv0.ServerJSON `body:""`
 }
 
-// RegisterPublishEndpoint registers the publish endpoint
+// PublishServerOutput represents the response to a publish
+type PublishServerOutput struct {
+	IdempotentReplayed string           `header:"Idempotent-Replayed" doc:"\"true\" when this is the stored response to an earlier request with the same Idempotency-Key"`
+	Body               apiv0.ServerJSON `body:""`
+}
+
+// AsyncPublishServerInput represents the input for publishing a server asynchronously
+type AsyncPublishServerInput struct {
+	Authorization  string           `header:"Authorization" doc:"Registry JWT token (obtained from /v0/auth/token/github) or API key" required:"true"`
+	IdempotencyKey string           `header:"Idempotency-Key" doc:"Unique key for this publish; retrying the same request with the same key while its job is kept returns the original job instead of queueing another, unless that job failed" maxLength:"255"`
+	Body           apiv0.ServerJSON `body:""`
+}
+
+// AsyncPublishServerOutput represents the response to an asynchronous publish
+type AsyncPublishServerOutput struct {
+	IdempotentReplayed string             `header:"Idempotent-Replayed" doc:"\"true\" when this is the job queued by an earlier request with the same Idempotency-Key"`
+	Body               service.PublishJob `body:""`
+}
+
+// PublishStatusInput represents the input for getting the status of an async publish
+type PublishStatusInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token or API key that submitted the job" required:"true"`
+	ID            string `path:"id" doc:"Publish job ID (UUID)" format:"uuid"`
+}
+
+// RegisterPublishEndpoint registers the publish endpoints
 func RegisterPublishEndpoint(api huma.API, registry service.RegistryService, cfg *config.Config) {
 	// Create JWT manager for token validation
 	jwtManager := auth.NewJWTManager(cfg)
 
 	huma.Register(api, huma.Operation{
-		OperationID: "publish-server",
-		Method:      http.MethodPost,
-		Path:        "/v0/publish",
-		Summary:     "Publish MCP server",
-		Description: "Publish a new MCP server to the registry or update an existing one",
-		Tags:        []string{"publish"},
+		OperationID:  "publish-server",
+		Method:       http.MethodPost,
+		Path:         "/v0/publish",
+		Summary:      "Publish MCP server",
+		Description:  "Publish a new MCP server to the registry or update an existing one. Set dry_run=true to validate without publishing.",
+		Tags:         []string{"publish"},
+		MaxBodyBytes: publishMaxBodyBytes(cfg),
+		Errors:       []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusConflict, http.StatusRequestEntityTooLarge},
 		Security: []map[string][]string{
 			{"bearer": {}},
 		},
-	}, func(ctx context.Context, input *PublishServerInput) (*Response[apiv0.ServerJSON], error) {
-		// Extract bearer token
-		const bearerPrefix = "Bearer "
-		authHeader := input.Authorization
-		if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
-			return nil, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
+	}, func(ctx context.Context, input *PublishServerInput) (*PublishServerOutput, error) {
+		claims, published, err := authorizePublish(ctx, jwtManager, registry, cfg, input.Authorization, input.IdempotencyKey, input.Body)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkPublishLimits(input.Body, cfg); err != nil {
+			return nil, err
 		}
-		token := authHeader[len(bearerPrefix):]
 
-		// Validate Registry JWT token
-		claims, err := jwtManager.ValidateToken(ctx, token)
-		if err != nil {
-			return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+		// In debug mode, report the requests validators sent upstream so publishers can see why they failed
+		if input.Debug {
+			if input.IdempotencyKey != "" {
+				return nil, huma.Error400BadRequest("debug can't be combined with an Idempotency-Key")
+			}
+			message := "Failed to publish server"
+			if input.DryRun {
+				message = "Failed to validate server"
+			}
+			server, calls, err := registry.PublishTraced(input.Body, input.DryRun, input.VerifyFileHashes)
+			if err != nil {
+				return nil, withValidatorCalls(publishFailed(message, err), calls)
+			}
+			if !input.DryRun {
+				published()
+			}
+			return &PublishServerOutput{
+				Body: *server,
+			}, nil
+		}
+
+		// In dry-run mode, validate and return the would-be record without persisting it
+		if input.DryRun {
+			validatedServer, err := registry.PublishDryRun(input.Body, input.VerifyFileHashes)
+			if err != nil {
+				return nil, publishFailed("Failed to validate server", err)
+			}
+			return &PublishServerOutput{
+				Body: *validatedServer,
+			}, nil
 		}
 
-		// Verify that the token has permission to publish the server
-		if !jwtManager.HasPermission(input.Body.Name, auth.PermissionActionPublish, claims.Permissions) {
-			return nil, huma.Error403Forbidden(buildPermissionErrorMessage(input.Body.Name, claims.Permissions))
+		// With an idempotency key, retries of a publish return its original response
+		if input.IdempotencyKey != "" {
+			publishedServer, replayed, err := registry.PublishIdempotent(claimsOwner(claims), input.IdempotencyKey, input.Body)
+			if err != nil {
+				switch {
+				case errors.Is(err, service.ErrIdempotencyKeyReused):
+					return nil, NewError(http.StatusUnprocessableEntity, "Idempotency-Key was already used for a different request", err)
+				case errors.Is(err, service.ErrIdempotencyKeyInProgress):
+					return nil, NewError(http.StatusConflict, "A publish with this Idempotency-Key is still in progress; retry later", err)
+				}
+				return nil, publishFailed("Failed to publish server", err)
+			}
+			output := &PublishServerOutput{Body: *publishedServer}
+			if replayed {
+				output.IdempotentReplayed = "true"
+			} else {
+				published()
+			}
+			return output, nil
 		}
 
 		// Publish the server with extensions
 		publishedServer, err := registry.Publish(input.Body)
 		if err != nil {
-			return nil, huma.Error400BadRequest("Failed to publish server", err)
+			return nil, publishFailed("Failed to publish server", err)
 		}
+		published()
 
 		// Return the published server in flattened format
-		return &Response[apiv0.ServerJSON]{
+		return &PublishServerOutput{
 			Body: *publishedServer,
 		}, nil
 	})
+
+	// Async publish endpoint - registry validation runs in the background
+	huma.Register(api, huma.Operation{
+		OperationID:   "publish-server-async",
+		Method:        http.MethodPost,
+		Path:          "/v0/publish/async",
+		Summary:       "Publish MCP server asynchronously",
+		Description:   "Queue a server for publishing and return immediately with a job ID. Poll /v0/publish/status/{id} for the result of registry validation and publishing.",
+		Tags:          []string{"publish"},
+		DefaultStatus: http.StatusAccepted,
+		MaxBodyBytes:  publishMaxBodyBytes(cfg),
+		Errors:        []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusRequestEntityTooLarge, http.StatusUnprocessableEntity, http.StatusServiceUnavailable},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *AsyncPublishServerInput) (*AsyncPublishServerOutput, error) {
+		claims, published, err := authorizePublish(ctx, jwtManager, registry, cfg, input.Authorization, input.IdempotencyKey, input.Body)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkPublishLimits(input.Body, cfg); err != nil {
+			return nil, err
+		}
+
+		job, replayed, err := registry.PublishAsync(claimsOwner(claims), input.IdempotencyKey, input.Body, published)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrPublishQueueFull):
+				return nil, NewError(http.StatusServiceUnavailable, "Failed to queue server for publishing", err)
+			case errors.Is(err, service.ErrIdempotencyKeyReused):
+				return nil, NewError(http.StatusUnprocessableEntity, "Idempotency-Key was already used for a different request", err)
+			}
+			return nil, huma.Error400BadRequest("Failed to publish server", err)
+		}
+
+		output := &AsyncPublishServerOutput{Body: *job}
+		if replayed {
+			output.IdempotentReplayed = "true"
+		}
+		return output, nil
+	})
+
+	// Async publish status endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "get-publish-status",
+		Method:      http.MethodGet,
+		Path:        "/v0/publish/status/{id}",
+		Summary:     "Get async publish status",
+		Description: "Get the status of an asynchronous publish job. Jobs are only visible to the identity that submitted them.",
+		Tags:        []string{"publish"},
+		Errors:      []int{http.StatusUnauthorized, http.StatusNotFound},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *PublishStatusInput) (*Response[service.PublishJob], error) {
+		claims, err := authenticatePublisher(ctx, jwtManager, registry, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		job, err := registry.GetPublishJob(claimsOwner(claims), input.ID)
+		if err != nil {
+			if errors.Is(err, service.ErrPublishJobNotFound) {
+				return nil, huma.Error404NotFound("Publish job not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get publish job", err)
+		}
+
+		return &Response[service.PublishJob]{
+			Body: *job,
+		}, nil
+	})
+}
+
+// publishMaxBodyBytes returns the body size limit for operations accepting a server, where a negative value
+// tells huma not to apply its default limit
+func publishMaxBodyBytes(cfg *config.Config) int64 {
+	if cfg.PublishMaxBodyBytes <= 0 {
+		return -1
+	}
+	return cfg.PublishMaxBodyBytes
+}
+
+// checkPublishLimits rejects servers exceeding the configured package, environment variable and string length
+// limits with a 422 listing every violation
+func checkPublishLimits(server apiv0.ServerJSON, cfg *config.Config) error {
+	violations := validators.CheckPublishLimits(server, cfg)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	details := make([]error, 0, len(violations))
+	for _, violation := range violations {
+		details = append(details, &huma.ErrorDetail{Location: "body." + violation.Location, Message: violation.Message})
+	}
+	return huma.Error422UnprocessableEntity("Server exceeds publish limits", details...)
+}
+
+// publishFailed returns a 400 for a failed publish, listing each finding with its location when validation
+// found several
+func publishFailed(message string, err error) error {
+	var verr *validators.ValidationError
+	if !errors.As(err, &verr) {
+		return huma.Error400BadRequest(message, err)
+	}
+
+	causes := verr.Unwrap()
+	details := make([]error, 0, len(verr.Report.Errors))
+	for i, finding := range verr.Report.Errors {
+		location := "body"
+		if finding.Location != "" {
+			location += "." + finding.Location
+		}
+		details = append(details, causedDetail{detail: &huma.ErrorDetail{Location: location, Message: finding.Message}, cause: causes[i]})
+	}
+	return huma.Error400BadRequest(message, details...)
+}
+
+// withValidatorCalls adds the requests validators sent upstream to an error response
+func withValidatorCalls(err error, calls []apiv0.ValidatorCall) error {
+	var model *ErrorModel
+	if errors.As(err, &model) {
+		model.ValidatorCalls = calls
+	}
+	return err
+}
+
+// AuthorizePublish applies the checks POST /v0/publish makes before publishing a server, for other API surfaces.
+// It returns the publisher's identity as "<auth method>:<subject>" and a function to call once the server is
+// published, or a huma.StatusError.
+func AuthorizePublish(ctx context.Context, jwtManager *auth.JWTManager, registry service.RegistryService, cfg *config.Config, authHeader string, server apiv0.ServerJSON) (string, func(), error) {
+	claims, published, err := authorizePublish(ctx, jwtManager, registry, cfg, authHeader, "", server)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := checkPublishLimits(server, cfg); err != nil {
+		return "", nil, err
+	}
+	return claimsOwner(claims), published, nil
+}
+
+// authorizePublish validates the bearer token and checks it grants publish permission for the server,
+// returning the token's claims and a function to call once the server is published, which uses up a
+// single-use upload token. Dry runs and failed publishes therefore leave the token usable.
+func authorizePublish(ctx context.Context, jwtManager *auth.JWTManager, registry service.RegistryService, cfg *config.Config, authHeader, idempotencyKey string, server apiv0.ServerJSON) (*auth.JWTClaims, func(), error) {
+	// Mirrors only accept changes from their upstream registry
+	if cfg.MirrorUpstreamURL != "" {
+		return nil, nil, huma.Error403Forbidden("This registry is a read-only mirror of " + cfg.MirrorUpstreamURL + "; publish there instead")
+	}
+
+	// Upload tokens are bound to a single server version, and are only accepted here
+	uploadClaims := uploadTokenClaims(ctx, jwtManager, authHeader)
+	claims := uploadClaims
+	if claims == nil {
+		var err error
+		if claims, err = authenticatePublisher(ctx, jwtManager, registry, authHeader); err != nil {
+			return nil, nil, err
+		}
+	}
+	if uploadClaims != nil && server.Version != uploadClaims.Version {
+		return nil, nil, huma.Error403Forbidden("This upload token can only publish version " + uploadClaims.Version + " of " + uploadClaims.Permissions[0].ResourcePattern)
+	}
+
+	// Verify that the token has permission to publish the server
+	if !jwtManager.HasPermission(server.Name, auth.PermissionActionPublish, claims.Permissions) {
+		return nil, nil, huma.Error403Forbidden(buildPermissionErrorMessage(server.Name, claims.Permissions))
+	}
+
+	// Reserved namespaces only accept publishes from the identity holding the reservation
+	if err := registry.CheckNamespaceOwner(claimsOwner(claims), server.Name); err != nil {
+		if errors.Is(err, service.ErrNamespaceReserved) {
+			return nil, nil, NewError(http.StatusForbidden, "Namespace "+service.ServerNamespace(server.Name)+" is reserved by another publisher", err)
+		}
+		return nil, nil, huma.Error500InternalServerError("Failed to check namespace reservation", err)
+	}
+
+	// Prevent publishers from claiming someone else's repository
+	if cfg.EnableRepositoryValidation {
+		if err := validators.ValidateRepositoryOwner(server.Repository, claims); err != nil {
+			return nil, nil, huma.Error403Forbidden(err.Error())
+		}
+	}
+
+	if uploadClaims == nil {
+		return claims, func() {}, nil
+	}
+
+	// Refuse upload tokens that already published, except with an idempotency key, whose retries get the
+	// stored response
+	if idempotencyKey == "" {
+		used, err := registry.UploadTokenUsed(uploadClaims.ID)
+		if err != nil {
+			return nil, nil, huma.Error500InternalServerError("Failed to check upload token", err)
+		}
+		if used {
+			return nil, nil, huma.Error401Unauthorized("This upload token was already used")
+		}
+	}
+
+	// The server is already published when the token is used up, and its version can't be published again, so
+	// failing to record the use is only logged
+	published := func() {
+		if err := registry.RedeemUploadToken(uploadClaims.ID, uploadClaims.ExpiresAt.Time); err != nil {
+			log.Printf("Failed to redeem upload token: %v", err)
+		}
+	}
+	return claims, published, nil
+}
+
+// uploadTokenClaims returns the claims of a bearer upload token, or nil if the header holds another credential
+func uploadTokenClaims(ctx context.Context, jwtManager *auth.JWTManager, authHeader string) *auth.JWTClaims {
+	const bearerPrefix = "Bearer "
+	if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		return nil
+	}
+	claims, err := jwtManager.ValidateUploadToken(ctx, authHeader[len(bearerPrefix):])
+	if err != nil {
+		return nil
+	}
+	return claims
+}
+
+// authenticatePublisher validates a bearer registry JWT or API key, returning its claims
+func authenticatePublisher(ctx context.Context, jwtManager *auth.JWTManager, registry service.RegistryService, authHeader string) (*auth.JWTClaims, error) {
+	// Extract bearer token
+	const bearerPrefix = "Bearer "
+	if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		return nil, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
+	}
+	token := authHeader[len(bearerPrefix):]
+
+	if strings.HasPrefix(token, service.APIKeyPrefix) {
+		// Long-lived API key
+		key, err := registry.AuthenticateAPIKey(token)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidAPIKey) {
+				return nil, huma.Error401Unauthorized("Invalid, expired or revoked API key")
+			}
+			return nil, huma.Error500InternalServerError("Failed to check API key", err)
+		}
+		return apiKeyClaims(key), nil
+	}
+
+	// Validate Registry JWT token
+	claims, err := jwtManager.ValidateToken(ctx, token)
+	if err != nil {
+		return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+	}
+	return claims, nil
 }
 
 // buildPermissionErrorMessage creates a detailed error message showing what permissions
@@ -71,11 +397,11 @@ func RegisterPublishEndpoint(api huma.API, registry service.RegistryService, cfg
 func buildPermissionErrorMessage(attemptedResource string, permissions []auth.Permission) string {
 	var permissionStrs []string
 	for _, perm := range permissions {
-		if perm.Action == auth.PermissionActionPublish {
+		if perm.Action == auth.PermissionActionPublish && !perm.Deny {
 			permissionStrs = append(permissionStrs, perm.ResourcePattern)
 		}
 	}
-	
+
 	errorMsg := "You do not have permission to publish this server"
 	if len(permissionStrs) > 0 {
 		errorMsg += ". You have permission to publish: " + strings.Join(permissionStrs, ", ")
@@ -83,6 +409,6 @@ func buildPermissionErrorMessage(attemptedResource string, permissions []auth.Pe
 		errorMsg += ". You do not have any publish permissions"
 	}
 	errorMsg += ". Attempting to publish: " + attemptedResource
-	
+
 	return errorMsg
 }
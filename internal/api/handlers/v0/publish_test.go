@@ -5,11 +5,14 @@ import (
 	"context"
 	"crypto/ed25519"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
@@ -35,15 +38,44 @@ func generateTestJWTToken(cfg *config.Config, claims auth.JWTClaims) (string, er
 	return tokenResponse.RegistryToken, nil
 }
 
-func TestPublishEndpoint(t *testing.T) {
+// publishTestAPI serves the publish endpoints from an empty in-memory registry
+type publishTestAPI struct {
+	mux      *http.ServeMux
+	api      huma.API
+	registry service.RegistryService
+	db       database.Database
+	cfg      *config.Config
+	// token may publish any server
+	token string
+}
+
+// setupPublishTest serves the publish endpoints with cfg, which is given a random JWT signing key
+func setupPublishTest(t *testing.T, cfg *config.Config) *publishTestAPI {
+	t.Helper()
+
 	testSeed := make([]byte, ed25519.SeedSize)
 	_, err := rand.Read(testSeed)
 	require.NoError(t, err)
-	testConfig := &config.Config{
-		JWTPrivateKey:            hex.EncodeToString(testSeed),
-		EnableRegistryValidation: false, // Disable for unit tests
-	}
+	cfg.JWTPrivateKey = hex.EncodeToString(testSeed)
+
+	db := database.NewMemoryDB()
+	registryService := service.NewRegistryService(db, cfg)
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, registryService, cfg)
+
+	token, err := generateTestJWTToken(cfg, auth.JWTClaims{
+		AuthMethod: auth.MethodNone,
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+		},
+	})
+	require.NoError(t, err)
+
+	return &publishTestAPI{mux: mux, api: api, registry: registryService, db: db, cfg: cfg, token: token}
+}
 
+func TestPublishEndpoint(t *testing.T) {
 	testCases := []struct {
 		name                 string
 		requestBody          interface{}
@@ -357,18 +389,11 @@ func TestPublishEndpoint(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			// Create registry service
-			registryService := service.NewRegistryService(database.NewMemoryDB(), testConfig)
+			// Serve the endpoint from a registry with registry validation disabled for unit tests
+			publishAPI := setupPublishTest(t, &config.Config{EnableRegistryValidation: false})
 
 			// Setup registry service
-			tc.setupRegistryService(registryService)
-
-			// Create a new ServeMux and Huma API
-			mux := http.NewServeMux()
-			api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-
-			// Register the endpoint with test config
-			v0.RegisterPublishEndpoint(api, registryService, testConfig)
+			tc.setupRegistryService(publishAPI.registry)
 
 			// Prepare request body
 			var requestBody []byte
@@ -388,14 +413,14 @@ func TestPublishEndpoint(t *testing.T) {
 				req.Header.Set("Authorization", tc.authHeader)
 			} else if tc.tokenClaims != nil {
 				// Generate a valid JWT token
-				token, err := generateTestJWTToken(testConfig, *tc.tokenClaims)
+				token, err := generateTestJWTToken(publishAPI.cfg, *tc.tokenClaims)
 				assert.NoError(t, err)
 				req.Header.Set("Authorization", "Bearer "+token)
 			}
 
 			// Perform request
 			rr := httptest.NewRecorder()
-			mux.ServeHTTP(rr, req)
+			publishAPI.mux.ServeHTTP(rr, req)
 
 			// Assertions
 			assert.Equal(t, tc.expectedStatus, rr.Code, "status code mismatch")
@@ -411,14 +436,6 @@ func TestPublishEndpoint(t *testing.T) {
 
 // TestPublishEndpoint_MultipleSlashesEdgeCases tests additional edge cases for multi-slash validation
 func TestPublishEndpoint_MultipleSlashesEdgeCases(t *testing.T) {
-	testSeed := make([]byte, ed25519.SeedSize)
-	_, err := rand.Read(testSeed)
-	require.NoError(t, err)
-	testConfig := &config.Config{
-		JWTPrivateKey:            hex.EncodeToString(testSeed),
-		EnableRegistryValidation: false,
-	}
-
 	testCases := []struct {
 		name           string
 		serverName     string
@@ -459,15 +476,8 @@ func TestPublishEndpoint_MultipleSlashesEdgeCases(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			// Create registry service
-			registryService := service.NewRegistryService(database.NewMemoryDB(), testConfig)
-
-			// Create a new ServeMux and Huma API
-			mux := http.NewServeMux()
-			api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-
-			// Register the endpoint
-			v0.RegisterPublishEndpoint(api, registryService, testConfig)
+			// Serve the endpoint
+			publishAPI := setupPublishTest(t, &config.Config{EnableRegistryValidation: false})
 
 			// Create request body
 			requestBody := apiv0.ServerJSON{
@@ -485,19 +495,11 @@ func TestPublishEndpoint_MultipleSlashesEdgeCases(t *testing.T) {
 			req.Header.Set("Content-Type", "application/json")
 
 			// Set auth header with permissions
-			tokenClaims := auth.JWTClaims{
-				AuthMethod: auth.MethodNone,
-				Permissions: []auth.Permission{
-					{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
-				},
-			}
-			token, err := generateTestJWTToken(testConfig, tokenClaims)
-			require.NoError(t, err)
-			req.Header.Set("Authorization", "Bearer "+token)
+			req.Header.Set("Authorization", "Bearer "+publishAPI.token)
 
 			// Perform request
 			rr := httptest.NewRecorder()
-			mux.ServeHTTP(rr, req)
+			publishAPI.mux.ServeHTTP(rr, req)
 
 			// Assertions
 			assert.Equal(t, tc.expectedStatus, rr.Code, 
@@ -509,4 +511,267 @@ func TestPublishEndpoint_MultipleSlashesEdgeCases(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestPublishEndpoint_DryRun(t *testing.T) {
+	publishAPI := setupPublishTest(t, &config.Config{EnableRegistryValidation: false})
+	mux, registryService, token := publishAPI.mux, publishAPI.registry, publishAPI.token
+
+	doPublish := func(body apiv0.ServerJSON) *httptest.ResponseRecorder {
+		bodyBytes, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/v0/publish?dry_run=true", bytes.NewBuffer(bodyBytes))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("valid server returns would-be record without storing it", func(t *testing.T) {
+		rr := doPublish(apiv0.ServerJSON{
+			Name:        "com.example/dry-run-server",
+			Description: "A dry run server",
+			Version:     "1.0.0",
+		})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var result apiv0.ServerJSON
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		assert.Equal(t, "com.example/dry-run-server", result.Name)
+		require.NotNil(t, result.Meta)
+		require.NotNil(t, result.Meta.Official)
+		assert.True(t, result.Meta.Official.IsLatest)
+
+		servers, _, err := registryService.List(nil, "", 10)
+		require.NoError(t, err)
+		assert.Empty(t, servers, "dry run must not persist the server")
+	})
+
+	t.Run("invalid server returns validation error", func(t *testing.T) {
+		rr := doPublish(apiv0.ServerJSON{
+			Name:        "com.example/server/extra",
+			Description: "Invalid server",
+			Version:     "1.0.0",
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Failed to validate server")
+	})
+
+	t.Run("duplicate version is detected", func(t *testing.T) {
+		_, err := registryService.Publish(apiv0.ServerJSON{
+			Name:        "com.example/existing-server",
+			Description: "Existing server",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+
+		rr := doPublish(apiv0.ServerJSON{
+			Name:        "com.example/existing-server",
+			Description: "Existing server",
+			Version:     "1.0.0",
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "cannot publish duplicate version")
+	})
+}
+
+func TestPublishEndpoint_ReadOnlyMirror(t *testing.T) {
+	publishAPI := setupPublishTest(t, &config.Config{
+		EnableRegistryValidation: false,
+		MirrorUpstreamURL:        "https://registry.modelcontextprotocol.io",
+	})
+	mux, token := publishAPI.mux, publishAPI.token
+
+	body, err := json.Marshal(apiv0.ServerJSON{
+		Name:        "com.example/mirrored-server",
+		Description: "A test server",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+
+	for _, path := range []string{"/v0/publish", "/v0/publish/async"} {
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code, path)
+		assert.Contains(t, rr.Body.String(), "read-only mirror", path)
+	}
+}
+
+func TestPublishEndpoint_Limits(t *testing.T) {
+	publishAPI := setupPublishTest(t, &config.Config{
+		PublishMaxBodyBytes:    4096,
+		PublishMaxPackages:     1,
+		PublishMaxStringLength: 200,
+	})
+	mux, token := publishAPI.mux, publishAPI.token
+
+	publish := func(server apiv0.ServerJSON) *httptest.ResponseRecorder {
+		body, err := json.Marshal(server)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/v0/publish", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+	pkg := model.Package{RegistryType: model.RegistryTypeNPM, Identifier: "example", Version: "1.0.0", Transport: model.Transport{Type: "stdio"}}
+
+	t.Run("body too large", func(t *testing.T) {
+		rr := publish(apiv0.ServerJSON{Name: "com.example/server", Description: "A server", Version: "1.0.0", WebsiteURL: "https://example.com/" + strings.Repeat("a", 5000)})
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+	})
+
+	t.Run("too many packages and long strings", func(t *testing.T) {
+		rr := publish(apiv0.ServerJSON{
+			Name: "com.example/server", Description: "A server", Version: "1.0.0",
+			Packages: []model.Package{pkg, pkg},
+			Meta: &apiv0.ServerMeta{PublisherProvided: map[string]interface{}{"notes": strings.Repeat("a", 201)}},
+		})
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+		var problem huma.ErrorModel
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+		require.Len(t, problem.Errors, 2)
+		assert.Equal(t, "body.packages", problem.Errors[0].Location)
+		assert.Equal(t, "body._meta.io.modelcontextprotocol.registry/publisher-provided.notes", problem.Errors[1].Location)
+	})
+}
+
+func TestPublishEndpoint_UnknownFields(t *testing.T) {
+	body := `{
+		"name": "com.example/server",
+		"description": "A test server",
+		"version": "1.0.0",
+		"icons": [{"src": "https://example.com/icon.png"}],
+		"_meta": {"com.example/build": {"commit": "abc123"}}
+	}`
+	publish := func(testConfig *config.Config) *httptest.ResponseRecorder {
+		publishAPI := setupPublishTest(t, testConfig)
+
+		req := httptest.NewRequest(http.MethodPost, "/v0/publish", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+publishAPI.token)
+		rr := httptest.NewRecorder()
+		publishAPI.mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("rejected by default", func(t *testing.T) {
+		rr := publish(&config.Config{})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "icons")
+		assert.Contains(t, rr.Body.String(), "_meta.com.example/build")
+	})
+
+	t.Run("preserved when configured", func(t *testing.T) {
+		rr := publish(&config.Config{UnknownFields: config.UnknownFieldsPreserve})
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var published map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &published))
+		assert.JSONEq(t, `[{"src": "https://example.com/icon.png"}]`, string(published["icons"]))
+		var meta map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(published["_meta"], &meta))
+		assert.JSONEq(t, `{"commit": "abc123"}`, string(meta["com.example/build"]))
+		assert.Contains(t, meta, "io.modelcontextprotocol.registry/official")
+	})
+}
+
+func TestPublishEndpoint_IdempotencyKey(t *testing.T) {
+	publishAPI := setupPublishTest(t, &config.Config{})
+	mux, token := publishAPI.mux, publishAPI.token
+
+	publish := func(key string, server apiv0.ServerJSON) *httptest.ResponseRecorder {
+		body, err := json.Marshal(server)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/v0/publish", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Idempotency-Key", key)
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+	server := apiv0.ServerJSON{Name: "com.example/idempotent", Description: "A server", Version: "1.0.0"}
+
+	first := publish("key-1", server)
+	require.Equal(t, http.StatusOK, first.Code, first.Body.String())
+	assert.Empty(t, first.Header().Get("Idempotent-Replayed"))
+
+	// A retry returns the original response instead of failing as a duplicate version
+	retry := publish("key-1", server)
+	require.Equal(t, http.StatusOK, retry.Code, retry.Body.String())
+	assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+	assert.JSONEq(t, first.Body.String(), retry.Body.String())
+
+	// Reusing the key for a different request is rejected
+	other := server
+	other.Version = "1.0.1"
+	rr := publish("key-1", other)
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	assert.Equal(t, v0.ErrorCodeIdempotencyKeyReused, problemCode(t, rr))
+
+	// Keys of failed publishes can be used again
+	rr = publish("key-2", server)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	rr = publish("key-2", other)
+	assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	// Retries while the original publish is still running are told to wait
+	inProgress := server
+	inProgress.Version = "1.0.2"
+	body, err := json.Marshal(inProgress)
+	require.NoError(t, err)
+	sum := sha256.Sum256(body)
+	now := time.Now()
+	require.NoError(t, publishAPI.db.CreateIdempotencyKey(context.Background(), &database.IdempotencyKey{
+		Owner:       string(auth.MethodNone) + ":",
+		Key:         "key-3",
+		RequestHash: hex.EncodeToString(sum[:]),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Hour),
+	}))
+	rr = publish("key-3", inProgress)
+	assert.Equal(t, http.StatusConflict, rr.Code, rr.Body.String())
+	assert.Equal(t, v0.ErrorCodeIdempotencyKeyInProgress, problemCode(t, rr))
+}
+
+func TestPublishEndpoint_Debug(t *testing.T) {
+	original := huma.NewError
+	huma.NewError = v0.NewError
+	t.Cleanup(func() { huma.NewError = original })
+
+	publishAPI := setupPublishTest(t, &config.Config{})
+	mux, token := publishAPI.mux, publishAPI.token
+	server := apiv0.ServerJSON{Name: "com.example/debug", Description: "A server", Version: "1.0.0"}
+
+	rr := doJSONRequest(t, mux, http.MethodPost, "/v0/publish?debug=true", token, server)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var published apiv0.ServerJSON
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &published))
+	require.NotNil(t, published.Meta.Validation)
+
+	// Failed debug publishes keep their error code
+	rr = doJSONRequest(t, mux, http.MethodPost, "/v0/publish?debug=true", token, server)
+	require.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+	var problem v0.ErrorModel
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+	assert.Equal(t, v0.ErrorCodeVersionExists, problem.Code)
+
+	// Debug publishes can't be replayed
+	body, err := json.Marshal(server)
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/v0/publish?debug=true", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Idempotency-Key", "key-1")
+	rr = httptest.NewRecorder()
+	mux.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
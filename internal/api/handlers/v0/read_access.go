@@ -0,0 +1,172 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// catalogReads are the operations that read the catalog without credentials of their own, which READ_ACCESS
+// applies to. Those marked true can limit what they return to a read scope; the others either name the server
+// they read, in a name path parameter, or are unavailable when reads are limited to namespaces.
+var catalogReads = map[string]bool{
+	"list-servers":              true,
+	"get-server":                true,
+	"list-category-servers":     true,
+	"list-server-versions":      false,
+	"get-latest-server-version": false,
+	"record-server-event":       false,
+	"list-server-advisories":    false,
+	"list-categories":           false,
+	"get-icon":                  false,
+	"graphql-query":             false,
+	"graphql-query-post":        false,
+	"list-changes":              false,
+	"stream-events":             false,
+	"export-registry":           false,
+	"stream-export-registry":    false,
+	"get-transfer":              false,
+	"get-namespace-reservation": false,
+}
+
+// ReadRequest describes a catalog read for the read access checks
+type ReadRequest struct {
+	// Authorization is the request's Authorization header, if any
+	Authorization string
+	// Name is the server the read is about, if it names one
+	Name string
+	// Scoped is set if the read limits its results to the servers its read scope allows
+	Scoped bool
+}
+
+// readCheck is a link of the read access chain. It returns the context to handle the read with, or a
+// huma.StatusError refusing it.
+type readCheck func(ctx context.Context, req ReadRequest) (context.Context, error)
+
+type readerClaimsKey struct{}
+
+type readScopeKey struct{}
+
+// readScope limits reads to the servers a reader's credentials grant publish or edit permission on
+type readScope struct {
+	jwtManager  *auth.JWTManager
+	permissions []auth.Permission
+}
+
+func (s *readScope) allows(name string) bool {
+	return s.jwtManager.HasPermission(name, auth.PermissionActionPublish, s.permissions) ||
+		s.jwtManager.HasPermission(name, auth.PermissionActionEdit, s.permissions)
+}
+
+// readAccessChain returns the checks a catalog read goes through under a read access model. Reads are anonymous
+// when no model is set, and anything other than the known models gets the strictest checks.
+func readAccessChain(mode config.ReadAccess, jwtManager *auth.JWTManager, registry service.RegistryService) []readCheck {
+	switch mode {
+	case config.ReadAccessAnonymous, "":
+		return nil
+	case config.ReadAccessAuthenticated:
+		return []readCheck{authenticateReader(jwtManager, registry)}
+	default:
+		return []readCheck{authenticateReader(jwtManager, registry), limitToNamespaces(jwtManager)}
+	}
+}
+
+// authenticateReader requires a registry JWT or API key, keeping its claims for the checks that follow
+func authenticateReader(jwtManager *auth.JWTManager, registry service.RegistryService) readCheck {
+	return func(ctx context.Context, req ReadRequest) (context.Context, error) {
+		claims, err := authenticatePublisher(ctx, jwtManager, registry, req.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		return context.WithValue(ctx, readerClaimsKey{}, claims), nil
+	}
+}
+
+// limitToNamespaces only lets readers see servers their credentials grant permissions on. Servers they can't
+// see are reported as not found, so their existence isn't revealed.
+func limitToNamespaces(jwtManager *auth.JWTManager) readCheck {
+	return func(ctx context.Context, req ReadRequest) (context.Context, error) {
+		claims, _ := ctx.Value(readerClaimsKey{}).(*auth.JWTClaims)
+		if claims == nil {
+			return nil, huma.Error401Unauthorized("Reading the registry requires a registry JWT or API key")
+		}
+		scope := &readScope{jwtManager: jwtManager, permissions: claims.Permissions}
+		switch {
+		case req.Name != "":
+			if !scope.allows(req.Name) {
+				return nil, huma.Error404NotFound("Server not found")
+			}
+		case !req.Scoped:
+			return nil, huma.Error403Forbidden("This endpoint is unavailable when reads are limited to the namespaces of your credentials")
+		}
+		return context.WithValue(ctx, readScopeKey{}, scope), nil
+	}
+}
+
+// AuthorizeRead applies the registry's read access model to a catalog read, for other API surfaces. It returns
+// the context to handle the read with, which carries the read scope if there is one, or a huma.StatusError.
+func AuthorizeRead(ctx context.Context, jwtManager *auth.JWTManager, registry service.RegistryService, req ReadRequest) (context.Context, error) {
+	for _, check := range readAccessChain(registry.Config().ReadAccess, jwtManager, registry) {
+		var err error
+		if ctx, err = check(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return ctx, nil
+}
+
+// ReadAccessMiddleware applies the registry's read access model (READ_ACCESS), which can be reloaded, to the
+// catalog read endpoints
+func ReadAccessMiddleware(api huma.API, registry service.RegistryService, cfg *config.Config) func(huma.Context, func(huma.Context)) {
+	jwtManager := auth.NewJWTManager(cfg)
+	return func(ctx huma.Context, next func(huma.Context)) {
+		scoped, isRead := catalogReads[ctx.Operation().OperationID]
+		if !isRead {
+			next(ctx)
+			return
+		}
+
+		readCtx, err := AuthorizeRead(ctx.Context(), jwtManager, registry, ReadRequest{
+			Authorization: ctx.Header("Authorization"),
+			Name:          ctx.Param("name"),
+			Scoped:        scoped,
+		})
+		if err != nil {
+			status, message := http.StatusInternalServerError, err.Error()
+			var statusErr huma.StatusError
+			if errors.As(err, &statusErr) {
+				status = statusErr.GetStatus()
+			}
+			_ = huma.WriteErr(api, ctx, status, message)
+			return
+		}
+		next(huma.WithContext(ctx, readCtx))
+	}
+}
+
+// Readable reports whether the read scope in ctx, if there is one, allows reading the named server
+func Readable(ctx context.Context, name string) bool {
+	scope, ok := ctx.Value(readScopeKey{}).(*readScope)
+	return !ok || scope.allows(name)
+}
+
+// ReadableServers drops the servers the read scope in ctx doesn't allow. Pages of a scoped list may therefore
+// hold fewer servers than their limit, and even none, while there are more pages to fetch.
+func ReadableServers(ctx context.Context, servers []apiv0.ServerJSON) []apiv0.ServerJSON {
+	if _, ok := ctx.Value(readScopeKey{}).(*readScope); !ok {
+		return servers
+	}
+	readable := make([]apiv0.ServerJSON, 0, len(servers))
+	for _, server := range servers {
+		if Readable(ctx, server.Name) {
+			readable = append(readable, server)
+		}
+	}
+	return readable
+}
@@ -0,0 +1,132 @@
+package v0_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupReadAccessTest serves the catalog under a read access model, returning the IDs of its servers and of a
+// transfer, under "transfer", and a token with permissions on io.github.example
+func setupReadAccessTest(t *testing.T, mode config.ReadAccess) (*http.ServeMux, map[string]string, string) {
+	t.Helper()
+
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	testConfig := &config.Config{
+		JWTPrivateKey:            hex.EncodeToString(testSeed),
+		EnableRegistryValidation: false,
+		ReadAccess:               mode,
+	}
+
+	registryService := service.NewRegistryService(database.NewMemoryDB(), testConfig)
+	ids := map[string]string{}
+	for _, name := range []string{"io.github.example/mine", "io.github.other/theirs"} {
+		published, err := registryService.Publish(apiv0.ServerJSON{Name: name, Description: "A test server", Version: "1.0.0"})
+		require.NoError(t, err)
+		ids[name] = published.Meta.Official.ID
+	}
+	_, err = registryService.ReserveNamespace("github-at:example", "io.github.example")
+	require.NoError(t, err)
+	transfer, err := registryService.RequestTransfer("github-at:example", "io.github.example/mine", "io.github.other/mine")
+	require.NoError(t, err)
+	ids["transfer"] = transfer.ID
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	api.UseMiddleware(v0.ReadAccessMiddleware(api, registryService, testConfig))
+	v0.RegisterServersEndpoints(api, registryService)
+	v0.RegisterChangesEndpoint(api, registryService)
+	v0.RegisterTransferEndpoints(api, registryService, testConfig)
+	v0.RegisterPingEndpoint(api)
+
+	token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "example",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.example/*"},
+		},
+	})
+	require.NoError(t, err)
+
+	return mux, ids, token
+}
+
+func listServerNames(t *testing.T, mux *http.ServeMux, token string) []string {
+	t.Helper()
+	rr := doJSONRequest(t, mux, http.MethodGet, "/v0/servers", token, nil)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var response apiv0.ServerListResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	names := make([]string, len(response.Servers))
+	for i, server := range response.Servers {
+		names[i] = server.Name
+	}
+	return names
+}
+
+func TestReadAccess_Anonymous(t *testing.T) {
+	mux, ids, _ := setupReadAccessTest(t, config.ReadAccessAnonymous)
+
+	assert.ElementsMatch(t, []string{"io.github.example/mine", "io.github.other/theirs"}, listServerNames(t, mux, ""))
+	assert.Equal(t, http.StatusOK, doJSONRequest(t, mux, http.MethodGet, "/v0/changes", "", nil).Code)
+	assert.Equal(t, http.StatusOK, doJSONRequest(t, mux, http.MethodGet, "/v0/transfers/"+ids["transfer"], "", nil).Code)
+	assert.Equal(t, http.StatusOK, doJSONRequest(t, mux, http.MethodGet, "/v0/namespaces/io.github.example/reservation", "", nil).Code)
+}
+
+func TestReadAccess_Authenticated(t *testing.T) {
+	mux, ids, token := setupReadAccessTest(t, config.ReadAccessAuthenticated)
+
+	assert.Equal(t, http.StatusUnauthorized, doJSONRequest(t, mux, http.MethodGet, "/v0/servers", "", nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, doJSONRequest(t, mux, http.MethodGet, "/v0/servers", "not-a-token", nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, doJSONRequest(t, mux, http.MethodGet, "/v0/servers/"+ids["io.github.other/theirs"], "", nil).Code)
+
+	assert.ElementsMatch(t, []string{"io.github.example/mine", "io.github.other/theirs"}, listServerNames(t, mux, token))
+	assert.Equal(t, http.StatusOK, doJSONRequest(t, mux, http.MethodGet, "/v0/changes", token, nil).Code)
+
+	// Transfers and reservations reveal server names and owners, so they are catalog reads too
+	assert.Equal(t, http.StatusUnauthorized, doJSONRequest(t, mux, http.MethodGet, "/v0/transfers/"+ids["transfer"], "", nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, doJSONRequest(t, mux, http.MethodGet, "/v0/namespaces/io.github.example/reservation", "", nil).Code)
+	assert.Equal(t, http.StatusOK, doJSONRequest(t, mux, http.MethodGet, "/v0/transfers/"+ids["transfer"], token, nil).Code)
+	assert.Equal(t, http.StatusOK, doJSONRequest(t, mux, http.MethodGet, "/v0/namespaces/io.github.example/reservation", token, nil).Code)
+
+	// Endpoints outside the catalog stay open
+	assert.Equal(t, http.StatusOK, doJSONRequest(t, mux, http.MethodGet, "/v0/ping", "", nil).Code)
+}
+
+func TestReadAccess_Namespace(t *testing.T) {
+	mux, ids, token := setupReadAccessTest(t, config.ReadAccessNamespace)
+
+	assert.Equal(t, http.StatusUnauthorized, doJSONRequest(t, mux, http.MethodGet, "/v0/servers", "", nil).Code)
+	assert.Equal(t, []string{"io.github.example/mine"}, listServerNames(t, mux, token))
+
+	assert.Equal(t, http.StatusOK, doJSONRequest(t, mux, http.MethodGet, "/v0/servers/"+ids["io.github.example/mine"], token, nil).Code)
+	assert.Equal(t, http.StatusNotFound, doJSONRequest(t, mux, http.MethodGet, "/v0/servers/"+ids["io.github.other/theirs"], token, nil).Code)
+
+	versionsPath := func(name string) string { return "/v0/servers/" + url.PathEscape(name) + "/versions" }
+	assert.Equal(t, http.StatusOK, doJSONRequest(t, mux, http.MethodGet, versionsPath("io.github.example/mine"), token, nil).Code)
+	assert.Equal(t, http.StatusNotFound, doJSONRequest(t, mux, http.MethodGet, versionsPath("io.github.other/theirs"), token, nil).Code)
+
+	// Reads that can't be limited to namespaces are refused
+	assert.Equal(t, http.StatusForbidden, doJSONRequest(t, mux, http.MethodGet, "/v0/changes", token, nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, doJSONRequest(t, mux, http.MethodGet, "/v0/transfers/"+ids["transfer"], "", nil).Code)
+	assert.Equal(t, http.StatusForbidden, doJSONRequest(t, mux, http.MethodGet, "/v0/transfers/"+ids["transfer"], token, nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, doJSONRequest(t, mux, http.MethodGet, "/v0/namespaces/io.github.example/reservation", "", nil).Code)
+	assert.Equal(t, http.StatusForbidden, doJSONRequest(t, mux, http.MethodGet, "/v0/namespaces/io.github.example/reservation", token, nil).Code)
+}
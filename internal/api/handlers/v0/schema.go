@@ -0,0 +1,91 @@
+package v0
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	"github.com/danielgtaylor/huma/v2"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// SchemaOutput represents the JSON Schema export response
+type SchemaOutput struct {
+	ContentType string         `header:"Content-Type"`
+	Body        map[string]any `doc:"JSON Schema document for server.json"`
+}
+
+// SchemaVersionsResponse lists the server.json schema versions the registry accepts
+type SchemaVersionsResponse struct {
+	Current  string              `json:"current" doc:"Schema version the registry validates and serves documents in"`
+	Versions []apiv0.SpecVersion `json:"versions" doc:"Schema versions accepted in $schema, oldest first"`
+}
+
+// RegisterSchemaEndpoint registers the server.json schema export and version discovery endpoints
+func RegisterSchemaEndpoint(api huma.API) {
+	// The schema only depends on the ServerJSON type, so build it once up front
+	schemaDoc, err := buildServerJSONSchema()
+	if err != nil {
+		panic(err)
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-json-schema",
+		Method:      http.MethodGet,
+		Path:        "/v0/schema/server.json",
+		Summary:     "Get server.json JSON Schema",
+		Description: "Get the JSON Schema used by this registry to validate server.json documents, so publishers can validate locally before submitting",
+		Tags:        []string{"schema"},
+	}, func(_ context.Context, _ *struct{}) (*SchemaOutput, error) {
+		return &SchemaOutput{
+			ContentType: "application/schema+json",
+			Body:        schemaDoc,
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-server-json-schema-versions",
+		Method:      http.MethodGet,
+		Path:        "/v0/schema/versions",
+		Summary:     "List server.json schema versions",
+		Description: "List the server.json schema versions this registry accepts in $schema. Documents of older versions are converted to the current version, which servers are served in.",
+		Tags:        []string{"schema"},
+	}, func(_ context.Context, _ *struct{}) (*Response[SchemaVersionsResponse], error) {
+		return &Response[SchemaVersionsResponse]{
+			Body: SchemaVersionsResponse{
+				Current:  model.CurrentSchemaVersion,
+				Versions: apiv0.SupportedSpecVersions(),
+			},
+		}, nil
+	})
+}
+
+// buildServerJSONSchema reflects apiv0.ServerJSON into a standalone JSON Schema document
+func buildServerJSONSchema() (map[string]any, error) {
+	registry := huma.NewMapRegistry("#/$defs/", huma.DefaultSchemaNamer)
+	root := registry.Schema(reflect.TypeOf(apiv0.ServerJSON{}), false, "ServerJSON")
+
+	// Round-trip through JSON so the root schema can be extended with document-level keywords
+	rootJSON, err := json.Marshal(root)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(rootJSON, &doc); err != nil {
+		return nil, err
+	}
+
+	defs := registry.Map()
+	delete(defs, "ServerJSON") // The root schema is inlined at the top level
+	doc["$schema"] = jsonSchemaDialect
+	doc["title"] = "MCP server.json"
+	if len(defs) > 0 {
+		doc["$defs"] = defs
+	}
+
+	return doc, nil
+}
@@ -0,0 +1,67 @@
+package v0_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestSchemaEndpoint(t *testing.T) {
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterSchemaEndpoint(api)
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/schema/server.json", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/schema+json", w.Header().Get("Content-Type"))
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &schema))
+
+	assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", schema["$schema"])
+	assert.Equal(t, "object", schema["type"])
+
+	properties, ok := schema["properties"].(map[string]any)
+	require.True(t, ok, "schema should declare properties")
+	for _, field := range []string{"name", "description", "version", "packages", "remotes", "repository"} {
+		assert.Contains(t, properties, field)
+	}
+
+	// Nested types are exposed as local definitions
+	defs, ok := schema["$defs"].(map[string]any)
+	require.True(t, ok, "schema should include $defs")
+	assert.Contains(t, defs, "Package")
+	assert.NotContains(t, defs, "ServerJSON")
+}
+
+func TestSchemaVersionsEndpoint(t *testing.T) {
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterSchemaEndpoint(api)
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/schema/versions", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp v0.SchemaVersionsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, model.CurrentSchemaVersion, resp.Current)
+	require.NotEmpty(t, resp.Versions)
+
+	current := resp.Versions[len(resp.Versions)-1]
+	assert.True(t, current.Current)
+	assert.Equal(t, "https://static.modelcontextprotocol.io/schemas/"+model.CurrentSchemaVersion+"/server.schema.json", current.SchemaURL)
+}
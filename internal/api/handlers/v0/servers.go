@@ -2,7 +2,9 @@ package v0
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
@@ -19,11 +21,43 @@ type ListServersInput struct {
 	UpdatedSince string `query:"updated_since" doc:"Filter servers updated since timestamp (RFC3339 datetime)" required:"false" example:"2025-08-07T13:15:04.280Z"`
 	Search       string `query:"search" doc:"Search servers by name (substring match)" required:"false" example:"filesystem"`
 	Version      string `query:"version" doc:"Filter by version ('latest' for latest version, or an exact version like '1.2.3')" required:"false" example:"latest"`
+	Transport    string `query:"transport" doc:"Only include servers with a remote or package using this transport" enum:"stdio,streamable-http,sse" required:"false" example:"sse"`
+	Registry     string `query:"registry" doc:"Only include servers with a package from this registry type" enum:"npm,pypi,oci,nuget,mcpb,maven,gem,vsix" required:"false" example:"oci"`
+	Category     string `query:"category" doc:"Only include servers in this category (see /v0/categories)" required:"false" example:"developer-tools"`
+	Tag          string `query:"tag" doc:"Only include servers with this tag" required:"false" example:"weather"`
+	HasAdvisory  string `query:"has_advisory" doc:"Only include server versions that are ('true') or aren't ('false') affected by a security advisory" enum:"true,false" required:"false" example:"false"`
+	Sort         string `query:"sort" doc:"Order results by weekly installs ('downloads'), publish time, newest first ('recent'), name ('name'), or closeness to the search term ('relevance'). Defaults to a stable but unspecified order." enum:"downloads,recent,name,relevance" required:"false" example:"downloads"`
+	Fields       string `query:"fields" doc:"Comma-separated top-level server fields to return, e.g. name,version,remotes; all fields if omitted" required:"false" example:"name,version,remotes"`
 }
 
 // ServerDetailInput represents the input for getting server details
 type ServerDetailInput struct {
-	ID string `path:"id" doc:"Server ID (UUID)" format:"uuid"`
+	ID     string `path:"id" doc:"Server ID (UUID)" format:"uuid"`
+	Fields string `query:"fields" doc:"Comma-separated top-level server fields to return, e.g. name,version,remotes; all fields if omitted" required:"false" example:"name,version,remotes"`
+}
+
+// ServerDetailOutput is a single server record along with its revision as an entity tag
+type ServerDetailOutput struct {
+	ETag string `header:"ETag" doc:"The record's revision; send it in If-Match when editing the server"`
+	Body apiv0.ServerJSON
+}
+
+// ServerVersionsInput represents the input for listing or resolving versions of a server
+type ServerVersionsInput struct {
+	Name   string `path:"name" doc:"Server name, URL-encoded (e.g. io.github.example%2Fserver)" example:"io.github.example%2Fserver"`
+	Range  string `query:"range" doc:"Only include semantic versions within this range (npm syntax, e.g. '^1.2', '~1.2.3', '>=1.0.0 <2.0.0')" required:"false" example:"^1.2"`
+	Fields string `query:"fields" doc:"Comma-separated top-level server fields to return, e.g. name,version,remotes; all fields if omitted" required:"false" example:"name,version,remotes"`
+}
+
+// ServerEventInput represents the input for recording a usage event
+type ServerEventInput struct {
+	Name  string `path:"name" doc:"Server name, URL-encoded (e.g. io.github.example%2Fserver)" example:"io.github.example%2Fserver"`
+	Event string `path:"event" doc:"Event type" enum:"install,view"`
+}
+
+// ServerVersionListResponse represents every version of a server, highest first
+type ServerVersionListResponse struct {
+	Versions []apiv0.ServerJSON `json:"versions"`
 }
 
 // RegisterServersEndpoints registers all server-related endpoints
@@ -36,7 +70,11 @@ func RegisterServersEndpoints(api huma.API, registry service.RegistryService) {
 		Summary:     "List MCP servers",
 		Description: "Get a paginated list of MCP servers from the registry",
 		Tags:        []string{"servers"},
-	}, func(_ context.Context, input *ListServersInput) (*Response[apiv0.ServerListResponse], error) {
+	}, func(ctx context.Context, input *ListServersInput) (*Response[apiv0.ServerListResponse], error) {
+		if _, err := parseFields(input.Fields); err != nil {
+			return nil, err
+		}
+
 		// Validate cursor if provided
 		if input.Cursor != "" {
 			_, err := uuid.Parse(input.Cursor)
@@ -63,6 +101,29 @@ func RegisterServersEndpoints(api huma.API, registry service.RegistryService) {
 			filter.SubstringName = &input.Search
 		}
 
+		// Handle facet parameters
+		if input.Transport != "" {
+			filter.Transport = &input.Transport
+		}
+		if input.Registry != "" {
+			filter.RegistryType = &input.Registry
+		}
+
+		if input.Category != "" {
+			filter.Category = &input.Category
+		}
+		if input.Tag != "" {
+			filter.Tag = &input.Tag
+		}
+
+		if input.HasAdvisory != "" {
+			hasAdvisory := input.HasAdvisory == "true"
+			filter.HasAdvisory = &hasAdvisory
+		}
+
+		// Handle sort parameter
+		filter.Sort = database.ServerSort(input.Sort)
+
 		// Handle version parameter
 		if input.Version != "" {
 			if input.Version == "latest" {
@@ -80,6 +141,7 @@ func RegisterServersEndpoints(api huma.API, registry service.RegistryService) {
 		if err != nil {
 			return nil, huma.Error500InternalServerError("Failed to get registry list", err)
 		}
+		servers = ReadableServers(ctx, servers)
 
 		return &Response[apiv0.ServerListResponse]{
 			Body: apiv0.ServerListResponse{
@@ -100,7 +162,11 @@ func RegisterServersEndpoints(api huma.API, registry service.RegistryService) {
 		Summary:     "Get MCP server details",
 		Description: "Get detailed information about a specific MCP server",
 		Tags:        []string{"servers"},
-	}, func(_ context.Context, input *ServerDetailInput) (*Response[apiv0.ServerJSON], error) {
+	}, func(ctx context.Context, input *ServerDetailInput) (*ServerDetailOutput, error) {
+		if _, err := parseFields(input.Fields); err != nil {
+			return nil, err
+		}
+
 		// Get the server details from the registry service
 		serverDetail, err := registry.GetByID(input.ID)
 		if err != nil {
@@ -109,9 +175,101 @@ func RegisterServersEndpoints(api huma.API, registry service.RegistryService) {
 			}
 			return nil, huma.Error500InternalServerError("Failed to get server details", err)
 		}
+		if !Readable(ctx, serverDetail.Name) {
+			return nil, huma.Error404NotFound("Server not found")
+		}
 
-		return &Response[apiv0.ServerJSON]{
+		return &ServerDetailOutput{
+			ETag: serverETag(serverDetail),
 			Body: *serverDetail,
 		}, nil
 	})
+
+	// List server versions endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "list-server-versions",
+		Method:      http.MethodGet,
+		Path:        "/v0/servers/{name}/versions",
+		Summary:     "List MCP server versions",
+		Description: "List every version of a server, highest first. Semantic versions are ordered by semver precedence; other versions are treated as opaque strings, ordered by publish time below all semantic versions and excluded by range queries.",
+		Tags:        []string{"servers"},
+		Errors:      []int{http.StatusBadRequest},
+	}, func(_ context.Context, input *ServerVersionsInput) (*Response[ServerVersionListResponse], error) {
+		if _, err := parseFields(input.Fields); err != nil {
+			return nil, err
+		}
+
+		versions, err := registry.ListServerVersions(input.Name, input.Range)
+		if err != nil {
+			return nil, serverVersionError(err)
+		}
+
+		return &Response[ServerVersionListResponse]{
+			Body: ServerVersionListResponse{Versions: versions},
+		}, nil
+	})
+
+	// Resolve latest server version endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "get-latest-server-version",
+		Method:      http.MethodGet,
+		Path:        "/v0/servers/{name}/versions/latest",
+		Summary:     "Get latest MCP server version",
+		Description: "Get the highest non-deleted version of a server, optionally within a semver range",
+		Tags:        []string{"servers"},
+		Errors:      []int{http.StatusBadRequest},
+	}, func(_ context.Context, input *ServerVersionsInput) (*Response[apiv0.ServerJSON], error) {
+		if _, err := parseFields(input.Fields); err != nil {
+			return nil, err
+		}
+
+		server, err := registry.GetLatestServerVersion(input.Name, input.Range)
+		if err != nil {
+			return nil, serverVersionError(err)
+		}
+
+		return &Response[apiv0.ServerJSON]{
+			Body: *server,
+		}, nil
+	})
+
+	// Record usage event endpoint
+	huma.Register(api, huma.Operation{
+		OperationID:   "record-server-event",
+		Method:        http.MethodPost,
+		Path:          "/v0/servers/{name}/events/{event}",
+		Summary:       "Record an MCP server install or view",
+		Description:   "Count an install or view of a server. Counts are aggregated per day and reported in each server's `io.modelcontextprotocol.registry/stats` metadata.",
+		Tags:          []string{"servers"},
+		DefaultStatus: http.StatusNoContent,
+	}, func(_ context.Context, input *ServerEventInput) (*struct{}, error) {
+		if err := registry.RecordServerEvent(input.Name, database.ServerEventType(input.Event)); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Server not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to record event", err)
+		}
+		return nil, nil
+	})
+}
+
+// serverVersionError maps version lookup errors to HTTP errors
+func serverVersionError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidVersionRange):
+		return huma.Error400BadRequest(err.Error())
+	case errors.Is(err, database.ErrNotFound):
+		return huma.Error404NotFound("No matching server version found")
+	default:
+		return huma.Error500InternalServerError("Failed to get server versions", err)
+	}
+}
+
+// serverETag returns the entity tag of a server record, its quoted revision
+func serverETag(server *apiv0.ServerJSON) string {
+	revision := int64(1)
+	if server.Meta != nil && server.Meta.Official != nil && server.Meta.Official.Revision != 0 {
+		revision = server.Meta.Official.Revision
+	}
+	return `"` + strconv.FormatInt(revision, 10) + `"`
 }
@@ -0,0 +1,51 @@
+package v0_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// BenchmarkListServers measures a full page of GET /v0/servers, which is dominated by serializing the servers
+func BenchmarkListServers(b *testing.B) {
+	registryService := service.NewRegistryService(database.NewMemoryDB(), &config.Config{EnableRegistryValidation: false})
+	for i := range 500 {
+		if _, err := registryService.Publish(apiv0.ServerJSON{
+			Name:        fmt.Sprintf("io.github.example/server-%d", i),
+			Description: "A benchmark server",
+			Version:     "1.0.0",
+			Repository:  model.Repository{URL: fmt.Sprintf("https://github.com/example/server-%d", i), Source: "github"},
+			Packages: []model.Package{{
+				RegistryType: model.RegistryTypeNPM,
+				Identifier:   fmt.Sprintf("@example/server-%d", i),
+				Version:      "1.0.0",
+				Transport:    model.Transport{Type: model.TransportTypeStdio},
+			}},
+		}); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v0/servers?limit=100", nil))
+		if rr.Code != http.StatusOK {
+			b.Fatalf("unexpected status %d: %s", rr.Code, rr.Body.String())
+		}
+	}
+}
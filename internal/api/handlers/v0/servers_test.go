@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
@@ -563,3 +564,327 @@ func TestServersEndpointsIntegration(t *testing.T) {
 	// Verify mock expectations
 	// No expectations to verify with real service
 }
+
+func TestServerVersionsEndpoints(t *testing.T) {
+	registryService := service.NewRegistryService(database.NewMemoryDB(), &config.Config{})
+	for _, version := range []string{"1.2.0", "1.10.0", "2.0.0-beta.1", "1.9.3", "nightly"} {
+		_, err := registryService.Publish(apiv0.ServerJSON{
+			Name:        "com.example/versioned-server",
+			Description: "A versioned server",
+			Version:     version,
+		})
+		assert.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+	const base = "/v0/servers/com.example%2Fversioned-server/versions"
+
+	t.Run("lists versions highest first", func(t *testing.T) {
+		w := get(base)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var resp v0.ServerVersionListResponse
+		assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		var versions []string
+		for _, server := range resp.Versions {
+			versions = append(versions, server.Version)
+		}
+		assert.Equal(t, []string{"2.0.0-beta.1", "1.10.0", "1.9.3", "1.2.0", "nightly"}, versions)
+	})
+
+	t.Run("filters by range", func(t *testing.T) {
+		w := get(base + "?range=%5E1.2")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var resp v0.ServerVersionListResponse
+		assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		var versions []string
+		for _, server := range resp.Versions {
+			versions = append(versions, server.Version)
+		}
+		assert.Equal(t, []string{"1.10.0", "1.9.3", "1.2.0"}, versions)
+	})
+
+	t.Run("resolves latest within range", func(t *testing.T) {
+		w := get(base + "/latest?range=~1.9")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var server apiv0.ServerJSON
+		assert.NoError(t, json.NewDecoder(w.Body).Decode(&server))
+		assert.Equal(t, "1.9.3", server.Version)
+	})
+
+	t.Run("rejects invalid range", func(t *testing.T) {
+		w := get(base + "/latest?range=%5Ebanana")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid version range")
+	})
+
+	t.Run("no matching version", func(t *testing.T) {
+		w := get(base + "/latest?range=%3E%3D3")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("unknown server", func(t *testing.T) {
+		w := get("/v0/servers/com.example%2Fmissing/versions")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestServerEventsEndpoint(t *testing.T) {
+	registryService := service.NewRegistryService(database.NewMemoryDB(), &config.Config{})
+	for _, name := range []string{"com.example/popular", "com.example/quiet"} {
+		_, err := registryService.Publish(apiv0.ServerJSON{
+			Name:        name,
+			Description: "A server with usage statistics",
+			Version:     "1.0.0",
+		})
+		assert.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService)
+
+	post := func(path string) int {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, nil))
+		return w.Code
+	}
+
+	for range 3 {
+		assert.Equal(t, http.StatusNoContent, post("/v0/servers/com.example%2Fpopular/events/install"))
+	}
+	assert.Equal(t, http.StatusNoContent, post("/v0/servers/com.example%2Fpopular/events/view"))
+	assert.Equal(t, http.StatusNotFound, post("/v0/servers/com.example%2Fmissing/events/install"))
+	assert.Equal(t, http.StatusUnprocessableEntity, post("/v0/servers/com.example%2Fpopular/events/uninstall"))
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/servers", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp apiv0.ServerListResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	stats := make(map[string]*apiv0.StatsExtensions)
+	for _, server := range resp.Servers {
+		if assert.NotNil(t, server.Meta) {
+			stats[server.Name] = server.Meta.Stats
+		}
+	}
+	assert.Equal(t, &apiv0.StatsExtensions{WeeklyInstalls: 3, WeeklyViews: 1, Trend: apiv0.StatsTrendUp}, stats["com.example/popular"])
+	assert.Equal(t, &apiv0.StatsExtensions{Trend: apiv0.StatsTrendFlat}, stats["com.example/quiet"])
+}
+
+func TestServersListSort(t *testing.T) {
+	registryService := service.NewRegistryService(database.NewMemoryDB(), &config.Config{})
+	for _, name := range []string{"com.example/weather-tools", "io.github.weather/server", "com.example/weather", "com.example/news"} {
+		_, err := registryService.Publish(apiv0.ServerJSON{
+			Name:        name,
+			Description: "A sortable server",
+			Version:     "1.0.0",
+		})
+		assert.NoError(t, err)
+		time.Sleep(time.Millisecond) // distinct publish times
+	}
+	for name, installs := range map[string]int{"com.example/news": 3, "io.github.weather/server": 1, "com.example/weather-tools": 2} {
+		for range installs {
+			assert.NoError(t, registryService.RecordServerEvent(name, database.ServerEventInstall))
+		}
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService)
+
+	list := func(t *testing.T, query string) ([]string, string) {
+		t.Helper()
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/servers?"+query, nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var resp apiv0.ServerListResponse
+		assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		var names []string
+		for _, server := range resp.Servers {
+			names = append(names, server.Name)
+		}
+		return names, resp.Metadata.NextCursor
+	}
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"sort=name", []string{"com.example/news", "com.example/weather", "com.example/weather-tools", "io.github.weather/server"}},
+		{"sort=recent", []string{"com.example/news", "com.example/weather", "io.github.weather/server", "com.example/weather-tools"}},
+		{"sort=downloads", []string{"com.example/news", "com.example/weather-tools", "io.github.weather/server", "com.example/weather"}},
+		{"sort=relevance&search=weather", []string{"com.example/weather", "com.example/weather-tools", "io.github.weather/server"}},
+		{"sort=relevance&search=com.example/weather", []string{"com.example/weather", "com.example/weather-tools"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			names, _ := list(t, tt.query)
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+
+	t.Run("paginates in sort order", func(t *testing.T) {
+		var names []string
+		cursor := ""
+		for {
+			query := "sort=name&limit=3"
+			if cursor != "" {
+				query += "&cursor=" + cursor
+			}
+			page, next := list(t, query)
+			names = append(names, page...)
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+		assert.Equal(t, tests[0].expected, names)
+	})
+
+	t.Run("rejects unknown sort", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/servers?sort=stars", nil))
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+}
+
+func TestServersListFacets(t *testing.T) {
+	registryService := service.NewRegistryService(database.NewMemoryDB(), &config.Config{})
+	for _, server := range []apiv0.ServerJSON{
+		{
+			Name:    "com.example/remote-sse",
+			Remotes: []model.Transport{{Type: model.TransportTypeSSE, URL: "https://sse.example.com/sse"}},
+		},
+		{
+			Name:    "com.example/remote-http",
+			Remotes: []model.Transport{{Type: model.TransportTypeStreamableHTTP, URL: "https://http.example.com/mcp"}},
+		},
+		{
+			Name: "com.example/npm-stdio",
+			Packages: []model.Package{{
+				RegistryType: model.RegistryTypeNPM, Identifier: "@example/npm-stdio", Version: "1.0.0",
+				Transport: model.Transport{Type: model.TransportTypeStdio},
+			}},
+		},
+		{
+			Name: "com.example/oci-sse",
+			Packages: []model.Package{{
+				RegistryType: model.RegistryTypeOCI, Identifier: "example/oci-sse", Version: "1.0.0",
+				Transport: model.Transport{Type: model.TransportTypeSSE, URL: "http://localhost:8080/sse"},
+			}},
+		},
+	} {
+		server.Description = "A faceted server"
+		server.Version = "1.0.0"
+		_, err := registryService.Publish(server)
+		assert.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService)
+
+	tests := []struct {
+		query          string
+		expectedStatus int
+		expected       []string
+	}{
+		{"transport=sse", http.StatusOK, []string{"com.example/oci-sse", "com.example/remote-sse"}},
+		{"transport=stdio", http.StatusOK, []string{"com.example/npm-stdio"}},
+		{"registry=oci", http.StatusOK, []string{"com.example/oci-sse"}},
+		{"transport=sse&registry=oci", http.StatusOK, []string{"com.example/oci-sse"}},
+		{"transport=streamable-http&registry=npm", http.StatusOK, nil},
+		{"transport=websocket", http.StatusUnprocessableEntity, nil},
+		{"registry=cargo", http.StatusUnprocessableEntity, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/servers?sort=name&"+tt.query, nil))
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var resp apiv0.ServerListResponse
+			assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			var names []string
+			for _, server := range resp.Servers {
+				names = append(names, server.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestServersFieldSelection(t *testing.T) {
+	registryService := service.NewRegistryService(database.NewMemoryDB(), &config.Config{})
+	published, err := registryService.Publish(apiv0.ServerJSON{
+		Name:        "com.example/sparse-server",
+		Description: "A server with many fields",
+		Version:     "1.0.0",
+		Remotes:     []model.Transport{{Type: "streamable-http", URL: "https://example.com/mcp"}},
+	})
+	assert.NoError(t, err)
+
+	mux := http.NewServeMux()
+	humaConfig := huma.DefaultConfig("Test API", "1.0.0")
+	humaConfig.Transformers = append(humaConfig.Transformers, v0.SelectFieldsTransformer)
+	api := humago.New(mux, humaConfig)
+	v0.RegisterServersEndpoints(api, registryService)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	t.Run("list", func(t *testing.T) {
+		w := get("/v0/servers?fields=name,version,remotes")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var resp struct {
+			Servers  []map[string]any `json:"servers"`
+			Metadata apiv0.Metadata   `json:"metadata"`
+		}
+		assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, 1, resp.Metadata.Count)
+		if assert.Len(t, resp.Servers, 1) {
+			assert.Len(t, resp.Servers[0], 3)
+			assert.Equal(t, "com.example/sparse-server", resp.Servers[0]["name"])
+			assert.Contains(t, resp.Servers[0], "remotes")
+		}
+	})
+
+	t.Run("detail", func(t *testing.T) {
+		w := get("/v0/servers/" + published.Meta.Official.ID + "?fields=version")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"version":"1.0.0"}`, w.Body.String())
+		assert.NotEmpty(t, w.Header().Get("ETag"))
+	})
+
+	t.Run("versions", func(t *testing.T) {
+		w := get("/v0/servers/com.example%2Fsparse-server/versions?fields=version")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"versions":[{"version":"1.0.0"}]}`, w.Body.String())
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		w := get("/v0/servers?fields=name,secrets")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Unknown field secrets")
+	})
+}
@@ -0,0 +1,314 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// NamespaceReservation describes who holds a namespace
+type NamespaceReservation struct {
+	Namespace string    `json:"namespace" example:"io.github.example"`
+	Owner     string    `json:"owner" doc:"Identity holding the reservation, as <auth method>:<subject>" example:"github-at:example"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ServerTransfer describes a transfer of a server to a new name, with its audit trail
+type ServerTransfer struct {
+	ID          string                   `json:"id" doc:"Transfer ID"`
+	ServerName  string                   `json:"server_name" doc:"Current server name"`
+	NewName     string                   `json:"new_name" doc:"Name the server will have once the transfer completes"`
+	Status      database.TransferStatus  `json:"status" enum:"pending,accepted,completed,cancelled,expired"`
+	RequestedBy string                   `json:"requested_by"`
+	AcceptedBy  string                   `json:"accepted_by,omitempty"`
+	CreatedAt   time.Time                `json:"created_at"`
+	ExpiresAt   time.Time                `json:"expires_at" doc:"When a pending transfer expires unless accepted"`
+	CompletesAt *time.Time               `json:"completes_at,omitempty" doc:"When an accepted transfer takes effect"`
+	Events      []database.TransferEvent `json:"events" doc:"Audit trail of every action taken on the transfer"`
+}
+
+// NamespaceInput represents the input for reading a namespace reservation
+type NamespaceInput struct {
+	Namespace string `path:"namespace" doc:"Namespace, the part of server names before the slash" example:"io.github.example"`
+}
+
+// NamespaceReservationInput represents the input for reserving or releasing a namespace
+type NamespaceReservationInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token" required:"true"`
+	Namespace     string `path:"namespace" doc:"Namespace, the part of server names before the slash" example:"io.github.example"`
+}
+
+// RequestTransferBody represents the request body for starting a transfer
+type RequestTransferBody struct {
+	ServerName string `json:"server_name" doc:"Server to transfer" minLength:"1" maxLength:"200" example:"io.github.oldorg/server"`
+	NewName    string `json:"new_name" doc:"Name in the receiving namespace" minLength:"1" maxLength:"200" example:"io.github.neworg/server"`
+}
+
+// RequestTransferInput represents the input for starting a transfer
+type RequestTransferInput struct {
+	Authorization string              `header:"Authorization" doc:"Registry JWT token with publish permission for the server" required:"true"`
+	Body          RequestTransferBody `body:""`
+}
+
+// TransferInput represents the input for reading a transfer
+type TransferInput struct {
+	ID string `path:"id" doc:"Transfer ID (UUID)" format:"uuid"`
+}
+
+// TransferActionInput represents the input for accepting or cancelling a transfer
+type TransferActionInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token" required:"true"`
+	ID            string `path:"id" doc:"Transfer ID (UUID)" format:"uuid"`
+}
+
+// RegisterTransferEndpoints registers the namespace reservation and server transfer endpoints
+func RegisterTransferEndpoints(api huma.API, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "reserve-namespace",
+		Method:        http.MethodPost,
+		Path:          "/v0/namespaces/{namespace}/reservation",
+		Summary:       "Reserve namespace",
+		Description:   "Reserve a namespace before publishing to it, so no other identity can publish there even if it could otherwise prove ownership",
+		Tags:          []string{"transfers"},
+		DefaultStatus: http.StatusCreated,
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *NamespaceReservationInput) (*Response[NamespaceReservation], error) {
+		if err := rejectOnMirror(cfg); err != nil {
+			return nil, err
+		}
+		claims, err := validateBearerJWT(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		if !jwtManager.CanGrant(input.Namespace+"/*", auth.PermissionActionPublish, claims.Permissions) {
+			return nil, huma.Error403Forbidden("You do not have publish permission for every server in namespace " + input.Namespace)
+		}
+
+		reservation, err := registry.ReserveNamespace(claimsOwner(claims), input.Namespace)
+		if err != nil {
+			switch {
+			case errors.Is(err, database.ErrAlreadyExists):
+				return nil, huma.Error409Conflict("Namespace " + input.Namespace + " is already reserved")
+			case errors.Is(err, database.ErrInvalidInput):
+				return nil, huma.Error400BadRequest(err.Error())
+			}
+			return nil, huma.Error500InternalServerError("Failed to reserve namespace", err)
+		}
+
+		return &Response[NamespaceReservation]{Body: toNamespaceReservation(reservation)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-namespace-reservation",
+		Method:      http.MethodGet,
+		Path:        "/v0/namespaces/{namespace}/reservation",
+		Summary:     "Get namespace reservation",
+		Description: "Get which identity, if any, has reserved a namespace",
+		Tags:        []string{"transfers"},
+		Errors:      []int{http.StatusNotFound},
+	}, func(_ context.Context, input *NamespaceInput) (*Response[NamespaceReservation], error) {
+		reservation, err := registry.GetNamespaceReservation(input.Namespace)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Namespace is not reserved")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get namespace reservation", err)
+		}
+
+		return &Response[NamespaceReservation]{Body: toNamespaceReservation(reservation)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "release-namespace",
+		Method:        http.MethodDelete,
+		Path:          "/v0/namespaces/{namespace}/reservation",
+		Summary:       "Release namespace",
+		Description:   "Release a namespace you reserved",
+		Tags:          []string{"transfers"},
+		DefaultStatus: http.StatusNoContent,
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *NamespaceReservationInput) (*struct{}, error) {
+		if err := rejectOnMirror(cfg); err != nil {
+			return nil, err
+		}
+		claims, err := validateBearerJWT(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := registry.ReleaseNamespace(claimsOwner(claims), input.Namespace); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("You have not reserved namespace " + input.Namespace)
+			}
+			return nil, huma.Error500InternalServerError("Failed to release namespace", err)
+		}
+
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "request-transfer",
+		Method:        http.MethodPost,
+		Path:          "/v0/transfers",
+		Summary:       "Request server transfer",
+		Description:   "Start moving every version of a server to a new name, e.g. after an organization rename. The transfer takes effect once someone who can publish the new name accepts it and the grace period has passed.",
+		Tags:          []string{"transfers"},
+		DefaultStatus: http.StatusCreated,
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *RequestTransferInput) (*Response[ServerTransfer], error) {
+		if err := rejectOnMirror(cfg); err != nil {
+			return nil, err
+		}
+		claims, err := validateBearerJWT(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		if !jwtManager.HasPermission(input.Body.ServerName, auth.PermissionActionPublish, claims.Permissions) {
+			return nil, huma.Error403Forbidden("You do not have publish permission for " + input.Body.ServerName)
+		}
+
+		transfer, err := registry.RequestTransfer(claimsOwner(claims), input.Body.ServerName, input.Body.NewName)
+		if err != nil {
+			return nil, transferError(err)
+		}
+
+		return &Response[ServerTransfer]{Body: toServerTransfer(transfer)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-transfer",
+		Method:      http.MethodGet,
+		Path:        "/v0/transfers/{id}",
+		Summary:     "Get server transfer",
+		Description: "Get the state and audit trail of a server transfer",
+		Tags:        []string{"transfers"},
+		Errors:      []int{http.StatusNotFound},
+	}, func(_ context.Context, input *TransferInput) (*Response[ServerTransfer], error) {
+		transfer, err := registry.GetTransfer(input.ID)
+		if err != nil {
+			return nil, transferError(err)
+		}
+
+		return &Response[ServerTransfer]{Body: toServerTransfer(transfer)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "accept-transfer",
+		Method:      http.MethodPost,
+		Path:        "/v0/transfers/{id}/accept",
+		Summary:     "Accept server transfer",
+		Description: "Accept a pending transfer into a namespace you can publish to. The transfer completes after the grace period, during which either party can still cancel it.",
+		Tags:        []string{"transfers"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *TransferActionInput) (*Response[ServerTransfer], error) {
+		if err := rejectOnMirror(cfg); err != nil {
+			return nil, err
+		}
+		claims, err := validateBearerJWT(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		transfer, err := registry.GetTransfer(input.ID)
+		if err != nil {
+			return nil, transferError(err)
+		}
+		if !jwtManager.HasPermission(transfer.NewName, auth.PermissionActionPublish, claims.Permissions) {
+			return nil, huma.Error403Forbidden("You do not have publish permission for " + transfer.NewName)
+		}
+
+		transfer, err = registry.AcceptTransfer(claimsOwner(claims), input.ID)
+		if err != nil {
+			return nil, transferError(err)
+		}
+
+		return &Response[ServerTransfer]{Body: toServerTransfer(transfer)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "cancel-transfer",
+		Method:      http.MethodPost,
+		Path:        "/v0/transfers/{id}/cancel",
+		Summary:     "Cancel server transfer",
+		Description: "Cancel a transfer you requested or accepted, any time before it completes",
+		Tags:        []string{"transfers"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *TransferActionInput) (*Response[ServerTransfer], error) {
+		if err := rejectOnMirror(cfg); err != nil {
+			return nil, err
+		}
+		claims, err := validateBearerJWT(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		transfer, err := registry.CancelTransfer(claimsOwner(claims), input.ID)
+		if err != nil {
+			return nil, transferError(err)
+		}
+
+		return &Response[ServerTransfer]{Body: toServerTransfer(transfer)}, nil
+	})
+}
+
+// rejectOnMirror refuses changes on a read-only mirror, which only accepts changes from its upstream
+func rejectOnMirror(cfg *config.Config) error {
+	if cfg.MirrorUpstreamURL != "" {
+		return huma.Error403Forbidden("This registry is a read-only mirror of " + cfg.MirrorUpstreamURL + "; make changes there instead")
+	}
+	return nil
+}
+
+func transferError(err error) error {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return huma.Error404NotFound("Transfer or server not found")
+	case errors.Is(err, service.ErrNamespaceReserved):
+		return NewError(http.StatusForbidden, err.Error(), err)
+	case errors.Is(err, service.ErrInvalidTransfer):
+		return NewError(http.StatusConflict, err.Error(), err)
+	}
+	return huma.Error500InternalServerError("Failed to process transfer", err)
+}
+
+func toNamespaceReservation(reservation *database.NamespaceReservation) NamespaceReservation {
+	return NamespaceReservation{
+		Namespace: reservation.Namespace,
+		Owner:     reservation.Owner,
+		CreatedAt: reservation.CreatedAt,
+	}
+}
+
+func toServerTransfer(transfer *database.ServerTransfer) ServerTransfer {
+	return ServerTransfer{
+		ID:          transfer.ID,
+		ServerName:  transfer.ServerName,
+		NewName:     transfer.NewName,
+		Status:      transfer.Status,
+		RequestedBy: transfer.RequestedBy,
+		AcceptedBy:  transfer.AcceptedBy,
+		CreatedAt:   transfer.CreatedAt,
+		ExpiresAt:   transfer.ExpiresAt,
+		CompletesAt: transfer.CompletesAt,
+		Events:      transfer.Events,
+	}
+}
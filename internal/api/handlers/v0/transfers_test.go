@@ -0,0 +1,117 @@
+package v0_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferEndpoints(t *testing.T) {
+	testConfig := &config.Config{
+		JWTPrivateKey: "bb2c6b424005acd5df47a9e2c87f446def86dd740c888ea3efb825b23f7ef47c",
+	}
+	registryService := service.NewRegistryService(database.NewMemoryDB(), testConfig)
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, registryService, testConfig)
+	v0.RegisterTransferEndpoints(api, registryService, testConfig)
+
+	tokenFor := func(user string) string {
+		token, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: user,
+			Permissions: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: "io.github." + user + "/*"},
+			},
+		})
+		require.NoError(t, err)
+		return token
+	}
+	oldOrg, newOrg := tokenFor("oldorg"), tokenFor("neworg")
+
+	rr := doJSONRequest(t, mux, http.MethodPost, "/v0/publish", oldOrg, apiv0.ServerJSON{
+		Name: "io.github.oldorg/server", Description: "A server", Version: "1.0.0",
+	})
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	t.Run("reservations", func(t *testing.T) {
+		rr := doJSONRequest(t, mux, http.MethodPost, "/v0/namespaces/io.github.neworg/reservation", oldOrg, nil)
+		assert.Equal(t, http.StatusForbidden, rr.Code, "reserving requires publish permission for the whole namespace")
+
+		rr = doJSONRequest(t, mux, http.MethodPost, "/v0/namespaces/io.github.neworg/reservation", newOrg, nil)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		rr = doJSONRequest(t, mux, http.MethodGet, "/v0/namespaces/io.github.neworg/reservation", "", nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+		var reservation v0.NamespaceReservation
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &reservation))
+		assert.Equal(t, "github-at:neworg", reservation.Owner)
+
+		rr = doJSONRequest(t, mux, http.MethodPost, "/v0/namespaces/io.github.neworg/reservation", newOrg, nil)
+		assert.Equal(t, http.StatusConflict, rr.Code)
+
+		// Another identity with publish permission is locked out of the reserved namespace
+		otherIdentity, err := generateTestJWTToken(testConfig, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubOIDC,
+			AuthMethodSubject: "neworg",
+			Permissions: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.neworg/*"},
+			},
+		})
+		require.NoError(t, err)
+		rr = doJSONRequest(t, mux, http.MethodPost, "/v0/publish", otherIdentity, apiv0.ServerJSON{
+			Name: "io.github.neworg/other", Description: "A server", Version: "1.0.0",
+		})
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Contains(t, rr.Body.String(), "reserved")
+		assert.Equal(t, v0.ErrorCodeNamespaceReserved, problemCode(t, rr))
+	})
+
+	t.Run("transfer", func(t *testing.T) {
+		rr := doJSONRequest(t, mux, http.MethodPost, "/v0/transfers", newOrg, v0.RequestTransferBody{
+			ServerName: "io.github.oldorg/server", NewName: "io.github.neworg/server",
+		})
+		assert.Equal(t, http.StatusForbidden, rr.Code, "only the current owner can request a transfer")
+
+		rr = doJSONRequest(t, mux, http.MethodPost, "/v0/transfers", oldOrg, v0.RequestTransferBody{
+			ServerName: "io.github.oldorg/server", NewName: "io.github.neworg/server",
+		})
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var transfer v0.ServerTransfer
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &transfer))
+		assert.Equal(t, database.TransferStatusPending, transfer.Status)
+
+		rr = doJSONRequest(t, mux, http.MethodPost, "/v0/transfers/"+transfer.ID+"/accept", oldOrg, nil)
+		assert.Equal(t, http.StatusForbidden, rr.Code, "only the recipient can accept")
+
+		rr = doJSONRequest(t, mux, http.MethodPost, "/v0/transfers/"+transfer.ID+"/accept", newOrg, nil)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &transfer))
+		assert.Equal(t, database.TransferStatusAccepted, transfer.Status)
+		assert.Equal(t, "github-at:neworg", transfer.AcceptedBy)
+
+		rr = doJSONRequest(t, mux, http.MethodPost, "/v0/transfers/"+transfer.ID+"/cancel", oldOrg, nil)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		rr = doJSONRequest(t, mux, http.MethodGet, "/v0/transfers/"+transfer.ID, "", nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &transfer))
+		assert.Equal(t, database.TransferStatusCancelled, transfer.Status)
+		assert.Len(t, transfer.Events, 3)
+
+		rr = doJSONRequest(t, mux, http.MethodPost, "/v0/transfers/"+transfer.ID+"/accept", newOrg, nil)
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.Equal(t, v0.ErrorCodeTransferInvalid, problemCode(t, rr))
+	})
+}
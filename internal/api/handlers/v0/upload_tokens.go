@@ -0,0 +1,78 @@
+package v0
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+)
+
+// CreateUploadTokenBody represents the request body for minting an upload token
+type CreateUploadTokenBody struct {
+	Name             string `json:"name" doc:"Server name the token may publish, within your own publish permissions" minLength:"1" maxLength:"200" example:"io.github.example/weather"`
+	Version          string `json:"version" doc:"Server version the token may publish" minLength:"1" maxLength:"255" example:"1.0.2"`
+	ExpiresInSeconds int    `json:"expires_in_seconds,omitempty" doc:"Seconds until the token expires" default:"600" minimum:"1" maximum:"3600"`
+}
+
+// CreateUploadTokenInput represents the input for minting an upload token
+type CreateUploadTokenInput struct {
+	Authorization string                `header:"Authorization" doc:"Registry JWT token" required:"true"`
+	Body          CreateUploadTokenBody `body:""`
+}
+
+// RegisterUploadTokenEndpoint registers the endpoint minting upload tokens
+func RegisterUploadTokenEndpoint(api huma.API, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-upload-token",
+		Method:        http.MethodPost,
+		Path:          "/v0/auth/upload-token",
+		Summary:       "Create upload token",
+		Description:   "Mint a short-lived token that can publish one version of one server, once, for handing to CI. It is only accepted by /v0/publish and /v0/publish/async, and is used up once a publish it authorizes succeeds; dry runs and failed publishes leave it usable.",
+		Tags:          []string{"auth"},
+		DefaultStatus: http.StatusCreated,
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *CreateUploadTokenInput) (*Response[auth.TokenResponse], error) {
+		claims, err := validateBearerJWT(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		// Tokens are bound to a single server, so the name can't be a pattern
+		if strings.Contains(input.Body.Name, "*") {
+			return nil, huma.Error400BadRequest("Upload tokens are bound to a single server; the name can't contain *")
+		}
+		if err := validators.ValidateServerName(input.Body.Name); err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name", err)
+		}
+
+		if !jwtManager.CanGrant(input.Body.Name, auth.PermissionActionPublish, claims.Permissions) {
+			return nil, huma.Error403Forbidden(buildPermissionErrorMessage(input.Body.Name, claims.Permissions))
+		}
+
+		expiresInSeconds := input.Body.ExpiresInSeconds
+		if expiresInSeconds <= 0 {
+			expiresInSeconds = 600
+		}
+
+		token, err := jwtManager.GenerateUploadToken(ctx, auth.JWTClaims{
+			AuthMethod:        claims.AuthMethod,
+			AuthMethodSubject: claims.AuthMethodSubject,
+		}, input.Body.Name, input.Body.Version, time.Duration(expiresInSeconds)*time.Second)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to create upload token", err)
+		}
+
+		return &Response[auth.TokenResponse]{
+			Body: *token,
+		}, nil
+	})
+}
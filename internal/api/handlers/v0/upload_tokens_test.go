@@ -0,0 +1,108 @@
+package v0_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadTokenEndpoint(t *testing.T) {
+	publishAPI := setupPublishTest(t, &config.Config{EnableRegistryValidation: false})
+	mux := publishAPI.mux
+	v0.RegisterAPIKeyEndpoints(publishAPI.api, publishAPI.registry, publishAPI.cfg)
+	v0.RegisterUploadTokenEndpoint(publishAPI.api, publishAPI.cfg)
+
+	token, err := generateTestJWTToken(publishAPI.cfg, auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: "example",
+		Permissions: []auth.Permission{
+			{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.example/*"},
+		},
+	})
+	require.NoError(t, err)
+
+	server := func(name, version string) apiv0.ServerJSON {
+		return apiv0.ServerJSON{Name: name, Description: "A test server", Version: version}
+	}
+
+	// Tokens can only be minted within the caller's permissions
+	rr := doJSONRequest(t, mux, http.MethodPost, "/v0/auth/upload-token", token, v0.CreateUploadTokenBody{
+		Name: "io.github.other/server", Version: "1.0.0",
+	})
+	assert.Equal(t, http.StatusForbidden, rr.Code, rr.Body.String())
+
+	// And for a single, well-formed server name
+	for _, name := range []string{"io.github.example/*", "io.github.example/server/extra", "no-namespace"} {
+		rr = doJSONRequest(t, mux, http.MethodPost, "/v0/auth/upload-token", token, v0.CreateUploadTokenBody{
+			Name: name, Version: "1.0.0",
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code, "%s: %s", name, rr.Body.String())
+	}
+
+	rr = doJSONRequest(t, mux, http.MethodPost, "/v0/auth/upload-token", token, v0.CreateUploadTokenBody{
+		Name: "io.github.example/server", Version: "1.0.0",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var uploadToken auth.TokenResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &uploadToken))
+
+	// The token is bound to its server name and version
+	rr = doJSONRequest(t, mux, http.MethodPost, "/v0/publish", uploadToken.RegistryToken, server("io.github.example/other", "1.0.0"))
+	assert.Equal(t, http.StatusForbidden, rr.Code, rr.Body.String())
+	rr = doJSONRequest(t, mux, http.MethodPost, "/v0/publish", uploadToken.RegistryToken, server("io.github.example/server", "1.0.1"))
+	assert.Equal(t, http.StatusForbidden, rr.Code, rr.Body.String())
+
+	// It isn't accepted outside the publish handler
+	rr = doJSONRequest(t, mux, http.MethodGet, "/v0/auth/api-keys", uploadToken.RegistryToken, nil)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code, rr.Body.String())
+
+	// Dry runs and failed publishes don't use it up
+	rr = doJSONRequest(t, mux, http.MethodPost, "/v0/publish?dry_run=true", uploadToken.RegistryToken, server("io.github.example/server", "1.0.0"))
+	assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	invalid := server("io.github.example/server", "1.0.0")
+	invalid.Repository = model.Repository{URL: "not a url", Source: "github"}
+	rr = doJSONRequest(t, mux, http.MethodPost, "/v0/publish", uploadToken.RegistryToken, invalid)
+	assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+
+	// But a successful publish does, so it can only publish once
+	rr = doJSONRequest(t, mux, http.MethodPost, "/v0/publish", uploadToken.RegistryToken, server("io.github.example/server", "1.0.0"))
+	assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	rr = doJSONRequest(t, mux, http.MethodPost, "/v0/publish", uploadToken.RegistryToken, server("io.github.example/server", "1.0.0"))
+	assert.Equal(t, http.StatusUnauthorized, rr.Code, rr.Body.String())
+	rr = doJSONRequest(t, mux, http.MethodPost, "/v0/publish?dry_run=true", uploadToken.RegistryToken, server("io.github.example/server", "1.0.0"))
+	assert.Equal(t, http.StatusUnauthorized, rr.Code, rr.Body.String())
+
+	// Retries of a publish made with an idempotency key get its stored response
+	rr = doJSONRequest(t, mux, http.MethodPost, "/v0/auth/upload-token", token, v0.CreateUploadTokenBody{
+		Name: "io.github.example/server", Version: "1.0.1",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &uploadToken))
+	publishIdempotent := func() *httptest.ResponseRecorder {
+		body, err := json.Marshal(server("io.github.example/server", "1.0.1"))
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/v0/publish", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+uploadToken.RegistryToken)
+		req.Header.Set("Idempotency-Key", "key-1")
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+	first := publishIdempotent()
+	require.Equal(t, http.StatusOK, first.Code, first.Body.String())
+	retry := publishIdempotent()
+	require.Equal(t, http.StatusOK, retry.Code, retry.Body.String())
+	assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+	assert.JSONEq(t, first.Body.String(), retry.Body.String())
+}
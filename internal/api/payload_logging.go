@@ -0,0 +1,202 @@
+package api
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+const (
+	// TraceIDHeader carries the trace ID payload logs are correlated by, so publishers can quote it
+	TraceIDHeader = "X-Trace-Id"
+
+	// maxLoggedPayloadBytes is the largest body that is logged; larger bodies are logged by size only, since a
+	// truncated document can't be reliably redacted
+	maxLoggedPayloadBytes = 64 << 10
+
+	redacted = "[REDACTED]"
+)
+
+// sensitiveHeaders are headers whose values are never logged, alongside any whose name mentions a token or secret
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"X-Api-Key":           true,
+}
+
+// sensitiveKeyParts mark JSON members whose values are redacted wherever they appear
+var sensitiveKeyParts = []string{"token", "secret", "password", "authorization", "api_key", "apikey", "private_key", "signature"}
+
+// PayloadLoggingMiddleware logs the request and response bodies of publish operations, with credentials and
+// environment variable values redacted, when payload logging is enabled. Each exchange is logged under a trace ID,
+// taken from the W3C traceparent header if there is one and returned in the X-Trace-Id header.
+func PayloadLoggingMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !cfg.PayloadLogging {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isPublishOperation(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			traceID := requestTraceID(r)
+			w.Header().Set(TraceIDHeader, traceID)
+
+			// Read only as much of the body as is logged, leaving the rest for the handler and its size limits
+			prefix, _ := io.ReadAll(io.LimitReader(r.Body, maxLoggedPayloadBytes+1))
+			r.Body = readCloser{io.MultiReader(bytes.NewReader(prefix), r.Body), r.Body}
+			headers, _ := json.Marshal(redactHeaders(r.Header))
+			log.Printf("payload trace_id=%s request %s %s headers=%s body=%s", traceID, r.Method, r.URL.Path, headers, redactPayload(prefix))
+
+			lw := &loggingWriter{ResponseWriter: w}
+			next.ServeHTTP(lw, r)
+			log.Printf("payload trace_id=%s response status=%d body=%s", traceID, lw.status, redactPayload(lw.body))
+		})
+	}
+}
+
+// isPublishOperation reports whether a request publishes, edits or checks on the publishing of a server,
+// including for a tenant
+func isPublishOperation(r *http.Request) bool {
+	path := r.URL.Path
+	if rest, ok := strings.CutPrefix(path, TenantPathPrefix); ok {
+		_, path, _ = strings.Cut(rest, "/")
+		path = "/" + path
+	}
+	if strings.HasPrefix(path, "/v0/publish") {
+		return true
+	}
+	return r.Method != http.MethodGet && r.Method != http.MethodHead && strings.HasPrefix(path, "/v0/servers/")
+}
+
+// requestTraceID returns the trace ID of a W3C traceparent header, or a new random one
+func requestTraceID(r *http.Request) string {
+	parts := strings.Split(r.Header.Get("Traceparent"), "-")
+	if len(parts) == 4 && len(parts[1]) == 32 && parts[1] != strings.Repeat("0", 32) {
+		if _, err := hex.DecodeString(parts[1]); err == nil {
+			return strings.ToLower(parts[1])
+		}
+	}
+
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// redactHeaders returns the headers with the values of sensitive ones replaced
+func redactHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for name, values := range header {
+		lower := strings.ToLower(name)
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] || strings.Contains(lower, "token") || strings.Contains(lower, "secret") {
+			headers[name] = redacted
+			continue
+		}
+		headers[name] = strings.Join(values, ", ")
+	}
+	return headers
+}
+
+// redactPayload returns a body as it should be logged: JSON with credentials and environment variable values
+// redacted, or only its size if it is too large, isn't JSON or is empty
+func redactPayload(body []byte) string {
+	if len(body) == 0 {
+		return "-"
+	}
+	if len(body) > maxLoggedPayloadBytes {
+		return "[more than 64KiB not shown]"
+	}
+
+	var document any
+	if err := json.Unmarshal(body, &document); err != nil {
+		return "[non-JSON body of " + strconv.Itoa(len(body)) + " bytes not shown]"
+	}
+	out, err := json.Marshal(redactValue(document, false))
+	if err != nil {
+		return "[body not shown]"
+	}
+	return string(out)
+}
+
+// redactValue replaces sensitive members of a JSON value. Inside environment variables and headers, which
+// publishers use for credentials, every value and default is redacted too.
+func redactValue(v any, inVariables bool) any {
+	switch value := v.(type) {
+	case map[string]any:
+		for key, member := range value {
+			lower := strings.ToLower(key)
+			switch {
+			case isSensitiveKey(lower), inVariables && (lower == "value" || lower == "default"):
+				value[key] = redacted
+			default:
+				value[key] = redactValue(member, inVariables || lower == "environment_variables" || lower == "environmentvariables" || lower == "headers")
+			}
+		}
+		return value
+	case []any:
+		for i, item := range value {
+			value[i] = redactValue(item, inVariables)
+		}
+		return value
+	default:
+		return v
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// readCloser reads from a replayed body while closing the original
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// loggingWriter keeps the status and up to the logged size of a response body, plus a byte to tell if there
+// was more
+type loggingWriter struct {
+	http.ResponseWriter
+	status int
+	body   []byte
+}
+
+func (w *loggingWriter) WriteHeader(statusCode int) {
+	if w.status == 0 && statusCode >= 200 {
+		w.status = statusCode
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *loggingWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if room := maxLoggedPayloadBytes + 1 - len(w.body); room > 0 {
+		w.body = append(w.body, b[:min(room, len(b))]...)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (w *loggingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
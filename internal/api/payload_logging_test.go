@@ -0,0 +1,90 @@
+package api_test
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/api"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+func TestPayloadLoggingMiddleware(t *testing.T) {
+	var logs bytes.Buffer
+	original := log.Writer()
+	log.SetOutput(&logs)
+	t.Cleanup(func() { log.SetOutput(original) })
+
+	var received string
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"com.example/server","_meta":{"registry_token":"issued-token"}}`))
+	})
+	handler := api.PayloadLoggingMiddleware(&config.Config{PayloadLogging: true})(echo)
+
+	body := `{"name":"com.example/server","packages":[{"environment_variables":[{"name":"API_KEY","value":"sk-live-credential","default":"fallback-credential"}]}],"remotes":[{"url":"https://example.com/mcp","headers":[{"name":"X-Custom","value":"header-credential"}]}],"client_secret":"oauth-credential"}`
+	req := httptest.NewRequest(http.MethodPost, "/v0/publish", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer jwt-credential")
+	req.Header.Set("Traceparent", "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, body, received, "the handler should receive the full body")
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", rr.Header().Get(api.TraceIDHeader))
+
+	output := logs.String()
+	assert.Equal(t, 2, strings.Count(output, "trace_id=4bf92f3577b34da6a3ce929d0e0e4736"))
+	assert.Contains(t, output, "com.example/server")
+	assert.Contains(t, output, "status=200")
+	for _, secret := range []string{"jwt-credential", "sk-live-credential", "fallback-credential", "header-credential", "oauth-credential", "issued-token"} {
+		assert.NotContains(t, output, secret)
+	}
+}
+
+func TestPayloadLoggingMiddleware_Scope(t *testing.T) {
+	var logs bytes.Buffer
+	original := log.Writer()
+	log.SetOutput(&logs)
+	t.Cleanup(func() { log.SetOutput(original) })
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name    string
+		enabled bool
+		method  string
+		path    string
+		logged  bool
+	}{
+		{"publish", true, http.MethodPost, "/v0/publish", true},
+		{"publish status", true, http.MethodGet, "/v0/publish/status/123", true},
+		{"edit", true, http.MethodPut, "/v0/servers/123", true},
+		{"tenant publish", true, http.MethodPost, "/tenants/internal/v0/publish", true},
+		{"read", true, http.MethodGet, "/v0/servers/123", false},
+		{"disabled", false, http.MethodPost, "/v0/publish", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs.Reset()
+			handler := api.PayloadLoggingMiddleware(&config.Config{PayloadLogging: tt.enabled})(ok)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(tt.method, tt.path, nil))
+
+			require.Equal(t, http.StatusNoContent, rr.Code)
+			assert.Equal(t, tt.logged, logs.Len() > 0)
+			assert.Equal(t, tt.logged, rr.Header().Get(api.TraceIDHeader) != "")
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package router_test
+
+import (
+	"encoding/json"
+	"flag"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/api/router"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/telemetry"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden OpenAPI spec")
+
+var goldenSpecPath = filepath.Join("testdata", "openapi.golden.json")
+
+// TestOpenAPISpec keeps the checked-in spec in sync with the registered handlers.
+// After changing an endpoint, regenerate it with `make openapi`.
+func TestOpenAPISpec(t *testing.T) {
+	// Endpoints behind feature flags (anonymous auth, OIDC) are left out, as in the default configuration
+	cfg := &config.Config{
+		JWTPrivateKey: "bb2c6b424005acd5df47a9e2c87f446def86dd740c888ea3efb825b23f7ef47c",
+	}
+	shutdownTelemetry, metrics, err := telemetry.InitMetrics("test")
+	require.NoError(t, err)
+	defer func() { _ = shutdownTelemetry(t.Context()) }()
+
+	registryService := service.NewRegistryService(database.NewMemoryDB(), cfg)
+	api := router.NewHumaAPI(cfg, registryService, http.NewServeMux(), metrics)
+
+	spec, err := json.MarshalIndent(api.OpenAPI(), "", "  ")
+	require.NoError(t, err)
+	spec = append(spec, '\n')
+
+	if *updateGolden {
+		require.NoError(t, os.WriteFile(goldenSpecPath, spec, 0o600))
+	}
+
+	golden, err := os.ReadFile(goldenSpecPath)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(golden), string(spec), "OpenAPI spec is out of date; run `make openapi` to regenerate %s", goldenSpecPath)
+}
+
+func TestOpenAPISpec_ErrorResponses(t *testing.T) {
+	cfg := &config.Config{
+		JWTPrivateKey: "bb2c6b424005acd5df47a9e2c87f446def86dd740c888ea3efb825b23f7ef47c",
+	}
+	shutdownTelemetry, metrics, err := telemetry.InitMetrics("test")
+	require.NoError(t, err)
+	defer func() { _ = shutdownTelemetry(t.Context()) }()
+
+	api := router.NewHumaAPI(cfg, service.NewRegistryService(database.NewMemoryDB(), cfg), http.NewServeMux(), metrics)
+
+	publish := api.OpenAPI().Paths["/v0/publish"].Post
+	require.NotNil(t, publish)
+	assert.NotContains(t, publish.Responses, "default")
+	for _, status := range []string{"400", "401", "403", "422", "500"} {
+		require.Contains(t, publish.Responses, status)
+		assert.Equal(t, "#/components/schemas/ErrorModel", publish.Responses[status].Content["application/problem+json"].Schema.Ref)
+	}
+
+	getServer := api.OpenAPI().Paths["/v0/servers/{id}"].Get
+	require.NotNil(t, getServer)
+	assert.Contains(t, getServer.Responses, "404")
+	assert.NotContains(t, getServer.Responses, "401")
+}
@@ -2,9 +2,9 @@
 package router
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,6 +13,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	"github.com/modelcontextprotocol/registry/internal/telemetry"
@@ -95,9 +96,6 @@ func WithSkipPaths(paths ...string) MiddlewareOption {
 
 // handle404 returns a helpful 404 error with suggestions for common mistakes
 func handle404(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(http.StatusNotFound)
-
 	path := r.URL.Path
 	detail := "Endpoint not found. See /docs for the API documentation."
 
@@ -109,40 +107,76 @@ func handle404(w http.ResponseWriter, r *http.Request) {
 		)
 	}
 
-	errorBody := map[string]interface{}{
-		"title":  "Not Found",
-		"status": 404,
-		"detail": detail,
-	}
+	v0.WriteProblem(w, r, http.StatusNotFound, detail)
+}
 
-	// Use JSON marshal to ensure consistent formatting
-	jsonData, err := json.Marshal(errorBody)
-	if err != nil {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+// documentErrorResponses replaces huma's catch-all error response with the statuses an operation
+// can actually return, inferred from its inputs and security requirements
+func documentErrorResponses(_ *huma.OpenAPI, op *huma.Operation) {
+	defaultResponse, ok := op.Responses["default"]
+	if !ok {
 		return
 	}
-	_, err = w.Write(jsonData)
-	if err != nil {
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+
+	hasPathParams := false
+	for _, param := range op.Parameters {
+		if param.In == "path" {
+			hasPathParams = true
+		}
+	}
+
+	statuses := []int{http.StatusInternalServerError}
+	if op.RequestBody != nil {
+		statuses = append(statuses, http.StatusBadRequest)
+	}
+	if op.RequestBody != nil || len(op.Parameters) > 0 {
+		statuses = append(statuses, http.StatusUnprocessableEntity)
+	}
+	if len(op.Security) > 0 {
+		statuses = append(statuses, http.StatusUnauthorized, http.StatusForbidden)
+	}
+	if hasPathParams {
+		statuses = append(statuses, http.StatusNotFound)
+	}
+
+	delete(op.Responses, "default")
+	for _, status := range statuses {
+		op.Responses[strconv.Itoa(status)] = &huma.Response{
+			Description: http.StatusText(status),
+			Content:     defaultResponse.Content,
+		}
 	}
 }
 
 // NewHumaAPI creates a new Huma API with all routes registered
 func NewHumaAPI(cfg *config.Config, registry service.RegistryService, mux *http.ServeMux, metrics *telemetry.Metrics) huma.API {
+	// Give every error response a machine-readable code
+	huma.NewError = v0.NewError
+
 	// Create Huma API configuration
 	humaConfig := huma.DefaultConfig("Official MCP Registry", "1.0.0")
 	humaConfig.Info.Description = "A community driven registry service for Model Context Protocol (MCP) servers.\n\n[GitHub repository](https://github.com/modelcontextprotocol/registry) | [Documentation](https://github.com/modelcontextprotocol/registry/tree/main/docs)"
 	// Disable $schema property in responses: https://github.com/danielgtaylor/huma/issues/230
 	humaConfig.CreateHooks = []func(huma.Config) huma.Config{}
+	// Trim servers in read responses to the fields requested with ?fields=
+	humaConfig.Transformers = append(humaConfig.Transformers, v0.SelectFieldsTransformer)
+	// Identify the request each error response is about
+	humaConfig.Transformers = append(humaConfig.Transformers, v0.ProblemTransformer)
 
 	// Create a new API using humago adapter for standard library
 	api := humago.New(mux, humaConfig)
 
+	// Document the error responses each operation can return, rather than a catch-all default
+	api.OpenAPI().OnAddOperation = append(api.OpenAPI().OnAddOperation, documentErrorResponses)
+
 	// Add metrics middleware with options
 	api.UseMiddleware(MetricTelemetryMiddleware(metrics,
 		WithSkipPaths("/health", "/metrics", "/ping", "/docs"),
 	))
 
+	// Apply the read access model to catalog reads
+	api.UseMiddleware(v0.ReadAccessMiddleware(api, registry, cfg))
+
 	// Register routes for all API versions
 	RegisterV0Routes(api, cfg, registry, metrics)
 
@@ -16,8 +16,20 @@ func RegisterV0Routes(
 ) {
 	v0.RegisterHealthEndpoint(api, cfg, metrics)
 	v0.RegisterPingEndpoint(api)
+	v0.RegisterSchemaEndpoint(api)
 	v0.RegisterServersEndpoints(api, registry)
+	v0.RegisterCategoryEndpoints(api, registry, cfg)
+	v0.RegisterIconEndpoints(api, registry, cfg)
+	v0.RegisterAdvisoryEndpoints(api, registry, cfg)
+	v0.RegisterExportEndpoints(api, registry, cfg)
+	v0.RegisterGraphQLEndpoint(api, registry)
+	v0.RegisterChangesEndpoint(api, registry)
+	v0.RegisterEventsEndpoint(api, registry)
 	v0.RegisterEditEndpoints(api, registry, cfg)
 	v0auth.RegisterAuthEndpoints(api, cfg)
+	v0.RegisterAPIKeyEndpoints(api, registry, cfg)
+	v0.RegisterUploadTokenEndpoint(api, cfg)
 	v0.RegisterPublishEndpoint(api, registry, cfg)
+	v0.RegisterTransferEndpoints(api, registry, cfg)
+	v0.RegisterBlocklistEndpoints(api, registry, cfg)
 }
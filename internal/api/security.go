@@ -0,0 +1,96 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+const (
+	corsAllowedMethods = "GET, POST, PUT, DELETE, OPTIONS"
+	corsAllowedHeaders = "Authorization, Content-Type, Last-Event-ID"
+	// corsExposedHeaders lets browser clients read the registry's custom response headers
+	corsExposedHeaders = "X-Registry-Snapshot-Version, X-Registry-Snapshot-Count, X-Registry-Signature"
+	corsMaxAge         = "600"
+)
+
+// SecurityHeadersMiddleware adds CORS headers for the configured origins, HSTS and
+// X-Content-Type-Options to every response, and a Content-Security-Policy to HTML responses
+func SecurityHeadersMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
+	var hsts string
+	if cfg.HSTSMaxAge > 0 {
+		hsts = "max-age=" + strconv.Itoa(int(cfg.HSTSMaxAge.Seconds())) + "; includeSubDomains"
+	}
+	allowAnyOrigin := slices.Contains(cfg.CORSAllowedOrigins, "*")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := w.Header()
+			header.Set("X-Content-Type-Options", "nosniff")
+			if hsts != "" {
+				header.Set("Strict-Transport-Security", hsts)
+			}
+
+			if origin := r.Header.Get("Origin"); origin != "" {
+				header.Add("Vary", "Origin")
+				if allowAnyOrigin || slices.Contains(cfg.CORSAllowedOrigins, origin) {
+					if allowAnyOrigin {
+						header.Set("Access-Control-Allow-Origin", "*")
+					} else {
+						header.Set("Access-Control-Allow-Origin", origin)
+					}
+					header.Set("Access-Control-Expose-Headers", corsExposedHeaders)
+
+					// Answer preflight requests directly rather than routing them to the API
+					if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+						header.Set("Access-Control-Allow-Methods", corsAllowedMethods)
+						header.Set("Access-Control-Allow-Headers", corsAllowedHeaders)
+						header.Set("Access-Control-Max-Age", corsMaxAge)
+						w.WriteHeader(http.StatusNoContent)
+						return
+					}
+				}
+			}
+
+			if cfg.ContentSecurityPolicy != "" {
+				w = &htmlPolicyWriter{ResponseWriter: w, policy: cfg.ContentSecurityPolicy}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// htmlPolicyWriter sets a Content-Security-Policy once the response turns out to be HTML
+type htmlPolicyWriter struct {
+	http.ResponseWriter
+	policy      string
+	wroteHeader bool
+}
+
+func (w *htmlPolicyWriter) WriteHeader(statusCode int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if strings.HasPrefix(w.Header().Get("Content-Type"), "text/html") {
+			w.Header().Set("Content-Security-Policy", w.policy)
+		}
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *htmlPolicyWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", http.DetectContentType(b))
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap exposes the underlying writer so streaming responses can still flush
+func (w *htmlPolicyWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
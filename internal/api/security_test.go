@@ -0,0 +1,122 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/modelcontextprotocol/registry/internal/api"
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+func TestSecurityHeadersMiddleware(t *testing.T) {
+	cfg := &config.Config{
+		CORSAllowedOrigins:    []string{"https://ui.example.com"},
+		HSTSMaxAge:            time.Hour,
+		ContentSecurityPolicy: "default-src 'none'",
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0/servers", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"servers":[]}`))
+	})
+	mux.HandleFunc("/docs", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<!doctype html><html></html>"))
+	})
+	handler := api.SecurityHeadersMiddleware(cfg)(mux)
+
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		headers       map[string]string
+		expectedCode  int
+		expectedCORS  string
+		expectCSP     bool
+		expectMethods bool
+	}{
+		{
+			name:         "same-origin JSON request",
+			method:       http.MethodGet,
+			path:         "/v0/servers",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "allowed origin",
+			method:       http.MethodGet,
+			path:         "/v0/servers",
+			headers:      map[string]string{"Origin": "https://ui.example.com"},
+			expectedCode: http.StatusOK,
+			expectedCORS: "https://ui.example.com",
+		},
+		{
+			name:         "disallowed origin",
+			method:       http.MethodGet,
+			path:         "/v0/servers",
+			headers:      map[string]string{"Origin": "https://evil.example.com"},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "preflight from allowed origin",
+			method: http.MethodOptions,
+			path:   "/v0/publish",
+			headers: map[string]string{
+				"Origin":                        "https://ui.example.com",
+				"Access-Control-Request-Method": http.MethodPost,
+			},
+			expectedCode:  http.StatusNoContent,
+			expectedCORS:  "https://ui.example.com",
+			expectMethods: true,
+		},
+		{
+			name:         "HTML response",
+			method:       http.MethodGet,
+			path:         "/docs",
+			expectedCode: http.StatusOK,
+			expectCSP:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedCode, rr.Code)
+			assert.Equal(t, "nosniff", rr.Header().Get("X-Content-Type-Options"))
+			assert.Equal(t, "max-age=3600; includeSubDomains", rr.Header().Get("Strict-Transport-Security"))
+			assert.Equal(t, tt.expectedCORS, rr.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, tt.expectMethods, rr.Header().Get("Access-Control-Allow-Methods") != "")
+			if tt.expectCSP {
+				assert.Equal(t, "default-src 'none'", rr.Header().Get("Content-Security-Policy"))
+			} else {
+				assert.Empty(t, rr.Header().Get("Content-Security-Policy"))
+			}
+		})
+	}
+}
+
+func TestSecurityHeadersMiddleware_AnyOrigin(t *testing.T) {
+	handler := api.SecurityHeadersMiddleware(&config.Config{CORSAllowedOrigins: []string{"*"}})(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/servers", nil)
+	req.Header.Set("Origin", "https://anything.example.com")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, "*", rr.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, rr.Header().Get("Strict-Transport-Security"))
+}
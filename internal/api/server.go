@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"log"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -41,15 +42,32 @@ type Server struct {
 	server   *http.Server
 }
 
-// NewServer creates a new HTTP server
-func NewServer(cfg *config.Config, registryService service.RegistryService, metrics *telemetry.Metrics) *Server {
+// Tenant is an isolated registry hosted alongside the default one, with its own configuration and service
+type Tenant struct {
+	Config   *config.Config
+	Registry service.RegistryService
+}
+
+// NewServer creates a new HTTP server for the default registry and any tenants, keyed by name
+func NewServer(cfg *config.Config, registryService service.RegistryService, metrics *telemetry.Metrics, tenants map[string]Tenant) *Server {
 	// Create HTTP mux and Huma API
 	mux := http.NewServeMux()
 
 	api := router.NewHumaAPI(cfg, registryService, mux, metrics)
 
-	// Wrap the mux with trailing slash middleware
-	handler := TrailingSlashMiddleware(mux)
+	// Each tenant gets its own API, so its endpoints use its configuration and data
+	tenantHandlers := make(map[string]http.Handler, len(tenants))
+	for name, tenant := range tenants {
+		tenantMux := http.NewServeMux()
+		router.NewHumaAPI(tenant.Config, tenant.Registry, tenantMux, metrics)
+		tenantHandlers[name] = tenantMux
+	}
+
+	// Wrap the mux with tenant routing, payload logging, trailing slash and compression middleware, then security headers so redirects carry them too
+	handler := SecurityHeadersMiddleware(cfg)(CompressionMiddleware(cfg)(TrailingSlashMiddleware(PayloadLoggingMiddleware(cfg)(TenantMiddleware(tenantHandlers)(mux)))))
+
+	// Cancelled on shutdown so long-lived streams such as /v0/events end instead of holding shutdown open
+	baseCtx, cancelBaseCtx := context.WithCancel(context.Background())
 
 	server := &Server{
 		config:   cfg,
@@ -59,8 +77,10 @@ func NewServer(cfg *config.Config, registryService service.RegistryService, metr
 			Addr:              cfg.ServerAddress,
 			Handler:           handler,
 			ReadHeaderTimeout: 10 * time.Second,
+			BaseContext:       func(net.Listener) context.Context { return baseCtx },
 		},
 	}
+	server.server.RegisterOnShutdown(cancelBaseCtx)
 
 	return server
 }
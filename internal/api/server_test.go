@@ -93,3 +93,45 @@ func TestTrailingSlashMiddleware(t *testing.T) {
 		})
 	}
 }
+
+func TestTenantMiddleware(t *testing.T) {
+	respond := func(name string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(name + " " + r.URL.Path))
+		})
+	}
+	handler := api.TenantMiddleware(map[string]http.Handler{"internal": respond("internal")})(respond("default"))
+
+	tests := []struct {
+		name           string
+		path           string
+		header         string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"default registry", "/v0/servers", "", http.StatusOK, "default /v0/servers"},
+		{"tenant path prefix", "/tenants/internal/v0/servers", "", http.StatusOK, "internal /v0/servers"},
+		{"tenant header", "/v0/servers", "internal", http.StatusOK, "internal /v0/servers"},
+		{"path prefix takes precedence over header", "/tenants/internal/v0/servers", "other", http.StatusOK, "internal /v0/servers"},
+		{"unknown tenant path", "/tenants/other/v0/servers", "", http.StatusNotFound, ""},
+		{"unknown tenant header", "/v0/servers", "other", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set(api.TenantHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if tt.expectedBody != "" && w.Body.String() != tt.expectedBody {
+				t.Errorf("expected body %q, got %q", tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
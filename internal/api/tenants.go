@@ -0,0 +1,44 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+)
+
+const (
+	// TenantPathPrefix selects a tenant's registry by path, e.g. /tenants/internal/v0/servers
+	TenantPathPrefix = "/tenants/"
+	// TenantHeader selects a tenant's registry for requests to the unprefixed paths
+	TenantHeader = "X-Registry-Tenant"
+)
+
+// TenantMiddleware routes requests for a tenant, named by a /tenants/{name} path prefix or the
+// X-Registry-Tenant header, to that tenant's handler with the prefix removed. Other requests are
+// served by the default registry.
+func TenantMiddleware(tenants map[string]http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			name := r.Header.Get(TenantHeader)
+			if rest, ok := strings.CutPrefix(r.URL.Path, TenantPathPrefix); ok {
+				var path string
+				name, path, _ = strings.Cut(rest, "/")
+				r = r.Clone(r.Context())
+				r.URL.Path = "/" + path
+				r.URL.RawPath = ""
+			}
+
+			if name == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			tenant, ok := tenants[name]
+			if !ok {
+				v0.WriteProblem(w, r, http.StatusNotFound, "Unknown tenant: "+name)
+				return
+			}
+			tenant.ServeHTTP(w, r)
+		})
+	}
+}
@@ -2,13 +2,11 @@ package auth
 
 import (
 	"context"
-	"crypto/ed25519"
-	"encoding/hex"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/modelcontextprotocol/registry/internal/config"
 )
 
@@ -22,8 +20,9 @@ const (
 )
 
 type Permission struct {
-	Action          PermissionAction `json:"action"`   // The action type (publish or edit)
-	ResourcePattern string           `json:"resource"` // e.g., "io.github.username/*"
+	Action          PermissionAction `json:"action"`         // The action type (publish or edit)
+	ResourcePattern string           `json:"resource"`       // e.g., "io.github.username/*" or "com.example.*/*"
+	Deny            bool             `json:"deny,omitempty"` // Deny rules override any matching allow rule
 }
 
 // JWTClaims represents the claims for the Registry JWT token
@@ -33,6 +32,8 @@ type JWTClaims struct {
 	AuthMethod        Method       `json:"auth_method"`
 	AuthMethodSubject string       `json:"auth_method_sub"`
 	Permissions       []Permission `json:"permissions"`
+	// Version is the only server version an upload token may publish
+	Version string `json:"version,omitempty"`
 }
 
 type TokenResponse struct {
@@ -42,31 +43,41 @@ type TokenResponse struct {
 
 // JWTManager handles JWT token operations
 type JWTManager struct {
-	privateKey    ed25519.PrivateKey
-	publicKey     ed25519.PublicKey
-	tokenDuration time.Duration
+	keys           []signingKey // ordered by activation time
+	audience       string
+	uploadAudience string
+	tokenDuration  time.Duration
+	now            func() time.Time
 }
 
 func NewJWTManager(cfg *config.Config) *JWTManager {
-	seed, err := hex.DecodeString(cfg.JWTPrivateKey)
+	keys, err := parseSigningKeys(cfg.JWTPrivateKey, cfg.JWTSigningKeys)
 	if err != nil {
-		panic(fmt.Sprintf("JWTPrivateKey must be a valid hex-encoded string: %v", err))
+		panic(err.Error())
 	}
 
-	// Require a valid Ed25519 seed (32 bytes)
-	if len(seed) != ed25519.SeedSize {
-		panic(fmt.Sprintf("JWTPrivateKey seed must be exactly %d bytes for Ed25519, got %d bytes", ed25519.SeedSize, len(seed)))
+	return &JWTManager{
+		keys:           keys,
+		audience:       TokenAudience(cfg.Tenant),
+		uploadAudience: UploadTokenAudience(cfg.Tenant),
+		tokenDuration:  5 * time.Minute, // 5-minute tokens as per requirements
+		now:            time.Now,
 	}
+}
 
-	// Generate the full Ed25519 key pair from the seed
-	privateKey := ed25519.NewKeyFromSeed(seed)
-	publicKey := privateKey.Public().(ed25519.PublicKey)
-
-	return &JWTManager{
-		privateKey:    privateKey,
-		publicKey:     publicKey,
-		tokenDuration: 5 * time.Minute, // 5-minute tokens as per requirements
+// TokenAudience is the aud claim of registry tokens for a tenant, or for the default registry when tenant is
+// empty, so a token minted by one registry of a deployment is never accepted by another
+func TokenAudience(tenant string) string {
+	if tenant == "" {
+		return "mcp-registry"
 	}
+	return "mcp-registry/tenants/" + tenant
+}
+
+// UploadTokenAudience is the aud claim of upload tokens for a tenant. It differs from TokenAudience, so upload
+// tokens are refused everywhere but the publish handler.
+func UploadTokenAudience(tenant string) string {
+	return TokenAudience(tenant) + "/publish"
 }
 
 // GenerateToken generates a new Registry JWT token
@@ -74,7 +85,7 @@ func (j *JWTManager) GenerateTokenResponse(_ context.Context, claims JWTClaims)
 	// Check whether they have global permissions (used by admins)
 	hasGlobalPermissions := false
 	for _, perm := range claims.Permissions {
-		if perm.ResourcePattern == "*" {
+		if !perm.Deny && perm.ResourcePattern == "*" {
 			hasGlobalPermissions = true
 			break
 		}
@@ -101,12 +112,38 @@ func (j *JWTManager) GenerateTokenResponse(_ context.Context, claims JWTClaims)
 	if claims.Issuer == "" {
 		claims.Issuer = "mcp-registry"
 	}
+	claims.Audience = jwt.ClaimStrings{j.audience}
+
+	return j.sign(claims)
+}
+
+// GenerateUploadToken generates a single-use upload token, valid for ttl, that may only publish version of the
+// server named name on behalf of the identity in claims
+func (j *JWTManager) GenerateUploadToken(_ context.Context, claims JWTClaims, name, version string, ttl time.Duration) (*TokenResponse, error) {
+	now := j.now()
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        uuid.NewString(),
+		Issuer:    "mcp-registry",
+		Audience:  jwt.ClaimStrings{j.uploadAudience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+	}
+	claims.Permissions = []Permission{{Action: PermissionActionPublish, ResourcePattern: name}}
+	claims.Version = version
+
+	return j.sign(claims)
+}
 
-	// Create token with claims
+// sign signs a token with the active key
+func (j *JWTManager) sign(claims JWTClaims) (*TokenResponse, error) {
+	// Create token with claims, naming the signing key so verifiers can pick it from the JWKS
+	key := j.activeKey()
 	token := jwt.NewWithClaims(&jwt.SigningMethodEd25519{}, claims)
+	token.Header["kid"] = key.id
 
 	// Sign token with Ed25519 private key
-	tokenString, err := token.SignedString(j.privateKey)
+	tokenString, err := token.SignedString(key.privateKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign token: %w", err)
 	}
@@ -124,9 +161,10 @@ func (j *JWTManager) ValidateToken(_ context.Context, tokenString string) (*JWTC
 	token, err := jwt.ParseWithClaims(
 		tokenString,
 		&JWTClaims{},
-		func(_ *jwt.Token) (interface{}, error) { return j.publicKey, nil },
+		j.verificationKey,
 		jwt.WithValidMethods([]string{"EdDSA"}),
 		jwt.WithExpirationRequired(),
+		jwt.WithAudience(j.audience),
 	)
 
 	// Validate token
@@ -146,21 +184,82 @@ func (j *JWTManager) ValidateToken(_ context.Context, tokenString string) (*JWTC
 	return claims, nil
 }
 
+// ValidateUploadToken validates an upload token and returns its claims. Registry JWTs are refused, having
+// another audience.
+func (j *JWTManager) ValidateUploadToken(_ context.Context, tokenString string) (*JWTClaims, error) {
+	claims := &JWTClaims{}
+	_, err := jwt.ParseWithClaims(
+		tokenString,
+		claims,
+		j.verificationKey,
+		jwt.WithValidMethods([]string{"EdDSA"}),
+		jwt.WithExpirationRequired(),
+		jwt.WithAudience(j.uploadAudience),
+		jwt.WithTimeFunc(j.now),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse upload token: %w", err)
+	}
+	if claims.ID == "" || claims.Version == "" || len(claims.Permissions) != 1 {
+		return nil, fmt.Errorf("invalid upload token claims")
+	}
+	return claims, nil
+}
+
+// verificationKey looks up the public key named by a token's kid header.
+// Tokens issued before key IDs were introduced have no kid and are checked against every key.
+func (j *JWTManager) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		keySet := jwt.VerificationKeySet{}
+		for _, key := range j.keys {
+			keySet.Keys = append(keySet.Keys, key.publicKey)
+		}
+		return keySet, nil
+	}
+
+	for _, key := range j.keys {
+		if key.id == kid {
+			return key.publicKey, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// HasPermission reports whether the permissions allow action on resource.
+// Deny rules take precedence over any allow rule.
 func (j *JWTManager) HasPermission(resource string, action PermissionAction, permissions []Permission) bool {
+	allowed := false
 	for _, perm := range permissions {
-		if perm.Action == action && isResourceMatch(resource, perm.ResourcePattern) {
-			return true
+		if perm.Action != action || !MatchResourcePattern(resource, perm.ResourcePattern) {
+			continue
+		}
+		if perm.Deny {
+			return false
 		}
+		allowed = true
 	}
-	return false
+	return allowed
 }
 
-func isResourceMatch(resource, pattern string) bool {
-	if pattern == "*" {
-		return true
-	}
-	if strings.HasSuffix(pattern, "*") {
-		return strings.HasPrefix(resource, strings.TrimSuffix(pattern, "*"))
+// CanGrant reports whether the permissions cover every resource matched by pattern for action,
+// so that a credential scoped to pattern never grants more than its creator holds
+func (j *JWTManager) CanGrant(pattern string, action PermissionAction, permissions []Permission) bool {
+	covered := false
+	for _, perm := range permissions {
+		if perm.Action != action {
+			continue
+		}
+		if perm.Deny {
+			// A derived credential doesn't carry deny rules, so it must not reach any denied resource
+			if patternsOverlap(pattern, perm.ResourcePattern) {
+				return false
+			}
+			continue
+		}
+		if isPatternCovered(pattern, perm.ResourcePattern) {
+			covered = true
+		}
 	}
-	return resource == pattern
+	return covered
 }
@@ -139,6 +139,27 @@ func TestJWTManager_GenerateAndVerifyToken(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to parse token")
 	})
 
+	t.Run("token for another tenant should fail", func(t *testing.T) {
+		// Same signing key, so only the audience tells the registries apart
+		tenantJWTManager := auth.NewJWTManager(&config.Config{
+			JWTPrivateKey: hex.EncodeToString(testSeed),
+			Tenant:        "internal",
+		})
+
+		tokenResponse, err := tenantJWTManager.GenerateTokenResponse(ctx, auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: "testuser",
+		})
+		require.NoError(t, err)
+
+		claims, err := tenantJWTManager.ValidateToken(ctx, tokenResponse.RegistryToken)
+		require.NoError(t, err)
+		assert.Equal(t, jwt.ClaimStrings{auth.TokenAudience("internal")}, claims.Audience)
+
+		_, err = jwtManager.ValidateToken(ctx, tokenResponse.RegistryToken)
+		assert.ErrorIs(t, err, jwt.ErrTokenInvalidAudience)
+	})
+
 	t.Run("malformed token should fail", func(t *testing.T) {
 		// Try to validate a malformed token
 		_, err := jwtManager.ValidateToken(ctx, "not.a.valid.token")
@@ -255,6 +276,62 @@ func TestJWTManager_HasPermission(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name:     "multi-level wildcard match",
+			resource: "com.example.api/server1",
+			action:   auth.PermissionActionPublish,
+			permissions: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: "com.example.*/*"},
+			},
+			expected: true,
+		},
+		{
+			name:     "multi-level wildcard does not match parent domain",
+			resource: "com.example/server1",
+			action:   auth.PermissionActionPublish,
+			permissions: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: "com.example.*/*"},
+			},
+			expected: false,
+		},
+		{
+			name:     "segment wildcard does not cross slash",
+			resource: "com.example.api/nested/server1",
+			action:   auth.PermissionActionPublish,
+			permissions: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: "com.example.*/server1"},
+			},
+			expected: false,
+		},
+		{
+			name:     "deny overrides allow",
+			resource: "com.example.internal/server1",
+			action:   auth.PermissionActionPublish,
+			permissions: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: "com.example.*/*"},
+				{Action: auth.PermissionActionPublish, ResourcePattern: "com.example.internal/*", Deny: true},
+			},
+			expected: false,
+		},
+		{
+			name:     "deny for another action does not apply",
+			resource: "com.example.internal/server1",
+			action:   auth.PermissionActionPublish,
+			permissions: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+				{Action: auth.PermissionActionEdit, ResourcePattern: "com.example.internal/*", Deny: true},
+			},
+			expected: true,
+		},
+		{
+			name:     "deny alone grants nothing",
+			resource: "com.example.api/server1",
+			action:   auth.PermissionActionPublish,
+			permissions: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: "com.example.internal/*", Deny: true},
+			},
+			expected: false,
+		},
 		{
 			name:        "empty permissions",
 			resource:    "io.github.testuser/server1",
@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// signingKey is an Ed25519 key used to sign Registry JWTs from its activation time onwards
+type signingKey struct {
+	id          string
+	privateKey  ed25519.PrivateKey
+	publicKey   ed25519.PublicKey
+	activatesAt time.Time
+}
+
+// JWK is a JSON Web Key for an Ed25519 public key (RFC 8037)
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+}
+
+// JWKS is a JSON Web Key Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// parseSigningKeys builds the key schedule from the primary JWTPrivateKey seed, which is active
+// from the start, and scheduled keys of the form "<hex seed>@<RFC3339 activation time>".
+func parseSigningKeys(primarySeed string, scheduled []string) ([]signingKey, error) {
+	primary, err := newSigningKey(primarySeed, time.Time{})
+	if err != nil {
+		return nil, fmt.Errorf("JWTPrivateKey %w", err)
+	}
+	keys := []signingKey{primary}
+
+	for i, entry := range scheduled {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		seedHex, activation, ok := strings.Cut(entry, "@")
+		if !ok {
+			return nil, fmt.Errorf("JWTSigningKeys entry %d must be of the form <hex seed>@<RFC3339 activation time>", i)
+		}
+		activatesAt, err := time.Parse(time.RFC3339, activation)
+		if err != nil {
+			return nil, fmt.Errorf("JWTSigningKeys entry %d has an invalid activation time: %w", i, err)
+		}
+		key, err := newSigningKey(seedHex, activatesAt)
+		if err != nil {
+			return nil, fmt.Errorf("JWTSigningKeys entry %d %w", i, err)
+		}
+		keys = append(keys, key)
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i].activatesAt.Before(keys[j].activatesAt)
+	})
+	return keys, nil
+}
+
+func newSigningKey(seedHex string, activatesAt time.Time) (signingKey, error) {
+	seed, err := hex.DecodeString(seedHex)
+	if err != nil {
+		return signingKey{}, fmt.Errorf("must be a valid hex-encoded string: %w", err)
+	}
+
+	// Require a valid Ed25519 seed (32 bytes)
+	if len(seed) != ed25519.SeedSize {
+		return signingKey{}, fmt.Errorf("seed must be exactly %d bytes for Ed25519, got %d bytes", ed25519.SeedSize, len(seed))
+	}
+
+	// Generate the full Ed25519 key pair from the seed
+	privateKey := ed25519.NewKeyFromSeed(seed)
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+
+	return signingKey{
+		id:          jwkThumbprint(publicKey),
+		privateKey:  privateKey,
+		publicKey:   publicKey,
+		activatesAt: activatesAt,
+	}, nil
+}
+
+// jwkThumbprint derives a stable key ID from the public key (RFC 7638)
+func jwkThumbprint(publicKey ed25519.PublicKey) string {
+	// Required members in lexicographic order, with no whitespace
+	canonical := `{"crv":"Ed25519","kty":"OKP","x":"` + base64.RawURLEncoding.EncodeToString(publicKey) + `"}`
+	sum := sha256.Sum256([]byte(canonical))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// activeKey returns the most recently activated key, which signs new tokens
+func (j *JWTManager) activeKey() signingKey {
+	now := j.now()
+	active := j.keys[0]
+	for _, key := range j.keys[1:] {
+		if key.activatesAt.After(now) {
+			break
+		}
+		active = key
+	}
+	return active
+}
+
+// JWKS returns every configured public key, including keys scheduled for future activation,
+// so resource servers already trust the next key by the time it starts signing tokens
+func (j *JWTManager) JWKS() JWKS {
+	jwks := JWKS{Keys: make([]JWK, 0, len(j.keys))}
+	for _, key := range j.keys {
+		jwks.Keys = append(jwks.Keys, JWK{
+			KeyType:   "OKP",
+			Curve:     "Ed25519",
+			X:         base64.RawURLEncoding.EncodeToString(key.publicKey),
+			KeyID:     key.id,
+			Algorithm: "EdDSA",
+			Use:       "sig",
+		})
+	}
+	return jwks
+}
@@ -0,0 +1,113 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSeed(t *testing.T) string {
+	t.Helper()
+	seed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(seed)
+	require.NoError(t, err)
+	return hex.EncodeToString(seed)
+}
+
+func tokenKeyID(t *testing.T, tokenString string) string {
+	t.Helper()
+	token, _, err := jwt.NewParser().ParseUnverified(tokenString, &auth.JWTClaims{})
+	require.NoError(t, err)
+	kid, _ := token.Header["kid"].(string)
+	return kid
+}
+
+func TestJWTManager_KeyRotation(t *testing.T) {
+	primarySeed := newTestSeed(t)
+	now := time.Now().UTC()
+
+	previous := auth.NewJWTManager(&config.Config{JWTPrivateKey: primarySeed})
+	rotated := auth.NewJWTManager(&config.Config{
+		JWTPrivateKey: primarySeed,
+		JWTSigningKeys: []string{
+			newTestSeed(t) + "@" + now.Add(time.Hour).Format(time.RFC3339),  // next key
+			newTestSeed(t) + "@" + now.Add(-time.Hour).Format(time.RFC3339), // current key
+		},
+	})
+	ctx := context.Background()
+	claims := auth.JWTClaims{AuthMethod: auth.MethodNone}
+
+	jwks := rotated.JWKS()
+	require.Len(t, jwks.Keys, 3)
+	for _, key := range jwks.Keys {
+		assert.Equal(t, "OKP", key.KeyType)
+		assert.Equal(t, "Ed25519", key.Curve)
+		assert.Equal(t, "EdDSA", key.Algorithm)
+		assert.NotEmpty(t, key.KeyID)
+	}
+	// Keys are listed in activation order: primary, current, next
+	primaryKID, currentKID := jwks.Keys[0].KeyID, jwks.Keys[1].KeyID
+
+	t.Run("signs with the most recently activated key", func(t *testing.T) {
+		token, err := rotated.GenerateTokenResponse(ctx, claims)
+		require.NoError(t, err)
+		assert.Equal(t, currentKID, tokenKeyID(t, token.RegistryToken))
+
+		_, err = rotated.ValidateToken(ctx, token.RegistryToken)
+		require.NoError(t, err)
+	})
+
+	t.Run("accepts tokens signed with a previous key", func(t *testing.T) {
+		token, err := previous.GenerateTokenResponse(ctx, claims)
+		require.NoError(t, err)
+		assert.Equal(t, primaryKID, tokenKeyID(t, token.RegistryToken))
+
+		_, err = rotated.ValidateToken(ctx, token.RegistryToken)
+		require.NoError(t, err)
+	})
+
+	t.Run("accepts tokens without a key ID", func(t *testing.T) {
+		seed, err := hex.DecodeString(primarySeed)
+		require.NoError(t, err)
+		legacyClaims := claims
+		legacyClaims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(time.Minute))
+		legacyClaims.Audience = jwt.ClaimStrings{auth.TokenAudience("")}
+		tokenString, err := jwt.NewWithClaims(&jwt.SigningMethodEd25519{}, legacyClaims).SignedString(ed25519.NewKeyFromSeed(seed))
+		require.NoError(t, err)
+
+		_, err = rotated.ValidateToken(ctx, tokenString)
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects tokens signed with an unknown key", func(t *testing.T) {
+		other := auth.NewJWTManager(&config.Config{JWTPrivateKey: newTestSeed(t)})
+		token, err := other.GenerateTokenResponse(ctx, claims)
+		require.NoError(t, err)
+
+		_, err = rotated.ValidateToken(ctx, token.RegistryToken)
+		require.Error(t, err)
+	})
+}
+
+func TestNewJWTManager_InvalidSigningKeys(t *testing.T) {
+	primarySeed := newTestSeed(t)
+
+	for _, entry := range []string{
+		newTestSeed(t),                 // missing activation time
+		newTestSeed(t) + "@not-a-time", // invalid activation time
+		"abcd@2025-01-01T00:00:00Z",    // seed too short
+	} {
+		assert.Panics(t, func() {
+			auth.NewJWTManager(&config.Config{JWTPrivateKey: primarySeed, JWTSigningKeys: []string{entry}})
+		}, entry)
+	}
+}
@@ -0,0 +1,191 @@
+package auth
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Resource patterns match server names. A trailing "*" matches any remaining characters,
+// so "io.github.username/*" covers every server in that namespace. A "*" anywhere else
+// matches within a single path segment, so "com.example.*/*" covers servers under any
+// subdomain of example.com but not "com.example/server". Namespaces, the part before the "/",
+// match case-insensitively like the domains and GitHub accounts they come from.
+
+// ParsePermissions builds permissions for action from comma-separated patterns.
+// Patterns prefixed with "!" become deny rules.
+func ParsePermissions(action PermissionAction, patterns string) []Permission {
+	var permissions []Permission
+	for _, pattern := range strings.Split(patterns, ",") {
+		pattern = strings.TrimSpace(pattern)
+		deny := strings.HasPrefix(pattern, "!")
+		pattern = strings.TrimSpace(strings.TrimPrefix(pattern, "!"))
+		if pattern == "" {
+			continue
+		}
+		permissions = append(permissions, Permission{
+			Action:          action,
+			ResourcePattern: pattern,
+			Deny:            deny,
+		})
+	}
+	return permissions
+}
+
+// claimPlaceholder is a {claim} placeholder in a permission pattern
+var claimPlaceholder = regexp.MustCompile(`\{([A-Za-z0-9_.:-]+)\}`)
+
+// claimValue is a claim value that may be substituted into a pattern: one name segment, with no wildcards
+var claimValue = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ExpandClaims maps an identity's claims into permissions whose patterns hold {claim} placeholders, e.g.
+// "com.example/{preferred_username}-*" or "com.example.{groups}/*". A placeholder for a list claim expands to one
+// permission per value. Allow rules are dropped where a claim is missing or has a value that isn't a single name
+// segment, and deny rules instead deny everything for their action, so a missing claim never widens access.
+func ExpandClaims(permissions []Permission, claims map[string]any) []Permission {
+	var expanded []Permission
+	for _, perm := range permissions {
+		patterns, ok := expandPattern(perm.ResourcePattern, claims)
+		if !ok {
+			if perm.Deny {
+				expanded = append(expanded, Permission{Action: perm.Action, ResourcePattern: "*", Deny: true})
+			}
+			continue
+		}
+		for _, pattern := range patterns {
+			expanded = append(expanded, Permission{Action: perm.Action, ResourcePattern: pattern, Deny: perm.Deny})
+		}
+	}
+	return expanded
+}
+
+// expandPattern returns the patterns a pattern expands to with claims, or false if a claim it names is missing
+// or has an unusable value
+func expandPattern(pattern string, claims map[string]any) ([]string, bool) {
+	match := claimPlaceholder.FindStringSubmatchIndex(pattern)
+	if match == nil {
+		return []string{pattern}, true
+	}
+
+	var values []string
+	switch value := claims[pattern[match[2]:match[3]]].(type) {
+	case string:
+		values = []string{value}
+	case []any:
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+	if len(values) == 0 {
+		return nil, false
+	}
+
+	var patterns []string
+	for _, value := range values {
+		if !claimValue.MatchString(value) {
+			return nil, false
+		}
+		rest, ok := expandPattern(pattern[match[1]:], claims)
+		if !ok {
+			return nil, false
+		}
+		for _, suffix := range rest {
+			patterns = append(patterns, pattern[:match[0]]+value+suffix)
+		}
+	}
+	return patterns, true
+}
+
+// MatchResourcePattern reports whether resource matches pattern
+func MatchResourcePattern(resource, pattern string) bool {
+	return globMatch(foldNamespace(resource), foldNamespace(pattern))
+}
+
+// foldNamespace lowercases the namespace of a server name or pattern, leaving the rest as is
+func foldNamespace(s string) string {
+	namespace, rest, found := strings.Cut(s, "/")
+	if !found {
+		return strings.ToLower(s)
+	}
+	return strings.ToLower(namespace) + "/" + rest
+}
+
+// HasGlobalPermission reports whether permissions allow action on every resource, as granted to registry admins
+func HasGlobalPermission(action PermissionAction, permissions []Permission) bool {
+	for _, perm := range permissions {
+		if perm.Action == action && perm.ResourcePattern == "*" && !perm.Deny {
+			return true
+		}
+	}
+	return false
+}
+
+// isPatternCovered reports whether every resource matched by pattern is also matched by grant.
+// Each wildcard in pattern must be absorbed by a wildcard in grant at least as broad.
+func isPatternCovered(pattern, grant string) bool {
+	if strings.HasSuffix(pattern, "*") && !strings.HasSuffix(grant, "*") {
+		return false
+	}
+	// Matching pattern as if it were a name works because a mid-pattern "*" in grant spans
+	// the same characters as one in pattern, and only a trailing "*" in grant spans a "/"
+	return globMatch(foldNamespace(pattern), foldNamespace(grant))
+}
+
+// globMatch matches name against pattern, where name is taken literally
+func globMatch(name, pattern string) bool {
+	if pattern == "" {
+		return name == ""
+	}
+	if pattern[0] != '*' {
+		return name != "" && name[0] == pattern[0] && globMatch(name[1:], pattern[1:])
+	}
+	if len(pattern) == 1 {
+		return true
+	}
+	for i := 0; i <= len(name); i++ {
+		if globMatch(name[i:], pattern[1:]) {
+			return true
+		}
+		if i < len(name) && name[i] == '/' {
+			return false
+		}
+	}
+	return false
+}
+
+// patternsOverlap reports whether some resource name matches both a and b
+func patternsOverlap(a, b string) bool {
+	memo := make(map[[2]int]bool)
+	var overlap func(i, j int) bool
+	overlap = func(i, j int) bool {
+		key := [2]int{i, j}
+		if result, ok := memo[key]; ok {
+			return result
+		}
+		memo[key] = false // guards against revisiting while in progress
+
+		var result bool
+		switch {
+		case i == len(a) && j == len(b):
+			result = true
+		case i < len(a) && a[i] == '*':
+			// The wildcard matches nothing more, or consumes the next character of b
+			result = overlap(i+1, j) || (j < len(b) && consumes(a, i, b[j]) && overlap(i, j+1))
+		case j < len(b) && b[j] == '*':
+			result = overlap(i, j+1) || (i < len(a) && consumes(b, j, a[i]) && overlap(i+1, j))
+		case i < len(a) && j < len(b):
+			result = a[i] == b[j] && overlap(i+1, j+1)
+		}
+
+		memo[key] = result
+		return result
+	}
+	return overlap(0, 0)
+}
+
+// consumes reports whether the wildcard at pattern[i] can match c. Two wildcards facing
+// each other can always agree on a character other than "/".
+func consumes(pattern string, i int, c byte) bool {
+	return c != '/' || i == len(pattern)-1
+}
@@ -0,0 +1,101 @@
+package auth_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePermissions(t *testing.T) {
+	permissions := auth.ParsePermissions(auth.PermissionActionEdit, " com.example.*/* , !com.example.internal/*,,* ")
+	assert.Equal(t, []auth.Permission{
+		{Action: auth.PermissionActionEdit, ResourcePattern: "com.example.*/*"},
+		{Action: auth.PermissionActionEdit, ResourcePattern: "com.example.internal/*", Deny: true},
+		{Action: auth.PermissionActionEdit, ResourcePattern: "*"},
+	}, permissions)
+
+	assert.Empty(t, auth.ParsePermissions(auth.PermissionActionEdit, ""))
+}
+
+func TestMatchResourcePattern(t *testing.T) {
+	assert.True(t, auth.MatchResourcePattern("io.github.octocat/server", "io.github.Octocat/*"))
+	assert.True(t, auth.MatchResourcePattern("COM.Example/server", "com.example/*"))
+	assert.True(t, auth.MatchResourcePattern("com.example/server", "com.example/server"))
+	assert.False(t, auth.MatchResourcePattern("com.example/Server", "com.example/server"))
+	assert.False(t, auth.MatchResourcePattern("com.example.api/server", "com.example/*"))
+}
+
+func TestExpandClaims(t *testing.T) {
+	claims := map[string]any{
+		"preferred_username": "octocat",
+		"groups":             []any{"platform", "data"},
+		"email":              "octocat@example.com",
+	}
+
+	permissions := auth.ExpandClaims([]auth.Permission{
+		{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/{preferred_username}-*"},
+		{Action: auth.PermissionActionPublish, ResourcePattern: "com.example.{groups}/*"},
+		{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/{missing}"},
+		{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/{email}"},
+		{Action: auth.PermissionActionEdit, ResourcePattern: "com.example/*"},
+	}, claims)
+	assert.Equal(t, []auth.Permission{
+		{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/octocat-*"},
+		{Action: auth.PermissionActionPublish, ResourcePattern: "com.example.platform/*"},
+		{Action: auth.PermissionActionPublish, ResourcePattern: "com.example.data/*"},
+		{Action: auth.PermissionActionEdit, ResourcePattern: "com.example/*"},
+	}, permissions, "claims that are missing or not a single name segment drop their allow rules")
+
+	denials := auth.ExpandClaims([]auth.Permission{
+		{Action: auth.PermissionActionPublish, ResourcePattern: "com.example.{missing}/*", Deny: true},
+	}, claims)
+	assert.Equal(t, []auth.Permission{
+		{Action: auth.PermissionActionPublish, ResourcePattern: "*", Deny: true},
+	}, denials, "a deny rule that can't be expanded denies everything")
+}
+
+func TestJWTManager_CanGrant(t *testing.T) {
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	jwtManager := auth.NewJWTManager(&config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)})
+
+	publish := func(pattern string) auth.Permission {
+		return auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: pattern}
+	}
+	deny := func(pattern string) auth.Permission {
+		return auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: pattern, Deny: true}
+	}
+
+	tests := []struct {
+		name        string
+		pattern     string
+		permissions []auth.Permission
+		expected    bool
+	}{
+		{"exact name under namespace", "io.github.user/server", []auth.Permission{publish("io.github.user/*")}, true},
+		{"narrower wildcard", "io.github.user/ci-*", []auth.Permission{publish("io.github.user/*")}, true},
+		{"same wildcard", "io.github.user/*", []auth.Permission{publish("io.github.user/*")}, true},
+		{"broader wildcard", "io.github.*", []auth.Permission{publish("io.github.user/*")}, false},
+		{"global wildcard", "*", []auth.Permission{publish("io.github.user/*")}, false},
+		{"wildcard against exact grant", "io.github.user/*", []auth.Permission{publish("io.github.user/server")}, false},
+		{"subdomain under multi-level grant", "com.example.api/*", []auth.Permission{publish("com.example.*/*")}, true},
+		{"multi-level under multi-level grant", "com.example.*/*", []auth.Permission{publish("com.example.*/*")}, true},
+		{"segment wildcard escaping its segment", "com.example.a*", []auth.Permission{publish("com.example.*/*")}, false},
+		{"overlapping deny", "com.example.*/*", []auth.Permission{publish("com.example.*/*"), deny("com.example.internal/*")}, false},
+		{"deny inside the pattern", "*", []auth.Permission{publish("*"), deny("com.example.internal/secret")}, false},
+		{"disjoint deny", "com.example.api/*", []auth.Permission{publish("com.example.*/*"), deny("com.example.internal/*")}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, jwtManager.CanGrant(tt.pattern, auth.PermissionActionPublish, tt.permissions))
+		})
+	}
+}
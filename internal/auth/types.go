@@ -14,6 +14,8 @@ const (
 	MethodDNS Method = "dns"
 	// HTTP-based public/private key authentication
 	MethodHTTP Method = "http"
+	// Long-lived API key created by an authenticated publisher
+	MethodAPIKey Method = "api-key"
 	// No authentication - should only be used for local development and testing
 	MethodNone Method = "none"
 )
\ No newline at end of file
@@ -0,0 +1,41 @@
+//nolint:testpackage
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWTManager_UploadTokenExpiry(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(seed)
+	require.NoError(t, err)
+	jwtManager := NewJWTManager(&config.Config{JWTPrivateKey: hex.EncodeToString(seed)})
+
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	jwtManager.now = func() time.Time { return now }
+
+	token, err := jwtManager.GenerateUploadToken(context.Background(), JWTClaims{
+		AuthMethod:        MethodGitHubAT,
+		AuthMethodSubject: "example",
+	}, "io.github.example/server", "1.0.0", 10*time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int(now.Add(10*time.Minute).Unix()), token.ExpiresAt)
+
+	now = now.Add(9 * time.Minute)
+	claims, err := jwtManager.ValidateUploadToken(context.Background(), token.RegistryToken)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", claims.Version)
+
+	now = now.Add(2 * time.Minute)
+	_, err = jwtManager.ValidateUploadToken(context.Background(), token.RegistryToken)
+	assert.Error(t, err)
+}
@@ -0,0 +1,71 @@
+// Package backup dumps the registry's catalog, every version of every server including deleted ones, and
+// restores it into an empty database. Dumps are newline-delimited JSON, the same format as /v0/export.
+package backup
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const pageSize = 1000
+
+// ErrNotEmpty is returned when restoring into a database that already holds servers
+var ErrNotEmpty = errors.New("the database already holds servers")
+
+// Dump writes every server version in db to w, returning how many were written
+func Dump(ctx context.Context, db database.Database, w io.Writer) (int, error) {
+	encoder := json.NewEncoder(w)
+	count := 0
+	cursor := ""
+	for {
+		servers, nextCursor, err := db.List(ctx, nil, cursor, pageSize)
+		if err != nil {
+			return count, fmt.Errorf("failed to list servers: %w", err)
+		}
+		for _, server := range servers {
+			if err := encoder.Encode(server); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if nextCursor == "" || len(servers) == 0 {
+			return count, nil
+		}
+		cursor = nextCursor
+	}
+}
+
+// Restore adds every server version in a dump to db, which must hold no servers, returning how many were
+// added. Records are restored as they were dumped, including their IDs, timestamps and status, without
+// validating them again.
+func Restore(ctx context.Context, db database.Database, r io.Reader) (int, error) {
+	existing, _, err := db.List(ctx, nil, "", 1)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list servers: %w", err)
+	}
+	if len(existing) > 0 {
+		return 0, ErrNotEmpty
+	}
+
+	decoder := json.NewDecoder(bufio.NewReader(r))
+	count := 0
+	for {
+		var server apiv0.ServerJSON
+		if err := decoder.Decode(&server); errors.Is(err, io.EOF) {
+			return count, nil
+		} else if err != nil {
+			return count, fmt.Errorf("failed to parse record %d: %w", count+1, err)
+		}
+		if _, err := db.CreateServer(ctx, &server); err != nil {
+			return count, fmt.Errorf("failed to restore %s %s: %w", server.Name, server.Version, err)
+		}
+		count++
+	}
+}
@@ -0,0 +1,70 @@
+package backup_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/backup"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestDumpAndRestore(t *testing.T) {
+	source := database.NewMemoryDB()
+	publishedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i := range 3 {
+		status := model.StatusActive
+		if i == 0 {
+			status = model.StatusDeleted
+		}
+		_, err := source.CreateServer(t.Context(), &apiv0.ServerJSON{
+			Name:        "com.example/server",
+			Description: "A test server",
+			Version:     fmt.Sprintf("1.0.%d", i),
+			Status:      status,
+			Meta: &apiv0.ServerMeta{
+				Official: &apiv0.RegistryExtensions{
+					ID:          fmt.Sprintf("id-%d", i),
+					PublishedAt: publishedAt,
+					UpdatedAt:   publishedAt,
+					IsLatest:    i == 2,
+				},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	var dump bytes.Buffer
+	count, err := backup.Dump(t.Context(), source, &dump)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, 3, strings.Count(dump.String(), "\n"), "the dump is newline-delimited JSON")
+
+	target := database.NewMemoryDB()
+	count, err = backup.Restore(t.Context(), target, bytes.NewReader(dump.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	for i := range 3 {
+		original, err := source.GetByID(t.Context(), fmt.Sprintf("id-%d", i))
+		require.NoError(t, err)
+		restored, err := target.GetByID(t.Context(), fmt.Sprintf("id-%d", i))
+		require.NoError(t, err)
+		assert.Equal(t, original, restored)
+	}
+
+	_, err = backup.Restore(t.Context(), target, bytes.NewReader(dump.Bytes()))
+	assert.ErrorIs(t, err, backup.ErrNotEmpty, "restoring over an existing catalog is refused")
+}
+
+func TestRestore_InvalidDump(t *testing.T) {
+	_, err := backup.Restore(t.Context(), database.NewMemoryDB(), strings.NewReader("{not json"))
+	assert.ErrorContains(t, err, "record 1")
+}
@@ -0,0 +1,35 @@
+// Package cache provides simple key/value caches with per-entry expiry,
+// backed either by process memory or by Redis.
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Cache is a byte-oriented key/value store with per-entry TTLs
+type Cache interface {
+	// Get returns the value stored under key, and whether it was found
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value under key for the given TTL
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete removes the given keys, ignoring keys that don't exist
+	Delete(ctx context.Context, keys ...string) error
+	// Close releases any resources held by the cache
+	Close() error
+}
+
+// New creates a cache from a URL: an empty URL or "memory://" returns an in-memory
+// cache, and "redis://[:password@]host:port[/db]" returns a Redis-backed cache
+func New(rawURL string) (Cache, error) {
+	switch {
+	case rawURL == "" || rawURL == "memory://":
+		return NewMemoryCache(), nil
+	case strings.HasPrefix(rawURL, "redis://"):
+		return NewRedisCache(rawURL)
+	default:
+		return nil, fmt.Errorf("unsupported cache URL scheme: %s", rawURL)
+	}
+}
@@ -0,0 +1,116 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryPruneInterval is how often expired entries that were never read again are dropped
+const memoryPruneInterval = time.Minute
+
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// MemoryCache is an in-process Cache implementation
+type MemoryCache struct {
+	entries map[string]memoryEntry
+	mu      sync.RWMutex
+	now     func() time.Time
+
+	stop      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewMemoryCache creates an empty in-memory cache. Expired entries are dropped when read, and
+// periodically in the background until the cache is closed.
+func NewMemoryCache() *MemoryCache {
+	c := &MemoryCache{
+		entries: make(map[string]memoryEntry),
+		now:     time.Now,
+		stop:    make(chan struct{}),
+	}
+	go c.pruneLoop(memoryPruneInterval)
+	return c
+}
+
+func (c *MemoryCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	if !ok {
+		return nil, false, nil
+	}
+	if !c.now().Before(entry.expiresAt) {
+		c.mu.Lock()
+		// Re-check under the write lock in case the entry was refreshed concurrently
+		if current, ok := c.entries[key]; ok && !c.now().Before(current.expiresAt) {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+		return nil, false, nil
+	}
+
+	return entry.value, true, nil
+}
+
+func (c *MemoryCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = memoryEntry{
+		value:     value,
+		expiresAt: c.now().Add(ttl),
+	}
+	return nil
+}
+
+func (c *MemoryCache) Delete(_ context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+	return nil
+}
+
+// Close stops the background pruning
+func (c *MemoryCache) Close() error {
+	c.closeOnce.Do(func() { close(c.stop) })
+	return nil
+}
+
+func (c *MemoryCache) pruneLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			c.prune()
+		}
+	}
+}
+
+// prune drops expired entries so the map doesn't grow without bound
+func (c *MemoryCache) prune() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for key, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+}
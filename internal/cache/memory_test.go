@@ -0,0 +1,51 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCache(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewMemoryCache()
+	defer c.Close()
+
+	_, ok, err := c.Get(ctx, "missing")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, c.Set(ctx, "key", []byte("value"), time.Hour))
+	value, ok, err := c.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("value"), value)
+
+	require.NoError(t, c.Delete(ctx, "key"))
+	_, ok, err = c.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, c.Set(ctx, "short", []byte("value"), time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+	_, ok, err = c.Get(ctx, "short")
+	require.NoError(t, err)
+	assert.False(t, ok, "expired entries should not be returned")
+}
+
+func TestNew(t *testing.T) {
+	c, err := cache.New("")
+	require.NoError(t, err)
+	assert.IsType(t, &cache.MemoryCache{}, c)
+
+	c, err = cache.New("redis://:secret@localhost:6380/2")
+	require.NoError(t, err)
+	assert.IsType(t, &cache.RedisCache{}, c)
+
+	_, err = cache.New("memcached://localhost:11211")
+	assert.Error(t, err)
+}
@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisDialTimeout = 5 * time.Second
+	redisIOTimeout   = 5 * time.Second
+	redisMaxIdle     = 8
+)
+
+// RedisCache is a Cache backed by Redis
+type RedisCache struct {
+	client *redis.Client
+}
+
+// NewRedisCache creates a Redis-backed cache from a redis://[:password@]host:port[/db] URL.
+// Connections are established lazily on first use.
+func NewRedisCache(rawURL string) (*RedisCache, error) {
+	opts, err := redis.ParseURL(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+	// redis://secret@host has always meant a password without a user
+	if opts.Password == "" && opts.Username != "" {
+		opts.Password, opts.Username = opts.Username, ""
+	}
+
+	opts.DialTimeout = redisDialTimeout
+	opts.ReadTimeout = redisIOTimeout
+	opts.WriteTimeout = redisIOTimeout
+	opts.MaxIdleConns = redisMaxIdle
+
+	return &RedisCache{client: redis.NewClient(opts)}, nil
+}
+
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := c.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+	return c.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (c *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return c.client.Del(ctx, keys...).Err()
+}
+
+// Close closes the client's connections
+func (c *RedisCache) Close() error {
+	return c.client.Close()
+}
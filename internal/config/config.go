@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	env "github.com/caarlos0/env/v11"
 )
 
@@ -11,25 +13,248 @@ const (
 	DatabaseTypeMemory     DatabaseType = "memory"
 )
 
+// SimilarNameCheck controls what happens when a new server name looks like an existing one
+type SimilarNameCheck string
+
+const (
+	SimilarNameCheckOff    SimilarNameCheck = "off"
+	SimilarNameCheckWarn   SimilarNameCheck = "warn"
+	SimilarNameCheckReject SimilarNameCheck = "reject"
+)
+
+// MixedScriptPolicy controls what happens when a server's namespace mixes scripts, e.g. Latin and Cyrillic
+type MixedScriptPolicy string
+
+const (
+	MixedScriptOff    MixedScriptPolicy = "off"
+	MixedScriptWarn   MixedScriptPolicy = "warn"
+	MixedScriptReject MixedScriptPolicy = "reject"
+)
+
+// ScanPolicy controls what happens when a publish-time scanner finds something suspicious
+type ScanPolicy string
+
+const (
+	ScanPolicyOff    ScanPolicy = "off"
+	ScanPolicyWarn   ScanPolicy = "warn"
+	ScanPolicyReject ScanPolicy = "reject"
+)
+
+// UnknownFieldsPolicy controls what happens when a published server has fields this registry doesn't know, e.g.
+// ones added in a newer version of the server.json schema
+type UnknownFieldsPolicy string
+
+const (
+	UnknownFieldsReject   UnknownFieldsPolicy = "reject"
+	UnknownFieldsPreserve UnknownFieldsPolicy = "preserve"
+)
+
+// ReadAccess controls who may read the catalog
+type ReadAccess string
+
+const (
+	// ReadAccessAnonymous lets anyone read every server
+	ReadAccessAnonymous ReadAccess = "anonymous"
+	// ReadAccessAuthenticated requires a registry JWT or API key to read
+	ReadAccessAuthenticated ReadAccess = "authenticated"
+	// ReadAccessNamespace requires credentials and only shows servers in the namespaces they grant permissions on
+	ReadAccessNamespace ReadAccess = "namespace"
+)
+
 // Config holds the application configuration
 // See .env.example for more documentation
 type Config struct {
 	ServerAddress            string       `env:"SERVER_ADDRESS" envDefault:":8080"`
 	DatabaseType             DatabaseType `env:"DATABASE_TYPE" envDefault:"postgresql"`
 	DatabaseURL              string       `env:"DATABASE_URL" envDefault:"postgres://localhost:5432/mcp-registry?sslmode=disable"`
+	DatabaseAutoMigrate      bool         `env:"DATABASE_AUTO_MIGRATE" envDefault:"true"`
 	SeedFrom                 string       `env:"SEED_FROM" envDefault:""`
 	Version                  string       `env:"VERSION" envDefault:"dev"`
 	GithubClientID           string       `env:"GITHUB_CLIENT_ID" envDefault:""`
 	GithubClientSecret       string       `env:"GITHUB_CLIENT_SECRET" envDefault:""`
 	JWTPrivateKey            string       `env:"JWT_PRIVATE_KEY" envDefault:""`
+	JWTSigningKeys           []string     `env:"JWT_SIGNING_KEYS" envSeparator:","`
 	EnableAnonymousAuth      bool         `env:"ENABLE_ANONYMOUS_AUTH" envDefault:"false"`
 	EnableRegistryValidation bool         `env:"ENABLE_REGISTRY_VALIDATION" envDefault:"true"`
+	RequireNPMProvenance     bool         `env:"REQUIRE_NPM_PROVENANCE" envDefault:"false"`
+	NPMProvenanceRootsFile   string       `env:"NPM_PROVENANCE_ROOTS_FILE" envDefault:""`
+	RequireSemanticVersions  bool         `env:"REQUIRE_SEMANTIC_VERSIONS" envDefault:"false"`
+	PublishJobWorkers        int          `env:"PUBLISH_JOB_WORKERS" envDefault:"4"`
+	ExportSigningKey         string       `env:"EXPORT_SIGNING_KEY" envDefault:""`
+
+	// Further isolated registries hosted by this deployment, each with its own database and settings (see LoadTenants).
+	// Tenant is the name of the tenant a configuration belongs to, and empty for the default registry.
+	TenantsFile string `env:"TENANTS_FILE" envDefault:""`
+	Tenant      string
+
+	// JSON file of reloadable setting overrides, applied at startup and again when it changes or on SIGHUP;
+	// it is checked for changes every poll interval, and 0 only reloads on SIGHUP
+	SettingsFile         string        `env:"SETTINGS_FILE" envDefault:""`
+	SettingsPollInterval time.Duration `env:"SETTINGS_POLL_INTERVAL" envDefault:"10s"`
+
+	// Maximum number of distinct server names in one namespace (the part of a name before the "/"); 0 is unlimited
+	MaxServersPerNamespace int `env:"MAX_SERVERS_PER_NAMESPACE" envDefault:"0"`
+
+	// Address to serve the gRPC API on, alongside HTTP; empty disables it
+	GRPCAddress string `env:"GRPC_ADDRESS" envDefault:""`
+
+	// Public reads may be served by a read replica, falling back to the primary while it lags more than the tolerance
+	DatabaseReadURL    string        `env:"DATABASE_READ_URL" envDefault:""`
+	DatabaseReadMaxLag time.Duration `env:"DATABASE_READ_MAX_LAG" envDefault:"5s"`
+
+	// Remote and website URLs must use HTTPS, and their hostnames must not resolve to private addresses
+	RequireHTTPSURLs bool `env:"REQUIRE_HTTPS_URLS" envDefault:"true"`
+	ResolveURLHosts  bool `env:"RESOLVE_URL_HOSTS" envDefault:"true"`
+
+	// Who may read the catalog: anyone ("anonymous"), holders of a registry JWT or API key ("authenticated"), or
+	// holders of credentials, limited to the servers they could publish or edit ("namespace")
+	ReadAccess ReadAccess `env:"READ_ACCESS" envDefault:"anonymous"`
+
+	// Categories servers may declare; the built-in taxonomy is used if empty
+	Categories []string `env:"CATEGORIES" envSeparator:","`
+
+	// Server name patterns no one may publish, in addition to those added through the admin API
+	BlockedNames []string `env:"BLOCKED_NAMES" envSeparator:","`
+
+	// New server names that look confusingly similar to existing ones are logged ("warn"), rejected ("reject") or allowed ("off")
+	SimilarNameCheck     SimilarNameCheck `env:"SIMILAR_NAME_CHECK" envDefault:"warn"`
+	SimilarNameThreshold float64          `env:"SIMILAR_NAME_THRESHOLD" envDefault:"0.9"`
+
+	// Namespaces with a label mixing scripts, which can spoof another namespace, are rejected ("reject"),
+	// reported as a validation warning ("warn") or allowed ("off")
+	MixedScriptNamespaces MixedScriptPolicy `env:"MIXED_SCRIPT_NAMESPACES" envDefault:"reject"`
+
+	// Servers are scanned for suspicious arguments and known-bad packages when published; findings are
+	// logged ("warn"), reject the publish ("reject") or scanning is skipped ("off")
+	ScanPolicy          ScanPolicy    `env:"SCAN_POLICY" envDefault:"warn"`
+	ScanBlockedPackages []string      `env:"SCAN_BLOCKED_PACKAGES" envSeparator:","`
+	ScanWebhookURL      string        `env:"SCAN_WEBHOOK_URL" envDefault:""`
+	ScanWebhookToken    string        `env:"SCAN_WEBHOOK_TOKEN" envDefault:""`
+	ScanWebhookTimeout  time.Duration `env:"SCAN_WEBHOOK_TIMEOUT" envDefault:"10s"`
+
+	// Published servers with top-level or _meta fields this registry doesn't know are rejected ("reject"), or
+	// stored with those fields kept as they are ("preserve"). Mirrored and imported servers always keep them.
+	UnknownFields UnknownFieldsPolicy `env:"UNKNOWN_FIELDS" envDefault:"reject"`
+
+	// Limits on publish and edit request bodies, rejected with 413 (body size) or 422 (contents); 0 disables a limit
+	PublishMaxBodyBytes       int64 `env:"PUBLISH_MAX_BODY_BYTES" envDefault:"262144"`
+	PublishMaxPackages        int   `env:"PUBLISH_MAX_PACKAGES" envDefault:"50"`
+	PublishMaxEnvironmentVars int   `env:"PUBLISH_MAX_ENVIRONMENT_VARIABLES" envDefault:"100"`
+	PublishMaxStringLength    int   `env:"PUBLISH_MAX_STRING_LENGTH" envDefault:"4096"`
+
+	// Limits on the publisher-provided _meta extension: its encoded size in bytes and how deeply its objects and
+	// arrays nest (0 disables either), and a regular expression every key in it must match (empty allows any).
+	// Its top-level keys must match an allowed pattern, if any are set, and no denied one; patterns use path.Match
+	// syntax, e.g. "build_*".
+	PublisherMetaMaxBytes    int      `env:"PUBLISHER_META_MAX_BYTES" envDefault:"4096"`
+	PublisherMetaMaxDepth    int      `env:"PUBLISHER_META_MAX_DEPTH" envDefault:"5"`
+	PublisherMetaKeyPattern  string   `env:"PUBLISHER_META_KEY_PATTERN" envDefault:"^[A-Za-z0-9_][A-Za-z0-9_./-]{0,127}$"`
+	PublisherMetaAllowedKeys []string `env:"PUBLISHER_META_ALLOWED_KEYS" envSeparator:","`
+	PublisherMetaDeniedKeys  []string `env:"PUBLISHER_META_DENIED_KEYS" envSeparator:","`
+
+	// Accepted server transfers take effect after this long, giving either party time to cancel
+	TransferGracePeriod time.Duration `env:"TRANSFER_GRACE_PERIOD" envDefault:"72h"`
+
+	// Browser security headers; the default CSP allows the /docs page to load its assets from unpkg
+	CORSAllowedOrigins    []string      `env:"CORS_ALLOWED_ORIGINS" envSeparator:","`
+	HSTSMaxAge            time.Duration `env:"HSTS_MAX_AGE" envDefault:"8760h"`
+	ContentSecurityPolicy string        `env:"CONTENT_SECURITY_POLICY" envDefault:"default-src 'none'; script-src https://unpkg.com; style-src 'unsafe-inline' https://unpkg.com; img-src 'self' data: https:; font-src 'self' data: https:; connect-src 'self'; frame-ancestors 'none'; base-uri 'none'"`
+
+	// Responses of these content types are compressed with brotli or gzip, if the client accepts it and they are at
+	// least the minimum size in bytes; an empty list disables compression
+	CompressionContentTypes []string `env:"COMPRESSION_CONTENT_TYPES" envSeparator:"," envDefault:"application/json,application/problem+json,application/x-ndjson,text/html,text/plain"`
+	CompressionMinSize      int      `env:"COMPRESSION_MIN_SIZE" envDefault:"1024"`
+
+	// Debug logging of publish request and response bodies, with credentials and environment variable values redacted
+	PayloadLogging bool `env:"PAYLOAD_LOGGING" envDefault:"false"`
+
+	// Background probing of published remote URLs, reported as health metadata on each server
+	RemoteProbeEnabled  bool          `env:"REMOTE_PROBE_ENABLED" envDefault:"false"`
+	RemoteProbeInterval time.Duration `env:"REMOTE_PROBE_INTERVAL" envDefault:"15m"`
+	RemoteProbeTimeout  time.Duration `env:"REMOTE_PROBE_TIMEOUT" envDefault:"10s"`
+
+	// Change feed events are POSTed to these endpoints in sequence order, retrying each until it succeeds
+	WebhookURLs     []string      `env:"WEBHOOK_URLS" envSeparator:","`
+	WebhookSecret   string        `env:"WEBHOOK_SECRET" envDefault:""`
+	WebhookInterval time.Duration `env:"WEBHOOK_INTERVAL" envDefault:"5s"`
+	WebhookTimeout  time.Duration `env:"WEBHOOK_TIMEOUT" envDefault:"10s"`
+
+	// Mirror mode makes this instance a read-only copy of an upstream registry
+	MirrorUpstreamURL       string        `env:"MIRROR_UPSTREAM_URL" envDefault:""`
+	MirrorSyncInterval      time.Duration `env:"MIRROR_SYNC_INTERVAL" envDefault:"5m"`
+	MirrorUpstreamPublicKey string        `env:"MIRROR_UPSTREAM_PUBLIC_KEY" envDefault:""`
+
+	// Repository validation checks the declared repository exists, is public and, for GitHub OIDC, matches the workflow owner
+	EnableRepositoryValidation      bool   `env:"ENABLE_REPOSITORY_VALIDATION" envDefault:"false"`
+	RepositoryValidationGitHubToken string `env:"REPOSITORY_VALIDATION_GITHUB_TOKEN" envDefault:""`
+
+	// Outbound registry validation requests
+	ValidatorMaxRetries       int           `env:"VALIDATOR_MAX_RETRIES" envDefault:"2"`
+	ValidatorRetryBaseDelay   time.Duration `env:"VALIDATOR_RETRY_BASE_DELAY" envDefault:"200ms"`
+	ValidatorRetryMaxDelay    time.Duration `env:"VALIDATOR_RETRY_MAX_DELAY" envDefault:"2s"`
+	ValidatorBreakerThreshold int           `env:"VALIDATOR_BREAKER_THRESHOLD" envDefault:"5"`
+	ValidatorBreakerCooldown  time.Duration `env:"VALIDATOR_BREAKER_COOLDOWN" envDefault:"30s"`
+
+	// Timeout of each outbound registry validation request, and limits per registry type (e.g. "oci:30s,npm:5s");
+	// disabled registry types (e.g. "oci" during a Docker Hub outage) are accepted without being checked
+	ValidatorTimeout             time.Duration            `env:"VALIDATOR_TIMEOUT" envDefault:"10s"`
+	ValidatorRegistryTimeouts    map[string]time.Duration `env:"VALIDATOR_REGISTRY_TIMEOUTS"`
+	ValidatorRegistryConcurrency map[string]int           `env:"VALIDATOR_REGISTRY_CONCURRENCY"`
+	ValidatorDisabledRegistries  []string                 `env:"VALIDATOR_DISABLED_REGISTRIES" envSeparator:","`
+
+	// Number of a server's packages validated against their registries at the same time
+	ValidatorPackageConcurrency int `env:"VALIDATOR_PACKAGE_CONCURRENCY" envDefault:"4"`
+
+	// Proxy and TLS settings for outbound registry validation requests
+	ValidatorProxyURL                string   `env:"VALIDATOR_PROXY_URL" envDefault:""`
+	ValidatorCACertFile              string   `env:"VALIDATOR_CA_CERT_FILE" envDefault:""`
+	ValidatorInsecureSkipVerifyHosts []string `env:"VALIDATOR_INSECURE_SKIP_VERIFY_HOSTS" envSeparator:","`
+
+	// Connection reuse for outbound registry validation requests
+	ValidatorMaxIdleConnsPerHost int           `env:"VALIDATOR_MAX_IDLE_CONNS_PER_HOST" envDefault:"16"`
+	ValidatorIdleConnTimeout     time.Duration `env:"VALIDATOR_IDLE_CONN_TIMEOUT" envDefault:"90s"`
+	ValidatorDNSCacheTTL         time.Duration `env:"VALIDATOR_DNS_CACHE_TTL" envDefault:"30s"`
+
+	// Mirrors of Maven Central to resolve Maven packages from, tried in order before Maven Central itself
+	ValidatorMavenMirrorURLs []string `env:"VALIDATOR_MAVEN_MIRROR_URLS" envSeparator:","`
+
+	// Self-hosted NPM registries (e.g. Verdaccio or Artifactory) that packages may declare as their base URL
+	ValidatorNPMRegistryURLs []string `env:"VALIDATOR_NPM_REGISTRY_URLS" envSeparator:","`
+
+	// Largest NPM tarball or MCPB file downloaded at publish time to record and verify its SHA-256 hash, in bytes;
+	// 0 disables hash recording
+	ValidatorFileHashMaxBytes int64 `env:"VALIDATOR_FILE_HASH_MAX_BYTES" envDefault:"0"`
+
+	// Private OCI registry credentials, in Docker config.json format
+	OCIRegistryCredentials     string `env:"OCI_REGISTRY_CREDENTIALS" envDefault:""`
+	OCIRegistryCredentialsFile string `env:"OCI_REGISTRY_CREDENTIALS_FILE" envDefault:""`
+
+	// Settings holding credentials (e.g. DATABASE_URL and JWT_PRIVATE_KEY) may instead name where the credential is
+	// kept: file:///path, vault://mount/path#field or awssm://secret-name#field. Secrets are fetched at startup.
+	SecretsVaultAddress   string `env:"SECRETS_VAULT_ADDRESS" envDefault:""`
+	SecretsVaultTokenFile string `env:"SECRETS_VAULT_TOKEN_FILE" envDefault:""`
+
+	// Cache Configuration
+	RedisURL           string        `env:"REDIS_URL" envDefault:""`
+	ValidationCacheTTL time.Duration `env:"VALIDATION_CACHE_TTL" envDefault:"1h"`
+	ReadCacheTTL       time.Duration `env:"READ_CACHE_TTL" envDefault:"0"`
+
+	// Object storage for uploaded server icons
+	IconStorageURL             string `env:"ICON_STORAGE_URL" envDefault:""`
+	IconStorageAccessKeyID     string `env:"ICON_STORAGE_ACCESS_KEY_ID" envDefault:""`
+	IconStorageSecretAccessKey string `env:"ICON_STORAGE_SECRET_ACCESS_KEY" envDefault:""`
+
+	// Object storage for catalog dumps made by `registry backup`, in the same URL format as ICON_STORAGE_URL
+	BackupStorageURL             string `env:"BACKUP_STORAGE_URL" envDefault:""`
+	BackupStorageAccessKeyID     string `env:"BACKUP_STORAGE_ACCESS_KEY_ID" envDefault:""`
+	BackupStorageSecretAccessKey string `env:"BACKUP_STORAGE_SECRET_ACCESS_KEY" envDefault:""`
 
 	// OIDC Configuration
 	OIDCEnabled      bool   `env:"OIDC_ENABLED" envDefault:"false"`
 	OIDCIssuer       string `env:"OIDC_ISSUER" envDefault:""`
 	OIDCClientID     string `env:"OIDC_CLIENT_ID" envDefault:""`
 	OIDCClientSecret string `env:"OIDC_CLIENT_SECRET" envDefault:""`
+	OIDCRedirectURL  string `env:"OIDC_REDIRECT_URL" envDefault:""`
 	OIDCExtraClaims  string `env:"OIDC_EXTRA_CLAIMS" envDefault:""`
 	OIDCEditPerms    string `env:"OIDC_EDIT_PERMISSIONS" envDefault:""`
 	OIDCPublishPerms string `env:"OIDC_PUBLISH_PERMISSIONS" envDefault:""`
@@ -39,7 +264,7 @@ type Config struct {
 func NewConfig() *Config {
 	var cfg Config
 	err := env.ParseWithOptions(&cfg, env.Options{
-		Prefix: "MCP_REGISTRY_",
+		Prefix: envPrefix,
 	})
 	if err != nil {
 		panic(err)
@@ -0,0 +1,208 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	env "github.com/caarlos0/env/v11"
+)
+
+// reloadableSettings can be changed while the registry is running, from the settings file or on SIGHUP. The
+// rest of the configuration is read once at startup.
+var reloadableSettings = []string{
+	"BLOCKED_NAMES", "MAX_SERVERS_PER_NAMESPACE",
+	"SIMILAR_NAME_CHECK", "SIMILAR_NAME_THRESHOLD", "MIXED_SCRIPT_NAMESPACES", "SCAN_POLICY", "UNKNOWN_FIELDS",
+	"ENABLE_REGISTRY_VALIDATION", "REQUIRE_SEMANTIC_VERSIONS",
+	"PUBLISHER_META_MAX_BYTES", "PUBLISHER_META_MAX_DEPTH", "PUBLISHER_META_KEY_PATTERN",
+	"PUBLISHER_META_ALLOWED_KEYS", "PUBLISHER_META_DENIED_KEYS",
+	"VALIDATOR_TIMEOUT", "VALIDATOR_REGISTRY_TIMEOUTS", "VALIDATOR_REGISTRY_CONCURRENCY", "VALIDATOR_DISABLED_REGISTRIES",
+	"READ_ACCESS",
+}
+
+// Reload returns a copy of cfg with the reloadable settings read again from the environment and the settings
+// file, a JSON object of setting overrides like a tenant's (e.g. {"MAX_SERVERS_PER_NAMESPACE": "10"}). The
+// file may only hold reloadable settings. An error leaves the configuration to keep using unchanged.
+func Reload(cfg *Config) (*Config, error) {
+	environment := env.ToMap(os.Environ())
+	if cfg.SettingsFile != "" {
+		data, err := os.ReadFile(cfg.SettingsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read settings file: %w", err)
+		}
+		var settings map[string]string
+		if err := json.Unmarshal(data, &settings); err != nil {
+			return nil, fmt.Errorf("failed to parse settings file: %w", err)
+		}
+		for key, value := range settings {
+			key = strings.TrimPrefix(strings.ToUpper(key), envPrefix)
+			if !isReloadable(key) {
+				return nil, fmt.Errorf("%s can't be changed while the registry is running", key)
+			}
+			environment[envPrefix+key] = value
+		}
+	}
+
+	var fresh Config
+	if err := env.ParseWithOptions(&fresh, env.Options{Prefix: envPrefix, Environment: environment}); err != nil {
+		return nil, err
+	}
+	if err := fresh.Validate(); err != nil {
+		return nil, err
+	}
+
+	next := *cfg
+	next.BlockedNames = fresh.BlockedNames
+	next.MaxServersPerNamespace = fresh.MaxServersPerNamespace
+	next.SimilarNameCheck = fresh.SimilarNameCheck
+	next.SimilarNameThreshold = fresh.SimilarNameThreshold
+	next.MixedScriptNamespaces = fresh.MixedScriptNamespaces
+	next.ScanPolicy = fresh.ScanPolicy
+	next.UnknownFields = fresh.UnknownFields
+	next.EnableRegistryValidation = fresh.EnableRegistryValidation
+	next.RequireSemanticVersions = fresh.RequireSemanticVersions
+	next.PublisherMetaMaxBytes = fresh.PublisherMetaMaxBytes
+	next.PublisherMetaMaxDepth = fresh.PublisherMetaMaxDepth
+	next.PublisherMetaKeyPattern = fresh.PublisherMetaKeyPattern
+	next.PublisherMetaAllowedKeys = fresh.PublisherMetaAllowedKeys
+	next.PublisherMetaDeniedKeys = fresh.PublisherMetaDeniedKeys
+	next.ValidatorTimeout = fresh.ValidatorTimeout
+	next.ValidatorRegistryTimeouts = fresh.ValidatorRegistryTimeouts
+	next.ValidatorRegistryConcurrency = fresh.ValidatorRegistryConcurrency
+	next.ValidatorDisabledRegistries = fresh.ValidatorDisabledRegistries
+	next.ReadAccess = fresh.ReadAccess
+	return &next, nil
+}
+
+func isReloadable(key string) bool {
+	for _, setting := range reloadableSettings {
+		if key == setting {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the reloadable settings, which unlike the others are applied without restarting
+func (c *Config) Validate() error {
+	var errs []error
+	if c.MaxServersPerNamespace < 0 {
+		errs = append(errs, fmt.Errorf("MAX_SERVERS_PER_NAMESPACE must not be negative, got %d", c.MaxServersPerNamespace))
+	}
+	switch c.SimilarNameCheck {
+	case SimilarNameCheckOff, SimilarNameCheckWarn, SimilarNameCheckReject:
+	default:
+		errs = append(errs, fmt.Errorf("SIMILAR_NAME_CHECK must be off, warn or reject, got %q", c.SimilarNameCheck))
+	}
+	if c.SimilarNameThreshold <= 0 || c.SimilarNameThreshold > 1 {
+		errs = append(errs, fmt.Errorf("SIMILAR_NAME_THRESHOLD must be above 0 and at most 1, got %g", c.SimilarNameThreshold))
+	}
+	switch c.MixedScriptNamespaces {
+	case MixedScriptOff, MixedScriptWarn, MixedScriptReject:
+	default:
+		errs = append(errs, fmt.Errorf("MIXED_SCRIPT_NAMESPACES must be off, warn or reject, got %q", c.MixedScriptNamespaces))
+	}
+	switch c.ScanPolicy {
+	case ScanPolicyOff, ScanPolicyWarn, ScanPolicyReject:
+	default:
+		errs = append(errs, fmt.Errorf("SCAN_POLICY must be off, warn or reject, got %q", c.ScanPolicy))
+	}
+	switch c.UnknownFields {
+	case UnknownFieldsReject, UnknownFieldsPreserve:
+	default:
+		errs = append(errs, fmt.Errorf("UNKNOWN_FIELDS must be reject or preserve, got %q", c.UnknownFields))
+	}
+	switch c.ReadAccess {
+	case ReadAccessAnonymous, ReadAccessAuthenticated, ReadAccessNamespace:
+	default:
+		errs = append(errs, fmt.Errorf("READ_ACCESS must be anonymous, authenticated or namespace, got %q", c.ReadAccess))
+	}
+	if c.PublisherMetaMaxBytes < 0 {
+		errs = append(errs, fmt.Errorf("PUBLISHER_META_MAX_BYTES must not be negative, got %d", c.PublisherMetaMaxBytes))
+	}
+	if c.PublisherMetaMaxDepth < 0 {
+		errs = append(errs, fmt.Errorf("PUBLISHER_META_MAX_DEPTH must not be negative, got %d", c.PublisherMetaMaxDepth))
+	}
+	if _, err := regexp.Compile(c.PublisherMetaKeyPattern); err != nil {
+		errs = append(errs, fmt.Errorf("PUBLISHER_META_KEY_PATTERN is not a valid regular expression: %w", err))
+	}
+	for _, pattern := range slices.Concat(c.PublisherMetaAllowedKeys, c.PublisherMetaDeniedKeys) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			errs = append(errs, fmt.Errorf("publisher metadata key pattern %q is invalid: %w", pattern, err))
+		}
+	}
+	if c.ValidatorTimeout < 0 {
+		errs = append(errs, fmt.Errorf("VALIDATOR_TIMEOUT must not be negative, got %s", c.ValidatorTimeout))
+	}
+	for registryType, timeout := range c.ValidatorRegistryTimeouts {
+		if timeout <= 0 {
+			errs = append(errs, fmt.Errorf("VALIDATOR_REGISTRY_TIMEOUTS for %s must be positive, got %s", registryType, timeout))
+		}
+	}
+	for registryType, concurrency := range c.ValidatorRegistryConcurrency {
+		if concurrency < 0 {
+			errs = append(errs, fmt.Errorf("VALIDATOR_REGISTRY_CONCURRENCY for %s must not be negative, got %d", registryType, concurrency))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Live holds the current configuration of a registry, which reloads swap atomically. Each configuration is
+// immutable once stored, so readers can use the one they loaded for as long as they need.
+type Live struct {
+	current atomic.Pointer[Config]
+}
+
+// NewLive creates a holder for cfg
+func NewLive(cfg *Config) *Live {
+	l := &Live{}
+	l.current.Store(cfg)
+	return l
+}
+
+// Load returns the current configuration
+func (l *Live) Load() *Config {
+	return l.current.Load()
+}
+
+// Store replaces the current configuration
+func (l *Live) Store(cfg *Config) {
+	l.current.Store(cfg)
+}
+
+// WatchFile calls onChange whenever the file at path is modified, checking every interval until ctx is cancelled
+func WatchFile(ctx context.Context, path string, interval time.Duration, onChange func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := fileVersion(path)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if version := fileVersion(path); version != last {
+			last = version
+			onChange()
+		}
+	}
+}
+
+// fileVersion identifies the contents of a file by its modification time and size, or is empty if it can't be read
+func fileVersion(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", info.ModTime().UnixNano(), info.Size())
+}
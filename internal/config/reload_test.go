@@ -0,0 +1,81 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReload(t *testing.T) {
+	t.Setenv("MCP_REGISTRY_MAX_SERVERS_PER_NAMESPACE", "5")
+	t.Setenv("MCP_REGISTRY_DATABASE_URL", "postgres://registry@db/changed")
+
+	reload := func(t *testing.T, settings string) (*config.Config, error) {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "settings.json")
+		require.NoError(t, os.WriteFile(path, []byte(settings), 0o600))
+		return config.Reload(&config.Config{SettingsFile: path, DatabaseURL: "postgres://registry@db/original"})
+	}
+
+	t.Run("settings file overrides the environment", func(t *testing.T) {
+		cfg, err := reload(t, `{"MAX_SERVERS_PER_NAMESPACE": "10", "BLOCKED_NAMES": "com.example/*", "SCAN_POLICY": "reject"}`)
+		require.NoError(t, err)
+		assert.Equal(t, 10, cfg.MaxServersPerNamespace)
+		assert.Equal(t, []string{"com.example/*"}, cfg.BlockedNames)
+		assert.Equal(t, config.ScanPolicyReject, cfg.ScanPolicy)
+		assert.Equal(t, "postgres://registry@db/original", cfg.DatabaseURL, "settings that aren't reloadable are kept")
+	})
+
+	t.Run("environment is read again", func(t *testing.T) {
+		cfg, err := reload(t, `{}`)
+		require.NoError(t, err)
+		assert.Equal(t, 5, cfg.MaxServersPerNamespace)
+	})
+
+	t.Run("settings that aren't reloadable are rejected", func(t *testing.T) {
+		_, err := reload(t, `{"DATABASE_URL": "postgres://registry@db/other"}`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "DATABASE_URL")
+	})
+
+	t.Run("invalid settings are rejected", func(t *testing.T) {
+		_, err := reload(t, `{"SIMILAR_NAME_CHECK": "sometimes", "MAX_SERVERS_PER_NAMESPACE": "-1"}`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "SIMILAR_NAME_CHECK")
+		assert.Contains(t, err.Error(), "MAX_SERVERS_PER_NAMESPACE")
+	})
+
+	t.Run("invalid publisher metadata patterns are rejected", func(t *testing.T) {
+		_, err := reload(t, `{"PUBLISHER_META_KEY_PATTERN": "^[a-z", "PUBLISHER_META_DENIED_KEYS": "secret[*"}`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "PUBLISHER_META_KEY_PATTERN")
+		assert.Contains(t, err.Error(), "secret[*")
+	})
+
+	t.Run("malformed file is rejected", func(t *testing.T) {
+		_, err := reload(t, `MAX_SERVERS_PER_NAMESPACE=10`)
+		assert.Error(t, err)
+	})
+}
+
+func TestWatchFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{}`), 0o600))
+
+	changed := make(chan struct{}, 1)
+	go config.WatchFile(t.Context(), path, 10*time.Millisecond, func() { changed <- struct{}{} })
+
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, os.WriteFile(path, []byte(`{"MAX_SERVERS_PER_NAMESPACE": "10"}`), 0o600))
+
+	select {
+	case <-changed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("change to the settings file was not noticed")
+	}
+}
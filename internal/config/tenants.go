@@ -0,0 +1,129 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	env "github.com/caarlos0/env/v11"
+)
+
+const envPrefix = "MCP_REGISTRY_"
+
+// tenantNameRe restricts tenant names to values that are safe in URL paths and cache keys
+var tenantNameRe = regexp.MustCompile(`^[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// processSettings apply to the whole deployment and can't be overridden per tenant: the listener, browser security
+// headers, response compression and payload logging are shared, outbound validation requests share one HTTP
+// transport and cache, and `registry backup` dumps the deployment's default catalog
+var processSettings = []string{
+	"SERVER_ADDRESS", "GRPC_ADDRESS", "TENANTS_FILE", "SETTINGS_FILE", "SETTINGS_POLL_INTERVAL", "VERSION",
+	"CORS_ALLOWED_ORIGINS", "HSTS_MAX_AGE", "CONTENT_SECURITY_POLICY", "COMPRESSION_CONTENT_TYPES", "COMPRESSION_MIN_SIZE", "PAYLOAD_LOGGING",
+	"REDIS_URL", "VALIDATION_CACHE_TTL", "OCI_REGISTRY_CREDENTIALS", "OCI_REGISTRY_CREDENTIALS_FILE",
+	"VALIDATOR_MAX_RETRIES", "VALIDATOR_RETRY_BASE_DELAY", "VALIDATOR_RETRY_MAX_DELAY",
+	"VALIDATOR_BREAKER_THRESHOLD", "VALIDATOR_BREAKER_COOLDOWN",
+	"VALIDATOR_TIMEOUT", "VALIDATOR_REGISTRY_TIMEOUTS", "VALIDATOR_REGISTRY_CONCURRENCY", "VALIDATOR_DISABLED_REGISTRIES",
+	"VALIDATOR_PROXY_URL", "VALIDATOR_CA_CERT_FILE", "VALIDATOR_INSECURE_SKIP_VERIFY_HOSTS",
+	"VALIDATOR_MAVEN_MIRROR_URLS", "VALIDATOR_NPM_REGISTRY_URLS", "VALIDATOR_FILE_HASH_MAX_BYTES",
+	"NPM_PROVENANCE_ROOTS_FILE", "SECRETS_VAULT_ADDRESS", "SECRETS_VAULT_TOKEN_FILE",
+	"BACKUP_STORAGE_URL", "BACKUP_STORAGE_ACCESS_KEY_ID", "BACKUP_STORAGE_SECRET_ACCESS_KEY",
+}
+
+// tenantSettings must be set by every tenant to a value of its own, so tenants never share data or token signing keys
+var tenantSettings = []struct {
+	key   string
+	value func(cfg *Config) string
+}{
+	{"DATABASE_URL", func(cfg *Config) string { return cfg.DatabaseURL }},
+	{"JWT_PRIVATE_KEY", func(cfg *Config) string { return cfg.JWTPrivateKey }},
+}
+
+// LoadTenants reads the tenants file, a JSON object mapping each tenant name to the settings it overrides
+// (environment variable names without the MCP_REGISTRY_ prefix, e.g. {"internal": {"DATABASE_URL": "..."}}).
+// Each tenant's configuration is the deployment's environment with its overrides applied.
+func LoadTenants(path string) (map[string]*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tenants file: %w", err)
+	}
+
+	var overrides map[string]map[string]string
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse tenants file: %w", err)
+	}
+
+	tenants := make(map[string]*Config, len(overrides))
+	for name, settings := range overrides {
+		cfg, err := tenantConfig(name, settings)
+		if err != nil {
+			return nil, fmt.Errorf("tenant %q: %w", name, err)
+		}
+		tenants[name] = cfg
+	}
+	return tenants, nil
+}
+
+// CheckTenantIsolation checks that no two of the default deployment and its tenants share a database or token
+// signing key. Run it once secret references are resolved, since different references can name the same secret.
+func CheckTenantIsolation(cfg *Config, tenants map[string]*Config) error {
+	for _, setting := range tenantSettings {
+		owners := map[string]string{setting.value(cfg): "the default deployment"}
+		for _, name := range TenantNames(tenants) {
+			value := setting.value(tenants[name])
+			if owner, shared := owners[value]; shared {
+				return fmt.Errorf("tenant %q: %s is the same as that of %s", name, setting.key, owner)
+			}
+			owners[value] = fmt.Sprintf("tenant %q", name)
+		}
+	}
+	return nil
+}
+
+// TenantNames returns the names of tenants in sorted order
+func TenantNames(tenants map[string]*Config) []string {
+	names := make([]string, 0, len(tenants))
+	for name := range tenants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// tenantConfig parses a tenant's configuration from the process environment with its overrides applied
+func tenantConfig(name string, settings map[string]string) (*Config, error) {
+	if !tenantNameRe.MatchString(name) {
+		return nil, fmt.Errorf("invalid tenant name: must be lowercase letters, digits and hyphens")
+	}
+
+	environment := env.ToMap(os.Environ())
+	inherited := make(map[string]string, len(tenantSettings))
+	for _, setting := range tenantSettings {
+		inherited[setting.key] = environment[envPrefix+setting.key]
+	}
+
+	for key, value := range settings {
+		key = strings.TrimPrefix(strings.ToUpper(key), envPrefix)
+		for _, setting := range processSettings {
+			if key == setting {
+				return nil, fmt.Errorf("%s applies to the whole deployment and can't be set per tenant", key)
+			}
+		}
+		environment[envPrefix+key] = value
+	}
+
+	for _, setting := range tenantSettings {
+		if value := environment[envPrefix+setting.key]; value == "" || value == inherited[setting.key] {
+			return nil, fmt.Errorf("%s must be set to a value of the tenant's own", setting.key)
+		}
+	}
+
+	var cfg Config
+	if err := env.ParseWithOptions(&cfg, env.Options{Prefix: envPrefix, Environment: environment}); err != nil {
+		return nil, err
+	}
+	cfg.Tenant = name
+	return &cfg, nil
+}
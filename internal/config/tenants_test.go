@@ -0,0 +1,80 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTenants(t *testing.T) {
+	t.Setenv("MCP_REGISTRY_DATABASE_URL", "postgres://registry@db/default")
+	t.Setenv("MCP_REGISTRY_JWT_PRIVATE_KEY", "default-key")
+
+	load := func(t *testing.T, tenants string) (map[string]*config.Config, error) {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "tenants.json")
+		require.NoError(t, os.WriteFile(path, []byte(tenants), 0o600))
+		return config.LoadTenants(path)
+	}
+
+	t.Run("tenant with its own database and key", func(t *testing.T) {
+		tenants, err := load(t, `{"internal": {"DATABASE_URL": "postgres://registry@db/internal", "JWT_PRIVATE_KEY": "internal-key"}}`)
+		require.NoError(t, err)
+		require.Contains(t, tenants, "internal")
+		assert.Equal(t, "internal", tenants["internal"].Tenant)
+		assert.Equal(t, "postgres://registry@db/internal", tenants["internal"].DatabaseURL)
+	})
+
+	t.Run("inherited signing key is rejected", func(t *testing.T) {
+		_, err := load(t, `{"internal": {"DATABASE_URL": "postgres://registry@db/internal"}}`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "JWT_PRIVATE_KEY")
+	})
+
+	t.Run("database shared with the deployment is rejected", func(t *testing.T) {
+		_, err := load(t, `{"internal": {"DATABASE_URL": "postgres://registry@db/default", "JWT_PRIVATE_KEY": "internal-key"}}`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "DATABASE_URL")
+	})
+
+	t.Run("process settings can't be overridden", func(t *testing.T) {
+		_, err := load(t, `{"internal": {"DATABASE_URL": "postgres://registry@db/internal", "JWT_PRIVATE_KEY": "internal-key", "SERVER_ADDRESS": ":9090"}}`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "SERVER_ADDRESS")
+	})
+}
+
+func TestCheckTenantIsolation(t *testing.T) {
+	deployment := &config.Config{DatabaseURL: "postgres://registry@db/default", JWTPrivateKey: "default-key"}
+
+	t.Run("tenants with their own databases and keys", func(t *testing.T) {
+		err := config.CheckTenantIsolation(deployment, map[string]*config.Config{
+			"internal": {DatabaseURL: "postgres://registry@db/internal", JWTPrivateKey: "internal-key"},
+			"partners": {DatabaseURL: "postgres://registry@db/partners", JWTPrivateKey: "partners-key"},
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("tenants sharing a signing key are rejected", func(t *testing.T) {
+		err := config.CheckTenantIsolation(deployment, map[string]*config.Config{
+			"internal": {DatabaseURL: "postgres://registry@db/internal", JWTPrivateKey: "shared-key"},
+			"partners": {DatabaseURL: "postgres://registry@db/partners", JWTPrivateKey: "shared-key"},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "JWT_PRIVATE_KEY")
+		assert.Contains(t, err.Error(), `"internal"`)
+	})
+
+	t.Run("database resolved to the deployment's is rejected", func(t *testing.T) {
+		err := config.CheckTenantIsolation(deployment, map[string]*config.Config{
+			"internal": {DatabaseURL: "postgres://registry@db/default", JWTPrivateKey: "internal-key"},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "DATABASE_URL")
+		assert.Contains(t, err.Error(), "default deployment")
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
 // Common database errors
@@ -15,17 +16,194 @@ var (
 	ErrInvalidInput      = errors.New("invalid input")
 	ErrDatabase          = errors.New("database error")
 	ErrInvalidVersion    = errors.New("invalid version: cannot publish duplicate version")
+	ErrLeaseHeld         = errors.New("lease held by another instance")
+	ErrRevisionConflict  = errors.New("revision conflict: record was changed since it was read")
+	ErrNameConflict      = errors.New("server name differs only in case from an existing server name")
 	ErrMaxServersReached = errors.New("maximum number of versions for this server reached (10000): please reach out at https://github.com/modelcontextprotocol/registry to explain your use case")
 )
 
 // ServerFilter defines filtering options for server queries
 type ServerFilter struct {
-	Name          *string    // for finding versions of same server
-	RemoteURL     *string    // for duplicate URL detection
-	UpdatedSince  *time.Time // for incremental sync filtering
-	SubstringName *string    // for substring search on name
-	Version       *string    // for exact version matching
-	IsLatest      *bool      // for filtering latest versions only
+	Name           *string    // for finding versions of same server
+	NameFold       *string    // for finding servers whose name differs only in case
+	RemoteURL      *string    // for duplicate URL detection
+	UpdatedSince   *time.Time // for incremental sync filtering
+	SubstringName  *string    // for substring search on name
+	Version        *string    // for exact version matching
+	IsLatest       *bool      // for filtering latest versions only
+	Transport      *string    // for filtering by remote or package transport type
+	RegistryType   *string    // for filtering by package registry type
+	Category       *string    // for filtering by category
+	Tag            *string    // for filtering by tag
+	ExcludeDeleted bool       // for hiding deleted servers
+	HasAdvisory    *bool      // for filtering by whether a security advisory affects the version
+	Sort           ServerSort // order of results; registry ID order if empty
+}
+
+// ServerSort is an order in which servers can be listed
+type ServerSort string
+
+const (
+	// ServerSortDownloads lists the most installed servers over the last 7 days first
+	ServerSortDownloads ServerSort = "downloads"
+	// ServerSortRecent lists the most recently published servers first
+	ServerSortRecent ServerSort = "recent"
+	// ServerSortName lists servers alphabetically by name
+	ServerSortName ServerSort = "name"
+	// ServerSortRelevance lists the closest matches to SubstringName first: exact names, then exact
+	// names within the namespace, then prefixes, then other matches, each alphabetically by name
+	ServerSortRelevance ServerSort = "relevance"
+)
+
+// APIKey is a long-lived publish credential. Only a hash of the key itself is stored.
+type APIKey struct {
+	ID        string
+	Name      string
+	Prefix    string // leading characters of the key, so owners can tell keys apart
+	KeyHash   string // hex-encoded SHA-256 of the full key
+	Owner     string // "<auth method>:<subject>" of the identity that created the key
+	Scopes    []string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+}
+
+// NamespaceReservation claims a namespace for a single identity, so only it can publish there
+type NamespaceReservation struct {
+	Namespace string
+	Owner     string // "<auth method>:<subject>" of the identity holding the reservation
+	CreatedAt time.Time
+}
+
+// BlockedName is a blocklist entry that no server name may match
+type BlockedName struct {
+	Pattern   string // "namespace/name" pattern, or a word blocked anywhere in a name
+	Reason    string
+	CreatedBy string // "<auth method>:<subject>" of the admin who added the entry
+	CreatedAt time.Time
+}
+
+// RemoteHealth is the outcome of the most recent liveness probe of a remote URL
+type RemoteHealth struct {
+	URL             string
+	LastCheckedAt   time.Time
+	LastSeenHealthy *time.Time // nil if no probe has succeeded
+	LastError       string     // empty if the most recent probe succeeded
+}
+
+// WebhookCursor tracks how far through the change feed a webhook endpoint has been delivered, and which
+// registry instance currently holds the lease to deliver to it
+type WebhookCursor struct {
+	Endpoint     string
+	LastSequence int64 // sequence of the last change delivered
+	LeasedBy     string
+	LeasedUntil  time.Time
+}
+
+// IdempotencyKey records a publish made with an Idempotency-Key header, so a retry of the same request
+// returns the original response instead of publishing again
+type IdempotencyKey struct {
+	Owner       string // "<auth method>:<subject>" of the publisher; keys are scoped to the identity using them
+	Key         string
+	RequestHash string            // hex-encoded SHA-256 of the request body
+	Response    *apiv0.ServerJSON // nil while the publish is in progress
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// ServerEventType is a kind of usage event counted for a server
+type ServerEventType string
+
+const (
+	ServerEventInstall ServerEventType = "install"
+	ServerEventView    ServerEventType = "view"
+)
+
+// ServerEventCount is how many events of one type a server received on one day (UTC)
+type ServerEventCount struct {
+	ServerName string
+	EventType  ServerEventType
+	Day        time.Time // midnight UTC
+	Count      int64
+}
+
+// WeeklyStatsStart returns midnight UTC at the start of the 7-day window, ending with the day containing now,
+// that weekly usage statistics cover
+func WeeklyStatsStart(now time.Time) time.Time {
+	return eventDay(now).AddDate(0, 0, -6)
+}
+
+// eventDay truncates t to midnight UTC, the granularity usage events are counted at
+func eventDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// ServerIcon records the icon uploaded for a server. The image itself lives in object storage,
+// addressed by its SHA256 so every version of a server shares it.
+type ServerIcon struct {
+	ServerName  string
+	ContentType string // image/png or image/svg+xml
+	SHA256      string // hex digest of the sanitized image
+	UpdatedBy   string // "<auth method>:<subject>" of whoever uploaded it
+	UpdatedAt   time.Time
+}
+
+// AdvisorySeverity is how serious a security advisory is
+type AdvisorySeverity string
+
+const (
+	AdvisorySeverityLow      AdvisorySeverity = "low"
+	AdvisorySeverityMedium   AdvisorySeverity = "medium"
+	AdvisorySeverityHigh     AdvisorySeverity = "high"
+	AdvisorySeverityCritical AdvisorySeverity = "critical"
+)
+
+// Advisory is a security advisory about a server. It is linked to each version of the server it affects,
+// including versions published after the advisory.
+type Advisory struct {
+	ID               string
+	ServerName       string
+	Severity         AdvisorySeverity
+	Summary          string
+	Description      string
+	AffectedVersions string   // npm-style version range; empty if every version is affected
+	Remediation      string   // what users should do, e.g. upgrade to a fixed version
+	Aliases          []string // identifiers of the same issue elsewhere, such as CVE IDs
+	CreatedBy        string   // "<auth method>:<subject>" of the admin or publisher who filed it
+	CreatedAt        time.Time
+}
+
+// TransferStatus is the state of a server transfer
+type TransferStatus string
+
+const (
+	TransferStatusPending   TransferStatus = "pending"   // waiting for the recipient to accept
+	TransferStatusAccepted  TransferStatus = "accepted"  // accepted, and completing once the grace period ends
+	TransferStatusCompleted TransferStatus = "completed" // every version has been renamed
+	TransferStatusCancelled TransferStatus = "cancelled" // cancelled by either party, or failed to complete
+	TransferStatusExpired   TransferStatus = "expired"   // not accepted in time
+)
+
+// TransferEvent is an entry in a transfer's audit trail
+type TransferEvent struct {
+	Action string    `json:"action"`
+	Actor  string    `json:"actor,omitempty"`
+	Reason string    `json:"reason,omitempty"`
+	At     time.Time `json:"at"`
+}
+
+// ServerTransfer renames every version of a server into another namespace once both parties agree
+type ServerTransfer struct {
+	ID          string
+	ServerName  string
+	NewName     string
+	Status      TransferStatus
+	RequestedBy string // "<auth method>:<subject>" of the current owner
+	AcceptedBy  string // "<auth method>:<subject>" of the recipient, once accepted
+	CreatedAt   time.Time
+	ExpiresAt   time.Time  // pending transfers expire if not accepted by then
+	CompletesAt *time.Time // when an accepted transfer takes effect
+	Events      []TransferEvent
 }
 
 // Database defines the interface for database operations
@@ -34,14 +212,146 @@ type Database interface {
 	List(ctx context.Context, filter *ServerFilter, cursor string, limit int) ([]*apiv0.ServerJSON, string, error)
 	// Retrieve a single server by its ID
 	GetByID(ctx context.Context, id string) (*apiv0.ServerJSON, error)
+	// GetLatestVersion returns the version of the named server marked latest, without reading its other versions
+	GetLatestVersion(ctx context.Context, name string) (*apiv0.ServerJSON, error)
 	// CreateServer adds a new server to the database
 	CreateServer(ctx context.Context, server *apiv0.ServerJSON) (*apiv0.ServerJSON, error)
-	// UpdateServer updates an existing server record
-	UpdateServer(ctx context.Context, id string, server *apiv0.ServerJSON) (*apiv0.ServerJSON, error)
+	// PublishServerVersion adds a new version of a server and, if it is marked latest, marks the server's previous
+	// latest version as no longer the latest, recording both changes in the change feed atomically. It fails with
+	// ErrNameConflict if another server's name differs from the server's only in case.
+	PublishServerVersion(ctx context.Context, server *apiv0.ServerJSON) (*apiv0.ServerJSON, error)
+	// UpdateServer updates an existing server record and increments its revision. If expectedRevision is
+	// non-zero, the update fails with ErrRevisionConflict unless the stored record is at that revision.
+	UpdateServer(ctx context.Context, id string, server *apiv0.ServerJSON, expectedRevision int64) (*apiv0.ServerJSON, error)
+	// ListChanges returns change feed events with a sequence number greater than since, in sequence order
+	ListChanges(ctx context.Context, since int64, limit int) ([]*apiv0.ChangeEvent, error)
+	// CreateAPIKey stores a new API key
+	CreateAPIKey(ctx context.Context, key *APIKey) error
+	// GetAPIKeyByHash retrieves an API key by the hash of its secret
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (*APIKey, error)
+	// ListAPIKeys retrieves all API keys created by an owner, including expired and revoked keys
+	ListAPIKeys(ctx context.Context, owner string) ([]*APIKey, error)
+	// RevokeAPIKey marks an owner's API key as revoked
+	RevokeAPIKey(ctx context.Context, id, owner string, revokedAt time.Time) error
+	// ReserveNamespace stores a namespace reservation, failing if the namespace is already reserved
+	ReserveNamespace(ctx context.Context, reservation *NamespaceReservation) error
+	// GetNamespaceReservation retrieves the reservation for a namespace
+	GetNamespaceReservation(ctx context.Context, namespace string) (*NamespaceReservation, error)
+	// ReleaseNamespace removes an owner's namespace reservation
+	ReleaseNamespace(ctx context.Context, namespace, owner string) error
+	// CreateTransfer stores a new server transfer
+	CreateTransfer(ctx context.Context, transfer *ServerTransfer) error
+	// GetTransfer retrieves a server transfer by ID
+	GetTransfer(ctx context.Context, id string) (*ServerTransfer, error)
+	// ListTransfers retrieves transfers in the given status, oldest first
+	ListTransfers(ctx context.Context, status TransferStatus) ([]*ServerTransfer, error)
+	// UpdateTransfer saves a transfer, provided its status is still previousStatus
+	UpdateTransfer(ctx context.Context, transfer *ServerTransfer, previousStatus TransferStatus) error
+	// AddBlockedName stores a blocklist entry, failing if the pattern is already blocked
+	AddBlockedName(ctx context.Context, entry *BlockedName) error
+	// ListBlockedNames retrieves every blocklist entry, oldest first
+	ListBlockedNames(ctx context.Context) ([]*BlockedName, error)
+	// RemoveBlockedName deletes a blocklist entry
+	RemoveBlockedName(ctx context.Context, pattern string) error
+	// SaveRemoteHealth records a probe result, keeping the previous LastSeenHealthy if the probe failed
+	SaveRemoteHealth(ctx context.Context, health *RemoteHealth) error
+	// GetRemoteHealth retrieves the health of the given remote URLs, keyed by URL; unprobed URLs are omitted
+	GetRemoteHealth(ctx context.Context, urls []string) (map[string]*RemoteHealth, error)
+	// CountServersByCategory counts the latest, non-deleted version of each server in each category
+	CountServersByCategory(ctx context.Context) (map[string]int, error)
+	// RecordServerEvent increments a server's counter of eventType for the day containing at
+	RecordServerEvent(ctx context.Context, serverName string, eventType ServerEventType, at time.Time) error
+	// ListServerEventCounts retrieves the daily event counts of the given servers from the day containing since onwards
+	ListServerEventCounts(ctx context.Context, serverNames []string, since time.Time) ([]*ServerEventCount, error)
+	// SetServerIcon stores a server's icon, replacing any previous one
+	SetServerIcon(ctx context.Context, icon *ServerIcon) error
+	// GetServerIcons retrieves the icons of the given servers, keyed by server name; servers without one are omitted
+	GetServerIcons(ctx context.Context, serverNames []string) (map[string]*ServerIcon, error)
+	// DeleteServerIcon removes a server's icon, returning ErrNotFound if it has none
+	DeleteServerIcon(ctx context.Context, serverName string) error
+	// CreateAdvisory stores a security advisory and links it to the given server versions
+	CreateAdvisory(ctx context.Context, advisory *Advisory, serverIDs []string) error
+	// GetAdvisory retrieves a security advisory by ID
+	GetAdvisory(ctx context.Context, id string) (*Advisory, error)
+	// ListAdvisories retrieves the security advisories filed against a server name, oldest first
+	ListAdvisories(ctx context.Context, serverName string) ([]*Advisory, error)
+	// ListServerAdvisories retrieves the security advisories affecting the given server versions, keyed by server ID
+	ListServerAdvisories(ctx context.Context, serverIDs []string) (map[string][]*Advisory, error)
+	// LinkAdvisories marks a server version as affected by the given security advisories
+	LinkAdvisories(ctx context.Context, serverID string, advisoryIDs []string) error
+	// DeleteAdvisory removes a security advisory and its links to server versions
+	DeleteAdvisory(ctx context.Context, id string) error
+	// ClaimWebhookCursor leases an endpoint's delivery cursor to owner until leasedUntil, creating it at the
+	// end of the change feed if it doesn't exist. It returns ErrLeaseHeld if another owner's lease is current.
+	ClaimWebhookCursor(ctx context.Context, endpoint, owner string, leasedUntil time.Time) (*WebhookCursor, error)
+	// AdvanceWebhookCursor records delivery up to sequence, returning ErrLeaseHeld if owner no longer holds the lease
+	AdvanceWebhookCursor(ctx context.Context, endpoint, owner string, sequence int64) error
+	// CreateIdempotencyKey stores an in-progress idempotency key, replacing an expired one. It returns
+	// ErrAlreadyExists if the owner has an unexpired key with the same value.
+	CreateIdempotencyKey(ctx context.Context, key *IdempotencyKey) error
+	// GetIdempotencyKey retrieves an owner's unexpired idempotency key
+	GetIdempotencyKey(ctx context.Context, owner, key string) (*IdempotencyKey, error)
+	// CompleteIdempotencyKey stores the response to the publish made with an idempotency key
+	CompleteIdempotencyKey(ctx context.Context, owner, key string, response *apiv0.ServerJSON) error
+	// DeleteIdempotencyKey removes an idempotency key, so a failed publish can be retried with it
+	DeleteIdempotencyKey(ctx context.Context, owner, key string) error
+	// UseUploadToken records that the upload token with the given ID was used, remembering it until expiresAt.
+	// It returns ErrAlreadyExists if the token was already used.
+	UseUploadToken(ctx context.Context, id string, expiresAt time.Time) error
+	// UploadTokenUsed reports whether the unexpired upload token with the given ID was used
+	UploadTokenUsed(ctx context.Context, id string) (bool, error)
 	// Close closes the database connection
 	Close() error
 }
 
+// serverRevision returns a stored record's revision; records from before revisions were tracked are at 1
+func serverRevision(server *apiv0.ServerJSON) int64 {
+	if server.Meta == nil || server.Meta.Official == nil || server.Meta.Official.Revision == 0 {
+		return 1
+	}
+	return server.Meta.Official.Revision
+}
+
+// withRevision returns a copy of server at the given revision
+func withRevision(server *apiv0.ServerJSON, revision int64) *apiv0.ServerJSON {
+	if server.Meta == nil || server.Meta.Official == nil {
+		return server
+	}
+	updated := *server
+	meta := *server.Meta
+	official := *meta.Official
+	official.Revision = revision
+	meta.Official = &official
+	updated.Meta = &meta
+	return &updated
+}
+
+// demoteLatest returns a copy of server, at the next revision, that is no longer the latest version
+func demoteLatest(server *apiv0.ServerJSON, now time.Time) *apiv0.ServerJSON {
+	demoted := withRevision(server, serverRevision(server)+1)
+	if demoted.Meta != nil && demoted.Meta.Official != nil {
+		demoted.Meta.Official.IsLatest = false
+		demoted.Meta.Official.UpdatedAt = now
+	}
+	return demoted
+}
+
+// updateChangeType classifies an update for the change feed: marking a server deprecated
+// or deleted is reported as a deprecate or delete
+func updateChangeType(previous, updated *apiv0.ServerJSON) apiv0.ChangeType {
+	if previous != nil && previous.Status == updated.Status {
+		return apiv0.ChangeTypeUpdate
+	}
+	switch updated.Status {
+	case model.StatusDeleted:
+		return apiv0.ChangeTypeDelete
+	case model.StatusDeprecated:
+		return apiv0.ChangeTypeDeprecate
+	default:
+		return apiv0.ChangeTypeUpdate
+	}
+}
+
 // ConnectionType represents the type of database connection
 type ConnectionType string
 
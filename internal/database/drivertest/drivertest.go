@@ -0,0 +1,578 @@
+// Package drivertest is a conformance suite for implementations of database.Database. It pins down the
+// behaviour the registry relies on beyond the method signatures: result ordering, cursor pagination that
+// neither skips nor repeats records, atomic publishes and the change feed, optimistic concurrency, and
+// keeping fields from newer server.json schemas.
+//
+// A backend passes the suite by calling Run from one of its tests:
+//
+//	func TestConformance(t *testing.T) {
+//		drivertest.Run(t, func(t *testing.T) database.Database {
+//			return newMyDatabase(t)
+//		})
+//	}
+//
+// Every record the suite creates lives in a namespace unique to the subtest, and every check is limited to
+// those records, so the suite can run against a database shared with other tests.
+package drivertest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Factory returns the database under test. It is called once per subtest.
+type Factory func(t *testing.T) database.Database
+
+// Run runs the conformance suite against the databases newDB returns
+func Run(t *testing.T, newDB Factory) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		test func(t *testing.T, db database.Database)
+	}{
+		{"GetByID", testGetByID},
+		{"ListOrdering", testListOrdering},
+		{"ListFilters", testListFilters},
+		{"PaginationStability", testPaginationStability},
+		{"PublishServerVersion", testPublishServerVersion},
+		{"PublishServerVersionNotLatest", testPublishServerVersionNotLatest},
+		{"UpdateServerRevisions", testUpdateServerRevisions},
+		{"ConcurrentUpdates", testConcurrentUpdates},
+		{"ConcurrentPublishes", testConcurrentPublishes},
+		{"ConcurrentPublishesOfOneServer", testConcurrentPublishesOfOneServer},
+		{"ConcurrentNameConflicts", testConcurrentNameConflicts},
+		{"GetLatestVersion", testGetLatestVersion},
+		{"UnknownFields", testUnknownFields},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newDB(t))
+		})
+	}
+}
+
+// namespace returns a server name namespace no other subtest or run uses
+func namespace() string {
+	return "com.drivertest-" + uuid.NewString()[:8]
+}
+
+// newServer returns a new server version ready to store, marked latest
+func newServer(name, version string) *apiv0.ServerJSON {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	return &apiv0.ServerJSON{
+		Name:        name,
+		Description: "A conformance test server",
+		Version:     version,
+		Meta: &apiv0.ServerMeta{
+			Official: &apiv0.RegistryExtensions{
+				ID:          uuid.NewString(),
+				PublishedAt: now,
+				UpdatedAt:   now,
+				IsLatest:    true,
+			},
+		},
+	}
+}
+
+// create stores a new server version
+func create(t *testing.T, db database.Database, name, version string) *apiv0.ServerJSON {
+	t.Helper()
+	server, err := db.CreateServer(t.Context(), newServer(name, version))
+	require.NoError(t, err)
+	return server
+}
+
+// listAll pages through every server matching filter, limit at a time
+func listAll(t *testing.T, db database.Database, filter *database.ServerFilter, limit int) []*apiv0.ServerJSON {
+	t.Helper()
+	var all []*apiv0.ServerJSON
+	cursor := ""
+	for range 1000 {
+		page, next, err := db.List(t.Context(), filter, cursor, limit)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(page), limit, "a page must not exceed the limit")
+		all = append(all, page...)
+		if next == "" {
+			return all
+		}
+		cursor = next
+	}
+	require.FailNow(t, "pagination did not terminate")
+	return nil
+}
+
+// changesFor returns the change feed events about the given server IDs, in feed order
+func changesFor(t *testing.T, db database.Database, ids ...string) []*apiv0.ChangeEvent {
+	t.Helper()
+	var result []*apiv0.ChangeEvent
+	since := int64(0)
+	for {
+		changes, err := db.ListChanges(t.Context(), since, 1000)
+		require.NoError(t, err)
+		for _, change := range changes {
+			require.Greater(t, change.Sequence, since, "change feed must be in increasing sequence order")
+			since = change.Sequence
+			if slices.Contains(ids, change.ServerID) {
+				result = append(result, change)
+			}
+		}
+		if len(changes) < 1000 {
+			return result
+		}
+	}
+}
+
+func ids(servers []*apiv0.ServerJSON) []string {
+	result := make([]string, len(servers))
+	for i, server := range servers {
+		result[i] = server.GetID()
+	}
+	return result
+}
+
+func testGetByID(t *testing.T, db database.Database) {
+	created := create(t, db, namespace()+"/server", "1.0.0")
+
+	got, err := db.GetByID(t.Context(), created.GetID())
+	require.NoError(t, err)
+	assert.Equal(t, created.Name, got.Name)
+	assert.Equal(t, created.Version, got.Version)
+	assert.Equal(t, int64(1), got.Meta.Official.Revision, "new records start at revision 1")
+
+	_, err = db.GetByID(t.Context(), uuid.NewString())
+	assert.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func testListOrdering(t *testing.T, db database.Database) {
+	ns := namespace()
+	var created []*apiv0.ServerJSON
+	for _, name := range []string{"charlie", "alpha", "echo", "bravo", "delta"} {
+		created = append(created, create(t, db, ns+"/"+name, "1.0.0"))
+	}
+	filter := &database.ServerFilter{SubstringName: &ns}
+
+	// Without a sort, servers are ordered by ID
+	servers, _, err := db.List(t.Context(), filter, "", 10)
+	require.NoError(t, err)
+	expected := ids(created)
+	slices.Sort(expected)
+	assert.Equal(t, expected, ids(servers))
+
+	// Sorting by name orders by name, then ID
+	byName := &database.ServerFilter{SubstringName: &ns, Sort: database.ServerSortName}
+	servers, _, err = db.List(t.Context(), byName, "", 10)
+	require.NoError(t, err)
+	names := make([]string, len(servers))
+	for i, server := range servers {
+		names[i] = server.Name
+	}
+	assert.Equal(t, []string{ns + "/alpha", ns + "/bravo", ns + "/charlie", ns + "/delta", ns + "/echo"}, names)
+}
+
+func testListFilters(t *testing.T, db database.Database) {
+	ns := namespace()
+	first := create(t, db, ns+"/server", "1.0.0")
+	other := create(t, db, ns+"/other", "2.0.0")
+
+	name := ns + "/server"
+	servers, _, err := db.List(t.Context(), &database.ServerFilter{Name: &name}, "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{first.GetID()}, ids(servers))
+
+	version := "2.0.0"
+	servers, _, err = db.List(t.Context(), &database.ServerFilter{SubstringName: &ns, Version: &version}, "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{other.GetID()}, ids(servers))
+
+	missing := ns + "/missing"
+	servers, next, err := db.List(t.Context(), &database.ServerFilter{Name: &missing}, "", 10)
+	require.NoError(t, err)
+	assert.Empty(t, servers)
+	assert.Empty(t, next)
+}
+
+func testPaginationStability(t *testing.T, db database.Database) {
+	ns := namespace()
+	var created []*apiv0.ServerJSON
+	for i := range 7 {
+		created = append(created, create(t, db, fmt.Sprintf("%s/server-%d", ns, i), "1.0.0"))
+	}
+
+	for _, sort := range []database.ServerSort{"", database.ServerSortName, database.ServerSortRecent} {
+		label := string(sort)
+		if label == "" {
+			label = "default"
+		}
+		t.Run(label, func(t *testing.T) {
+			filter := &database.ServerFilter{SubstringName: &ns, Sort: sort}
+			whole, next, err := db.List(t.Context(), filter, "", 100)
+			require.NoError(t, err)
+			assert.Empty(t, next, "the last page has no next cursor")
+			require.Len(t, whole, len(created))
+
+			// Paging visits the same records in the same order as a single page
+			assert.Equal(t, ids(whole), ids(listAll(t, db, filter, 3)))
+		})
+	}
+
+	// Records added while paging don't make the pages repeat or skip existing ones
+	filter := &database.ServerFilter{SubstringName: &ns}
+	first, cursor, err := db.List(t.Context(), filter, "", 3)
+	require.NoError(t, err)
+	require.NotEmpty(t, cursor)
+	create(t, db, ns+"/added", "1.0.0")
+
+	seen := ids(first)
+	for cursor != "" {
+		var page []*apiv0.ServerJSON
+		page, cursor, err = db.List(t.Context(), filter, cursor, 3)
+		require.NoError(t, err)
+		seen = append(seen, ids(page)...)
+	}
+	for _, server := range created {
+		assert.Equal(t, 1, countOf(seen, server.GetID()), "server %s must be listed exactly once", server.Name)
+	}
+}
+
+func countOf(values []string, value string) int {
+	count := 0
+	for _, v := range values {
+		if v == value {
+			count++
+		}
+	}
+	return count
+}
+
+func testPublishServerVersion(t *testing.T, db database.Database) {
+	name := namespace() + "/server"
+	first, err := db.PublishServerVersion(t.Context(), newServer(name, "1.0.0"))
+	require.NoError(t, err)
+	second, err := db.PublishServerVersion(t.Context(), newServer(name, "1.1.0"))
+	require.NoError(t, err)
+
+	// The previous latest version is demoted in the same write
+	previous, err := db.GetByID(t.Context(), first.GetID())
+	require.NoError(t, err)
+	assert.False(t, previous.Meta.Official.IsLatest)
+	assert.Equal(t, int64(2), previous.Meta.Official.Revision)
+
+	isLatest := true
+	latest, _, err := db.List(t.Context(), &database.ServerFilter{Name: &name, IsLatest: &isLatest}, "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{second.GetID()}, ids(latest))
+
+	// Both changes are in the feed, the new version first
+	changes := changesFor(t, db, first.GetID(), second.GetID())
+	require.Len(t, changes, 3)
+	assert.Equal(t, apiv0.ChangeTypePublish, changes[0].Type)
+	assert.Equal(t, first.GetID(), changes[0].ServerID)
+	assert.Equal(t, apiv0.ChangeTypePublish, changes[1].Type)
+	assert.Equal(t, second.GetID(), changes[1].ServerID)
+	assert.Equal(t, apiv0.ChangeTypeUpdate, changes[2].Type)
+	assert.Equal(t, first.GetID(), changes[2].ServerID)
+	assert.False(t, changes[2].Server.Meta.Official.IsLatest)
+}
+
+func testPublishServerVersionNotLatest(t *testing.T, db database.Database) {
+	name := namespace() + "/server"
+	latest, err := db.PublishServerVersion(t.Context(), newServer(name, "2.0.0"))
+	require.NoError(t, err)
+
+	// A version that isn't the latest is stored without demoting the current latest version
+	older := newServer(name, "1.0.0")
+	older.Meta.Official.IsLatest = false
+	_, err = db.PublishServerVersion(t.Context(), older)
+	require.NoError(t, err)
+
+	current, err := db.GetLatestVersion(t.Context(), name)
+	require.NoError(t, err)
+	assert.Equal(t, latest.GetID(), current.GetID())
+	assert.True(t, current.Meta.Official.IsLatest)
+	assert.Equal(t, int64(1), current.Meta.Official.Revision)
+	assert.Len(t, changesFor(t, db, latest.GetID(), older.GetID()), 2)
+}
+
+func testUpdateServerRevisions(t *testing.T, db database.Database) {
+	created := create(t, db, namespace()+"/server", "1.0.0")
+
+	edited := *created
+	edited.Description = "Edited"
+	updated, err := db.UpdateServer(t.Context(), created.GetID(), &edited, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), updated.Meta.Official.Revision)
+
+	// An update naming a stale revision is rejected and leaves the record alone
+	stale := *created
+	stale.Description = "Stale"
+	_, err = db.UpdateServer(t.Context(), created.GetID(), &stale, 1)
+	require.ErrorIs(t, err, database.ErrRevisionConflict)
+
+	stored, err := db.GetByID(t.Context(), created.GetID())
+	require.NoError(t, err)
+	assert.Equal(t, "Edited", stored.Description)
+
+	// Without an expected revision, updates always apply
+	stale.Meta = stored.Meta
+	updated, err = db.UpdateServer(t.Context(), created.GetID(), &stale, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), updated.Meta.Official.Revision)
+
+	missing := newServer(namespace()+"/missing", "1.0.0")
+	_, err = db.UpdateServer(t.Context(), missing.GetID(), missing, 0)
+	assert.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func testConcurrentUpdates(t *testing.T, db database.Database) {
+	const editors = 8
+	created := create(t, db, namespace()+"/server", "1.0.0")
+
+	// Editors racing on the same revision: exactly one wins, the rest conflict
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+		conflicts int
+	)
+	for i := range editors {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			edited := *created
+			edited.Description = fmt.Sprintf("Edited by %d", i)
+			_, err := db.UpdateServer(context.WithoutCancel(t.Context()), created.GetID(), &edited, 1)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				succeeded++
+			case errors.Is(err, database.ErrRevisionConflict):
+				conflicts++
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, succeeded)
+	assert.Equal(t, editors-1, conflicts)
+
+	stored, err := db.GetByID(t.Context(), created.GetID())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), stored.Meta.Official.Revision)
+}
+
+func testConcurrentPublishes(t *testing.T, db database.Database) {
+	const (
+		servers  = 6
+		versions = 5
+	)
+	ns := namespace()
+
+	// Each server's versions are published in order, while different servers publish concurrently
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		published []string
+	)
+	for s := range servers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name := fmt.Sprintf("%s/server-%d", ns, s)
+			for v := range versions {
+				server, err := db.PublishServerVersion(context.WithoutCancel(t.Context()), newServer(name, fmt.Sprintf("1.0.%d", v)))
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				mu.Lock()
+				published = append(published, server.GetID())
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	require.Len(t, published, servers*versions)
+
+	// Every version landed, and each server has exactly one latest version
+	all := listAll(t, db, &database.ServerFilter{SubstringName: &ns}, 7)
+	assert.ElementsMatch(t, published, ids(all))
+	latest := make(map[string]int)
+	for _, server := range all {
+		if server.Meta.Official.IsLatest {
+			latest[server.Name]++
+		}
+	}
+	assert.Len(t, latest, servers)
+	for name, count := range latest {
+		assert.Equal(t, 1, count, "%s must have one latest version", name)
+	}
+
+	// Every publish and demotion is in the change feed exactly once
+	changes := changesFor(t, db, published...)
+	assert.Len(t, changes, servers*(2*versions-1))
+	sequences := make(map[int64]bool, len(changes))
+	for _, change := range changes {
+		assert.False(t, sequences[change.Sequence], "sequence %d is used twice", change.Sequence)
+		sequences[change.Sequence] = true
+	}
+}
+
+func testConcurrentPublishesOfOneServer(t *testing.T, db database.Database) {
+	const publishers = 8
+	name := namespace() + "/server"
+
+	// Every publish is marked latest, so each must demote whichever version was latest when it was stored
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		published []string
+	)
+	for p := range publishers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			server, err := db.PublishServerVersion(context.WithoutCancel(t.Context()), newServer(name, fmt.Sprintf("1.0.%d", p)))
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			mu.Lock()
+			published = append(published, server.GetID())
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	require.Len(t, published, publishers)
+
+	// Exactly one version is marked latest, and it is the one the latest version lookup returns
+	var latestIDs []string
+	for _, server := range listAll(t, db, &database.ServerFilter{Name: &name}, 3) {
+		if server.Meta.Official.IsLatest {
+			latestIDs = append(latestIDs, server.GetID())
+		}
+	}
+	require.Len(t, latestIDs, 1)
+	latest, err := db.GetLatestVersion(t.Context(), name)
+	require.NoError(t, err)
+	assert.Equal(t, latestIDs[0], latest.GetID())
+
+	// Every version but the latest was demoted exactly once
+	assert.Len(t, changesFor(t, db, published...), 2*publishers-1)
+}
+
+func testConcurrentNameConflicts(t *testing.T, db database.Database) {
+	ns := namespace()
+	spellings := []string{ns + "/server", ns + "/Server", ns + "/SERVER", ns + "/sErVeR"}
+
+	// Names differing only in case race to be published first, and only one of them may win
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		published []string
+	)
+	for _, name := range spellings {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := db.PublishServerVersion(context.WithoutCancel(t.Context()), newServer(name, "1.0.0"))
+			if err != nil {
+				assert.ErrorIs(t, err, database.ErrNameConflict)
+				return
+			}
+
+			mu.Lock()
+			published = append(published, name)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	require.Len(t, published, 1)
+
+	// Later versions of the winning name are still accepted
+	_, err := db.PublishServerVersion(t.Context(), newServer(published[0], "1.1.0"))
+	require.NoError(t, err)
+	all := listAll(t, db, &database.ServerFilter{SubstringName: &ns}, 10)
+	assert.Len(t, all, 2)
+}
+
+func testGetLatestVersion(t *testing.T, db database.Database) {
+	name := namespace() + "/server"
+	_, err := db.GetLatestVersion(t.Context(), name)
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	first, err := db.PublishServerVersion(t.Context(), newServer(name, "1.0.0"))
+	require.NoError(t, err)
+	latest, err := db.GetLatestVersion(t.Context(), name)
+	require.NoError(t, err)
+	assert.Equal(t, first.GetID(), latest.GetID())
+
+	second, err := db.PublishServerVersion(t.Context(), newServer(name, "2.0.0"))
+	require.NoError(t, err)
+	latest, err = db.GetLatestVersion(t.Context(), name)
+	require.NoError(t, err)
+	assert.Equal(t, second.GetID(), latest.GetID())
+
+	// Edits to the latest version are visible through it
+	edited := *latest
+	edited.Description = "Edited"
+	_, err = db.UpdateServer(t.Context(), second.GetID(), &edited, 0)
+	require.NoError(t, err)
+	latest, err = db.GetLatestVersion(t.Context(), name)
+	require.NoError(t, err)
+	assert.Equal(t, "Edited", latest.Description)
+}
+
+func testUnknownFields(t *testing.T, db database.Database) {
+	server := newServer(namespace()+"/server", "1.0.0")
+	server.UnknownFields = map[string]json.RawMessage{"icons": json.RawMessage(`[{"src": "https://example.com/icon.png"}]`)}
+	server.Meta.UnknownFields = map[string]json.RawMessage{"com.example/build": json.RawMessage(`{"commit": "abc123"}`)}
+	created, err := db.CreateServer(t.Context(), server)
+	require.NoError(t, err)
+
+	assertKept := func(t *testing.T, got *apiv0.ServerJSON) {
+		t.Helper()
+		require.Contains(t, got.UnknownFields, "icons")
+		assert.JSONEq(t, `[{"src": "https://example.com/icon.png"}]`, string(got.UnknownFields["icons"]))
+		require.NotNil(t, got.Meta)
+		require.Contains(t, got.Meta.UnknownFields, "com.example/build")
+		assert.JSONEq(t, `{"commit": "abc123"}`, string(got.Meta.UnknownFields["com.example/build"]))
+	}
+
+	got, err := db.GetByID(t.Context(), created.GetID())
+	require.NoError(t, err)
+	assertKept(t, got)
+
+	listed := listAll(t, db, &database.ServerFilter{Name: &server.Name}, 10)
+	require.Len(t, listed, 1)
+	assertKept(t, listed[0])
+
+	// Edits that don't touch them keep them too
+	got.Description = "Edited"
+	updated, err := db.UpdateServer(t.Context(), got.GetID(), got, 0)
+	require.NoError(t, err)
+	assertKept(t, updated)
+
+	changes := changesFor(t, db, created.GetID())
+	require.NotEmpty(t, changes)
+	for _, change := range changes {
+		assertKept(t, &change.Server)
+	}
+}
@@ -3,84 +3,704 @@ package database
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
-// MemoryDB is an in-memory implementation of the Database interface
+// MemoryDB is an in-memory implementation of the Database interface. Server records and the change
+// feed live in a sharded store with their own locks, so catalog reads don't wait on publishes; mu
+// guards everything else.
 type MemoryDB struct {
-	entries map[string]*apiv0.ServerJSON // maps registry metadata ID to ServerJSON
-	mu      sync.RWMutex
+	servers *serverStore
+	apiKeys map[string]*APIKey // maps API key ID to key
+
+	reservations  map[string]*NamespaceReservation // maps namespace to reservation
+	transfers     map[string]*ServerTransfer       // maps transfer ID to transfer
+	blockedNames  []*BlockedName                   // in the order they were added
+	remoteHealth  map[string]*RemoteHealth         // maps remote URL to its latest probe result
+	eventCounts   map[serverEventKey]int64         // daily usage event counters
+	icons         map[string]*ServerIcon           // maps server name to its icon
+	advisories    []*Advisory                      // in the order they were filed
+	advisoryLinks map[string][]string              // maps server ID to the IDs of advisories affecting it
+	webhooks      map[string]*WebhookCursor        // maps webhook endpoint to its delivery cursor
+	idempotency   map[idempotencyKeyID]*IdempotencyKey
+	uploadTokens  map[string]time.Time // maps the ID of each used upload token to its expiry
+
+	mu sync.RWMutex
+}
+
+func NewMemoryDB() *MemoryDB {
+	return &MemoryDB{
+		servers: newServerStore(),
+		apiKeys: make(map[string]*APIKey),
+
+		reservations:  make(map[string]*NamespaceReservation),
+		transfers:     make(map[string]*ServerTransfer),
+		remoteHealth:  make(map[string]*RemoteHealth),
+		eventCounts:   make(map[serverEventKey]int64),
+		icons:         make(map[string]*ServerIcon),
+		advisoryLinks: make(map[string][]string),
+		webhooks:      make(map[string]*WebhookCursor),
+		idempotency:   make(map[idempotencyKeyID]*IdempotencyKey),
+		uploadTokens:  make(map[string]time.Time),
+	}
+}
+
+func (db *MemoryDB) List(
+	ctx context.Context,
+	filter *ServerFilter,
+	cursor string,
+	limit int,
+) ([]*apiv0.ServerJSON, string, error) {
+	if ctx.Err() != nil {
+		return nil, "", ctx.Err()
+	}
+
+	if limit <= 0 {
+		limit = 10 // Default limit
+	}
+
+	// Filtering reads advisory links and usage counts, but not the server store's write lock
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	// Apply filtering and sorting to a snapshot of every entry
+	filteredEntries := db.filterAndSort(db.servers.all(), filter)
+
+	// Find starting point for cursor-based pagination
+	startIdx := 0
+	if cursor != "" {
+		for i, entry := range filteredEntries {
+			if db.getRegistryID(entry) == cursor {
+				startIdx = i + 1 // Start after the cursor
+				break
+			}
+		}
+	}
+
+	// Apply pagination
+	endIdx := min(startIdx+limit, len(filteredEntries))
+
+	var result []*apiv0.ServerJSON
+	if startIdx < len(filteredEntries) {
+		result = filteredEntries[startIdx:endIdx]
+	} else {
+		result = []*apiv0.ServerJSON{}
+	}
+
+	// Determine next cursor
+	nextCursor := ""
+	if endIdx < len(filteredEntries) && len(result) > 0 {
+		nextCursor = db.getRegistryID(result[len(result)-1])
+	}
+
+	return result, nextCursor, nil
+}
+
+func (db *MemoryDB) GetByID(ctx context.Context, id string) (*apiv0.ServerJSON, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	// Find entry by registry metadata ID
+	if entry, exists := db.servers.get(id); exists {
+		// Return a copy of the ServerRecord
+		entryCopy := *entry
+		return &entryCopy, nil
+	}
+
+	return nil, ErrNotFound
+}
+
+func (db *MemoryDB) GetLatestVersion(ctx context.Context, name string) (*apiv0.ServerJSON, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if entry, exists := db.servers.getLatest(name); exists {
+		entryCopy := *entry
+		return &entryCopy, nil
+	}
+	return nil, ErrNotFound
+}
+
+func (db *MemoryDB) CreateServer(ctx context.Context, server *apiv0.ServerJSON) (*apiv0.ServerJSON, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	// Get the ID from the registry metadata
+	if server.Meta == nil || server.Meta.Official == nil {
+		return nil, fmt.Errorf("server must have registry metadata with ID")
+	}
+
+	id := server.Meta.Official.ID
+	server = withRevision(server, serverRevision(server))
+
+	db.servers.mu.Lock()
+	defer db.servers.mu.Unlock()
+
+	// Store the record using registry metadata ID
+	db.servers.putLocked(id, server)
+	db.recordChangeLocked(apiv0.ChangeTypePublish, id, server)
+
+	return server, nil
+}
+
+func (db *MemoryDB) PublishServerVersion(ctx context.Context, server *apiv0.ServerJSON) (*apiv0.ServerJSON, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if server.Meta == nil || server.Meta.Official == nil {
+		return nil, fmt.Errorf("server must have registry metadata with ID")
+	}
+
+	id := server.Meta.Official.ID
+	server = withRevision(server, serverRevision(server))
+
+	db.servers.mu.Lock()
+	defer db.servers.mu.Unlock()
+
+	if existing, conflict := db.servers.nameConflictLocked(server.Name); conflict {
+		return nil, fmt.Errorf("%w: %q and %q", ErrNameConflict, server.Name, existing)
+	}
+
+	// Look up the previous latest version under the write lock, so concurrent publishes each demote the one before
+	var previous *apiv0.ServerJSON
+	if server.Meta.Official.IsLatest {
+		previous, _ = db.servers.getLatest(server.Name)
+	}
+
+	db.servers.putLocked(id, server)
+	db.recordChangeLocked(apiv0.ChangeTypePublish, id, server)
+
+	if previous != nil {
+		previousLatestID := previous.Meta.Official.ID
+		demoted := demoteLatest(previous, time.Now())
+		db.servers.putLocked(previousLatestID, demoted)
+		db.recordChangeLocked(updateChangeType(previous, demoted), previousLatestID, demoted)
+	}
+
+	return server, nil
+}
+
+func (db *MemoryDB) UpdateServer(ctx context.Context, id string, server *apiv0.ServerJSON, expectedRevision int64) (*apiv0.ServerJSON, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.servers.mu.Lock()
+	defer db.servers.mu.Unlock()
+
+	previous, exists := db.servers.get(id)
+	if !exists {
+		return nil, ErrNotFound
+	}
+	if expectedRevision != 0 && serverRevision(previous) != expectedRevision {
+		return nil, ErrRevisionConflict
+	}
+
+	// Update the server
+	server = withRevision(server, serverRevision(previous)+1)
+	db.servers.putLocked(id, server)
+	db.recordChangeLocked(updateChangeType(previous, server), id, server)
+
+	// Return the updated record
+	return server, nil
+}
+
+func (db *MemoryDB) ListChanges(ctx context.Context, since int64, limit int) ([]*apiv0.ChangeEvent, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if limit <= 0 {
+		limit = 10 // Default limit
+	}
+
+	db.servers.mu.RLock()
+	defer db.servers.mu.RUnlock()
+
+	// Sequences start at 1 and have no gaps, so the first event after since is at index since
+	changes := db.servers.changes
+	start := int(max(since, 0))
+	if start >= len(changes) {
+		return []*apiv0.ChangeEvent{}, nil
+	}
+	end := min(start+limit, len(changes))
+
+	result := make([]*apiv0.ChangeEvent, 0, end-start)
+	for _, change := range changes[start:end] {
+		changeCopy := *change
+		result = append(result, &changeCopy)
+	}
+	return result, nil
+}
+
+// recordChangeLocked appends a change feed event. Callers must hold db.servers.mu.
+func (db *MemoryDB) recordChangeLocked(changeType apiv0.ChangeType, id string, server *apiv0.ServerJSON) {
+	// Stored records are shared with callers, so snapshot the registry metadata they may later modify
+	snapshot := *server
+	if server.Meta != nil {
+		meta := *server.Meta
+		if meta.Official != nil {
+			official := *meta.Official
+			meta.Official = &official
+		}
+		snapshot.Meta = &meta
+	}
+
+	db.servers.changes = append(db.servers.changes, &apiv0.ChangeEvent{
+		Sequence:  int64(len(db.servers.changes) + 1),
+		Type:      changeType,
+		ServerID:  id,
+		Server:    snapshot,
+		CreatedAt: time.Now(),
+	})
+}
+
+func (db *MemoryDB) CreateAPIKey(ctx context.Context, key *APIKey) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, existing := range db.apiKeys {
+		if existing.ID == key.ID || existing.KeyHash == key.KeyHash {
+			return ErrAlreadyExists
+		}
+	}
+
+	keyCopy := *key
+	db.apiKeys[key.ID] = &keyCopy
+	return nil
+}
+
+func (db *MemoryDB) GetAPIKeyByHash(ctx context.Context, keyHash string) (*APIKey, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	for _, key := range db.apiKeys {
+		if key.KeyHash == keyHash {
+			keyCopy := *key
+			return &keyCopy, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (db *MemoryDB) ListAPIKeys(ctx context.Context, owner string) ([]*APIKey, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	result := []*APIKey{}
+	for _, key := range db.apiKeys {
+		if key.Owner == owner {
+			keyCopy := *key
+			result = append(result, &keyCopy)
+		}
+	}
+
+	// Newest keys first
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+func (db *MemoryDB) RevokeAPIKey(ctx context.Context, id, owner string, revokedAt time.Time) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	key, ok := db.apiKeys[id]
+	if !ok || key.Owner != owner {
+		return ErrNotFound
+	}
+	if key.RevokedAt == nil {
+		key.RevokedAt = &revokedAt
+	}
+	return nil
+}
+
+func (db *MemoryDB) ReserveNamespace(ctx context.Context, reservation *NamespaceReservation) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if _, ok := db.reservations[reservation.Namespace]; ok {
+		return ErrAlreadyExists
+	}
+
+	reservationCopy := *reservation
+	db.reservations[reservation.Namespace] = &reservationCopy
+	return nil
+}
+
+func (db *MemoryDB) GetNamespaceReservation(ctx context.Context, namespace string) (*NamespaceReservation, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	reservation, ok := db.reservations[namespace]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	reservationCopy := *reservation
+	return &reservationCopy, nil
+}
+
+func (db *MemoryDB) ReleaseNamespace(ctx context.Context, namespace, owner string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	reservation, ok := db.reservations[namespace]
+	if !ok || reservation.Owner != owner {
+		return ErrNotFound
+	}
+	delete(db.reservations, namespace)
+	return nil
+}
+
+// idempotencyKeyID identifies an idempotency key, which is scoped to its owner
+type idempotencyKeyID struct {
+	owner string
+	key   string
+}
+
+func (db *MemoryDB) CreateIdempotencyKey(ctx context.Context, key *IdempotencyKey) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	id := idempotencyKeyID{owner: key.Owner, key: key.Key}
+	if existing, ok := db.idempotency[id]; ok && time.Now().Before(existing.ExpiresAt) {
+		return ErrAlreadyExists
+	}
+
+	keyCopy := *key
+	db.idempotency[id] = &keyCopy
+	return nil
+}
+
+func (db *MemoryDB) GetIdempotencyKey(ctx context.Context, owner, key string) (*IdempotencyKey, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	existing, ok := db.idempotency[idempotencyKeyID{owner: owner, key: key}]
+	if !ok || !time.Now().Before(existing.ExpiresAt) {
+		return nil, ErrNotFound
+	}
+	keyCopy := *existing
+	return &keyCopy, nil
+}
+
+func (db *MemoryDB) CompleteIdempotencyKey(ctx context.Context, owner, key string, response *apiv0.ServerJSON) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	existing, ok := db.idempotency[idempotencyKeyID{owner: owner, key: key}]
+	if !ok {
+		return ErrNotFound
+	}
+	responseCopy := *response
+	existing.Response = &responseCopy
+	return nil
+}
+
+func (db *MemoryDB) DeleteIdempotencyKey(ctx context.Context, owner, key string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	delete(db.idempotency, idempotencyKeyID{owner: owner, key: key})
+	return nil
+}
+
+func (db *MemoryDB) UseUploadToken(ctx context.Context, id string, expiresAt time.Time) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	now := time.Now()
+	for usedID, usedExpiresAt := range db.uploadTokens {
+		if !now.Before(usedExpiresAt) {
+			delete(db.uploadTokens, usedID)
+		}
+	}
+	if _, ok := db.uploadTokens[id]; ok {
+		return ErrAlreadyExists
+	}
+	db.uploadTokens[id] = expiresAt
+	return nil
+}
+
+func (db *MemoryDB) UploadTokenUsed(ctx context.Context, id string) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	expiresAt, ok := db.uploadTokens[id]
+	return ok && time.Now().Before(expiresAt), nil
+}
+
+func (db *MemoryDB) AddBlockedName(ctx context.Context, entry *BlockedName) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, existing := range db.blockedNames {
+		if existing.Pattern == entry.Pattern {
+			return ErrAlreadyExists
+		}
+	}
+
+	entryCopy := *entry
+	db.blockedNames = append(db.blockedNames, &entryCopy)
+	return nil
+}
+
+func (db *MemoryDB) ListBlockedNames(ctx context.Context) ([]*BlockedName, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	result := make([]*BlockedName, len(db.blockedNames))
+	for i, entry := range db.blockedNames {
+		entryCopy := *entry
+		result[i] = &entryCopy
+	}
+	return result, nil
+}
+
+func (db *MemoryDB) RemoveBlockedName(ctx context.Context, pattern string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for i, entry := range db.blockedNames {
+		if entry.Pattern == pattern {
+			db.blockedNames = append(db.blockedNames[:i], db.blockedNames[i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (db *MemoryDB) ClaimWebhookCursor(ctx context.Context, endpoint, owner string, leasedUntil time.Time) (*WebhookCursor, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	cursor, ok := db.webhooks[endpoint]
+	if !ok {
+		cursor = &WebhookCursor{Endpoint: endpoint, LastSequence: db.servers.lastSequence()}
+		db.webhooks[endpoint] = cursor
+	} else if cursor.LeasedBy != owner && time.Now().Before(cursor.LeasedUntil) {
+		return nil, ErrLeaseHeld
+	}
+
+	cursor.LeasedBy = owner
+	cursor.LeasedUntil = leasedUntil
+	cursorCopy := *cursor
+	return &cursorCopy, nil
 }
 
-func NewMemoryDB() *MemoryDB {
-	// Convert input ServerJSON entries to have proper metadata
-	serverRecords := make(map[string]*apiv0.ServerJSON)
-	return &MemoryDB{
-		entries: serverRecords,
+func (db *MemoryDB) AdvanceWebhookCursor(ctx context.Context, endpoint, owner string, sequence int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
 	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	cursor, ok := db.webhooks[endpoint]
+	if !ok {
+		return ErrNotFound
+	}
+	if cursor.LeasedBy != owner {
+		return ErrLeaseHeld
+	}
+	cursor.LastSequence = max(cursor.LastSequence, sequence)
+	return nil
 }
 
-func (db *MemoryDB) List(
-	ctx context.Context,
-	filter *ServerFilter,
-	cursor string,
-	limit int,
-) ([]*apiv0.ServerJSON, string, error) {
+func (db *MemoryDB) SaveRemoteHealth(ctx context.Context, health *RemoteHealth) error {
 	if ctx.Err() != nil {
-		return nil, "", ctx.Err()
+		return ctx.Err()
 	}
 
-	if limit <= 0 {
-		limit = 10 // Default limit
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	healthCopy := *health
+	if healthCopy.LastSeenHealthy == nil {
+		if previous, ok := db.remoteHealth[health.URL]; ok {
+			healthCopy.LastSeenHealthy = previous.LastSeenHealthy
+		}
+	}
+	db.remoteHealth[health.URL] = &healthCopy
+	return nil
+}
+
+func (db *MemoryDB) GetRemoteHealth(ctx context.Context, urls []string) (map[string]*RemoteHealth, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
 	}
 
 	db.mu.RLock()
 	defer db.mu.RUnlock()
 
-	// Convert all entries to a slice for pagination
-	var allEntries []*apiv0.ServerJSON
-	for _, entry := range db.entries {
-		allEntries = append(allEntries, entry)
+	result := make(map[string]*RemoteHealth)
+	for _, url := range urls {
+		if health, ok := db.remoteHealth[url]; ok {
+			healthCopy := *health
+			result[url] = &healthCopy
+		}
 	}
+	return result, nil
+}
 
-	// Apply filtering and sorting
-	filteredEntries := db.filterAndSort(allEntries, filter)
+func (db *MemoryDB) CountServersByCategory(ctx context.Context) (map[string]int, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
 
-	// Find starting point for cursor-based pagination
-	startIdx := 0
-	if cursor != "" {
-		for i, entry := range filteredEntries {
-			if db.getRegistryID(entry) == cursor {
-				startIdx = i + 1 // Start after the cursor
-				break
-			}
+	counts := make(map[string]int)
+	for _, entry := range db.servers.all() {
+		if entry.Status == model.StatusDeleted || entry.Meta == nil || entry.Meta.Official == nil || !entry.Meta.Official.IsLatest {
+			continue
+		}
+		for _, category := range entry.Categories {
+			counts[category]++
 		}
 	}
+	return counts, nil
+}
 
-	// Apply pagination
-	endIdx := min(startIdx+limit, len(filteredEntries))
+// serverEventKey identifies a daily usage event counter
+type serverEventKey struct {
+	serverName string
+	eventType  ServerEventType
+	day        time.Time
+}
 
-	var result []*apiv0.ServerJSON
-	if startIdx < len(filteredEntries) {
-		result = filteredEntries[startIdx:endIdx]
-	} else {
-		result = []*apiv0.ServerJSON{}
+func (db *MemoryDB) RecordServerEvent(ctx context.Context, serverName string, eventType ServerEventType, at time.Time) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
 	}
 
-	// Determine next cursor
-	nextCursor := ""
-	if endIdx < len(filteredEntries) && len(result) > 0 {
-		nextCursor = db.getRegistryID(result[len(result)-1])
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.eventCounts[serverEventKey{serverName: serverName, eventType: eventType, day: eventDay(at)}]++
+	return nil
+}
+
+func (db *MemoryDB) ListServerEventCounts(ctx context.Context, serverNames []string, since time.Time) ([]*ServerEventCount, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
 	}
 
-	return result, nextCursor, nil
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	names := make(map[string]bool, len(serverNames))
+	for _, name := range serverNames {
+		names[name] = true
+	}
+	since = eventDay(since)
+
+	var result []*ServerEventCount
+	for key, count := range db.eventCounts {
+		if names[key.serverName] && !key.day.Before(since) {
+			result = append(result, &ServerEventCount{
+				ServerName: key.serverName,
+				EventType:  key.eventType,
+				Day:        key.day,
+				Count:      count,
+			})
+		}
+	}
+	return result, nil
 }
 
-func (db *MemoryDB) GetByID(ctx context.Context, id string) (*apiv0.ServerJSON, error) {
+func (db *MemoryDB) SetServerIcon(ctx context.Context, icon *ServerIcon) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	iconCopy := *icon
+	db.icons[icon.ServerName] = &iconCopy
+	return nil
+}
+
+func (db *MemoryDB) GetServerIcons(ctx context.Context, serverNames []string) (map[string]*ServerIcon, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
@@ -88,55 +708,216 @@ func (db *MemoryDB) GetByID(ctx context.Context, id string) (*apiv0.ServerJSON,
 	db.mu.RLock()
 	defer db.mu.RUnlock()
 
-	// Find entry by registry metadata ID
-	if entry, exists := db.entries[id]; exists {
-		// Return a copy of the ServerRecord
-		entryCopy := *entry
-		return &entryCopy, nil
+	result := make(map[string]*ServerIcon)
+	for _, name := range serverNames {
+		if icon, ok := db.icons[name]; ok {
+			iconCopy := *icon
+			result[name] = &iconCopy
+		}
+	}
+	return result, nil
+}
+
+func (db *MemoryDB) DeleteServerIcon(ctx context.Context, serverName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if _, ok := db.icons[serverName]; !ok {
+		return ErrNotFound
+	}
+	delete(db.icons, serverName)
+	return nil
+}
+
+func (db *MemoryDB) CreateAdvisory(ctx context.Context, advisory *Advisory, serverIDs []string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	advisoryCopy := *advisory
+	advisoryCopy.Aliases = slices.Clone(advisory.Aliases)
+	db.advisories = append(db.advisories, &advisoryCopy)
+	for _, serverID := range serverIDs {
+		db.advisoryLinks[serverID] = append(db.advisoryLinks[serverID], advisory.ID)
 	}
+	return nil
+}
+
+func (db *MemoryDB) GetAdvisory(ctx context.Context, id string) (*Advisory, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
 
+	for _, advisory := range db.advisories {
+		if advisory.ID == id {
+			advisoryCopy := *advisory
+			return &advisoryCopy, nil
+		}
+	}
 	return nil, ErrNotFound
 }
 
-func (db *MemoryDB) CreateServer(ctx context.Context, server *apiv0.ServerJSON) (*apiv0.ServerJSON, error) {
+func (db *MemoryDB) ListAdvisories(ctx context.Context, serverName string) ([]*Advisory, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
 
-	// Get the ID from the registry metadata
-	if server.Meta == nil || server.Meta.Official == nil {
-		return nil, fmt.Errorf("server must have registry metadata with ID")
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	var result []*Advisory
+	for _, advisory := range db.advisories {
+		if advisory.ServerName == serverName {
+			advisoryCopy := *advisory
+			result = append(result, &advisoryCopy)
+		}
 	}
+	return result, nil
+}
 
-	id := server.Meta.Official.ID
+func (db *MemoryDB) ListServerAdvisories(ctx context.Context, serverIDs []string) (map[string][]*Advisory, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	result := make(map[string][]*Advisory)
+	for _, advisory := range db.advisories {
+		for _, serverID := range serverIDs {
+			if slices.Contains(db.advisoryLinks[serverID], advisory.ID) {
+				advisoryCopy := *advisory
+				result[serverID] = append(result[serverID], &advisoryCopy)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (db *MemoryDB) LinkAdvisories(ctx context.Context, serverID string, advisoryIDs []string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
 
 	db.mu.Lock()
 	defer db.mu.Unlock()
 
-	// Store the record using registry metadata ID
-	db.entries[id] = server
+	for _, id := range advisoryIDs {
+		if !slices.Contains(db.advisoryLinks[serverID], id) {
+			db.advisoryLinks[serverID] = append(db.advisoryLinks[serverID], id)
+		}
+	}
+	return nil
+}
 
-	return server, nil
+func (db *MemoryDB) DeleteAdvisory(ctx context.Context, id string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for i, advisory := range db.advisories {
+		if advisory.ID != id {
+			continue
+		}
+		db.advisories = append(db.advisories[:i], db.advisories[i+1:]...)
+		for serverID, ids := range db.advisoryLinks {
+			db.advisoryLinks[serverID] = slices.DeleteFunc(ids, func(linked string) bool { return linked == id })
+		}
+		return nil
+	}
+	return ErrNotFound
 }
 
-func (db *MemoryDB) UpdateServer(ctx context.Context, id string, server *apiv0.ServerJSON) (*apiv0.ServerJSON, error) {
+func (db *MemoryDB) CreateTransfer(ctx context.Context, transfer *ServerTransfer) error {
 	if ctx.Err() != nil {
-		return nil, ctx.Err()
+		return ctx.Err()
 	}
 
 	db.mu.Lock()
 	defer db.mu.Unlock()
 
-	_, exists := db.entries[id]
-	if !exists {
+	if _, ok := db.transfers[transfer.ID]; ok {
+		return ErrAlreadyExists
+	}
+	db.transfers[transfer.ID] = copyTransfer(transfer)
+	return nil
+}
+
+func (db *MemoryDB) GetTransfer(ctx context.Context, id string) (*ServerTransfer, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	transfer, ok := db.transfers[id]
+	if !ok {
 		return nil, ErrNotFound
 	}
+	return copyTransfer(transfer), nil
+}
+
+func (db *MemoryDB) ListTransfers(ctx context.Context, status TransferStatus) ([]*ServerTransfer, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
 
-	// Update the server
-	db.entries[id] = server
+	db.mu.RLock()
+	defer db.mu.RUnlock()
 
-	// Return the updated record
-	return server, nil
+	result := []*ServerTransfer{}
+	for _, transfer := range db.transfers {
+		if transfer.Status == status {
+			result = append(result, copyTransfer(transfer))
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+func (db *MemoryDB) UpdateTransfer(ctx context.Context, transfer *ServerTransfer, previousStatus TransferStatus) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	existing, ok := db.transfers[transfer.ID]
+	if !ok || existing.Status != previousStatus {
+		return ErrNotFound
+	}
+	db.transfers[transfer.ID] = copyTransfer(transfer)
+	return nil
+}
+
+// copyTransfer copies a transfer so callers can't modify stored records
+func copyTransfer(transfer *ServerTransfer) *ServerTransfer {
+	transferCopy := *transfer
+	transferCopy.Events = append([]TransferEvent(nil), transfer.Events...)
+	if transfer.CompletesAt != nil {
+		completesAt := *transfer.CompletesAt
+		transferCopy.CompletesAt = &completesAt
+	}
+	return &transferCopy
 }
 
 // For an in-memory database, this is a no-op
@@ -154,17 +935,116 @@ func (db *MemoryDB) filterAndSort(allEntries []*apiv0.ServerJSON, filter *Server
 		}
 	}
 
-	// Sort by registry metadata ID for consistent pagination
+	// Sort by registry metadata ID for consistent pagination, after any requested order
+	var sortBy ServerSort
+	if filter != nil {
+		sortBy = filter.Sort
+	}
+	key := db.sortKey(filteredEntries, filter)
 	sort.Slice(filteredEntries, func(i, j int) bool {
-		iID := db.getRegistryID(filteredEntries[i])
-		jID := db.getRegistryID(filteredEntries[j])
+		a, b := filteredEntries[i], filteredEntries[j]
+		iID := db.getRegistryID(a)
+		jID := db.getRegistryID(b)
+		switch sortBy {
+		case ServerSortDownloads:
+			if key[a].installs != key[b].installs {
+				return key[a].installs > key[b].installs
+			}
+			return iID > jID
+		case ServerSortRecent:
+			if !key[a].publishedAt.Equal(key[b].publishedAt) {
+				return key[a].publishedAt.After(key[b].publishedAt)
+			}
+			return iID > jID
+		case ServerSortRelevance:
+			if key[a].relevance != key[b].relevance {
+				return key[a].relevance < key[b].relevance
+			}
+			fallthrough
+		case ServerSortName:
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+		}
 		return iID < jID
 	})
 
 	return filteredEntries
 }
 
+// hasTransport reports whether any of a server's remotes or packages uses the given transport type
+func hasTransport(entry *apiv0.ServerJSON, transportType string) bool {
+	for _, remote := range entry.Remotes {
+		if remote.Type == transportType {
+			return true
+		}
+	}
+	for _, pkg := range entry.Packages {
+		if pkg.Transport.Type == transportType {
+			return true
+		}
+	}
+	return false
+}
+
+// serverSortKey holds the values entries are ordered by
+type serverSortKey struct {
+	installs    int64
+	publishedAt time.Time
+	relevance   int
+}
+
+// sortKey computes the sort key of each entry for filter.Sort. Callers must hold db.mu.
+func (db *MemoryDB) sortKey(entries []*apiv0.ServerJSON, filter *ServerFilter) map[*apiv0.ServerJSON]serverSortKey {
+	if filter == nil || filter.Sort == "" {
+		return nil
+	}
+
+	var installs map[string]int64
+	if filter.Sort == ServerSortDownloads {
+		installs = make(map[string]int64)
+		since := WeeklyStatsStart(time.Now())
+		for key, count := range db.eventCounts {
+			if key.eventType == ServerEventInstall && !key.day.Before(since) {
+				installs[key.serverName] += count
+			}
+		}
+	}
+
+	keys := make(map[*apiv0.ServerJSON]serverSortKey, len(entries))
+	for _, entry := range entries {
+		key := serverSortKey{installs: installs[entry.Name]}
+		if entry.Meta != nil && entry.Meta.Official != nil {
+			key.publishedAt = entry.Meta.Official.PublishedAt
+		}
+		if filter.Sort == ServerSortRelevance && filter.SubstringName != nil {
+			key.relevance = searchRelevance(entry.Name, *filter.SubstringName)
+		}
+		keys[entry] = key
+	}
+	return keys
+}
+
+// searchRelevance ranks how closely a server name matches a search term, lower being closer:
+// 0 for the whole name, 1 for the name within its namespace, 2 for a prefix of either, and 3 otherwise
+func searchRelevance(name, search string) int {
+	name = strings.ToLower(name)
+	search = strings.ToLower(search)
+	_, shortName, _ := strings.Cut(name, "/")
+	switch {
+	case name == search:
+		return 0
+	case shortName == search:
+		return 1
+	case strings.HasPrefix(name, search) || strings.HasPrefix(shortName, search):
+		return 2
+	default:
+		return 3
+	}
+}
+
 // matchesFilter checks if an entry matches the provided filter
+//
 //nolint:cyclop // Filter matching logic is inherently complex but clear
 func (db *MemoryDB) matchesFilter(entry *apiv0.ServerJSON, filter *ServerFilter) bool {
 	if filter == nil {
@@ -175,6 +1055,9 @@ func (db *MemoryDB) matchesFilter(entry *apiv0.ServerJSON, filter *ServerFilter)
 	if filter.Name != nil && entry.Name != *filter.Name {
 		return false
 	}
+	if filter.NameFold != nil && !strings.EqualFold(entry.Name, *filter.NameFold) {
+		return false
+	}
 
 	// Check remote URL filter
 	if filter.RemoteURL != nil {
@@ -228,6 +1111,42 @@ func (db *MemoryDB) matchesFilter(entry *apiv0.ServerJSON, filter *ServerFilter)
 		}
 	}
 
+	// Check transport filter
+	if filter.Transport != nil && !hasTransport(entry, *filter.Transport) {
+		return false
+	}
+
+	if filter.ExcludeDeleted && entry.Status == model.StatusDeleted {
+		return false
+	}
+
+	if filter.HasAdvisory != nil && entry.Meta != nil && entry.Meta.Official != nil &&
+		len(db.advisoryLinks[entry.Meta.Official.ID]) > 0 != *filter.HasAdvisory {
+		return false
+	}
+
+	// Check category and tag filters
+	if filter.Category != nil && !slices.Contains(entry.Categories, *filter.Category) {
+		return false
+	}
+	if filter.Tag != nil && !slices.Contains(entry.Tags, *filter.Tag) {
+		return false
+	}
+
+	// Check registry type filter
+	if filter.RegistryType != nil {
+		found := false
+		for _, pkg := range entry.Packages {
+			if pkg.RegistryType == *filter.RegistryType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
 	return true
 }
 
@@ -0,0 +1,152 @@
+package database
+
+import (
+	"hash/fnv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// serverShardCount is the number of shards server records are spread over
+const serverShardCount = 16
+
+// serverStore holds the server records of a MemoryDB, spread over shards so lookups by ID only
+// contend with writes to the same shard. Lists read an immutable snapshot of every record, rebuilt
+// after the first read following a write, so they never hold a lock while filtering and sorting.
+type serverStore struct {
+	shards [serverShardCount]serverShard
+
+	// mu serializes writes, so a publish and the demotion of the previous latest version land
+	// together and in change feed order. It also guards the change feed.
+	mu      sync.RWMutex
+	changes []*apiv0.ChangeEvent // change feed, in sequence order
+
+	// snapshot is every record as of the last rebuild, or nil if a write has happened since
+	snapshot atomic.Pointer[[]*apiv0.ServerJSON]
+}
+
+type serverShard struct {
+	mu      sync.RWMutex
+	entries map[string]*apiv0.ServerJSON // maps registry metadata ID to ServerJSON
+	latest  map[string]string            // maps server name to the ID of its latest version, for names in this shard
+}
+
+func newServerStore() *serverStore {
+	store := &serverStore{}
+	for i := range store.shards {
+		store.shards[i].entries = make(map[string]*apiv0.ServerJSON)
+		store.shards[i].latest = make(map[string]string)
+	}
+	return store
+}
+
+func (s *serverStore) shardFor(id string) *serverShard {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(id))
+	return &s.shards[h.Sum32()%serverShardCount]
+}
+
+// get returns the record with the given ID
+func (s *serverStore) get(id string) (*apiv0.ServerJSON, bool) {
+	shard := s.shardFor(id)
+	shard.mu.RLock()
+	defer shard.mu.RUnlock()
+	entry, ok := shard.entries[id]
+	return entry, ok
+}
+
+// getLatest returns the latest version of the named server
+func (s *serverStore) getLatest(name string) (*apiv0.ServerJSON, bool) {
+	shard := s.shardFor(name)
+	shard.mu.RLock()
+	id, ok := shard.latest[name]
+	shard.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return s.get(id)
+}
+
+// nameConflictLocked returns the name of a record whose name differs from name only in case, if there is one.
+// Callers must hold s.mu, so no conflicting record can be written before they store theirs.
+func (s *serverStore) nameConflictLocked(name string) (string, bool) {
+	for i := range s.shards {
+		shard := &s.shards[i]
+		shard.mu.RLock()
+		for _, entry := range shard.entries {
+			if entry.Name != name && strings.EqualFold(entry.Name, name) {
+				shard.mu.RUnlock()
+				return entry.Name, true
+			}
+		}
+		shard.mu.RUnlock()
+	}
+	return "", false
+}
+
+// putLocked stores a record, points its name at it if it is the latest version, and discards the
+// snapshot. Callers must hold s.mu.
+func (s *serverStore) putLocked(id string, server *apiv0.ServerJSON) {
+	shard := s.shardFor(id)
+	shard.mu.Lock()
+	previous := shard.entries[id]
+	shard.entries[id] = server
+	shard.mu.Unlock()
+
+	// A renamed record is no longer the latest version under its old name
+	if previous != nil && previous.Name != server.Name {
+		s.setLatest(previous.Name, id, false)
+	}
+	if server.Meta != nil && server.Meta.Official != nil {
+		s.setLatest(server.Name, id, server.Meta.Official.IsLatest)
+	}
+
+	s.snapshot.Store(nil)
+}
+
+// setLatest points name at the record with the given ID, or stops it pointing there
+func (s *serverStore) setLatest(name, id string, isLatest bool) {
+	shard := s.shardFor(name)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	if isLatest {
+		shard.latest[name] = id
+	} else if shard.latest[name] == id {
+		delete(shard.latest, name)
+	}
+}
+
+// lastSequence returns the sequence number of the latest change feed event
+func (s *serverStore) lastSequence() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return int64(len(s.changes))
+}
+
+// all returns every record. The slice is shared by concurrent readers, so callers must not modify it.
+func (s *serverStore) all() []*apiv0.ServerJSON {
+	if snapshot := s.snapshot.Load(); snapshot != nil {
+		return *snapshot
+	}
+
+	// Rebuilding excludes writers, so the snapshot never holds half of a publish
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if snapshot := s.snapshot.Load(); snapshot != nil {
+		return *snapshot
+	}
+
+	var entries []*apiv0.ServerJSON
+	for i := range s.shards {
+		shard := &s.shards[i]
+		shard.mu.RLock()
+		for _, entry := range shard.entries {
+			entries = append(entries, entry)
+		}
+		shard.mu.RUnlock()
+	}
+	s.snapshot.Store(&entries)
+	return entries
+}
@@ -0,0 +1,203 @@
+package database_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/database/drivertest"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func TestMemoryDB_Conformance(t *testing.T) {
+	drivertest.Run(t, func(*testing.T) database.Database {
+		return database.NewMemoryDB()
+	})
+}
+
+func newServerVersion(name, version string) *apiv0.ServerJSON {
+	now := time.Now()
+	return &apiv0.ServerJSON{
+		Name:        name,
+		Description: "A stress tested server",
+		Version:     version,
+		Meta: &apiv0.ServerMeta{
+			Official: &apiv0.RegistryExtensions{
+				ID:          uuid.NewString(),
+				PublishedAt: now,
+				UpdatedAt:   now,
+				IsLatest:    true,
+			},
+		},
+	}
+}
+
+// TestMemoryDB_ConcurrentPublishAndRead publishes versions of many servers while lists, lookups and
+// change feed reads run alongside. Run with -race to check the sharded store's locking.
+func TestMemoryDB_ConcurrentPublishAndRead(t *testing.T) {
+	const (
+		publishers = 8
+		versions   = 25
+		readers    = 8
+	)
+	ctx := context.Background()
+	db := database.NewMemoryDB()
+
+	var publishing sync.WaitGroup
+	for p := range publishers {
+		publishing.Add(1)
+		go func() {
+			defer publishing.Done()
+			name := fmt.Sprintf("com.example/server-%d", p)
+			for v := range versions {
+				server := newServerVersion(name, fmt.Sprintf("1.0.%d", v))
+				_, err := db.PublishServerVersion(ctx, server)
+				assert.NoError(t, err)
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	var reading sync.WaitGroup
+	for range readers {
+		reading.Add(1)
+		go func() {
+			defer reading.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				// A publish and the demotion of the previous version are never seen apart
+				servers, _, err := db.List(ctx, nil, "", publishers*versions)
+				if !assert.NoError(t, err) {
+					return
+				}
+				latest := make(map[string]int)
+				for _, server := range servers {
+					if server.Meta.Official.IsLatest {
+						latest[server.Name]++
+					}
+				}
+				for name, count := range latest {
+					assert.Equal(t, 1, count, "%s has %d latest versions", name, count)
+				}
+
+				if len(servers) > 0 {
+					_, err := db.GetByID(ctx, servers[0].Meta.Official.ID)
+					assert.NoError(t, err)
+				}
+				_, err = db.ListChanges(ctx, 0, 50)
+				assert.NoError(t, err)
+				_, err = db.CountServersByCategory(ctx)
+				assert.NoError(t, err)
+			}
+		}()
+	}
+
+	publishing.Wait()
+	close(done)
+	reading.Wait()
+
+	servers, nextCursor, err := db.List(ctx, nil, "", publishers*versions+1)
+	require.NoError(t, err)
+	assert.Empty(t, nextCursor)
+	assert.Len(t, servers, publishers*versions)
+
+	isLatest := true
+	latest, _, err := db.List(ctx, &database.ServerFilter{IsLatest: &isLatest}, "", publishers*versions)
+	require.NoError(t, err)
+	assert.Len(t, latest, publishers)
+
+	// Each version is a publish, and each but the first of a server also demotes its predecessor
+	changes, err := db.ListChanges(ctx, 0, 2*publishers*versions)
+	require.NoError(t, err)
+	require.Len(t, changes, publishers*(2*versions-1))
+	for i, change := range changes {
+		assert.Equal(t, int64(i+1), change.Sequence)
+	}
+}
+
+// TestMemoryDB_ConcurrentUpdates checks revisions stay consistent when the same record is edited concurrently
+func TestMemoryDB_ConcurrentUpdates(t *testing.T) {
+	const editors = 16
+	ctx := context.Background()
+	db := database.NewMemoryDB()
+
+	created, err := db.CreateServer(ctx, newServerVersion("com.example/edited", "1.0.0"))
+	require.NoError(t, err)
+	id := created.Meta.Official.ID
+
+	var wg sync.WaitGroup
+	for e := range editors {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			current, err := db.GetByID(ctx, id)
+			if !assert.NoError(t, err) {
+				return
+			}
+			current.Description = fmt.Sprintf("Edited by %d", e)
+			_, _ = db.UpdateServer(ctx, id, current, 0)
+		}()
+	}
+	wg.Wait()
+
+	final, err := db.GetByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, created.Meta.Official.Revision+editors, final.Meta.Official.Revision)
+
+	servers, _, err := db.List(ctx, nil, "", 10)
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	assert.Equal(t, final.Description, servers[0].Description)
+}
+
+func TestMemoryDB_GetLatestVersion(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewMemoryDB()
+
+	_, err := db.GetLatestVersion(ctx, "com.example/server")
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	_, err = db.PublishServerVersion(ctx, newServerVersion("com.example/server", "1.0.0"))
+	require.NoError(t, err)
+	second, err := db.PublishServerVersion(ctx, newServerVersion("com.example/server", "1.1.0"))
+	require.NoError(t, err)
+
+	latest, err := db.GetLatestVersion(ctx, "com.example/server")
+	require.NoError(t, err)
+	assert.Equal(t, second.Meta.Official.ID, latest.Meta.Official.ID)
+
+	// Renaming the latest version moves it to the new name
+	renamed := *second
+	renamed.Name = "com.example/renamed"
+	_, err = db.UpdateServer(ctx, second.Meta.Official.ID, &renamed, 0)
+	require.NoError(t, err)
+
+	_, err = db.GetLatestVersion(ctx, "com.example/server")
+	assert.ErrorIs(t, err, database.ErrNotFound)
+	latest, err = db.GetLatestVersion(ctx, "com.example/renamed")
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", latest.Version)
+
+	// A version no longer marked latest isn't returned
+	demoted := *latest
+	official := *demoted.Meta.Official
+	official.IsLatest = false
+	demoted.Meta = &apiv0.ServerMeta{Official: &official}
+	_, err = db.UpdateServer(ctx, second.Meta.Official.ID, &demoted, 0)
+	require.NoError(t, err)
+
+	_, err = db.GetLatestVersion(ctx, "com.example/renamed")
+	assert.ErrorIs(t, err, database.ErrNotFound)
+}
@@ -10,10 +10,21 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 )
 
+// migrationLockID is the Postgres advisory lock key held while migrating, so replicas starting together don't race
+const migrationLockID = 7263541038
+
+var (
+	// ErrSchemaOutOfDate is returned when the database is missing migrations this binary expects
+	ErrSchemaOutOfDate = errors.New("database schema is out of date")
+	// ErrSchemaTooNew is returned when the database has migrations applied that this binary doesn't know about
+	ErrSchemaTooNew = errors.New("database schema is newer than this binary")
+)
+
 //go:embed migrations/*.sql
 var migrationFiles embed.FS
 
@@ -24,6 +35,12 @@ type Migration struct {
 	SQL     string
 }
 
+// MigrationStatus reports whether a known migration has been applied
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time // nil if pending
+}
+
 // Migrator handles database migrations
 type Migrator struct {
 	conn *pgx.Conn
@@ -47,22 +64,23 @@ func (m *Migrator) ensureMigrationsTable(ctx context.Context) error {
 	return err
 }
 
-// getAppliedMigrations returns a map of already applied migration versions
-func (m *Migrator) getAppliedMigrations(ctx context.Context) (map[int]bool, error) {
-	query := "SELECT version FROM schema_migrations ORDER BY version"
+// getAppliedMigrations returns the applied time of each already applied migration version
+func (m *Migrator) getAppliedMigrations(ctx context.Context) (map[int]time.Time, error) {
+	query := "SELECT version, applied_at FROM schema_migrations ORDER BY version"
 	rows, err := m.conn.Query(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query applied migrations: %w", err)
 	}
 	defer rows.Close()
 
-	applied := make(map[int]bool)
+	applied := make(map[int]time.Time)
 	for rows.Next() {
 		var version int
-		if err := rows.Scan(&version); err != nil {
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan migration version: %w", err)
 		}
-		applied[version] = true
+		applied[version] = appliedAt
 	}
 
 	return applied, rows.Err()
@@ -123,6 +141,16 @@ func (m *Migrator) Migrate(ctx context.Context) error {
 		return fmt.Errorf("failed to create migrations table: %w", err)
 	}
 
+	// Serialize migrations across instances sharing the database
+	if _, err := m.conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := m.conn.Exec(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", migrationLockID); err != nil {
+			log.Printf("Failed to release migration lock: %v", err)
+		}
+	}()
+
 	// Get applied migrations
 	applied, err := m.getAppliedMigrations(ctx)
 	if err != nil {
@@ -135,10 +163,15 @@ func (m *Migrator) Migrate(ctx context.Context) error {
 		return fmt.Errorf("failed to load migrations: %w", err)
 	}
 
+	// Refuse to touch a schema written by a newer release
+	if err := checkUnknown(applied, migrations); err != nil {
+		return err
+	}
+
 	// Find pending migrations
 	var pending []Migration
 	for _, migration := range migrations {
-		if !applied[migration.Version] {
+		if _, ok := applied[migration.Version]; !ok {
 			pending = append(pending, migration)
 		}
 	}
@@ -162,6 +195,89 @@ func (m *Migrator) Migrate(ctx context.Context) error {
 	return nil
 }
 
+// Status returns every known migration in version order along with when it was applied
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	if err := m.ensureMigrationsTable(ctx); err != nil {
+		return nil, fmt.Errorf("failed to create migrations table: %w", err)
+	}
+	return m.status(ctx)
+}
+
+// status is Status for a database whose migrations table exists
+func (m *Migrator) status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.getAppliedMigrations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+
+	migrations, err := m.loadMigrations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, migration := range migrations {
+		status := MigrationStatus{Migration: migration}
+		if appliedAt, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, checkUnknown(applied, migrations)
+}
+
+// CheckSchema returns ErrSchemaOutOfDate if any migration is pending and ErrSchemaTooNew if the
+// database has migrations this binary doesn't know about. It only reads, so it works with a role
+// that can't create tables.
+func (m *Migrator) CheckSchema(ctx context.Context) error {
+	var initialized bool
+	if err := m.conn.QueryRow(ctx, "SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&initialized); err != nil {
+		return fmt.Errorf("failed to check for migrations table: %w", err)
+	}
+	if !initialized {
+		return fmt.Errorf("%w: schema not initialized; run `registry migrate`", ErrSchemaOutOfDate)
+	}
+
+	statuses, err := m.status(ctx)
+	if err != nil {
+		return err
+	}
+
+	var pending []string
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending = append(pending, status.Name)
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: %d pending migrations (%s); run `registry migrate`", ErrSchemaOutOfDate, len(pending), strings.Join(pending, ", "))
+	}
+
+	return nil
+}
+
+// checkUnknown returns ErrSchemaTooNew if any applied version is missing from the known migrations
+func checkUnknown(applied map[int]time.Time, migrations []Migration) error {
+	known := make(map[int]bool, len(migrations))
+	for _, migration := range migrations {
+		known[migration.Version] = true
+	}
+
+	var unknown []int
+	for version := range applied {
+		if !known[version] {
+			unknown = append(unknown, version)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Ints(unknown)
+		return fmt.Errorf("%w: unknown applied migrations %v", ErrSchemaTooNew, unknown)
+	}
+
+	return nil
+}
+
 // applyMigration applies a single migration in a transaction
 func (m *Migrator) applyMigration(ctx context.Context, migration Migration) error {
 	tx, err := m.conn.Begin(ctx)
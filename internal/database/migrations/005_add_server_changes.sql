@@ -0,0 +1,15 @@
+-- Add an append-only change feed so downstream indexes and mirrors can sync incrementally
+CREATE TABLE server_changes (
+    sequence BIGSERIAL PRIMARY KEY,
+    change_type VARCHAR(16) NOT NULL, -- publish, update or delete
+    server_id VARCHAR(255) NOT NULL,
+    value JSONB NOT NULL, -- Complete ServerJSON as of this change
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Backfill a publish event for every existing server, in publish order
+INSERT INTO server_changes (change_type, server_id, value, created_at)
+SELECT 'publish', id, value,
+    COALESCE((value->'_meta'->'io.modelcontextprotocol.registry/official'->>'published_at')::timestamptz, NOW())
+FROM servers
+ORDER BY (value->'_meta'->'io.modelcontextprotocol.registry/official'->>'published_at'), id;
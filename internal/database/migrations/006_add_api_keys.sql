@@ -0,0 +1,15 @@
+-- Add long-lived API keys for machine publishers
+-- Only a SHA-256 hash of each key is stored; the key itself is shown once at creation
+CREATE TABLE api_keys (
+    id VARCHAR(255) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    prefix VARCHAR(32) NOT NULL,
+    key_hash VARCHAR(64) NOT NULL UNIQUE,
+    owner VARCHAR(255) NOT NULL,
+    scopes JSONB NOT NULL, -- Resource patterns the key may publish to
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_api_keys_owner ON api_keys (owner);
@@ -0,0 +1,23 @@
+-- Add namespace reservations and server transfers
+-- A reservation limits publishing in a namespace to one identity
+CREATE TABLE namespace_reservations (
+    namespace VARCHAR(255) PRIMARY KEY,
+    owner VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- A transfer renames every version of a server once the recipient accepts and the grace period ends
+CREATE TABLE server_transfers (
+    id VARCHAR(255) PRIMARY KEY,
+    server_name VARCHAR(255) NOT NULL,
+    new_name VARCHAR(255) NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    requested_by VARCHAR(255) NOT NULL,
+    accepted_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    completes_at TIMESTAMP WITH TIME ZONE,
+    events JSONB NOT NULL -- Audit trail of transfer actions
+);
+
+CREATE INDEX idx_server_transfers_status ON server_transfers (status);
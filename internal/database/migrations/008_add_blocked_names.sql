@@ -0,0 +1,8 @@
+-- Add the server name blocklist managed through the admin API
+-- Patterns containing a slash match whole server names; other patterns are words blocked anywhere in a name
+CREATE TABLE blocked_names (
+    pattern VARCHAR(255) PRIMARY KEY,
+    reason TEXT NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
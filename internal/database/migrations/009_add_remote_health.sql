@@ -0,0 +1,8 @@
+-- Add liveness probe results for remote server URLs
+-- Keyed by URL rather than server, since many versions of a server usually share the same remotes
+CREATE TABLE remote_health (
+    url TEXT PRIMARY KEY,
+    last_checked_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_seen_healthy TIMESTAMP WITH TIME ZONE, -- NULL until a probe succeeds
+    last_error TEXT NOT NULL DEFAULT ''
+);
@@ -0,0 +1,11 @@
+-- Add daily usage event counters (installs and views) for server popularity statistics
+-- Counters are keyed by server name and aggregated per day, so no per-client data is stored
+CREATE TABLE server_event_counts (
+    server_name VARCHAR(255) NOT NULL,
+    event_type VARCHAR(32) NOT NULL,
+    day DATE NOT NULL,
+    count BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (server_name, event_type, day)
+);
+
+CREATE INDEX idx_server_event_counts_day ON server_event_counts (day);
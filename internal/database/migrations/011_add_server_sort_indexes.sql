@@ -0,0 +1,12 @@
+-- Add indexes backing the list sort orders
+-- published_at is stored as RFC 3339 text with a variable number of fractional digits, which doesn't sort
+-- lexicographically, so it is indexed as a timestamp. Casting text to timestamptz isn't IMMUTABLE in general
+-- because it can depend on the session time zone, but registry timestamps always carry an explicit offset.
+CREATE FUNCTION registry_timestamp(value TEXT) RETURNS TIMESTAMP WITH TIME ZONE
+    LANGUAGE sql IMMUTABLE STRICT PARALLEL SAFE
+    AS $$ SELECT value::timestamptz $$;
+
+CREATE INDEX idx_servers_name_id ON servers ((value->>'name'), id);
+CREATE INDEX idx_servers_published_at ON servers (
+    registry_timestamp(value->'_meta'->'io.modelcontextprotocol.registry/official'->>'published_at') DESC, id DESC
+);
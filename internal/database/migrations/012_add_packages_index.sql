@@ -0,0 +1,3 @@
+-- Add a GIN index on packages for transport and registry type filters
+-- Remotes are already covered by idx_servers_remotes
+CREATE INDEX idx_servers_packages ON servers USING GIN((value->'packages'));
@@ -0,0 +1,3 @@
+-- Add indexes for browsing servers by category and filtering by tag
+CREATE INDEX idx_servers_categories ON servers USING GIN((value->'categories'));
+CREATE INDEX idx_servers_tags ON servers USING GIN((value->'tags'));
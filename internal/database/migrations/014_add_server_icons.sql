@@ -0,0 +1,9 @@
+-- Add uploaded server icons
+-- The images themselves are kept in object storage under their SHA256; this table maps each server name to its current one
+CREATE TABLE server_icons (
+    server_name VARCHAR(255) PRIMARY KEY,
+    content_type VARCHAR(64) NOT NULL,
+    sha256 CHAR(64) NOT NULL,
+    updated_by TEXT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
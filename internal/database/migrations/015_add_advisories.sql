@@ -0,0 +1,24 @@
+-- Add security advisories filed against servers by admins or their publishers
+-- Each advisory is linked to the server versions its version range affects, so listings can filter on them
+CREATE TABLE advisories (
+    id VARCHAR(255) PRIMARY KEY,
+    server_name VARCHAR(255) NOT NULL,
+    severity VARCHAR(16) NOT NULL,
+    summary TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    affected_versions TEXT NOT NULL DEFAULT '', -- empty if every version is affected
+    remediation TEXT NOT NULL DEFAULT '',
+    aliases JSONB NOT NULL DEFAULT '[]',
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_advisories_server_name ON advisories (server_name);
+
+CREATE TABLE server_advisories (
+    server_id VARCHAR(255) NOT NULL REFERENCES servers (id) ON DELETE CASCADE,
+    advisory_id VARCHAR(255) NOT NULL REFERENCES advisories (id) ON DELETE CASCADE,
+    PRIMARY KEY (server_id, advisory_id)
+);
+
+CREATE INDEX idx_server_advisories_advisory_id ON server_advisories (advisory_id);
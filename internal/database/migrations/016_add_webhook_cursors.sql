@@ -0,0 +1,8 @@
+-- Track webhook delivery through the change feed. Changes are written in the same transaction as the
+-- server record they describe, so the feed serves as the outbox and each endpoint only needs a cursor.
+CREATE TABLE webhook_cursors (
+    endpoint TEXT PRIMARY KEY,
+    last_sequence BIGINT NOT NULL, -- sequence of the last change delivered
+    leased_by TEXT NOT NULL, -- registry instance currently delivering to this endpoint
+    leased_until TIMESTAMP WITH TIME ZONE NOT NULL
+);
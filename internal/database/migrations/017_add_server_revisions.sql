@@ -0,0 +1,5 @@
+-- Start every existing server record at revision 1; edits must name the revision they apply to
+UPDATE servers
+SET value = jsonb_set(value, '{_meta,io.modelcontextprotocol.registry/official,revision}', '1')
+WHERE value->'_meta'->'io.modelcontextprotocol.registry/official' IS NOT NULL
+  AND value->'_meta'->'io.modelcontextprotocol.registry/official'->'revision' IS NULL;
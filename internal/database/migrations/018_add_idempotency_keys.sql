@@ -0,0 +1,10 @@
+-- Remember the response to each publish made with an Idempotency-Key header, so retries don't publish twice
+CREATE TABLE idempotency_keys (
+    owner TEXT NOT NULL, -- "<auth method>:<subject>" of the publisher
+    key TEXT NOT NULL,
+    request_hash TEXT NOT NULL, -- hex-encoded SHA-256 of the request body
+    response JSONB, -- NULL while the publish is in progress
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (owner, key)
+);
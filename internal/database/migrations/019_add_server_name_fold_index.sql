@@ -0,0 +1,2 @@
+-- Add an index backing case-insensitive server name lookups, used to keep names unique regardless of case
+CREATE INDEX idx_servers_name_lower ON servers (lower(value->>'name'));
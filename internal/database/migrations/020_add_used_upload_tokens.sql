@@ -0,0 +1,7 @@
+-- Remember the upload tokens that have been used, until they expire, so each can only publish once
+CREATE TABLE used_upload_tokens (
+    id TEXT PRIMARY KEY, -- jti claim of the token
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_used_upload_tokens_expires_at ON used_upload_tokens (expires_at);
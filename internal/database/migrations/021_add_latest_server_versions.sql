@@ -0,0 +1,13 @@
+-- Point each server name at its latest version, maintained on publish and edit, so resolving the
+-- latest version of a server is a primary key lookup rather than a scan of its version history
+CREATE TABLE latest_server_versions (
+    name TEXT PRIMARY KEY,
+    server_id VARCHAR(255) NOT NULL REFERENCES servers (id) ON DELETE CASCADE
+);
+
+-- Backfill from the records currently marked latest, preferring the most recently published if a name has several
+INSERT INTO latest_server_versions (name, server_id)
+SELECT DISTINCT ON (value->>'name') value->>'name', id
+FROM servers
+WHERE (value->'_meta'->'io.modelcontextprotocol.registry/official'->>'is_latest')::boolean = true
+ORDER BY value->>'name', value->'_meta'->'io.modelcontextprotocol.registry/official'->>'published_at' DESC;
@@ -12,15 +12,46 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
 // PostgreSQL is an implementation of the Database interface using PostgreSQL
 type PostgreSQL struct {
-	pool *pgxpool.Pool
+	pool    *pgxpool.Pool
+	replica *readReplica // nil unless a read replica is connected
 }
 
-// NewPostgreSQL creates a new instance of the PostgreSQL database
-func NewPostgreSQL(ctx context.Context, connectionURI string) (*PostgreSQL, error) {
+// NewPostgreSQL creates a new instance of the PostgreSQL database. Pending migrations are applied when
+// autoMigrate is set; otherwise it refuses to connect unless the schema matches this binary.
+func NewPostgreSQL(ctx context.Context, connectionURI string, autoMigrate bool) (*PostgreSQL, error) {
+	pool, err := newPool(ctx, connectionURI)
+	if err != nil {
+		return nil, err
+	}
+
+	// Run migrations using a single connection from the pool
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection for migrations: %w", err)
+	}
+	defer conn.Release()
+	
+	migrator := NewMigrator(conn.Conn())
+	if autoMigrate {
+		if err := migrator.Migrate(ctx); err != nil {
+			return nil, fmt.Errorf("failed to run database migrations: %w", err)
+		}
+	} else if err := migrator.CheckSchema(ctx); err != nil {
+		return nil, fmt.Errorf("failed to verify database schema: %w", err)
+	}
+
+	return &PostgreSQL{
+		pool: pool,
+	}, nil
+}
+
+// newPool creates a connection pool with the registry's pool settings and checks that it can connect
+func newPool(ctx context.Context, connectionURI string) (*pgxpool.Pool, error) {
 	// Parse connection config for pool settings
 	config, err := pgxpool.ParseConfig(connectionURI)
 	if err != nil {
@@ -41,24 +72,11 @@ func NewPostgreSQL(ctx context.Context, connectionURI string) (*PostgreSQL, erro
 
 	// Test the connection
 	if err = pool.Ping(ctx); err != nil {
+		pool.Close()
 		return nil, fmt.Errorf("failed to ping PostgreSQL: %w", err)
 	}
 
-	// Run migrations using a single connection from the pool
-	conn, err := pool.Acquire(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to acquire connection for migrations: %w", err)
-	}
-	defer conn.Release()
-	
-	migrator := NewMigrator(conn.Conn())
-	if err := migrator.Migrate(ctx); err != nil {
-		return nil, fmt.Errorf("failed to run database migrations: %w", err)
-	}
-
-	return &PostgreSQL{
-		pool: pool,
-	}, nil
+	return pool, nil
 }
 
 //nolint:cyclop // Database filtering logic is inherently complex but clear
@@ -88,6 +106,11 @@ func (db *PostgreSQL) List(
 			args = append(args, *filter.Name)
 			argIndex++
 		}
+		if filter.NameFold != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("lower(value->>'name') = lower($%d)", argIndex))
+			args = append(args, *filter.NameFold)
+			argIndex++
+		}
 		if filter.RemoteURL != nil {
 			whereConditions = append(whereConditions, fmt.Sprintf("EXISTS (SELECT 1 FROM jsonb_array_elements(value->'remotes') AS remote WHERE remote->>'url' = $%d)", argIndex))
 			args = append(args, *filter.RemoteURL)
@@ -104,7 +127,7 @@ func (db *PostgreSQL) List(
 			argIndex++
 		}
 		if filter.Version != nil {
-			whereConditions = append(whereConditions, fmt.Sprintf("(value->'version_detail'->>'version') = $%d", argIndex))
+			whereConditions = append(whereConditions, fmt.Sprintf("value->>'version' = $%d", argIndex))
 			args = append(args, *filter.Version)
 			argIndex++
 		}
@@ -113,14 +136,69 @@ func (db *PostgreSQL) List(
 			args = append(args, *filter.IsLatest)
 			argIndex++
 		}
+		// Containment queries are served by the GIN indexes on remotes and packages
+		if filter.Transport != nil {
+			remotes, _ := json.Marshal([]map[string]string{{"type": *filter.Transport}})
+			packages, _ := json.Marshal([]map[string]map[string]string{{"transport": {"type": *filter.Transport}}})
+			whereConditions = append(whereConditions, fmt.Sprintf("(value->'remotes' @> $%d::jsonb OR value->'packages' @> $%d::jsonb)", argIndex, argIndex+1))
+			args = append(args, string(remotes), string(packages))
+			argIndex += 2
+		}
+		if filter.ExcludeDeleted {
+			whereConditions = append(whereConditions, "COALESCE(value->>'status', '') <> 'deleted'")
+		}
+		if filter.HasAdvisory != nil {
+			condition := "EXISTS (SELECT 1 FROM server_advisories sa WHERE sa.server_id = servers.id)"
+			if !*filter.HasAdvisory {
+				condition = "NOT " + condition
+			}
+			whereConditions = append(whereConditions, condition)
+		}
+		if filter.Category != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("value->'categories' ? $%d", argIndex))
+			args = append(args, *filter.Category)
+			argIndex++
+		}
+		if filter.Tag != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("value->'tags' ? $%d", argIndex))
+			args = append(args, *filter.Tag)
+			argIndex++
+		}
+		if filter.RegistryType != nil {
+			packages, _ := json.Marshal([]map[string]string{{"registry_type": *filter.RegistryType}})
+			whereConditions = append(whereConditions, fmt.Sprintf("value->'packages' @> $%d::jsonb", argIndex))
+			args = append(args, string(packages))
+			argIndex++
+		}
+	}
+
+	// Order by the requested sort keys, then primary key ID for consistent pagination
+	sortKeys, descending, args, argIndex := serverSortKeys(filter, args, argIndex)
+	orderBy := []string{}
+	for _, key := range sortKeys {
+		orderBy = append(orderBy, fmt.Sprintf(key, "servers"))
+	}
+	orderBy = append(orderBy, "id")
+	direction, comparison := "", ">"
+	if descending {
+		direction, comparison = " DESC", "<"
 	}
 
-	// Add cursor pagination using primary key ID
+	// Add cursor pagination, continuing after the sort keys of the cursor's row
 	if cursor != "" {
 		if _, err := uuid.Parse(cursor); err != nil {
 			return nil, "", fmt.Errorf("invalid cursor format: %w", err)
 		}
-		whereConditions = append(whereConditions, fmt.Sprintf("id > $%d", argIndex))
+		if len(sortKeys) == 0 {
+			whereConditions = append(whereConditions, fmt.Sprintf("id > $%d", argIndex))
+		} else {
+			cursorKeys := []string{}
+			for _, key := range sortKeys {
+				cursorKeys = append(cursorKeys, fmt.Sprintf(key, "c"))
+			}
+			whereConditions = append(whereConditions, fmt.Sprintf("(%s) %s (SELECT %s, c.id FROM servers c WHERE c.id = $%d)",
+				strings.Join(orderBy, ", "), comparison, strings.Join(cursorKeys, ", "), argIndex))
+		}
 		args = append(args, cursor)
 		argIndex++
 	}
@@ -136,12 +214,12 @@ func (db *PostgreSQL) List(
         SELECT value
         FROM servers
         %s
-        ORDER BY id
+        ORDER BY %s
         LIMIT $%d
-    `, whereClause, argIndex)
+    `, whereClause, strings.Join(orderBy, direction+", ")+direction, argIndex)
 	args = append(args, limit)
 
-	rows, err := db.pool.Query(ctx, query, args...)
+	rows, err := db.readPool(ctx).Query(ctx, query, args...)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to query servers: %w", err)
 	}
@@ -181,6 +259,42 @@ func (db *PostgreSQL) List(
 	return results, nextCursor, nil
 }
 
+// serverSortKeys returns the expressions servers are ordered by for filter.Sort, before the primary key, and
+// whether they sort descending. Each expression takes the servers table alias as its format argument.
+func serverSortKeys(filter *ServerFilter, args []any, argIndex int) ([]string, bool, []any, int) {
+	if filter == nil {
+		return nil, false, args, argIndex
+	}
+
+	const name = "%[1]s.value->>'name'"
+	switch filter.Sort {
+	case ServerSortDownloads:
+		// Served by the (server_name, event_type, day) primary key of server_event_counts
+		installs := fmt.Sprintf(`COALESCE((SELECT SUM(e.count) FROM server_event_counts e
+			WHERE e.server_name = %s AND e.event_type = 'install' AND e.day >= $%d), 0)`, name, argIndex)
+		return []string{installs}, true, append(args, WeeklyStatsStart(time.Now())), argIndex + 1
+	case ServerSortRecent:
+		// Served by idx_servers_published_at
+		return []string{"registry_timestamp(%[1]s.value->'_meta'->'io.modelcontextprotocol.registry/official'->>'published_at')"}, true, args, argIndex
+	case ServerSortRelevance:
+		if filter.SubstringName == nil {
+			return []string{name}, false, args, argIndex
+		}
+		shortName := "split_part(lower(" + name + "), '/', 2)"
+		relevance := fmt.Sprintf(`CASE
+			WHEN lower(%[1]s) = lower($%[3]d) THEN 0
+			WHEN %[2]s = lower($%[3]d) THEN 1
+			WHEN starts_with(lower(%[1]s), lower($%[3]d)) OR starts_with(%[2]s, lower($%[3]d)) THEN 2
+			ELSE 3 END`, name, shortName, argIndex)
+		return []string{relevance, name}, false, append(args, *filter.SubstringName), argIndex + 1
+	case ServerSortName:
+		// Served by idx_servers_name_id
+		return []string{name}, false, args, argIndex
+	default:
+		return nil, false, args, argIndex
+	}
+}
+
 func (db *PostgreSQL) GetByID(ctx context.Context, id string) (*apiv0.ServerJSON, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
@@ -194,7 +308,7 @@ func (db *PostgreSQL) GetByID(ctx context.Context, id string) (*apiv0.ServerJSON
 
 	var valueJSON []byte
 
-	err := db.pool.QueryRow(ctx, query, id).Scan(&valueJSON)
+	err := db.readPool(ctx).QueryRow(ctx, query, id).Scan(&valueJSON)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -212,6 +326,57 @@ func (db *PostgreSQL) GetByID(ctx context.Context, id string) (*apiv0.ServerJSON
 	return &serverJSON, nil
 }
 
+// GetLatestVersion returns the version of the named server marked latest, from the latest_server_versions projection
+func (db *PostgreSQL) GetLatestVersion(ctx context.Context, name string) (*apiv0.ServerJSON, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT s.value
+		FROM latest_server_versions l
+		JOIN servers s ON s.id = l.server_id
+		WHERE l.name = $1
+	`
+
+	var valueJSON []byte
+	if err := db.readPool(ctx).QueryRow(ctx, query, name).Scan(&valueJSON); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get latest server version: %w", err)
+	}
+
+	var serverJSON apiv0.ServerJSON
+	if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+	}
+	return &serverJSON, nil
+}
+
+// updateLatestVersion keeps latest_server_versions in step with a stored record: the record's name points at
+// it while it is marked latest, and no name points at it once it isn't or after it is renamed
+func updateLatestVersion(ctx context.Context, tx pgx.Tx, id string, server *apiv0.ServerJSON) error {
+	if server.Meta == nil || server.Meta.Official == nil {
+		return nil
+	}
+	isLatest := server.Meta.Official.IsLatest
+
+	_, err := tx.Exec(ctx, `
+		DELETE FROM latest_server_versions WHERE server_id = $1 AND (name <> $2 OR NOT $3)
+	`, id, server.Name, isLatest)
+	if err == nil && isLatest {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO latest_server_versions (name, server_id) VALUES ($1, $2)
+			ON CONFLICT (name) DO UPDATE SET server_id = EXCLUDED.server_id
+		`, server.Name, id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update latest server version: %w", err)
+	}
+	return nil
+}
+
 // CreateServer adds a new server to the database
 func (db *PostgreSQL) CreateServer(ctx context.Context, server *apiv0.ServerJSON) (*apiv0.ServerJSON, error) {
 	if ctx.Err() != nil {
@@ -224,6 +389,7 @@ func (db *PostgreSQL) CreateServer(ctx context.Context, server *apiv0.ServerJSON
 	}
 
 	id := server.Meta.Official.ID
+	server = withRevision(server, serverRevision(server))
 
 	// Marshal the complete server to JSONB
 	valueJSON, err := json.Marshal(server)
@@ -231,22 +397,152 @@ func (db *PostgreSQL) CreateServer(ctx context.Context, server *apiv0.ServerJSON
 		return nil, fmt.Errorf("failed to marshal server JSON: %w", err)
 	}
 
-	// Insert into simple servers table
-	query := `
-		INSERT INTO servers (id, value)
-		VALUES ($1, $2)
-	`
+	err = db.inChangeTx(ctx, func(tx pgx.Tx) error {
+		// Insert into simple servers table
+		query := `
+			INSERT INTO servers (id, value)
+			VALUES ($1, $2)
+		`
+
+		if _, err := tx.Exec(ctx, query, id, valueJSON); err != nil {
+			return fmt.Errorf("failed to insert server: %w", err)
+		}
+		if err := updateLatestVersion(ctx, tx, id, server); err != nil {
+			return err
+		}
+
+		return recordChange(ctx, tx, apiv0.ChangeTypePublish, id, valueJSON)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return server, nil
+}
+
+// PublishServerVersion adds a new version of a server and, if it is the latest, demotes the previous latest version
+// in one transaction
+func (db *PostgreSQL) PublishServerVersion(ctx context.Context, server *apiv0.ServerJSON) (*apiv0.ServerJSON, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if server.Meta == nil || server.Meta.Official == nil {
+		return nil, fmt.Errorf("server must have registry metadata with ID")
+	}
+
+	id := server.Meta.Official.ID
+	server = withRevision(server, serverRevision(server))
+
+	valueJSON, err := json.Marshal(server)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal server JSON: %w", err)
+	}
+
+	err = db.inChangeTx(ctx, func(tx pgx.Tx) error {
+		if err := checkNameConflict(ctx, tx, server.Name); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `INSERT INTO servers (id, value) VALUES ($1, $2)`, id, valueJSON); err != nil {
+			return fmt.Errorf("failed to insert server: %w", err)
+		}
+		if err := recordChange(ctx, tx, apiv0.ChangeTypePublish, id, valueJSON); err != nil {
+			return err
+		}
 
-	_, err = db.pool.Exec(ctx, query, id, valueJSON)
+		if !server.Meta.Official.IsLatest {
+			return nil
+		}
+		previousLatestID, err := claimLatestVersion(ctx, tx, server.Name, id)
+		if err != nil || previousLatestID == "" {
+			return err
+		}
+
+		// Demote the stored record rather than the caller's copy, so concurrent edits to it aren't lost
+		var previousJSON []byte
+		err = tx.QueryRow(ctx, `SELECT value FROM servers WHERE id = $1 FOR UPDATE`, previousLatestID).Scan(&previousJSON)
+		if err != nil {
+			return fmt.Errorf("failed to get previous latest version: %w", err)
+		}
+		var previous apiv0.ServerJSON
+		if err := json.Unmarshal(previousJSON, &previous); err != nil {
+			return fmt.Errorf("failed to unmarshal server JSON: %w", err)
+		}
+
+		demoted := demoteLatest(&previous, time.Now())
+		demotedJSON, err := json.Marshal(demoted)
+		if err != nil {
+			return fmt.Errorf("failed to marshal updated server: %w", err)
+		}
+		if _, err := tx.Exec(ctx, `UPDATE servers SET value = $1 WHERE id = $2`, demotedJSON, previousLatestID); err != nil {
+			return fmt.Errorf("failed to update previous latest version: %w", err)
+		}
+		return recordChange(ctx, tx, updateChangeType(&previous, demoted), previousLatestID, demotedJSON)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to insert server: %w", err)
+		return nil, err
 	}
 
 	return server, nil
 }
 
+// checkNameConflict returns ErrNameConflict if a stored server's name differs from name only in case. It holds a
+// lock on the lowercased name until the transaction ends, so concurrent publishes of two such names can't both pass.
+func checkNameConflict(ctx context.Context, tx pgx.Tx, name string) error {
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext(lower($1)))`, name); err != nil {
+		return fmt.Errorf("failed to lock server name: %w", err)
+	}
+
+	var existing string
+	err := tx.QueryRow(ctx, `
+		SELECT value->>'name' FROM servers
+		WHERE lower(value->>'name') = lower($1) AND value->>'name' <> $1
+		LIMIT 1
+	`, name).Scan(&existing)
+	switch {
+	case err == nil:
+		return fmt.Errorf("%w: %q and %q", ErrNameConflict, name, existing)
+	case errors.Is(err, pgx.ErrNoRows):
+		return nil
+	default:
+		return fmt.Errorf("failed to check for conflicting server names: %w", err)
+	}
+}
+
+// claimLatestVersion points name at the version id and returns the ID of the version it replaced, if any. The
+// previous latest version is found and locked inside the transaction, so concurrent publishes of the same server
+// take turns and each demotes the version the one before it published.
+func claimLatestVersion(ctx context.Context, tx pgx.Tx, name, id string) (string, error) {
+	for {
+		var previousLatestID string
+		err := tx.QueryRow(ctx, `SELECT server_id FROM latest_server_versions WHERE name = $1 FOR UPDATE`, name).Scan(&previousLatestID)
+		if err == nil {
+			if _, err := tx.Exec(ctx, `UPDATE latest_server_versions SET server_id = $2 WHERE name = $1`, name, id); err != nil {
+				return "", fmt.Errorf("failed to update latest server version: %w", err)
+			}
+			return previousLatestID, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return "", fmt.Errorf("failed to get latest server version: %w", err)
+		}
+
+		// No version is latest yet. If a concurrent publish claims the name first, the insert waits for it to
+		// commit and does nothing, and the next pass locks and demotes that version instead.
+		tag, err := tx.Exec(ctx, `
+			INSERT INTO latest_server_versions (name, server_id) VALUES ($1, $2)
+			ON CONFLICT (name) DO NOTHING
+		`, name, id)
+		if err != nil {
+			return "", fmt.Errorf("failed to insert latest server version: %w", err)
+		}
+		if tag.RowsAffected() == 1 {
+			return "", nil
+		}
+	}
+}
+
 // UpdateServer updates an existing server record with new server details
-func (db *PostgreSQL) UpdateServer(ctx context.Context, id string, server *apiv0.ServerJSON) (*apiv0.ServerJSON, error) {
+func (db *PostgreSQL) UpdateServer(ctx context.Context, id string, server *apiv0.ServerJSON, expectedRevision int64) (*apiv0.ServerJSON, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
@@ -256,33 +552,1103 @@ func (db *PostgreSQL) UpdateServer(ctx context.Context, id string, server *apiv0
 		return nil, fmt.Errorf("%w: io.modelcontextprotocol.registry/official.id must match path id (%s)", ErrInvalidInput, id)
 	}
 
-	// Marshal updated server
-	valueJSON, err := json.Marshal(server)
+	err := db.inChangeTx(ctx, func(tx pgx.Tx) error {
+		// Load the previous status so deletions can be told apart from other updates, and the revision to check and increment
+		var (
+			previousStatus   *string
+			previousRevision int64
+		)
+		err := tx.QueryRow(ctx, `
+			SELECT value->>'status', COALESCE((value->'_meta'->'io.modelcontextprotocol.registry/official'->>'revision')::bigint, 1)
+			FROM servers WHERE id = $1 FOR UPDATE
+		`, id).Scan(&previousStatus, &previousRevision)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrNotFound
+			}
+			return fmt.Errorf("failed to get server for update: %w", err)
+		}
+		if expectedRevision != 0 && previousRevision != expectedRevision {
+			return ErrRevisionConflict
+		}
+
+		// Marshal updated server
+		server = withRevision(server, previousRevision+1)
+		valueJSON, err := json.Marshal(server)
+		if err != nil {
+			return fmt.Errorf("failed to marshal updated server: %w", err)
+		}
+
+		// Update the complete server record in simple table
+		query := `
+			UPDATE servers 
+			SET value = $1
+			WHERE id = $2
+		`
+
+		if _, err := tx.Exec(ctx, query, valueJSON, id); err != nil {
+			return fmt.Errorf("failed to update server: %w", err)
+		}
+		if err := updateLatestVersion(ctx, tx, id, server); err != nil {
+			return err
+		}
+
+		previous := &apiv0.ServerJSON{}
+		if previousStatus != nil {
+			previous.Status = model.Status(*previousStatus)
+		}
+		return recordChange(ctx, tx, updateChangeType(previous, server), id, valueJSON)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return server, nil
+}
+
+func (db *PostgreSQL) ListChanges(ctx context.Context, since int64, limit int) ([]*apiv0.ChangeEvent, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if limit <= 0 {
+		limit = 10 // Default limit
+	}
+
+	query := `
+		SELECT sequence, change_type, server_id, value, created_at
+		FROM server_changes
+		WHERE sequence > $1
+		ORDER BY sequence
+		LIMIT $2
+	`
+
+	rows, err := db.pool.Query(ctx, query, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query changes: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []*apiv0.ChangeEvent
+	for rows.Next() {
+		var (
+			change    apiv0.ChangeEvent
+			valueJSON []byte
+		)
+		if err := rows.Scan(&change.Sequence, &change.Type, &change.ServerID, &valueJSON, &change.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan change row: %w", err)
+		}
+		if err := json.Unmarshal(valueJSON, &change.Server); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+		}
+		changes = append(changes, &change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating change rows: %w", err)
+	}
+
+	return changes, nil
+}
+
+// inChangeTx runs fn in a transaction that may append to the change feed.
+// Writers are serialized on the change feed so sequence numbers become visible in order:
+// otherwise a reader could see sequence N+1 commit before N and skip N forever.
+func (db *PostgreSQL) inChangeTx(ctx context.Context, fn func(pgx.Tx) error) error {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, "LOCK TABLE server_changes IN EXCLUSIVE MODE"); err != nil {
+		return fmt.Errorf("failed to lock change feed: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// recordChange appends an event to the change feed within tx
+func recordChange(ctx context.Context, tx pgx.Tx, changeType apiv0.ChangeType, id string, valueJSON []byte) error {
+	query := `
+		INSERT INTO server_changes (change_type, server_id, value)
+		VALUES ($1, $2, $3)
+	`
+	if _, err := tx.Exec(ctx, query, string(changeType), id, valueJSON); err != nil {
+		return fmt.Errorf("failed to record change: %w", err)
+	}
+	return nil
+}
+
+// CreateAPIKey stores a new API key
+func (db *PostgreSQL) CreateAPIKey(ctx context.Context, key *APIKey) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	scopesJSON, err := json.Marshal(key.Scopes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal API key scopes: %w", err)
+	}
+
+	query := `
+		INSERT INTO api_keys (id, name, prefix, key_hash, owner, scopes, created_at, expires_at, revoked_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err = db.pool.Exec(ctx, query, key.ID, key.Name, key.Prefix, key.KeyHash, key.Owner, scopesJSON, key.CreatedAt, key.ExpiresAt, key.RevokedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert API key: %w", err)
+	}
+
+	return nil
+}
+
+// GetAPIKeyByHash retrieves an API key by the hash of its secret
+func (db *PostgreSQL) GetAPIKeyByHash(ctx context.Context, keyHash string) (*APIKey, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT id, name, prefix, key_hash, owner, scopes, created_at, expires_at, revoked_at
+		FROM api_keys
+		WHERE key_hash = $1
+	`
+
+	key, err := scanAPIKey(db.pool.QueryRow(ctx, query, keyHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	return key, nil
+}
+
+// ListAPIKeys retrieves all API keys created by an owner, newest first
+func (db *PostgreSQL) ListAPIKeys(ctx context.Context, owner string) ([]*APIKey, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT id, name, prefix, key_hash, owner, scopes, created_at, expires_at, revoked_at
+		FROM api_keys
+		WHERE owner = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := db.pool.Query(ctx, query, owner)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal updated server: %w", err)
+		return nil, fmt.Errorf("failed to query API keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []*APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan API key row: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating API key rows: %w", err)
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey marks an owner's API key as revoked, keeping the original revocation time if already revoked
+func (db *PostgreSQL) RevokeAPIKey(ctx context.Context, id, owner string, revokedAt time.Time) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
 	}
 
-	// Update the complete server record in simple table
 	query := `
-		UPDATE servers 
-		SET value = $1
-		WHERE id = $2
+		UPDATE api_keys
+		SET revoked_at = COALESCE(revoked_at, $1)
+		WHERE id = $2 AND owner = $3
 	`
 
-	result, err := db.pool.Exec(ctx, query, valueJSON, id)
+	result, err := db.pool.Exec(ctx, query, revokedAt, id, owner)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update server: %w", err)
+		return fmt.Errorf("failed to revoke API key: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
-		return nil, ErrNotFound
+		return ErrNotFound
 	}
 
-	return server, nil
+	return nil
 }
 
-// Close closes the database connection
-func (db *PostgreSQL) Close() error {
+func scanAPIKey(row pgx.Row) (*APIKey, error) {
+	var (
+		key        APIKey
+		scopesJSON []byte
+	)
+	if err := row.Scan(&key.ID, &key.Name, &key.Prefix, &key.KeyHash, &key.Owner, &scopesJSON, &key.CreatedAt, &key.ExpiresAt, &key.RevokedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(scopesJSON, &key.Scopes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal API key scopes: %w", err)
+	}
+	return &key, nil
+}
+
+// ReserveNamespace stores a namespace reservation, failing if the namespace is already reserved
+func (db *PostgreSQL) ReserveNamespace(ctx context.Context, reservation *NamespaceReservation) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO namespace_reservations (namespace, owner, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (namespace) DO NOTHING
+	`
+
+	result, err := db.pool.Exec(ctx, query, reservation.Namespace, reservation.Owner, reservation.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert namespace reservation: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrAlreadyExists
+	}
+
+	return nil
+}
+
+// GetNamespaceReservation retrieves the reservation for a namespace
+func (db *PostgreSQL) GetNamespaceReservation(ctx context.Context, namespace string) (*NamespaceReservation, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT namespace, owner, created_at
+		FROM namespace_reservations
+		WHERE namespace = $1
+	`
+
+	var reservation NamespaceReservation
+	err := db.pool.QueryRow(ctx, query, namespace).Scan(&reservation.Namespace, &reservation.Owner, &reservation.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get namespace reservation: %w", err)
+	}
+
+	return &reservation, nil
+}
+
+// ReleaseNamespace removes an owner's namespace reservation
+func (db *PostgreSQL) ReleaseNamespace(ctx context.Context, namespace, owner string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.pool.Exec(ctx, `DELETE FROM namespace_reservations WHERE namespace = $1 AND owner = $2`, namespace, owner)
+	if err != nil {
+		return fmt.Errorf("failed to release namespace reservation: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// AddBlockedName stores a blocklist entry, failing if the pattern is already blocked
+func (db *PostgreSQL) AddBlockedName(ctx context.Context, entry *BlockedName) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO blocked_names (pattern, reason, created_by, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (pattern) DO NOTHING
+	`
+
+	result, err := db.pool.Exec(ctx, query, entry.Pattern, entry.Reason, entry.CreatedBy, entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert blocked name: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrAlreadyExists
+	}
+
+	return nil
+}
+
+// ListBlockedNames retrieves every blocklist entry, oldest first
+func (db *PostgreSQL) ListBlockedNames(ctx context.Context) ([]*BlockedName, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	rows, err := db.pool.Query(ctx, `SELECT pattern, reason, created_by, created_at FROM blocked_names ORDER BY created_at, pattern`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query blocked names: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*BlockedName
+	for rows.Next() {
+		var entry BlockedName
+		if err := rows.Scan(&entry.Pattern, &entry.Reason, &entry.CreatedBy, &entry.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan blocked name: %w", err)
+		}
+		entries = append(entries, &entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating blocked names: %w", err)
+	}
+
+	return entries, nil
+}
+
+// RemoveBlockedName deletes a blocklist entry
+func (db *PostgreSQL) RemoveBlockedName(ctx context.Context, pattern string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.pool.Exec(ctx, `DELETE FROM blocked_names WHERE pattern = $1`, pattern)
+	if err != nil {
+		return fmt.Errorf("failed to remove blocked name: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// SaveRemoteHealth records a probe result, keeping the previous LastSeenHealthy if the probe failed
+func (db *PostgreSQL) SaveRemoteHealth(ctx context.Context, health *RemoteHealth) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO remote_health (url, last_checked_at, last_seen_healthy, last_error)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (url) DO UPDATE SET
+			last_checked_at = EXCLUDED.last_checked_at,
+			last_seen_healthy = COALESCE(EXCLUDED.last_seen_healthy, remote_health.last_seen_healthy),
+			last_error = EXCLUDED.last_error
+	`
+
+	if _, err := db.pool.Exec(ctx, query, health.URL, health.LastCheckedAt, health.LastSeenHealthy, health.LastError); err != nil {
+		return fmt.Errorf("failed to save remote health: %w", err)
+	}
+
+	return nil
+}
+
+// GetRemoteHealth retrieves the health of the given remote URLs, keyed by URL; unprobed URLs are omitted
+func (db *PostgreSQL) GetRemoteHealth(ctx context.Context, urls []string) (map[string]*RemoteHealth, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT url, last_checked_at, last_seen_healthy, last_error
+		FROM remote_health
+		WHERE url = ANY($1)
+	`
+
+	rows, err := db.readPool(ctx).Query(ctx, query, urls)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query remote health: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]*RemoteHealth)
+	for rows.Next() {
+		var health RemoteHealth
+		if err := rows.Scan(&health.URL, &health.LastCheckedAt, &health.LastSeenHealthy, &health.LastError); err != nil {
+			return nil, fmt.Errorf("failed to scan remote health: %w", err)
+		}
+		result[health.URL] = &health
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating remote health: %w", err)
+	}
+
+	return result, nil
+}
+
+// SetServerIcon stores a server's icon, replacing any previous one
+func (db *PostgreSQL) SetServerIcon(ctx context.Context, icon *ServerIcon) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO server_icons (server_name, content_type, sha256, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (server_name) DO UPDATE SET
+			content_type = EXCLUDED.content_type,
+			sha256 = EXCLUDED.sha256,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	if _, err := db.pool.Exec(ctx, query, icon.ServerName, icon.ContentType, icon.SHA256, icon.UpdatedBy, icon.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to save server icon: %w", err)
+	}
+
+	return nil
+}
+
+// GetServerIcons retrieves the icons of the given servers, keyed by server name; servers without one are omitted
+func (db *PostgreSQL) GetServerIcons(ctx context.Context, serverNames []string) (map[string]*ServerIcon, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT server_name, content_type, sha256, updated_by, updated_at
+		FROM server_icons
+		WHERE server_name = ANY($1)
+	`
+
+	rows, err := db.readPool(ctx).Query(ctx, query, serverNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server icons: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]*ServerIcon)
+	for rows.Next() {
+		var icon ServerIcon
+		if err := rows.Scan(&icon.ServerName, &icon.ContentType, &icon.SHA256, &icon.UpdatedBy, &icon.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan server icon: %w", err)
+		}
+		result[icon.ServerName] = &icon
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating server icons: %w", err)
+	}
+
+	return result, nil
+}
+
+// DeleteServerIcon removes a server's icon, returning ErrNotFound if it has none
+func (db *PostgreSQL) DeleteServerIcon(ctx context.Context, serverName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.pool.Exec(ctx, `DELETE FROM server_icons WHERE server_name = $1`, serverName)
+	if err != nil {
+		return fmt.Errorf("failed to delete server icon: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// CreateAdvisory stores a security advisory and links it to the given server versions
+func (db *PostgreSQL) CreateAdvisory(ctx context.Context, advisory *Advisory, serverIDs []string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	aliasesJSON, err := json.Marshal(advisory.Aliases)
+	if err != nil {
+		return fmt.Errorf("failed to marshal advisory aliases: %w", err)
+	}
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := `
+		INSERT INTO advisories (id, server_name, severity, summary, description, affected_versions, remediation, aliases, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	if _, err := tx.Exec(ctx, query, advisory.ID, advisory.ServerName, string(advisory.Severity), advisory.Summary, advisory.Description,
+		advisory.AffectedVersions, advisory.Remediation, aliasesJSON, advisory.CreatedBy, advisory.CreatedAt); err != nil {
+		return fmt.Errorf("failed to insert advisory: %w", err)
+	}
+
+	if len(serverIDs) > 0 {
+		linkQuery := `
+			INSERT INTO server_advisories (server_id, advisory_id)
+			SELECT server_id, $2 FROM unnest($1::text[]) AS server_id
+		`
+		if _, err := tx.Exec(ctx, linkQuery, serverIDs, advisory.ID); err != nil {
+			return fmt.Errorf("failed to link advisory: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+const advisoryColumns = `a.id, a.server_name, a.severity, a.summary, a.description, a.affected_versions, a.remediation, a.aliases, a.created_by, a.created_at`
+
+func scanAdvisory(row pgx.Row, extra ...any) (*Advisory, error) {
+	var (
+		advisory    Advisory
+		severity    string
+		aliasesJSON []byte
+	)
+	dest := append([]any{&advisory.ID, &advisory.ServerName, &severity, &advisory.Summary, &advisory.Description,
+		&advisory.AffectedVersions, &advisory.Remediation, &aliasesJSON, &advisory.CreatedBy, &advisory.CreatedAt}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	advisory.Severity = AdvisorySeverity(severity)
+	if err := json.Unmarshal(aliasesJSON, &advisory.Aliases); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal advisory aliases: %w", err)
+	}
+	return &advisory, nil
+}
+
+// GetAdvisory retrieves a security advisory by ID
+func (db *PostgreSQL) GetAdvisory(ctx context.Context, id string) (*Advisory, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + advisoryColumns + ` FROM advisories a WHERE a.id = $1`
+	advisory, err := scanAdvisory(db.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get advisory: %w", err)
+	}
+	return advisory, nil
+}
+
+// ListAdvisories retrieves the security advisories filed against a server name, oldest first
+func (db *PostgreSQL) ListAdvisories(ctx context.Context, serverName string) ([]*Advisory, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `SELECT ` + advisoryColumns + ` FROM advisories a WHERE a.server_name = $1 ORDER BY a.created_at, a.id`
+	rows, err := db.pool.Query(ctx, query, serverName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query advisories: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*Advisory
+	for rows.Next() {
+		advisory, err := scanAdvisory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan advisory: %w", err)
+		}
+		result = append(result, advisory)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating advisories: %w", err)
+	}
+
+	return result, nil
+}
+
+// ListServerAdvisories retrieves the security advisories affecting the given server versions, keyed by server ID
+func (db *PostgreSQL) ListServerAdvisories(ctx context.Context, serverIDs []string) (map[string][]*Advisory, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT ` + advisoryColumns + `, sa.server_id
+		FROM server_advisories sa
+		JOIN advisories a ON a.id = sa.advisory_id
+		WHERE sa.server_id = ANY($1)
+		ORDER BY a.created_at, a.id
+	`
+	rows, err := db.readPool(ctx).Query(ctx, query, serverIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server advisories: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string][]*Advisory)
+	for rows.Next() {
+		var serverID string
+		advisory, err := scanAdvisory(rows, &serverID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan advisory: %w", err)
+		}
+		result[serverID] = append(result[serverID], advisory)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating server advisories: %w", err)
+	}
+
+	return result, nil
+}
+
+// LinkAdvisories marks a server version as affected by the given security advisories
+func (db *PostgreSQL) LinkAdvisories(ctx context.Context, serverID string, advisoryIDs []string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO server_advisories (server_id, advisory_id)
+		SELECT $1, advisory_id FROM unnest($2::text[]) AS advisory_id
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := db.pool.Exec(ctx, query, serverID, advisoryIDs); err != nil {
+		return fmt.Errorf("failed to link advisories: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteAdvisory removes a security advisory and its links to server versions
+func (db *PostgreSQL) DeleteAdvisory(ctx context.Context, id string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	// Links are removed by ON DELETE CASCADE
+	result, err := db.pool.Exec(ctx, `DELETE FROM advisories WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete advisory: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// CountServersByCategory counts the latest, non-deleted version of each server in each category
+func (db *PostgreSQL) CountServersByCategory(ctx context.Context) (map[string]int, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT category, COUNT(*)
+		FROM servers, jsonb_array_elements_text(COALESCE(value->'categories', '[]'::jsonb)) AS category
+		WHERE (value->'_meta'->'io.modelcontextprotocol.registry/official'->>'is_latest')::boolean = true
+			AND COALESCE(value->>'status', '') <> 'deleted'
+		GROUP BY category
+	`
+
+	rows, err := db.readPool(ctx).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count servers by category: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var category string
+		var count int
+		if err := rows.Scan(&category, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan category count: %w", err)
+		}
+		counts[category] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating category counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// RecordServerEvent increments a server's counter of eventType for the day containing at
+func (db *PostgreSQL) RecordServerEvent(ctx context.Context, serverName string, eventType ServerEventType, at time.Time) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO server_event_counts (server_name, event_type, day, count)
+		VALUES ($1, $2, $3, 1)
+		ON CONFLICT (server_name, event_type, day) DO UPDATE SET
+			count = server_event_counts.count + 1
+	`
+
+	if _, err := db.pool.Exec(ctx, query, serverName, string(eventType), eventDay(at)); err != nil {
+		return fmt.Errorf("failed to record server event: %w", err)
+	}
+
+	return nil
+}
+
+// ListServerEventCounts retrieves the daily event counts of the given servers from the day containing since onwards
+func (db *PostgreSQL) ListServerEventCounts(ctx context.Context, serverNames []string, since time.Time) ([]*ServerEventCount, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT server_name, event_type, day, count
+		FROM server_event_counts
+		WHERE server_name = ANY($1) AND day >= $2
+	`
+
+	rows, err := db.readPool(ctx).Query(ctx, query, serverNames, eventDay(since))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server event counts: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*ServerEventCount
+	for rows.Next() {
+		var count ServerEventCount
+		var eventType string
+		if err := rows.Scan(&count.ServerName, &eventType, &count.Day, &count.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan server event count: %w", err)
+		}
+		count.EventType = ServerEventType(eventType)
+		count.Day = count.Day.UTC()
+		result = append(result, &count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating server event counts: %w", err)
+	}
+
+	return result, nil
+}
+
+// CreateTransfer stores a new server transfer
+func (db *PostgreSQL) CreateTransfer(ctx context.Context, transfer *ServerTransfer) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	eventsJSON, err := json.Marshal(transfer.Events)
+	if err != nil {
+		return fmt.Errorf("failed to marshal transfer events: %w", err)
+	}
+
+	query := `
+		INSERT INTO server_transfers (id, server_name, new_name, status, requested_by, accepted_by, created_at, expires_at, completes_at, events)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err = db.pool.Exec(ctx, query, transfer.ID, transfer.ServerName, transfer.NewName, string(transfer.Status),
+		transfer.RequestedBy, transfer.AcceptedBy, transfer.CreatedAt, transfer.ExpiresAt, transfer.CompletesAt, eventsJSON)
+	if err != nil {
+		return fmt.Errorf("failed to insert server transfer: %w", err)
+	}
+
+	return nil
+}
+
+// GetTransfer retrieves a server transfer by ID
+func (db *PostgreSQL) GetTransfer(ctx context.Context, id string) (*ServerTransfer, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT id, server_name, new_name, status, requested_by, accepted_by, created_at, expires_at, completes_at, events
+		FROM server_transfers
+		WHERE id = $1
+	`
+
+	transfer, err := scanTransfer(db.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get server transfer: %w", err)
+	}
+
+	return transfer, nil
+}
+
+// ListTransfers retrieves transfers in the given status, oldest first
+func (db *PostgreSQL) ListTransfers(ctx context.Context, status TransferStatus) ([]*ServerTransfer, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT id, server_name, new_name, status, requested_by, accepted_by, created_at, expires_at, completes_at, events
+		FROM server_transfers
+		WHERE status = $1
+		ORDER BY created_at
+	`
+
+	rows, err := db.pool.Query(ctx, query, string(status))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server transfers: %w", err)
+	}
+	defer rows.Close()
+
+	transfers := []*ServerTransfer{}
+	for rows.Next() {
+		transfer, err := scanTransfer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan server transfer row: %w", err)
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating server transfer rows: %w", err)
+	}
+
+	return transfers, nil
+}
+
+// UpdateTransfer saves a transfer, provided its status is still previousStatus, so concurrent
+// actions such as an accept racing a cancel can't both succeed
+func (db *PostgreSQL) UpdateTransfer(ctx context.Context, transfer *ServerTransfer, previousStatus TransferStatus) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	eventsJSON, err := json.Marshal(transfer.Events)
+	if err != nil {
+		return fmt.Errorf("failed to marshal transfer events: %w", err)
+	}
+
+	query := `
+		UPDATE server_transfers
+		SET status = $1, accepted_by = $2, completes_at = $3, events = $4
+		WHERE id = $5 AND status = $6
+	`
+
+	result, err := db.pool.Exec(ctx, query, string(transfer.Status), transfer.AcceptedBy, transfer.CompletesAt, eventsJSON,
+		transfer.ID, string(previousStatus))
+	if err != nil {
+		return fmt.Errorf("failed to update server transfer: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func scanTransfer(row pgx.Row) (*ServerTransfer, error) {
+	var (
+		transfer   ServerTransfer
+		status     string
+		eventsJSON []byte
+	)
+	if err := row.Scan(&transfer.ID, &transfer.ServerName, &transfer.NewName, &status, &transfer.RequestedBy, &transfer.AcceptedBy,
+		&transfer.CreatedAt, &transfer.ExpiresAt, &transfer.CompletesAt, &eventsJSON); err != nil {
+		return nil, err
+	}
+	transfer.Status = TransferStatus(status)
+	if err := json.Unmarshal(eventsJSON, &transfer.Events); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal transfer events: %w", err)
+	}
+	return &transfer, nil
+}
+
+// ClaimWebhookCursor leases an endpoint's delivery cursor, creating it at the end of the change feed if needed
+func (db *PostgreSQL) ClaimWebhookCursor(ctx context.Context, endpoint, owner string, leasedUntil time.Time) (*WebhookCursor, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		INSERT INTO webhook_cursors (endpoint, last_sequence, leased_by, leased_until)
+		SELECT $1, COALESCE(MAX(sequence), 0), $2, $3 FROM server_changes
+		ON CONFLICT (endpoint) DO UPDATE
+		SET leased_by = EXCLUDED.leased_by, leased_until = EXCLUDED.leased_until
+		WHERE webhook_cursors.leased_by = EXCLUDED.leased_by OR webhook_cursors.leased_until <= NOW()
+		RETURNING endpoint, last_sequence, leased_by, leased_until
+	`
+
+	var cursor WebhookCursor
+	err := db.pool.QueryRow(ctx, query, endpoint, owner, leasedUntil).
+		Scan(&cursor.Endpoint, &cursor.LastSequence, &cursor.LeasedBy, &cursor.LeasedUntil)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrLeaseHeld
+		}
+		return nil, fmt.Errorf("failed to claim webhook cursor: %w", err)
+	}
+	return &cursor, nil
+}
+
+// AdvanceWebhookCursor records delivery up to sequence while owner holds the lease
+func (db *PostgreSQL) AdvanceWebhookCursor(ctx context.Context, endpoint, owner string, sequence int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		UPDATE webhook_cursors
+		SET last_sequence = GREATEST(last_sequence, $3)
+		WHERE endpoint = $1 AND leased_by = $2
+	`
+	result, err := db.pool.Exec(ctx, query, endpoint, owner, sequence)
+	if err != nil {
+		return fmt.Errorf("failed to advance webhook cursor: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrLeaseHeld
+	}
+	return nil
+}
+
+// CreateIdempotencyKey stores an in-progress idempotency key, replacing the owner's expired key with the same value
+func (db *PostgreSQL) CreateIdempotencyKey(ctx context.Context, key *IdempotencyKey) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO idempotency_keys (owner, key, request_hash, response, created_at, expires_at)
+		VALUES ($1, $2, $3, NULL, $4, $5)
+		ON CONFLICT (owner, key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash, response = NULL,
+			created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= NOW()
+	`
+
+	result, err := db.pool.Exec(ctx, query, key.Owner, key.Key, key.RequestHash, key.CreatedAt, key.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert idempotency key: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrAlreadyExists
+	}
+	return nil
+}
+
+// GetIdempotencyKey retrieves an owner's unexpired idempotency key
+func (db *PostgreSQL) GetIdempotencyKey(ctx context.Context, owner, key string) (*IdempotencyKey, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT owner, key, request_hash, response, created_at, expires_at
+		FROM idempotency_keys
+		WHERE owner = $1 AND key = $2 AND expires_at > NOW()
+	`
+
+	var (
+		idempotencyKey IdempotencyKey
+		responseJSON   []byte
+	)
+	err := db.pool.QueryRow(ctx, query, owner, key).Scan(&idempotencyKey.Owner, &idempotencyKey.Key, &idempotencyKey.RequestHash,
+		&responseJSON, &idempotencyKey.CreatedAt, &idempotencyKey.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	if responseJSON != nil {
+		if err := json.Unmarshal(responseJSON, &idempotencyKey.Response); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal idempotent response: %w", err)
+		}
+	}
+	return &idempotencyKey, nil
+}
+
+// CompleteIdempotencyKey stores the response to the publish made with an idempotency key
+func (db *PostgreSQL) CompleteIdempotencyKey(ctx context.Context, owner, key string, response *apiv0.ServerJSON) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("failed to marshal idempotent response: %w", err)
+	}
+
+	result, err := db.pool.Exec(ctx, `UPDATE idempotency_keys SET response = $3 WHERE owner = $1 AND key = $2`, owner, key, responseJSON)
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// DeleteIdempotencyKey removes an idempotency key
+func (db *PostgreSQL) DeleteIdempotencyKey(ctx context.Context, owner, key string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if _, err := db.pool.Exec(ctx, `DELETE FROM idempotency_keys WHERE owner = $1 AND key = $2`, owner, key); err != nil {
+		return fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// UseUploadToken records that an upload token was used, forgetting tokens that have since expired
+func (db *PostgreSQL) UseUploadToken(ctx context.Context, id string, expiresAt time.Time) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if _, err := db.pool.Exec(ctx, `DELETE FROM used_upload_tokens WHERE expires_at <= NOW()`); err != nil {
+		return fmt.Errorf("failed to delete expired upload tokens: %w", err)
+	}
+
+	result, err := db.pool.Exec(ctx, `INSERT INTO used_upload_tokens (id, expires_at) VALUES ($1, $2) ON CONFLICT (id) DO NOTHING`, id, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to record upload token use: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrAlreadyExists
+	}
+	return nil
+}
+
+// UploadTokenUsed reports whether an unexpired upload token was used
+func (db *PostgreSQL) UploadTokenUsed(ctx context.Context, id string) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	var used bool
+	err := db.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM used_upload_tokens WHERE id = $1 AND expires_at > NOW())`, id).Scan(&used)
+	if err != nil {
+		return false, fmt.Errorf("failed to check upload token use: %w", err)
+	}
+	return used, nil
+}
+
+// Close closes the database connection
+func (db *PostgreSQL) Close() error {
+	if db.replica != nil {
+		db.replica.close()
+	}
 	db.pool.Close()
 	return nil
 }
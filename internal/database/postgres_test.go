@@ -0,0 +1,58 @@
+package database_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/database/drivertest"
+)
+
+// TestPostgreSQL_Conformance runs the conformance suite against the database at MCP_REGISTRY_TEST_DATABASE_URL,
+// migrating it first. The suite only touches records it creates, so any development database will do.
+func TestPostgreSQL_Conformance(t *testing.T) {
+	uri := os.Getenv("MCP_REGISTRY_TEST_DATABASE_URL")
+	if uri == "" {
+		t.Skip("MCP_REGISTRY_TEST_DATABASE_URL is not set")
+	}
+
+	db, err := database.NewPostgreSQL(t.Context(), uri, true)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	drivertest.Run(t, func(*testing.T) database.Database {
+		return db
+	})
+}
+
+// TestMigrator_CheckSchemaUninitialized checks an empty schema of the database at MCP_REGISTRY_TEST_DATABASE_URL
+func TestMigrator_CheckSchemaUninitialized(t *testing.T) {
+	uri := os.Getenv("MCP_REGISTRY_TEST_DATABASE_URL")
+	if uri == "" {
+		t.Skip("MCP_REGISTRY_TEST_DATABASE_URL is not set")
+	}
+
+	conn, err := pgx.Connect(t.Context(), uri)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close(context.Background()) })
+
+	schema := "check_schema_" + uuid.NewString()[:8]
+	_, err = conn.Exec(t.Context(), "CREATE SCHEMA "+schema+"; SET search_path TO "+schema)
+	require.NoError(t, err)
+	t.Cleanup(func() { _, _ = conn.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE") })
+
+	err = database.NewMigrator(conn).CheckSchema(t.Context())
+	require.ErrorIs(t, err, database.ErrSchemaOutOfDate)
+	assert.ErrorContains(t, err, "schema not initialized")
+
+	// Checking doesn't create the migrations table
+	var exists bool
+	require.NoError(t, conn.QueryRow(t.Context(), "SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exists))
+	assert.False(t, exists)
+}
@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// replicaLagCheckInterval is how often a read replica's replication lag is measured
+const replicaLagCheckInterval = 5 * time.Second
+
+// replicaLagQuery measures how far a replica is behind the primary. A replica that has replayed everything
+// it received reports no lag, so an idle primary doesn't make the replica look stale.
+const replicaLagQuery = `
+	SELECT CASE
+		WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+	END
+`
+
+type replicaReadsKey struct{}
+
+// WithReplicaReads marks ctx as tolerating slightly stale data, letting reads made with it be served by a
+// read replica. Reads that a following write depends on should not use it.
+func WithReplicaReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replicaReadsKey{}, true)
+}
+
+// replicaReadsAllowed reports whether ctx was marked with WithReplicaReads
+func replicaReadsAllowed(ctx context.Context) bool {
+	allowed, _ := ctx.Value(replicaReadsKey{}).(bool)
+	return allowed
+}
+
+// readReplica is a connection pool to a read replica, along with whether it is currently fresh enough to use
+type readReplica struct {
+	pool   *pgxpool.Pool
+	maxLag time.Duration
+	usable atomic.Bool
+	stop   context.CancelFunc
+}
+
+// ConnectReadReplica routes reads made with a WithReplicaReads context to the replica at connectionURI. When
+// maxLag is positive, the replica is only used while its replication lag is within it; otherwise it is
+// always used while reachable. Reads fall back to the primary whenever the replica can't be used.
+func (db *PostgreSQL) ConnectReadReplica(ctx context.Context, connectionURI string, maxLag time.Duration) error {
+	pool, err := newPool(ctx, connectionURI)
+	if err != nil {
+		return fmt.Errorf("failed to connect to read replica: %w", err)
+	}
+
+	monitorCtx, stop := context.WithCancel(context.Background())
+	replica := &readReplica{pool: pool, maxLag: maxLag, stop: stop}
+	replica.check(ctx)
+	go replica.monitor(monitorCtx)
+
+	db.replica = replica
+	return nil
+}
+
+// readPool returns the pool to serve a read from: the replica if ctx allows it and the replica is usable,
+// otherwise the primary
+func (db *PostgreSQL) readPool(ctx context.Context) *pgxpool.Pool {
+	if db.replica != nil && replicaReadsAllowed(ctx) && db.replica.usable.Load() {
+		return db.replica.pool
+	}
+	return db.pool
+}
+
+// monitor re-checks the replica's lag until ctx is cancelled
+func (r *readReplica) monitor(ctx context.Context) {
+	ticker := time.NewTicker(replicaLagCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			checkCtx, cancel := context.WithTimeout(ctx, replicaLagCheckInterval)
+			r.check(checkCtx)
+			cancel()
+		}
+	}
+}
+
+// check measures the replica's lag and records whether it is usable, logging when that changes
+func (r *readReplica) check(ctx context.Context) {
+	var lagSeconds float64
+	err := r.pool.QueryRow(ctx, replicaLagQuery).Scan(&lagSeconds)
+	lag := time.Duration(lagSeconds * float64(time.Second))
+	usable := err == nil && (r.maxLag <= 0 || lag <= r.maxLag)
+
+	if r.usable.Swap(usable) == usable {
+		return
+	}
+	switch {
+	case usable:
+		log.Println("Serving reads from read replica")
+	case err != nil:
+		log.Printf("Serving reads from primary: failed to check read replica lag: %v", err)
+	default:
+		log.Printf("Serving reads from primary: read replica is %s behind (tolerance %s)", lag.Round(time.Millisecond), r.maxLag)
+	}
+}
+
+// close stops monitoring and closes the replica's connections
+func (r *readReplica) close() {
+	r.stop()
+	r.pool.Close()
+}
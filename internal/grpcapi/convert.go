@@ -0,0 +1,325 @@
+package grpcapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/api/v0/registrypb"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// JSON names of the _meta extensions with dedicated ServerMeta fields
+const (
+	officialMetaKey          = "io.modelcontextprotocol.registry/official"
+	publisherProvidedMetaKey = "io.modelcontextprotocol.registry/publisher-provided"
+)
+
+var changeTypes = map[apiv0.ChangeType]registrypb.ChangeType{
+	apiv0.ChangeTypePublish:   registrypb.ChangeType_CHANGE_TYPE_PUBLISH,
+	apiv0.ChangeTypeUpdate:    registrypb.ChangeType_CHANGE_TYPE_UPDATE,
+	apiv0.ChangeTypeDeprecate: registrypb.ChangeType_CHANGE_TYPE_DEPRECATE,
+	apiv0.ChangeTypeDelete:    registrypb.ChangeType_CHANGE_TYPE_DELETE,
+}
+
+func toProtoChange(change apiv0.ChangeEvent) (*registrypb.ChangeEvent, error) {
+	server, err := toProtoServer(change.Server)
+	if err != nil {
+		return nil, err
+	}
+	return &registrypb.ChangeEvent{
+		Sequence:  change.Sequence,
+		Type:      changeTypes[change.Type],
+		ServerId:  change.ServerID,
+		Server:    server,
+		CreatedAt: timestamppb.New(change.CreatedAt),
+	}, nil
+}
+
+func toProtoServers(servers []apiv0.ServerJSON) ([]*registrypb.Server, error) {
+	result := make([]*registrypb.Server, len(servers))
+	for i, server := range servers {
+		converted, err := toProtoServer(server)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = converted
+	}
+	return result, nil
+}
+
+func toProtoServer(server apiv0.ServerJSON) (*registrypb.Server, error) {
+	result := &registrypb.Server{
+		Name:        server.Name,
+		Description: server.Description,
+		Status:      string(server.Status),
+		Version:     server.Version,
+		WebsiteUrl:  server.WebsiteURL,
+		Categories:  server.Categories,
+		Tags:        server.Tags,
+	}
+	if server.Repository != (model.Repository{}) {
+		result.Repository = &registrypb.Repository{
+			Url:       server.Repository.URL,
+			Source:    server.Repository.Source,
+			Id:        server.Repository.ID,
+			Subfolder: server.Repository.Subfolder,
+		}
+	}
+	for _, pkg := range server.Packages {
+		result.Packages = append(result.Packages, toProtoPackage(pkg))
+	}
+	for _, remote := range server.Remotes {
+		result.Remotes = append(result.Remotes, toProtoTransport(remote))
+	}
+
+	if server.Meta != nil {
+		meta, err := toProtoMeta(server.Meta)
+		if err != nil {
+			return nil, err
+		}
+		result.Meta = meta
+	}
+	return result, nil
+}
+
+func toProtoMeta(meta *apiv0.ServerMeta) (*registrypb.ServerMeta, error) {
+	result := &registrypb.ServerMeta{}
+	if official := meta.Official; official != nil {
+		result.Official = &registrypb.RegistryExtensions{
+			Id:          official.ID,
+			PublishedAt: timestamppb.New(official.PublishedAt),
+			UpdatedAt:   protoTime(official.UpdatedAt),
+			IsLatest:    official.IsLatest,
+			Revision:    official.Revision,
+		}
+	}
+	if meta.PublisherProvided != nil {
+		publisherProvided, err := structpb.NewStruct(meta.PublisherProvided)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert publisher-provided metadata: %w", err)
+		}
+		result.PublisherProvided = publisherProvided
+	}
+
+	// Pass the remaining extensions through as JSON objects
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return nil, err
+	}
+	var extensions map[string]any
+	if err := json.Unmarshal(data, &extensions); err != nil {
+		return nil, err
+	}
+	delete(extensions, officialMetaKey)
+	delete(extensions, publisherProvidedMetaKey)
+	if len(extensions) > 0 {
+		result.Extensions, err = structpb.NewStruct(extensions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert registry extensions: %w", err)
+		}
+	}
+	return result, nil
+}
+
+func protoTime(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func toProtoPackage(pkg model.Package) *registrypb.Package {
+	result := &registrypb.Package{
+		RegistryType:    pkg.RegistryType,
+		RegistryBaseUrl: pkg.RegistryBaseURL,
+		Identifier:      pkg.Identifier,
+		Version:         pkg.Version,
+		FileSha256:      pkg.FileSHA256,
+		Digest:          pkg.Digest,
+		RuntimeHint:     pkg.RunTimeHint,
+		Transport:       toProtoTransport(pkg.Transport),
+	}
+	for _, arg := range pkg.RuntimeArguments {
+		result.RuntimeArguments = append(result.RuntimeArguments, toProtoArgument(arg))
+	}
+	for _, arg := range pkg.PackageArguments {
+		result.PackageArguments = append(result.PackageArguments, toProtoArgument(arg))
+	}
+	for _, env := range pkg.EnvironmentVariables {
+		result.EnvironmentVariables = append(result.EnvironmentVariables, toProtoKeyValueInput(env))
+	}
+	return result
+}
+
+func toProtoTransport(transport model.Transport) *registrypb.Transport {
+	result := &registrypb.Transport{Type: transport.Type, Url: transport.URL}
+	for _, header := range transport.Headers {
+		result.Headers = append(result.Headers, toProtoKeyValueInput(header))
+	}
+	return result
+}
+
+func toProtoKeyValueInput(input model.KeyValueInput) *registrypb.KeyValueInput {
+	return &registrypb.KeyValueInput{
+		Name:      input.Name,
+		Input:     toProtoInput(input.Input),
+		Variables: toProtoVariables(input.Variables),
+	}
+}
+
+func toProtoArgument(arg model.Argument) *registrypb.Argument {
+	return &registrypb.Argument{
+		Type:       string(arg.Type),
+		Name:       arg.Name,
+		IsRepeated: arg.IsRepeated,
+		ValueHint:  arg.ValueHint,
+		Input:      toProtoInput(arg.Input),
+		Variables:  toProtoVariables(arg.Variables),
+	}
+}
+
+func toProtoVariables(variables map[string]model.Input) map[string]*registrypb.Input {
+	if len(variables) == 0 {
+		return nil
+	}
+	result := make(map[string]*registrypb.Input, len(variables))
+	for name, variable := range variables {
+		result[name] = toProtoInput(variable)
+	}
+	return result
+}
+
+func toProtoInput(input model.Input) *registrypb.Input {
+	return &registrypb.Input{
+		Description: input.Description,
+		IsRequired:  input.IsRequired,
+		Format:      string(input.Format),
+		Value:       input.Value,
+		IsSecret:    input.IsSecret,
+		Default:     input.Default,
+		Choices:     input.Choices,
+	}
+}
+
+// errRegistryMetadata is returned when a published server sets metadata only the registry may set
+var errRegistryMetadata = errors.New("only publisher_provided metadata can be set when publishing")
+
+func fromProtoServer(server *registrypb.Server) (apiv0.ServerJSON, error) {
+	result := apiv0.ServerJSON{
+		Name:        server.GetName(),
+		Description: server.GetDescription(),
+		Status:      model.Status(server.GetStatus()),
+		Version:     server.GetVersion(),
+		WebsiteURL:  server.GetWebsiteUrl(),
+		Categories:  server.GetCategories(),
+		Tags:        server.GetTags(),
+	}
+	if repository := server.GetRepository(); repository != nil {
+		result.Repository = model.Repository{
+			URL:       repository.GetUrl(),
+			Source:    repository.GetSource(),
+			ID:        repository.GetId(),
+			Subfolder: repository.GetSubfolder(),
+		}
+	}
+	for _, pkg := range server.GetPackages() {
+		result.Packages = append(result.Packages, fromProtoPackage(pkg))
+	}
+	for _, remote := range server.GetRemotes() {
+		result.Remotes = append(result.Remotes, fromProtoTransport(remote))
+	}
+
+	if meta := server.GetMeta(); meta != nil {
+		if meta.GetOfficial() != nil || meta.GetExtensions() != nil {
+			return apiv0.ServerJSON{}, errRegistryMetadata
+		}
+		if meta.GetPublisherProvided() != nil {
+			result.Meta = &apiv0.ServerMeta{PublisherProvided: meta.GetPublisherProvided().AsMap()}
+		}
+	}
+	return result, nil
+}
+
+func fromProtoPackage(pkg *registrypb.Package) model.Package {
+	result := model.Package{
+		RegistryType:    pkg.GetRegistryType(),
+		RegistryBaseURL: pkg.GetRegistryBaseUrl(),
+		Identifier:      pkg.GetIdentifier(),
+		Version:         pkg.GetVersion(),
+		FileSHA256:      pkg.GetFileSha256(),
+		Digest:          pkg.GetDigest(),
+		RunTimeHint:     pkg.GetRuntimeHint(),
+	}
+	if pkg.GetTransport() != nil {
+		result.Transport = fromProtoTransport(pkg.GetTransport())
+	}
+	for _, arg := range pkg.GetRuntimeArguments() {
+		result.RuntimeArguments = append(result.RuntimeArguments, fromProtoArgument(arg))
+	}
+	for _, arg := range pkg.GetPackageArguments() {
+		result.PackageArguments = append(result.PackageArguments, fromProtoArgument(arg))
+	}
+	for _, env := range pkg.GetEnvironmentVariables() {
+		result.EnvironmentVariables = append(result.EnvironmentVariables, fromProtoKeyValueInput(env))
+	}
+	return result
+}
+
+func fromProtoTransport(transport *registrypb.Transport) model.Transport {
+	result := model.Transport{Type: transport.GetType(), URL: transport.GetUrl()}
+	for _, header := range transport.GetHeaders() {
+		result.Headers = append(result.Headers, fromProtoKeyValueInput(header))
+	}
+	return result
+}
+
+func fromProtoKeyValueInput(input *registrypb.KeyValueInput) model.KeyValueInput {
+	return model.KeyValueInput{
+		Name: input.GetName(),
+		InputWithVariables: model.InputWithVariables{
+			Input:     fromProtoInput(input.GetInput()),
+			Variables: fromProtoVariables(input.GetVariables()),
+		},
+	}
+}
+
+func fromProtoArgument(arg *registrypb.Argument) model.Argument {
+	return model.Argument{
+		Type:       model.ArgumentType(arg.GetType()),
+		Name:       arg.GetName(),
+		IsRepeated: arg.GetIsRepeated(),
+		ValueHint:  arg.GetValueHint(),
+		InputWithVariables: model.InputWithVariables{
+			Input:     fromProtoInput(arg.GetInput()),
+			Variables: fromProtoVariables(arg.GetVariables()),
+		},
+	}
+}
+
+func fromProtoVariables(variables map[string]*registrypb.Input) map[string]model.Input {
+	if len(variables) == 0 {
+		return nil
+	}
+	result := make(map[string]model.Input, len(variables))
+	for name, variable := range variables {
+		result[name] = fromProtoInput(variable)
+	}
+	return result
+}
+
+func fromProtoInput(input *registrypb.Input) model.Input {
+	return model.Input{
+		Description: input.GetDescription(),
+		IsRequired:  input.GetIsRequired(),
+		Format:      model.Format(input.GetFormat()),
+		Value:       input.GetValue(),
+		IsSecret:    input.GetIsSecret(),
+		Default:     input.GetDefault(),
+		Choices:     input.GetChoices(),
+	}
+}
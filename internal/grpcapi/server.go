@@ -0,0 +1,305 @@
+// Package grpcapi serves the registry over gRPC, sharing the service layer with the HTTP API
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/pkg/api/v0/registrypb"
+)
+
+const (
+	defaultListLimit    = 30
+	maxListLimit        = 100
+	watchPollInterval   = time.Second
+	watchReplayPageSize = 500
+)
+
+// Server is the gRPC server for the registry
+type Server struct {
+	config *config.Config
+	server *grpc.Server
+
+	// Cancelled on shutdown so Watch streams end instead of holding graceful shutdown open
+	baseCtx       context.Context
+	cancelBaseCtx context.CancelFunc
+}
+
+// NewServer creates a gRPC server exposing the Registry service
+func NewServer(cfg *config.Config, registryService service.RegistryService) *Server {
+	baseCtx, cancelBaseCtx := context.WithCancel(context.Background())
+
+	grpcServer := grpc.NewServer()
+	registrypb.RegisterRegistryServer(grpcServer, &registryServer{
+		cfg:        cfg,
+		registry:   registryService,
+		jwtManager: auth.NewJWTManager(cfg),
+		baseCtx:    baseCtx,
+	})
+	// Let tools such as grpcurl discover the service without the .proto file
+	reflection.Register(grpcServer)
+
+	return &Server{config: cfg, server: grpcServer, baseCtx: baseCtx, cancelBaseCtx: cancelBaseCtx}
+}
+
+// Start begins listening for incoming gRPC requests
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.config.GRPCAddress)
+	if err != nil {
+		return err
+	}
+	log.Printf("gRPC server starting on %s", s.config.GRPCAddress)
+	return s.Serve(listener)
+}
+
+// Serve accepts gRPC connections on listener until the server is shut down
+func (s *Server) Serve(listener net.Listener) error {
+	return s.server.Serve(listener)
+}
+
+// Shutdown gracefully shuts down the server, closing remaining connections once ctx is done
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.cancelBaseCtx()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
+
+// registryServer implements the Registry service
+type registryServer struct {
+	registrypb.UnimplementedRegistryServer
+
+	cfg        *config.Config
+	registry   service.RegistryService
+	jwtManager *auth.JWTManager
+	baseCtx    context.Context
+}
+
+func (s *registryServer) Publish(ctx context.Context, req *registrypb.PublishRequest) (*registrypb.Server, error) {
+	if req.GetServer() == nil {
+		return nil, status.Error(codes.InvalidArgument, "server is required")
+	}
+	server, err := fromProtoServer(req.GetServer())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	_, authorized, err := v0.AuthorizePublish(ctx, s.jwtManager, s.registry, s.cfg, authorization(ctx), server)
+	if err != nil {
+		return nil, statusFromHTTPError(err)
+	}
+
+	published, err := s.registry.Publish(server)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to publish server: %v", err)
+	}
+	authorized()
+	return toProtoServer(*published)
+}
+
+func (s *registryServer) Get(ctx context.Context, req *registrypb.GetRequest) (*registrypb.Server, error) {
+	if _, err := uuid.Parse(req.GetId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "id must be a UUID")
+	}
+	ctx, err := s.authorizeRead(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	server, err := s.registry.GetByID(req.GetId())
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "server not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get server: %v", err)
+	}
+	if !v0.Readable(ctx, server.Name) {
+		return nil, status.Error(codes.NotFound, "server not found")
+	}
+	return toProtoServer(*server)
+}
+
+func (s *registryServer) List(ctx context.Context, req *registrypb.ListRequest) (*registrypb.ListResponse, error) {
+	filter := &database.ServerFilter{}
+	if req.GetUpdatedSince() != nil {
+		updatedSince := req.GetUpdatedSince().AsTime()
+		filter.UpdatedSince = &updatedSince
+	}
+	if req.GetTransport() != "" {
+		transport := req.GetTransport()
+		filter.Transport = &transport
+	}
+	if req.GetRegistryType() != "" {
+		registryType := req.GetRegistryType()
+		filter.RegistryType = &registryType
+	}
+	if req.GetCategory() != "" {
+		category := req.GetCategory()
+		filter.Category = &category
+	}
+	if req.GetTag() != "" {
+		tag := req.GetTag()
+		filter.Tag = &tag
+	}
+	return s.list(ctx, filter, req.GetVersion(), req.GetCursor(), req.GetLimit())
+}
+
+func (s *registryServer) Search(ctx context.Context, req *registrypb.SearchRequest) (*registrypb.ListResponse, error) {
+	if req.GetQuery() == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+	query := req.GetQuery()
+	filter := &database.ServerFilter{SubstringName: &query, Sort: database.ServerSortRelevance}
+	return s.list(ctx, filter, req.GetVersion(), req.GetCursor(), req.GetLimit())
+}
+
+// list applies the version filter and pagination shared by List and Search
+func (s *registryServer) list(ctx context.Context, filter *database.ServerFilter, version, cursor string, limit int32) (*registrypb.ListResponse, error) {
+	ctx, err := s.authorizeRead(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	if cursor != "" {
+		if _, err := uuid.Parse(cursor); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid cursor")
+		}
+	}
+	if limit == 0 {
+		limit = defaultListLimit
+	}
+	if limit < 1 || limit > maxListLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", maxListLimit)
+	}
+
+	if version == "latest" {
+		isLatest := true
+		filter.IsLatest = &isLatest
+	} else if version != "" {
+		filter.Version = &version
+	}
+
+	servers, nextCursor, err := s.registry.List(filter, cursor, int(limit))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list servers: %v", err)
+	}
+	result, err := toProtoServers(v0.ReadableServers(ctx, servers))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert servers: %v", err)
+	}
+	return &registrypb.ListResponse{Servers: result, NextCursor: nextCursor}, nil
+}
+
+func (s *registryServer) Watch(req *registrypb.WatchRequest, stream grpc.ServerStreamingServer[registrypb.ChangeEvent]) error {
+	if req.GetSince() < 0 {
+		return status.Error(codes.InvalidArgument, "since must not be negative")
+	}
+	if _, err := s.authorizeRead(stream.Context(), false); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	since := req.GetSince()
+	for {
+		// Send everything after since, a page at a time, then wait for new changes
+		for {
+			changes, nextSince, err := s.registry.ListChanges(since, watchReplayPageSize)
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to list changes: %v", err)
+			}
+			for _, change := range changes {
+				event, err := toProtoChange(change)
+				if err != nil {
+					return status.Errorf(codes.Internal, "failed to convert change: %v", err)
+				}
+				if err := stream.Send(event); err != nil {
+					return err
+				}
+			}
+			since = nextSince
+			if len(changes) < watchReplayPageSize {
+				break
+			}
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-s.baseCtx.Done():
+			return status.Error(codes.Unavailable, "server is shutting down")
+		case <-ticker.C:
+		}
+	}
+}
+
+// authorizeRead applies the registry's read access model to a read, returning the context to handle it with.
+// Scoped reads limit their results to the servers the read scope in that context allows.
+func (s *registryServer) authorizeRead(ctx context.Context, scoped bool) (context.Context, error) {
+	ctx, err := v0.AuthorizeRead(ctx, s.jwtManager, s.registry, v0.ReadRequest{Authorization: authorization(ctx), Scoped: scoped})
+	if err != nil {
+		return nil, statusFromHTTPError(err)
+	}
+	return ctx, nil
+}
+
+// authorization returns the authorization metadata of a call, if any
+func authorization(ctx context.Context) string {
+	if values := metadata.ValueFromIncomingContext(ctx, "authorization"); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// statusFromHTTPError converts the HTTP errors returned by shared handler checks into gRPC statuses
+func statusFromHTTPError(err error) error {
+	var statusErr huma.StatusError
+	if !errors.As(err, &statusErr) {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	code := codes.Internal
+	switch statusErr.GetStatus() {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusRequestEntityTooLarge:
+		code = codes.InvalidArgument
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusConflict:
+		code = codes.Aborted
+	case http.StatusServiceUnavailable:
+		code = codes.Unavailable
+	}
+	return status.Error(code, err.Error())
+}
@@ -0,0 +1,136 @@
+package grpcapi_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/grpcapi"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/pkg/api/v0/registrypb"
+)
+
+func newTestClient(t *testing.T, cfg *config.Config) registrypb.RegistryClient {
+	t.Helper()
+
+	server := grpcapi.NewServer(cfg, service.NewRegistryService(database.NewMemoryDB(), cfg))
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = server.Shutdown(ctx)
+	})
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return registrypb.NewRegistryClient(conn)
+}
+
+func TestRegistryService(t *testing.T) {
+	cfg := &config.Config{JWTPrivateKey: "bb2c6b424005acd5df47a9e2c87f446def86dd740c888ea3efb825b23f7ef47c"}
+	client := newTestClient(t, cfg)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	token, err := auth.NewJWTManager(cfg).GenerateTokenResponse(ctx, auth.JWTClaims{
+		AuthMethod:  auth.MethodNone,
+		Permissions: []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"}},
+	})
+	require.NoError(t, err)
+	authCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token.RegistryToken)
+
+	publisherProvided, err := structpb.NewStruct(map[string]any{"tool": "test"})
+	require.NoError(t, err)
+	server := &registrypb.Server{
+		Name:        "com.example/grpc-server",
+		Description: "A server published over gRPC",
+		Version:     "1.0.0",
+		Remotes:     []*registrypb.Transport{{Type: "streamable-http", Url: "https://example.com/mcp"}},
+		Meta:        &registrypb.ServerMeta{PublisherProvided: publisherProvided},
+	}
+
+	t.Run("publish requires authorization", func(t *testing.T) {
+		_, err := client.Publish(ctx, &registrypb.PublishRequest{Server: server})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("publish checks permissions", func(t *testing.T) {
+		other := &registrypb.Server{Name: "org.other/server", Description: "Not ours", Version: "1.0.0"}
+		_, err := client.Publish(authCtx, &registrypb.PublishRequest{Server: other})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	published, err := client.Publish(authCtx, &registrypb.PublishRequest{Server: server})
+	require.NoError(t, err)
+	require.NotNil(t, published.GetMeta().GetOfficial())
+	assert.True(t, published.GetMeta().GetOfficial().GetIsLatest())
+	assert.Equal(t, "test", published.GetMeta().GetPublisherProvided().AsMap()["tool"])
+
+	t.Run("get", func(t *testing.T) {
+		got, err := client.Get(ctx, &registrypb.GetRequest{Id: published.GetMeta().GetOfficial().GetId()})
+		require.NoError(t, err)
+		assert.Equal(t, "com.example/grpc-server", got.GetName())
+		assert.Equal(t, "https://example.com/mcp", got.GetRemotes()[0].GetUrl())
+
+		_, err = client.Get(ctx, &registrypb.GetRequest{Id: "00000000-0000-0000-0000-000000000000"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("list and search", func(t *testing.T) {
+		list, err := client.List(ctx, &registrypb.ListRequest{Version: "latest", Transport: "streamable-http"})
+		require.NoError(t, err)
+		assert.Len(t, list.GetServers(), 1)
+
+		search, err := client.Search(ctx, &registrypb.SearchRequest{Query: "grpc"})
+		require.NoError(t, err)
+		assert.Len(t, search.GetServers(), 1)
+
+		_, err = client.List(ctx, &registrypb.ListRequest{Limit: 1000})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("watch replays and streams changes", func(t *testing.T) {
+		watchCtx, stopWatching := context.WithCancel(ctx)
+		defer stopWatching()
+		stream, err := client.Watch(watchCtx, &registrypb.WatchRequest{})
+		require.NoError(t, err)
+
+		event, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, registrypb.ChangeType_CHANGE_TYPE_PUBLISH, event.GetType())
+		assert.Equal(t, "1.0.0", event.GetServer().GetVersion())
+
+		next := &registrypb.Server{Name: server.GetName(), Description: server.GetDescription(), Version: "1.1.0"}
+		_, err = client.Publish(authCtx, &registrypb.PublishRequest{Server: next})
+		require.NoError(t, err)
+
+		// Publishing a new latest version also updates the previous one
+		versions := map[string]registrypb.ChangeType{}
+		for len(versions) < 2 {
+			event, err := stream.Recv()
+			require.NoError(t, err)
+			versions[event.GetServer().GetVersion()] = event.GetType()
+		}
+		assert.Equal(t, registrypb.ChangeType_CHANGE_TYPE_PUBLISH, versions["1.1.0"])
+		assert.Equal(t, registrypb.ChangeType_CHANGE_TYPE_UPDATE, versions["1.0.0"])
+	})
+}
@@ -0,0 +1,195 @@
+package importer
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Publisher publishes a server version, as the publish endpoint does
+type Publisher interface {
+	Publish(req apiv0.ServerJSON) (*apiv0.ServerJSON, error)
+}
+
+// CatalogReport summarizes a catalog import
+type CatalogReport struct {
+	// Published counts the server versions published by this import
+	Published int
+	// AlreadyPublished counts the server versions that were in the registry already
+	AlreadyPublished int
+	// Resumed counts the server versions skipped because the checkpoint records them as imported
+	Resumed int
+	// Invalid lists the entries that failed validation, with the reason
+	Invalid []string
+	// Failed lists the server versions that couldn't be published, with the reason
+	Failed []string
+}
+
+// catalogEntry is a server.json read from a catalog, with where it was read from
+type catalogEntry struct {
+	location string
+	server   apiv0.ServerJSON
+}
+
+// ImportCatalog validates and publishes every server.json in a catalog, recording each imported server version
+// in the checkpoint so an interrupted import can be run again to resume it. The source is a directory, searched
+// for files named server.json, or a file or URL holding a server.json or a JSON array of them. Invalid entries
+// and failed publishes are reported rather than stopping the import.
+func ImportCatalog(ctx context.Context, source string, publisher Publisher, checkpoint *Checkpoint) (*CatalogReport, error) {
+	entries, err := readCatalog(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CatalogReport{}
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		if err := validators.ValidateServerJSON(&entry.server); err != nil {
+			report.Invalid = append(report.Invalid, fmt.Sprintf("%s: %v", entry.location, err))
+			continue
+		}
+		key := entry.server.Name + "@" + entry.server.Version
+		if checkpoint.Done(key) {
+			report.Resumed++
+			continue
+		}
+
+		_, err := publisher.Publish(entry.server)
+		switch {
+		case errors.Is(err, database.ErrInvalidVersion):
+			report.AlreadyPublished++
+		case err != nil:
+			report.Failed = append(report.Failed, fmt.Sprintf("%s: %v", key, err))
+			continue
+		default:
+			report.Published++
+			log.Printf("Published %s", key)
+		}
+		if err := checkpoint.Mark(key); err != nil {
+			return report, fmt.Errorf("failed to update checkpoint: %w", err)
+		}
+	}
+	return report, nil
+}
+
+// readCatalog reads the server.json files of a catalog
+func readCatalog(ctx context.Context, source string) ([]catalogEntry, error) {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		data, err := fetchFromHTTP(ctx, source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", source, err)
+		}
+		return parseCatalogFile(source, data)
+	}
+
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return nil, err
+		}
+		return parseCatalogFile(source, data)
+	}
+
+	var entries []catalogEntry
+	err = filepath.WalkDir(source, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || d.Name() != "server.json" {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		fileEntries, err := parseCatalogFile(path, data)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, fileEntries...)
+		return nil
+	})
+	return entries, err
+}
+
+// parseCatalogFile parses a file holding a server.json or a JSON array of them
+func parseCatalogFile(location string, data []byte) ([]catalogEntry, error) {
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "[") {
+		var servers []apiv0.ServerJSON
+		if err := json.Unmarshal(data, &servers); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", location, err)
+		}
+		entries := make([]catalogEntry, len(servers))
+		for i, server := range servers {
+			entries[i] = catalogEntry{location: fmt.Sprintf("%s[%d]", location, i), server: server}
+		}
+		return entries, nil
+	}
+
+	var server apiv0.ServerJSON
+	if err := json.Unmarshal(data, &server); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", location, err)
+	}
+	return []catalogEntry{{location: location, server: server}}, nil
+}
+
+// Checkpoint records the server versions a catalog import has finished with, one name@version per line of a file
+type Checkpoint struct {
+	path string
+	done map[string]bool
+}
+
+// LoadCheckpoint reads the checkpoint kept in path, which doesn't have to exist yet
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	checkpoint := &Checkpoint{path: path, done: map[string]bool{}}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return checkpoint, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if key := strings.TrimSpace(scanner.Text()); key != "" {
+			checkpoint.done[key] = true
+		}
+	}
+	return checkpoint, scanner.Err()
+}
+
+// Done reports whether the checkpoint records key as imported
+func (c *Checkpoint) Done(key string) bool {
+	return c.done[key]
+}
+
+// Mark records key as imported, appending it to the checkpoint file
+func (c *Checkpoint) Mark(key string) error {
+	file, err := os.OpenFile(c.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(key + "\n"); err != nil {
+		_ = file.Close()
+		return err
+	}
+	c.done[key] = true
+	return file.Close()
+}
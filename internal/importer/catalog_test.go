@@ -0,0 +1,107 @@
+package importer_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/importer"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// flakyPublisher fails to publish the servers named in failing
+type flakyPublisher struct {
+	importer.Publisher
+	failing map[string]bool
+}
+
+func (p *flakyPublisher) Publish(req apiv0.ServerJSON) (*apiv0.ServerJSON, error) {
+	if p.failing[req.Name] {
+		return nil, errors.New("connection reset")
+	}
+	return p.Publisher.Publish(req)
+}
+
+func writeServerJSON(t *testing.T, path string, server apiv0.ServerJSON) {
+	t.Helper()
+	data, err := json.Marshal(server)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	require.NoError(t, os.WriteFile(path, data, 0600))
+}
+
+func TestImportCatalog_Resumes(t *testing.T) {
+	catalog := t.TempDir()
+	writeServerJSON(t, filepath.Join(catalog, "alpha", "server.json"), apiv0.ServerJSON{
+		Name: "io.github.example/alpha", Description: "Alpha", Version: "1.0.0",
+	})
+	writeServerJSON(t, filepath.Join(catalog, "beta", "server.json"), apiv0.ServerJSON{
+		Name: "io.github.example/beta", Description: "Beta", Version: "2.0.0",
+	})
+	writeServerJSON(t, filepath.Join(catalog, "broken", "server.json"), apiv0.ServerJSON{
+		Name: "not-namespaced", Description: "Broken", Version: "1.0.0",
+	})
+	writeServerJSON(t, filepath.Join(catalog, "alpha", "package.json"), apiv0.ServerJSON{})
+
+	db := database.NewMemoryDB()
+	registry := service.NewRegistryService(db, &config.Config{EnableRegistryValidation: false})
+	checkpointPath := filepath.Join(t.TempDir(), "import.checkpoint")
+
+	// The first run is interrupted publishing beta
+	checkpoint, err := importer.LoadCheckpoint(checkpointPath)
+	require.NoError(t, err)
+	publisher := &flakyPublisher{Publisher: registry, failing: map[string]bool{"io.github.example/beta": true}}
+	report, err := importer.ImportCatalog(t.Context(), catalog, publisher, checkpoint)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Published)
+	assert.Len(t, report.Invalid, 1, "files other than server.json are ignored")
+	assert.Contains(t, report.Invalid[0], filepath.Join("broken", "server.json"))
+	require.Len(t, report.Failed, 1)
+	assert.Contains(t, report.Failed[0], "io.github.example/beta@2.0.0")
+
+	// The second run skips what the first imported
+	checkpoint, err = importer.LoadCheckpoint(checkpointPath)
+	require.NoError(t, err)
+	report, err = importer.ImportCatalog(t.Context(), catalog, registry, checkpoint)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Published)
+	assert.Equal(t, 1, report.Resumed)
+	assert.Empty(t, report.Failed)
+
+	servers, _, err := db.List(t.Context(), nil, "", 10)
+	require.NoError(t, err)
+	assert.Len(t, servers, 2)
+
+	// Without the checkpoint, versions already in the registry are counted rather than failing
+	checkpoint, err = importer.LoadCheckpoint(filepath.Join(t.TempDir(), "fresh.checkpoint"))
+	require.NoError(t, err)
+	report, err = importer.ImportCatalog(t.Context(), catalog, registry, checkpoint)
+	require.NoError(t, err)
+	assert.Equal(t, 0, report.Published)
+	assert.Equal(t, 2, report.AlreadyPublished)
+}
+
+func TestImportCatalog_ArrayFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "servers.json")
+	data, err := json.Marshal([]apiv0.ServerJSON{
+		{Name: "io.github.example/alpha", Description: "Alpha", Version: "1.0.0"},
+		{Name: "io.github.example/alpha", Description: "Alpha", Version: "1.1.0"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0600))
+
+	registry := service.NewRegistryService(database.NewMemoryDB(), &config.Config{EnableRegistryValidation: false})
+	checkpoint, err := importer.LoadCheckpoint(filepath.Join(t.TempDir(), "import.checkpoint"))
+	require.NoError(t, err)
+	report, err := importer.ImportCatalog(t.Context(), path, registry, checkpoint)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Published)
+}
@@ -62,6 +62,37 @@ func TestImportService_LocalFile(t *testing.T) {
 	assert.Equal(t, "io.github.test/test-server-1", servers[0].Name)
 }
 
+func TestImportService_LegacyFile(t *testing.T) {
+	// Dumps from before server.json declared a schema version are converted to the current version
+	tempFile := t.TempDir() + "/seed.json"
+	seedData := `[{
+		"name": "io.github.test/legacy-server",
+		"description": "Legacy server",
+		"version_detail": {"version": "0.9.0"},
+		"_meta": {
+			"io.modelcontextprotocol.registry": {
+				"id": "legacy-id",
+				"published_at": "2025-06-01T00:00:00Z",
+				"updated_at": "2025-06-01T00:00:00Z",
+				"is_latest": true
+			}
+		}
+	}]`
+	require.NoError(t, os.WriteFile(tempFile, []byte(seedData), 0600))
+
+	memDB := database.NewMemoryDB()
+	service := importer.NewService(memDB)
+	require.NoError(t, service.ImportFromPath(context.Background(), tempFile))
+
+	server, err := memDB.GetByID(context.Background(), "legacy-id")
+	require.NoError(t, err)
+	assert.Equal(t, "0.9.0", server.Version)
+	assert.Equal(t, apiv0.SchemaURL(model.CurrentSchemaVersion), server.Schema)
+	assert.True(t, server.Meta.Official.IsLatest)
+	assert.Empty(t, server.UnknownFields)
+	assert.Empty(t, server.Meta.UnknownFields)
+}
+
 func TestImportService_HTTPFile(t *testing.T) {
 	// Create a test HTTP server
 	seedData := []apiv0.ServerJSON{
@@ -0,0 +1,335 @@
+// Package mirror keeps a read-only copy of an upstream registry in sync,
+// tagging every mirrored record with where it came from.
+package mirror
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const (
+	serversPageSize = 100
+	// maxSnapshotSize bounds how much of an upstream export is read into memory
+	maxSnapshotSize = 512 << 20
+)
+
+var (
+	// ErrInvalidSnapshotSignature is returned when an upstream export doesn't match its signature
+	ErrInvalidSnapshotSignature = errors.New("upstream snapshot signature is invalid")
+	// ErrExportUnavailable is returned when the upstream registry doesn't serve /v0/export
+	ErrExportUnavailable = errors.New("upstream registry does not support export")
+)
+
+// SyncResult summarizes a single sync pass
+type SyncResult struct {
+	Created   int
+	Updated   int
+	Unchanged int
+	Conflicts int
+}
+
+// Syncer pulls server records from an upstream registry into the local database
+type Syncer struct {
+	db        database.Database
+	upstream  string
+	publicKey ed25519.PublicKey
+	client    *http.Client
+	now       func() time.Time
+
+	// lastUpdatedAt is the newest upstream updated_at seen so far; zero until the first sync succeeds
+	lastUpdatedAt time.Time
+}
+
+// NewSyncer creates a syncer for the upstream registry at upstreamURL. When publicKey
+// (base64 Ed25519, as served by /v0/export/public-key) is set, snapshots must be signed with it.
+func NewSyncer(db database.Database, upstreamURL, publicKey string) (*Syncer, error) {
+	parsed, err := url.Parse(upstreamURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid mirror upstream URL: %s", upstreamURL)
+	}
+
+	s := &Syncer{
+		db:       db,
+		upstream: strings.TrimSuffix(upstreamURL, "/"),
+		client:   &http.Client{Timeout: 2 * time.Minute},
+		now:      time.Now,
+	}
+
+	if publicKey != "" {
+		key, err := base64.StdEncoding.DecodeString(publicKey)
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("mirror upstream public key must be a base64-encoded %d byte Ed25519 key", ed25519.PublicKeySize)
+		}
+		s.publicKey = ed25519.PublicKey(key)
+	}
+
+	return s, nil
+}
+
+// Run syncs immediately and then every interval until ctx is cancelled
+func (s *Syncer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := s.SyncOnce(ctx)
+		if err != nil {
+			log.Printf("Mirror sync from %s failed: %v", s.upstream, err)
+		} else {
+			log.Printf("Mirror sync from %s: %d created, %d updated, %d unchanged, %d conflicts",
+				s.upstream, result.Created, result.Updated, result.Unchanged, result.Conflicts)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SyncOnce pulls everything changed upstream since the last successful sync. The first
+// sync bootstraps from the upstream export, falling back to paging through /v0/servers.
+func (s *Syncer) SyncOnce(ctx context.Context) (SyncResult, error) {
+	var (
+		servers []apiv0.ServerJSON
+		err     error
+	)
+	if s.lastUpdatedAt.IsZero() {
+		servers, err = s.fetchSnapshot(ctx)
+		if errors.Is(err, ErrExportUnavailable) {
+			servers, err = s.fetchServers(ctx, nil)
+		}
+	} else {
+		since := s.lastUpdatedAt
+		servers, err = s.fetchServers(ctx, &since)
+	}
+	if err != nil {
+		return SyncResult{}, err
+	}
+
+	var result SyncResult
+	newest := s.lastUpdatedAt
+	for i := range servers {
+		server := &servers[i]
+		if server.Meta == nil || server.Meta.Official == nil || server.Meta.Official.ID == "" {
+			log.Printf("Mirror sync: skipping %s %s without registry metadata", server.Name, server.Version)
+			continue
+		}
+
+		if err := s.apply(ctx, server, &result); err != nil {
+			return result, fmt.Errorf("failed to mirror %s %s: %w", server.Name, server.Version, err)
+		}
+
+		if server.Meta.Official.UpdatedAt.After(newest) {
+			newest = server.Meta.Official.UpdatedAt
+		}
+	}
+
+	// Only advance once every record is stored, so a failed pass is retried in full
+	s.lastUpdatedAt = newest
+	return result, nil
+}
+
+// apply stores a single upstream record, refusing to overwrite records that didn't come from this upstream
+func (s *Syncer) apply(ctx context.Context, server *apiv0.ServerJSON, result *SyncResult) error {
+	id := server.Meta.Official.ID
+
+	existing, err := s.db.GetByID(ctx, id)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return err
+	}
+
+	if existing != nil {
+		if !s.isMirrored(existing) {
+			log.Printf("Mirror sync conflict: %s (%s) exists locally but was not mirrored from %s", server.Name, id, s.upstream)
+			result.Conflicts++
+			return nil
+		}
+		if !server.Meta.Official.UpdatedAt.After(existing.Meta.Official.UpdatedAt) {
+			result.Unchanged++
+			return nil
+		}
+		if _, err := s.db.UpdateServer(ctx, id, s.tag(server), 0); err != nil {
+			return err
+		}
+		result.Updated++
+		return nil
+	}
+
+	// A different record for the same name and version means the two registries have diverged
+	conflictID, err := s.findVersionConflict(ctx, server)
+	if err != nil {
+		return err
+	}
+	if conflictID != "" {
+		log.Printf("Mirror sync conflict: %s %s already exists locally as %s", server.Name, server.Version, conflictID)
+		result.Conflicts++
+		return nil
+	}
+
+	if _, err := s.db.CreateServer(ctx, s.tag(server)); err != nil {
+		return err
+	}
+	result.Created++
+	return nil
+}
+
+// findVersionConflict returns the ID of a local record with the same name and version, if any
+func (s *Syncer) findVersionConflict(ctx context.Context, server *apiv0.ServerJSON) (string, error) {
+	filter := &database.ServerFilter{Name: &server.Name, Version: &server.Version}
+	existing, _, err := s.db.List(ctx, filter, "", 1)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return "", err
+	}
+	if len(existing) > 0 {
+		return existing[0].GetID(), nil
+	}
+	return "", nil
+}
+
+// isMirrored reports whether a local record was previously synced from this syncer's upstream
+func (s *Syncer) isMirrored(server *apiv0.ServerJSON) bool {
+	return server.Meta != nil && server.Meta.Official != nil &&
+		server.Meta.Mirror != nil && server.Meta.Mirror.Source == s.upstream
+}
+
+// tag records the upstream provenance on a copy of server, keeping the upstream registry metadata intact
+func (s *Syncer) tag(server *apiv0.ServerJSON) *apiv0.ServerJSON {
+	tagged := *server
+	meta := *server.Meta
+	// Health, usage statistics, icons and advisories are attached by each registry when serving a record
+	meta.Health = nil
+	meta.Stats = nil
+	meta.Icon = nil
+	meta.Advisories = nil
+	meta.Mirror = &apiv0.MirrorExtensions{
+		Source:   s.upstream,
+		SyncedAt: s.now(),
+	}
+	tagged.Meta = &meta
+	return &tagged
+}
+
+// fetchSnapshot downloads the upstream export and verifies its signature when a public key is configured
+func (s *Syncer) fetchSnapshot(ctx context.Context) ([]apiv0.ServerJSON, error) {
+	resp, err := s.get(ctx, s.upstream+"/v0/export")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrExportUnavailable
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("upstream export failed with status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSnapshotSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upstream export: %w", err)
+	}
+
+	if s.publicKey != nil {
+		signature, err := base64.StdEncoding.DecodeString(resp.Header.Get("X-Registry-Signature"))
+		if err != nil || !ed25519.Verify(s.publicKey, body, signature) {
+			return nil, ErrInvalidSnapshotSignature
+		}
+	}
+
+	var servers []apiv0.ServerJSON
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSnapshotSize)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var server apiv0.ServerJSON
+		if err := json.Unmarshal(line, &server); err != nil {
+			return nil, fmt.Errorf("failed to parse upstream export: %w", err)
+		}
+		servers = append(servers, server)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read upstream export: %w", err)
+	}
+
+	return servers, nil
+}
+
+// fetchServers pages through the upstream server list, optionally limited to servers updated since a time
+func (s *Syncer) fetchServers(ctx context.Context, updatedSince *time.Time) ([]apiv0.ServerJSON, error) {
+	var servers []apiv0.ServerJSON
+	cursor := ""
+
+	for {
+		query := url.Values{}
+		query.Set("limit", fmt.Sprint(serversPageSize))
+		if updatedSince != nil {
+			query.Set("updated_since", updatedSince.UTC().Format(time.RFC3339Nano))
+		}
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+
+		page, err := s.fetchServersPage(ctx, s.upstream+"/v0/servers?"+query.Encode())
+		if err != nil {
+			return nil, err
+		}
+		servers = append(servers, page.Servers...)
+
+		if page.Metadata.NextCursor == "" || len(page.Servers) == 0 {
+			return servers, nil
+		}
+		cursor = page.Metadata.NextCursor
+	}
+}
+
+func (s *Syncer) fetchServersPage(ctx context.Context, pageURL string) (*apiv0.ServerListResponse, error) {
+	resp, err := s.get(ctx, pageURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("upstream server list failed with status %d", resp.StatusCode)
+	}
+
+	var page apiv0.ServerListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to parse upstream server list: %w", err)
+	}
+	return &page, nil
+}
+
+func (s *Syncer) get(ctx context.Context, rawURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "MCP-Registry-Mirror/1.0")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch from upstream: %w", err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,155 @@
+package mirror_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/mirror"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const testSigningSeed = "bb2c6b424005acd5df47a9e2c87f446def86dd740c888ea3efb825b23f7ef47c"
+
+// newUpstream serves the read endpoints of a registry backed by an in-memory database
+func newUpstream(t *testing.T) (service.RegistryService, *httptest.Server) {
+	t.Helper()
+
+	cfg := &config.Config{EnableRegistryValidation: false, ExportSigningKey: testSigningSeed}
+	registryService := service.NewRegistryService(database.NewMemoryDB(), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Upstream API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, registryService)
+	v0.RegisterExportEndpoints(api, registryService, cfg)
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return registryService, server
+}
+
+func testPublicKey(t *testing.T) string {
+	t.Helper()
+	seed, err := hex.DecodeString(testSigningSeed)
+	require.NoError(t, err)
+	publicKey, ok := ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)
+	require.True(t, ok)
+	return base64.StdEncoding.EncodeToString(publicKey)
+}
+
+func publish(t *testing.T, registryService service.RegistryService, name, version string) *apiv0.ServerJSON {
+	t.Helper()
+	server, err := registryService.Publish(apiv0.ServerJSON{
+		Name:        name,
+		Description: "A mirrored server",
+		Version:     version,
+	})
+	require.NoError(t, err)
+	return server
+}
+
+func TestSyncer(t *testing.T) {
+	upstreamService, upstream := newUpstream(t)
+	first := publish(t, upstreamService, "com.example/mirrored", "1.0.0")
+
+	localDB := database.NewMemoryDB()
+	syncer, err := mirror.NewSyncer(localDB, upstream.URL+"/", testPublicKey(t))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("bootstraps from signed export", func(t *testing.T) {
+		result, err := syncer.SyncOnce(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, mirror.SyncResult{Created: 1}, result)
+
+		mirrored, err := localDB.GetByID(ctx, first.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, first.Meta.Official.PublishedAt.UTC(), mirrored.Meta.Official.PublishedAt.UTC())
+		require.NotNil(t, mirrored.Meta.Mirror)
+		assert.Equal(t, upstream.URL, mirrored.Meta.Mirror.Source)
+	})
+
+	t.Run("pulls incremental changes", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond) // Ensure the new version's updated_at is strictly later
+		second := publish(t, upstreamService, "com.example/mirrored", "1.1.0")
+
+		result, err := syncer.SyncOnce(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Created)
+		assert.Equal(t, 1, result.Updated, "previous latest version is updated upstream")
+
+		mirrored, err := localDB.GetByID(ctx, second.GetID())
+		require.NoError(t, err)
+		assert.True(t, mirrored.Meta.Official.IsLatest)
+
+		previous, err := localDB.GetByID(ctx, first.GetID())
+		require.NoError(t, err)
+		assert.False(t, previous.Meta.Official.IsLatest)
+	})
+
+	t.Run("detects conflicting local records", func(t *testing.T) {
+		_, err := localDB.CreateServer(ctx, &apiv0.ServerJSON{
+			Name:        "com.example/diverged",
+			Description: "A local server",
+			Version:     "1.0.0",
+			Meta: &apiv0.ServerMeta{Official: &apiv0.RegistryExtensions{
+				ID:          "11111111-1111-1111-1111-111111111111",
+				PublishedAt: time.Now(),
+				UpdatedAt:   time.Now(),
+				IsLatest:    true,
+			}},
+		})
+		require.NoError(t, err)
+
+		time.Sleep(10 * time.Millisecond)
+		upstreamRecord := publish(t, upstreamService, "com.example/diverged", "1.0.0")
+
+		result, err := syncer.SyncOnce(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Conflicts)
+
+		_, err = localDB.GetByID(ctx, upstreamRecord.GetID())
+		assert.ErrorIs(t, err, database.ErrNotFound)
+	})
+}
+
+func TestSyncer_RejectsUntrustedSnapshot(t *testing.T) {
+	upstreamService, upstream := newUpstream(t)
+	publish(t, upstreamService, "com.example/mirrored", "1.0.0")
+
+	otherKey, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	localDB := database.NewMemoryDB()
+	syncer, err := mirror.NewSyncer(localDB, upstream.URL, base64.StdEncoding.EncodeToString(otherKey))
+	require.NoError(t, err)
+
+	_, err = syncer.SyncOnce(context.Background())
+	require.ErrorIs(t, err, mirror.ErrInvalidSnapshotSignature)
+
+	servers, _, err := localDB.List(context.Background(), nil, "", 10)
+	require.NoError(t, err)
+	assert.Empty(t, servers)
+}
+
+func TestNewSyncer_InvalidConfig(t *testing.T) {
+	_, err := mirror.NewSyncer(database.NewMemoryDB(), "ftp://example.com", "")
+	require.Error(t, err)
+
+	_, err = mirror.NewSyncer(database.NewMemoryDB(), "https://example.com", "not-a-key")
+	require.Error(t, err)
+}
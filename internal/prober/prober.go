@@ -0,0 +1,197 @@
+// Package prober periodically checks that the remote endpoints of published servers respond,
+// so the API can report when each was last seen healthy.
+package prober
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+const (
+	serversPageSize = 500
+	// probeConcurrency bounds how many remotes are probed at once
+	probeConcurrency = 8
+	// maxProbeBodySize bounds how much of a probe response is read before the connection is closed
+	maxProbeBodySize = 64 << 10
+)
+
+// initializeRequest is the MCP handshake sent to streamable HTTP remotes
+var initializeRequest = []byte(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"mcp-registry-prober","version":"1.0.0"}}}`)
+
+// Prober probes the remotes of the latest version of every server
+type Prober struct {
+	db     database.Database
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewProber creates a prober that stores results in db. The client should refuse to connect to
+// non-public addresses, since remote URLs are supplied by publishers (see validators.NewPublicHTTPClient).
+func NewProber(db database.Database, client *http.Client) *Prober {
+	return &Prober{db: db, client: client, now: time.Now}
+}
+
+// Run probes every remote, then again every interval until ctx is cancelled
+func (p *Prober) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		healthy, total, err := p.ProbeOnce(ctx)
+		if err != nil {
+			log.Printf("Remote probing failed: %v", err)
+		} else {
+			log.Printf("Probed %d remotes, %d healthy", total, healthy)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProbeOnce probes the remotes of the latest version of every non-deleted server, returning how many were
+// healthy out of how many were probed
+func (p *Prober) ProbeOnce(ctx context.Context) (int, int, error) {
+	remotes, err := p.listRemotes(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		healthy int
+		saveErr error
+		work    = make(chan model.Transport)
+	)
+	for range probeConcurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for remote := range work {
+				result := p.probe(ctx, remote)
+				err := p.db.SaveRemoteHealth(ctx, result)
+
+				mu.Lock()
+				if result.LastError == "" {
+					healthy++
+				}
+				if err != nil && saveErr == nil {
+					saveErr = fmt.Errorf("failed to save health of %s: %w", remote.URL, err)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, remote := range remotes {
+		select {
+		case work <- remote:
+		case <-ctx.Done():
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return healthy, len(remotes), ctx.Err()
+	}
+	return healthy, len(remotes), saveErr
+}
+
+// listRemotes returns the distinct remotes of the latest version of every non-deleted server
+func (p *Prober) listRemotes(ctx context.Context) ([]model.Transport, error) {
+	isLatest := true
+	filter := &database.ServerFilter{IsLatest: &isLatest}
+
+	var remotes []model.Transport
+	seen := make(map[string]bool)
+	cursor := ""
+	for {
+		servers, nextCursor, err := p.db.List(ctx, filter, cursor, serversPageSize)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return nil, fmt.Errorf("failed to list servers: %w", err)
+		}
+		for _, server := range servers {
+			if server.Status == model.StatusDeleted {
+				continue
+			}
+			for _, remote := range server.Remotes {
+				if remote.URL != "" && !seen[remote.URL] {
+					seen[remote.URL] = true
+					remotes = append(remotes, remote)
+				}
+			}
+		}
+		if nextCursor == "" || len(servers) == 0 {
+			return remotes, nil
+		}
+		cursor = nextCursor
+	}
+}
+
+// probe checks a single remote. Streamable HTTP remotes are sent an MCP initialize request and SSE remotes
+// are opened as an event stream. A 2xx response counts as healthy, as do 401 and 403, which show a server
+// is up behind authentication.
+func (p *Prober) probe(ctx context.Context, remote model.Transport) *database.RemoteHealth {
+	now := p.now()
+	result := &database.RemoteHealth{URL: remote.URL, LastCheckedAt: now}
+
+	if err := p.check(ctx, remote); err != nil {
+		result.LastError = err.Error()
+		return result
+	}
+	result.LastSeenHealthy = &now
+	return result
+}
+
+func (p *Prober) check(ctx context.Context, remote model.Transport) error {
+	var req *http.Request
+	var err error
+	if remote.Type == model.TransportTypeSSE {
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, remote.URL, nil)
+		if err == nil {
+			req.Header.Set("Accept", "text/event-stream")
+		}
+	} else {
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, remote.URL, bytes.NewReader(initializeRequest))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Accept", "application/json, text/event-stream")
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("invalid remote URL: %w", err)
+	}
+	req.Header.Set("User-Agent", "MCP-Registry-Prober/1.0")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	// Event streams stay open, so only read enough to let the connection close cleanly
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxProbeBodySize))
+	_ = resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300,
+		resp.StatusCode == http.StatusUnauthorized,
+		resp.StatusCode == http.StatusForbidden:
+		return nil
+	default:
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+}
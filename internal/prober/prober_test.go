@@ -0,0 +1,136 @@
+package prober_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/prober"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func createServer(t *testing.T, db database.Database, name string, isLatest bool, remotes ...model.Transport) *apiv0.ServerJSON {
+	t.Helper()
+	server, err := db.CreateServer(context.Background(), &apiv0.ServerJSON{
+		Name:        name,
+		Description: "A remote server",
+		Version:     "1.0.0",
+		Remotes:     remotes,
+		Meta: &apiv0.ServerMeta{
+			Official: &apiv0.RegistryExtensions{
+				ID:          uuid.New().String(),
+				PublishedAt: time.Now(),
+				UpdatedAt:   time.Now(),
+				IsLatest:    isLatest,
+			},
+		},
+	})
+	require.NoError(t, err)
+	return server
+}
+
+func TestProbeOnce(t *testing.T) {
+	var initializeRequests int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mcp", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Contains(t, r.Header.Get("Accept"), "text/event-stream")
+		initializeRequests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{}}`))
+	})
+	mux.HandleFunc("/sse", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("event: endpoint\ndata: /messages\n\n"))
+	})
+	mux.HandleFunc("/private", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	mux.HandleFunc("/broken", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	upstream := httptest.NewServer(mux)
+	defer upstream.Close()
+
+	db := database.NewMemoryDB()
+	createServer(t, db, "com.example/streamable", true, model.Transport{Type: model.TransportTypeStreamableHTTP, URL: upstream.URL + "/mcp"})
+	createServer(t, db, "com.example/sse", true, model.Transport{Type: model.TransportTypeSSE, URL: upstream.URL + "/sse"})
+	createServer(t, db, "com.example/private", true, model.Transport{Type: model.TransportTypeStreamableHTTP, URL: upstream.URL + "/private"})
+	createServer(t, db, "com.example/broken", true, model.Transport{Type: model.TransportTypeStreamableHTTP, URL: upstream.URL + "/broken"})
+	// Earlier versions and servers sharing a remote are probed once
+	createServer(t, db, "com.example/old", false, model.Transport{Type: model.TransportTypeStreamableHTTP, URL: upstream.URL + "/old"})
+	createServer(t, db, "com.example/alias", true, model.Transport{Type: model.TransportTypeStreamableHTTP, URL: upstream.URL + "/mcp"})
+
+	p := prober.NewProber(db, upstream.Client())
+	healthy, total, err := p.ProbeOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 4, total)
+	assert.Equal(t, 3, healthy)
+	assert.Equal(t, 1, initializeRequests)
+
+	health, err := db.GetRemoteHealth(context.Background(), []string{
+		upstream.URL + "/mcp", upstream.URL + "/sse", upstream.URL + "/private", upstream.URL + "/broken", upstream.URL + "/old",
+	})
+	require.NoError(t, err)
+	require.Len(t, health, 4)
+	assert.NotNil(t, health[upstream.URL+"/mcp"].LastSeenHealthy)
+	assert.NotNil(t, health[upstream.URL+"/sse"].LastSeenHealthy)
+	assert.NotNil(t, health[upstream.URL+"/private"].LastSeenHealthy)
+	assert.Nil(t, health[upstream.URL+"/broken"].LastSeenHealthy)
+	assert.Contains(t, health[upstream.URL+"/broken"].LastError, "502")
+}
+
+func TestProbeOnceKeepsLastSeenHealthy(t *testing.T) {
+	up := true
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if !up {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer upstream.Close()
+
+	db := database.NewMemoryDB()
+	server := createServer(t, db, "com.example/flaky", true, model.Transport{Type: model.TransportTypeStreamableHTTP, URL: upstream.URL})
+
+	p := prober.NewProber(db, upstream.Client())
+	_, _, err := p.ProbeOnce(context.Background())
+	require.NoError(t, err)
+
+	up = false
+	healthy, _, err := p.ProbeOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, healthy)
+
+	// Probe results are reported on servers once probing is enabled
+	registryService := service.NewRegistryService(db, &config.Config{RemoteProbeEnabled: true})
+	got, err := registryService.GetByID(server.Meta.Official.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.Meta.Health)
+	require.Len(t, got.Meta.Health.Remotes, 1)
+	remote := got.Meta.Health.Remotes[0]
+	assert.False(t, remote.Healthy)
+	assert.NotNil(t, remote.LastSeenHealthy)
+	assert.True(t, remote.LastSeenHealthy.Before(remote.LastCheckedAt) || remote.LastSeenHealthy.Equal(remote.LastCheckedAt))
+	assert.Contains(t, remote.LastError, "503")
+
+	servers, _, err := registryService.List(nil, "", 10)
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	assert.NotNil(t, servers[0].Meta.Health)
+
+	// The stored record isn't modified
+	stored, err := db.GetByID(context.Background(), server.Meta.Official.ID)
+	require.NoError(t, err)
+	assert.Nil(t, stored.Meta.Health)
+}
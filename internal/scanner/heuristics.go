@@ -0,0 +1,158 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// heuristicRule flags argument and environment variable values matching a pattern
+type heuristicRule struct {
+	name    string
+	pattern *regexp.Regexp
+	message string
+}
+
+var heuristicRules = []heuristicRule{
+	{
+		name:    "download-and-execute",
+		pattern: regexp.MustCompile(`(?i)\b(curl|wget)\b[^|;&]*\|\s*(sudo\s+)?(ba|z|da|k)?sh\b`),
+		message: "pipes a downloaded script into a shell",
+	},
+	{
+		name:    "encoded-payload",
+		pattern: regexp.MustCompile(`(?i)\bbase64\s+(-d|--decode)\b|\bpowershell\b.*\s-(e|enc|encodedcommand)\s`),
+		message: "decodes and runs an encoded payload",
+	},
+	{
+		name:    "secret-exfiltration",
+		pattern: regexp.MustCompile(`(?i)https?://\S*(\$\{?|\{)[A-Z0-9_]*(TOKEN|SECRET|PASSWORD|PASSWD|CREDENTIAL|API_?KEY|PRIVATE_?KEY)[A-Z0-9_]*\}?`),
+		message: "sends a secret-looking variable to a URL",
+	},
+	{
+		name:    "sensitive-file",
+		pattern: regexp.MustCompile(`(~|\$HOME|\$\{HOME\})/\.(ssh|aws|gnupg|kube|docker|netrc)\b|/etc/shadow\b|\bid_(rsa|ed25519|ecdsa)\b|\.npmrc\b|\.pypirc\b`),
+		message: "references a credentials file",
+	},
+}
+
+// HeuristicScanner flags package arguments and environment variables that match patterns commonly
+// used to compromise the machines servers are installed on
+type HeuristicScanner struct{}
+
+// NewHeuristicScanner creates a scanner using the built-in heuristics
+func NewHeuristicScanner() *HeuristicScanner {
+	return &HeuristicScanner{}
+}
+
+func (s *HeuristicScanner) Name() string {
+	return "heuristics"
+}
+
+func (s *HeuristicScanner) Scan(_ context.Context, server *apiv0.ServerJSON) ([]Finding, error) {
+	var findings []Finding
+	check := func(location, value string) {
+		if value == "" {
+			return
+		}
+		for _, rule := range heuristicRules {
+			if rule.pattern.MatchString(value) {
+				findings = append(findings, Finding{Scanner: s.Name(), Rule: rule.name, Location: location, Message: rule.message})
+			}
+		}
+	}
+	checkInput := func(location string, input model.InputWithVariables) {
+		check(location, input.Value)
+		check(location, input.Default)
+		for name, variable := range input.Variables {
+			check(location+".variables."+name, variable.Value)
+			check(location+".variables."+name, variable.Default)
+		}
+	}
+
+	for i, pkg := range server.Packages {
+		prefix := fmt.Sprintf("packages[%d]", i)
+		for j, arg := range pkg.RuntimeArguments {
+			location := fmt.Sprintf("%s.runtime_arguments[%d]", prefix, j)
+			check(location, arg.Name)
+			checkInput(location, arg.InputWithVariables)
+		}
+		for j, arg := range pkg.PackageArguments {
+			location := fmt.Sprintf("%s.package_arguments[%d]", prefix, j)
+			check(location, arg.Name)
+			checkInput(location, arg.InputWithVariables)
+		}
+		for j, env := range pkg.EnvironmentVariables {
+			checkInput(fmt.Sprintf("%s.environment_variables[%d]", prefix, j), env.InputWithVariables)
+		}
+	}
+	return findings, nil
+}
+
+// PackageBlocklistScanner flags packages with known-bad identifiers
+type PackageBlocklistScanner struct {
+	blocked []blockedPackage
+}
+
+type blockedPackage struct {
+	registryType string // empty to match any registry
+	identifier   string
+}
+
+// NewPackageBlocklistScanner creates a scanner flagging the given packages, each either an identifier
+// blocked in every registry or "<registry type>:<identifier>" (e.g. "npm:event-stream"). Identifiers
+// are compared case-insensitively.
+func NewPackageBlocklistScanner(entries []string) *PackageBlocklistScanner {
+	s := &PackageBlocklistScanner{}
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		var blocked blockedPackage
+		// OCI identifiers may contain a colon for a registry port, so only split on known registry types
+		if registryType, identifier, ok := strings.Cut(entry, ":"); ok && isRegistryType(registryType) {
+			blocked = blockedPackage{registryType: registryType, identifier: identifier}
+		} else {
+			blocked = blockedPackage{identifier: entry}
+		}
+		s.blocked = append(s.blocked, blocked)
+	}
+	return s
+}
+
+func isRegistryType(s string) bool {
+	switch s {
+	case model.RegistryTypeNPM, model.RegistryTypePyPI, model.RegistryTypeOCI, model.RegistryTypeNuGet, model.RegistryTypeMCPB, model.RegistryTypeMaven, model.RegistryTypeGem, model.RegistryTypeVSIX:
+		return true
+	}
+	return false
+}
+
+func (s *PackageBlocklistScanner) Name() string {
+	return "package-blocklist"
+}
+
+func (s *PackageBlocklistScanner) Scan(_ context.Context, server *apiv0.ServerJSON) ([]Finding, error) {
+	var findings []Finding
+	for i, pkg := range server.Packages {
+		for _, blocked := range s.blocked {
+			if blocked.registryType != "" && blocked.registryType != pkg.RegistryType {
+				continue
+			}
+			if strings.EqualFold(blocked.identifier, pkg.Identifier) {
+				findings = append(findings, Finding{
+					Scanner:  s.Name(),
+					Rule:     "known-bad-package",
+					Location: fmt.Sprintf("packages[%d]", i),
+					Message:  fmt.Sprintf("%s package %s is known to be malicious", pkg.RegistryType, pkg.Identifier),
+				})
+			}
+		}
+	}
+	return findings, nil
+}
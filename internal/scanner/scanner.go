@@ -0,0 +1,68 @@
+// Package scanner inspects servers at publish time for signs of malicious intent, such as arguments that
+// download and run scripts or ship secrets to remote hosts, using built-in heuristics and optional external scanners.
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Finding is a suspicious pattern a scanner found in a server
+type Finding struct {
+	Scanner  string `json:"scanner"`
+	Rule     string `json:"rule"`
+	Location string `json:"location,omitempty"` // where in server.json, e.g. packages[0].runtime_arguments[1]
+	Message  string `json:"message"`
+}
+
+func (f Finding) String() string {
+	if f.Location == "" {
+		return fmt.Sprintf("%s/%s: %s", f.Scanner, f.Rule, f.Message)
+	}
+	return fmt.Sprintf("%s/%s at %s: %s", f.Scanner, f.Rule, f.Location, f.Message)
+}
+
+// Scanner checks a server about to be published
+type Scanner interface {
+	// Name identifies the scanner in findings and logs
+	Name() string
+	// Scan returns what the scanner found suspicious about server, or an error if it couldn't scan it
+	Scan(ctx context.Context, server *apiv0.ServerJSON) ([]Finding, error)
+}
+
+// FromConfig returns the scanners enabled by cfg: the built-in heuristics, the package blocklist
+// if any packages are blocked, and the external scanner if a webhook URL is set
+func FromConfig(cfg *config.Config) []Scanner {
+	scanners := []Scanner{NewHeuristicScanner()}
+	if len(cfg.ScanBlockedPackages) > 0 {
+		scanners = append(scanners, NewPackageBlocklistScanner(cfg.ScanBlockedPackages))
+	}
+	if cfg.ScanWebhookURL != "" {
+		timeout := cfg.ScanWebhookTimeout
+		if timeout <= 0 {
+			timeout = 10 * time.Second
+		}
+		scanners = append(scanners, NewWebhookScanner(cfg.ScanWebhookURL, cfg.ScanWebhookToken, timeout))
+	}
+	return scanners
+}
+
+// Run scans server with every scanner, returning all findings along with the errors of scanners that failed
+func Run(ctx context.Context, scanners []Scanner, server *apiv0.ServerJSON) ([]Finding, error) {
+	var findings []Finding
+	var errs []error
+	for _, s := range scanners {
+		found, err := s.Scan(ctx, server)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.Name(), err))
+			continue
+		}
+		findings = append(findings, found...)
+	}
+	return findings, errors.Join(errs...)
+}
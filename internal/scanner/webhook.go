@@ -0,0 +1,81 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// webhookMaxResponseSize bounds how much of an external scanner's response is read
+const webhookMaxResponseSize = 1 << 20
+
+// WebhookScanner delegates scanning to an external service. It POSTs {"server": <server.json>} to the
+// webhook URL, which must answer 200 with {"findings": [{"rule": "...", "message": "...", "location": "..."}]}.
+type WebhookScanner struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+type webhookRequest struct {
+	Server *apiv0.ServerJSON `json:"server"`
+}
+
+type webhookResponse struct {
+	Findings []Finding `json:"findings"`
+}
+
+// NewWebhookScanner creates a scanner calling the given URL, authenticating with token as a bearer token if set
+func NewWebhookScanner(url, token string, timeout time.Duration) *WebhookScanner {
+	return &WebhookScanner{
+		url:    url,
+		token:  token,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *WebhookScanner) Name() string {
+	return "webhook"
+}
+
+func (s *WebhookScanner) Scan(ctx context.Context, server *apiv0.ServerJSON) ([]Finding, error) {
+	body, err := json.Marshal(webhookRequest{Server: server})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode scan request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scan request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("scan request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("scan request failed with status %d", resp.StatusCode)
+	}
+
+	var result webhookResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, webhookMaxResponseSize)).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode scan response: %w", err)
+	}
+	for i := range result.Findings {
+		result.Findings[i].Scanner = s.Name()
+	}
+	return result.Findings, nil
+}
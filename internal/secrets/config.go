@@ -0,0 +1,47 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// FromConfig creates a resolver for file, vault and awssm secret references
+func FromConfig(cfg *config.Config) *Resolver {
+	return NewResolver(map[string]Provider{
+		"file":  FileProvider{},
+		"vault": VaultProvider{Address: cfg.SecretsVaultAddress, TokenFile: cfg.SecretsVaultTokenFile},
+		"awssm": AWSSecretsManagerProvider{},
+	})
+}
+
+// ResolveConfig replaces secret references in the settings that hold credentials with the secrets they name
+func (r *Resolver) ResolveConfig(ctx context.Context, cfg *config.Config) error {
+	settings := map[string]*string{
+		"DATABASE_URL":                       &cfg.DatabaseURL,
+		"DATABASE_READ_URL":                  &cfg.DatabaseReadURL,
+		"GITHUB_CLIENT_SECRET":               &cfg.GithubClientSecret,
+		"JWT_PRIVATE_KEY":                    &cfg.JWTPrivateKey,
+		"EXPORT_SIGNING_KEY":                 &cfg.ExportSigningKey,
+		"OIDC_CLIENT_SECRET":                 &cfg.OIDCClientSecret,
+		"REPOSITORY_VALIDATION_GITHUB_TOKEN": &cfg.RepositoryValidationGitHubToken,
+		"OCI_REGISTRY_CREDENTIALS":           &cfg.OCIRegistryCredentials,
+		"ICON_STORAGE_SECRET_ACCESS_KEY":     &cfg.IconStorageSecretAccessKey,
+		"BACKUP_STORAGE_SECRET_ACCESS_KEY":   &cfg.BackupStorageSecretAccessKey,
+		"SCAN_WEBHOOK_TOKEN":                 &cfg.ScanWebhookToken,
+		"WEBHOOK_SECRET":                     &cfg.WebhookSecret,
+	}
+	for i := range cfg.JWTSigningKeys {
+		settings[fmt.Sprintf("JWT_SIGNING_KEYS[%d]", i)] = &cfg.JWTSigningKeys[i]
+	}
+
+	for name, setting := range settings {
+		value, err := r.Resolve(ctx, *setting)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		*setting = value
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/sigv4"
+)
+
+const (
+	requestTimeout = 10 * time.Second
+	maxSecretSize  = 64 << 10
+)
+
+// FileProvider reads secrets from files, such as Kubernetes Secrets mounted into the pod, named by
+// file:///path references
+type FileProvider struct{}
+
+func (FileProvider) Fetch(_ context.Context, ref *url.URL) (string, error) {
+	data, err := os.ReadFile(ref.Path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// VaultProvider reads secrets from a HashiCorp Vault KV version 2 secrets engine, named by
+// vault://mount/path references. The token is read from a file, such as one kept up to date by the
+// Vault agent, before each request, or else taken from the VAULT_TOKEN environment variable.
+type VaultProvider struct {
+	Address   string
+	TokenFile string
+	Client    *http.Client
+}
+
+func (p VaultProvider) Fetch(ctx context.Context, ref *url.URL) (string, error) {
+	if p.Address == "" {
+		return "", errors.New("no Vault address is configured")
+	}
+	token := os.Getenv("VAULT_TOKEN")
+	if p.TokenFile != "" {
+		data, err := os.ReadFile(p.TokenFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read Vault token: %w", err)
+		}
+		token = strings.TrimSpace(string(data))
+	}
+
+	endpoint := strings.TrimSuffix(p.Address, "/") + "/v1/" + ref.Host + "/data/" + strings.TrimPrefix(ref.Path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", token)
+
+	body, err := doRequest(p.Client, req)
+	if err != nil {
+		return "", err
+	}
+	var secret struct {
+		Data struct {
+			Data json.RawMessage `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil || len(secret.Data.Data) == 0 {
+		return "", errors.New("unexpected Vault response")
+	}
+	return string(secret.Data.Data), nil
+}
+
+// AWSSecretsManagerProvider reads secrets from AWS Secrets Manager, named by awssm://name references, or
+// awssm:///arn:... for ARNs, with an optional ?region= (by default AWS_REGION or AWS_DEFAULT_REGION) and
+// ?endpoint=. Requests are signed with the credentials in the standard AWS environment variables.
+type AWSSecretsManagerProvider struct {
+	Client *http.Client
+}
+
+func (p AWSSecretsManagerProvider) Fetch(ctx context.Context, ref *url.URL) (string, error) {
+	creds := sigv4.Credentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return "", errors.New("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set")
+	}
+
+	query := ref.Query()
+	region := query.Get("region")
+	if region == "" {
+		region = os.Getenv("AWS_REGION")
+	}
+	if region == "" {
+		region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	if region == "" {
+		return "", errors.New("no AWS region is configured")
+	}
+	endpoint := query.Get("endpoint")
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://secretsmanager.%s.amazonaws.com", region)
+	}
+
+	payload, err := json.Marshal(map[string]string{"SecretId": strings.TrimPrefix(ref.Host+ref.Path, "/")})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(endpoint, "/")+"/", bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	sigv4.Sign(req, payload, creds, region, "secretsmanager", time.Now().UTC())
+
+	body, err := doRequest(p.Client, req)
+	if err != nil {
+		return "", err
+	}
+	var secret struct {
+		SecretString *string `json:"SecretString"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil || secret.SecretString == nil {
+		return "", errors.New("unexpected Secrets Manager response, or a binary secret")
+	}
+	return *secret.SecretString, nil
+}
+
+// doRequest sends a request to a secret store and returns the body of a successful response
+func doRequest(client *http.Client, req *http.Request) ([]byte, error) {
+	if client == nil {
+		client = &http.Client{Timeout: requestTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSecretSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		// Error bodies describe the failure without including secrets
+		return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
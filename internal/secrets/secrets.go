@@ -0,0 +1,88 @@
+// Package secrets resolves settings that name where a credential is kept, rather than holding it: a file such
+// as a mounted Kubernetes Secret, a HashiCorp Vault KV secret or an AWS Secrets Manager secret. Settings without
+// a secret reference are used as they are.
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrFieldNotFound is returned when a reference selects a field its secret doesn't have
+var ErrFieldNotFound = errors.New("secret field not found")
+
+// Provider fetches secrets from one kind of store
+type Provider interface {
+	// Fetch returns the secret a reference names
+	Fetch(ctx context.Context, ref *url.URL) (string, error)
+}
+
+// Resolver resolves secret references with the provider for their scheme. Secrets are fetched each time they
+// are resolved; the registry resolves its settings once at startup, so rotated credentials are picked up on
+// restart.
+type Resolver struct {
+	providers map[string]Provider
+}
+
+// NewResolver creates a resolver using providers keyed by URL scheme
+func NewResolver(providers map[string]Provider) *Resolver {
+	return &Resolver{providers: providers}
+}
+
+// IsReference reports whether value names a secret with one of the resolver's schemes, rather than being one
+func (r *Resolver) IsReference(value string) bool {
+	scheme, _, found := strings.Cut(value, "://")
+	_, ok := r.providers[scheme]
+	return found && ok
+}
+
+// Resolve returns the secret value names, or value itself if it isn't a secret reference. A reference's
+// fragment selects a field of a secret holding a JSON object, e.g. vault://secret/registry#jwt_private_key.
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	if !r.IsReference(value) {
+		return value, nil
+	}
+
+	ref, err := url.Parse(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid secret reference %s: %w", redactReference(value), err)
+	}
+
+	secret, err := r.providers[ref.Scheme].Fetch(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch secret %s: %w", redactReference(value), err)
+	}
+	if ref.Fragment != "" {
+		if secret, err = selectField(secret, ref.Fragment); err != nil {
+			return "", fmt.Errorf("secret %s: %w", redactReference(value), err)
+		}
+	}
+	return secret, nil
+}
+
+// selectField returns a field of a secret holding a JSON object; fields that aren't strings are returned as JSON
+func selectField(secret, field string) (string, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(secret), &fields); err != nil {
+		return "", fmt.Errorf("%w: %q (the secret isn't a JSON object)", ErrFieldNotFound, field)
+	}
+	raw, ok := fields[field]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrFieldNotFound, field)
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return string(raw), nil //nolint:nilerr // non-string fields are returned as JSON
+	}
+	return value, nil
+}
+
+// redactReference drops the query of a reference in messages, in case it holds anything sensitive
+func redactReference(value string) string {
+	reference, _, _ := strings.Cut(value, "?")
+	return reference
+}
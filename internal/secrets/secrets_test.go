@@ -0,0 +1,167 @@
+package secrets_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingProvider returns its value, counting fetches
+type countingProvider struct {
+	value   string
+	fetches int
+}
+
+func (p *countingProvider) Fetch(_ context.Context, _ *url.URL) (string, error) {
+	p.fetches++
+	return p.value, nil
+}
+
+func TestResolver(t *testing.T) {
+	provider := &countingProvider{value: `{"password":"first"}`}
+	resolver := secrets.NewResolver(map[string]secrets.Provider{"test": provider})
+
+	value, err := resolver.Resolve(t.Context(), "plain-password")
+	require.NoError(t, err)
+	assert.Equal(t, "plain-password", value, "values that aren't references are used as they are")
+	assert.Equal(t, 0, provider.fetches)
+
+	value, err = resolver.Resolve(t.Context(), "test://db#password")
+	require.NoError(t, err)
+	assert.Equal(t, "first", value)
+
+	provider.value = `{"password":"second"}`
+	value, err = resolver.Resolve(t.Context(), "test://db#password")
+	require.NoError(t, err)
+	assert.Equal(t, "second", value, "secrets are fetched each time they are resolved")
+
+	_, err = resolver.Resolve(t.Context(), "test://db#username")
+	assert.ErrorIs(t, err, secrets.ErrFieldNotFound)
+}
+
+func TestFileProvider(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "jwt"), []byte("0123abcd\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "db.json"), []byte(`{"url":"postgres://registry@db/prod","port":5432}`), 0o600))
+	resolver := secrets.NewResolver(map[string]secrets.Provider{"file": secrets.FileProvider{}})
+
+	value, err := resolver.Resolve(t.Context(), "file://"+filepath.Join(dir, "jwt"))
+	require.NoError(t, err)
+	assert.Equal(t, "0123abcd", value)
+
+	value, err = resolver.Resolve(t.Context(), "file://"+filepath.Join(dir, "db.json")+"#url")
+	require.NoError(t, err)
+	assert.Equal(t, "postgres://registry@db/prod", value)
+
+	value, err = resolver.Resolve(t.Context(), "file://"+filepath.Join(dir, "db.json")+"#port")
+	require.NoError(t, err)
+	assert.Equal(t, "5432", value)
+
+	_, err = resolver.Resolve(t.Context(), "file://"+filepath.Join(dir, "db.json")+"#password")
+	assert.ErrorIs(t, err, secrets.ErrFieldNotFound)
+
+	_, err = resolver.Resolve(t.Context(), "file://"+filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
+
+func TestVaultProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = io.WriteString(w, `{"errors":["permission denied"]}`)
+			return
+		}
+		if r.URL.Path != "/v1/secret/data/registry/prod" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = io.WriteString(w, `{"lease_duration":0,"data":{"data":{"jwt_private_key":"0123abcd"},"metadata":{"version":3}}}`)
+	}))
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("vault-token\n"), 0o600))
+	resolver := secrets.NewResolver(map[string]secrets.Provider{
+		"vault": secrets.VaultProvider{Address: server.URL, TokenFile: tokenFile},
+	})
+
+	value, err := resolver.Resolve(t.Context(), "vault://secret/registry/prod#jwt_private_key")
+	require.NoError(t, err)
+	assert.Equal(t, "0123abcd", value)
+
+	_, err = resolver.Resolve(t.Context(), "vault://secret/registry/staging#jwt_private_key")
+	assert.ErrorContains(t, err, "404")
+}
+
+func TestAWSSecretsManagerProvider(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret-key")
+	t.Setenv("AWS_SESSION_TOKEN", "session-token")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") ||
+			!strings.Contains(r.Header.Get("Authorization"), "/eu-west-1/secretsmanager/aws4_request") ||
+			r.Header.Get("X-Amz-Security-Token") != "session-token" ||
+			r.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var input struct {
+			SecretID string `json:"SecretId"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&input)
+		if input.SecretID != "prod/registry" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"__type":"ResourceNotFoundException"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"Name":"prod/registry","SecretString":"{\"database_url\":\"postgres://registry@db/prod\"}"}`)
+	}))
+	defer server.Close()
+
+	resolver := secrets.NewResolver(map[string]secrets.Provider{"awssm": secrets.AWSSecretsManagerProvider{}})
+	query := "?region=eu-west-1&endpoint=" + url.QueryEscape(server.URL)
+
+	value, err := resolver.Resolve(t.Context(), "awssm://prod/registry"+query+"#database_url")
+	require.NoError(t, err)
+	assert.Equal(t, "postgres://registry@db/prod", value)
+
+	_, err = resolver.Resolve(t.Context(), "awssm://prod/other"+query+"#database_url")
+	assert.ErrorContains(t, err, "ResourceNotFoundException")
+}
+
+func TestResolveConfig(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "jwt"), []byte("0123abcd"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "db"), []byte("postgres://registry@db/prod"), 0o600))
+
+	cfg := &config.Config{
+		DatabaseURL:    "file://" + filepath.Join(dir, "db"),
+		JWTPrivateKey:  "file://" + filepath.Join(dir, "jwt"),
+		JWTSigningKeys: []string{"file://" + filepath.Join(dir, "jwt")},
+		GithubClientID: "file://not-a-secret-setting",
+		WebhookSecret:  "plain-secret",
+	}
+	require.NoError(t, secrets.FromConfig(cfg).ResolveConfig(t.Context(), cfg))
+	assert.Equal(t, "postgres://registry@db/prod", cfg.DatabaseURL)
+	assert.Equal(t, "0123abcd", cfg.JWTPrivateKey)
+	assert.Equal(t, []string{"0123abcd"}, cfg.JWTSigningKeys)
+	assert.Equal(t, "file://not-a-secret-setting", cfg.GithubClientID)
+	assert.Equal(t, "plain-secret", cfg.WebhookSecret)
+
+	cfg.OIDCClientSecret = "vault://secret/registry#oidc"
+	err := secrets.FromConfig(cfg).ResolveConfig(t.Context(), cfg)
+	assert.ErrorContains(t, err, "OIDC_CLIENT_SECRET")
+}
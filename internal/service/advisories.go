@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ErrInvalidAdvisory is returned when a security advisory is missing required fields or has invalid values
+var ErrInvalidAdvisory = errors.New("invalid advisory")
+
+// advisoryAffects reports whether an advisory applies to a server version. Advisories without a version
+// range affect every version; ranges only match semantic versions.
+func advisoryAffects(advisory *database.Advisory, version string) bool {
+	if advisory.AffectedVersions == "" {
+		return true
+	}
+	r, err := ParseVersionRange(advisory.AffectedVersions)
+	if err != nil {
+		return false
+	}
+	return r.Matches(version)
+}
+
+// CreateAdvisory files a security advisory against the named server and links it to the versions it affects
+func (s *registryServiceImpl) CreateAdvisory(createdBy, serverName string, req apiv0.Advisory) (*apiv0.Advisory, error) {
+	switch database.AdvisorySeverity(req.Severity) {
+	case database.AdvisorySeverityLow, database.AdvisorySeverityMedium, database.AdvisorySeverityHigh, database.AdvisorySeverityCritical:
+	default:
+		return nil, fmt.Errorf("%w: severity must be low, medium, high or critical", ErrInvalidAdvisory)
+	}
+	if req.Summary == "" {
+		return nil, fmt.Errorf("%w: summary is required", ErrInvalidAdvisory)
+	}
+	if req.AffectedVersions != "" {
+		if _, err := ParseVersionRange(req.AffectedVersions); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidAdvisory, err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	versions, _, err := s.db.List(ctx, &database.ServerFilter{Name: &serverName}, "", maxServerVersionsPerServer)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, database.ErrNotFound
+	}
+
+	advisory := &database.Advisory{
+		ID:               uuid.New().String(),
+		ServerName:       serverName,
+		Severity:         database.AdvisorySeverity(req.Severity),
+		Summary:          req.Summary,
+		Description:      req.Description,
+		AffectedVersions: req.AffectedVersions,
+		Remediation:      req.Remediation,
+		Aliases:          req.Aliases,
+		CreatedBy:        createdBy,
+		CreatedAt:        time.Now(),
+	}
+
+	var serverIDs []string
+	for _, version := range versions {
+		if version.Meta != nil && version.Meta.Official != nil && advisoryAffects(advisory, version.Version) {
+			serverIDs = append(serverIDs, version.Meta.Official.ID)
+		}
+	}
+
+	if err := s.db.CreateAdvisory(ctx, advisory, serverIDs); err != nil {
+		return nil, err
+	}
+	// Listings filtered on has_advisory change with the new links
+	s.readCache.invalidate(ctx)
+
+	result := toAdvisory(advisory)
+	return &result, nil
+}
+
+// GetAdvisory retrieves a security advisory and the name of the server it was filed against
+func (s *registryServiceImpl) GetAdvisory(id string) (*apiv0.Advisory, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	advisory, err := s.db.GetAdvisory(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+	result := toAdvisory(advisory)
+	return &result, advisory.ServerName, nil
+}
+
+// ListAdvisories retrieves every security advisory filed against the named server, oldest first
+func (s *registryServiceImpl) ListAdvisories(serverName string) ([]apiv0.Advisory, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	advisories, err := s.db.ListAdvisories(ctx, serverName)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]apiv0.Advisory, 0, len(advisories))
+	for _, advisory := range advisories {
+		result = append(result, toAdvisory(advisory))
+	}
+	return result, nil
+}
+
+// DeleteAdvisory withdraws a security advisory
+func (s *registryServiceImpl) DeleteAdvisory(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.db.DeleteAdvisory(ctx, id); err != nil {
+		return err
+	}
+	s.readCache.invalidate(ctx)
+	return nil
+}
+
+// linkAdvisories links a newly published version to the existing advisories whose range covers it
+func (s *registryServiceImpl) linkAdvisories(ctx context.Context, server *apiv0.ServerJSON) {
+	advisories, err := s.db.ListAdvisories(ctx, server.Name)
+	if err != nil {
+		log.Printf("Failed to load advisories for %s: %v", server.Name, err)
+		return
+	}
+
+	var ids []string
+	for _, advisory := range advisories {
+		if advisoryAffects(advisory, server.Version) {
+			ids = append(ids, advisory.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	if err := s.db.LinkAdvisories(ctx, server.GetID(), ids); err != nil {
+		log.Printf("Failed to link advisories to %s %s: %v", server.Name, server.Version, err)
+	}
+}
+
+// attachAdvisories adds the security advisories affecting each server version to its metadata
+func (s *registryServiceImpl) attachAdvisories(ctx context.Context, servers []apiv0.ServerJSON) {
+	var ids []string
+	for _, server := range servers {
+		if id := server.GetID(); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	advisories, err := s.db.ListServerAdvisories(ctx, ids)
+	if err != nil {
+		// Serve the servers without advisories rather than failing the read
+		log.Printf("Failed to load server advisories: %v", err)
+		return
+	}
+
+	for i := range servers {
+		serverAdvisories := advisories[servers[i].GetID()]
+		if len(serverAdvisories) == 0 {
+			continue
+		}
+
+		// Copy the metadata rather than modifying what the database or cache holds
+		meta := apiv0.ServerMeta{}
+		if servers[i].Meta != nil {
+			meta = *servers[i].Meta
+		}
+		meta.Advisories = make([]apiv0.Advisory, 0, len(serverAdvisories))
+		for _, advisory := range serverAdvisories {
+			meta.Advisories = append(meta.Advisories, toAdvisory(advisory))
+		}
+		servers[i].Meta = &meta
+	}
+}
+
+func toAdvisory(advisory *database.Advisory) apiv0.Advisory {
+	return apiv0.Advisory{
+		ID:               advisory.ID,
+		Severity:         string(advisory.Severity),
+		Summary:          advisory.Summary,
+		Description:      advisory.Description,
+		AffectedVersions: advisory.AffectedVersions,
+		Remediation:      advisory.Remediation,
+		Aliases:          advisory.Aliases,
+		PublishedAt:      advisory.CreatedAt,
+	}
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// APIKeyPrefix starts every API key, so keys are recognizable in Authorization headers and secret scanners
+const APIKeyPrefix = "mcpr_"
+
+const (
+	apiKeyRandomBytes   = 32
+	apiKeyDisplayLength = len(APIKeyPrefix) + 8
+	maxAPIKeysPerOwner  = 50
+)
+
+var (
+	// ErrInvalidAPIKey is returned when an API key is unknown, expired or revoked
+	ErrInvalidAPIKey = errors.New("invalid, expired or revoked API key")
+	// ErrTooManyAPIKeys is returned when an owner already has the maximum number of active API keys
+	ErrTooManyAPIKeys = errors.New("too many active API keys, revoke unused keys first")
+)
+
+// CreateAPIKey generates and stores a new API key for owner, returning the stored record and the
+// key itself. The key is not stored, so this is the only time it can be shown.
+func (s *registryServiceImpl) CreateAPIKey(owner, name string, scopes []string, expiresAt time.Time) (*database.APIKey, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	existing, err := s.db.ListAPIKeys(ctx, owner)
+	if err != nil {
+		return nil, "", err
+	}
+	now := time.Now()
+	active := 0
+	for _, key := range existing {
+		if apiKeyUsable(key, now) {
+			active++
+		}
+	}
+	if active >= maxAPIKeysPerOwner {
+		return nil, "", ErrTooManyAPIKeys
+	}
+
+	secret := make([]byte, apiKeyRandomBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", err
+	}
+	plaintext := APIKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	key := &database.APIKey{
+		ID:        uuid.New().String(),
+		Name:      name,
+		Prefix:    plaintext[:apiKeyDisplayLength],
+		KeyHash:   hashAPIKey(plaintext),
+		Owner:     owner,
+		Scopes:    scopes,
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+	}
+	if err := s.db.CreateAPIKey(ctx, key); err != nil {
+		return nil, "", err
+	}
+
+	return key, plaintext, nil
+}
+
+// ListAPIKeys returns all API keys created by owner, including expired and revoked keys
+func (s *registryServiceImpl) ListAPIKeys(owner string) ([]database.APIKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	keys, err := s.db.ListAPIKeys(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]database.APIKey, len(keys))
+	for i, key := range keys {
+		result[i] = *key
+	}
+	return result, nil
+}
+
+// RevokeAPIKey revokes one of owner's API keys
+func (s *registryServiceImpl) RevokeAPIKey(owner, id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return s.db.RevokeAPIKey(ctx, id, owner, time.Now())
+}
+
+// AuthenticateAPIKey returns the stored record for a usable API key
+func (s *registryServiceImpl) AuthenticateAPIKey(plaintext string) (*database.APIKey, error) {
+	if !strings.HasPrefix(plaintext, APIKeyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	key, err := s.db.GetAPIKeyByHash(ctx, hashAPIKey(plaintext))
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+	if !apiKeyUsable(key, time.Now()) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	return key, nil
+}
+
+// hashAPIKey hashes a key for storage. Keys carry 256 bits of randomness, so a fast hash is sufficient.
+func hashAPIKey(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
+
+func apiKeyUsable(key *database.APIKey, now time.Time) bool {
+	return key.RevokedAt == nil && now.Before(key.ExpiresAt)
+}
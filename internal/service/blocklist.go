@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+)
+
+const maxBlockedNameLength = 255
+
+// AddBlockedName blocks server names matching pattern and applies the change immediately
+func (s *registryServiceImpl) AddBlockedName(createdBy, pattern, reason string) (*database.BlockedName, error) {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	if pattern == "" || len(pattern) > maxBlockedNameLength || strings.ContainsAny(pattern, " \t\n") || strings.Count(pattern, "/") > 1 {
+		return nil, fmt.Errorf("%w: blocked name pattern %q", database.ErrInvalidInput, pattern)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	entry := &database.BlockedName{
+		Pattern:   pattern,
+		Reason:    reason,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	}
+	if err := s.db.AddBlockedName(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	if err := s.RefreshBlocklist(ctx); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// ListBlockedNames returns the blocklist entries added through the admin API, oldest first
+func (s *registryServiceImpl) ListBlockedNames() ([]database.BlockedName, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	entries, err := s.db.ListBlockedNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]database.BlockedName, len(entries))
+	for i, entry := range entries {
+		result[i] = *entry
+	}
+	return result, nil
+}
+
+// RemoveBlockedName removes a blocklist entry and applies the change immediately
+func (s *registryServiceImpl) RemoveBlockedName(pattern string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.db.RemoveBlockedName(ctx, strings.ToLower(pattern)); err != nil {
+		return err
+	}
+	return s.RefreshBlocklist(ctx)
+}
+
+// RefreshBlocklist loads the configured and stored blocklist entries into the validators.
+// Other instances pick up changes made through the admin API when they next refresh.
+func (s *registryServiceImpl) RefreshBlocklist(ctx context.Context) error {
+	entries, err := s.db.ListBlockedNames(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load blocked names: %w", err)
+	}
+
+	cfg := s.config()
+	patterns := append([]string{}, cfg.BlockedNames...)
+	for _, entry := range entries {
+		patterns = append(patterns, entry.Pattern)
+	}
+	validators.ConfigureBlocklistSource(cfg.Tenant, patterns)
+	return nil
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+)
+
+// CategoryCount is a category in the registry's taxonomy and how many servers are in it
+type CategoryCount struct {
+	Name        string `json:"name"`
+	ServerCount int    `json:"server_count" doc:"Number of servers whose latest version is in this category"`
+}
+
+// ListCategories returns the registry's category taxonomy, in order, with the number of servers in each
+func (s *registryServiceImpl) ListCategories() ([]CategoryCount, error) {
+	ctx, cancel := context.WithTimeout(database.WithReplicaReads(context.Background()), 5*time.Second)
+	defer cancel()
+
+	counts, err := s.db.CountServersByCategory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	taxonomy := validators.CategoryTaxonomy(s.config())
+	result := make([]CategoryCount, 0, len(taxonomy))
+	for _, name := range taxonomy {
+		result = append(result, CategoryCount{Name: name, ServerCount: counts[name]})
+	}
+	return result, nil
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// idempotencyKeyTTL is how long a publish's response is kept for retries using the same idempotency key
+const idempotencyKeyTTL = 24 * time.Hour
+
+// idempotencyStoreTimeout bounds each database operation on an idempotency key
+var idempotencyStoreTimeout = 5 * time.Second
+
+var (
+	// ErrIdempotencyKeyReused is returned when an idempotency key is sent again with a different request
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different request")
+	// ErrIdempotencyKeyInProgress is returned when a publish with the same idempotency key hasn't finished yet
+	ErrIdempotencyKeyInProgress = errors.New("a publish with this idempotency key is still in progress")
+)
+
+// PublishIdempotent publishes a server once per owner and idempotency key. Retrying the same request with the
+// same key returns the original response, reporting it as replayed, instead of publishing again. Keys of failed
+// publishes are released so the request can be retried.
+func (s *registryServiceImpl) PublishIdempotent(owner, key string, req apiv0.ServerJSON) (*apiv0.ServerJSON, bool, error) {
+	requestHash, err := hashPublishRequest(req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	replayed, err := s.reserveIdempotencyKey(owner, key, requestHash)
+	if err != nil || replayed != nil {
+		return replayed, replayed != nil, err
+	}
+
+	published, err := s.Publish(req)
+
+	// Registry validation can take far longer than the store timeout, so the deadline starts once the publish is done
+	ctx, cancel := context.WithTimeout(context.Background(), idempotencyStoreTimeout)
+	defer cancel()
+
+	if err != nil {
+		if deleteErr := s.db.DeleteIdempotencyKey(ctx, owner, key); deleteErr != nil {
+			log.Printf("Failed to release idempotency key after failed publish: %v", deleteErr)
+		}
+		return nil, false, err
+	}
+
+	// The server is published either way; if its response can't be stored, retries will see the key in progress
+	// until it expires rather than publishing again
+	if err := s.db.CompleteIdempotencyKey(ctx, owner, key, published); err != nil {
+		log.Printf("Failed to store response for idempotency key: %v", err)
+	}
+	return published, false, nil
+}
+
+// reserveIdempotencyKey claims an idempotency key for a new publish, or returns the stored response if the
+// same request was already published with it
+func (s *registryServiceImpl) reserveIdempotencyKey(owner, key, requestHash string) (*apiv0.ServerJSON, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), idempotencyStoreTimeout)
+	defer cancel()
+
+	now := time.Now()
+	err := s.db.CreateIdempotencyKey(ctx, &database.IdempotencyKey{
+		Owner:       owner,
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(idempotencyKeyTTL),
+	})
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, database.ErrAlreadyExists) {
+		return nil, err
+	}
+
+	existing, err := s.db.GetIdempotencyKey(ctx, owner, key)
+	if err != nil {
+		return nil, err
+	}
+	if existing.RequestHash != requestHash {
+		return nil, ErrIdempotencyKeyReused
+	}
+	if existing.Response == nil {
+		return nil, ErrIdempotencyKeyInProgress
+	}
+	return existing.Response, nil
+}
+
+// hashPublishRequest returns the hex-encoded SHA-256 of a publish request, to tell retries from other requests
+func hashPublishRequest(req apiv0.ServerJSON) (string, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash publish request: %w", err)
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
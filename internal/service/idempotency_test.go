@@ -0,0 +1,71 @@
+//nolint:testpackage
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowCreateDB takes longer than the idempotency store timeout to store a server, like a publish that spends
+// a while validating packages, and optionally fails afterwards
+type slowCreateDB struct {
+	database.Database
+	delay time.Duration
+	fail  bool
+}
+
+func (db *slowCreateDB) PublishServerVersion(ctx context.Context, server *apiv0.ServerJSON) (*apiv0.ServerJSON, error) {
+	time.Sleep(db.delay)
+	if db.fail {
+		return nil, errors.New("create failed")
+	}
+	return db.Database.PublishServerVersion(ctx, server)
+}
+
+func TestPublishIdempotent_SlowPublish(t *testing.T) {
+	previous := idempotencyStoreTimeout
+	idempotencyStoreTimeout = 20 * time.Millisecond
+	defer func() { idempotencyStoreTimeout = previous }()
+
+	db := &slowCreateDB{Database: database.NewMemoryDB(), delay: 100 * time.Millisecond, fail: true}
+	svc := NewRegistryService(db, &config.Config{EnableRegistryValidation: false})
+
+	server := apiv0.ServerJSON{
+		Name:        "com.example/slow-server",
+		Description: "A server that is slow to publish",
+		Version:     "1.0.0",
+	}
+
+	t.Run("failed publish releases the key", func(t *testing.T) {
+		_, _, err := svc.PublishIdempotent("owner", "key-1", server)
+		require.Error(t, err)
+
+		// The retry publishes again rather than finding the key stuck in progress
+		db.fail = false
+		published, replayed, err := svc.PublishIdempotent("owner", "key-1", server)
+		require.NoError(t, err)
+		assert.False(t, replayed)
+		require.NotNil(t, published)
+	})
+
+	t.Run("successful publish stores the response", func(t *testing.T) {
+		second := server
+		second.Version = "2.0.0"
+
+		published, _, err := svc.PublishIdempotent("owner", "key-2", second)
+		require.NoError(t, err)
+
+		replay, replayed, err := svc.PublishIdempotent("owner", "key-2", second)
+		require.NoError(t, err)
+		assert.True(t, replayed)
+		assert.Equal(t, published.GetID(), replay.GetID())
+	})
+}
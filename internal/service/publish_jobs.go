@@ -0,0 +1,221 @@
+package service
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const (
+	defaultPublishJobWorkers  = 4
+	publishJobQueueSize       = 256
+	publishJobRetentionPeriod = time.Hour
+)
+
+var (
+	// ErrPublishJobNotFound is returned when a publish job ID is unknown or has expired
+	ErrPublishJobNotFound = errors.New("publish job not found")
+	// ErrPublishQueueFull is returned when too many publish jobs are already waiting
+	ErrPublishQueueFull = errors.New("publish queue is full, please retry later")
+)
+
+// PublishJobStatus represents the lifecycle state of an asynchronous publish
+type PublishJobStatus string
+
+const (
+	PublishJobStatusPending   PublishJobStatus = "pending"
+	PublishJobStatusRunning   PublishJobStatus = "running"
+	PublishJobStatusSucceeded PublishJobStatus = "succeeded"
+	PublishJobStatusFailed    PublishJobStatus = "failed"
+)
+
+// PublishJob tracks an asynchronous publish while registry validation runs in the background
+type PublishJob struct {
+	// owner is the identity that submitted the job, the only one allowed to see it
+	owner string
+
+	ID         string            `json:"id" doc:"Publish job ID"`
+	Status     PublishJobStatus  `json:"status" doc:"Current job status" enum:"pending,running,succeeded,failed"`
+	ServerName string            `json:"server_name" doc:"Name of the server being published"`
+	Version    string            `json:"version" doc:"Version of the server being published"`
+	Error      string            `json:"error,omitempty" doc:"Failure reason, when status is failed"`
+	Server     *apiv0.ServerJSON `json:"server,omitempty" doc:"Published server, when status is succeeded"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+}
+
+// publishJobQueue runs publish requests on a bounded pool of background workers. Jobs and their idempotency
+// keys live in process memory only: they are lost on restart, and a deployment running several instances must
+// route status polls to the instance that accepted the job.
+type publishJobQueue struct {
+	jobs    map[string]*PublishJob
+	keys    map[publishJobKey]publishJobKeyEntry
+	queue   chan publishTask
+	mu      sync.RWMutex
+	start   sync.Once
+	workers int
+	publish func(apiv0.ServerJSON) (*apiv0.ServerJSON, error)
+}
+
+// publishJobKey is an idempotency key, which is scoped to the identity that sent it
+type publishJobKey struct {
+	owner string
+	key   string
+}
+
+type publishJobKeyEntry struct {
+	jobID       string
+	requestHash string
+}
+
+type publishTask struct {
+	jobID string
+	req   apiv0.ServerJSON
+	// published is called once the server is published, if set
+	published func()
+}
+
+func newPublishJobQueue(workers int, publish func(apiv0.ServerJSON) (*apiv0.ServerJSON, error)) *publishJobQueue {
+	if workers <= 0 {
+		workers = defaultPublishJobWorkers
+	}
+	return &publishJobQueue{
+		jobs:    make(map[string]*PublishJob),
+		keys:    make(map[publishJobKey]publishJobKeyEntry),
+		queue:   make(chan publishTask, publishJobQueueSize),
+		workers: workers,
+		publish: publish,
+	}
+}
+
+// submit records a new pending job for owner and queues it for a background worker. With an idempotency key,
+// resubmitting the same request returns the existing job, reporting it as replayed, unless that job failed.
+func (q *publishJobQueue) submit(owner, idempotencyKey string, req apiv0.ServerJSON, published func()) (*PublishJob, bool, error) {
+	// Workers are started lazily so services that never publish asynchronously don't spawn goroutines
+	q.start.Do(func() {
+		for i := 0; i < q.workers; i++ {
+			go q.work()
+		}
+	})
+
+	var requestHash string
+	if idempotencyKey != "" {
+		var err error
+		if requestHash, err = hashPublishRequest(req); err != nil {
+			return nil, false, err
+		}
+	}
+	key := publishJobKey{owner: owner, key: idempotencyKey}
+
+	now := time.Now()
+	job := &PublishJob{
+		owner:      owner,
+		ID:         uuid.New().String(),
+		Status:     PublishJobStatusPending,
+		ServerName: req.Name,
+		Version:    req.Version,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	q.mu.Lock()
+	q.pruneLocked(now)
+	if idempotencyKey != "" {
+		if entry, ok := q.keys[key]; ok {
+			existing := q.jobs[entry.jobID]
+			switch {
+			case entry.requestHash != requestHash:
+				q.mu.Unlock()
+				return nil, false, ErrIdempotencyKeyReused
+			case existing.Status != PublishJobStatusFailed:
+				jobCopy := *existing
+				q.mu.Unlock()
+				return &jobCopy, true, nil
+			}
+		}
+		q.keys[key] = publishJobKeyEntry{jobID: job.ID, requestHash: requestHash}
+	}
+	q.jobs[job.ID] = job
+	q.mu.Unlock()
+
+	select {
+	case q.queue <- publishTask{jobID: job.ID, req: req, published: published}:
+	default:
+		q.mu.Lock()
+		delete(q.jobs, job.ID)
+		if idempotencyKey != "" {
+			delete(q.keys, key)
+		}
+		q.mu.Unlock()
+		return nil, false, ErrPublishQueueFull
+	}
+
+	job, err := q.get(owner, job.ID)
+	return job, false, err
+}
+
+// get returns a snapshot of one of owner's jobs so callers never observe concurrent updates.
+// Other identities' jobs are reported as not found.
+func (q *publishJobQueue) get(owner, id string) (*PublishJob, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	job, ok := q.jobs[id]
+	if !ok || job.owner != owner {
+		return nil, ErrPublishJobNotFound
+	}
+	jobCopy := *job
+	return &jobCopy, nil
+}
+
+func (q *publishJobQueue) work() {
+	for task := range q.queue {
+		q.update(task.jobID, func(job *PublishJob) {
+			job.Status = PublishJobStatusRunning
+		})
+
+		server, err := q.publish(task.req)
+		if err == nil && task.published != nil {
+			task.published()
+		}
+
+		q.update(task.jobID, func(job *PublishJob) {
+			if err != nil {
+				job.Status = PublishJobStatusFailed
+				job.Error = err.Error()
+				return
+			}
+			job.Status = PublishJobStatusSucceeded
+			job.Server = server
+		})
+	}
+}
+
+func (q *publishJobQueue) update(id string, fn func(*PublishJob)) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if job, ok := q.jobs[id]; ok {
+		fn(job)
+		job.UpdatedAt = time.Now()
+	}
+}
+
+// pruneLocked drops finished jobs older than the retention period, with their idempotency keys.
+// Callers must hold q.mu.
+func (q *publishJobQueue) pruneLocked(now time.Time) {
+	for id, job := range q.jobs {
+		finished := job.Status == PublishJobStatusSucceeded || job.Status == PublishJobStatusFailed
+		if finished && now.Sub(job.UpdatedAt) > publishJobRetentionPeriod {
+			delete(q.jobs, id)
+		}
+	}
+	for key, entry := range q.keys {
+		if _, ok := q.jobs[entry.jobID]; !ok {
+			delete(q.keys, key)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+//nolint:testpackage
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testJobOwner = "github-at:example"
+
+func waitForPublishJob(t *testing.T, svc RegistryService, owner, id string) *PublishJob {
+	t.Helper()
+
+	var job *PublishJob
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = svc.GetPublishJob(owner, id)
+		require.NoError(t, err)
+		return job.Status == PublishJobStatusSucceeded || job.Status == PublishJobStatusFailed
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return job
+}
+
+func TestPublishAsync(t *testing.T) {
+	svc := NewRegistryService(database.NewMemoryDB(), &config.Config{EnableRegistryValidation: false})
+
+	server := apiv0.ServerJSON{
+		Name:        "com.example/async-server",
+		Description: "An async server",
+		Version:     "1.0.0",
+	}
+
+	t.Run("successful publish", func(t *testing.T) {
+		published := make(chan struct{}, 1)
+		job, replayed, err := svc.PublishAsync(testJobOwner, "", server, func() { published <- struct{}{} })
+		require.NoError(t, err)
+		assert.False(t, replayed)
+		assert.NotEmpty(t, job.ID)
+		assert.Equal(t, "com.example/async-server", job.ServerName)
+		assert.Equal(t, "1.0.0", job.Version)
+
+		job = waitForPublishJob(t, svc, testJobOwner, job.ID)
+		assert.Equal(t, PublishJobStatusSucceeded, job.Status)
+		require.NotNil(t, job.Server)
+		assert.NotEmpty(t, job.Server.GetID())
+		assert.Len(t, published, 1, "published is called once the server is published")
+
+		stored, err := svc.GetByID(job.Server.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, server.Name, stored.Name)
+	})
+
+	t.Run("failed publish records the error", func(t *testing.T) {
+		// Same version again is rejected during the background publish
+		published := make(chan struct{}, 1)
+		job, _, err := svc.PublishAsync(testJobOwner, "", server, func() { published <- struct{}{} })
+		require.NoError(t, err)
+
+		job = waitForPublishJob(t, svc, testJobOwner, job.ID)
+		assert.Equal(t, PublishJobStatusFailed, job.Status)
+		assert.Empty(t, published, "published isn't called for failed publishes")
+		assert.Contains(t, job.Error, "cannot publish duplicate version")
+		assert.Nil(t, job.Server)
+	})
+
+	t.Run("malformed request is rejected up front", func(t *testing.T) {
+		_, _, err := svc.PublishAsync(testJobOwner, "", apiv0.ServerJSON{
+			Name:        "missing-namespace",
+			Description: "Invalid server",
+			Version:     "1.0.0",
+		}, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		_, err := svc.GetPublishJob(testJobOwner, "00000000-0000-0000-0000-000000000000")
+		assert.ErrorIs(t, err, ErrPublishJobNotFound)
+	})
+
+	t.Run("jobs are only visible to their submitter", func(t *testing.T) {
+		job, _, err := svc.PublishAsync(testJobOwner, "", apiv0.ServerJSON{
+			Name:        "com.example/private-job",
+			Description: "A server",
+			Version:     "1.0.0",
+		}, nil)
+		require.NoError(t, err)
+
+		_, err = svc.GetPublishJob("github-at:someone-else", job.ID)
+		assert.ErrorIs(t, err, ErrPublishJobNotFound)
+		waitForPublishJob(t, svc, testJobOwner, job.ID)
+	})
+}
+
+func TestPublishAsyncIdempotency(t *testing.T) {
+	svc := NewRegistryService(database.NewMemoryDB(), &config.Config{EnableRegistryValidation: false})
+
+	server := apiv0.ServerJSON{
+		Name:        "com.example/idempotent-async",
+		Description: "An async server",
+		Version:     "1.0.0",
+	}
+
+	first, replayed, err := svc.PublishAsync(testJobOwner, "key-1", server, nil)
+	require.NoError(t, err)
+	assert.False(t, replayed)
+
+	retry, replayed, err := svc.PublishAsync(testJobOwner, "key-1", server, nil)
+	require.NoError(t, err)
+	assert.True(t, replayed, "a retry returns the original job")
+	assert.Equal(t, first.ID, retry.ID)
+	assert.Equal(t, PublishJobStatusSucceeded, waitForPublishJob(t, svc, testJobOwner, first.ID).Status)
+
+	changed := server
+	changed.Version = "1.0.1"
+	_, _, err = svc.PublishAsync(testJobOwner, "key-1", changed, nil)
+	assert.ErrorIs(t, err, ErrIdempotencyKeyReused)
+
+	// Keys are scoped to the identity that sent them
+	other, replayed, err := svc.PublishAsync("github-at:someone-else", "key-1", changed, nil)
+	require.NoError(t, err)
+	assert.False(t, replayed)
+	waitForPublishJob(t, svc, "github-at:someone-else", other.ID)
+
+	// A failed job's key can be reused to try again
+	failed, _, err := svc.PublishAsync(testJobOwner, "key-2", server, nil)
+	require.NoError(t, err)
+	assert.Equal(t, PublishJobStatusFailed, waitForPublishJob(t, svc, testJobOwner, failed.ID).Status)
+	again, replayed, err := svc.PublishAsync(testJobOwner, "key-2", server, nil)
+	require.NoError(t, err)
+	assert.False(t, replayed)
+	assert.NotEqual(t, failed.ID, again.ID)
+}
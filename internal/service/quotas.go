@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrNamespaceQuotaExceeded is returned when publishing a new server name would exceed its namespace's quota
+var ErrNamespaceQuotaExceeded = errors.New("namespace server quota exceeded")
+
+// checkNamespaceQuota applies the configured limit on distinct server names per namespace to the first
+// publish of a server name
+func (s *registryServiceImpl) checkNamespaceQuota(ctx context.Context, name string) error {
+	limit := s.config().MaxServersPerNamespace
+	if limit <= 0 {
+		return nil
+	}
+
+	names, err := s.listServerNames(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check namespace quota: %w", err)
+	}
+
+	namespace := ServerNamespace(name)
+	count := 0
+	for _, existing := range names {
+		if ServerNamespace(existing) == namespace {
+			count++
+		}
+	}
+	if count >= limit {
+		return fmt.Errorf("%w: %s already has %d servers (limit %d)", ErrNamespaceQuotaExceeded, namespace, count, limit)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/cache"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const (
+	readCacheKeyPrefix = "registry-read:"
+	// readCacheGenerationKey holds a random token that is part of every read cache key.
+	// Replacing it on writes invalidates all cached reads at once, across every replica sharing the cache.
+	readCacheGenerationKey = "generation"
+	readCacheGenerationTTL = 24 * time.Hour
+)
+
+// readCache caches List, GetByID and latest version results, and is invalidated wholesale whenever a server is written
+type readCache struct {
+	cache  cache.Cache
+	ttl    time.Duration
+	prefix string // namespaces keys so tenants sharing a cache don't see each other's results
+}
+
+// cachedListResult is the cached form of a List call
+type cachedListResult struct {
+	Servers    []apiv0.ServerJSON `json:"servers"`
+	NextCursor string             `json:"next_cursor"`
+}
+
+func newReadCache(c cache.Cache, ttl time.Duration, tenant string) *readCache {
+	if c == nil || ttl <= 0 {
+		return nil
+	}
+	prefix := readCacheKeyPrefix
+	if tenant != "" {
+		prefix += "tenant-" + tenant + ":"
+	}
+	return &readCache{cache: c, ttl: ttl, prefix: prefix}
+}
+
+// generation returns the current cache generation, starting a new one if none is stored
+func (rc *readCache) generation(ctx context.Context) (string, error) {
+	value, ok, err := rc.cache.Get(ctx, rc.prefix+readCacheGenerationKey)
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		return string(value), nil
+	}
+
+	// A missing generation may have expired, so never fall back to a fixed value that older entries could share
+	gen := uuid.New().String()
+	if err := rc.cache.Set(ctx, rc.prefix+readCacheGenerationKey, []byte(gen), readCacheGenerationTTL); err != nil {
+		return "", err
+	}
+	return gen, nil
+}
+
+// key builds a cache key for a read operation and its parameters in the current generation
+func (rc *readCache) key(ctx context.Context, operation string, params any) (string, error) {
+	gen, err := rc.generation(ctx)
+	if err != nil {
+		return "", err
+	}
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(paramsJSON)
+	return rc.prefix + gen + ":" + operation + ":" + hex.EncodeToString(sum[:]), nil
+}
+
+// lookup runs load unless a cached result for the same operation and parameters exists.
+// Cache failures are logged and fall through to load, so a cache outage never fails a read.
+func lookup[T any](ctx context.Context, rc *readCache, operation string, params any, load func() (T, error)) (T, error) {
+	if rc == nil {
+		return load()
+	}
+
+	key, err := rc.key(ctx, operation, params)
+	if err != nil {
+		log.Printf("Read cache lookup failed: %v", err)
+		return load()
+	}
+
+	if data, ok, err := rc.cache.Get(ctx, key); err != nil {
+		log.Printf("Read cache lookup failed: %v", err)
+	} else if ok {
+		var cached T
+		if err := json.Unmarshal(data, &cached); err == nil {
+			return cached, nil
+		}
+	}
+
+	result, err := load()
+	if err != nil {
+		return result, err
+	}
+
+	if data, err := json.Marshal(result); err != nil {
+		log.Printf("Failed to encode read cache entry: %v", err)
+	} else if err := rc.cache.Set(ctx, key, data, rc.ttl); err != nil {
+		log.Printf("Failed to store read cache entry: %v", err)
+	}
+	return result, nil
+}
+
+// invalidate drops all cached reads by starting a new generation
+func (rc *readCache) invalidate(ctx context.Context) {
+	if rc == nil {
+		return
+	}
+	gen := uuid.New().String()
+	if err := rc.cache.Set(ctx, rc.prefix+readCacheGenerationKey, []byte(gen), readCacheGenerationTTL); err != nil {
+		// Fall back to deleting the generation, which also forces a new one on the next read
+		log.Printf("Failed to invalidate read cache: %v", err)
+		if err := rc.cache.Delete(ctx, rc.prefix+readCacheGenerationKey); err != nil {
+			log.Printf("Failed to invalidate read cache: %v", err)
+		}
+	}
+}
+
+// listCacheParams identifies a List call in the read cache
+type listCacheParams struct {
+	Filter *database.ServerFilter `json:"filter"`
+	Cursor string                 `json:"cursor"`
+	Limit  int                    `json:"limit"`
+}
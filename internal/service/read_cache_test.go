@@ -0,0 +1,93 @@
+//nolint:testpackage
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/cache"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCache(t *testing.T) {
+	db := database.NewMemoryDB()
+	cfg := &config.Config{EnableRegistryValidation: false, ReadCacheTTL: time.Minute}
+	svc := NewCachedRegistryService(db, cfg, cache.NewMemoryCache(), nil)
+
+	published, err := svc.Publish(apiv0.ServerJSON{
+		Name:        "com.example/cached-server",
+		Description: "A cached server",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+
+	servers, _, err := svc.List(nil, "", 30)
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+
+	got, err := svc.GetByID(published.GetID())
+	require.NoError(t, err)
+	assert.Equal(t, "A cached server", got.Description)
+
+	t.Run("reads are served from cache", func(t *testing.T) {
+		// Write directly to the database so the service has no chance to invalidate
+		_, err := db.CreateServer(context.Background(), &apiv0.ServerJSON{
+			Name:        "com.example/uncached-server",
+			Description: "Written behind the cache",
+			Version:     "1.0.0",
+			Meta: &apiv0.ServerMeta{Official: &apiv0.RegistryExtensions{
+				ID:          "11111111-1111-1111-1111-111111111111",
+				PublishedAt: time.Now(),
+				UpdatedAt:   time.Now(),
+				IsLatest:    true,
+			}},
+		})
+		require.NoError(t, err)
+
+		servers, _, err := svc.List(nil, "", 30)
+		require.NoError(t, err)
+		assert.Len(t, servers, 1)
+	})
+
+	t.Run("publish invalidates cached reads", func(t *testing.T) {
+		_, err := svc.Publish(apiv0.ServerJSON{
+			Name:        "com.example/cached-server",
+			Description: "A cached server",
+			Version:     "1.0.1",
+		})
+		require.NoError(t, err)
+
+		servers, _, err := svc.List(nil, "", 30)
+		require.NoError(t, err)
+		assert.Len(t, servers, 3)
+
+		got, err := svc.GetByID(published.GetID())
+		require.NoError(t, err)
+		assert.False(t, got.Meta.Official.IsLatest)
+	})
+
+	t.Run("edit invalidates cached reads", func(t *testing.T) {
+		_, err := svc.EditServer(published.GetID(), apiv0.ServerJSON{
+			Name:        "com.example/cached-server",
+			Description: "An edited server",
+			Version:     "1.0.0",
+		}, 0)
+		require.NoError(t, err)
+
+		got, err := svc.GetByID(published.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, "An edited server", got.Description)
+	})
+}
+
+func TestReadCacheDisabled(t *testing.T) {
+	svc := NewCachedRegistryService(database.NewMemoryDB(), &config.Config{}, cache.NewMemoryCache(), nil)
+	impl, ok := svc.(*registryServiceImpl)
+	require.True(t, ok)
+	assert.Nil(t, impl.readCache)
+}
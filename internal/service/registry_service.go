@@ -4,35 +4,76 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/cache"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/scanner"
+	"github.com/modelcontextprotocol/registry/internal/storage"
 	"github.com/modelcontextprotocol/registry/internal/validators"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
 const maxServerVersionsPerServer = 10000
 
 // registryServiceImpl implements the RegistryService interface using our Database
 type registryServiceImpl struct {
-	db  database.Database
-	cfg *config.Config
+	db        database.Database
+	live      *config.Live
+	jobs      *publishJobQueue
+	readCache *readCache
+	icons     storage.Store
+	scanners  []scanner.Scanner
 }
 
 // NewRegistryService creates a new registry service with the provided database
 func NewRegistryService(db database.Database, cfg *config.Config) RegistryService {
-	return &registryServiceImpl{
-		db:  db,
-		cfg: cfg,
+	return NewCachedRegistryService(db, cfg, nil, nil)
+}
+
+// NewCachedRegistryService creates a registry service that caches List and GetByID results
+// in readCache for cfg.ReadCacheTTL. A nil cache or non-positive TTL disables read caching.
+// Uploaded icons are kept in iconStore, or in memory if it is nil.
+func NewCachedRegistryService(db database.Database, cfg *config.Config, readCache cache.Cache, iconStore storage.Store) RegistryService {
+	if iconStore == nil {
+		iconStore = storage.NewMemoryStore()
 	}
+	s := &registryServiceImpl{
+		db:        db,
+		live:      config.NewLive(cfg),
+		readCache: newReadCache(readCache, cfg.ReadCacheTTL, cfg.Tenant),
+		icons:     iconStore,
+		scanners:  scanner.FromConfig(cfg),
+	}
+	s.jobs = newPublishJobQueue(cfg.PublishJobWorkers, s.Publish)
+	return s
+}
+
+// config returns the current configuration, which may change between calls when settings are reloaded
+func (s *registryServiceImpl) config() *config.Config {
+	return s.live.Load()
+}
+
+// Config returns the current configuration
+func (s *registryServiceImpl) Config() *config.Config {
+	return s.config()
+}
+
+// ReloadConfig switches to a reloaded configuration and reapplies the configured blocklist
+func (s *registryServiceImpl) ReloadConfig(ctx context.Context, cfg *config.Config) error {
+	s.live.Store(cfg)
+	return s.RefreshBlocklist(ctx)
 }
 
 // List returns registry entries with cursor-based pagination and optional filtering
 func (s *registryServiceImpl) List(filter *database.ServerFilter, cursor string, limit int) ([]apiv0.ServerJSON, string, error) {
-	// Create a timeout context for the database operation
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	// Create a timeout context for the database operation; like the read cache, these reads may be slightly stale
+	ctx, cancel := context.WithTimeout(database.WithReplicaReads(context.Background()), 5*time.Second)
 	defer cancel()
 
 	// If limit is not set or negative, use a default limit
@@ -40,50 +81,245 @@ func (s *registryServiceImpl) List(filter *database.ServerFilter, cursor string,
 		limit = 30
 	}
 
-	// Use the database's ListServers method with pagination and filtering
-	serverRecords, nextCursor, err := s.db.List(ctx, filter, cursor, limit)
+	params := listCacheParams{Filter: filter, Cursor: cursor, Limit: limit}
+	cached, err := lookup(ctx, s.readCache, "list", params, func() (cachedListResult, error) {
+		// Use the database's ListServers method with pagination and filtering
+		serverRecords, nextCursor, err := s.db.List(ctx, filter, cursor, limit)
+		if err != nil {
+			return cachedListResult{}, err
+		}
+
+		// Return ServerJSONs directly
+		result := make([]apiv0.ServerJSON, len(serverRecords))
+		for i, record := range serverRecords {
+			result[i] = *record
+		}
+		return cachedListResult{Servers: result, NextCursor: nextCursor}, nil
+	})
 	if err != nil {
 		return nil, "", err
 	}
 
-	// Return ServerJSONs directly
-	result := make([]apiv0.ServerJSON, len(serverRecords))
-	for i, record := range serverRecords {
-		result[i] = *record
-	}
-
-	return result, nextCursor, nil
+	servers := slices.Clone(cached.Servers)
+	s.attachReadTimeMeta(ctx, servers)
+	return servers, cached.NextCursor, nil
 }
 
 // GetByID retrieves a specific server by its registry metadata ID in flattened format
 func (s *registryServiceImpl) GetByID(id string) (*apiv0.ServerJSON, error) {
-	// Create a timeout context for the database operation
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	// Create a timeout context for the database operation; like the read cache, these reads may be slightly stale
+	ctx, cancel := context.WithTimeout(database.WithReplicaReads(context.Background()), 5*time.Second)
 	defer cancel()
 
-	serverRecord, err := s.db.GetByID(ctx, id)
+	serverRecord, err := lookup(ctx, s.readCache, "get", id, func() (*apiv0.ServerJSON, error) {
+		return s.db.GetByID(ctx, id)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Return the server record directly
-	return serverRecord, nil
+	servers := []apiv0.ServerJSON{*serverRecord}
+	s.attachReadTimeMeta(ctx, servers)
+	return &servers[0], nil
+}
+
+// attachReadTimeMeta adds the metadata that changes independently of a server's record: usage statistics,
+// remote health, icons and security advisories. It is looked up after the read cache so cached entries
+// don't serve stale values.
+func (s *registryServiceImpl) attachReadTimeMeta(ctx context.Context, servers []apiv0.ServerJSON) {
+	s.attachStats(ctx, servers)
+	s.attachRemoteHealth(ctx, servers)
+	s.attachIcons(ctx, servers)
+	s.attachAdvisories(ctx, servers)
+}
+
+// withoutReadTimeMeta returns a copy of meta without the metadata attachReadTimeMeta and publish responses add,
+// so records copied from API responses don't store stale statistics, health, icons, advisories or validation reports
+func withoutReadTimeMeta(meta *apiv0.ServerMeta) *apiv0.ServerMeta {
+	result := apiv0.ServerMeta{}
+	if meta != nil {
+		result = *meta
+	}
+	result.Stats = nil
+	result.Health = nil
+	result.Icon = nil
+	result.Advisories = nil
+	result.Validation = nil
+	return &result
+}
+
+// ListChanges returns change feed events after since, along with the sequence number to pass as since for the next page
+func (s *registryServiceImpl) ListChanges(since int64, limit int) ([]apiv0.ChangeEvent, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if limit <= 0 {
+		limit = 100
+	}
+
+	changes, err := s.db.ListChanges(ctx, since, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]apiv0.ChangeEvent, len(changes))
+	nextSince := since
+	for i, change := range changes {
+		result[i] = *change
+		nextSince = change.Sequence
+	}
+
+	return result, nextSince, nil
+}
+
+// publishPlan holds the record that a publish would store, along with the
+// report of validating it
+type publishPlan struct {
+	server *apiv0.ServerJSON
+	report *apiv0.ValidationReport
+}
+
+// withValidationReport returns a copy of server carrying the validation report of its publish, which isn't stored
+func withValidationReport(server *apiv0.ServerJSON, report *apiv0.ValidationReport) *apiv0.ServerJSON {
+	result := *server
+	meta := apiv0.ServerMeta{}
+	if server.Meta != nil {
+		meta = *server.Meta
+	}
+	meta.Validation = report
+	result.Meta = &meta
+	return &result
 }
 
 // Publish publishes a server with flattened _meta extensions
 func (s *registryServiceImpl) Publish(req apiv0.ServerJSON) (*apiv0.ServerJSON, error) {
+	return s.publish(context.Background(), req)
+}
+
+// PublishTraced publishes a server, or validates it in a dry run, recording the requests validators send
+// upstream. The requests are returned whether or not the publish succeeds, and are added to its validation report.
+func (s *registryServiceImpl) PublishTraced(req apiv0.ServerJSON, dryRun, verifyFileHashes bool) (*apiv0.ServerJSON, []apiv0.ValidatorCall, error) {
+	ctx, calls := registries.TraceRequests(context.Background())
+
+	var (
+		server *apiv0.ServerJSON
+		err    error
+	)
+	if dryRun {
+		server, err = s.publishDryRun(ctx, req, verifyFileHashes)
+	} else {
+		server, err = s.publish(ctx, req)
+	}
+	if err != nil {
+		return nil, calls(), err
+	}
+
+	if server.Meta != nil && server.Meta.Validation != nil {
+		server.Meta.Validation.ValidatorCalls = calls()
+	}
+	return server, calls(), nil
+}
+
+// publish publishes a server. Validators see the values of parent, but not its deadline.
+func (s *registryServiceImpl) publish(parent context.Context, req apiv0.ServerJSON) (*apiv0.ServerJSON, error) {
 	// Create a timeout context for the database operation
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(parent, 5*time.Second)
+	defer cancel()
+
+	plan, err := s.preparePublish(ctx, req, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Store the new version and demote the previous latest together, so there is never more than one latest version
+	serverRecord, err := s.db.PublishServerVersion(ctx, plan.server)
+	if errors.Is(err, database.ErrNameConflict) {
+		// A publish of a name differing only in case won the race past checkNameConflict
+		return nil, fmt.Errorf("%w: %w", ErrServerNameConflict, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer s.readCache.invalidate(ctx)
+
+	s.linkAdvisories(ctx, serverRecord)
+
+	// Return the server record along with the warnings found validating it
+	return withValidationReport(serverRecord, plan.report), nil
+}
+
+// PublishDryRun runs every publish validation and returns the record that would be stored, without persisting it.
+// Package files are only downloaded to verify their hashes if verifyFileHashes is set.
+func (s *registryServiceImpl) PublishDryRun(req apiv0.ServerJSON, verifyFileHashes bool) (*apiv0.ServerJSON, error) {
+	return s.publishDryRun(context.Background(), req, verifyFileHashes)
+}
+
+func (s *registryServiceImpl) publishDryRun(parent context.Context, req apiv0.ServerJSON, verifyFileHashes bool) (*apiv0.ServerJSON, error) {
+	ctx, cancel := context.WithTimeout(parent, 5*time.Second)
 	defer cancel()
 
-	// Validate the request
-	if err := validators.ValidatePublishRequest(req, s.cfg); err != nil {
+	plan, err := s.preparePublish(ctx, req, verifyFileHashes)
+	if err != nil {
+		return nil, err
+	}
+
+	return withValidationReport(plan.server, plan.report), nil
+}
+
+// PublishAsync checks the request shape up front and queues the publish, including registry validation, as a
+// background job owned by owner, which calls published, if set, once the server is published. With an
+// idempotency key, retrying the same request returns the original job, reported as replayed, unless it failed.
+func (s *registryServiceImpl) PublishAsync(owner, idempotencyKey string, req apiv0.ServerJSON, published func()) (*PublishJob, bool, error) {
+	// Fail fast on malformed requests so clients don't have to poll for obvious errors
+	if err := validators.ValidateServerJSON(&req); err != nil {
+		return nil, false, err
+	}
+
+	return s.jobs.submit(owner, idempotencyKey, req, published)
+}
+
+// GetPublishJob returns the current state of an asynchronous publish job submitted by owner
+func (s *registryServiceImpl) GetPublishJob(owner, id string) (*PublishJob, error) {
+	return s.jobs.get(owner, id)
+}
+
+// preparePublish validates a publish request against the registry state and builds the record to store.
+// fileHashes controls whether package files are downloaded to record and verify their hashes.
+func (s *registryServiceImpl) preparePublish(ctx context.Context, req apiv0.ServerJSON, fileHashes bool) (*publishPlan, error) {
+	// Store names in canonical form, so lookups and uniqueness checks don't depend on how they were typed
+	req.Name = validators.NormalizeServerName(req.Name)
+
+	// Upstream checks rely on the HTTP client timeout rather than the database deadline
+	validationCtx := context.WithoutCancel(ctx)
+
+	// Validate the request, collecting every finding rather than stopping at the first
+	cfg := s.config()
+	report, err := validators.CheckPublishRequest(validationCtx, req, cfg)
+	if err != nil {
 		return nil, err
 	}
 
 	publishTime := time.Now()
 	serverJSON := req
 
+	// Pin OCI packages to the image digest their tag currently points to.
+	// Like registry validation, this relies on the HTTP client timeout rather than the database deadline.
+	if cfg.EnableRegistryValidation && serverJSON.Status != model.StatusDeleted {
+		serverJSON.Packages = slices.Clone(req.Packages)
+		if err := validators.ResolvePackageDigests(validationCtx, &serverJSON, fileHashes); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.RequireSemanticVersions && !IsSemanticVersion(serverJSON.Version) {
+		return nil, fmt.Errorf("%w: %q", ErrNonSemanticVersion, serverJSON.Version)
+	}
+
+	// Like registry validation, external scanners rely on their own timeout rather than the database deadline
+	if err := s.scanServer(context.Background(), &serverJSON); err != nil {
+		return nil, err
+	}
+
 	// Check for duplicate remote URLs
 	if err := s.validateNoDuplicateRemoteURLs(ctx, serverJSON); err != nil {
 		return nil, err
@@ -108,6 +344,19 @@ func (s *registryServiceImpl) Publish(req apiv0.ServerJSON) (*apiv0.ServerJSON,
 		}
 	}
 
+	// Only a server's first version introduces a new name
+	if len(existingServerVersions) == 0 {
+		if err := s.checkNameConflict(ctx, serverJSON.Name); err != nil {
+			return nil, err
+		}
+		if err := s.checkNamespaceQuota(ctx, serverJSON.Name); err != nil {
+			return nil, err
+		}
+		if err := s.checkSimilarName(ctx, serverJSON.Name); err != nil {
+			return nil, err
+		}
+	}
+
 	// Determine if this version should be marked as latest
 	existingLatest := s.getCurrentLatestVersion(existingServerVersions)
 	isNewLatest := true
@@ -127,43 +376,22 @@ func (s *registryServiceImpl) Publish(req apiv0.ServerJSON) (*apiv0.ServerJSON,
 	// Create complete server with metadata
 	server := serverJSON // Copy the input
 
-	// Initialize meta if not present
-	if server.Meta == nil {
-		server.Meta = &apiv0.ServerMeta{}
-	}
+	// Initialize meta if not present, dropping any metadata computed when serving records
+	server.Meta = withoutReadTimeMeta(server.Meta)
 
 	// Set registry metadata
 	server.Meta.Official = &apiv0.RegistryExtensions{
-		ID:          uuid.New().String(),
-		PublishedAt: publishTime,
-		UpdatedAt:   publishTime,
-		IsLatest:    isNewLatest,
-	}
-
-	// Create server in database
-	serverRecord, err := s.db.CreateServer(ctx, &server)
-	if err != nil {
-		return nil, err
-	}
-
-	// Mark previous latest as no longer latest
-	if isNewLatest && existingLatest != nil {
-		var existingLatestID string
-		if existingLatest.Meta != nil && existingLatest.Meta.Official != nil {
-			existingLatestID = existingLatest.Meta.Official.ID
-		}
-		if existingLatestID != "" {
-			// Update the existing server to set is_latest = false
-			existingLatest.Meta.Official.IsLatest = false
-			existingLatest.Meta.Official.UpdatedAt = time.Now()
-			if _, err := s.db.UpdateServer(ctx, existingLatestID, existingLatest); err != nil {
-				return nil, err
-			}
-		}
+		ID:           uuid.New().String(),
+		PublishedAt:  publishTime,
+		UpdatedAt:    publishTime,
+		IsLatest:     isNewLatest,
+		QualityScore: &report.QualityScore,
 	}
 
-	// Return the server record directly
-	return serverRecord, nil
+	return &publishPlan{
+		server: &server,
+		report: report,
+	}, nil
 }
 
 // validateNoDuplicateRemoteURLs checks that no other server is using the same remote URLs
@@ -200,28 +428,51 @@ func (s *registryServiceImpl) getCurrentLatestVersion(existingServerVersions []*
 	return nil
 }
 
-// EditServer updates an existing server with new details (admin operation)
-func (s *registryServiceImpl) EditServer(id string, req apiv0.ServerJSON) (*apiv0.ServerJSON, error) {
+// EditServer updates an existing server with new details (admin operation). If revision is non-zero, the
+// edit fails with database.ErrRevisionConflict unless the server is still at that revision.
+func (s *registryServiceImpl) EditServer(id string, req apiv0.ServerJSON, revision int64) (*apiv0.ServerJSON, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	// Validate the request
-	if err := validators.ValidatePublishRequest(req, s.cfg); err != nil {
+	// Validate the request, with the name in canonical form as on publish
+	req.Name = validators.NormalizeServerName(req.Name)
+	report, err := validators.CheckPublishRequest(context.Background(), req, s.config())
+	if err != nil {
 		return nil, err
 	}
 
 	serverJSON := req
+	if serverJSON.Meta != nil {
+		serverJSON.Meta = withoutReadTimeMeta(serverJSON.Meta)
+	}
 
 	// Check for duplicate remote URLs
 	if err := s.validateNoDuplicateRemoteURLs(ctx, serverJSON); err != nil {
 		return nil, err
 	}
 
-	// Update server in database
-	serverRecord, err := s.db.UpdateServer(ctx, id, &serverJSON)
+	// Keep the registry metadata of the stored record; editors can't supply it
+	current, err := s.db.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if current.Meta != nil && current.Meta.Official != nil {
+		official := *current.Meta.Official
+		official.UpdatedAt = time.Now()
+		official.QualityScore = &report.QualityScore
+		if serverJSON.Meta == nil {
+			serverJSON.Meta = &apiv0.ServerMeta{}
+		}
+		serverJSON.Meta.Official = &official
+	}
+
+	// Update server in database, unless it changed since the revision the editor read
+	serverRecord, err := s.db.UpdateServer(ctx, id, &serverJSON, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	s.readCache.invalidate(ctx)
 
 	// Return the server record directly
 	return serverRecord, nil
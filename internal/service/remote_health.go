@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// attachRemoteHealth adds the latest probe results for each server's remotes to its metadata
+func (s *registryServiceImpl) attachRemoteHealth(ctx context.Context, servers []apiv0.ServerJSON) {
+	if !s.config().RemoteProbeEnabled {
+		return
+	}
+
+	var urls []string
+	for _, server := range servers {
+		for _, remote := range server.Remotes {
+			urls = append(urls, remote.URL)
+		}
+	}
+	if len(urls) == 0 {
+		return
+	}
+
+	health, err := s.db.GetRemoteHealth(ctx, urls)
+	if err != nil {
+		// Health is informational, so serve the servers without it
+		log.Printf("Failed to load remote health: %v", err)
+		return
+	}
+
+	for i := range servers {
+		var remotes []apiv0.RemoteHealth
+		for _, remote := range servers[i].Remotes {
+			h, ok := health[remote.URL]
+			if !ok {
+				continue
+			}
+			remotes = append(remotes, apiv0.RemoteHealth{
+				URL:             h.URL,
+				Healthy:         h.LastError == "",
+				LastCheckedAt:   h.LastCheckedAt,
+				LastSeenHealthy: h.LastSeenHealthy,
+				LastError:       h.LastError,
+			})
+		}
+		if len(remotes) == 0 {
+			continue
+		}
+
+		// Copy the metadata rather than modifying what the database or cache holds
+		meta := apiv0.ServerMeta{}
+		if servers[i].Meta != nil {
+			meta = *servers[i].Meta
+		}
+		meta.Health = &apiv0.HealthExtensions{Remotes: remotes}
+		servers[i].Meta = &meta
+	}
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/scanner"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+var (
+	// ErrSuspiciousServer is returned when a publish-time scanner flags a server and the scan policy is "reject"
+	ErrSuspiciousServer = errors.New("server was flagged by a security scan")
+	// ErrScanFailed is returned when a scanner can't scan a server and the scan policy is "reject"
+	ErrScanFailed = errors.New("server could not be scanned")
+)
+
+// scanServer applies the configured scan policy to a server about to be published. Under "reject",
+// scanner failures also reject the publish, so an unavailable scanner can't be used to slip past it.
+func (s *registryServiceImpl) scanServer(ctx context.Context, server *apiv0.ServerJSON) error {
+	policy := s.config().ScanPolicy
+	if policy != config.ScanPolicyWarn && policy != config.ScanPolicyReject {
+		return nil
+	}
+	reject := policy == config.ScanPolicyReject
+
+	findings, err := scanner.Run(ctx, s.scanners, server)
+	if err != nil {
+		if reject {
+			return fmt.Errorf("%w: %w", ErrScanFailed, err)
+		}
+		log.Printf("Warning: failed to scan %s %s: %v", server.Name, server.Version, err)
+	}
+	if len(findings) == 0 {
+		return nil
+	}
+
+	descriptions := make([]string, 0, len(findings))
+	for _, finding := range findings {
+		descriptions = append(descriptions, finding.String())
+	}
+	if reject {
+		return fmt.Errorf("%w: %s", ErrSuspiciousServer, strings.Join(descriptions, "; "))
+	}
+	log.Printf("Warning: security scan flagged %s %s: %s", server.Name, server.Version, strings.Join(descriptions, "; "))
+	return nil
+}
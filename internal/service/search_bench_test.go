@@ -0,0 +1,35 @@
+package service_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func BenchmarkSearch(b *testing.B) {
+	registry := service.NewRegistryService(database.NewMemoryDB(), &config.Config{EnableRegistryValidation: false})
+	for i := range 2000 {
+		if _, err := registry.Publish(apiv0.ServerJSON{
+			Name:        fmt.Sprintf("io.github.example%d/server-%d", i%50, i),
+			Description: "A benchmark server",
+			Version:     "1.0.0",
+		}); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	query := "server-1"
+	latest := true
+	filter := &database.ServerFilter{SubstringName: &query, IsLatest: &latest}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, _, err := registry.List(filter, "", 100); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/storage"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// iconFileRe matches the file names icons are served under: the image's SHA256 and an extension
+var iconFileRe = regexp.MustCompile(`^[0-9a-f]{64}\.(png|svg)$`)
+
+// iconFile returns the file name an icon is stored and served under. Icons are content-addressed,
+// so a server's icon URL changes whenever its image does and can be cached indefinitely.
+func iconFile(sha, contentType string) string {
+	if contentType == validators.IconContentTypeSVG {
+		return sha + ".svg"
+	}
+	return sha + ".png"
+}
+
+// iconStorageKey returns the object storage key of an icon file
+func iconStorageKey(file string) string {
+	return "icons/" + file
+}
+
+// SetServerIcon sanitizes and stores an icon for the named server, replacing any previous one
+func (s *registryServiceImpl) SetServerIcon(updatedBy, serverName string, data []byte, contentType string) (*apiv0.IconExtensions, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if exists, err := s.serverExists(ctx, serverName); err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, database.ErrNotFound
+	}
+
+	sanitized, sniffedType, err := validators.SanitizeIcon(data, contentType)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256(sanitized)
+	sha := hex.EncodeToString(digest[:])
+
+	// Store the image before pointing the server at it, so the icon URL never dangles
+	if err := s.icons.Put(ctx, iconStorageKey(iconFile(sha, sniffedType)), sniffedType, sanitized); err != nil {
+		return nil, fmt.Errorf("failed to store icon: %w", err)
+	}
+
+	icon := &database.ServerIcon{
+		ServerName:  serverName,
+		ContentType: sniffedType,
+		SHA256:      sha,
+		UpdatedBy:   updatedBy,
+		UpdatedAt:   time.Now(),
+	}
+	if err := s.db.SetServerIcon(ctx, icon); err != nil {
+		return nil, err
+	}
+	return iconExtensions(icon), nil
+}
+
+// DeleteServerIcon removes the named server's icon. The image stays in object storage, since another
+// server may have uploaded the same one and clients may have cached its URL.
+func (s *registryServiceImpl) DeleteServerIcon(serverName string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return s.db.DeleteServerIcon(ctx, serverName)
+}
+
+// GetIcon retrieves an icon image by the file name it is served under
+func (s *registryServiceImpl) GetIcon(file string) ([]byte, string, error) {
+	if !iconFileRe.MatchString(file) {
+		return nil, "", database.ErrNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	data, contentType, err := s.icons.Get(ctx, iconStorageKey(file))
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, "", database.ErrNotFound
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load icon: %w", err)
+	}
+	return data, contentType, nil
+}
+
+// attachIcons adds a link to each server's uploaded icon to its metadata
+func (s *registryServiceImpl) attachIcons(ctx context.Context, servers []apiv0.ServerJSON) {
+	if len(servers) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(servers))
+	for _, server := range servers {
+		names = append(names, server.Name)
+	}
+
+	icons, err := s.db.GetServerIcons(ctx, names)
+	if err != nil {
+		// Icons are cosmetic, so serve the servers without them
+		log.Printf("Failed to load server icons: %v", err)
+		return
+	}
+
+	for i := range servers {
+		icon, ok := icons[servers[i].Name]
+		if !ok {
+			continue
+		}
+
+		// Copy the metadata rather than modifying what the database or cache holds
+		meta := apiv0.ServerMeta{}
+		if servers[i].Meta != nil {
+			meta = *servers[i].Meta
+		}
+		meta.Icon = iconExtensions(icon)
+		servers[i].Meta = &meta
+	}
+}
+
+func iconExtensions(icon *database.ServerIcon) *apiv0.IconExtensions {
+	return &apiv0.IconExtensions{
+		URL:         "/v0/icons/" + iconFile(icon.SHA256, icon.ContentType),
+		ContentType: icon.ContentType,
+		SHA256:      icon.SHA256,
+	}
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+)
+
+// ErrServerNameConflict is returned when a new server name differs from an existing one only in case
+var ErrServerNameConflict = errors.New("server name conflicts with an existing server")
+
+// checkNameConflict rejects the first publish of a name that matches an existing server case-insensitively or
+// with its namespace in punycode, so names like com.example/Server and com.example/server can't be registered
+// by different publishers
+func (s *registryServiceImpl) checkNameConflict(ctx context.Context, name string) error {
+	// Punycode and Unicode spellings of an internationalized namespace are the same namespace
+	for _, spelling := range validators.NamespaceSpellings(name) {
+		existing, _, err := s.db.List(ctx, &database.ServerFilter{NameFold: &spelling}, "", 1)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return fmt.Errorf("failed to check for conflicting server names: %w", err)
+		}
+		for _, server := range existing {
+			if server.Name != name {
+				return fmt.Errorf("%w: %q is another spelling of %q", ErrServerNameConflict, name, server.Name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// statsWindow is the length of the rolling windows usage statistics are reported over
+const statsWindow = 7 * 24 * time.Hour
+
+// RecordServerEvent counts an install or view of the named server
+func (s *registryServiceImpl) RecordServerEvent(serverName string, eventType database.ServerEventType) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if exists, err := s.serverExists(ctx, serverName); err != nil {
+		return err
+	} else if !exists {
+		return database.ErrNotFound
+	}
+
+	return s.db.RecordServerEvent(ctx, serverName, eventType, time.Now())
+}
+
+// attachStats adds each server's weekly install and view counts to its metadata. Counts are per server name,
+// so every version of a server reports the same statistics.
+func (s *registryServiceImpl) attachStats(ctx context.Context, servers []apiv0.ServerJSON) {
+	if len(servers) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(servers))
+	for _, server := range servers {
+		names = append(names, server.Name)
+	}
+
+	currentStart := database.WeeklyStatsStart(time.Now())
+	previousStart := currentStart.Add(-statsWindow)
+
+	counts, err := s.db.ListServerEventCounts(ctx, names, previousStart)
+	if err != nil {
+		// Statistics are informational, so serve the servers without them
+		log.Printf("Failed to load server statistics: %v", err)
+		return
+	}
+
+	stats := make(map[string]*apiv0.StatsExtensions, len(servers))
+	for _, name := range names {
+		stats[name] = &apiv0.StatsExtensions{}
+	}
+	for _, count := range counts {
+		serverStats, ok := stats[count.ServerName]
+		if !ok {
+			continue
+		}
+		current := !count.Day.Before(currentStart)
+		switch {
+		case count.EventType == database.ServerEventInstall && current:
+			serverStats.WeeklyInstalls += count.Count
+		case count.EventType == database.ServerEventInstall:
+			serverStats.PreviousWeeklyInstalls += count.Count
+		case count.EventType == database.ServerEventView && current:
+			serverStats.WeeklyViews += count.Count
+		}
+	}
+
+	for i := range servers {
+		serverStats := *stats[servers[i].Name]
+		switch {
+		case serverStats.WeeklyInstalls > serverStats.PreviousWeeklyInstalls:
+			serverStats.Trend = apiv0.StatsTrendUp
+		case serverStats.WeeklyInstalls < serverStats.PreviousWeeklyInstalls:
+			serverStats.Trend = apiv0.StatsTrendDown
+		default:
+			serverStats.Trend = apiv0.StatsTrendFlat
+		}
+
+		// Copy the metadata rather than modifying what the database or cache holds
+		meta := apiv0.ServerMeta{}
+		if servers[i].Meta != nil {
+			meta = *servers[i].Meta
+		}
+		meta.Stats = &serverStats
+		servers[i].Meta = &meta
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// ErrNonSemanticVersion is returned when publishing a non-semver version while semantic versions are required
+var ErrNonSemanticVersion = errors.New("version must be a semantic version (major.minor.patch, e.g. 1.2.3)")
+
+// ListServerVersions returns every version of the named server, highest first. The namespace of name is matched
+// case-insensitively. If versionRange is set, only
+// semantic versions within it are returned; other versions are treated as opaque strings that never match.
+func (s *registryServiceImpl) ListServerVersions(name, versionRange string) ([]apiv0.ServerJSON, error) {
+	var r *VersionRange
+	if versionRange != "" {
+		var err error
+		if r, err = ParseVersionRange(versionRange); err != nil {
+			return nil, err
+		}
+	}
+
+	name = validators.NormalizeServerName(name)
+	versions, _, err := s.List(&database.ServerFilter{Name: &name}, "", maxServerVersionsPerServer)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, database.ErrNotFound
+	}
+
+	result := make([]apiv0.ServerJSON, 0, len(versions))
+	for _, server := range versions {
+		if r == nil || r.Matches(server.Version) {
+			result = append(result, server)
+		}
+	}
+
+	slices.SortStableFunc(result, func(a, b apiv0.ServerJSON) int {
+		return CompareVersions(b.Version, a.Version, publishedAt(b), publishedAt(a))
+	})
+	return result, nil
+}
+
+// GetLatestServerVersion returns the highest version of the named server within versionRange, skipping deleted versions
+func (s *registryServiceImpl) GetLatestServerVersion(name, versionRange string) (*apiv0.ServerJSON, error) {
+	// The version marked latest is the highest, so unless it is deleted it is found without reading the others
+	if versionRange == "" {
+		if server, err := s.getLatestVersion(name); err != nil && !errors.Is(err, database.ErrNotFound) {
+			return nil, err
+		} else if server != nil && server.Status != model.StatusDeleted {
+			return server, nil
+		}
+	}
+
+	versions, err := s.ListServerVersions(name, versionRange)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, server := range versions {
+		if server.Status != model.StatusDeleted {
+			return &server, nil
+		}
+	}
+	return nil, database.ErrNotFound
+}
+
+// getLatestVersion returns the version of the named server marked latest, through the read cache
+func (s *registryServiceImpl) getLatestVersion(name string) (*apiv0.ServerJSON, error) {
+	// Like the read cache, these reads may be slightly stale
+	ctx, cancel := context.WithTimeout(database.WithReplicaReads(context.Background()), 5*time.Second)
+	defer cancel()
+
+	name = validators.NormalizeServerName(name)
+	serverRecord, err := lookup(ctx, s.readCache, "latest", name, func() (*apiv0.ServerJSON, error) {
+		return s.db.GetLatestVersion(ctx, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	servers := []apiv0.ServerJSON{*serverRecord}
+	s.attachReadTimeMeta(ctx, servers)
+	return &servers[0], nil
+}
+
+func publishedAt(server apiv0.ServerJSON) time.Time {
+	if server.Meta == nil || server.Meta.Official == nil {
+		return time.Time{}
+	}
+	return server.Meta.Official.PublishedAt
+}
@@ -1,6 +1,10 @@
 package service
 
 import (
+	"context"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 )
@@ -11,8 +15,84 @@ type RegistryService interface {
 	List(filter *database.ServerFilter, cursor string, limit int) ([]apiv0.ServerJSON, string, error)
 	// Retrieve a single server by registry metadata ID
 	GetByID(id string) (*apiv0.ServerJSON, error)
+	// Retrieve every version of a server matching an optional semver range, highest first
+	ListServerVersions(name, versionRange string) ([]apiv0.ServerJSON, error)
+	// Retrieve the highest non-deleted version of a server matching an optional semver range
+	GetLatestServerVersion(name, versionRange string) (*apiv0.ServerJSON, error)
+	// Retrieve the category taxonomy with the number of servers in each category
+	ListCategories() ([]CategoryCount, error)
+	// Count an install or view of a server, for the usage statistics reported on its records
+	RecordServerEvent(serverName string, eventType database.ServerEventType) error
+	// Upload a server's icon, replacing any previous one
+	SetServerIcon(updatedBy, serverName string, data []byte, contentType string) (*apiv0.IconExtensions, error)
+	// Remove a server's icon
+	DeleteServerIcon(serverName string) error
+	// Retrieve an uploaded icon image and its content type by file name (<sha256>.png or <sha256>.svg)
+	GetIcon(file string) ([]byte, string, error)
+	// File a security advisory against a server, linking it to the versions it affects
+	CreateAdvisory(createdBy, serverName string, advisory apiv0.Advisory) (*apiv0.Advisory, error)
+	// Retrieve a security advisory and the name of the server it was filed against
+	GetAdvisory(id string) (*apiv0.Advisory, string, error)
+	// Retrieve the security advisories filed against a server, oldest first
+	ListAdvisories(serverName string) ([]apiv0.Advisory, error)
+	// Withdraw a security advisory
+	DeleteAdvisory(id string) error
 	// Publish a server
 	Publish(req apiv0.ServerJSON) (*apiv0.ServerJSON, error)
-	// Update an existing server
-	EditServer(id string, req apiv0.ServerJSON) (*apiv0.ServerJSON, error)
+	// Publish a server at most once per owner and idempotency key, returning the stored response and true for retries
+	PublishIdempotent(owner, key string, req apiv0.ServerJSON) (*apiv0.ServerJSON, bool, error)
+	// Publish a server, or validate it in a dry run, returning the requests validators sent upstream even if it fails
+	PublishTraced(req apiv0.ServerJSON, dryRun, verifyFileHashes bool) (*apiv0.ServerJSON, []apiv0.ValidatorCall, error)
+	// Validate a publish request and return the would-be record without storing it, optionally downloading package files to verify their hashes
+	PublishDryRun(req apiv0.ServerJSON, verifyFileHashes bool) (*apiv0.ServerJSON, error)
+	// Queue a publish for owner to run in the background, calling published if it succeeds, returning the job used to track it and, with an idempotency key, true for retries
+	PublishAsync(owner, idempotencyKey string, req apiv0.ServerJSON, published func()) (*PublishJob, bool, error)
+	// Retrieve the status of an asynchronous publish job submitted by owner
+	GetPublishJob(owner, id string) (*PublishJob, error)
+	// Retrieve change feed events after the given sequence number, with the sequence to resume from
+	ListChanges(since int64, limit int) ([]apiv0.ChangeEvent, int64, error)
+	// Create a long-lived API key, returning the stored record and the key itself (shown only once)
+	CreateAPIKey(owner, name string, scopes []string, expiresAt time.Time) (*database.APIKey, string, error)
+	// Retrieve the API keys created by an owner
+	ListAPIKeys(owner string) ([]database.APIKey, error)
+	// Revoke one of an owner's API keys
+	RevokeAPIKey(owner, id string) error
+	// Look up a usable API key from its plaintext value
+	AuthenticateAPIKey(plaintext string) (*database.APIKey, error)
+	// Use up a single-use upload token, failing with ErrUploadTokenUsed if it was used before
+	RedeemUploadToken(id string, expiresAt time.Time) error
+	// Check whether a single-use upload token was already used
+	UploadTokenUsed(id string) (bool, error)
+	// Reserve a namespace so only owner can publish in it
+	ReserveNamespace(owner, namespace string) (*database.NamespaceReservation, error)
+	// Retrieve the reservation for a namespace
+	GetNamespaceReservation(namespace string) (*database.NamespaceReservation, error)
+	// Release one of owner's namespace reservations
+	ReleaseNamespace(owner, namespace string) error
+	// Check that a server name's namespace isn't reserved by someone other than owner
+	CheckNamespaceOwner(owner, serverName string) error
+	// Request moving every version of a server to a new name
+	RequestTransfer(owner, serverName, newName string) (*database.ServerTransfer, error)
+	// Accept a pending transfer on behalf of the recipient
+	AcceptTransfer(owner, id string) (*database.ServerTransfer, error)
+	// Cancel a transfer that hasn't completed yet
+	CancelTransfer(owner, id string) (*database.ServerTransfer, error)
+	// Retrieve a transfer and its audit trail
+	GetTransfer(id string) (*database.ServerTransfer, error)
+	// Expire stale transfers and complete accepted transfers whose grace period has passed
+	ProcessTransfers(ctx context.Context) (int, error)
+	// Block server names matching a pattern (admin operation)
+	AddBlockedName(createdBy, pattern, reason string) (*database.BlockedName, error)
+	// Retrieve the blocklist entries added through the admin API
+	ListBlockedNames() ([]database.BlockedName, error)
+	// Remove a blocklist entry added through the admin API
+	RemoveBlockedName(pattern string) error
+	// Reload the blocklist enforced by validators from configuration and the database
+	RefreshBlocklist(ctx context.Context) error
+	// Retrieve the current configuration, including reloaded settings
+	Config() *config.Config
+	// Switch to a reloaded configuration, applying its settings to subsequent requests
+	ReloadConfig(ctx context.Context, cfg *config.Config) error
+	// Update an existing server; a non-zero revision must match the stored record's
+	EditServer(id string, req apiv0.ServerJSON, revision int64) (*apiv0.ServerJSON, error)
 }
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+)
+
+// similarNamePageSize is how many latest server versions are read at a time when looking for similar names
+const similarNamePageSize = 1000
+
+// ErrSimilarServerName is returned when a new server name is confusingly similar to an existing one
+var ErrSimilarServerName = errors.New("server name is too similar to an existing server")
+
+// checkSimilarName applies the configured similar-name policy to the first publish of a server name,
+// to make typosquatting names like com.example/serv3r harder to register
+func (s *registryServiceImpl) checkSimilarName(ctx context.Context, name string) error {
+	cfg := s.config()
+	if cfg.SimilarNameCheck != config.SimilarNameCheckWarn && cfg.SimilarNameCheck != config.SimilarNameCheckReject {
+		return nil
+	}
+
+	names, err := s.listServerNames(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check for similar server names: %w", err)
+	}
+
+	similar, found := validators.FindSimilarServerName(name, names, cfg.SimilarNameThreshold)
+	if !found {
+		return nil
+	}
+	if cfg.SimilarNameCheck == config.SimilarNameCheckReject {
+		return fmt.Errorf("%w: %q looks like %q", ErrSimilarServerName, name, similar)
+	}
+	log.Printf("Warning: new server name %q looks similar to existing server %q", name, similar)
+	return nil
+}
+
+// listServerNames returns the name of every server in the registry
+func (s *registryServiceImpl) listServerNames(ctx context.Context) ([]string, error) {
+	isLatest := true
+	filter := &database.ServerFilter{IsLatest: &isLatest}
+
+	var names []string
+	cursor := ""
+	for {
+		servers, nextCursor, err := s.db.List(ctx, filter, cursor, similarNamePageSize)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return nil, err
+		}
+		for _, server := range servers {
+			names = append(names, server.Name)
+		}
+		if nextCursor == "" || len(servers) == 0 {
+			return names, nil
+		}
+		cursor = nextCursor
+	}
+}
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+)
+
+// transferRequestTTL is how long the recipient has to accept a transfer
+const transferRequestTTL = 7 * 24 * time.Hour
+
+var (
+	// ErrNamespaceReserved is returned when a namespace is reserved by a different identity
+	ErrNamespaceReserved = errors.New("namespace is reserved by another publisher")
+	// ErrInvalidTransfer is returned when a transfer request or action isn't allowed in the current state
+	ErrInvalidTransfer = errors.New("invalid transfer")
+)
+
+// ServerNamespace returns the namespace part of a server name, e.g. "io.github.example" for "io.github.example/server"
+func ServerNamespace(serverName string) string {
+	namespace, _, _ := strings.Cut(serverName, "/")
+	return namespace
+}
+
+// ReserveNamespace reserves namespace for owner, so no other identity can publish in it
+func (s *registryServiceImpl) ReserveNamespace(owner, namespace string) (*database.NamespaceReservation, error) {
+	if err := validators.ValidateServerName(namespace + "/server"); err != nil || strings.Contains(namespace, "*") {
+		return nil, fmt.Errorf("%w: namespace %q", database.ErrInvalidInput, namespace)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	reservation := &database.NamespaceReservation{
+		Namespace: namespace,
+		Owner:     owner,
+		CreatedAt: time.Now(),
+	}
+	if err := s.db.ReserveNamespace(ctx, reservation); err != nil {
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// GetNamespaceReservation returns the reservation for a namespace
+func (s *registryServiceImpl) GetNamespaceReservation(namespace string) (*database.NamespaceReservation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return s.db.GetNamespaceReservation(ctx, namespace)
+}
+
+// ReleaseNamespace removes owner's reservation of a namespace
+func (s *registryServiceImpl) ReleaseNamespace(owner, namespace string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return s.db.ReleaseNamespace(ctx, namespace, owner)
+}
+
+// CheckNamespaceOwner returns ErrNamespaceReserved if serverName's namespace is reserved by someone other than owner
+func (s *registryServiceImpl) CheckNamespaceOwner(owner, serverName string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return s.checkNamespaceOwner(ctx, owner, serverName)
+}
+
+func (s *registryServiceImpl) checkNamespaceOwner(ctx context.Context, owner, serverName string) error {
+	reservation, err := s.db.GetNamespaceReservation(ctx, ServerNamespace(serverName))
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if reservation.Owner != owner {
+		return ErrNamespaceReserved
+	}
+	return nil
+}
+
+// RequestTransfer starts moving every version of serverName to newName. The transfer waits for
+// an identity that can publish newName to accept it.
+func (s *registryServiceImpl) RequestTransfer(owner, serverName, newName string) (*database.ServerTransfer, error) {
+	if err := validators.ValidateServerName(newName); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTransfer, err)
+	}
+	if newName == serverName {
+		return nil, fmt.Errorf("%w: new name must differ from the current name", ErrInvalidTransfer)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.checkNamespaceOwner(ctx, owner, serverName); err != nil {
+		return nil, err
+	}
+
+	if exists, err := s.serverExists(ctx, serverName); err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, database.ErrNotFound
+	}
+	if exists, err := s.serverExists(ctx, newName); err != nil {
+		return nil, err
+	} else if exists {
+		return nil, fmt.Errorf("%w: server %s already exists", ErrInvalidTransfer, newName)
+	}
+
+	// Only one transfer per server may be in flight
+	for _, status := range []database.TransferStatus{database.TransferStatusPending, database.TransferStatusAccepted} {
+		transfers, err := s.db.ListTransfers(ctx, status)
+		if err != nil {
+			return nil, err
+		}
+		for _, transfer := range transfers {
+			if transfer.ServerName == serverName || transfer.NewName == newName {
+				return nil, fmt.Errorf("%w: transfer %s is already in progress", ErrInvalidTransfer, transfer.ID)
+			}
+		}
+	}
+
+	now := time.Now()
+	transfer := &database.ServerTransfer{
+		ID:          uuid.New().String(),
+		ServerName:  serverName,
+		NewName:     newName,
+		Status:      database.TransferStatusPending,
+		RequestedBy: owner,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(transferRequestTTL),
+		Events:      []database.TransferEvent{{Action: "requested", Actor: owner, At: now}},
+	}
+	if err := s.db.CreateTransfer(ctx, transfer); err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// AcceptTransfer accepts a pending transfer on behalf of the recipient. It completes once the grace period has passed.
+func (s *registryServiceImpl) AcceptTransfer(owner, id string) (*database.ServerTransfer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	transfer, err := s.db.GetTransfer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.Status != database.TransferStatusPending {
+		return nil, fmt.Errorf("%w: transfer is %s", ErrInvalidTransfer, transfer.Status)
+	}
+	if err := s.checkNamespaceOwner(ctx, owner, transfer.NewName); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if now.After(transfer.ExpiresAt) {
+		s.expireTransfer(ctx, transfer, now)
+		return nil, fmt.Errorf("%w: transfer expired", ErrInvalidTransfer)
+	}
+
+	completesAt := now.Add(s.config().TransferGracePeriod)
+	transfer.Status = database.TransferStatusAccepted
+	transfer.AcceptedBy = owner
+	transfer.CompletesAt = &completesAt
+	transfer.Events = append(transfer.Events, database.TransferEvent{Action: "accepted", Actor: owner, At: now})
+	if err := s.db.UpdateTransfer(ctx, transfer, database.TransferStatusPending); err != nil {
+		return nil, transferUpdateError(err)
+	}
+	return transfer, nil
+}
+
+// CancelTransfer cancels a transfer that hasn't completed yet. Either party may cancel.
+func (s *registryServiceImpl) CancelTransfer(owner, id string) (*database.ServerTransfer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	transfer, err := s.db.GetTransfer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if owner != transfer.RequestedBy && owner != transfer.AcceptedBy {
+		return nil, database.ErrNotFound
+	}
+	if transfer.Status != database.TransferStatusPending && transfer.Status != database.TransferStatusAccepted {
+		return nil, fmt.Errorf("%w: transfer is %s", ErrInvalidTransfer, transfer.Status)
+	}
+
+	previousStatus := transfer.Status
+	transfer.Status = database.TransferStatusCancelled
+	transfer.Events = append(transfer.Events, database.TransferEvent{Action: "cancelled", Actor: owner, At: time.Now()})
+	if err := s.db.UpdateTransfer(ctx, transfer, previousStatus); err != nil {
+		return nil, transferUpdateError(err)
+	}
+	return transfer, nil
+}
+
+// GetTransfer returns a transfer and its audit trail
+func (s *registryServiceImpl) GetTransfer(id string) (*database.ServerTransfer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return s.db.GetTransfer(ctx, id)
+}
+
+// ProcessTransfers expires pending transfers that weren't accepted in time and completes accepted
+// transfers whose grace period has passed, returning how many transfers were completed
+func (s *registryServiceImpl) ProcessTransfers(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	pending, err := s.db.ListTransfers(ctx, database.TransferStatusPending)
+	if err != nil {
+		return 0, err
+	}
+	for _, transfer := range pending {
+		if now.After(transfer.ExpiresAt) {
+			s.expireTransfer(ctx, transfer, now)
+		}
+	}
+
+	accepted, err := s.db.ListTransfers(ctx, database.TransferStatusAccepted)
+	if err != nil {
+		return 0, err
+	}
+	completed := 0
+	for _, transfer := range accepted {
+		if transfer.CompletesAt == nil || transfer.CompletesAt.After(now) {
+			continue
+		}
+		if err := s.completeTransfer(ctx, transfer); err != nil {
+			return completed, fmt.Errorf("failed to complete transfer %s: %w", transfer.ID, err)
+		}
+		completed++
+	}
+	return completed, nil
+}
+
+// completeTransfer renames every version of the transferred server
+func (s *registryServiceImpl) completeTransfer(ctx context.Context, transfer *database.ServerTransfer) error {
+	now := time.Now()
+
+	// The new name may have been taken since the transfer was requested
+	exists, err := s.serverExists(ctx, transfer.NewName)
+	if err != nil {
+		return err
+	}
+	if exists {
+		transfer.Status = database.TransferStatusCancelled
+		transfer.Events = append(transfer.Events, database.TransferEvent{
+			Action: "failed", Reason: "server " + transfer.NewName + " already exists", At: now,
+		})
+		return s.db.UpdateTransfer(ctx, transfer, database.TransferStatusAccepted)
+	}
+
+	versions, _, err := s.db.List(ctx, &database.ServerFilter{Name: &transfer.ServerName}, "", maxServerVersionsPerServer)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return err
+	}
+	defer s.readCache.invalidate(ctx)
+	for _, version := range versions {
+		renamed := *version
+		renamed.Name = transfer.NewName
+		if renamed.Meta != nil && renamed.Meta.Official != nil {
+			meta := *renamed.Meta
+			official := *meta.Official
+			official.UpdatedAt = now
+			meta.Official = &official
+			renamed.Meta = &meta
+		}
+		if _, err := s.db.UpdateServer(ctx, version.GetID(), &renamed, 0); err != nil {
+			return err
+		}
+	}
+
+	transfer.Status = database.TransferStatusCompleted
+	transfer.Events = append(transfer.Events, database.TransferEvent{Action: "completed", At: now})
+	return s.db.UpdateTransfer(ctx, transfer, database.TransferStatusAccepted)
+}
+
+// expireTransfer marks a pending transfer as expired. Failures are left for the next pass to retry.
+func (s *registryServiceImpl) expireTransfer(ctx context.Context, transfer *database.ServerTransfer, now time.Time) {
+	transfer.Status = database.TransferStatusExpired
+	transfer.Events = append(transfer.Events, database.TransferEvent{Action: "expired", At: now})
+	_ = s.db.UpdateTransfer(ctx, transfer, database.TransferStatusPending)
+}
+
+func (s *registryServiceImpl) serverExists(ctx context.Context, name string) (bool, error) {
+	servers, _, err := s.db.List(ctx, &database.ServerFilter{Name: &name}, "", 1)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return false, err
+	}
+	return len(servers) > 0, nil
+}
+
+// transferUpdateError reports a transfer that changed state while being updated
+func transferUpdateError(err error) error {
+	if errors.Is(err, database.ErrNotFound) {
+		return fmt.Errorf("%w: transfer was modified concurrently", ErrInvalidTransfer)
+	}
+	return err
+}
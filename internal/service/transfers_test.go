@@ -0,0 +1,151 @@
+//nolint:testpackage
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceReservation(t *testing.T) {
+	svc := NewRegistryService(database.NewMemoryDB(), &config.Config{})
+
+	require.NoError(t, svc.CheckNamespaceOwner("github-at:someone", "io.github.example/server"))
+
+	reservation, err := svc.ReserveNamespace("github-at:example", "io.github.example")
+	require.NoError(t, err)
+	assert.Equal(t, "github-at:example", reservation.Owner)
+
+	_, err = svc.ReserveNamespace("github-at:someone", "io.github.example")
+	require.ErrorIs(t, err, database.ErrAlreadyExists)
+
+	_, err = svc.ReserveNamespace("github-at:someone", "io.github.*")
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+
+	require.NoError(t, svc.CheckNamespaceOwner("github-at:example", "io.github.example/server"))
+	require.ErrorIs(t, svc.CheckNamespaceOwner("github-at:someone", "io.github.example/server"), ErrNamespaceReserved)
+	require.NoError(t, svc.CheckNamespaceOwner("github-at:someone", "io.github.example-other/server"))
+
+	require.ErrorIs(t, svc.ReleaseNamespace("github-at:someone", "io.github.example"), database.ErrNotFound)
+	require.NoError(t, svc.ReleaseNamespace("github-at:example", "io.github.example"))
+	require.NoError(t, svc.CheckNamespaceOwner("github-at:someone", "io.github.example/server"))
+}
+
+func TestServerTransfer(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewMemoryDB()
+	svc := NewRegistryService(db, &config.Config{TransferGracePeriod: time.Hour})
+	impl, ok := svc.(*registryServiceImpl)
+	require.True(t, ok)
+
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		_, err := svc.Publish(apiv0.ServerJSON{Name: "io.github.oldorg/server", Description: "A server", Version: version})
+		require.NoError(t, err)
+	}
+
+	t.Run("rejects invalid requests", func(t *testing.T) {
+		_, err := svc.RequestTransfer("github-at:oldorg", "io.github.oldorg/missing", "io.github.neworg/missing")
+		require.ErrorIs(t, err, database.ErrNotFound)
+
+		_, err = svc.RequestTransfer("github-at:oldorg", "io.github.oldorg/server", "io.github.oldorg/server")
+		require.ErrorIs(t, err, ErrInvalidTransfer)
+
+		_, err = svc.RequestTransfer("github-at:oldorg", "io.github.oldorg/server", "not-a-server-name")
+		require.ErrorIs(t, err, ErrInvalidTransfer)
+	})
+
+	transfer, err := svc.RequestTransfer("github-at:oldorg", "io.github.oldorg/server", "io.github.neworg/server")
+	require.NoError(t, err)
+	assert.Equal(t, database.TransferStatusPending, transfer.Status)
+
+	_, err = svc.RequestTransfer("github-at:oldorg", "io.github.oldorg/server", "io.github.other/server")
+	require.ErrorIs(t, err, ErrInvalidTransfer, "only one transfer per server may be in flight")
+
+	// A reservation of the receiving namespace by someone else blocks acceptance
+	_, err = svc.ReserveNamespace("github-at:squatter", "io.github.neworg")
+	require.NoError(t, err)
+	_, err = svc.AcceptTransfer("github-at:neworg", transfer.ID)
+	require.ErrorIs(t, err, ErrNamespaceReserved)
+	require.NoError(t, svc.ReleaseNamespace("github-at:squatter", "io.github.neworg"))
+
+	accepted, err := svc.AcceptTransfer("github-at:neworg", transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, database.TransferStatusAccepted, accepted.Status)
+	require.NotNil(t, accepted.CompletesAt)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), *accepted.CompletesAt, time.Minute)
+
+	// Nothing happens during the grace period
+	completed, err := svc.ProcessTransfers(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, completed)
+
+	// Skip to the end of the grace period
+	past := time.Now().Add(-time.Second)
+	accepted.CompletesAt = &past
+	require.NoError(t, db.UpdateTransfer(ctx, accepted, database.TransferStatusAccepted))
+
+	completed, err = impl.ProcessTransfers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, completed)
+
+	oldName, newName := "io.github.oldorg/server", "io.github.neworg/server"
+	old, _, err := svc.List(&database.ServerFilter{Name: &oldName}, "", 10)
+	require.NoError(t, err)
+	assert.Empty(t, old)
+	renamed, _, err := svc.List(&database.ServerFilter{Name: &newName}, "", 10)
+	require.NoError(t, err)
+	assert.Len(t, renamed, 2)
+
+	final, err := svc.GetTransfer(transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, database.TransferStatusCompleted, final.Status)
+	actions := make([]string, len(final.Events))
+	for i, event := range final.Events {
+		actions[i] = event.Action
+	}
+	assert.Equal(t, []string{"requested", "accepted", "completed"}, actions)
+	assert.Equal(t, "github-at:oldorg", final.Events[0].Actor)
+	assert.Equal(t, "github-at:neworg", final.Events[1].Actor)
+
+	_, err = svc.CancelTransfer("github-at:oldorg", transfer.ID)
+	require.ErrorIs(t, err, ErrInvalidTransfer, "completed transfers can't be cancelled")
+}
+
+func TestServerTransfer_CancelAndExpire(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewMemoryDB()
+	svc := NewRegistryService(db, &config.Config{})
+
+	_, err := svc.Publish(apiv0.ServerJSON{Name: "com.example/server", Description: "A server", Version: "1.0.0"})
+	require.NoError(t, err)
+
+	transfer, err := svc.RequestTransfer("dns:example.com", "com.example/server", "com.example-new/server")
+	require.NoError(t, err)
+
+	_, err = svc.CancelTransfer("dns:someone-else.com", transfer.ID)
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	cancelled, err := svc.CancelTransfer("dns:example.com", transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, database.TransferStatusCancelled, cancelled.Status)
+
+	_, err = svc.AcceptTransfer("dns:example-new.com", transfer.ID)
+	require.ErrorIs(t, err, ErrInvalidTransfer)
+
+	// Unaccepted transfers expire; the in-memory database stores the whole record, so expiry can be rewound
+	transfer, err = svc.RequestTransfer("dns:example.com", "com.example/server", "com.example-new/server")
+	require.NoError(t, err)
+	transfer.ExpiresAt = time.Now().Add(-time.Second)
+	require.NoError(t, db.UpdateTransfer(ctx, transfer, database.TransferStatusPending))
+	_, err = svc.ProcessTransfers(ctx)
+	require.NoError(t, err)
+	expired, err := svc.GetTransfer(transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, database.TransferStatusExpired, expired.Status)
+}
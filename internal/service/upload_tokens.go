@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// ErrUploadTokenUsed is returned when a single-use upload token is presented again
+var ErrUploadTokenUsed = errors.New("upload token was already used")
+
+// RedeemUploadToken uses up the upload token with the given ID, which is remembered until it expires
+func (s *registryServiceImpl) RedeemUploadToken(id string, expiresAt time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.db.UseUploadToken(ctx, id, expiresAt); err != nil {
+		if errors.Is(err, database.ErrAlreadyExists) {
+			return ErrUploadTokenUsed
+		}
+		return err
+	}
+	return nil
+}
+
+// UploadTokenUsed reports whether the upload token with the given ID was already used
+func (s *registryServiceImpl) UploadTokenUsed(id string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return s.db.UploadTokenUsed(ctx, id)
+}
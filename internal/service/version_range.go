@@ -0,0 +1,280 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// ErrInvalidVersionRange is returned when a version range can't be parsed
+var ErrInvalidVersionRange = errors.New("invalid version range")
+
+// VersionRange is a set of semantic version constraints in the style of npm ranges, e.g. "^1.2",
+// "~1.2.3", ">=1.0.0 <2.0.0", "1.x" or "^1.0.0 || ^2.0.0". Versions that aren't valid semver never match.
+type VersionRange struct {
+	sets [][]versionComparator // a version matches if it satisfies every comparator in any set
+}
+
+type versionComparator struct {
+	op      string // one of "=", ">", ">=", "<", "<="
+	version string // canonical semver with a "v" prefix
+	// explicit is set for prerelease versions named in the range itself, which opt in
+	// to matching prereleases of the same major.minor.patch
+	explicit bool
+}
+
+// partialVersion is a version with optional minor and patch parts; -1 marks a missing or wildcard part
+type partialVersion struct {
+	major, minor, patch int
+	prerelease          string
+}
+
+// ParseVersionRange parses an npm-style version range
+func ParseVersionRange(expr string) (*VersionRange, error) {
+	r := &VersionRange{}
+	for _, setExpr := range strings.Split(expr, "||") {
+		set, err := parseComparatorSet(strings.TrimSpace(setExpr))
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %w", ErrInvalidVersionRange, expr, err)
+		}
+		r.sets = append(r.sets, set)
+	}
+	return r, nil
+}
+
+// Matches reports whether version satisfies the range
+func (r *VersionRange) Matches(version string) bool {
+	if !IsSemanticVersion(version) {
+		return false
+	}
+	v := semver.Canonical(ensureVPrefix(version))
+
+	for _, set := range r.sets {
+		if setMatches(set, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func setMatches(set []versionComparator, v string) bool {
+	for _, c := range set {
+		if !c.matches(v) {
+			return false
+		}
+	}
+	if semver.Prerelease(v) == "" {
+		return true
+	}
+
+	// Prereleases only match when the range names a prerelease of the same release
+	for _, c := range set {
+		if c.explicit && releaseOf(c.version) == releaseOf(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c versionComparator) matches(v string) bool {
+	cmp := semver.Compare(v, c.version)
+	switch c.op {
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	default:
+		return cmp == 0
+	}
+}
+
+func parseComparatorSet(expr string) ([]versionComparator, error) {
+	fields := strings.Fields(expr)
+	if len(fields) == 0 {
+		return []versionComparator{}, nil // matches any release
+	}
+
+	var set []versionComparator
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		// Allow a space between an operator and its version, e.g. ">= 1.2.0"
+		if isRangeOperator(field) && i+1 < len(fields) {
+			i++
+			field += fields[i]
+		}
+
+		comparators, err := parseComparator(field)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, comparators...)
+	}
+	return set, nil
+}
+
+func isRangeOperator(s string) bool {
+	switch s {
+	case "^", "~", "=", ">", ">=", "<", "<=":
+		return true
+	}
+	return false
+}
+
+// parseComparator expands a single range term into primitive comparators
+func parseComparator(term string) ([]versionComparator, error) {
+	op := ""
+	for _, prefix := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(term, prefix) {
+			op = prefix
+			term = term[len(prefix):]
+			break
+		}
+	}
+
+	if op != "" && term == "" {
+		return nil, fmt.Errorf("operator %q is missing a version", op)
+	}
+
+	p, err := parsePartialVersion(term)
+	if err != nil {
+		return nil, err
+	}
+	if p.major < 0 {
+		// "*" matches everything, and so do comparisons like ">=*"; "<*" matches nothing
+		if op == "<" || op == ">" {
+			return []versionComparator{{op: "<", version: "v0.0.0-0"}}, nil
+		}
+		return []versionComparator{}, nil
+	}
+
+	lower := p.lowerBound()
+	switch op {
+	case "^":
+		return []versionComparator{{op: ">=", version: lower, explicit: p.prerelease != ""}, {op: "<", version: p.caretUpperBound()}}, nil
+	case "~":
+		return []versionComparator{{op: ">=", version: lower, explicit: p.prerelease != ""}, {op: "<", version: p.tildeUpperBound()}}, nil
+	case ">=":
+		return []versionComparator{{op: ">=", version: lower, explicit: p.prerelease != ""}}, nil
+	case "<":
+		return []versionComparator{{op: "<", version: lower, explicit: p.prerelease != ""}}, nil
+	case ">":
+		if p.isPartial() {
+			return []versionComparator{{op: ">=", version: p.nextRelease()}}, nil
+		}
+		return []versionComparator{{op: ">", version: lower, explicit: p.prerelease != ""}}, nil
+	case "<=":
+		if p.isPartial() {
+			return []versionComparator{{op: "<", version: p.nextRelease()}}, nil
+		}
+		return []versionComparator{{op: "<=", version: lower, explicit: p.prerelease != ""}}, nil
+	default:
+		if p.isPartial() {
+			// "1.2" and "1.2.x" mean any 1.2 release
+			return []versionComparator{{op: ">=", version: lower}, {op: "<", version: p.nextRelease()}}, nil
+		}
+		return []versionComparator{{op: "=", version: lower, explicit: p.prerelease != ""}}, nil
+	}
+}
+
+func parsePartialVersion(s string) (partialVersion, error) {
+	s = strings.TrimPrefix(s, "v")
+	p := partialVersion{major: -1, minor: -1, patch: -1}
+	if s == "" || s == "*" || s == "x" || s == "X" {
+		return p, nil
+	}
+
+	// Build metadata never affects matching
+	if idx := strings.Index(s, "+"); idx != -1 {
+		s = s[:idx]
+	}
+	if idx := strings.Index(s, "-"); idx != -1 {
+		p.prerelease = s[idx+1:]
+		s = s[:idx]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return p, fmt.Errorf("version %q has more than three parts", s)
+	}
+	numbers := []*int{&p.major, &p.minor, &p.patch}
+	wildcard := false
+	for i, part := range parts {
+		if part == "x" || part == "X" || part == "*" {
+			wildcard = true
+			continue
+		}
+		if wildcard {
+			return p, fmt.Errorf("version %q has a number after a wildcard", s)
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return p, fmt.Errorf("version part %q is not a number", part)
+		}
+		*numbers[i] = n
+	}
+
+	if p.prerelease != "" && p.isPartial() {
+		return p, fmt.Errorf("prerelease %q requires a full major.minor.patch version", p.prerelease)
+	}
+	if p.prerelease != "" && !semver.IsValid(p.lowerBound()) {
+		return p, fmt.Errorf("invalid prerelease %q", p.prerelease)
+	}
+	return p, nil
+}
+
+func (p partialVersion) isPartial() bool {
+	return p.minor < 0 || p.patch < 0
+}
+
+// lowerBound is the lowest version the partial version covers
+func (p partialVersion) lowerBound() string {
+	v := fmt.Sprintf("v%d.%d.%d", p.major, max(p.minor, 0), max(p.patch, 0))
+	if p.prerelease != "" {
+		v += "-" + p.prerelease
+	}
+	return v
+}
+
+// nextRelease is the first version after everything the partial version covers, before any of its prereleases
+func (p partialVersion) nextRelease() string {
+	switch {
+	case p.minor < 0:
+		return fmt.Sprintf("v%d.0.0-0", p.major+1)
+	case p.patch < 0:
+		return fmt.Sprintf("v%d.%d.0-0", p.major, p.minor+1)
+	default:
+		return fmt.Sprintf("v%d.%d.%d-0", p.major, p.minor, p.patch+1)
+	}
+}
+
+// caretUpperBound allows changes that don't modify the left-most non-zero part
+func (p partialVersion) caretUpperBound() string {
+	switch {
+	case p.major > 0 || p.minor < 0:
+		return fmt.Sprintf("v%d.0.0-0", p.major+1)
+	case p.minor > 0 || p.patch < 0:
+		return fmt.Sprintf("v0.%d.0-0", p.minor+1)
+	default:
+		return fmt.Sprintf("v0.0.%d-0", p.patch+1)
+	}
+}
+
+// tildeUpperBound allows patch-level changes, or minor-level changes if only a major version is given
+func (p partialVersion) tildeUpperBound() string {
+	if p.minor < 0 {
+		return fmt.Sprintf("v%d.0.0-0", p.major+1)
+	}
+	return fmt.Sprintf("v%d.%d.0-0", p.major, p.minor+1)
+}
+
+// releaseOf strips the prerelease and build metadata from a canonical version
+func releaseOf(v string) string {
+	return strings.TrimSuffix(semver.Canonical(v), semver.Prerelease(v))
+}
@@ -1,10 +1,14 @@
 package service_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 )
 
 func TestIsSemanticVersion(t *testing.T) {
@@ -162,3 +166,68 @@ func TestCompareVersions(t *testing.T) {
 		})
 	}
 }
+
+func TestVersionRangeMatches(t *testing.T) {
+	tests := []struct {
+		rangeExpr string
+		matches   []string
+		rejects   []string
+	}{
+		{"^1.2", []string{"1.2.0", "1.9.9", "v1.3.0"}, []string{"1.1.9", "2.0.0", "2.0.0-rc.1", "1.5.0-beta"}},
+		{"^0.2.3", []string{"0.2.3", "0.2.9"}, []string{"0.3.0", "0.2.2"}},
+		{"^0.0.3", []string{"0.0.3"}, []string{"0.0.4"}},
+		{"~1.2.3", []string{"1.2.3", "1.2.10"}, []string{"1.3.0", "1.2.2"}},
+		{"~1", []string{"1.0.0", "1.9.0"}, []string{"2.0.0"}},
+		{">=1.0.0 <2.0.0", []string{"1.0.0", "1.99.0"}, []string{"0.9.0", "2.0.0"}},
+		{">= 1.0.0", []string{"1.0.0", "10.0.0"}, []string{"0.1.0"}},
+		{">1.2", []string{"1.3.0"}, []string{"1.2.5"}},
+		{"<=1.2", []string{"1.2.9"}, []string{"1.3.0"}},
+		{"1.2.x", []string{"1.2.0", "1.2.7"}, []string{"1.3.0"}},
+		{"1.2.3", []string{"1.2.3", "v1.2.3"}, []string{"1.2.4"}},
+		{"*", []string{"0.0.1", "3.0.0"}, []string{"1.0.0-alpha"}},
+		{"", []string{"1.0.0"}, []string{"1.0.0-alpha"}},
+		{"^1.0.0 || ^3.0.0", []string{"1.4.0", "3.1.0"}, []string{"2.0.0"}},
+		{"^1.2.3-beta.2", []string{"1.2.3-beta.3", "1.2.3", "1.4.0"}, []string{"1.2.3-beta.1", "1.4.0-beta.1"}},
+		{"^1", nil, []string{"latest", "1.0", "nightly-2025-01-01"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rangeExpr, func(t *testing.T) {
+			r, err := service.ParseVersionRange(tt.rangeExpr)
+			if err != nil {
+				t.Fatalf("ParseVersionRange(%q) error = %v", tt.rangeExpr, err)
+			}
+			for _, v := range tt.matches {
+				if !r.Matches(v) {
+					t.Errorf("range %q should match %q", tt.rangeExpr, v)
+				}
+			}
+			for _, v := range tt.rejects {
+				if r.Matches(v) {
+					t.Errorf("range %q should not match %q", tt.rangeExpr, v)
+				}
+			}
+		})
+	}
+}
+
+func TestParseVersionRangeInvalid(t *testing.T) {
+	for _, expr := range []string{"^banana", "1.2.3.4", "1.x.3", ">=1.2-beta", "~"} {
+		if _, err := service.ParseVersionRange(expr); !errors.Is(err, service.ErrInvalidVersionRange) {
+			t.Errorf("ParseVersionRange(%q) error = %v, want ErrInvalidVersionRange", expr, err)
+		}
+	}
+}
+
+func TestPublishRequireSemanticVersions(t *testing.T) {
+	registry := service.NewRegistryService(database.NewMemoryDB(), &config.Config{RequireSemanticVersions: true})
+
+	_, err := registry.Publish(apiv0.ServerJSON{Name: "com.example/strict", Description: "Strict server", Version: "nightly"})
+	if !errors.Is(err, service.ErrNonSemanticVersion) {
+		t.Fatalf("Publish() error = %v, want ErrNonSemanticVersion", err)
+	}
+
+	if _, err := registry.Publish(apiv0.ServerJSON{Name: "com.example/strict", Description: "Strict server", Version: "1.0.0"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+}
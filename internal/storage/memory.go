@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+	"sync"
+)
+
+type memoryObject struct {
+	data        []byte
+	contentType string
+}
+
+// MemoryStore is an in-process Store implementation. Objects are lost on restart,
+// so it is only suitable for development and single-replica deployments.
+type MemoryStore struct {
+	objects map[string]memoryObject
+	mu      sync.RWMutex
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{objects: make(map[string]memoryObject)}
+}
+
+func (s *MemoryStore) Put(_ context.Context, key, contentType string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[key] = memoryObject{data: append([]byte(nil), data...), contentType: contentType}
+	return nil
+}
+
+func (s *MemoryStore) Get(_ context.Context, key string) ([]byte, string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	obj, ok := s.objects[key]
+	if !ok {
+		return nil, "", ErrNotFound
+	}
+	return append([]byte(nil), obj.data...), obj.contentType, nil
+}
+
+func (s *MemoryStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects, key)
+	return nil
+}
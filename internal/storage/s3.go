@@ -0,0 +1,186 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/sigv4"
+)
+
+const (
+	s3RequestTimeout = 30 * time.Second
+	s3MaxObjectSize  = 16 << 20
+	gcsEndpoint      = "https://storage.googleapis.com"
+)
+
+// S3Store is a Store backed by an S3-compatible bucket. It signs requests with AWS
+// Signature Version 4 itself, so the registry doesn't need a cloud SDK to store a
+// handful of small objects. Objects are addressed path-style (endpoint/bucket/key).
+type S3Store struct {
+	endpoint        *url.URL
+	bucket          string
+	prefix          string
+	region          string
+	accessKeyID     string
+	secretAccessKey string
+	client          *http.Client
+	now             func() time.Time
+}
+
+// NewS3Store creates a store from an s3://bucket[/prefix][?region=...&endpoint=...] or
+// gs://bucket[/prefix] URL. S3 buckets default to us-east-1 and the regional AWS endpoint;
+// set endpoint for other S3-compatible services such as MinIO or R2.
+func NewS3Store(rawURL, accessKeyID, secretAccessKey string) (*S3Store, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid storage URL: %w", err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("storage URL must include a bucket: %s", rawURL)
+	}
+	if accessKeyID == "" || secretAccessKey == "" {
+		return nil, fmt.Errorf("%s storage requires an access key ID and secret access key", u.Scheme)
+	}
+
+	query := u.Query()
+	region := query.Get("region")
+	endpoint := query.Get("endpoint")
+	switch u.Scheme {
+	case "s3":
+		if region == "" {
+			region = "us-east-1"
+		}
+		if endpoint == "" {
+			endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", region)
+		}
+	case "gs":
+		// Google Cloud Storage accepts SigV4-signed requests made with HMAC keys
+		region = "auto"
+		if endpoint == "" {
+			endpoint = gcsEndpoint
+		}
+	default:
+		return nil, fmt.Errorf("invalid storage URL scheme: %s", u.Scheme)
+	}
+
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil || endpointURL.Host == "" || (endpointURL.Scheme != "https" && endpointURL.Scheme != "http") {
+		return nil, fmt.Errorf("invalid storage endpoint: %s", endpoint)
+	}
+
+	return &S3Store{
+		endpoint:        endpointURL,
+		bucket:          u.Host,
+		prefix:          strings.Trim(u.Path, "/"),
+		region:          region,
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+		client:          &http.Client{Timeout: s3RequestTimeout},
+		now:             time.Now,
+	}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key, contentType string, data []byte) error {
+	req, err := s.newRequest(ctx, http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := s.do(req, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return s.responseError(resp)
+	}
+	return nil
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) ([]byte, string, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	resp, err := s.do(req, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, "", ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", s.responseError(resp)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, s3MaxObjectSize))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read object %s: %w", key, err)
+	}
+	return data, resp.Header.Get("Content-Type"), nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// S3 answers 204 whether or not the object existed; other services may answer 404
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return s.responseError(resp)
+	}
+	return nil
+}
+
+func (s *S3Store) newRequest(ctx context.Context, method, key string, body []byte) (*http.Request, error) {
+	objectPath := "/" + s.bucket + "/"
+	if s.prefix != "" {
+		objectPath += s.prefix + "/"
+	}
+	objectPath += strings.TrimPrefix(key, "/")
+
+	u := *s.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + objectPath
+	u.RawPath = ""
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create storage request: %w", err)
+	}
+	req.ContentLength = int64(len(body))
+	return req, nil
+}
+
+func (s *S3Store) do(req *http.Request, body []byte) (*http.Response, error) {
+	signRequest(req, body, s.accessKeyID, s.secretAccessKey, s.region, s.now().UTC())
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("storage request failed: %w", err)
+	}
+	return resp, nil
+}
+
+func (s *S3Store) responseError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("storage request %s %s failed with status %d: %s",
+		resp.Request.Method, resp.Request.URL.Path, resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// signRequest adds AWS Signature Version 4 headers to req for S3, signing the host and every header already set
+func signRequest(req *http.Request, body []byte, accessKeyID, secretAccessKey, region string, now time.Time) {
+	sigv4.Sign(req, body, sigv4.Credentials{AccessKeyID: accessKeyID, SecretAccessKey: secretAccessKey}, region, "s3", now)
+}
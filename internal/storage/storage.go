@@ -0,0 +1,39 @@
+// Package storage provides a minimal object store for registry-hosted assets
+// such as server icons, backed either by process memory or by an S3-compatible
+// bucket (Amazon S3, or Google Cloud Storage through its XML interoperability API).
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNotFound is returned when an object doesn't exist
+var ErrNotFound = errors.New("object not found")
+
+// Store is a key/value object store
+type Store interface {
+	// Put stores data under key with the given content type, replacing any existing object
+	Put(ctx context.Context, key, contentType string, data []byte) error
+	// Get returns the object stored under key and its content type, or ErrNotFound
+	Get(ctx context.Context, key string) ([]byte, string, error)
+	// Delete removes the object stored under key, ignoring keys that don't exist
+	Delete(ctx context.Context, key string) error
+}
+
+// New creates a store from a URL: an empty URL or "memory://" returns an in-memory store,
+// "s3://bucket[/prefix][?region=...&endpoint=...]" an S3 bucket and "gs://bucket[/prefix]"
+// a Google Cloud Storage bucket. Buckets are accessed with the given access key, which for
+// Google Cloud Storage must be an HMAC key.
+func New(rawURL, accessKeyID, secretAccessKey string) (Store, error) {
+	switch {
+	case rawURL == "" || rawURL == "memory://":
+		return NewMemoryStore(), nil
+	case strings.HasPrefix(rawURL, "s3://"), strings.HasPrefix(rawURL, "gs://"):
+		return NewS3Store(rawURL, accessKeyID, secretAccessKey)
+	default:
+		return nil, fmt.Errorf("unsupported storage URL scheme: %s", rawURL)
+	}
+}
@@ -0,0 +1,62 @@
+package validators
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+)
+
+var (
+	blocklistMu      sync.RWMutex
+	blocklist        []string
+	blocklistSources = map[string][]string{}
+)
+
+// ConfigureBlocklist replaces the patterns no server name may match. Patterns containing a "/" match whole
+// names using permission pattern syntax (e.g. "com.example/*"); other patterns are words blocked anywhere in a name.
+// Matching is case-insensitive.
+func ConfigureBlocklist(patterns []string) {
+	ConfigureBlocklistSource("", patterns)
+}
+
+// ConfigureBlocklistSource replaces the patterns contributed by one source, such as a tenant's registry. Names
+// are blocked if they match a pattern from any source, so blocking applies across every tenant of a deployment.
+func ConfigureBlocklistSource(source string, patterns []string) {
+	normalized := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		if pattern = strings.ToLower(strings.TrimSpace(pattern)); pattern != "" {
+			normalized = append(normalized, pattern)
+		}
+	}
+
+	blocklistMu.Lock()
+	defer blocklistMu.Unlock()
+	blocklistSources[source] = normalized
+
+	blocklist = nil
+	for _, sourcePatterns := range blocklistSources {
+		blocklist = append(blocklist, sourcePatterns...)
+	}
+}
+
+// CheckBlockedName returns an error if name matches a blocklist pattern
+func CheckBlockedName(name string) error {
+	blocklistMu.RLock()
+	defer blocklistMu.RUnlock()
+
+	lower := strings.ToLower(name)
+	for _, pattern := range blocklist {
+		var blocked bool
+		if strings.Contains(pattern, "/") {
+			blocked = auth.MatchResourcePattern(lower, pattern)
+		} else {
+			blocked = strings.Contains(lower, pattern)
+		}
+		if blocked {
+			return fmt.Errorf("%w: %s", ErrBlockedServerName, name)
+		}
+	}
+	return nil
+}
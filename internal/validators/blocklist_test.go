@@ -0,0 +1,40 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckBlockedName(t *testing.T) {
+	validators.ConfigureBlocklist([]string{"com.anthropic/*", "com.example.*/*", " Exact.Name/server ", "", "slur"})
+	t.Cleanup(func() { validators.ConfigureBlocklist(nil) })
+
+	tests := []struct {
+		name    string
+		blocked bool
+	}{
+		{"com.anthropic/claude", true},
+		{"COM.Anthropic/claude", true},
+		{"com.anthropicx/claude", false},
+		{"com.example.api/server", true},
+		{"com.example/server", false},
+		{"exact.name/server", true},
+		{"exact.name/server2", false},
+		{"io.github.someone/no-slurs-here", true},
+		{"io.github.someone/weather", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validators.CheckBlockedName(tt.name)
+			if tt.blocked {
+				assert.ErrorIs(t, err, validators.ErrBlockedServerName)
+				assert.ErrorIs(t, validators.ValidateServerName(tt.name), validators.ErrBlockedServerName)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
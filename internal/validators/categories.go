@@ -0,0 +1,84 @@
+package validators
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const (
+	maxCategories = 3
+	maxTags       = 10
+	maxTagLength  = 32
+)
+
+// DefaultCategories is the category taxonomy used unless the registry configures its own
+var DefaultCategories = []string{
+	"ai",
+	"analytics",
+	"cloud",
+	"communication",
+	"databases",
+	"developer-tools",
+	"devops",
+	"documents",
+	"finance",
+	"knowledge",
+	"media",
+	"monitoring",
+	"productivity",
+	"search",
+	"security",
+	"web",
+}
+
+// tagRe matches lowercase words separated by single hyphens, e.g. "weather" or "open-data"
+var tagRe = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// CategoryTaxonomy returns the categories servers may declare
+func CategoryTaxonomy(cfg *config.Config) []string {
+	if len(cfg.Categories) > 0 {
+		return cfg.Categories
+	}
+	return DefaultCategories
+}
+
+// validateCategoriesAndTags checks the number and format of a server's categories and tags. Whether the
+// categories are in the registry's taxonomy is checked at publish time by validateCategoryTaxonomy.
+func validateCategoriesAndTags(serverJSON *apiv0.ServerJSON) error {
+	if len(serverJSON.Categories) > maxCategories {
+		return fmt.Errorf("%w: at most %d allowed", ErrTooManyCategories, maxCategories)
+	}
+	for i, category := range serverJSON.Categories {
+		if slices.Contains(serverJSON.Categories[:i], category) {
+			return fmt.Errorf("%w: %q is listed more than once", ErrInvalidCategory, category)
+		}
+	}
+
+	if len(serverJSON.Tags) > maxTags {
+		return fmt.Errorf("%w: at most %d allowed", ErrTooManyTags, maxTags)
+	}
+	for i, tag := range serverJSON.Tags {
+		if len(tag) > maxTagLength || !tagRe.MatchString(tag) {
+			return fmt.Errorf("%w: %q must be at most %d lowercase letters, digits and single hyphens", ErrInvalidTag, tag, maxTagLength)
+		}
+		if slices.Contains(serverJSON.Tags[:i], tag) {
+			return fmt.Errorf("%w: %q is listed more than once", ErrInvalidTag, tag)
+		}
+	}
+	return nil
+}
+
+// validateCategoryTaxonomy checks that every category is in the registry's taxonomy
+func validateCategoryTaxonomy(serverJSON apiv0.ServerJSON, cfg *config.Config) error {
+	taxonomy := CategoryTaxonomy(cfg)
+	for _, category := range serverJSON.Categories {
+		if !slices.Contains(taxonomy, category) {
+			return fmt.Errorf("%w: %q is not in the registry's taxonomy (see GET /v0/categories)", ErrInvalidCategory, category)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package validators_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCategoriesAndTags(t *testing.T) {
+	tests := []struct {
+		name       string
+		categories []string
+		tags       []string
+		cfg        *config.Config
+		expected   error
+	}{
+		{name: "none", cfg: &config.Config{}},
+		{name: "default taxonomy", categories: []string{"developer-tools", "databases"}, tags: []string{"postgres", "open-data", "v2"}, cfg: &config.Config{}},
+		{name: "configured taxonomy", categories: []string{"games"}, cfg: &config.Config{Categories: []string{"games"}}},
+		{name: "not in configured taxonomy", categories: []string{"databases"}, cfg: &config.Config{Categories: []string{"games"}}, expected: validators.ErrInvalidCategory},
+		{name: "unknown category", categories: []string{"weather"}, cfg: &config.Config{}, expected: validators.ErrInvalidCategory},
+		{name: "duplicate category", categories: []string{"web", "web"}, cfg: &config.Config{}, expected: validators.ErrInvalidCategory},
+		{name: "too many categories", categories: []string{"ai", "cloud", "web", "search"}, cfg: &config.Config{}, expected: validators.ErrTooManyCategories},
+		{name: "too many tags", tags: strings.Split("a,b,c,d,e,f,g,h,i,j,k", ","), cfg: &config.Config{}, expected: validators.ErrTooManyTags},
+		{name: "uppercase tag", tags: []string{"Weather"}, cfg: &config.Config{}, expected: validators.ErrInvalidTag},
+		{name: "tag with spaces", tags: []string{"open data"}, cfg: &config.Config{}, expected: validators.ErrInvalidTag},
+		{name: "tag with double hyphen", tags: []string{"open--data"}, cfg: &config.Config{}, expected: validators.ErrInvalidTag},
+		{name: "long tag", tags: []string{strings.Repeat("a", 33)}, cfg: &config.Config{}, expected: validators.ErrInvalidTag},
+		{name: "duplicate tag", tags: []string{"weather", "weather"}, cfg: &config.Config{}, expected: validators.ErrInvalidTag},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validators.ValidatePublishRequest(apiv0.ServerJSON{
+				Name:        "com.example/server",
+				Description: "A categorized server",
+				Version:     "1.0.0",
+				Categories:  tt.categories,
+				Tags:        tt.tags,
+			}, tt.cfg)
+			if tt.expected != nil {
+				assert.ErrorIs(t, err, tt.expected)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
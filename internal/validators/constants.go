@@ -7,16 +7,36 @@ var (
 	// Repository validation errors
 	ErrInvalidRepositoryURL = errors.New("invalid repository URL")
 	ErrInvalidSubfolderPath = errors.New("invalid subfolder path")
+	ErrRepositoryNotFound   = errors.New("repository not found")
+	ErrRepositoryNotPublic  = errors.New("repository must be public")
+
+	// Repository ownership errors
+	ErrRepositoryOwnerMismatch = errors.New("repository owner does not match the authenticated identity")
 
 	// Package validation errors
 	ErrPackageNameHasSpaces  = errors.New("package name cannot contain spaces")
 	ErrReservedVersionString = errors.New("version string 'latest' is reserved and cannot be used")
 	ErrVersionLooksLikeRange = errors.New("version must be a specific version, not a range")
+	ErrInvalidPackageDigest  = errors.New("package digest must be in the form 'sha256:<64 hex characters>'")
+	ErrDigestRequiresOCI     = errors.New("package digest is only supported for OCI packages")
+	ErrInvalidFileSHA256     = errors.New("package file_sha256 must be 64 lowercase hex characters")
+
+	// Environment variable validation errors
+	ErrInvalidEnvironmentVariableName         = errors.New("environment variable name must start with a letter or underscore and contain only letters, digits and underscores")
+	ErrDuplicateEnvironmentVariable           = errors.New("environment variable is declared more than once")
+	ErrEnvironmentVariableDescriptionRequired = errors.New("required environment variable must have a description")
 
 	// Remote validation errors
-	ErrInvalidRemoteURL = errors.New("invalid remote URL")
+	ErrInvalidRemoteURL   = errors.New("invalid remote URL")
+	ErrDuplicateRemoteURL = errors.New("remote URL is declared more than once")
+
+	// Transport validation errors
+	ErrStdioTransportHeaders  = errors.New("stdio transport cannot declare headers")
+	ErrPackageRuntimeRequired = errors.New("package needs a runtime_hint or runtime_arguments to be started")
 
 	// Registry validation errors
+	ErrRegistryValidationFailed     = errors.New("registry validation failed")
+	ErrProvenanceValidationFailed   = errors.New("provenance validation failed")
 	ErrUnsupportedRegistryBaseURL   = errors.New("unsupported registry base URL")
 	ErrMismatchedRegistryTypeAndURL = errors.New("registry type and base URL do not match")
 
@@ -29,6 +49,29 @@ var (
 	// Server name validation errors
 	ErrInvalidServerNameFormat     = errors.New("server name format is invalid: must contain exactly one slash")
 	ErrMultipleSlashesInServerName = errors.New("server name cannot contain multiple slashes")
+	ErrBlockedServerName           = errors.New("server name is reserved and cannot be used")
+	ErrInvalidNamespaceLabel       = errors.New("invalid namespace label")
+	ErrMixedScriptNamespace        = errors.New("server namespace mixes scripts")
+
+	// Category and tag validation errors
+	ErrTooManyCategories = errors.New("too many categories")
+	ErrInvalidCategory   = errors.New("invalid category")
+	ErrTooManyTags       = errors.New("too many tags")
+	ErrInvalidTag        = errors.New("invalid tag")
+
+	// Unknown field errors
+	ErrUnknownField             = errors.New("field is not supported by this registry")
+	ErrUnsupportedSchemaVersion = errors.New("unsupported server.json schema version")
+
+	// Publisher-provided metadata errors
+	ErrPublisherMetaTooLarge      = errors.New("publisher-provided metadata is too large")
+	ErrPublisherMetaTooDeep       = errors.New("publisher-provided metadata is nested too deeply")
+	ErrInvalidPublisherMetaKey    = errors.New("invalid publisher-provided metadata key")
+	ErrPublisherMetaKeyNotAllowed = errors.New("publisher-provided metadata key is not allowed by this registry")
+
+	// Icon validation errors
+	ErrInvalidIcon  = errors.New("invalid icon")
+	ErrIconTooLarge = errors.New("icon is too large")
 )
 
 // RepositorySource represents valid repository sources
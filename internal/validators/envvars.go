@@ -0,0 +1,105 @@
+package validators
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// environmentVariableNameRegex matches POSIX-portable environment variable names
+var environmentVariableNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Heuristics for values that look like embedded credentials
+var (
+	secretPrefixes = []string{"ghp_", "gho_", "ghs_", "github_pat_", "glpat-", "sk-", "sk_live_", "xoxb-", "xoxp-", "AKIA", "AIza"}
+	// minSecretLength and minSecretEntropy are the length and Shannon entropy, in bits per character, from
+	// which a value without spaces is considered random enough to be a token. Hex digests are around 3.7 and
+	// base64 or alphanumeric tokens above 4.5, while words and identifiers are well below.
+	minSecretLength  = 20
+	minSecretEntropy = 4.0
+)
+
+// validateEnvironmentVariables checks that a package's environment variables have valid, unique names and
+// that required ones describe what to set them to
+func validateEnvironmentVariables(vars []model.KeyValueInput) error {
+	seen := make(map[string]bool, len(vars))
+	for i, env := range vars {
+		if !environmentVariableNameRegex.MatchString(env.Name) {
+			return fmt.Errorf("%w: environment_variables[%d] %q", ErrInvalidEnvironmentVariableName, i, env.Name)
+		}
+		if seen[env.Name] {
+			return fmt.Errorf("%w: environment_variables[%d] %q", ErrDuplicateEnvironmentVariable, i, env.Name)
+		}
+		seen[env.Name] = true
+
+		if env.IsRequired && strings.TrimSpace(env.Description) == "" {
+			return fmt.Errorf("%w: environment_variables[%d] %q", ErrEnvironmentVariableDescriptionRequired, i, env.Name)
+		}
+	}
+	return nil
+}
+
+// lintSecretDefaults flags environment variables whose default or fixed value looks like a credential, which
+// would be published to everyone reading the registry
+func lintSecretDefaults(server apiv0.ServerJSON) []apiv0.ValidationFinding {
+	var findings []apiv0.ValidationFinding
+	for i, pkg := range server.Packages {
+		for j, env := range pkg.EnvironmentVariables {
+			for _, value := range []string{env.Default, env.Value} {
+				if !looksLikeSecret(value) {
+					continue
+				}
+				findings = append(findings, apiv0.ValidationFinding{
+					Location: fmt.Sprintf("packages[%d].environment_variables[%d]", i, j),
+					Message:  fmt.Sprintf("environment variable %q has a value that looks like an embedded secret; ask users for it instead", env.Name),
+				})
+				break
+			}
+		}
+	}
+	return findings
+}
+
+// looksLikeSecret reports whether a value has a well-known token prefix, or is long and random enough to be one
+func looksLikeSecret(value string) bool {
+	if strings.ContainsAny(value, " \t\n{}") {
+		return false
+	}
+	for _, prefix := range secretPrefixes {
+		if strings.HasPrefix(value, prefix) && len(value) >= len(prefix)+16 {
+			return true
+		}
+	}
+	if len(value) < minSecretLength || strings.Contains(value, "://") {
+		return false
+	}
+
+	var hasLetter, hasDigit bool
+	for _, r := range value {
+		hasLetter = hasLetter || unicode.IsLetter(r)
+		hasDigit = hasDigit || unicode.IsDigit(r)
+	}
+	return hasLetter && hasDigit && shannonEntropy(value) >= minSecretEntropy
+}
+
+// shannonEntropy returns the entropy of the characters of s, in bits per character
+func shannonEntropy(s string) float64 {
+	counts := make(map[rune]int)
+	total := 0
+	for _, r := range s {
+		counts[r]++
+		total++
+	}
+
+	var entropy float64
+	for _, count := range counts {
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
@@ -0,0 +1,211 @@
+package validators
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"image/png"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// MaxIconSize is the largest icon, in bytes, a server may upload
+	MaxIconSize = 256 << 10
+	// maxIconDimension bounds PNG width and height, which also bounds the memory needed to decode one
+	maxIconDimension = 1024
+
+	IconContentTypePNG = "image/png"
+	IconContentTypeSVG = "image/svg+xml"
+)
+
+// svgElements are the SVG elements kept in uploaded icons: shapes, gradients and text, but nothing that
+// runs scripts, embeds other documents or fetches external resources
+var svgElements = map[string]bool{
+	"svg": true, "g": true, "defs": true, "symbol": true, "use": true, "title": true, "desc": true,
+	"path": true, "rect": true, "circle": true, "ellipse": true, "line": true, "polyline": true, "polygon": true,
+	"linearGradient": true, "radialGradient": true, "stop": true, "clipPath": true, "mask": true,
+	"text": true, "tspan": true,
+}
+
+// svgNamespaces are the namespace declarations kept in uploaded SVG icons
+var svgNamespaces = map[string]bool{
+	"http://www.w3.org/2000/svg":   true,
+	"http://www.w3.org/1999/xlink": true,
+}
+
+// SanitizeIcon checks that data is a PNG or SVG icon within the registry's limits, and returns a sanitized copy
+// together with its sniffed content type. PNGs are re-encoded, dropping any metadata chunks; SVGs are rebuilt
+// from an allowlist of elements, without scripts, event handlers or references to external resources.
+// declaredType is the uploader's Content-Type, and must agree with the sniffed type if set.
+func SanitizeIcon(data []byte, declaredType string) ([]byte, string, error) {
+	if len(data) > MaxIconSize {
+		return nil, "", fmt.Errorf("%w: %d bytes exceeds the %d byte limit", ErrIconTooLarge, len(data), MaxIconSize)
+	}
+	if len(data) == 0 {
+		return nil, "", fmt.Errorf("%w: icon is empty", ErrInvalidIcon)
+	}
+
+	contentType := IconContentTypeSVG
+	if http.DetectContentType(data) == IconContentTypePNG {
+		contentType = IconContentTypePNG
+	}
+	if declared, _, _ := strings.Cut(declaredType, ";"); declared != "" && declared != "application/octet-stream" &&
+		strings.TrimSpace(declared) != contentType {
+		return nil, "", fmt.Errorf("%w: content type %q does not match the uploaded %s data", ErrInvalidIcon, declared, contentType)
+	}
+
+	var sanitized []byte
+	var err error
+	if contentType == IconContentTypePNG {
+		sanitized, err = sanitizePNG(data)
+	} else {
+		sanitized, err = sanitizeSVG(data)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return sanitized, contentType, nil
+}
+
+func sanitizePNG(data []byte) ([]byte, error) {
+	config, err := png.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIcon, err)
+	}
+	if config.Width > maxIconDimension || config.Height > maxIconDimension {
+		return nil, fmt.Errorf("%w: %dx%d exceeds the %dx%d limit",
+			ErrIconTooLarge, config.Width, config.Height, maxIconDimension, maxIconDimension)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIcon, err)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to re-encode icon: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func sanitizeSVG(data []byte) ([]byte, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = true
+
+	var out bytes.Buffer
+	// RawToken doesn't match end tags to start tags, so track open elements here
+	var open []xml.Name
+	skipDepth := 0
+	sawRoot := false
+	for {
+		// RawToken leaves namespace prefixes as written, so elements can be re-serialized verbatim
+		token, err := decoder.RawToken()
+		if errors.Is(err, io.EOF) && len(open) == 0 {
+			break
+		}
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: not a PNG or well-formed SVG: %w", ErrInvalidIcon, err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			open = append(open, t.Name)
+			depth := len(open)
+			if depth == 1 {
+				if sawRoot || t.Name.Local != "svg" {
+					return nil, fmt.Errorf("%w: not a PNG or SVG image", ErrInvalidIcon)
+				}
+				sawRoot = true
+			}
+			if skipDepth > 0 {
+				continue
+			}
+			if (t.Name.Space != "" && t.Name.Space != "svg") || !svgElements[t.Name.Local] {
+				skipDepth = depth
+				continue
+			}
+			writeSVGStart(&out, t)
+		case xml.EndElement:
+			depth := len(open)
+			if depth == 0 || open[depth-1] != t.Name {
+				return nil, fmt.Errorf("%w: not a PNG or well-formed SVG: unexpected </%s>", ErrInvalidIcon, qualifiedName(t.Name))
+			}
+			open = open[:depth-1]
+			if skipDepth == 0 {
+				out.WriteString("</" + qualifiedName(t.Name) + ">")
+			} else if skipDepth == depth {
+				skipDepth = 0
+			}
+		case xml.CharData:
+			if skipDepth == 0 && len(open) > 0 {
+				_ = xml.EscapeText(&out, t)
+			}
+		case xml.Directive:
+			// DOCTYPEs can declare entities, which have no place in an icon
+			return nil, fmt.Errorf("%w: SVG icons must not contain DOCTYPE or entity declarations", ErrInvalidIcon)
+		}
+		// Comments and processing instructions are dropped
+	}
+	if !sawRoot {
+		return nil, fmt.Errorf("%w: not a PNG or SVG image", ErrInvalidIcon)
+	}
+	return out.Bytes(), nil
+}
+
+func writeSVGStart(out *bytes.Buffer, el xml.StartElement) {
+	out.WriteString("<" + qualifiedName(el.Name))
+	for _, attr := range el.Attr {
+		if !allowedSVGAttr(attr) {
+			continue
+		}
+		out.WriteString(" " + qualifiedName(attr.Name) + `="`)
+		_ = xml.EscapeText(out, []byte(attr.Value))
+		out.WriteString(`"`)
+	}
+	out.WriteString(">")
+}
+
+func allowedSVGAttr(attr xml.Attr) bool {
+	name := strings.ToLower(attr.Name.Local)
+	value := strings.ToLower(strings.Join(strings.Fields(attr.Value), ""))
+
+	switch attr.Name.Space {
+	case "xmlns":
+		return svgNamespaces[attr.Value]
+	case "":
+		if name == "xmlns" {
+			return svgNamespaces[attr.Value]
+		}
+	case "xlink", "xml":
+	default:
+		return false
+	}
+
+	switch {
+	case strings.HasPrefix(name, "on"):
+		return false
+	case name == "href":
+		// Only references to elements within the icon itself
+		return strings.HasPrefix(value, "#")
+	case strings.Contains(value, "javascript:") || strings.Contains(value, "data:"):
+		return false
+	case strings.Contains(value, "url("):
+		return !strings.Contains(strings.ReplaceAll(value, "url(#", ""), "url(")
+	case name == "style":
+		return !strings.Contains(value, "@import") && !strings.Contains(value, "expression(")
+	}
+	return true
+}
+
+func qualifiedName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
@@ -0,0 +1,90 @@
+package validators_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))))
+	return buf.Bytes()
+}
+
+func TestSanitizeIconPNG(t *testing.T) {
+	data, contentType, err := validators.SanitizeIcon(encodePNG(t, 64, 64), "image/png")
+	require.NoError(t, err)
+	assert.Equal(t, validators.IconContentTypePNG, contentType)
+	config, err := png.DecodeConfig(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, 64, config.Width)
+
+	_, _, err = validators.SanitizeIcon(encodePNG(t, 2048, 16), "image/png")
+	assert.ErrorIs(t, err, validators.ErrIconTooLarge)
+
+	_, _, err = validators.SanitizeIcon(encodePNG(t, 16, 16), "image/svg+xml")
+	assert.ErrorIs(t, err, validators.ErrInvalidIcon)
+
+	truncated := encodePNG(t, 16, 16)
+	_, _, err = validators.SanitizeIcon(truncated[:len(truncated)/2], "")
+	assert.ErrorIs(t, err, validators.ErrInvalidIcon)
+
+	_, _, err = validators.SanitizeIcon(make([]byte, validators.MaxIconSize+1), "")
+	assert.ErrorIs(t, err, validators.ErrIconTooLarge)
+}
+
+func TestSanitizeIconSVG(t *testing.T) {
+	input := `<?xml version="1.0"?>
+<!-- comment -->
+<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" xmlns:ev="http://example.com/ev" viewBox="0 0 24 24" onload="alert(1)">
+  <script>alert(1)</script>
+  <defs><linearGradient id="g"><stop offset="0" stop-color="#fff"/></linearGradient></defs>
+  <path d="M0 0h24v24H0z" fill="url(#g)" onclick="alert(1)"/>
+  <rect style="fill: url(https://evil.example/x)" width="1" height="1"/>
+  <use xlink:href="#g"/><use href="https://evil.example/x.svg#a"/>
+  <foreignObject><div>html</div></foreignObject>
+  <image href="https://evil.example/track.png"/>
+  <text x="1">A &amp; B</text>
+</svg>`
+
+	data, contentType, err := validators.SanitizeIcon([]byte(input), "image/svg+xml; charset=utf-8")
+	require.NoError(t, err)
+	assert.Equal(t, validators.IconContentTypeSVG, contentType)
+
+	out := string(data)
+	assert.True(t, strings.HasPrefix(out, `<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" viewBox="0 0 24 24">`), out)
+	assert.Contains(t, out, `<path d="M0 0h24v24H0z" fill="url(#g)"></path>`)
+	assert.Contains(t, out, `<use xlink:href="#g"></use><use></use>`)
+	assert.Contains(t, out, `<rect width="1" height="1"></rect>`)
+	assert.Contains(t, out, `A &amp; B`)
+	for _, unwanted := range []string{"script", "alert", "foreignObject", "html", "evil", "image", "comment", "<?xml", "xmlns:ev"} {
+		assert.NotContains(t, out, unwanted)
+	}
+}
+
+func TestSanitizeIconRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"plain text", "hello"},
+		{"html", "<html><body></body></html>"},
+		{"malformed svg", `<svg xmlns="http://www.w3.org/2000/svg"><path></svg>`},
+		{"doctype", `<!DOCTYPE svg [<!ENTITY x "y">]><svg xmlns="http://www.w3.org/2000/svg">&x;</svg>`},
+		{"empty", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := validators.SanitizeIcon([]byte(tt.data), "")
+			assert.ErrorIs(t, err, validators.ErrInvalidIcon)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package validators
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"golang.org/x/net/idna"
+)
+
+// NamespaceSpellings returns the spellings of a server name that refer to the same namespace: the name itself,
+// and the name with its namespace in punycode (ASCII) and in Unicode. For example "com.例え/server" and
+// "com.xn--r8jz45g/server" name the same server.
+func NamespaceSpellings(name string) []string {
+	namespace, rest, found := strings.Cut(name, "/")
+	if !found {
+		return []string{name}
+	}
+
+	spellings := []string{name}
+	if ascii, err := idna.Punycode.ToASCII(namespace); err == nil {
+		spellings = append(spellings, ascii+"/"+rest)
+	}
+	if unicodeForm, err := idna.Punycode.ToUnicode(namespace); err == nil {
+		spellings = append(spellings, unicodeForm+"/"+rest)
+	}
+	slices.Sort(spellings)
+	return slices.Compact(spellings)
+}
+
+// validateNamespaceIDN checks that internationalized labels of a server's namespace are valid domain labels,
+// and applies policy to labels mixing scripts, such as Latin and Cyrillic in "com.exаmple", that let a namespace
+// pass for another. It returns a warning when policy is to warn.
+func validateNamespaceIDN(name string, policy config.MixedScriptPolicy) (string, error) {
+	namespace, _, _ := strings.Cut(name, "/")
+	for _, label := range strings.Split(namespace, ".") {
+		unicodeLabel := label
+		if strings.HasPrefix(label, "xn--") {
+			decoded, err := idna.Punycode.ToUnicode(label)
+			if err != nil {
+				return "", fmt.Errorf("%w: %q is not valid punycode", ErrInvalidNamespaceLabel, label)
+			}
+			unicodeLabel = decoded
+		} else if !isASCII(label) {
+			if _, err := idna.Lookup.ToASCII(label); err != nil {
+				return "", fmt.Errorf("%w: %q is not a valid internationalized domain label", ErrInvalidNamespaceLabel, label)
+			}
+		}
+
+		scripts := labelScripts(unicodeLabel)
+		if !isMixedScript(scripts) {
+			continue
+		}
+		message := fmt.Sprintf("namespace label %q mixes %s scripts", label, strings.Join(scripts, " and "))
+		switch policy {
+		case config.MixedScriptReject:
+			return "", fmt.Errorf("%w: %s", ErrMixedScriptNamespace, message)
+		case config.MixedScriptWarn:
+			return message, nil
+		}
+	}
+	return "", nil
+}
+
+// allowedScriptCombinations are the scripts that are written together, following the "highly restrictive"
+// level of Unicode Technical Standard #39: Japanese, Chinese and Korean text, optionally with Latin
+var allowedScriptCombinations = [][]string{
+	{"Han", "Hiragana", "Katakana", "Latin"},
+	{"Bopomofo", "Han", "Latin"},
+	{"Han", "Hangul", "Latin"},
+}
+
+// isMixedScript reports whether scripts, as returned by labelScripts, mix scripts that aren't written together
+func isMixedScript(scripts []string) bool {
+	if len(scripts) <= 1 {
+		return false
+	}
+	for _, allowed := range allowedScriptCombinations {
+		covered := true
+		for _, script := range scripts {
+			if !slices.Contains(allowed, script) {
+				covered = false
+				break
+			}
+		}
+		if covered {
+			return false
+		}
+	}
+	return true
+}
+
+// labelScripts returns the sorted names of the scripts used by the letters of label, ignoring characters such
+// as digits and hyphens that are common to every script
+func labelScripts(label string) []string {
+	var scripts []string
+	for _, r := range label {
+		if unicode.In(r, unicode.Common, unicode.Inherited) {
+			continue
+		}
+		for script, table := range unicode.Scripts {
+			if unicode.Is(table, r) {
+				if !slices.Contains(scripts, script) {
+					scripts = append(scripts, script)
+				}
+				break
+			}
+		}
+	}
+	slices.Sort(scripts)
+	return scripts
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,57 @@
+//nolint:testpackage
+package validators
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceSpellings(t *testing.T) {
+	assert.Equal(t, []string{"com.example/server"}, NamespaceSpellings("com.example/server"))
+	assert.ElementsMatch(t, []string{"com.例え/server", "com.xn--r8jz45g/server"}, NamespaceSpellings("com.例え/server"))
+	assert.ElementsMatch(t, []string{"com.例え/server", "com.xn--r8jz45g/server"}, NamespaceSpellings("com.xn--r8jz45g/server"))
+}
+
+func TestValidateNamespaceIDN(t *testing.T) {
+	// "exаmple" with a Cyrillic "а"
+	const spoofed = "com.ex\u0430mple/server"
+
+	tests := []struct {
+		name            string
+		serverName      string
+		policy          config.MixedScriptPolicy
+		expectedErr     error
+		expectedWarning bool
+	}{
+		{name: "ASCII namespace", serverName: "com.example/server", policy: config.MixedScriptReject},
+		{name: "single-script Unicode namespace", serverName: "com.例え/server", policy: config.MixedScriptReject},
+		{name: "single-script punycode namespace", serverName: "com.xn--r8jz45g/server", policy: config.MixedScriptReject},
+		{name: "Japanese scripts written together", serverName: "jp.カタカナ漢字/server", policy: config.MixedScriptReject},
+		{name: "digits and hyphens don't count as a script", serverName: "com.пример-2/server", policy: config.MixedScriptReject},
+		{name: "mixed scripts rejected", serverName: spoofed, policy: config.MixedScriptReject, expectedErr: ErrMixedScriptNamespace},
+		{name: "mixed scripts in punycode rejected", serverName: "com.xn--exmple-4nf/server", policy: config.MixedScriptReject, expectedErr: ErrMixedScriptNamespace},
+		{name: "mixed scripts warned", serverName: spoofed, policy: config.MixedScriptWarn, expectedWarning: true},
+		{name: "mixed scripts allowed", serverName: spoofed, policy: config.MixedScriptOff},
+		{name: "invalid punycode", serverName: "com.xn--99999999999999/server", policy: config.MixedScriptReject, expectedErr: ErrInvalidNamespaceLabel},
+		{name: "invalid Unicode label", serverName: "com.ex\u200dample/server", policy: config.MixedScriptOff, expectedErr: ErrInvalidNamespaceLabel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warning, err := validateNamespaceIDN(tt.serverName, tt.policy)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.expectedWarning {
+				assert.Contains(t, warning, "Cyrillic and Latin")
+			} else {
+				assert.Empty(t, warning)
+			}
+		})
+	}
+}
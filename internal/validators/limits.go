@@ -0,0 +1,140 @@
+package validators
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// LimitViolation describes a part of a publish request exceeding a configured limit
+type LimitViolation struct {
+	Location string // JSON path within the request body, e.g. "packages[0].environment_variables"
+	Message  string
+}
+
+func (v LimitViolation) Error() string {
+	return v.Location + ": " + v.Message
+}
+
+// CheckPublishLimits returns the parts of a server exceeding the configured package, environment variable and
+// string length limits, so oversized publishes are rejected before any validation or storage work is done
+func CheckPublishLimits(server apiv0.ServerJSON, cfg *config.Config) []LimitViolation {
+	var violations []LimitViolation
+
+	if cfg.PublishMaxPackages > 0 && len(server.Packages) > cfg.PublishMaxPackages {
+		violations = append(violations, LimitViolation{
+			Location: "packages",
+			Message:  fmt.Sprintf("%d packages exceeds the limit of %d", len(server.Packages), cfg.PublishMaxPackages),
+		})
+	}
+
+	if cfg.PublishMaxEnvironmentVars > 0 {
+		for i, pkg := range server.Packages {
+			if len(pkg.EnvironmentVariables) > cfg.PublishMaxEnvironmentVars {
+				violations = append(violations, LimitViolation{
+					Location: fmt.Sprintf("packages[%d].environment_variables", i),
+					Message:  fmt.Sprintf("%d environment variables exceeds the limit of %d", len(pkg.EnvironmentVariables), cfg.PublishMaxEnvironmentVars),
+				})
+			}
+		}
+	}
+
+	if cfg.PublishMaxStringLength > 0 {
+		checkLength := func(location, value string) {
+			if len(value) > cfg.PublishMaxStringLength {
+				violations = append(violations, LimitViolation{
+					Location: location,
+					Message:  fmt.Sprintf("%d bytes exceeds the limit of %d", len(value), cfg.PublishMaxStringLength),
+				})
+			}
+		}
+		walkStrings(reflect.ValueOf(server), "", checkLength)
+		walkUnknownFieldStrings(server, checkLength)
+	}
+
+	return violations
+}
+
+// walkUnknownFieldStrings calls fn with the JSON path of every string in the server's unknown top-level and
+// _meta fields, which walkStrings skips
+func walkUnknownFieldStrings(server apiv0.ServerJSON, fn func(location, value string)) {
+	walk := func(prefix string, fields map[string]json.RawMessage) {
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			var value any
+			if err := json.Unmarshal(fields[name], &value); err != nil {
+				continue
+			}
+			location := joinLocation(prefix, name)
+			fn(location, name)
+			walkStrings(reflect.ValueOf(value), location, fn)
+		}
+	}
+	walk("", server.UnknownFields)
+	if server.Meta != nil {
+		walk("_meta", server.Meta.UnknownFields)
+	}
+}
+
+// walkStrings calls fn with the JSON path of every string, including map keys, reachable from v
+func walkStrings(v reflect.Value, location string, fn func(location, value string)) {
+	switch v.Kind() {
+	case reflect.String:
+		fn(location, v.String())
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			walkStrings(v.Elem(), location, fn)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			walkStrings(v.Index(i), fmt.Sprintf("%s[%d]", location, i), fn)
+		}
+	case reflect.Map:
+		// Visit keys in order so violations are reported deterministically
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		for _, key := range keys {
+			keyLocation := joinLocation(location, fmt.Sprint(key))
+			walkStrings(key, keyLocation, fn)
+			walkStrings(v.MapIndex(key), keyLocation, fn)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := range t.NumField() {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			fieldLocation := location
+			if !field.Anonymous || name != "" {
+				if name == "" {
+					name = field.Name
+				}
+				fieldLocation = joinLocation(location, name)
+			}
+			walkStrings(v.Field(i), fieldLocation, fn)
+		}
+	default:
+		// Numbers, booleans and times can't be oversized
+	}
+}
+
+func joinLocation(location, name string) string {
+	if location == "" {
+		return name
+	}
+	return location + "." + name
+}
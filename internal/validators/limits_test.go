@@ -0,0 +1,50 @@
+package validators_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPublishLimits(t *testing.T) {
+	server := apiv0.ServerJSON{
+		Name:        "com.example/server",
+		Description: "A server",
+		Version:     "1.0.0",
+		Packages: []model.Package{{
+			RegistryType: model.RegistryTypeNPM,
+			Identifier:   "example",
+			Version:      "1.0.0",
+			EnvironmentVariables: []model.KeyValueInput{
+				{Name: "ONE"},
+				{Name: "TWO", InputWithVariables: model.InputWithVariables{Input: model.Input{Default: strings.Repeat("a", 65)}}},
+			},
+		}},
+	}
+
+	cfg := &config.Config{PublishMaxEnvironmentVars: 1, PublishMaxStringLength: 64}
+	violations := validators.CheckPublishLimits(server, cfg)
+	if assert.Len(t, violations, 2) {
+		assert.Equal(t, "packages[0].environment_variables", violations[0].Location)
+		assert.Equal(t, "packages[0].environment_variables[1].default", violations[1].Location)
+	}
+
+	// Strings in fields the registry doesn't know are limited too
+	server.Packages = nil
+	server.Meta = &apiv0.ServerMeta{UnknownFields: map[string]json.RawMessage{
+		"com.example/build": json.RawMessage(`{"log": "` + strings.Repeat("a", 65) + `"}`),
+	}}
+	violations = validators.CheckPublishLimits(server, cfg)
+	if assert.Len(t, violations, 1) {
+		assert.Equal(t, "_meta.com.example/build.log", violations[0].Location)
+	}
+
+	// Zero disables every limit
+	assert.Empty(t, validators.CheckPublishLimits(server, &config.Config{}))
+}
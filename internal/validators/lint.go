@@ -0,0 +1,92 @@
+package validators
+
+import (
+	"fmt"
+	"regexp"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// minDescriptionLength is the shortest description that lint considers informative
+const minDescriptionLength = 20
+
+// exactVersionRegex matches major.minor.patch versions, with optional prerelease and build metadata
+var exactVersionRegex = regexp.MustCompile(`^v?\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+
+// lintRule is a quality check that doesn't block publishing. Each rule that finds problems costs its weight
+// from the quality score, however many problems it finds.
+type lintRule struct {
+	id     string
+	weight int
+	check  func(server apiv0.ServerJSON) []apiv0.ValidationFinding
+}
+
+var lintRules = []lintRule{
+	{id: "description-length", weight: 20, check: lintDescriptionLength},
+	{id: "website-url", weight: 10, check: lintWebsiteURL},
+	{id: "transports", weight: 40, check: lintTransports},
+	{id: "pinned-versions", weight: 30, check: lintPinnedVersions},
+	{id: "secret-defaults", weight: 30, check: lintSecretDefaults},
+}
+
+// Lint runs the quality rules against a server, returning a warning for each problem found and a quality
+// score from 0 to 100
+func Lint(server apiv0.ServerJSON) ([]apiv0.ValidationFinding, int) {
+	var warnings []apiv0.ValidationFinding
+	score := 100
+	for _, rule := range lintRules {
+		findings := rule.check(server)
+		if len(findings) == 0 {
+			continue
+		}
+		for _, f := range findings {
+			f.Rule = rule.id
+			warnings = append(warnings, f)
+		}
+		score -= rule.weight
+	}
+	return warnings, max(score, 0)
+}
+
+func lintDescriptionLength(server apiv0.ServerJSON) []apiv0.ValidationFinding {
+	if len(server.Description) >= minDescriptionLength {
+		return nil
+	}
+	return []apiv0.ValidationFinding{{
+		Location: "description",
+		Message:  fmt.Sprintf("description is shorter than %d characters; describe what the server does", minDescriptionLength),
+	}}
+}
+
+func lintWebsiteURL(server apiv0.ServerJSON) []apiv0.ValidationFinding {
+	if server.WebsiteURL != "" {
+		return nil
+	}
+	return []apiv0.ValidationFinding{{
+		Location: "website_url",
+		Message:  "no website URL is declared",
+	}}
+}
+
+func lintTransports(server apiv0.ServerJSON) []apiv0.ValidationFinding {
+	if len(server.Packages) > 0 || len(server.Remotes) > 0 {
+		return nil
+	}
+	return []apiv0.ValidationFinding{{
+		Message: "no packages or remotes are declared, so clients have no way to run the server",
+	}}
+}
+
+func lintPinnedVersions(server apiv0.ServerJSON) []apiv0.ValidationFinding {
+	var findings []apiv0.ValidationFinding
+	for i, pkg := range server.Packages {
+		if exactVersionRegex.MatchString(pkg.Version) {
+			continue
+		}
+		findings = append(findings, apiv0.ValidationFinding{
+			Location: fmt.Sprintf("packages[%d].version", i),
+			Message:  fmt.Sprintf("package version '%s' is not an exact major.minor.patch version and may change after publishing", pkg.Version),
+		})
+	}
+	return findings
+}
@@ -0,0 +1,91 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLint(t *testing.T) {
+	complete := apiv0.ServerJSON{
+		Name:        "io.github.example/test-server",
+		Description: "Searches and edits files in a workspace",
+		Version:     "1.0.0",
+		WebsiteURL:  "https://example.github.io/test-server",
+		Packages: []model.Package{
+			{RegistryType: model.RegistryTypeNPM, Identifier: "@example/test-server", Version: "1.0.0"},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		modify        func(server *apiv0.ServerJSON)
+		expectedRules []string
+		expectedScore int
+	}{
+		{
+			name:          "complete server scores 100",
+			modify:        func(*apiv0.ServerJSON) {},
+			expectedScore: 100,
+		},
+		{
+			name:          "short description",
+			modify:        func(server *apiv0.ServerJSON) { server.Description = "A server" },
+			expectedRules: []string{"description-length"},
+			expectedScore: 80,
+		},
+		{
+			name:          "missing website URL",
+			modify:        func(server *apiv0.ServerJSON) { server.WebsiteURL = "" },
+			expectedRules: []string{"website-url"},
+			expectedScore: 90,
+		},
+		{
+			name:          "no transports",
+			modify:        func(server *apiv0.ServerJSON) { server.Packages = nil },
+			expectedRules: []string{"transports"},
+			expectedScore: 60,
+		},
+		{
+			name: "unpinned package versions cost the rule weight once",
+			modify: func(server *apiv0.ServerJSON) {
+				server.Packages = []model.Package{
+					{RegistryType: model.RegistryTypeOCI, Identifier: "example/server", Version: "stable"},
+					{RegistryType: model.RegistryTypePyPI, Identifier: "example-server", Version: "1.2"},
+					{RegistryType: model.RegistryTypeNPM, Identifier: "example-server", Version: "1.2.0-beta.1"},
+				}
+			},
+			expectedRules: []string{"pinned-versions", "pinned-versions"},
+			expectedScore: 70,
+		},
+		{
+			name: "every rule",
+			modify: func(server *apiv0.ServerJSON) {
+				server.Description = "A server"
+				server.WebsiteURL = ""
+				server.Packages = nil
+			},
+			expectedRules: []string{"description-length", "website-url", "transports"},
+			expectedScore: 30,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := complete
+			tt.modify(&server)
+
+			warnings, score := validators.Lint(server)
+			rules := make([]string, 0, len(warnings))
+			for _, warning := range warnings {
+				assert.NotEmpty(t, warning.Message)
+				rules = append(rules, warning.Rule)
+			}
+			assert.ElementsMatch(t, tt.expectedRules, rules)
+			assert.Equal(t, tt.expectedScore, score)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package validators
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeServerName returns the canonical form of a server name: Unicode NFC, with the reverse-DNS
+// namespace lowercased since domains are case-insensitive. The part after the "/" keeps its case.
+func NormalizeServerName(name string) string {
+	name = norm.NFC.String(name)
+	namespace, rest, found := strings.Cut(name, "/")
+	if !found {
+		return name
+	}
+	return strings.ToLower(namespace) + "/" + rest
+}
@@ -0,0 +1,28 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeServerName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{name: "com.example/server", expected: "com.example/server"},
+		{name: "COM.Example/Server", expected: "com.example/Server"},
+		{name: "io.github.Octocat/my-server", expected: "io.github.octocat/my-server"},
+		// "e" followed by a combining acute accent composes to "é"
+		{name: "com.example/cafe\u0301", expected: "com.example/caf\u00e9"},
+		{name: "no-slash", expected: "no-slash"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, validators.NormalizeServerName(tt.name))
+		})
+	}
+}
@@ -5,25 +5,65 @@ import (
 	"fmt"
 
 	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
 // ValidatePackage validates that the package referenced in the server configuration is:
 // 1. allowed on the official registry (based on registry base url); and
 // 2. owned by the publisher, by checking for a matching server name in the package metadata
+//
+// Validation is delegated to the validator registered for the package's registry type (see registries.RegisterValidator),
+// within that registry's limits (see registries.ConfigureRegistryLimits). Packages of disabled registry types pass unchecked.
 func ValidatePackage(ctx context.Context, pkg model.Package, serverName string) error {
-	switch pkg.RegistryType {
-	case model.RegistryTypeNPM:
-		return registries.ValidateNPM(ctx, pkg, serverName)
-	case model.RegistryTypePyPI:
-		return registries.ValidatePyPI(ctx, pkg, serverName)
-	case model.RegistryTypeNuGet:
-		return registries.ValidateNuGet(ctx, pkg, serverName)
-	case model.RegistryTypeOCI:
-		return registries.ValidateOCI(ctx, pkg, serverName)
-	case model.RegistryTypeMCPB:
-		return registries.ValidateMCPB(ctx, pkg, serverName)
-	default:
+	// Checked before the cache so packages accepted during an outage aren't remembered as validated
+	if registries.RegistryDisabled(pkg.RegistryType) {
+		return nil
+	}
+	return registries.WithValidationCache(ctx, pkg, serverName, validatePackage)
+}
+
+func validatePackage(ctx context.Context, pkg model.Package, serverName string) error {
+	validator, ok := registries.GetValidator(pkg.RegistryType)
+	if !ok {
 		return fmt.Errorf("unsupported registry type: %s", pkg.RegistryType)
 	}
+	return registries.WithRegistryLimits(ctx, pkg.RegistryType, func(ctx context.Context) error {
+		return validator.Validate(ctx, pkg, serverName)
+	})
+}
+
+// ResolvePackageDigests records the manifest digest of each OCI package and, if fileHashes is set, the SHA-256
+// hash of each NPM tarball and MCPB file on the server, so clients can verify they install exactly what was
+// published, even when the package was published with a mutable tag. Declared hashes that don't match the
+// artifact fail. File hashes are only recorded when configured (see registries.ConfigureFileHashRecording), and
+// nothing is resolved for packages of disabled registry types.
+func ResolvePackageDigests(ctx context.Context, serverJSON *apiv0.ServerJSON, fileHashes bool) error {
+	for i, pkg := range serverJSON.Packages {
+		if registries.RegistryDisabled(pkg.RegistryType) {
+			continue
+		}
+		if pkg.RegistryType == model.RegistryTypeOCI {
+			digest, err := registries.ResolveOCIDigest(ctx, pkg)
+			if err != nil {
+				return fmt.Errorf("failed to resolve digest for package %d (%s): %w", i, pkg.Identifier, err)
+			}
+			if digest != "" {
+				serverJSON.Packages[i].Digest = digest
+			}
+			continue
+		}
+		if !fileHashes {
+			continue
+		}
+
+		sum, err := registries.ResolveFileSHA256(ctx, pkg)
+		if err != nil {
+			return fmt.Errorf("failed to verify file hash for package %d (%s): %w", i, pkg.Identifier, err)
+		}
+		if sum != "" {
+			serverJSON.Packages[i].FileSHA256 = sum
+		}
+	}
+	return nil
 }
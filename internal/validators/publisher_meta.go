@@ -0,0 +1,114 @@
+package validators
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+)
+
+// publisherMetaLocation is where the publisher-provided extension sits in a server
+const publisherMetaLocation = "_meta.io.modelcontextprotocol.registry/publisher-provided"
+
+// publisherMetaFindings checks the publisher-provided _meta extension against the configured size and depth
+// limits, key pattern and top-level key allow and deny lists, so it can't be used to store large amounts of
+// arbitrary data
+func publisherMetaFindings(provided map[string]any, cfg *config.Config) []finding {
+	if provided == nil {
+		return nil
+	}
+	var findings []finding
+
+	if cfg.PublisherMetaMaxBytes > 0 {
+		data, err := json.Marshal(provided)
+		if err != nil {
+			return []finding{{location: publisherMetaLocation, err: fmt.Errorf("failed to encode publisher-provided extension: %w", err)}}
+		}
+		if len(data) > cfg.PublisherMetaMaxBytes {
+			findings = append(findings, finding{
+				location: publisherMetaLocation,
+				err:      fmt.Errorf("%w: %d bytes exceeds the limit of %d", ErrPublisherMetaTooLarge, len(data), cfg.PublisherMetaMaxBytes),
+			})
+		}
+	}
+
+	for _, key := range sortedKeys(provided) {
+		if !publisherMetaKeyAllowed(key, cfg) {
+			findings = append(findings, finding{location: publisherMetaLocation + "." + key, err: ErrPublisherMetaKeyNotAllowed})
+		}
+	}
+
+	var keyPattern *regexp.Regexp
+	if cfg.PublisherMetaKeyPattern != "" {
+		var err error
+		if keyPattern, err = regexp.Compile(cfg.PublisherMetaKeyPattern); err != nil {
+			return append(findings, finding{location: publisherMetaLocation, err: fmt.Errorf("invalid publisher metadata key pattern: %w", err)})
+		}
+	}
+	walkPublisherMeta(provided, publisherMetaLocation, 1, func(location string, depth int, key string) bool {
+		if cfg.PublisherMetaMaxDepth > 0 && depth > cfg.PublisherMetaMaxDepth {
+			findings = append(findings, finding{
+				location: location,
+				err:      fmt.Errorf("%w: nested deeper than %d levels", ErrPublisherMetaTooDeep, cfg.PublisherMetaMaxDepth),
+			})
+			return false
+		}
+		if key != "" && keyPattern != nil && !keyPattern.MatchString(key) {
+			findings = append(findings, finding{
+				location: location,
+				err:      fmt.Errorf("%w: keys must match %s", ErrInvalidPublisherMetaKey, cfg.PublisherMetaKeyPattern),
+			})
+		}
+		return true
+	})
+
+	return findings
+}
+
+// publisherMetaKeyAllowed reports whether a top-level key of the publisher-provided extension matches an
+// allowed pattern, if any are configured, and no denied one
+func publisherMetaKeyAllowed(key string, cfg *config.Config) bool {
+	matches := func(pattern string) bool {
+		matched, err := path.Match(pattern, key)
+		return err == nil && matched
+	}
+	if len(cfg.PublisherMetaAllowedKeys) > 0 && !slices.ContainsFunc(cfg.PublisherMetaAllowedKeys, matches) {
+		return false
+	}
+	return !slices.ContainsFunc(cfg.PublisherMetaDeniedKeys, matches)
+}
+
+// walkPublisherMeta calls visit with the location, nesting depth and key of every object member and array item
+// in value, which is at the given depth. Items have no key. Returning false from visit skips what's nested in
+// that member or item.
+func walkPublisherMeta(value any, location string, depth int, visit func(location string, depth int, key string) bool) {
+	switch value := value.(type) {
+	case map[string]any:
+		for _, key := range sortedKeys(value) {
+			memberLocation := location + "." + key
+			if visit(memberLocation, depth, key) {
+				walkPublisherMeta(value[key], memberLocation, depth+1, visit)
+			}
+		}
+	case []any:
+		for i, item := range value {
+			itemLocation := fmt.Sprintf("%s[%d]", location, i)
+			if visit(itemLocation, depth, "") {
+				walkPublisherMeta(item, itemLocation, depth+1, visit)
+			}
+		}
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,109 @@
+package validators_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublisherProvidedMetadata(t *testing.T) {
+	defaults := &config.Config{
+		PublisherMetaMaxBytes:   4096,
+		PublisherMetaMaxDepth:   3,
+		PublisherMetaKeyPattern: "^[A-Za-z0-9_][A-Za-z0-9_./-]{0,127}$",
+	}
+	deep := map[string]any{"a": map[string]any{"b": map[string]any{"c": map[string]any{"d": 1}}}}
+
+	tests := []struct {
+		name      string
+		provided  map[string]any
+		cfg       *config.Config
+		expected  error
+		locations []string
+	}{
+		{
+			name:     "within limits",
+			provided: map[string]any{"tool": "npm-publisher", "build_info": map[string]any{"timestamp": "2023-12-01T10:30:00Z"}},
+			cfg:      defaults,
+		},
+		{
+			name:      "too large",
+			provided:  map[string]any{"notes": strings.Repeat("a", 4096)},
+			cfg:       defaults,
+			expected:  validators.ErrPublisherMetaTooLarge,
+			locations: []string{"_meta.io.modelcontextprotocol.registry/publisher-provided"},
+		},
+		{
+			name:      "nested too deeply",
+			provided:  deep,
+			cfg:       defaults,
+			expected:  validators.ErrPublisherMetaTooDeep,
+			locations: []string{"_meta.io.modelcontextprotocol.registry/publisher-provided.a.b.c.d"},
+		},
+		{
+			name:     "arrays count towards depth",
+			provided: map[string]any{"a": []any{[]any{[]any{1}}}},
+			cfg:      defaults,
+			expected: validators.ErrPublisherMetaTooDeep,
+		},
+		{
+			name:     "depth unlimited",
+			provided: deep,
+			cfg:      &config.Config{},
+		},
+		{
+			name:      "invalid keys at any depth",
+			provided:  map[string]any{"build info": "x", "ok": map[string]any{"$where": "x"}},
+			cfg:       defaults,
+			expected:  validators.ErrInvalidPublisherMetaKey,
+			locations: []string{"_meta.io.modelcontextprotocol.registry/publisher-provided.build info", "_meta.io.modelcontextprotocol.registry/publisher-provided.ok.$where"},
+		},
+		{
+			name:      "not in allowlist",
+			provided:  map[string]any{"tool": "x", "build_info": "y", "payload": "z"},
+			cfg:       &config.Config{PublisherMetaAllowedKeys: []string{"tool", "build_*"}},
+			expected:  validators.ErrPublisherMetaKeyNotAllowed,
+			locations: []string{"_meta.io.modelcontextprotocol.registry/publisher-provided.payload"},
+		},
+		{
+			name:      "in denylist",
+			provided:  map[string]any{"tool": "x", "secret_token": "y"},
+			cfg:       &config.Config{PublisherMetaDeniedKeys: []string{"secret*"}},
+			expected:  validators.ErrPublisherMetaKeyNotAllowed,
+			locations: []string{"_meta.io.modelcontextprotocol.registry/publisher-provided.secret_token"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := apiv0.ServerJSON{
+				Name:        "com.example/server",
+				Description: "A test server",
+				Version:     "1.0.0",
+				Meta:        &apiv0.ServerMeta{PublisherProvided: tt.provided},
+			}
+			report, err := validators.CheckPublishRequest(context.Background(), req, tt.cfg)
+			if tt.expected == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, tt.expected), "expected %v, got %v", tt.expected, err)
+
+			if tt.locations != nil {
+				locations := make([]string, 0, len(report.Errors))
+				for _, finding := range report.Errors {
+					locations = append(locations, finding.Location)
+				}
+				assert.Equal(t, tt.locations, locations)
+			}
+		})
+	}
+}
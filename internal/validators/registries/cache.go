@@ -0,0 +1,102 @@
+package registries
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/cache"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+const validationCacheKeyPrefix = "registry-validation:"
+
+// validationCacheValue is stored for packages that passed validation.
+// Failures are never cached so publishers can fix their package and retry immediately.
+var validationCacheValue = []byte("ok")
+
+var (
+	validationCacheMu  sync.RWMutex
+	validationCache    cache.Cache
+	validationCacheTTL time.Duration
+)
+
+// ConfigureValidationCache enables caching of successful package validations, so repeated
+// publishes of the same package version don't re-query upstream registries and get rate limited.
+// Passing a nil cache or a non-positive TTL disables caching.
+func ConfigureValidationCache(c cache.Cache, ttl time.Duration) {
+	validationCacheMu.Lock()
+	defer validationCacheMu.Unlock()
+
+	if c == nil || ttl <= 0 {
+		validationCache = nil
+		validationCacheTTL = 0
+		return
+	}
+	validationCache = c
+	validationCacheTTL = ttl
+}
+
+// WithValidationCache runs validate unless a previous successful validation of the same
+// package is still cached
+func WithValidationCache(ctx context.Context, pkg model.Package, serverName string, validate func(context.Context, model.Package, string) error) error {
+	// A cache outage shouldn't block publishing, so lookup failures fall through to a live validation
+	key := validationCacheKey(pkg, serverName)
+	if _, ok := cachedValidation(ctx, key); ok {
+		return nil
+	}
+
+	if err := validate(ctx, pkg, serverName); err != nil {
+		return err
+	}
+
+	storeValidation(ctx, key, validationCacheValue)
+	return nil
+}
+
+// cachedValidation returns a value stored with storeValidation, if caching is enabled and it hasn't expired
+func cachedValidation(ctx context.Context, key string) ([]byte, bool) {
+	validationCacheMu.RLock()
+	c := validationCache
+	validationCacheMu.RUnlock()
+
+	if c == nil {
+		return nil, false
+	}
+	value, ok, err := c.Get(ctx, key)
+	if err != nil {
+		log.Printf("Validation cache lookup failed: %v", err)
+		return nil, false
+	}
+	return value, ok
+}
+
+// storeValidation caches a value derived from a successful validation for the configured TTL
+func storeValidation(ctx context.Context, key string, value []byte) {
+	validationCacheMu.RLock()
+	c, ttl := validationCache, validationCacheTTL
+	validationCacheMu.RUnlock()
+
+	if c == nil {
+		return
+	}
+	if err := c.Set(ctx, key, value, ttl); err != nil {
+		log.Printf("Failed to store validation result in cache: %v", err)
+	}
+}
+
+// validationCacheKey identifies a validation by registry, package identifier and version.
+// The server name, file hash and image digest are included because they are part of what is validated.
+func validationCacheKey(pkg model.Package, serverName string) string {
+	return validationCacheKeyPrefix + strings.Join([]string{
+		pkg.RegistryType,
+		pkg.RegistryBaseURL,
+		pkg.Identifier,
+		pkg.Version,
+		pkg.FileSHA256,
+		pkg.Digest,
+		serverName,
+	}, "|")
+}
@@ -0,0 +1,70 @@
+package registries_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/cache"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithValidationCache(t *testing.T) {
+	ctx := context.Background()
+	pkg := model.Package{
+		RegistryType: model.RegistryTypeNPM,
+		Identifier:   "@example/server",
+		Version:      "1.0.0",
+	}
+
+	calls := 0
+	validate := func(_ context.Context, _ model.Package, _ string) error {
+		calls++
+		return nil
+	}
+
+	t.Run("disabled cache always validates", func(t *testing.T) {
+		registries.ConfigureValidationCache(nil, 0)
+		calls = 0
+
+		assert.NoError(t, registries.WithValidationCache(ctx, pkg, "io.github.example/server", validate))
+		assert.NoError(t, registries.WithValidationCache(ctx, pkg, "io.github.example/server", validate))
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("successful validations are cached per package version", func(t *testing.T) {
+		registries.ConfigureValidationCache(cache.NewMemoryCache(), time.Hour)
+		defer registries.ConfigureValidationCache(nil, 0)
+		calls = 0
+
+		assert.NoError(t, registries.WithValidationCache(ctx, pkg, "io.github.example/server", validate))
+		assert.NoError(t, registries.WithValidationCache(ctx, pkg, "io.github.example/server", validate))
+		assert.Equal(t, 1, calls)
+
+		newVersion := pkg
+		newVersion.Version = "1.0.1"
+		assert.NoError(t, registries.WithValidationCache(ctx, newVersion, "io.github.example/server", validate))
+		assert.Equal(t, 2, calls)
+
+		assert.NoError(t, registries.WithValidationCache(ctx, pkg, "io.github.other/server", validate))
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("failed validations are not cached", func(t *testing.T) {
+		registries.ConfigureValidationCache(cache.NewMemoryCache(), time.Hour)
+		defer registries.ConfigureValidationCache(nil, 0)
+
+		failures := 0
+		failing := func(_ context.Context, _ model.Package, _ string) error {
+			failures++
+			return errors.New("package not found")
+		}
+
+		assert.Error(t, registries.WithValidationCache(ctx, pkg, "io.github.example/server", failing))
+		assert.Error(t, registries.WithValidationCache(ctx, pkg, "io.github.example/server", failing))
+		assert.Equal(t, 2, failures)
+	})
+}
@@ -0,0 +1,97 @@
+package registries
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+)
+
+// maxDNSCacheEntries bounds the cache; expired entries are swept once it is reached
+const maxDNSCacheEntries = 1024
+
+// dnsCache remembers the addresses registry hosts resolve to for a short time, so a burst of publishes
+// referencing the same registries doesn't wait on a DNS lookup for every new connection
+type dnsCache struct {
+	ttl        time.Duration
+	lookupHost func(ctx context.Context, host string) ([]string, error)
+
+	mu      sync.Mutex
+	entries map[string]dnsCacheEntry
+}
+
+type dnsCacheEntry struct {
+	addrs   []string
+	expires time.Time
+}
+
+func newDNSCache(ttl time.Duration) *dnsCache {
+	return &dnsCache{
+		ttl:        ttl,
+		lookupHost: net.DefaultResolver.LookupHost,
+		entries:    make(map[string]dnsCacheEntry),
+	}
+}
+
+// lookup returns the cached addresses of host, resolving it if they are missing or expired.
+// Failed lookups are not cached, so a transient resolver error doesn't outlive the request.
+func (c *dnsCache) lookup(ctx context.Context, host string) ([]string, error) {
+	now := time.Now()
+
+	c.mu.Lock()
+	entry, ok := c.entries[host]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.addrs, nil
+	}
+
+	addrs, err := c.lookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= maxDNSCacheEntries {
+		for cached, entry := range c.entries {
+			if !now.Before(entry.expires) {
+				delete(c.entries, cached)
+			}
+		}
+	}
+	if len(c.entries) < maxDNSCacheEntries {
+		c.entries[host] = dnsCacheEntry{addrs: addrs, expires: now.Add(c.ttl)}
+	}
+	return addrs, nil
+}
+
+// dialContext dials address through dialer, resolving its host through the cache and trying each
+// address in turn until one connects
+func (c *dnsCache) dialContext(dialer *net.Dialer) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil || net.ParseIP(host) != nil {
+			return dialer.DialContext(ctx, network, address)
+		}
+
+		addrs, err := c.lookup(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+
+		var firstErr error
+		for _, addr := range addrs {
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(addr, port))
+			if err == nil {
+				return conn, nil
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		if firstErr == nil {
+			firstErr = &net.DNSError{Err: "no addresses found", Name: host, IsNotFound: true}
+		}
+		return nil, firstErr
+	}
+}
@@ -0,0 +1,74 @@
+package registries
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNSCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	cache := newDNSCache(time.Minute)
+	lookups := 0
+	cache.lookupHost = func(_ context.Context, host string) ([]string, error) {
+		lookups++
+		if host != "registry.example" {
+			return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		}
+		return []string{serverURL.Hostname()}, nil
+	}
+	dial := cache.dialContext(&net.Dialer{Timeout: time.Second})
+	address := net.JoinHostPort("registry.example", serverURL.Port())
+
+	t.Run("resolved addresses are reused until they expire", func(t *testing.T) {
+		for range 3 {
+			conn, err := dial(context.Background(), "tcp", address)
+			require.NoError(t, err)
+			_ = conn.Close()
+		}
+		assert.Equal(t, 1, lookups)
+
+		cache.mu.Lock()
+		entry := cache.entries["registry.example"]
+		entry.expires = time.Now().Add(-time.Second)
+		cache.entries["registry.example"] = entry
+		cache.mu.Unlock()
+
+		conn, err := dial(context.Background(), "tcp", address)
+		require.NoError(t, err)
+		_ = conn.Close()
+		assert.Equal(t, 2, lookups)
+	})
+
+	t.Run("failed lookups are not cached", func(t *testing.T) {
+		before := lookups
+		for range 2 {
+			_, err := dial(context.Background(), "tcp", "missing.example:443")
+			var dnsErr *net.DNSError
+			require.True(t, errors.As(err, &dnsErr))
+		}
+		assert.Equal(t, before+2, lookups)
+	})
+
+	t.Run("IP addresses are dialed directly", func(t *testing.T) {
+		before := lookups
+		conn, err := dial(context.Background(), "tcp", serverURL.Host)
+		require.NoError(t, err)
+		_ = conn.Close()
+		assert.Equal(t, before, lookups)
+	})
+}
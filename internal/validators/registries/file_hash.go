@@ -0,0 +1,216 @@
+package registries
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+const (
+	// Package files are downloaded in full to hash them, so allow more time than metadata requests
+	fileDownloadTimeout = 2 * time.Minute
+	maxPackageFileSize  = 512 << 20
+
+	fileHashCacheKeyPrefix = "file-sha256:"
+)
+
+var fileSHA256Re = regexp.MustCompile(`^[a-f0-9]{64}$`)
+
+var (
+	// ErrFileHashMismatch is returned when a package file doesn't match its declared or upstream hash
+	ErrFileHashMismatch = errors.New("package file hash mismatch")
+
+	errPackageFileTooLarge = errors.New("package file is too large")
+)
+
+var (
+	fileHashMaxBytesMu sync.RWMutex
+	fileHashMaxBytes   int64
+)
+
+// ConfigureFileHashRecording sets the largest package file ResolveFileSHA256 downloads to hash. Downloading
+// every file on every publish is expensive, so zero or less, the default, disables hash recording.
+func ConfigureFileHashRecording(maxBytes int64) {
+	fileHashMaxBytesMu.Lock()
+	defer fileHashMaxBytesMu.Unlock()
+	fileHashMaxBytes = min(maxBytes, maxPackageFileSize)
+}
+
+func configuredFileHashMaxBytes() int64 {
+	fileHashMaxBytesMu.RLock()
+	defer fileHashMaxBytesMu.RUnlock()
+	return fileHashMaxBytes
+}
+
+// IsFileSHA256 reports whether hash is a lowercase hex SHA-256 digest
+func IsFileSHA256(hash string) bool {
+	return fileSHA256Re.MatchString(hash)
+}
+
+// ResolveFileSHA256 downloads the file an NPM or MCPB package installs and returns its SHA-256 hash, failing
+// if it doesn't match the hash declared in the package. Other package types return an empty hash, as do all
+// packages unless hash recording is configured (see ConfigureFileHashRecording). An empty hash is also returned
+// without error if the file is larger than the configured limit or the host rate limits the download.
+// Hashes are kept in the validation cache, keyed by file URL and expected hashes, so republishing doesn't download again.
+func ResolveFileSHA256(ctx context.Context, pkg model.Package) (string, error) {
+	maxBytes := configuredFileHashMaxBytes()
+	if maxBytes <= 0 {
+		return "", nil
+	}
+
+	var (
+		fileURL   string
+		integrity string
+		err       error
+	)
+	switch pkg.RegistryType {
+	case model.RegistryTypeNPM:
+		fileURL, integrity, err = npmTarball(ctx, pkg)
+		if err != nil {
+			return "", err
+		}
+	case model.RegistryTypeMCPB:
+		fileURL = pkg.Identifier
+	default:
+		return "", nil
+	}
+
+	cacheKey := fileHashCacheKeyPrefix + strings.Join([]string{fileURL, integrity, pkg.FileSHA256}, "|")
+	if cached, ok := cachedValidation(ctx, cacheKey); ok {
+		return string(cached), nil
+	}
+
+	sum, integrityOK, err := hashPackageFile(ctx, fileURL, integrity, maxBytes)
+	if errors.Is(err, errPackageFileTooLarge) {
+		log.Printf("Package file '%s' is larger than %s. Skipping hash recording.", fileURL, formatByteSize(maxBytes))
+		return "", nil
+	}
+	if err != nil || sum == "" {
+		return "", err
+	}
+	if !integrityOK {
+		return "", fmt.Errorf("%w: '%s' does not match the registry's integrity %s", ErrFileHashMismatch, fileURL, integrity)
+	}
+	if pkg.FileSHA256 != "" && pkg.FileSHA256 != sum {
+		return "", fmt.Errorf("%w: '%s' has SHA-256 %s, but file_sha256 is %s", ErrFileHashMismatch, fileURL, sum, pkg.FileSHA256)
+	}
+
+	storeValidation(ctx, cacheKey, []byte(sum))
+	return sum, nil
+}
+
+// npmTarball returns the tarball URL and Subresource Integrity string of an NPM package version
+func npmTarball(ctx context.Context, pkg model.Package) (string, string, error) {
+	baseURL := pkg.RegistryBaseURL
+	if baseURL == "" {
+		baseURL = model.RegistryURLNPM
+	}
+
+	requestURL := baseURL + "/" + url.PathEscape(pkg.Identifier) + "/" + url.PathEscape(pkg.Version)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := NewHTTPClient().Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to fetch package metadata from NPM: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("NPM package '%s' not found (status: %d)", pkg.Identifier, resp.StatusCode)
+	}
+
+	var metadata struct {
+		Dist struct {
+			Tarball   string `json:"tarball"`
+			Integrity string `json:"integrity"`
+		} `json:"dist"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+		return "", "", fmt.Errorf("failed to parse NPM package metadata: %w", err)
+	}
+
+	// Only follow tarball links to the registry itself
+	tarball, err := url.Parse(metadata.Dist.Tarball)
+	registry, _ := url.Parse(baseURL)
+	if err != nil || metadata.Dist.Tarball == "" || registry == nil || !strings.EqualFold(tarball.Host, registry.Host) {
+		return "", "", fmt.Errorf("NPM package '%s' has an invalid tarball URL: %q", pkg.Identifier, metadata.Dist.Tarball)
+	}
+
+	return metadata.Dist.Tarball, metadata.Dist.Integrity, nil
+}
+
+// hashPackageFile downloads fileURL and returns its hex SHA-256 hash, along with whether it matches the
+// given Subresource Integrity string (e.g. "sha512-<base64>"). Unsupported or empty integrity strings match.
+// Files larger than maxBytes fail.
+func hashPackageFile(ctx context.Context, fileURL, integrity string, maxBytes int64) (string, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
+
+	client := &http.Client{Timeout: fileDownloadTimeout, Transport: currentTransport()}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to download package file '%s': %w", fileURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		// Matches the OCI digest lookup: don't fail publishing because of upstream rate limits
+		log.Printf("Warning: Rate limited when downloading package file '%s'. Skipping hash recording.", fileURL)
+		return "", false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", false, fmt.Errorf("failed to download package file '%s' (status: %d)", fileURL, resp.StatusCode)
+	}
+
+	sha256Hash := sha256.New()
+	writers := []io.Writer{sha256Hash}
+	algorithm, expected, _ := strings.Cut(integrity, "-")
+	var integrityHash hash.Hash
+	if algorithm == "sha512" {
+		integrityHash = sha512.New()
+		writers = append(writers, integrityHash)
+	}
+
+	n, err := io.Copy(io.MultiWriter(writers...), io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return "", false, fmt.Errorf("failed to download package file '%s': %w", fileURL, err)
+	}
+	if n > maxBytes {
+		return "", false, fmt.Errorf("%w: '%s' is larger than %s", errPackageFileTooLarge, fileURL, formatByteSize(maxBytes))
+	}
+
+	integrityOK := integrityHash == nil || base64.StdEncoding.EncodeToString(integrityHash.Sum(nil)) == expected
+	return hex.EncodeToString(sha256Hash.Sum(nil)), integrityOK, nil
+}
+
+// formatByteSize formats a size limit in MiB when it is a whole number of MiB, and in bytes otherwise
+func formatByteSize(n int64) string {
+	if n >= 1<<20 && n%(1<<20) == 0 {
+		return fmt.Sprintf("%d MiB", n>>20)
+	}
+	return fmt.Sprintf("%d bytes", n)
+}
@@ -0,0 +1,157 @@
+package registries_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/cache"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveFileSHA256(t *testing.T) {
+	isolateTransport(t)
+	ctx := context.Background()
+	registries.ConfigureFileHashRecording(1 << 20)
+	defer registries.ConfigureFileHashRecording(0)
+
+	tarball := []byte("package tarball contents")
+	sha256Sum := sha256.Sum256(tarball)
+	fileHash := hex.EncodeToString(sha256Sum[:])
+	sha512Sum := sha512.Sum512(tarball)
+	integrity := "sha512-" + base64.StdEncoding.EncodeToString(sha512Sum[:])
+
+	var (
+		serverURL string
+		downloads atomic.Int32
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/example-mcp/1.0.0":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"dist": map[string]string{"tarball": serverURL + "/example-mcp/-/example-mcp-1.0.0.tgz", "integrity": integrity},
+			})
+		case "/tampered-mcp/1.0.0":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"dist": map[string]string{"tarball": serverURL + "/example-mcp/-/example-mcp-1.0.0.tgz", "integrity": "sha512-AAAA"},
+			})
+		case "/offsite-mcp/1.0.0":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"dist": map[string]string{"tarball": "https://attacker.example/example-mcp-1.0.0.tgz"},
+			})
+		case "/example-mcp/-/example-mcp-1.0.0.tgz", "/releases/download/v1.0.0/server.mcpb":
+			downloads.Add(1)
+			_, _ = w.Write(tarball)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	serverURL = server.URL
+
+	npmPackage := func(identifier string) model.Package {
+		return model.Package{RegistryType: model.RegistryTypeNPM, RegistryBaseURL: server.URL, Identifier: identifier, Version: "1.0.0"}
+	}
+
+	t.Run("records NPM tarball hash", func(t *testing.T) {
+		sum, err := registries.ResolveFileSHA256(ctx, npmPackage("example-mcp"))
+		require.NoError(t, err)
+		assert.Equal(t, fileHash, sum)
+	})
+
+	t.Run("declared hash must match", func(t *testing.T) {
+		pkg := npmPackage("example-mcp")
+		pkg.FileSHA256 = fileHash
+		_, err := registries.ResolveFileSHA256(ctx, pkg)
+		require.NoError(t, err)
+
+		pkg.FileSHA256 = strings.Repeat("0", 64)
+		_, err = registries.ResolveFileSHA256(ctx, pkg)
+		assert.ErrorIs(t, err, registries.ErrFileHashMismatch)
+	})
+
+	t.Run("tarball must match NPM integrity", func(t *testing.T) {
+		_, err := registries.ResolveFileSHA256(ctx, npmPackage("tampered-mcp"))
+		assert.ErrorIs(t, err, registries.ErrFileHashMismatch)
+	})
+
+	t.Run("tarball must be hosted by the registry", func(t *testing.T) {
+		_, err := registries.ResolveFileSHA256(ctx, npmPackage("offsite-mcp"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid tarball URL")
+	})
+
+	t.Run("verifies MCPB file", func(t *testing.T) {
+		pkg := model.Package{
+			RegistryType: model.RegistryTypeMCPB,
+			Identifier:   server.URL + "/releases/download/v1.0.0/server.mcpb",
+			Version:      "1.0.0",
+			FileSHA256:   fileHash,
+		}
+		sum, err := registries.ResolveFileSHA256(ctx, pkg)
+		require.NoError(t, err)
+		assert.Equal(t, fileHash, sum)
+
+		pkg.FileSHA256 = strings.Repeat("a", 64)
+		_, err = registries.ResolveFileSHA256(ctx, pkg)
+		assert.ErrorIs(t, err, registries.ErrFileHashMismatch)
+	})
+
+	t.Run("hashes are cached by file URL and expected hashes", func(t *testing.T) {
+		registries.ConfigureValidationCache(cache.NewMemoryCache(), time.Hour)
+		defer registries.ConfigureValidationCache(nil, 0)
+		downloads.Store(0)
+
+		for i := 0; i < 2; i++ {
+			sum, err := registries.ResolveFileSHA256(ctx, npmPackage("example-mcp"))
+			require.NoError(t, err)
+			assert.Equal(t, fileHash, sum)
+		}
+		assert.Equal(t, int32(1), downloads.Load())
+
+		// A different declared hash isn't answered from the cache
+		pkg := npmPackage("example-mcp")
+		pkg.FileSHA256 = strings.Repeat("0", 64)
+		_, err := registries.ResolveFileSHA256(ctx, pkg)
+		assert.ErrorIs(t, err, registries.ErrFileHashMismatch)
+		assert.Equal(t, int32(2), downloads.Load())
+	})
+
+	t.Run("files over the size limit are not hashed", func(t *testing.T) {
+		registries.ConfigureFileHashRecording(int64(len(tarball) - 1))
+		defer registries.ConfigureFileHashRecording(1 << 20)
+
+		sum, err := registries.ResolveFileSHA256(ctx, npmPackage("example-mcp"))
+		require.NoError(t, err)
+		assert.Empty(t, sum)
+	})
+
+	t.Run("hash recording is off by default", func(t *testing.T) {
+		registries.ConfigureFileHashRecording(0)
+		defer registries.ConfigureFileHashRecording(1 << 20)
+		downloads.Store(0)
+
+		sum, err := registries.ResolveFileSHA256(ctx, npmPackage("example-mcp"))
+		require.NoError(t, err)
+		assert.Empty(t, sum)
+		assert.Equal(t, int32(0), downloads.Load())
+	})
+
+	t.Run("other package types are skipped", func(t *testing.T) {
+		sum, err := registries.ResolveFileSHA256(ctx, model.Package{RegistryType: model.RegistryTypePyPI, Identifier: "example", Version: "1.0.0"})
+		require.NoError(t, err)
+		assert.Empty(t, sum)
+	})
+}
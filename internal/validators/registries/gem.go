@@ -0,0 +1,87 @@
+package registries
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// gemServerNameMetadataKey is the gemspec metadata key that names the MCP server a gem belongs to
+const gemServerNameMetadataKey = "mcp_server_name"
+
+// gemNameRe matches the names RubyGems allows
+var gemNameRe = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// RubyGemsVersionResponse represents the structure returned by the RubyGems version API
+type RubyGemsVersionResponse struct {
+	Metadata map[string]string `json:"metadata"`
+}
+
+// ValidateGem validates that a RubyGems gem version exists and names the MCP server in its metadata
+func ValidateGem(ctx context.Context, pkg model.Package, serverName string) error {
+	// Set default registry base URL if empty
+	if pkg.RegistryBaseURL == "" {
+		pkg.RegistryBaseURL = model.RegistryURLRubyGems
+	}
+
+	if pkg.Identifier == "" {
+		return fmt.Errorf("package identifier is required for RubyGems packages")
+	}
+	if !gemNameRe.MatchString(pkg.Identifier) {
+		return fmt.Errorf("RubyGems package identifier '%s' is not a valid gem name", pkg.Identifier)
+	}
+
+	// Metadata is per version, so a version is needed to check it
+	if pkg.Version == "" {
+		return fmt.Errorf("package version is required for RubyGems packages")
+	}
+
+	// Validate that the registry base URL matches RubyGems exactly
+	if pkg.RegistryBaseURL != model.RegistryURLRubyGems {
+		return fmt.Errorf("registry type and base URL do not match: '%s' is not valid for registry type '%s'. Expected: %s",
+			pkg.RegistryBaseURL, model.RegistryTypeGem, model.RegistryURLRubyGems)
+	}
+
+	client := NewHTTPClient()
+
+	requestURL := pkg.RegistryBaseURL + "/api/v2/rubygems/" + url.PathEscape(pkg.Identifier) + "/versions/" + url.PathEscape(pkg.Version) + ".json"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch gem metadata from RubyGems: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("RubyGems gem '%s' version '%s' not found (status: %d)", pkg.Identifier, pkg.Version, resp.StatusCode)
+	}
+
+	var gemResp RubyGemsVersionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&gemResp); err != nil {
+		return fmt.Errorf("failed to parse RubyGems gem metadata: %w", err)
+	}
+
+	mcpServerName := gemResp.Metadata[gemServerNameMetadataKey]
+	if mcpServerName == "" {
+		return fmt.Errorf("RubyGems gem '%s' is missing required '%s' metadata. Add this to your gemspec: spec.metadata[\"%s\"] = \"%s\"",
+			pkg.Identifier, gemServerNameMetadataKey, gemServerNameMetadataKey, serverName)
+	}
+
+	if mcpServerName != serverName {
+		return fmt.Errorf("RubyGems gem ownership validation failed. Expected %s '%s', got '%s'", gemServerNameMetadataKey, serverName, mcpServerName)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package registries_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateGem(t *testing.T) {
+	isolateTransport(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		packageName     string
+		version         string
+		registryBaseURL string
+		errorMessage    string
+	}{
+		{
+			name:         "empty package identifier should fail",
+			version:      "1.0.0",
+			errorMessage: "package identifier is required for RubyGems packages",
+		},
+		{
+			name:         "invalid gem name should fail",
+			packageName:  "../weather-mcp",
+			version:      "1.0.0",
+			errorMessage: "is not a valid gem name",
+		},
+		{
+			name:         "empty package version should fail",
+			packageName:  "weather-mcp",
+			errorMessage: "package version is required for RubyGems packages",
+		},
+		{
+			name:            "other base URL should fail",
+			packageName:     "weather-mcp",
+			version:         "1.0.0",
+			registryBaseURL: "https://gems.example.com",
+			errorMessage:    "registry type and base URL do not match",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg := model.Package{
+				RegistryType:    model.RegistryTypeGem,
+				RegistryBaseURL: tt.registryBaseURL,
+				Identifier:      tt.packageName,
+				Version:         tt.version,
+			}
+
+			err := registries.ValidateGem(ctx, pkg, "com.example/test")
+			assert.ErrorContains(t, err, tt.errorMessage)
+		})
+	}
+}
@@ -0,0 +1,375 @@
+package registries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	defaultHTTPClientTimeout = 10 * time.Second
+
+	// Bodies of failed attempts are drained up to this size so connections can be reused
+	maxDrainBytes = 4 << 10
+)
+
+// ErrCircuitOpen is returned when requests to a registry host are being rejected after repeated failures
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// HTTPResilienceConfig controls retries and circuit breaking for outbound registry requests
+type HTTPResilienceConfig struct {
+	// MaxRetries is the number of times a failed GET or HEAD request is retried
+	MaxRetries int
+	// RetryBaseDelay is the backoff before the first retry; it doubles on each subsequent retry, with jitter
+	RetryBaseDelay time.Duration
+	// RetryMaxDelay caps the backoff between retries
+	RetryMaxDelay time.Duration
+	// BreakerThreshold is the number of consecutive failures after which a host's circuit opens; 0 disables the breaker
+	BreakerThreshold int
+	// BreakerCooldown is how long an open circuit rejects requests before letting a probe request through
+	BreakerCooldown time.Duration
+}
+
+// DefaultHTTPResilienceConfig returns the retry and circuit breaker settings used unless configured otherwise
+func DefaultHTTPResilienceConfig() HTTPResilienceConfig {
+	return HTTPResilienceConfig{
+		MaxRetries:       2,
+		RetryBaseDelay:   200 * time.Millisecond,
+		RetryMaxDelay:    2 * time.Second,
+		BreakerThreshold: 5,
+		BreakerCooldown:  30 * time.Second,
+	}
+}
+
+var (
+	httpResilienceMu sync.RWMutex
+	httpResilience   = DefaultHTTPResilienceConfig()
+
+	transportMu     sync.RWMutex
+	sharedTransport = NewTransport()
+)
+
+// ConfigureHTTPResilience sets the retry and circuit breaker behaviour for registry validators
+func ConfigureHTTPResilience(cfg HTTPResilienceConfig) {
+	httpResilienceMu.Lock()
+	defer httpResilienceMu.Unlock()
+	httpResilience = cfg
+}
+
+func currentHTTPResilience() HTTPResilienceConfig {
+	httpResilienceMu.RLock()
+	defer httpResilienceMu.RUnlock()
+	return httpResilience
+}
+
+// NewHTTPClient returns the HTTP client validators use to talk to upstream services.
+// It applies the configured request timeout, proxy, TLS, retry and circuit breaker settings.
+func NewHTTPClient() *http.Client {
+	return &http.Client{Timeout: currentClientTimeout(), Transport: currentTransport()}
+}
+
+// Transport retries transient failures with jittered exponential backoff and
+// stops calling hosts that keep failing, so one flaky registry can't stall every publish
+type Transport struct {
+	mu       sync.Mutex
+	breakers map[string]*circuitBreaker
+}
+
+// NewTransport returns a transport with every host's circuit closed
+func NewTransport() *Transport {
+	return &Transport{breakers: make(map[string]*circuitBreaker)}
+}
+
+// SetTransport replaces the transport validators send upstream requests through and returns the previous one.
+// Installing a new transport discards the circuit breaker state of every host, so tests use it to stay isolated.
+func SetTransport(t *Transport) *Transport {
+	transportMu.Lock()
+	defer transportMu.Unlock()
+
+	previous := sharedTransport
+	sharedTransport = t
+	return previous
+}
+
+func currentTransport() *Transport {
+	transportMu.RLock()
+	defer transportMu.RUnlock()
+	return sharedTransport
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	cfg := currentHTTPResilience()
+	host := req.URL.Host
+	breaker := t.breakerFor(host)
+
+	if !breaker.allow(cfg) {
+		breakerRejections(req.Context(), host)
+		err := fmt.Errorf("%w for %s", ErrCircuitOpen, host)
+		traceCall(req, time.Now(), nil, err)
+		return nil, err
+	}
+
+	// Only idempotent, bodiless requests are safe to resend
+	maxAttempts := 1
+	if (req.Method == http.MethodGet || req.Method == http.MethodHead) && req.Body == nil {
+		maxAttempts += max(cfg.MaxRetries, 0)
+	}
+
+	for attempt := 1; ; attempt++ {
+		started := time.Now()
+		resp, err := baseTransportFor(req.URL.Hostname()).RoundTrip(req)
+		traceCall(req, started, resp, err)
+		outcome, retryable := classifyAttempt(req.Context(), resp, err)
+
+		var delay time.Duration
+		if retryable && attempt < maxAttempts {
+			delay, retryable = retryDelay(cfg, attempt, resp)
+		}
+		if !retryable || attempt >= maxAttempts {
+			breaker.record(cfg, outcome)
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.CopyN(io.Discard, resp.Body, maxDrainBytes)
+			resp.Body.Close()
+		}
+		retryAttempts(req.Context(), host)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			breaker.record(cfg, outcome)
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+func (t *Transport) breakerFor(host string) *circuitBreaker {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	breaker, ok := t.breakers[host]
+	if !ok {
+		breaker = &circuitBreaker{}
+		t.breakers[host] = breaker
+	}
+	return breaker
+}
+
+// breakerStates returns the current circuit state of every host contacted so far
+func (t *Transport) breakerStates() map[string]circuitState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	states := make(map[string]circuitState, len(t.breakers))
+	for host, breaker := range t.breakers {
+		states[host] = breaker.currentState()
+	}
+	return states
+}
+
+type attemptOutcome int
+
+const (
+	outcomeSuccess attemptOutcome = iota
+	outcomeFailure
+	// outcomeIgnored covers results that say nothing about upstream health, such as caller cancellation
+	outcomeIgnored
+)
+
+// classifyAttempt decides whether an attempt counts against the host's health and whether it may be retried
+func classifyAttempt(ctx context.Context, resp *http.Response, err error) (attemptOutcome, bool) {
+	if err != nil {
+		if ctx.Err() != nil {
+			return outcomeIgnored, false
+		}
+		return outcomeFailure, true
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		// Rate limiting means the registry is healthy, so it doesn't trip the breaker
+		return outcomeIgnored, true
+	case resp.StatusCode == http.StatusBadGateway, resp.StatusCode == http.StatusServiceUnavailable, resp.StatusCode == http.StatusGatewayTimeout:
+		return outcomeFailure, true
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return outcomeFailure, false
+	default:
+		return outcomeSuccess, false
+	}
+}
+
+// retryDelay returns the jittered backoff before the next attempt. Rate limited responses are only
+// retried when the registry asks for a short enough wait via Retry-After.
+func retryDelay(cfg HTTPResilienceConfig, attempt int, resp *http.Response) (time.Duration, bool) {
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+		if err != nil || seconds < 0 {
+			return 0, false
+		}
+		wait := time.Duration(seconds) * time.Second
+		return wait, wait <= cfg.RetryMaxDelay
+	}
+
+	backoff := cfg.RetryBaseDelay << (attempt - 1)
+	if backoff <= 0 || backoff > cfg.RetryMaxDelay {
+		backoff = cfg.RetryMaxDelay
+	}
+	if backoff <= 0 {
+		return 0, true
+	}
+	// Full jitter between half and all of the backoff spreads out retries from concurrent publishes
+	half := backoff / 2
+	return half + rand.N(half+1), true //nolint:gosec // jitter doesn't need a cryptographic source
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker tracks consecutive failures for a single host
+type circuitBreaker struct {
+	mu       sync.Mutex
+	state    circuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// allow reports whether a request may be sent. After the cooldown, a single probe request is let
+// through; its outcome decides whether the circuit closes again or stays open.
+func (b *circuitBreaker) allow(cfg HTTPResilienceConfig) bool {
+	if cfg.BreakerThreshold <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if time.Since(b.openedAt) < cfg.BreakerCooldown {
+			return false
+		}
+		b.state = circuitHalfOpen
+		b.probing = true
+		return true
+	case circuitHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+func (b *circuitBreaker) record(cfg HTTPResilienceConfig, outcome attemptOutcome) {
+	if cfg.BreakerThreshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wasProbe := b.state == circuitHalfOpen
+	b.probing = false
+
+	switch outcome {
+	case outcomeSuccess:
+		b.state = circuitClosed
+		b.failures = 0
+	case outcomeFailure:
+		b.failures++
+		if wasProbe || b.failures >= cfg.BreakerThreshold {
+			b.state = circuitOpen
+			b.openedAt = time.Now()
+		}
+	case outcomeIgnored:
+	}
+}
+
+func (b *circuitBreaker) currentState() circuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+var (
+	httpMetricsMu        sync.RWMutex
+	retryCounter         metric.Int64Counter
+	breakerRejectCounter metric.Int64Counter
+)
+
+// RegisterMetrics registers validator HTTP metrics: retries, circuit breaker rejections,
+// and the circuit breaker state of each registry host (0 closed, 1 open, 2 half-open)
+func RegisterMetrics(meter metric.Meter, namespace string) error {
+	retries, err := meter.Int64Counter(
+		namespace+".validator.http.retries",
+		metric.WithDescription("Total number of retried requests to upstream package registries"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create validator retry counter: %w", err)
+	}
+
+	rejections, err := meter.Int64Counter(
+		namespace+".validator.circuit_breaker.rejections",
+		metric.WithDescription("Total number of requests to upstream package registries rejected by an open circuit breaker"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create circuit breaker rejection counter: %w", err)
+	}
+
+	_, err = meter.Int64ObservableGauge(
+		namespace+".validator.circuit_breaker.state",
+		metric.WithDescription("Circuit breaker state per upstream registry host (0 closed, 1 open, 2 half-open)"),
+		metric.WithInt64Callback(func(_ context.Context, observer metric.Int64Observer) error {
+			for host, state := range currentTransport().breakerStates() {
+				observer.Observe(int64(state), metric.WithAttributes(attribute.String("host", host)))
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create circuit breaker state gauge: %w", err)
+	}
+
+	httpMetricsMu.Lock()
+	defer httpMetricsMu.Unlock()
+	retryCounter = retries
+	breakerRejectCounter = rejections
+	return nil
+}
+
+func retryAttempts(ctx context.Context, host string) {
+	httpMetricsMu.RLock()
+	defer httpMetricsMu.RUnlock()
+	if retryCounter != nil {
+		retryCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("host", host)))
+	}
+}
+
+func breakerRejections(ctx context.Context, host string) {
+	httpMetricsMu.RLock()
+	defer httpMetricsMu.RUnlock()
+	if breakerRejectCounter != nil {
+		breakerRejectCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("host", host)))
+	}
+}
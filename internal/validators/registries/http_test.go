@@ -0,0 +1,87 @@
+package registries_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryHTTPResilience(t *testing.T) {
+	isolateTransport(t)
+	ctx := context.Background()
+	digest := "sha256:" + strings.Repeat("a", 64)
+	defer registries.ConfigureHTTPResilience(registries.DefaultHTTPResilienceConfig())
+
+	t.Run("transient failures are retried", func(t *testing.T) {
+		registries.ConfigureHTTPResilience(registries.HTTPResilienceConfig{
+			MaxRetries:       2,
+			RetryBaseDelay:   time.Millisecond,
+			RetryMaxDelay:    5 * time.Millisecond,
+			BreakerThreshold: 5,
+			BreakerCooldown:  time.Hour,
+		})
+
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if calls.Add(1) <= 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Header().Set("Docker-Content-Digest", digest)
+		}))
+		defer server.Close()
+
+		resolved, err := registries.ResolveOCIDigest(ctx, model.Package{
+			RegistryType:    model.RegistryTypeOCI,
+			RegistryBaseURL: server.URL,
+			Identifier:      "example/image",
+			Version:         "1.0.0",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, digest, resolved)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("circuit opens after repeated failures", func(t *testing.T) {
+		registries.ConfigureHTTPResilience(registries.HTTPResilienceConfig{
+			MaxRetries:       0,
+			BreakerThreshold: 2,
+			BreakerCooldown:  time.Hour,
+		})
+
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		pkg := model.Package{
+			RegistryType:    model.RegistryTypeOCI,
+			RegistryBaseURL: server.URL,
+			Identifier:      "example/image",
+			Version:         "1.0.0",
+		}
+
+		for i := 0; i < 2; i++ {
+			_, err := registries.ResolveOCIDigest(ctx, pkg)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "status: 502")
+		}
+
+		_, err := registries.ResolveOCIDigest(ctx, pkg)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, registries.ErrCircuitOpen), "expected circuit breaker error, got %v", err)
+		assert.Equal(t, int32(2), calls.Load(), "open circuit should not contact the registry")
+	})
+}
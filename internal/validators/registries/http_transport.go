@@ -0,0 +1,148 @@
+package registries
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxIdleConnsPerHost keeps enough connections to each registry open for a burst of
+	// concurrent publishes; net/http's default of 2 makes most of them open a new connection
+	DefaultMaxIdleConnsPerHost = 16
+	// DefaultIdleConnTimeout is how long an idle registry connection is kept for reuse
+	DefaultIdleConnTimeout = 90 * time.Second
+	// DefaultDNSCacheTTL is how long resolved registry addresses are reused
+	DefaultDNSCacheTTL = 30 * time.Second
+)
+
+// HTTPTransportConfig configures how validators connect to upstream registries,
+// for deployments behind proxies or TLS-intercepting middleboxes
+type HTTPTransportConfig struct {
+	// ProxyURL is used for all registry requests. If empty, HTTPS_PROXY, HTTP_PROXY and NO_PROXY are honored.
+	ProxyURL string
+	// CACertFile is a PEM bundle of additional root CAs to trust alongside the system roots
+	CACertFile string
+	// InsecureSkipVerifyHosts lists registry hosts whose TLS certificates are not verified
+	InsecureSkipVerifyHosts []string
+	// MaxIdleConnsPerHost is the number of idle connections kept to each registry host; 0 uses DefaultMaxIdleConnsPerHost
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout is how long idle connections are kept; 0 uses DefaultIdleConnTimeout
+	IdleConnTimeout time.Duration
+	// DNSCacheTTL is how long resolved registry addresses are reused; 0 resolves on every new connection
+	DNSCacheTTL time.Duration
+}
+
+var (
+	baseTransportMu     sync.RWMutex
+	secureBaseTransport http.RoundTripper = newBaseTransport(
+		http.ProxyFromEnvironment, nil, HTTPTransportConfig{}, newDNSCache(DefaultDNSCacheTTL),
+	)
+	insecureBaseTransport http.RoundTripper
+	insecureHosts         map[string]bool
+)
+
+// ConfigureHTTPTransport sets the proxy and TLS settings used by registry validators
+func ConfigureHTTPTransport(cfg HTTPTransportConfig) error {
+	proxy := http.ProxyFromEnvironment
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil || proxyURL.Host == "" {
+			return fmt.Errorf("invalid validator proxy URL: %s", cfg.ProxyURL)
+		}
+		proxy = http.ProxyURL(proxyURL)
+	}
+
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		rootCAs = x509.NewCertPool()
+	}
+	if cfg.CACertFile != "" {
+		pem, err := os.ReadFile(cfg.CACertFile)
+		if err != nil {
+			return fmt.Errorf("failed to read validator CA certificates: %w", err)
+		}
+		if !rootCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no PEM certificates found in %s", cfg.CACertFile)
+		}
+	}
+
+	// Both transports resolve through the same cache
+	var dns *dnsCache
+	if cfg.DNSCacheTTL > 0 {
+		dns = newDNSCache(cfg.DNSCacheTTL)
+	}
+
+	secure := newBaseTransport(proxy, &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: rootCAs}, cfg, dns)
+
+	var insecure http.RoundTripper
+	skipHosts := make(map[string]bool, len(cfg.InsecureSkipVerifyHosts))
+	for _, host := range cfg.InsecureSkipVerifyHosts {
+		host = strings.ToLower(strings.TrimSpace(host))
+		if host == "" {
+			continue
+		}
+		skipHosts[host] = true
+		log.Printf("WARNING: TLS certificate verification is DISABLED for registry host %q. "+
+			"Package validation for this host is vulnerable to man-in-the-middle attacks; only use this behind a trusted proxy.", host)
+	}
+	if len(skipHosts) > 0 {
+		insecure = newBaseTransport(proxy, &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: true, //nolint:gosec // explicitly opted into per host by the operator
+		}, cfg, dns)
+	}
+
+	baseTransportMu.Lock()
+	defer baseTransportMu.Unlock()
+	secureBaseTransport = secure
+	insecureBaseTransport = insecure
+	insecureHosts = skipHosts
+	return nil
+}
+
+// newBaseTransport returns a transport tuned for many concurrent requests to a handful of registry hosts.
+// HTTP/2 is attempted even though a custom TLS config is set, so requests to the same host share a connection.
+func newBaseTransport(
+	proxy func(*http.Request) (*url.URL, error), tlsConfig *tls.Config, cfg HTTPTransportConfig, dns *dnsCache,
+) *http.Transport {
+	maxIdlePerHost := cfg.MaxIdleConnsPerHost
+	if maxIdlePerHost <= 0 {
+		maxIdlePerHost = DefaultMaxIdleConnsPerHost
+	}
+	idleTimeout := cfg.IdleConnTimeout
+	if idleTimeout <= 0 {
+		idleTimeout = DefaultIdleConnTimeout
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxy
+	transport.TLSClientConfig = tlsConfig
+	transport.ForceAttemptHTTP2 = true
+	transport.MaxIdleConnsPerHost = maxIdlePerHost
+	transport.IdleConnTimeout = idleTimeout
+	if dns != nil {
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+		transport.DialContext = dns.dialContext(dialer)
+	}
+	return transport
+}
+
+// baseTransportFor returns the transport for requests to the given host (without port)
+func baseTransportFor(host string) http.RoundTripper {
+	baseTransportMu.RLock()
+	defer baseTransportMu.RUnlock()
+
+	if insecureBaseTransport != nil && insecureHosts[strings.ToLower(host)] {
+		return insecureBaseTransport
+	}
+	return secureBaseTransport
+}
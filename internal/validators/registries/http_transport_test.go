@@ -0,0 +1,99 @@
+package registries_test
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureHTTPTransport(t *testing.T) {
+	isolateTransport(t)
+	ctx := context.Background()
+
+	// Disable retries and the circuit breaker so TLS failures surface directly
+	registries.ConfigureHTTPResilience(registries.HTTPResilienceConfig{})
+	defer registries.ConfigureHTTPResilience(registries.DefaultHTTPResilienceConfig())
+	defer func() {
+		require.NoError(t, registries.ConfigureHTTPTransport(registries.HTTPTransportConfig{}))
+	}()
+
+	// The attestation lookup is a convenient request: a 404 yields a recognisable error without TLS problems
+	tlsServer := httptest.NewTLSServer(http.NotFoundHandler())
+	defer tlsServer.Close()
+	tlsPkg := model.Package{
+		RegistryType:    model.RegistryTypeNPM,
+		RegistryBaseURL: tlsServer.URL,
+		Identifier:      "example-server",
+		Version:         "1.0.0",
+	}
+	const reachedRegistry = "has no provenance attestation"
+
+	// Any trust roots do, as the lookup never gets as far as verifying an attestation
+	rootsFile := filepath.Join(t.TempDir(), "roots.pem")
+	rootsPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tlsServer.Certificate().Raw})
+	require.NoError(t, os.WriteFile(rootsFile, rootsPEM, 0o600))
+	require.NoError(t, registries.ConfigureNPMProvenanceRoots(rootsFile))
+	defer func() { _ = registries.ConfigureNPMProvenanceRoots("") }()
+
+	t.Run("untrusted certificates are rejected", func(t *testing.T) {
+		require.NoError(t, registries.ConfigureHTTPTransport(registries.HTTPTransportConfig{}))
+
+		err := registries.ValidateNPMProvenance(ctx, tlsPkg, "https://github.com/example/server")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "certificate")
+	})
+
+	t.Run("custom CA certificates are trusted", func(t *testing.T) {
+		caFile := filepath.Join(t.TempDir(), "ca.pem")
+		certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tlsServer.Certificate().Raw})
+		require.NoError(t, os.WriteFile(caFile, certPEM, 0o600))
+
+		require.NoError(t, registries.ConfigureHTTPTransport(registries.HTTPTransportConfig{CACertFile: caFile}))
+
+		err := registries.ValidateNPMProvenance(ctx, tlsPkg, "https://github.com/example/server")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), reachedRegistry)
+	})
+
+	t.Run("TLS verification can be skipped per host", func(t *testing.T) {
+		require.NoError(t, registries.ConfigureHTTPTransport(registries.HTTPTransportConfig{
+			InsecureSkipVerifyHosts: []string{"127.0.0.1"},
+		}))
+
+		err := registries.ValidateNPMProvenance(ctx, tlsPkg, "https://github.com/example/server")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), reachedRegistry)
+	})
+
+	t.Run("requests go through the configured proxy", func(t *testing.T) {
+		var proxiedHost string
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxiedHost = r.Host
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer proxy.Close()
+
+		require.NoError(t, registries.ConfigureHTTPTransport(registries.HTTPTransportConfig{ProxyURL: proxy.URL}))
+
+		pkg := tlsPkg
+		pkg.RegistryBaseURL = "http://registry.internal.example"
+		err := registries.ValidateNPMProvenance(ctx, pkg, "https://github.com/example/server")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), reachedRegistry)
+		assert.Equal(t, "registry.internal.example", proxiedHost)
+	})
+
+	t.Run("invalid configuration is rejected", func(t *testing.T) {
+		assert.Error(t, registries.ConfigureHTTPTransport(registries.HTTPTransportConfig{ProxyURL: "://bad"}))
+		assert.Error(t, registries.ConfigureHTTPTransport(registries.HTTPTransportConfig{CACertFile: filepath.Join(t.TempDir(), "missing.pem")}))
+	})
+}
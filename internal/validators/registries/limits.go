@@ -0,0 +1,93 @@
+package registries
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RegistryLimits bounds validation against one registry type
+type RegistryLimits struct {
+	// Timeout bounds the whole validation of a package, across retries; 0 leaves it to the HTTP client timeout
+	Timeout time.Duration
+	// MaxConcurrent is the number of packages validated against the registry at once; 0 is unlimited
+	MaxConcurrent int
+	// Disabled skips validation against the registry, e.g. while it has an outage
+	Disabled bool
+}
+
+type registryLimiter struct {
+	limits RegistryLimits
+	slots  chan struct{}
+}
+
+var (
+	registryLimitsMu sync.RWMutex
+	registryLimiters map[string]*registryLimiter
+	clientTimeout    = defaultHTTPClientTimeout
+)
+
+// ConfigureRegistryLimits sets the timeout of each request validators make upstream and the limits of each
+// registry type, keyed by registry type (e.g. "oci"). A timeout of 0 keeps the default of 10 seconds.
+func ConfigureRegistryLimits(requestTimeout time.Duration, limits map[string]RegistryLimits) {
+	limiters := make(map[string]*registryLimiter, len(limits))
+	for registryType, l := range limits {
+		limiter := &registryLimiter{limits: l}
+		if l.MaxConcurrent > 0 {
+			limiter.slots = make(chan struct{}, l.MaxConcurrent)
+		}
+		limiters[registryType] = limiter
+	}
+	if requestTimeout <= 0 {
+		requestTimeout = defaultHTTPClientTimeout
+	}
+
+	registryLimitsMu.Lock()
+	defer registryLimitsMu.Unlock()
+	registryLimiters = limiters
+	clientTimeout = requestTimeout
+}
+
+// RegistryDisabled reports whether validation against the registry type is switched off
+func RegistryDisabled(registryType string) bool {
+	limiter := limiterFor(registryType)
+	return limiter != nil && limiter.limits.Disabled
+}
+
+// WithRegistryLimits runs validate within the timeout and concurrency limit of the registry type,
+// waiting for a free slot until ctx is done
+func WithRegistryLimits(ctx context.Context, registryType string, validate func(ctx context.Context) error) error {
+	limiter := limiterFor(registryType)
+	if limiter == nil {
+		return validate(ctx)
+	}
+
+	if limiter.slots != nil {
+		select {
+		case limiter.slots <- struct{}{}:
+			defer func() { <-limiter.slots }()
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting to validate against %s registry: %w", registryType, ctx.Err())
+		}
+	}
+
+	if limiter.limits.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, limiter.limits.Timeout)
+		defer cancel()
+	}
+	return validate(ctx)
+}
+
+func limiterFor(registryType string) *registryLimiter {
+	registryLimitsMu.RLock()
+	defer registryLimitsMu.RUnlock()
+	return registryLimiters[registryType]
+}
+
+func currentClientTimeout() time.Duration {
+	registryLimitsMu.RLock()
+	defer registryLimitsMu.RUnlock()
+	return clientTimeout
+}
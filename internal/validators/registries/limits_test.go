@@ -0,0 +1,110 @@
+package registries_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryLimits(t *testing.T) {
+	t.Cleanup(func() { registries.ConfigureRegistryLimits(0, nil) })
+	ctx := context.Background()
+
+	t.Run("request timeout applies to validator HTTP clients", func(t *testing.T) {
+		registries.ConfigureRegistryLimits(0, nil)
+		assert.Equal(t, 10*time.Second, registries.NewHTTPClient().Timeout)
+
+		registries.ConfigureRegistryLimits(3*time.Second, nil)
+		assert.Equal(t, 3*time.Second, registries.NewHTTPClient().Timeout)
+	})
+
+	t.Run("per-registry timeout bounds validation", func(t *testing.T) {
+		registries.ConfigureRegistryLimits(0, map[string]registries.RegistryLimits{
+			"oci": {Timeout: 20 * time.Millisecond},
+		})
+
+		err := registries.WithRegistryLimits(ctx, "oci", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		// Other registry types keep the caller's context
+		err = registries.WithRegistryLimits(ctx, "npm", func(ctx context.Context) error {
+			_, hasDeadline := ctx.Deadline()
+			assert.False(t, hasDeadline)
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("concurrency is capped per registry", func(t *testing.T) {
+		registries.ConfigureRegistryLimits(0, map[string]registries.RegistryLimits{
+			"oci": {MaxConcurrent: 2},
+		})
+
+		var running, peak atomic.Int32
+		var wg sync.WaitGroup
+		for range 6 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := registries.WithRegistryLimits(ctx, "oci", func(context.Context) error {
+					n := running.Add(1)
+					for {
+						current := peak.Load()
+						if n <= current || peak.CompareAndSwap(current, n) {
+							break
+						}
+					}
+					time.Sleep(10 * time.Millisecond)
+					running.Add(-1)
+					return nil
+				})
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+		assert.LessOrEqual(t, peak.Load(), int32(2))
+	})
+
+	t.Run("waiting for a slot gives up when the context is done", func(t *testing.T) {
+		registries.ConfigureRegistryLimits(0, map[string]registries.RegistryLimits{
+			"oci": {MaxConcurrent: 1},
+		})
+
+		release := make(chan struct{})
+		started := make(chan struct{})
+		go func() {
+			_ = registries.WithRegistryLimits(ctx, "oci", func(context.Context) error {
+				close(started)
+				<-release
+				return nil
+			})
+		}()
+		<-started
+		defer close(release)
+
+		waitCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		err := registries.WithRegistryLimits(waitCtx, "oci", func(context.Context) error {
+			return nil
+		})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("registries can be disabled", func(t *testing.T) {
+		registries.ConfigureRegistryLimits(0, map[string]registries.RegistryLimits{
+			"oci": {Disabled: true},
+		})
+		assert.True(t, registries.RegistryDisabled("oci"))
+		assert.False(t, registries.RegistryDisabled("npm"))
+	})
+}
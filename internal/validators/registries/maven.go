@@ -0,0 +1,141 @@
+package registries
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+const (
+	// mavenServerNameProperty is the POM property that names the MCP server a Maven artifact belongs to
+	mavenServerNameProperty = "mcp-server-name"
+
+	// POMs are small; anything larger is not a POM we need to read
+	maxPOMBytes = 1 << 20
+)
+
+// mavenCoordinatePartRe matches a Maven groupId or artifactId
+var mavenCoordinatePartRe = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+var errMavenPOMNotFound = errors.New("POM not found")
+
+var (
+	mavenMirrorsMu sync.RWMutex
+	mavenMirrors   []string
+)
+
+// ConfigureMavenMirrors sets mirrors of Maven Central to resolve artifacts from, in order, before Maven Central itself
+func ConfigureMavenMirrors(urls []string) {
+	mirrors := make([]string, 0, len(urls))
+	for _, url := range urls {
+		if url = strings.TrimRight(strings.TrimSpace(url), "/"); url != "" {
+			mirrors = append(mirrors, url)
+		}
+	}
+
+	mavenMirrorsMu.Lock()
+	defer mavenMirrorsMu.Unlock()
+	mavenMirrors = mirrors
+}
+
+func currentMavenMirrors() []string {
+	mavenMirrorsMu.RLock()
+	defer mavenMirrorsMu.RUnlock()
+	return mavenMirrors
+}
+
+// mavenPOM is the subset of a Maven POM we read
+type mavenPOM struct {
+	Properties struct {
+		MCPServerName string `xml:"mcp-server-name"`
+	} `xml:"properties"`
+}
+
+// ValidateMaven validates that a Maven artifact, identified as groupId:artifactId, exists at the given
+// version and declares the MCP server name in its POM's mcp-server-name property
+func ValidateMaven(ctx context.Context, pkg model.Package, serverName string) error {
+	// Set default registry base URL if empty
+	if pkg.RegistryBaseURL == "" {
+		pkg.RegistryBaseURL = model.RegistryURLMaven
+	}
+
+	// Validate that the registry base URL matches Maven Central exactly
+	if pkg.RegistryBaseURL != model.RegistryURLMaven {
+		return fmt.Errorf("registry type and base URL do not match: '%s' is not valid for registry type '%s'. Expected: %s",
+			pkg.RegistryBaseURL, model.RegistryTypeMaven, model.RegistryURLMaven)
+	}
+
+	groupID, artifactID, ok := strings.Cut(pkg.Identifier, ":")
+	if !ok || !mavenCoordinatePartRe.MatchString(groupID) || !mavenCoordinatePartRe.MatchString(artifactID) {
+		return fmt.Errorf("Maven package identifier '%s' must be in the form groupId:artifactId", pkg.Identifier)
+	}
+	if pkg.Version == "" {
+		return fmt.Errorf("Maven package validation requires a specific version, but none was provided")
+	}
+	if !mavenCoordinatePartRe.MatchString(pkg.Version) {
+		return fmt.Errorf("Maven package version '%s' is not valid", pkg.Version)
+	}
+
+	pomPath := fmt.Sprintf("/%s/%s/%s/%s-%s.pom",
+		strings.ReplaceAll(groupID, ".", "/"), artifactID, pkg.Version, artifactID, pkg.Version)
+
+	// Mirrors are tried first, falling back to Maven Central for artifacts they don't have or when they're unreachable
+	var pom *mavenPOM
+	var err error
+	for _, repository := range append(slices.Clone(currentMavenMirrors()), pkg.RegistryBaseURL) {
+		pom, err = fetchMavenPOM(ctx, repository+pomPath)
+		if err == nil {
+			break
+		}
+	}
+	if errors.Is(err, errMavenPOMNotFound) {
+		return fmt.Errorf("Maven package '%s' version '%s' not found", pkg.Identifier, pkg.Version)
+	}
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(pom.Properties.MCPServerName) != serverName {
+		return fmt.Errorf("Maven package '%s' ownership validation failed. The server name '%s' must be set as the '%s' property in the package POM (<properties><%s>%s</%s></properties>)",
+			pkg.Identifier, serverName, mavenServerNameProperty, mavenServerNameProperty, serverName, mavenServerNameProperty)
+	}
+
+	return nil
+}
+
+// fetchMavenPOM downloads and parses a POM from a Maven repository
+func fetchMavenPOM(ctx context.Context, url string) (*mavenPOM, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
+
+	resp, err := NewHTTPClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch POM from Maven repository: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errMavenPOMNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch POM from Maven repository (status: %d)", resp.StatusCode)
+	}
+
+	var pom mavenPOM
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, maxPOMBytes)).Decode(&pom); err != nil {
+		return nil, fmt.Errorf("failed to parse Maven POM: %w", err)
+	}
+	return &pom, nil
+}
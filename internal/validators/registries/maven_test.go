@@ -0,0 +1,125 @@
+package registries_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateMaven(t *testing.T) {
+	isolateTransport(t)
+	ctx := context.Background()
+
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/io/github/example/weather-mcp/1.2.0/weather-mcp-1.2.0.pom":
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <modelVersion>4.0.0</modelVersion>
+  <groupId>io.github.example</groupId>
+  <artifactId>weather-mcp</artifactId>
+  <version>1.2.0</version>
+  <properties>
+    <java.version>21</java.version>
+    <mcp-server-name>io.github.example/weather</mcp-server-name>
+  </properties>
+</project>`))
+		case "/io/github/example/weather-mcp/1.1.0/weather-mcp-1.1.0.pom":
+			_, _ = w.Write([]byte(`<project><groupId>io.github.example</groupId></project>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer mirror.Close()
+
+	registries.ConfigureMavenMirrors([]string{mirror.URL + "/"})
+	defer registries.ConfigureMavenMirrors(nil)
+
+	tests := []struct {
+		name            string
+		identifier      string
+		version         string
+		registryBaseURL string
+		serverName      string
+		errorMessage    string
+	}{
+		{
+			name:       "POM property matching the server name should pass",
+			identifier: "io.github.example:weather-mcp",
+			version:    "1.2.0",
+			serverName: "io.github.example/weather",
+		},
+		{
+			name:            "explicit Maven Central base URL should pass",
+			identifier:      "io.github.example:weather-mcp",
+			version:         "1.2.0",
+			registryBaseURL: model.RegistryURLMaven,
+			serverName:      "io.github.example/weather",
+		},
+		{
+			name:         "POM property naming another server should fail",
+			identifier:   "io.github.example:weather-mcp",
+			version:      "1.2.0",
+			serverName:   "io.github.other/weather",
+			errorMessage: "ownership validation failed",
+		},
+		{
+			name:         "POM without the property should fail",
+			identifier:   "io.github.example:weather-mcp",
+			version:      "1.1.0",
+			serverName:   "io.github.example/weather",
+			errorMessage: "must be set as the 'mcp-server-name' property",
+		},
+		{
+			name:            "other base URL should fail",
+			identifier:      "io.github.example:weather-mcp",
+			version:         "1.2.0",
+			registryBaseURL: mirror.URL,
+			serverName:      "io.github.example/weather",
+			errorMessage:    "registry type and base URL do not match",
+		},
+		{
+			name:         "identifier without an artifactId should fail",
+			identifier:   "io.github.example",
+			version:      "1.2.0",
+			serverName:   "io.github.example/weather",
+			errorMessage: "must be in the form groupId:artifactId",
+		},
+		{
+			name:         "identifier with a path should fail",
+			identifier:   "io.github.example:../weather-mcp",
+			version:      "1.2.0",
+			serverName:   "io.github.example/weather",
+			errorMessage: "must be in the form groupId:artifactId",
+		},
+		{
+			name:         "missing version should fail",
+			identifier:   "io.github.example:weather-mcp",
+			serverName:   "io.github.example/weather",
+			errorMessage: "requires a specific version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg := model.Package{
+				RegistryType:    model.RegistryTypeMaven,
+				RegistryBaseURL: tt.registryBaseURL,
+				Identifier:      tt.identifier,
+				Version:         tt.version,
+			}
+
+			err := registries.ValidateMaven(ctx, pkg, tt.serverName)
+			if tt.errorMessage == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.errorMessage)
+			}
+		})
+	}
+}
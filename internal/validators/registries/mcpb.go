@@ -7,16 +7,21 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
-	"time"
 
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
+// ValidateMCPB validates that an MCPB file is a release asset on an allowlisted host, is publicly accessible
+// and within the package size limit. Its file_sha256 is checked against the file by ResolveFileSHA256 when the
+// registry records file hashes.
 func ValidateMCPB(ctx context.Context, pkg model.Package, _ string) error {
 	// MCPB packages must include a file hash for integrity verification
 	if pkg.FileSHA256 == "" {
 		return fmt.Errorf("MCPB package must include a file_sha256 hash for integrity verification")
 	}
+	if !IsFileSHA256(pkg.FileSHA256) {
+		return fmt.Errorf("MCPB package file_sha256 must be a 64 character lowercase hex SHA-256 hash: %q", pkg.FileSHA256)
+	}
 
 	err := validateMCPBUrl(pkg.Identifier)
 	if err != nil {
@@ -50,7 +55,7 @@ func ValidateMCPB(ctx context.Context, pkg model.Package, _ string) error {
 	}
 
 	// Verify the file exists and is publicly accessible
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := NewHTTPClient()
 	req, err := http.NewRequestWithContext(ctx, http.MethodHead, pkg.Identifier, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
@@ -68,6 +73,11 @@ func ValidateMCPB(ctx context.Context, pkg model.Package, _ string) error {
 		return fmt.Errorf("MCPB package '%s' is not publicly accessible (status: %d)", pkg.Identifier, resp.StatusCode)
 	}
 
+	// ContentLength is -1 when the host doesn't report a size
+	if resp.ContentLength > maxPackageFileSize {
+		return fmt.Errorf("MCPB package '%s' is larger than %s", pkg.Identifier, formatByteSize(maxPackageFileSize))
+	}
+
 	return nil
 }
 
@@ -10,6 +10,7 @@ import (
 )
 
 func TestValidateMCPB(t *testing.T) {
+	isolateTransport(t)
 	ctx := context.Background()
 
 	tests := []struct {
@@ -31,7 +32,7 @@ func TestValidateMCPB(t *testing.T) {
 			name:        "valid MCPB package should pass",
 			packageName: "https://github.com/microsoft/playwright-mcp/releases/download/v0.0.36/playwright-mcp-extension-v0.0.36.zip",
 			serverName:  "com.microsoft/playwright-mcp",
-			fileSHA256:  "abc123ef4567890abcdef1234567890abcdef1234567890abcdef1234567890",
+			fileSHA256:  "abc123ef4567890abcdef1234567890abcdef1234567890abcdef12345678900",
 			expectError: false,
 		},
 		{
@@ -42,6 +43,14 @@ func TestValidateMCPB(t *testing.T) {
 			expectError:  true,
 			errorMessage: "must include a file_sha256 hash for integrity verification",
 		},
+		{
+			name:         "MCPB package with a malformed file hash should fail",
+			packageName:  "https://github.com/example/server/releases/download/v1.0.0/server.mcpb",
+			serverName:   "com.example/test",
+			fileSHA256:   "FE333E598595000AE021BD27117DB32EC69AF6987F507BA7A63C90638FF633CE",
+			expectError:  true,
+			errorMessage: "must be a 64 character lowercase hex SHA-256 hash",
+		},
 		{
 			name:         "non-existent .mcpb package should fail accessibility check",
 			packageName:  "https://github.com/example/server/releases/download/v1.0.0/server.mcpb",
@@ -6,11 +6,53 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"time"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
 
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
+var (
+	// npmPackageNameRe matches NPM package names, optionally scoped (@scope/name). Uppercase letters are
+	// allowed because packages published before NPM banned them can still be installed.
+	npmPackageNameRe = regexp.MustCompile(`^(?:@[A-Za-z0-9-~][A-Za-z0-9-._~]*/)?[A-Za-z0-9-~][A-Za-z0-9-._~]*$`)
+
+	// npmExactVersionRe matches the exact semantic versions NPM requires; anything else is a dist-tag
+	npmExactVersionRe = regexp.MustCompile(`^\d+\.\d+\.\d+(?:-[0-9A-Za-z.-]+)?(?:\+[0-9A-Za-z.-]+)?$`)
+)
+
+var (
+	npmRegistriesMu sync.RWMutex
+	npmRegistries   []string
+)
+
+// ConfigureNPMRegistries sets the base URLs of self-hosted NPM registries, such as Verdaccio or Artifactory
+// repositories, that packages may declare in addition to the public NPM registry
+func ConfigureNPMRegistries(urls []string) {
+	normalized := make([]string, 0, len(urls))
+	for _, url := range urls {
+		if url = strings.TrimRight(strings.TrimSpace(url), "/"); url != "" {
+			normalized = append(normalized, url)
+		}
+	}
+
+	npmRegistriesMu.Lock()
+	defer npmRegistriesMu.Unlock()
+	npmRegistries = normalized
+}
+
+func isAllowedNPMRegistry(baseURL string) bool {
+	if baseURL == model.RegistryURLNPM {
+		return true
+	}
+
+	npmRegistriesMu.RLock()
+	defer npmRegistriesMu.RUnlock()
+	return slices.Contains(npmRegistries, baseURL)
+}
+
 // NPMPackageResponse represents the structure returned by the NPM registry API
 type NPMPackageResponse struct {
 	MCPName string `json:"mcpName"`
@@ -35,15 +77,25 @@ func ValidateNPM(ctx context.Context, pkg model.Package, serverName string) erro
 		return fmt.Errorf("package version is required for NPM packages")
 	}
 
-	// Validate that the registry base URL matches NPM exactly
-	if pkg.RegistryBaseURL != model.RegistryURLNPM {
+	// Validate that the registry base URL is NPM or a configured self-hosted registry
+	pkg.RegistryBaseURL = strings.TrimRight(pkg.RegistryBaseURL, "/")
+	if !isAllowedNPMRegistry(pkg.RegistryBaseURL) {
 		return fmt.Errorf("registry type and base URL do not match: '%s' is not valid for registry type '%s'. Expected: %s",
 			pkg.RegistryBaseURL, model.RegistryTypeNPM, model.RegistryURLNPM)
 	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	if !npmPackageNameRe.MatchString(pkg.Identifier) {
+		return fmt.Errorf("NPM package identifier '%s' is not a valid package name", pkg.Identifier)
+	}
+
+	client := NewHTTPClient()
+
+	// Dist-tags such as "next" move between versions, so packages must pin the version a tag points to
+	if !npmExactVersionRe.MatchString(pkg.Version) {
+		return npmDistTagError(ctx, client, pkg)
+	}
 
-	requestURL := pkg.RegistryBaseURL + "/" + url.PathEscape(pkg.Identifier) + "/" + url.PathEscape(pkg.Version)
+	requestURL := pkg.RegistryBaseURL + "/" + npmEscapeName(pkg.Identifier) + "/" + url.PathEscape(pkg.Version)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
@@ -77,3 +129,36 @@ func ValidateNPM(ctx context.Context, pkg model.Package, serverName string) erro
 
 	return nil
 }
+
+// npmEscapeName escapes a package name for use as a single path segment, encoding the slash in a scoped
+// name (@scope%2fname) as the NPM CLI does, since self-hosted registries only route that form reliably
+func npmEscapeName(name string) string {
+	return strings.Replace(name, "/", "%2f", 1)
+}
+
+// npmDistTagError explains that a package version is a dist-tag rather than an exact version, naming the
+// version the tag currently points to if the registry knows it
+func npmDistTagError(ctx context.Context, client *http.Client, pkg model.Package) error {
+	invalid := fmt.Errorf("NPM package version '%s' is not an exact version. Use a specific version such as '1.2.3' instead of a dist-tag or range", pkg.Version)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pkg.RegistryBaseURL+"/-/package/"+npmEscapeName(pkg.Identifier)+"/dist-tags", nil)
+	if err != nil {
+		return invalid
+	}
+	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return invalid
+	}
+	defer resp.Body.Close()
+
+	var distTags map[string]string
+	if resp.StatusCode != http.StatusOK || json.NewDecoder(resp.Body).Decode(&distTags) != nil || distTags[pkg.Version] == "" {
+		return invalid
+	}
+
+	return fmt.Errorf("NPM package version '%s' is a dist-tag, which can move to another version. Use the exact version it points to, '%s', instead",
+		pkg.Version, distTags[pkg.Version])
+}
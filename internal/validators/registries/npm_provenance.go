@@ -0,0 +1,346 @@
+package registries
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+const (
+	slsaProvenanceV1  = "https://slsa.dev/provenance/v1"
+	slsaProvenanceV02 = "https://slsa.dev/provenance/v0.2"
+)
+
+// oidFulcioSourceRepository is the Fulcio certificate extension naming the repository whose CI workflow the
+// signing certificate was issued to
+var oidFulcioSourceRepository = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 12}
+
+// ErrProvenanceTrustNotConfigured is returned when NPM provenance is checked without Fulcio certificates to verify it against
+var ErrProvenanceTrustNotConfigured = errors.New("NPM provenance trust roots are not configured")
+
+// NPMAttestationsResponse represents the structure returned by the NPM registry attestations API
+type NPMAttestationsResponse struct {
+	Attestations []struct {
+		PredicateType string         `json:"predicateType"`
+		Bundle        sigstoreBundle `json:"bundle"`
+	} `json:"attestations"`
+}
+
+// sigstoreBundle is the subset of a Sigstore bundle needed to verify a DSSE-signed attestation
+type sigstoreBundle struct {
+	VerificationMaterial struct {
+		// Bundles before v0.3 carry the certificate chain, later ones only the signing certificate
+		X509CertificateChain *struct {
+			Certificates []sigstoreCertificate `json:"certificates"`
+		} `json:"x509CertificateChain"`
+		Certificate *sigstoreCertificate `json:"certificate"`
+	} `json:"verificationMaterial"`
+	DSSEEnvelope struct {
+		Payload     string `json:"payload"`
+		PayloadType string `json:"payloadType"`
+		Signatures  []struct {
+			Sig string `json:"sig"`
+		} `json:"signatures"`
+	} `json:"dsseEnvelope"`
+}
+
+type sigstoreCertificate struct {
+	RawBytes string `json:"rawBytes"`
+}
+
+// slsaStatement is the subset of an in-toto SLSA provenance statement needed to find the package and source repository
+type slsaStatement struct {
+	Subject []struct {
+		Name string `json:"name"`
+	} `json:"subject"`
+	Predicate struct {
+		// SLSA v1
+		BuildDefinition struct {
+			ExternalParameters struct {
+				Workflow struct {
+					Repository string `json:"repository"`
+				} `json:"workflow"`
+			} `json:"externalParameters"`
+		} `json:"buildDefinition"`
+		// SLSA v0.2
+		Invocation struct {
+			ConfigSource struct {
+				URI string `json:"uri"`
+			} `json:"configSource"`
+		} `json:"invocation"`
+	} `json:"predicate"`
+}
+
+var (
+	provenanceTrustMu       sync.RWMutex
+	provenanceRoots         *x509.CertPool
+	provenanceIntermediates *x509.CertPool
+)
+
+// ConfigureNPMProvenanceRoots loads the Sigstore Fulcio CA certificates that NPM provenance signing certificates
+// must chain to, from a PEM file. Self-signed certificates are trusted as roots and the rest as intermediates.
+// An empty path removes the configured certificates, so provenance checks fail.
+func ConfigureNPMProvenanceRoots(path string) error {
+	var roots, intermediates *x509.CertPool
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read NPM provenance roots: %w", err)
+		}
+
+		roots, intermediates = x509.NewCertPool(), x509.NewCertPool()
+		found := false
+		for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+			if block.Type != "CERTIFICATE" {
+				continue
+			}
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return fmt.Errorf("invalid certificate in %s: %w", path, err)
+			}
+			if cert.CheckSignatureFrom(cert) == nil {
+				roots.AddCert(cert)
+				found = true
+			} else {
+				intermediates.AddCert(cert)
+			}
+		}
+		if !found {
+			return fmt.Errorf("no root certificates found in %s", path)
+		}
+	}
+
+	provenanceTrustMu.Lock()
+	defer provenanceTrustMu.Unlock()
+	provenanceRoots = roots
+	provenanceIntermediates = intermediates
+	return nil
+}
+
+// ValidateNPMProvenance verifies that an NPM package version has a SLSA provenance attestation for it, signed
+// by a Sigstore certificate chaining to the configured Fulcio roots (see ConfigureNPMProvenanceRoots) and issued
+// to the CI workflow of the server's declared repository, and that the attested source repository matches too.
+// The transparency log entry of the attestation is not checked.
+func ValidateNPMProvenance(ctx context.Context, pkg model.Package, repositoryURL string) error {
+	provenanceTrustMu.RLock()
+	roots, intermediates := provenanceRoots, provenanceIntermediates
+	provenanceTrustMu.RUnlock()
+	if roots == nil {
+		return ErrProvenanceTrustNotConfigured
+	}
+
+	if pkg.RegistryBaseURL == "" {
+		pkg.RegistryBaseURL = model.RegistryURLNPM
+	}
+
+	if repositoryURL == "" {
+		return fmt.Errorf("a repository URL is required to verify provenance of NPM package '%s'", pkg.Identifier)
+	}
+
+	client := NewHTTPClient()
+
+	requestURL := pkg.RegistryBaseURL + "/-/npm/v1/attestations/" + url.PathEscape(pkg.Identifier+"@"+pkg.Version)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch provenance attestations from NPM: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("NPM package '%s@%s' has no provenance attestation. Publish it from CI with `npm publish --provenance`", pkg.Identifier, pkg.Version)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch provenance attestations from NPM (status: %d)", resp.StatusCode)
+	}
+
+	var attestations NPMAttestationsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&attestations); err != nil {
+		return fmt.Errorf("failed to parse NPM provenance attestations: %w", err)
+	}
+
+	for _, attestation := range attestations.Attestations {
+		if attestation.PredicateType != slsaProvenanceV1 && attestation.PredicateType != slsaProvenanceV02 {
+			continue
+		}
+
+		statement, signerRepo, err := verifyProvenanceBundle(attestation.Bundle, roots, intermediates)
+		if err != nil {
+			return fmt.Errorf("NPM provenance validation failed for '%s@%s': %w", pkg.Identifier, pkg.Version, err)
+		}
+		if !statement.coversPackage(pkg.Identifier, pkg.Version) {
+			return fmt.Errorf("NPM provenance validation failed. The attestation for '%s@%s' is for a different package", pkg.Identifier, pkg.Version)
+		}
+
+		attestedRepo := statement.repository()
+		if normalizeRepositoryURL(signerRepo) != normalizeRepositoryURL(repositoryURL) {
+			return fmt.Errorf("NPM provenance validation failed. Package was signed by a workflow in '%s', but the server declares repository '%s'", signerRepo, repositoryURL)
+		}
+		if normalizeRepositoryURL(attestedRepo) != normalizeRepositoryURL(repositoryURL) {
+			return fmt.Errorf("NPM provenance validation failed. Package was built from '%s', but the server declares repository '%s'", attestedRepo, repositoryURL)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("NPM package '%s@%s' has no SLSA provenance attestation. Publish it from CI with `npm publish --provenance`", pkg.Identifier, pkg.Version)
+}
+
+// verifyProvenanceBundle checks the DSSE signature of a Sigstore bundle against its signing certificate and the
+// certificate against the Fulcio roots, returning the signed statement and the repository the certificate was issued to
+func verifyProvenanceBundle(bundle sigstoreBundle, roots, intermediates *x509.CertPool) (*slsaStatement, string, error) {
+	var rawCerts []sigstoreCertificate
+	if chain := bundle.VerificationMaterial.X509CertificateChain; chain != nil {
+		rawCerts = chain.Certificates
+	} else if bundle.VerificationMaterial.Certificate != nil {
+		rawCerts = []sigstoreCertificate{*bundle.VerificationMaterial.Certificate}
+	}
+	if len(rawCerts) == 0 {
+		return nil, "", errors.New("attestation has no signing certificate")
+	}
+
+	certs := make([]*x509.Certificate, 0, len(rawCerts))
+	for _, raw := range rawCerts {
+		der, err := base64.StdEncoding.DecodeString(raw.RawBytes)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid signing certificate encoding: %w", err)
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid signing certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+
+	leaf := certs[0]
+	chainIntermediates := intermediates.Clone()
+	for _, cert := range certs[1:] {
+		chainIntermediates.AddCert(cert)
+	}
+	// Fulcio certificates only live for minutes, so they are checked as of their issuance
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: chainIntermediates,
+		CurrentTime:   leaf.NotBefore,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return nil, "", fmt.Errorf("signing certificate is not trusted: %w", err)
+	}
+
+	envelope := bundle.DSSEEnvelope
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid attestation payload: %w", err)
+	}
+	if len(envelope.Signatures) == 0 {
+		return nil, "", errors.New("attestation is not signed")
+	}
+	signature, err := base64.StdEncoding.DecodeString(envelope.Signatures[0].Sig)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid attestation signature encoding: %w", err)
+	}
+	algorithm, err := signatureAlgorithm(leaf)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := leaf.CheckSignature(algorithm, dssePAE(envelope.PayloadType, payload), signature); err != nil {
+		return nil, "", fmt.Errorf("attestation signature is invalid: %w", err)
+	}
+
+	signerRepo, err := certificateSourceRepository(leaf)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var statement slsaStatement
+	if err := json.Unmarshal(payload, &statement); err != nil {
+		return nil, "", fmt.Errorf("invalid attestation statement: %w", err)
+	}
+	return &statement, signerRepo, nil
+}
+
+// dssePAE is the DSSE pre-authentication encoding of a payload, which is what the envelope signature covers
+func dssePAE(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+func signatureAlgorithm(cert *x509.Certificate) (x509.SignatureAlgorithm, error) {
+	switch cert.PublicKeyAlgorithm {
+	case x509.ECDSA:
+		return x509.ECDSAWithSHA256, nil
+	case x509.RSA:
+		return x509.SHA256WithRSA, nil
+	case x509.Ed25519:
+		return x509.PureEd25519, nil
+	default:
+		return 0, fmt.Errorf("unsupported signing key type: %s", cert.PublicKeyAlgorithm)
+	}
+}
+
+// certificateSourceRepository returns the repository a Fulcio certificate was issued to
+func certificateSourceRepository(cert *x509.Certificate) (string, error) {
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(oidFulcioSourceRepository) {
+			continue
+		}
+		var repo string
+		if _, err := asn1.Unmarshal(ext.Value, &repo); err != nil {
+			return "", fmt.Errorf("invalid source repository in signing certificate: %w", err)
+		}
+		return repo, nil
+	}
+	return "", errors.New("signing certificate doesn't name a source repository")
+}
+
+// coversPackage reports whether one of the statement's subjects is the given package version,
+// written as a package URL such as pkg:npm/%40scope/name@1.0.0
+func (s *slsaStatement) coversPackage(name, version string) bool {
+	for _, subject := range s.Subject {
+		purl, err := url.PathUnescape(strings.TrimPrefix(subject.Name, "pkg:npm/"))
+		if err == nil && purl == name+"@"+version {
+			return true
+		}
+	}
+	return false
+}
+
+// repository returns the source repository the statement says the package was built from
+func (s *slsaStatement) repository() string {
+	if repo := s.Predicate.BuildDefinition.ExternalParameters.Workflow.Repository; repo != "" {
+		return repo
+	}
+
+	// v0.2 config sources look like git+https://github.com/owner/repo@refs/heads/main
+	uri := strings.TrimPrefix(s.Predicate.Invocation.ConfigSource.URI, "git+")
+	if at := strings.LastIndex(uri, "@"); at > strings.Index(uri, "://") {
+		uri = uri[:at]
+	}
+	return uri
+}
+
+// normalizeRepositoryURL makes repository URLs comparable regardless of case, trailing slashes and .git suffixes
+func normalizeRepositoryURL(repoURL string) string {
+	normalized := strings.ToLower(strings.TrimSpace(repoURL))
+	normalized = strings.TrimPrefix(normalized, "git+")
+	normalized = strings.TrimSuffix(normalized, "/")
+	normalized = strings.TrimSuffix(normalized, ".git")
+	return normalized
+}
@@ -0,0 +1,276 @@
+package registries_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const dssePayloadType = "application/vnd.in-toto+json"
+
+// testFulcio issues short-lived signing certificates for a source repository, like Sigstore's Fulcio CA
+type testFulcio struct {
+	t      *testing.T
+	cert   *x509.Certificate
+	key    *ecdsa.PrivateKey
+	serial int64
+}
+
+func newTestFulcio(t *testing.T) *testFulcio {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-fulcio"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testFulcio{t: t, cert: cert, key: key, serial: 1}
+}
+
+// writeRoots writes the CA certificate to a PEM file and returns its path
+func (f *testFulcio) writeRoots() string {
+	path := filepath.Join(f.t.TempDir(), "fulcio.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: f.cert.Raw})
+	require.NoError(f.t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+// bundle signs an in-toto statement with a certificate issued to signerRepo
+func (f *testFulcio) bundle(signerRepo string, statement map[string]any) map[string]any {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(f.t, err)
+	repoExt, err := asn1.MarshalWithParams(signerRepo, "utf8")
+	require.NoError(f.t, err)
+	f.serial++
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(f.serial),
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(10 * time.Minute),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		ExtraExtensions: []pkix.Extension{
+			{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 12}, Value: repoExt},
+		},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, f.cert, &key.PublicKey, f.key)
+	require.NoError(f.t, err)
+
+	payload, err := json.Marshal(statement)
+	require.NoError(f.t, err)
+	digest := sha256.Sum256([]byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(dssePayloadType), dssePayloadType, len(payload), payload)))
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	require.NoError(f.t, err)
+
+	return map[string]any{
+		"verificationMaterial": map[string]any{
+			"x509CertificateChain": map[string]any{
+				"certificates": []map[string]any{{"rawBytes": base64.StdEncoding.EncodeToString(der)}},
+			},
+		},
+		"dsseEnvelope": map[string]any{
+			"payload":     base64.StdEncoding.EncodeToString(payload),
+			"payloadType": dssePayloadType,
+			"signatures":  []map[string]any{{"sig": base64.StdEncoding.EncodeToString(sig)}},
+		},
+	}
+}
+
+func TestValidateNPMProvenance(t *testing.T) {
+	isolateTransport(t)
+	ctx := context.Background()
+
+	fulcio := newTestFulcio(t)
+	require.NoError(t, registries.ConfigureNPMProvenanceRoots(fulcio.writeRoots()))
+	t.Cleanup(func() { _ = registries.ConfigureNPMProvenanceRoots("") })
+
+	slsaV1 := map[string]any{
+		"subject": []map[string]any{{"name": "pkg:npm/%40example/mcp-server@1.0.0"}},
+		"predicate": map[string]any{
+			"buildDefinition": map[string]any{
+				"externalParameters": map[string]any{
+					"workflow": map[string]any{"repository": "https://github.com/example/mcp-server"},
+				},
+			},
+		},
+	}
+	slsaV02 := map[string]any{
+		"subject": []map[string]any{{"name": "pkg:npm/legacy-server@1.0.0"}},
+		"predicate": map[string]any{
+			"invocation": map[string]any{
+				"configSource": map[string]any{"uri": "git+https://github.com/example/legacy-server@refs/heads/main"},
+			},
+		},
+	}
+
+	// Self-asserted: the statement claims the right repository, but the bundle is signed by someone else's workflow
+	selfAsserted := fulcio.bundle("https://github.com/attacker/mcp-server", map[string]any{
+		"subject":   []map[string]any{{"name": "pkg:npm/self-asserted-server@1.0.0"}},
+		"predicate": slsaV1["predicate"],
+	})
+	// Tampered: the payload is swapped after signing
+	tampered := fulcio.bundle("https://github.com/example/mcp-server", slsaV1)
+	tamperedStatement, err := json.Marshal(map[string]any{
+		"subject":   []map[string]any{{"name": "pkg:npm/tampered-server@1.0.0"}},
+		"predicate": slsaV1["predicate"],
+	})
+	require.NoError(t, err)
+	tampered["dsseEnvelope"].(map[string]any)["payload"] = base64.StdEncoding.EncodeToString(tamperedStatement)
+
+	attestations := map[string]map[string]any{
+		"/-/npm/v1/attestations/@example%2Fmcp-server@1.0.0": fulcio.bundle("https://github.com/example/mcp-server", slsaV1),
+		"/-/npm/v1/attestations/legacy-server@1.0.0":         fulcio.bundle("https://github.com/example/legacy-server", slsaV02),
+		"/-/npm/v1/attestations/other-server@1.0.0":          fulcio.bundle("https://github.com/example/mcp-server", slsaV1),
+		"/-/npm/v1/attestations/untrusted-server@1.0.0": newTestFulcio(t).bundle("https://github.com/example/untrusted-server", map[string]any{
+			"subject": []map[string]any{{"name": "pkg:npm/untrusted-server@1.0.0"}},
+		}),
+		"/-/npm/v1/attestations/self-asserted-server@1.0.0": selfAsserted,
+		"/-/npm/v1/attestations/tampered-server@1.0.0":      tampered,
+	}
+	predicateTypes := map[string]string{
+		"/-/npm/v1/attestations/legacy-server@1.0.0": "https://slsa.dev/provenance/v0.2",
+	}
+
+	mockNPM := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bundle, ok := attestations[r.URL.EscapedPath()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		predicateType := predicateTypes[r.URL.EscapedPath()]
+		if predicateType == "" {
+			predicateType = "https://slsa.dev/provenance/v1"
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"attestations": []map[string]any{{"predicateType": predicateType, "bundle": bundle}},
+		})
+	}))
+	defer mockNPM.Close()
+
+	tests := []struct {
+		name          string
+		identifier    string
+		repositoryURL string
+		errorMessage  string
+	}{
+		{
+			name:          "SLSA v1 provenance matching repository passes",
+			identifier:    "@example/mcp-server",
+			repositoryURL: "https://github.com/example/mcp-server",
+		},
+		{
+			name:          "repository comparison ignores case and .git suffix",
+			identifier:    "@example/mcp-server",
+			repositoryURL: "https://github.com/Example/mcp-server.git",
+		},
+		{
+			name:          "SLSA v0.2 provenance matching repository passes",
+			identifier:    "legacy-server",
+			repositoryURL: "https://github.com/example/legacy-server",
+		},
+		{
+			name:          "provenance from a different repository fails",
+			identifier:    "@example/mcp-server",
+			repositoryURL: "https://github.com/attacker/mcp-server",
+			errorMessage:  "provenance validation failed",
+		},
+		{
+			name:          "statement signed by another repository's workflow fails",
+			identifier:    "self-asserted-server",
+			repositoryURL: "https://github.com/example/mcp-server",
+			errorMessage:  "signed by a workflow in 'https://github.com/attacker/mcp-server'",
+		},
+		{
+			name:          "payload changed after signing fails",
+			identifier:    "tampered-server",
+			repositoryURL: "https://github.com/example/mcp-server",
+			errorMessage:  "attestation signature is invalid",
+		},
+		{
+			name:          "certificate from an untrusted CA fails",
+			identifier:    "untrusted-server",
+			repositoryURL: "https://github.com/example/untrusted-server",
+			errorMessage:  "signing certificate is not trusted",
+		},
+		{
+			name:          "attestation for another package fails",
+			identifier:    "other-server",
+			repositoryURL: "https://github.com/example/mcp-server",
+			errorMessage:  "is for a different package",
+		},
+		{
+			name:          "package without provenance fails",
+			identifier:    "unattested-server",
+			repositoryURL: "https://github.com/example/unattested-server",
+			errorMessage:  "has no provenance attestation",
+		},
+		{
+			name:          "missing repository URL fails",
+			identifier:    "@example/mcp-server",
+			repositoryURL: "",
+			errorMessage:  "repository URL is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg := model.Package{
+				RegistryType:    model.RegistryTypeNPM,
+				RegistryBaseURL: mockNPM.URL,
+				Identifier:      tt.identifier,
+				Version:         "1.0.0",
+			}
+
+			err := registries.ValidateNPMProvenance(ctx, pkg, tt.repositoryURL)
+			if tt.errorMessage == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMessage)
+			}
+		})
+	}
+
+	t.Run("fails without trust roots", func(t *testing.T) {
+		require.NoError(t, registries.ConfigureNPMProvenanceRoots(""))
+		t.Cleanup(func() { require.NoError(t, registries.ConfigureNPMProvenanceRoots(fulcio.writeRoots())) })
+
+		pkg := model.Package{
+			RegistryType:    model.RegistryTypeNPM,
+			RegistryBaseURL: mockNPM.URL,
+			Identifier:      "@example/mcp-server",
+			Version:         "1.0.0",
+		}
+		err := registries.ValidateNPMProvenance(ctx, pkg, "https://github.com/example/mcp-server")
+		assert.ErrorIs(t, err, registries.ErrProvenanceTrustNotConfigured)
+	})
+}
@@ -2,6 +2,8 @@ package registries_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/modelcontextprotocol/registry/internal/validators/registries"
@@ -10,6 +12,7 @@ import (
 )
 
 func TestValidateNPM_RealPackages(t *testing.T) {
+	isolateTransport(t)
 	ctx := context.Background()
 
 	tests := []struct {
@@ -135,3 +138,111 @@ func TestValidateNPM_RealPackages(t *testing.T) {
 		})
 	}
 }
+
+// createMockNPMRegistry creates a mock HTTP server that simulates a self-hosted NPM registry such as Verdaccio
+func createMockNPMRegistry(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.EscapedPath() {
+		case "/@example%2fweather-mcp/1.2.0":
+			_, _ = w.Write([]byte(`{"name":"@example/weather-mcp","version":"1.2.0","mcpName":"io.github.example/weather"}`))
+		case "/weather-mcp/1.0.0":
+			_, _ = w.Write([]byte(`{"name":"weather-mcp","version":"1.0.0"}`))
+		case "/-/package/@example%2fweather-mcp/dist-tags":
+			_, _ = w.Write([]byte(`{"latest":"1.2.0","next":"2.0.0-beta.1"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestValidateNPM_MockRegistry(t *testing.T) {
+	isolateTransport(t)
+	ctx := context.Background()
+
+	registry := createMockNPMRegistry(t)
+	defer registry.Close()
+
+	registries.ConfigureNPMRegistries([]string{registry.URL + "/"})
+	defer registries.ConfigureNPMRegistries(nil)
+
+	tests := []struct {
+		name            string
+		packageName     string
+		version         string
+		registryBaseURL string
+		errorMessage    string
+	}{
+		{
+			name:        "scoped package with mcpName should pass",
+			packageName: "@example/weather-mcp",
+			version:     "1.2.0",
+		},
+		{
+			name:            "base URL with a trailing slash should pass",
+			packageName:     "@example/weather-mcp",
+			version:         "1.2.0",
+			registryBaseURL: registry.URL + "/",
+		},
+		{
+			name:         "package without mcpName should fail",
+			packageName:  "weather-mcp",
+			version:      "1.0.0",
+			errorMessage: "missing required 'mcpName' field",
+		},
+		{
+			name:         "missing version should fail",
+			packageName:  "@example/weather-mcp",
+			version:      "1.3.0",
+			errorMessage: "not found",
+		},
+		{
+			name:         "dist-tag should fail naming the version it points to",
+			packageName:  "@example/weather-mcp",
+			version:      "next",
+			errorMessage: "Use the exact version it points to, '2.0.0-beta.1'",
+		},
+		{
+			name:         "unknown dist-tag should fail",
+			packageName:  "@example/weather-mcp",
+			version:      "canary",
+			errorMessage: "is not an exact version",
+		},
+		{
+			name:         "invalid package name should fail",
+			packageName:  "@example/weather/mcp",
+			version:      "1.2.0",
+			errorMessage: "is not a valid package name",
+		},
+		{
+			name:            "unconfigured registry should fail",
+			packageName:     "@example/weather-mcp",
+			version:         "1.2.0",
+			registryBaseURL: "https://npm.example.com",
+			errorMessage:    "registry type and base URL do not match",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseURL := tt.registryBaseURL
+			if baseURL == "" {
+				baseURL = registry.URL
+			}
+			pkg := model.Package{
+				RegistryType:    model.RegistryTypeNPM,
+				RegistryBaseURL: baseURL,
+				Identifier:      tt.packageName,
+				Version:         tt.version,
+			}
+
+			err := registries.ValidateNPM(ctx, pkg, "io.github.example/weather")
+			if tt.errorMessage == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.errorMessage)
+			}
+		})
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"io"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
@@ -24,7 +23,7 @@ func ValidateNuGet(ctx context.Context, pkg model.Package, serverName string) er
 			pkg.RegistryBaseURL, model.RegistryTypeNuGet, model.RegistryURLNuGet)
 	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := NewHTTPClient()
 
 	lowerID := strings.ToLower(pkg.Identifier)
 	lowerVersion := strings.ToLower(pkg.Version)
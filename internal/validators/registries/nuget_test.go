@@ -10,6 +10,7 @@ import (
 )
 
 func TestValidateNuGet_RealPackages(t *testing.T) {
+	isolateTransport(t)
 	ctx := context.Background()
 
 	tests := []struct {
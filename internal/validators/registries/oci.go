@@ -4,16 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
 const (
 	dockerIoAPIBaseURL = "https://registry-1.docker.io"
+
+	ociManifestAccept = "application/vnd.docker.distribution.manifest.v2+json,application/vnd.oci.image.manifest.v1+json"
 )
 
 // OCIAuthResponse represents the Docker Hub authentication response
@@ -45,51 +47,12 @@ func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) erro
 		pkg.RegistryBaseURL = model.RegistryURLDocker
 	}
 
-	// Map of supported OCI registries and their API base URLs
-	supportedRegistries := map[string]string{
-		model.RegistryURLDocker:      dockerIoAPIBaseURL,
-		model.RegistryURLGHCR:        "https://ghcr.io",
-		model.RegistryURLGAR:         "https://artifactregistry.googleapis.com",
-		model.RegistryURLGCR:         "https://gcr.io",
-		model.RegistryURLECR:         "https://public.ecr.aws",
-		model.RegistryURLACR:         "https://azurecr.io",
-		model.RegistryURLQuay:        "https://quay.io",
-		model.RegistryURLGitLabCR:    "https://registry.gitlab.com",
-		model.RegistryURLDockerHub:   dockerIoAPIBaseURL, // Same as Docker
-		model.RegistryURLJFrogCR:     "https://jfrog.io",
-		model.RegistryURLHarborCR:    "https://goharbor.io",
-		model.RegistryURLAlibabaACR:  "https://cr.console.aliyun.com",
-		model.RegistryURLIBMCR:       "https://icr.io",
-		model.RegistryURLOracleCR:    "https://container-registry.oracle.com",
-		model.RegistryURLDigitalOceanCR: "https://registry.digitalocean.com",
-	}
-
-	// Validate that the registry is supported
-	apiBaseURL, ok := supportedRegistries[pkg.RegistryBaseURL]
-	if !ok {
-		// For GAR, check if it's a regional endpoint
-		if strings.Contains(pkg.RegistryBaseURL, "-docker.pkg.dev") {
-			apiBaseURL = pkg.RegistryBaseURL
-		} else if strings.Contains(pkg.RegistryBaseURL, ".gcr.io") {
-			// Support regional GCR endpoints like us.gcr.io, eu.gcr.io, asia.gcr.io
-			apiBaseURL = pkg.RegistryBaseURL
-		} else if strings.Contains(pkg.RegistryBaseURL, ".amazonaws.com") {
-			// Support regional ECR endpoints
-			apiBaseURL = pkg.RegistryBaseURL
-		} else if strings.Contains(pkg.RegistryBaseURL, ".azurecr.io") {
-			// Support ACR instances like myregistry.azurecr.io
-			apiBaseURL = pkg.RegistryBaseURL
-		} else if strings.HasPrefix(pkg.RegistryBaseURL, "http://127.0.0.1:") || strings.HasPrefix(pkg.RegistryBaseURL, "http://localhost:") {
-			// Support local test servers
-			apiBaseURL = pkg.RegistryBaseURL
-		} else {
-			supportedList := []string{"docker.io", "ghcr.io", "gcr.io", "quay.io", "artifactregistry.googleapis.com"}
-			return fmt.Errorf("unsupported OCI registry: '%s'. Supported registries: %s",
-				pkg.RegistryBaseURL, strings.Join(supportedList, ", "))
-		}
+	apiBaseURL, err := resolveOCIAPIBaseURL(pkg.RegistryBaseURL)
+	if err != nil {
+		return err
 	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := NewHTTPClient()
 
 	// Parse image reference (namespace/repo or repo)
 	namespace, repo, err := parseImageReference(pkg.Identifier)
@@ -99,50 +62,18 @@ func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) erro
 
 	// apiBaseURL is already set from the supportedRegistries map above
 
+	// Docker Hub always needs a token; other registries are pulled anonymously unless
+	// credentials are configured for them (see ConfigureOCICredentials)
+	oc := newOCIClient(client, apiBaseURL, namespace, repo)
+
+	// The version may be a tag or, to pin the image, a manifest digest
 	tag := pkg.Version
-	manifestURL := fmt.Sprintf("%s/v2/%s/%s/manifests/%s", apiBaseURL, namespace, repo, tag)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, manifestURL, nil)
+	req, err := oc.newRequest(ctx, "manifests/"+tag, ociManifestAccept)
 	if err != nil {
 		return fmt.Errorf("failed to create manifest request: %w", err)
 	}
 
-	// Handle authentication based on registry type
-	switch {
-	case apiBaseURL == dockerIoAPIBaseURL:
-		// Docker Hub requires token authentication
-		token, err := getDockerIoAuthToken(ctx, client, namespace, repo)
-		if err != nil {
-			return fmt.Errorf("failed to authenticate with Docker registry: %w", err)
-		}
-		req.Header.Set("Authorization", "Bearer "+token)
-	case pkg.RegistryBaseURL == model.RegistryURLGHCR:
-		// GHCR allows anonymous pulls for public images
-		// Authentication would be needed for private images, but we're validating public MCP servers
-		// No auth header needed for public images
-	case pkg.RegistryBaseURL == model.RegistryURLGAR || strings.Contains(pkg.RegistryBaseURL, "-docker.pkg.dev"):
-		// GAR allows anonymous pulls for public images
-		// No auth header needed for public images
-	case pkg.RegistryBaseURL == model.RegistryURLQuay:
-		// Quay.io allows anonymous pulls for public images
-		// No auth header needed
-	case pkg.RegistryBaseURL == model.RegistryURLGitLabCR:
-		// GitLab Container Registry allows anonymous pulls for public images
-		// No auth header needed
-	case strings.Contains(pkg.RegistryBaseURL, ".gcr.io"):
-		// GCR allows anonymous pulls for public images
-		// No auth header needed
-	case strings.Contains(pkg.RegistryBaseURL, "public.ecr.aws"):
-		// Public ECR allows anonymous pulls
-		// No auth header needed
-	default:
-		// For other registries, attempt without authentication
-		// Most registries allow anonymous pulls for public images
-	}
-
-	req.Header.Set("Accept", "application/vnd.docker.distribution.manifest.v2+json,application/vnd.oci.image.manifest.v1+json")
-	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
-
-	resp, err := client.Do(req)
+	resp, err := oc.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to fetch OCI manifest: %w", err)
 	}
@@ -160,8 +91,20 @@ func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) erro
 		return fmt.Errorf("failed to fetch OCI manifest (status: %d)", resp.StatusCode)
 	}
 
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read OCI manifest: %w", err)
+	}
+
+	// Verify the declared digest, if any, is what the tag currently points to
+	if declared := declaredOCIDigest(pkg); declared != "" {
+		if resolved := manifestDigest(resp, body); resolved != declared {
+			return fmt.Errorf("OCI image digest mismatch for '%s/%s:%s': declared '%s', but registry has '%s'", namespace, repo, tag, declared, resolved)
+		}
+	}
+
 	var manifest OCIManifest
-	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
+	if err := json.Unmarshal(body, &manifest); err != nil {
 		return fmt.Errorf("failed to parse OCI manifest: %w", err)
 	}
 
@@ -169,13 +112,14 @@ func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) erro
 	var configDigest string
 	if len(manifest.Manifests) > 0 {
 		// This is a multi-arch image, get the specific manifest
-		specificManifest, err := getSpecificManifest(ctx, client, apiBaseURL, namespace, repo, manifest.Manifests[0].Digest)
+		specificManifest, err := oc.getSpecificManifest(ctx, manifest.Manifests[0].Digest)
 		if err != nil {
 			return fmt.Errorf("failed to get specific manifest: %w", err)
 		}
 		configDigest = specificManifest.Config.Digest
 	} else {
 		configDigest = manifest.Config.Digest
+		Warn(ctx, "OCI image '%s/%s:%s' is built for a single platform; publish a multi-arch image so clients on other architectures can run it", namespace, repo, tag)
 	}
 
 	if configDigest == "" {
@@ -183,7 +127,7 @@ func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) erro
 	}
 
 	// Get image config (contains labels)
-	config, err := getImageConfig(ctx, client, apiBaseURL, namespace, repo, configDigest)
+	config, err := oc.getImageConfig(ctx, configDigest)
 	if err != nil {
 		return fmt.Errorf("failed to get image config: %w", err)
 	}
@@ -200,6 +144,55 @@ func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) erro
 	return nil
 }
 
+// resolveOCIAPIBaseURL maps a package's registry base URL to the URL of its registry API
+func resolveOCIAPIBaseURL(registryBaseURL string) (string, error) {
+	// Map of supported OCI registries and their API base URLs
+	supportedRegistries := map[string]string{
+		model.RegistryURLDocker:      dockerIoAPIBaseURL,
+		model.RegistryURLGHCR:        "https://ghcr.io",
+		model.RegistryURLGAR:         "https://artifactregistry.googleapis.com",
+		model.RegistryURLGCR:         "https://gcr.io",
+		model.RegistryURLECR:         "https://public.ecr.aws",
+		model.RegistryURLACR:         "https://azurecr.io",
+		model.RegistryURLQuay:        "https://quay.io",
+		model.RegistryURLGitLabCR:    "https://registry.gitlab.com",
+		model.RegistryURLDockerHub:   dockerIoAPIBaseURL, // Same as Docker
+		model.RegistryURLJFrogCR:     "https://jfrog.io",
+		model.RegistryURLHarborCR:    "https://goharbor.io",
+		model.RegistryURLAlibabaACR:  "https://cr.console.aliyun.com",
+		model.RegistryURLIBMCR:       "https://icr.io",
+		model.RegistryURLOracleCR:    "https://container-registry.oracle.com",
+		model.RegistryURLDigitalOceanCR: "https://registry.digitalocean.com",
+	}
+
+	// Validate that the registry is supported
+	apiBaseURL, ok := supportedRegistries[registryBaseURL]
+	if !ok {
+		// For GAR, check if it's a regional endpoint
+		if strings.Contains(registryBaseURL, "-docker.pkg.dev") {
+			apiBaseURL = registryBaseURL
+		} else if strings.Contains(registryBaseURL, ".gcr.io") {
+			// Support regional GCR endpoints like us.gcr.io, eu.gcr.io, asia.gcr.io
+			apiBaseURL = registryBaseURL
+		} else if strings.Contains(registryBaseURL, ".amazonaws.com") {
+			// Support regional ECR endpoints
+			apiBaseURL = registryBaseURL
+		} else if strings.Contains(registryBaseURL, ".azurecr.io") {
+			// Support ACR instances like myregistry.azurecr.io
+			apiBaseURL = registryBaseURL
+		} else if strings.HasPrefix(registryBaseURL, "http://127.0.0.1:") || strings.HasPrefix(registryBaseURL, "http://localhost:") {
+			// Support local test servers
+			apiBaseURL = registryBaseURL
+		} else {
+			supportedList := []string{"docker.io", "ghcr.io", "gcr.io", "quay.io", "artifactregistry.googleapis.com"}
+			return "", fmt.Errorf("unsupported OCI registry: '%s'. Supported registries: %s",
+				registryBaseURL, strings.Join(supportedList, ", "))
+		}
+	}
+
+	return apiBaseURL, nil
+}
+
 func parseImageReference(identifier string) (string, string, error) {
 	parts := strings.Split(identifier, "/")
 	switch len(parts) {
@@ -240,27 +233,13 @@ func getDockerIoAuthToken(ctx context.Context, client *http.Client, namespace, r
 }
 
 // getSpecificManifest retrieves a specific manifest for multi-arch images
-func getSpecificManifest(ctx context.Context, client *http.Client, apiBaseURL, namespace, repo, digest string) (*OCIManifest, error) {
-	manifestURL := fmt.Sprintf("%s/v2/%s/%s/manifests/%s", apiBaseURL, namespace, repo, digest)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, manifestURL, nil)
+func (c *ociClient) getSpecificManifest(ctx context.Context, digest string) (*OCIManifest, error) {
+	req, err := c.newRequest(ctx, "manifests/"+digest, "application/vnd.oci.image.manifest.v1+json")
 	if err != nil {
 		return nil, fmt.Errorf("failed to create specific manifest request: %w", err)
 	}
 
-	// Handle authentication for specific registries
-	if apiBaseURL == dockerIoAPIBaseURL {
-		token, err := getDockerIoAuthToken(ctx, client, namespace, repo)
-		if err != nil {
-			return nil, fmt.Errorf("failed to authenticate with Docker registry: %w", err)
-		}
-		req.Header.Set("Authorization", "Bearer "+token)
-	}
-	// Other registries (GHCR, GAR, etc.) allow anonymous pulls for public images
-
-	req.Header.Set("Accept", "application/vnd.oci.image.manifest.v1+json")
-	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
-
-	resp, err := client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch specific manifest: %w", err)
 	}
@@ -279,27 +258,13 @@ func getSpecificManifest(ctx context.Context, client *http.Client, apiBaseURL, n
 }
 
 // getImageConfig retrieves the image configuration containing labels
-func getImageConfig(ctx context.Context, client *http.Client, apiBaseURL, namespace, repo, configDigest string) (*OCIImageConfig, error) {
-	configURL := fmt.Sprintf("%s/v2/%s/%s/blobs/%s", apiBaseURL, namespace, repo, configDigest)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, configURL, nil)
+func (c *ociClient) getImageConfig(ctx context.Context, configDigest string) (*OCIImageConfig, error) {
+	req, err := c.newRequest(ctx, "blobs/"+configDigest, "application/vnd.docker.distribution.manifest.v2+json")
 	if err != nil {
 		return nil, fmt.Errorf("failed to create config request: %w", err)
 	}
 
-	// Handle authentication for specific registries
-	if apiBaseURL == dockerIoAPIBaseURL {
-		token, err := getDockerIoAuthToken(ctx, client, namespace, repo)
-		if err != nil {
-			return nil, fmt.Errorf("failed to authenticate with Docker registry: %w", err)
-		}
-		req.Header.Set("Authorization", "Bearer "+token)
-	}
-	// Other registries (GHCR, GAR, etc.) allow anonymous pulls for public images
-
-	req.Header.Set("Accept", "application/vnd.docker.distribution.manifest.v2+json")
-	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
-
-	resp, err := client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch image config: %w", err)
 	}
@@ -0,0 +1,299 @@
+package registries
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// OCICredential holds the credentials used to pull from a private OCI registry.
+// For GHCR this is a username and personal access token, for ECR the username "AWS" and a
+// token from `aws ecr get-login-password`, and for ACR a service principal or token.
+type OCICredential struct {
+	Username string
+	Password string
+}
+
+// dockerConfig is the subset of Docker's config.json format (also used by kubernetes.io/dockerconfigjson Secrets) we read
+type dockerConfig struct {
+	Auths map[string]struct {
+		Auth     string `json:"auth"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+	} `json:"auths"`
+}
+
+var (
+	ociCredentialsMu sync.RWMutex
+	ociCredentials   map[string]OCICredential
+)
+
+// ConfigureOCICredentials sets the credentials used for private OCI registries, keyed by registry host
+// (e.g. "ghcr.io", "myregistry.azurecr.io" or "123456789012.dkr.ecr.us-east-1.amazonaws.com")
+func ConfigureOCICredentials(creds map[string]OCICredential) {
+	normalized := make(map[string]OCICredential, len(creds))
+	for host, cred := range creds {
+		normalized[normalizeOCIHost(host)] = cred
+	}
+
+	ociCredentialsMu.Lock()
+	defer ociCredentialsMu.Unlock()
+	ociCredentials = normalized
+}
+
+// LoadOCICredentials reads OCI registry credentials in Docker config.json format, either inline or from
+// a file such as a mounted kubernetes.io/dockerconfigjson Secret. Credentials from the file take precedence.
+func LoadOCICredentials(inline, path string) (map[string]OCICredential, error) {
+	creds := make(map[string]OCICredential)
+
+	if inline != "" {
+		parsed, err := ParseDockerConfigJSON([]byte(inline))
+		if err != nil {
+			return nil, err
+		}
+		for host, cred := range parsed {
+			creds[host] = cred
+		}
+	}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read OCI credentials file: %w", err)
+		}
+		parsed, err := ParseDockerConfigJSON(data)
+		if err != nil {
+			return nil, err
+		}
+		for host, cred := range parsed {
+			creds[host] = cred
+		}
+	}
+
+	return creds, nil
+}
+
+// ParseDockerConfigJSON parses registry credentials from Docker's config.json format
+func ParseDockerConfigJSON(data []byte) (map[string]OCICredential, error) {
+	var cfg dockerConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid OCI credentials: %w", err)
+	}
+
+	creds := make(map[string]OCICredential, len(cfg.Auths))
+	for host, entry := range cfg.Auths {
+		cred := OCICredential{Username: entry.Username, Password: entry.Password}
+		if entry.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("invalid auth for OCI registry '%s': %w", host, err)
+			}
+			username, password, ok := strings.Cut(string(decoded), ":")
+			if !ok {
+				return nil, fmt.Errorf("invalid auth for OCI registry '%s': expected username:password", host)
+			}
+			cred = OCICredential{Username: username, Password: password}
+		}
+		creds[normalizeOCIHost(host)] = cred
+	}
+
+	return creds, nil
+}
+
+// normalizeOCIHost reduces a registry URL or host to the host used for credential lookup
+func normalizeOCIHost(host string) string {
+	if u, err := url.Parse(host); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "/"))
+
+	// Docker Hub is known by several names, but the registry API lives on registry-1.docker.io
+	switch host {
+	case "docker.io", "index.docker.io", "hub.docker.com":
+		return "registry-1.docker.io"
+	}
+	return host
+}
+
+func ociCredentialFor(apiBaseURL string) (OCICredential, bool) {
+	ociCredentialsMu.RLock()
+	defer ociCredentialsMu.RUnlock()
+
+	cred, ok := ociCredentials[normalizeOCIHost(apiBaseURL)]
+	return cred, ok
+}
+
+// ociClient performs registry API requests for a single repository, authenticating when required.
+// Anonymous Docker Hub tokens are fetched up front; for registries with configured credentials
+// the standard registry token flow is followed whenever the registry answers 401 with a challenge.
+type ociClient struct {
+	client        *http.Client
+	apiBaseURL    string
+	namespace     string
+	repo          string
+	credential    *OCICredential
+	authorization string
+}
+
+func newOCIClient(client *http.Client, apiBaseURL, namespace, repo string) *ociClient {
+	c := &ociClient{
+		client:     client,
+		apiBaseURL: apiBaseURL,
+		namespace:  namespace,
+		repo:       repo,
+	}
+	if cred, ok := ociCredentialFor(apiBaseURL); ok {
+		c.credential = &cred
+	}
+	return c
+}
+
+// newRequest creates a GET request for a path under /v2/<namespace>/<repo>/
+func (c *ociClient) newRequest(ctx context.Context, path, accept string) (*http.Request, error) {
+	requestURL := fmt.Sprintf("%s/v2/%s/%s/%s", c.apiBaseURL, c.namespace, c.repo, path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
+	return req, nil
+}
+
+// do sends a request created by newRequest, authenticating as needed
+func (c *ociClient) do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if c.authorization == "" && c.credential == nil && c.apiBaseURL == dockerIoAPIBaseURL {
+		// Docker Hub requires token authentication even for public images
+		token, err := getDockerIoAuthToken(ctx, c.client, c.namespace, c.repo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to authenticate with Docker registry: %w", err)
+		}
+		c.authorization = "Bearer " + token
+	}
+	if c.authorization != "" {
+		req.Header.Set("Authorization", c.authorization)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || c.credential == nil {
+		return resp, err
+	}
+
+	// Authenticate using the registry's challenge and retry once
+	challenge := resp.Header.Get("WWW-Authenticate")
+	resp.Body.Close()
+	authorization, err := c.authorize(ctx, challenge)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate with OCI registry: %w", err)
+	}
+	c.authorization = authorization
+
+	retry := req.Clone(ctx)
+	retry.Header.Set("Authorization", authorization)
+	return c.client.Do(retry)
+}
+
+// authorize turns a WWW-Authenticate challenge into an Authorization header value using the configured credentials
+func (c *ociClient) authorize(ctx context.Context, challenge string) (string, error) {
+	scheme, params := parseAuthChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		// e.g. private ECR
+		basic := base64.StdEncoding.EncodeToString([]byte(c.credential.Username + ":" + c.credential.Password))
+		return "Basic " + basic, nil
+	case "bearer":
+		// e.g. GHCR, ACR and Docker Hub
+		token, err := c.fetchBearerToken(ctx, params)
+		if err != nil {
+			return "", err
+		}
+		return "Bearer " + token, nil
+	default:
+		return "", fmt.Errorf("unsupported authentication challenge: %q", challenge)
+	}
+}
+
+func (c *ociClient) fetchBearerToken(ctx context.Context, params map[string]string) (string, error) {
+	realm := params["realm"]
+	if realm == "" {
+		return "", fmt.Errorf("authentication challenge is missing a realm")
+	}
+	realmURL, err := url.Parse(realm)
+	if err != nil {
+		return "", fmt.Errorf("invalid authentication realm: %w", err)
+	}
+
+	query := realmURL.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s/%s:pull", c.namespace, c.repo)
+	}
+	query.Set("scope", scope)
+	realmURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realmURL.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.SetBasicAuth(c.credential.Username, c.credential.Password)
+	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to request token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token request failed with status %d", resp.StatusCode)
+	}
+
+	// Registries return the token as either "token" or "access_token"
+	var tokenResp struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", fmt.Errorf("failed to parse token response: %w", err)
+	}
+	if tokenResp.Token != "" {
+		return tokenResp.Token, nil
+	}
+	if tokenResp.AccessToken != "" {
+		return tokenResp.AccessToken, nil
+	}
+	return "", fmt.Errorf("token response did not contain a token")
+}
+
+// parseAuthChallenge parses a WWW-Authenticate header such as
+// `Bearer realm="https://ghcr.io/token",service="ghcr.io",scope="repository:owner/repo:pull"`
+func parseAuthChallenge(header string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+	params := make(map[string]string)
+
+	for rest != "" {
+		var key, value string
+		key, rest, _ = strings.Cut(strings.TrimLeft(rest, " ,"), "=")
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		if key = strings.TrimSpace(key); key != "" {
+			params[strings.ToLower(key)] = value
+		}
+	}
+
+	return scheme, params
+}
@@ -0,0 +1,94 @@
+package registries_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDockerConfigJSON(t *testing.T) {
+	isolateTransport(t)
+	creds, err := registries.ParseDockerConfigJSON([]byte(`{
+		"auths": {
+			"ghcr.io": {"username": "octocat", "password": "ghp_token"},
+			"https://index.docker.io/v1/": {"auth": "dXNlcjpwYXNz"}
+		}
+	}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, registries.OCICredential{Username: "octocat", Password: "ghp_token"}, creds["ghcr.io"])
+	assert.Equal(t, registries.OCICredential{Username: "user", Password: "pass"}, creds["registry-1.docker.io"])
+
+	_, err = registries.ParseDockerConfigJSON([]byte(`{"auths": {"ghcr.io": {"auth": "not-base64!"}}}`))
+	assert.Error(t, err)
+}
+
+func TestValidateOCI_PrivateRegistry(t *testing.T) {
+	isolateTransport(t)
+	ctx := context.Background()
+	const serverName = "com.example/private-server"
+
+	var registryURL string
+	mockRegistry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			user, pass, ok := r.BasicAuth()
+			if !ok || user != "octocat" || pass != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			assert.Equal(t, "repository:example/private:pull", r.URL.Query().Get("scope"))
+			_ = json.NewEncoder(w).Encode(map[string]string{"token": "registry-token"})
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer registry-token" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+registryURL+`/token",service="mock",scope="repository:example/private:pull"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v2/example/private/manifests/1.0.0":
+			_ = json.NewEncoder(w).Encode(map[string]any{"config": map[string]string{"digest": "sha256:config"}})
+		case "/v2/example/private/blobs/sha256:config":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"config": map[string]any{"Labels": map[string]string{"io.modelcontextprotocol.server.name": serverName}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockRegistry.Close()
+	registryURL = mockRegistry.URL
+
+	pkg := model.Package{
+		RegistryType:    model.RegistryTypeOCI,
+		RegistryBaseURL: mockRegistry.URL,
+		Identifier:      "example/private",
+		Version:         "1.0.0",
+	}
+
+	t.Run("anonymous pulls of private images fail", func(t *testing.T) {
+		registries.ConfigureOCICredentials(nil)
+
+		err := registries.ValidateOCI(ctx, pkg, serverName)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found (status: 401)")
+	})
+
+	t.Run("configured credentials are used for token auth", func(t *testing.T) {
+		registries.ConfigureOCICredentials(map[string]registries.OCICredential{
+			mockRegistry.URL: {Username: "octocat", Password: "secret"},
+		})
+		defer registries.ConfigureOCICredentials(nil)
+
+		assert.NoError(t, registries.ValidateOCI(ctx, pkg, serverName))
+	})
+}
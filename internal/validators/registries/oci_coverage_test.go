@@ -15,6 +15,7 @@ import (
 
 // Test getDockerIoAuthToken function coverage
 func TestValidateOCI_DockerHubAuth(t *testing.T) {
+	isolateTransport(t)
 	ctx := context.Background()
 
 	t.Run("Docker Hub successful auth", func(t *testing.T) {
@@ -124,6 +125,7 @@ func TestValidateOCI_DockerHubAuth(t *testing.T) {
 
 // Test multi-arch manifest handling
 func TestValidateOCI_MultiArchManifest(t *testing.T) {
+	isolateTransport(t)
 	ctx := context.Background()
 
 	t.Run("Multi-arch manifest", func(t *testing.T) {
@@ -214,6 +216,7 @@ func TestValidateOCI_MultiArchManifest(t *testing.T) {
 
 // Test error paths and edge cases
 func TestValidateOCI_ErrorPaths(t *testing.T) {
+	isolateTransport(t)
 	ctx := context.Background()
 
 	t.Run("Manifest request creation error", func(t *testing.T) {
@@ -407,6 +410,7 @@ func TestValidateOCI_ErrorPaths(t *testing.T) {
 
 // Test parseImageReference edge cases
 func TestParseImageReference(t *testing.T) {
+	isolateTransport(t)
 	// Since parseImageReference is not exported, we test it through ValidateOCI
 	ctx := context.Background()
 
@@ -426,6 +430,7 @@ func TestParseImageReference(t *testing.T) {
 
 // Test default registry URL
 func TestValidateOCI_DefaultRegistryURL(t *testing.T) {
+	isolateTransport(t)
 	ctx := context.Background()
 
 	t.Run("Empty registry URL defaults to Docker Hub", func(t *testing.T) {
@@ -447,6 +452,7 @@ func TestValidateOCI_DefaultRegistryURL(t *testing.T) {
 
 // Test getSpecificManifest error paths
 func TestValidateOCI_GetSpecificManifestErrors(t *testing.T) {
+	isolateTransport(t)
 	ctx := context.Background()
 
 	t.Run("Specific manifest malformed JSON", func(t *testing.T) {
@@ -0,0 +1,108 @@
+package registries
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+var ociDigestRe = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// IsOCIDigest reports whether ref is an OCI content digest (sha256:<hex>) rather than a tag
+func IsOCIDigest(ref string) bool {
+	return ociDigestRe.MatchString(ref)
+}
+
+// ResolveOCIDigest returns the manifest digest the package's version (a tag or digest) currently refers to.
+// An empty digest is returned without error if the registry rate limits the request.
+func ResolveOCIDigest(ctx context.Context, pkg model.Package) (string, error) {
+	if declared := declaredOCIDigest(pkg); declared != "" {
+		// Already pinned, and ValidateOCI has checked the digest against the registry
+		return declared, nil
+	}
+
+	if pkg.RegistryBaseURL == "" {
+		pkg.RegistryBaseURL = model.RegistryURLDocker
+	}
+	apiBaseURL, err := resolveOCIAPIBaseURL(pkg.RegistryBaseURL)
+	if err != nil {
+		return "", err
+	}
+	namespace, repo, err := parseImageReference(pkg.Identifier)
+	if err != nil {
+		return "", fmt.Errorf("invalid OCI image reference: %w", err)
+	}
+
+	oc := newOCIClient(NewHTTPClient(), apiBaseURL, namespace, repo)
+	req, err := oc.newRequest(ctx, "manifests/"+pkg.Version, ociManifestAccept)
+	if err != nil {
+		return "", fmt.Errorf("failed to create manifest request: %w", err)
+	}
+	// HEAD requests return the digest without the manifest body, and don't count towards Docker Hub pull limits
+	req.Method = http.MethodHead
+
+	resp, err := oc.do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch OCI manifest: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		// Matches ValidateOCI: don't fail publishing because of upstream rate limits, just leave the package unpinned
+		log.Printf("Warning: Rate limited when resolving digest for OCI image '%s/%s:%s'. Skipping digest pinning.", namespace, repo, pkg.Version)
+		return "", nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to resolve digest for OCI image '%s/%s:%s' (status: %d)", namespace, repo, pkg.Version, resp.StatusCode)
+	}
+	if digest := resp.Header.Get("Docker-Content-Digest"); IsOCIDigest(digest) {
+		return digest, nil
+	}
+
+	// Some registries omit the digest header on HEAD, so fall back to hashing the manifest
+	req, err = oc.newRequest(ctx, "manifests/"+pkg.Version, ociManifestAccept)
+	if err != nil {
+		return "", fmt.Errorf("failed to create manifest request: %w", err)
+	}
+	getResp, err := oc.do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch OCI manifest: %w", err)
+	}
+	defer getResp.Body.Close()
+
+	if getResp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to resolve digest for OCI image '%s/%s:%s' (status: %d)", namespace, repo, pkg.Version, getResp.StatusCode)
+	}
+	body, err := io.ReadAll(getResp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read OCI manifest: %w", err)
+	}
+	return manifestDigest(getResp, body), nil
+}
+
+// declaredOCIDigest returns the digest the publisher pinned the package to, either explicitly or as its version
+func declaredOCIDigest(pkg model.Package) string {
+	if pkg.Digest != "" {
+		return pkg.Digest
+	}
+	if IsOCIDigest(pkg.Version) {
+		return pkg.Version
+	}
+	return ""
+}
+
+// manifestDigest returns the digest of a fetched manifest, preferring the registry's Docker-Content-Digest header
+func manifestDigest(resp *http.Response, body []byte) string {
+	if digest := resp.Header.Get("Docker-Content-Digest"); IsOCIDigest(digest) {
+		return digest
+	}
+	sum := sha256.Sum256(body)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,92 @@
+package registries_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOCIDigestPinning(t *testing.T) {
+	isolateTransport(t)
+	ctx := context.Background()
+	const serverName = "com.example/pinned-server"
+
+	manifest := []byte(`{"config":{"digest":"sha256:config"}}`)
+	sum := sha256.Sum256(manifest)
+	manifestDigest := "sha256:" + hex.EncodeToString(sum[:])
+
+	mockRegistry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/example/pinned/manifests/1.0.0", "/v2/example/pinned/manifests/" + manifestDigest:
+			w.Header().Set("Docker-Content-Digest", manifestDigest)
+			if r.Method == http.MethodHead {
+				return
+			}
+			_, _ = w.Write(manifest)
+		case "/v2/example/pinned/blobs/sha256:config":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"config": map[string]any{"Labels": map[string]string{"io.modelcontextprotocol.server.name": serverName}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockRegistry.Close()
+
+	pkg := model.Package{
+		RegistryType:    model.RegistryTypeOCI,
+		RegistryBaseURL: mockRegistry.URL,
+		Identifier:      "example/pinned",
+		Version:         "1.0.0",
+	}
+
+	t.Run("tag resolves to digest", func(t *testing.T) {
+		require.NoError(t, registries.ValidateOCI(ctx, pkg, serverName))
+
+		digest, err := registries.ResolveOCIDigest(ctx, pkg)
+		require.NoError(t, err)
+		assert.Equal(t, manifestDigest, digest)
+	})
+
+	t.Run("declared digest matching the tag passes", func(t *testing.T) {
+		pinned := pkg
+		pinned.Digest = manifestDigest
+		assert.NoError(t, registries.ValidateOCI(ctx, pinned, serverName))
+	})
+
+	t.Run("declared digest not matching the tag fails", func(t *testing.T) {
+		pinned := pkg
+		pinned.Digest = "sha256:" + strings.Repeat("0", 64)
+		err := registries.ValidateOCI(ctx, pinned, serverName)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "digest mismatch")
+	})
+
+	t.Run("digest can be used instead of a tag", func(t *testing.T) {
+		pinned := pkg
+		pinned.Version = manifestDigest
+		require.NoError(t, registries.ValidateOCI(ctx, pinned, serverName))
+
+		digest, err := registries.ResolveOCIDigest(ctx, pinned)
+		require.NoError(t, err)
+		assert.Equal(t, manifestDigest, digest)
+	})
+}
+
+func TestIsOCIDigest(t *testing.T) {
+	isolateTransport(t)
+	assert.True(t, registries.IsOCIDigest("sha256:"+strings.Repeat("a", 64)))
+	assert.False(t, registries.IsOCIDigest("1.0.0"))
+	assert.False(t, registries.IsOCIDigest("sha256:abc"))
+	assert.False(t, registries.IsOCIDigest("sha512:"+strings.Repeat("a", 64)))
+}
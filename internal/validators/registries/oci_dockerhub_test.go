@@ -178,7 +178,7 @@ func TestGetSpecificManifest_Errors(t *testing.T) {
 
 	t.Run("Request creation error", func(t *testing.T) {
 		// Use nil context to trigger error
-		_, err := getSpecificManifest(nil, client, "http://test", "namespace", "repo", "digest")
+		_, err := newOCIClient(client, "http://test", "namespace", "repo").getSpecificManifest(nil, "digest")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to create specific manifest request")
 	})
@@ -188,7 +188,7 @@ func TestGetSpecificManifest_Errors(t *testing.T) {
 		serverURL := server.URL
 		server.Close()
 
-		_, err := getSpecificManifest(ctx, client, serverURL, "namespace", "repo", "digest")
+		_, err := newOCIClient(client, serverURL, "namespace", "repo").getSpecificManifest(ctx, "digest")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to fetch specific manifest")
 	})
@@ -201,7 +201,7 @@ func TestGetImageConfig_Errors(t *testing.T) {
 
 	t.Run("Request creation error", func(t *testing.T) {
 		// Use nil context to trigger error
-		_, err := getImageConfig(nil, client, "http://test", "namespace", "repo", "digest")
+		_, err := newOCIClient(client, "http://test", "namespace", "repo").getImageConfig(nil, "digest")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to create config request")
 	})
@@ -211,7 +211,7 @@ func TestGetImageConfig_Errors(t *testing.T) {
 		serverURL := server.URL
 		server.Close()
 
-		_, err := getImageConfig(ctx, client, serverURL, "namespace", "repo", "digest")
+		_, err := newOCIClient(client, serverURL, "namespace", "repo").getImageConfig(ctx, "digest")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to fetch image config")
 	})
@@ -15,6 +15,7 @@ import (
 
 // TestValidateOCI_RemainingCoverage tests remaining uncovered paths
 func TestValidateOCI_RemainingCoverage(t *testing.T) {
+	isolateTransport(t)
 	ctx := context.Background()
 
 	t.Run("Config request creation error in getImageConfig", func(t *testing.T) {
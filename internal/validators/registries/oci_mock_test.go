@@ -60,6 +60,7 @@ func createMockRegistry(t *testing.T, withMCPLabel bool, serverName string) *htt
 }
 
 func TestValidateOCI_WithMockRegistries(t *testing.T) {
+	isolateTransport(t)
 	ctx := context.Background()
 
 	t.Run("GHCR with valid MCP annotation", func(t *testing.T) {
@@ -147,6 +148,7 @@ func TestValidateOCI_WithMockRegistries(t *testing.T) {
 }
 
 func TestValidateOCI_ErrorCases(t *testing.T) {
+	isolateTransport(t)
 	ctx := context.Background()
 
 	t.Run("Unsupported registry", func(t *testing.T) {
@@ -195,6 +197,7 @@ func TestValidateOCI_ErrorCases(t *testing.T) {
 }
 
 func TestValidateOCI_RegionalEndpoints(t *testing.T) {
+	isolateTransport(t)
 	regionalEndpoints := []struct {
 		name     string
 		endpoint string
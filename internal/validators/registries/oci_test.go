@@ -10,6 +10,7 @@ import (
 )
 
 func TestValidateOCI_RealPackages(t *testing.T) {
+	isolateTransport(t)
 	ctx := context.Background()
 
 	tests := []struct {
@@ -84,6 +85,7 @@ func TestValidateOCI_RealPackages(t *testing.T) {
 }
 
 func TestValidateOCI_MultipleRegistries(t *testing.T) {
+	isolateTransport(t)
 	ctx := context.Background()
 
 	tests := []struct {
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
@@ -31,7 +30,7 @@ func ValidatePyPI(ctx context.Context, pkg model.Package, serverName string) err
 			pkg.RegistryBaseURL, model.RegistryTypePyPI, model.RegistryURLPyPI)
 	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := NewHTTPClient()
 
 	url := fmt.Sprintf("%s/pypi/%s/json", pkg.RegistryBaseURL, pkg.Identifier)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
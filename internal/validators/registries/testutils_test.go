@@ -4,8 +4,19 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
 )
 
+// isolateTransport gives the test its own validator transport, so circuit breakers opened by
+// failures in one test don't reject requests made by the next
+func isolateTransport(t *testing.T) {
+	t.Helper()
+	previous := registries.SetTransport(registries.NewTransport())
+	t.Cleanup(func() { registries.SetTransport(previous) })
+}
+
 func generateRandomPackageName() string {
 	bytes := make([]byte, 16)
 	if _, err := rand.Read(bytes); err != nil {
@@ -0,0 +1,73 @@
+package registries
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// maxTracedBodyBytes bounds how much of an error response's body a trace keeps
+const maxTracedBodyBytes = 1 << 10
+
+type traceKey struct{}
+
+type callTrace struct {
+	mu    sync.Mutex
+	calls []apiv0.ValidatorCall
+}
+
+// TraceRequests returns a context in which the requests validators send upstream, including retries, are
+// recorded, and a function returning the requests recorded so far
+func TraceRequests(ctx context.Context) (context.Context, func() []apiv0.ValidatorCall) {
+	trace := &callTrace{}
+	return context.WithValue(ctx, traceKey{}, trace), func() []apiv0.ValidatorCall {
+		trace.mu.Lock()
+		defer trace.mu.Unlock()
+		return append([]apiv0.ValidatorCall(nil), trace.calls...)
+	}
+}
+
+// traceCall records an attempt of req if its context comes from TraceRequests. Bodies are only kept for error
+// responses, which explain failures without exposing tokens returned by successful auth requests.
+func traceCall(req *http.Request, started time.Time, resp *http.Response, err error) {
+	trace, ok := req.Context().Value(traceKey{}).(*callTrace)
+	if !ok {
+		return
+	}
+
+	call := apiv0.ValidatorCall{
+		Method:     req.Method,
+		URL:        req.URL.Redacted(),
+		DurationMs: time.Since(started).Milliseconds(),
+	}
+	switch {
+	case err != nil:
+		call.Error = err.Error()
+	case resp != nil:
+		call.Status = resp.StatusCode
+		if resp.StatusCode >= http.StatusBadRequest && resp.Body != nil {
+			call.Body = peekBody(resp)
+		}
+	}
+
+	trace.mu.Lock()
+	defer trace.mu.Unlock()
+	trace.calls = append(trace.calls, call)
+}
+
+// peekBody returns the start of a response body, leaving the body readable in full
+func peekBody(resp *http.Response) string {
+	head, _ := io.ReadAll(io.LimitReader(resp.Body, maxTracedBodyBytes))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), resp.Body), resp.Body}
+	return strings.ToValidUTF8(string(head), string(utf8.RuneError))
+}
@@ -0,0 +1,64 @@
+package registries_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceRequests(t *testing.T) {
+	isolateTransport(t)
+	registries.ConfigureHTTPResilience(registries.HTTPResilienceConfig{})
+	defer registries.ConfigureHTTPResilience(registries.DefaultHTTPResilienceConfig())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"error":"not found"}`+strings.Repeat(" ", 2048))
+			return
+		}
+		_, _ = io.WriteString(w, `{"token":"secret"}`)
+	}))
+	defer server.Close()
+
+	get := func(req *http.Request) string {
+		t.Helper()
+		resp, err := registries.NewHTTPClient().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	ctx, calls := registries.TraceRequests(t.Context())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/token", nil)
+	require.NoError(t, err)
+	get(req)
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, strings.Replace(server.URL, "http://", "http://user:password@", 1)+"/missing", nil)
+	require.NoError(t, err)
+	assert.Len(t, get(req), len(`{"error":"not found"}`)+2048, "tracing leaves the body readable in full")
+
+	recorded := calls()
+	require.Len(t, recorded, 2)
+	assert.Equal(t, http.StatusOK, recorded[0].Status)
+	assert.Empty(t, recorded[0].Body, "bodies of successful responses aren't kept")
+
+	assert.Equal(t, http.StatusNotFound, recorded[1].Status)
+	assert.NotContains(t, recorded[1].URL, "password")
+	assert.True(t, strings.HasPrefix(recorded[1].Body, `{"error":"not found"}`))
+	assert.Len(t, recorded[1].Body, 1024)
+
+	// Requests outside a traced context aren't recorded
+	req, err = http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL+"/token", nil)
+	require.NoError(t, err)
+	get(req)
+	assert.Len(t, calls(), 2)
+}
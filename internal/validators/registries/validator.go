@@ -0,0 +1,58 @@
+package registries
+
+import (
+	"context"
+	"sync"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// RegistryValidator validates that a package exists in its registry and is owned by the given server
+type RegistryValidator interface {
+	Validate(ctx context.Context, pkg model.Package, serverName string) error
+}
+
+// RegistryValidatorFunc adapts an ordinary function to the RegistryValidator interface
+type RegistryValidatorFunc func(ctx context.Context, pkg model.Package, serverName string) error
+
+// Validate calls f(ctx, pkg, serverName)
+func (f RegistryValidatorFunc) Validate(ctx context.Context, pkg model.Package, serverName string) error {
+	return f(ctx, pkg, serverName)
+}
+
+var (
+	validatorsMu sync.RWMutex
+	validators   = map[string]RegistryValidator{
+		model.RegistryTypeNPM:   RegistryValidatorFunc(ValidateNPM),
+		model.RegistryTypePyPI:  RegistryValidatorFunc(ValidatePyPI),
+		model.RegistryTypeNuGet: RegistryValidatorFunc(ValidateNuGet),
+		model.RegistryTypeOCI:   RegistryValidatorFunc(ValidateOCI),
+		model.RegistryTypeMCPB:  RegistryValidatorFunc(ValidateMCPB),
+		model.RegistryTypeMaven: RegistryValidatorFunc(ValidateMaven),
+		model.RegistryTypeGem:   RegistryValidatorFunc(ValidateGem),
+		model.RegistryTypeVSIX:  RegistryValidatorFunc(ValidateVSIX),
+	}
+)
+
+// RegisterValidator registers the validator used for packages of the given registry type.
+// Deployments can use this to support private or internal registries, or to replace a built-in validator.
+// Registering a nil validator removes support for the registry type.
+func RegisterValidator(registryType string, v RegistryValidator) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+
+	if v == nil {
+		delete(validators, registryType)
+		return
+	}
+	validators[registryType] = v
+}
+
+// GetValidator returns the validator registered for the given registry type
+func GetValidator(registryType string) (RegistryValidator, bool) {
+	validatorsMu.RLock()
+	defer validatorsMu.RUnlock()
+
+	v, ok := validators[registryType]
+	return v, ok
+}
@@ -0,0 +1,49 @@
+package registries_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterValidator(t *testing.T) {
+	isolateTransport(t)
+	ctx := context.Background()
+	const registryType = "internal-artifactory"
+
+	pkg := model.Package{
+		RegistryType: registryType,
+		Identifier:   "example-server",
+		Version:      "1.0.0",
+	}
+
+	// Unknown registry types are rejected until a validator is registered
+	err := validators.ValidatePackage(ctx, pkg, "com.example/server")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported registry type")
+
+	var gotServerName string
+	registries.RegisterValidator(registryType, registries.RegistryValidatorFunc(func(_ context.Context, _ model.Package, serverName string) error {
+		gotServerName = serverName
+		return nil
+	}))
+	defer registries.RegisterValidator(registryType, nil)
+
+	v, ok := registries.GetValidator(registryType)
+	require.True(t, ok)
+	require.NotNil(t, v)
+
+	require.NoError(t, validators.ValidatePackage(ctx, pkg, "com.example/server"))
+	assert.Equal(t, "com.example/server", gotServerName)
+
+	// Built-in validators are registered by default
+	for _, builtin := range []string{model.RegistryTypeNPM, model.RegistryTypePyPI, model.RegistryTypeNuGet, model.RegistryTypeOCI, model.RegistryTypeMCPB} {
+		_, ok := registries.GetValidator(builtin)
+		assert.True(t, ok, "expected built-in validator for %s", builtin)
+	}
+}
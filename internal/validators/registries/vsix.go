@@ -0,0 +1,96 @@
+package registries
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// vsixIdentifierPartRe matches an extension publisher (namespace) or name
+var vsixIdentifierPartRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// ExtensionManifest is the subset of an editor extension's package.json we read
+type ExtensionManifest struct {
+	Version     string `json:"version"`
+	Contributes struct {
+		MCP struct {
+			ServerName string `json:"serverName"`
+		} `json:"mcp"`
+	} `json:"contributes"`
+}
+
+// ValidateVSIX validates that an editor extension, identified as publisher.name, exists at the given version
+// on the VS Code Marketplace or Open VSX and names the MCP server in its contributes.mcp.serverName manifest field
+func ValidateVSIX(ctx context.Context, pkg model.Package, serverName string) error {
+	// Set default registry base URL if empty
+	if pkg.RegistryBaseURL == "" {
+		pkg.RegistryBaseURL = model.RegistryURLVSCodeMarketplace
+	}
+
+	publisher, name, ok := strings.Cut(pkg.Identifier, ".")
+	if !ok || !vsixIdentifierPartRe.MatchString(publisher) || !vsixIdentifierPartRe.MatchString(name) {
+		return fmt.Errorf("extension package identifier '%s' must be in the form publisher.name", pkg.Identifier)
+	}
+	if pkg.Version == "" {
+		return fmt.Errorf("package version is required for extension packages")
+	}
+
+	var manifestURL string
+	switch pkg.RegistryBaseURL {
+	case model.RegistryURLVSCodeMarketplace:
+		// The Marketplace serves each version's package.json as an asset of the publisher's gallery
+		manifestURL = fmt.Sprintf("https://%s.gallery.vsassets.io/_apis/public/gallery/publisher/%s/extension/%s/%s/assetbyname/Microsoft.VisualStudio.Code.Manifest",
+			strings.ToLower(publisher), url.PathEscape(publisher), url.PathEscape(name), url.PathEscape(pkg.Version))
+	case model.RegistryURLOpenVSX:
+		manifestURL = fmt.Sprintf("%s/api/%s/%s/%s/file/package.json",
+			pkg.RegistryBaseURL, url.PathEscape(publisher), url.PathEscape(name), url.PathEscape(pkg.Version))
+	default:
+		return fmt.Errorf("registry type and base URL do not match: '%s' is not valid for registry type '%s'. Expected: %s or %s",
+			pkg.RegistryBaseURL, model.RegistryTypeVSIX, model.RegistryURLVSCodeMarketplace, model.RegistryURLOpenVSX)
+	}
+
+	client := NewHTTPClient()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, manifestURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch extension manifest: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("extension '%s' version '%s' not found (status: %d)", pkg.Identifier, pkg.Version, resp.StatusCode)
+	}
+
+	var manifest ExtensionManifest
+	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
+		return fmt.Errorf("failed to parse extension manifest: %w", err)
+	}
+
+	if manifest.Version != pkg.Version {
+		return fmt.Errorf("extension '%s' manifest version '%s' does not match package version '%s'", pkg.Identifier, manifest.Version, pkg.Version)
+	}
+
+	if manifest.Contributes.MCP.ServerName == "" {
+		return fmt.Errorf("extension '%s' is missing required 'contributes.mcp.serverName' field. Add this to your package.json: \"contributes\": {\"mcp\": {\"serverName\": \"%s\"}}", pkg.Identifier, serverName)
+	}
+
+	if manifest.Contributes.MCP.ServerName != serverName {
+		return fmt.Errorf("extension ownership validation failed. Expected contributes.mcp.serverName '%s', got '%s'", serverName, manifest.Contributes.MCP.ServerName)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package registries_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateVSIX(t *testing.T) {
+	isolateTransport(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		packageName     string
+		version         string
+		registryBaseURL string
+		errorMessage    string
+	}{
+		{
+			name:         "identifier without a publisher should fail",
+			packageName:  "weather-mcp",
+			version:      "1.0.0",
+			errorMessage: "must be in the form publisher.name",
+		},
+		{
+			name:         "identifier with a path should fail",
+			packageName:  "example./weather-mcp",
+			version:      "1.0.0",
+			errorMessage: "must be in the form publisher.name",
+		},
+		{
+			name:         "empty package version should fail",
+			packageName:  "example.weather-mcp",
+			errorMessage: "package version is required for extension packages",
+		},
+		{
+			name:            "other base URL should fail",
+			packageName:     "example.weather-mcp",
+			version:         "1.0.0",
+			registryBaseURL: model.RegistryURLNPM,
+			errorMessage:    "registry type and base URL do not match",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg := model.Package{
+				RegistryType:    model.RegistryTypeVSIX,
+				RegistryBaseURL: tt.registryBaseURL,
+				Identifier:      tt.packageName,
+				Version:         tt.version,
+			}
+
+			err := registries.ValidateVSIX(ctx, pkg, "com.example/test")
+			assert.ErrorContains(t, err, tt.errorMessage)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package registries
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+type warningsKey struct{}
+
+type warningCollector struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+// CollectWarnings returns a context in which validators can report non-blocking findings with Warn,
+// and a function returning the findings reported so far
+func CollectWarnings(ctx context.Context) (context.Context, func() []string) {
+	collector := &warningCollector{}
+	return context.WithValue(ctx, warningsKey{}, collector), func() []string {
+		collector.mu.Lock()
+		defer collector.mu.Unlock()
+		return append([]string(nil), collector.messages...)
+	}
+}
+
+// Warn reports a finding that doesn't fail validation, such as a single-architecture image.
+// Warnings are dropped unless ctx comes from CollectWarnings.
+func Warn(ctx context.Context, format string, args ...any) {
+	collector, ok := ctx.Value(warningsKey{}).(*warningCollector)
+	if !ok {
+		return
+	}
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	collector.messages = append(collector.messages, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,41 @@
+package validators
+
+import (
+	"strings"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// finding is a validation error about one field of a server, or the whole server when location is empty
+type finding struct {
+	location string
+	err      error
+}
+
+// ValidationError is returned when a publish request has blocking findings. It wraps every one of them,
+// so errors.Is matches any, and its Report lists them along with the warnings found.
+type ValidationError struct {
+	Report *apiv0.ValidationReport
+	errs   []error
+}
+
+func newValidationError(findings []finding, report *apiv0.ValidationReport) *ValidationError {
+	verr := &ValidationError{Report: report}
+	for _, f := range findings {
+		verr.errs = append(verr.errs, f.err)
+		report.Errors = append(report.Errors, apiv0.ValidationFinding{Location: f.location, Message: f.err.Error()})
+	}
+	return verr
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Unwrap() []error {
+	return e.errs
+}
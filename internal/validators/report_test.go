@@ -0,0 +1,230 @@
+//nolint:testpackage
+package validators
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPublishRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/example/licensed":
+			_, _ = w.Write([]byte(`{"private": false, "license": {"spdx_id": "MIT"}}`))
+		case "/repos/example/unlicensed":
+			_, _ = w.Write([]byte(`{"private": false, "license": null}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	originalGitHub := githubAPIBaseURL
+	githubAPIBaseURL = server.URL
+	defer func() { githubAPIBaseURL = originalGitHub }()
+
+	cfg := &config.Config{EnableRepositoryValidation: true}
+	serverJSON := func(repo string) apiv0.ServerJSON {
+		return apiv0.ServerJSON{
+			Name:        "io.github.example/test-server",
+			Description: "Searches and edits files in a workspace",
+			Version:     "1.0.0",
+			WebsiteURL:  "https://example.github.io/test-server",
+			Repository:  model.Repository{URL: "https://github.com/example/" + repo, Source: "github"},
+			Packages: []model.Package{
+				{RegistryType: model.RegistryTypeNPM, Identifier: "@example/test-server", Version: "1.0.0", Transport: model.Transport{Type: "stdio"}},
+			},
+		}
+	}
+
+	t.Run("valid server has an empty report", func(t *testing.T) {
+		report, err := CheckPublishRequest(t.Context(), serverJSON("licensed"), cfg)
+		require.NoError(t, err)
+		assert.Empty(t, report.Errors)
+		assert.Empty(t, report.Warnings)
+		assert.Equal(t, 100, report.QualityScore)
+	})
+
+	t.Run("warnings don't block the publish", func(t *testing.T) {
+		report, err := CheckPublishRequest(t.Context(), serverJSON("unlicensed"), cfg)
+		require.NoError(t, err)
+		require.Len(t, report.Warnings, 1)
+		assert.Equal(t, "repository", report.Warnings[0].Location)
+		assert.Contains(t, report.Warnings[0].Message, "has no license")
+	})
+
+	t.Run("lint warnings lower the quality score", func(t *testing.T) {
+		req := serverJSON("licensed")
+		req.WebsiteURL = ""
+
+		report, err := CheckPublishRequest(t.Context(), req, cfg)
+		require.NoError(t, err)
+		require.Len(t, report.Warnings, 1)
+		assert.Equal(t, "website-url", report.Warnings[0].Rule)
+		assert.Less(t, report.QualityScore, 100)
+	})
+
+	t.Run("every error is reported", func(t *testing.T) {
+		req := serverJSON("licensed")
+		req.Version = "latest"
+		req.WebsiteURL = "not-a-url"
+		req.Packages = []model.Package{{RegistryType: model.RegistryTypeNPM, Identifier: "", Version: "1.0.0"}}
+
+		report, err := CheckPublishRequest(t.Context(), req, cfg)
+		require.Error(t, err)
+
+		var verr *ValidationError
+		require.True(t, errors.As(err, &verr))
+		assert.Same(t, report, verr.Report)
+
+		locations := make([]string, 0, len(report.Errors))
+		for _, finding := range report.Errors {
+			locations = append(locations, finding.Location)
+		}
+		assert.Equal(t, []string{"version", "website_url", "packages[0]"}, locations)
+		assert.ErrorIs(t, err, ErrReservedVersionString)
+	})
+}
+
+func TestCheckPublishRequest_ConcurrentPackages(t *testing.T) {
+	previous := registries.SetTransport(registries.NewTransport())
+	defer registries.SetTransport(previous)
+
+	// Later packages answer first, so findings in package order show they aren't reported as they arrive
+	var inFlight, maxInFlight atomic.Int32
+	delays := map[string]time.Duration{"pkg-0": 150 * time.Millisecond, "pkg-1": 100 * time.Millisecond, "pkg-2": 50 * time.Millisecond}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+
+		name := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")[0]
+		time.Sleep(delays[name])
+		if name == "pkg-1" {
+			_, _ = w.Write([]byte(`{"name":"pkg-1","version":"1.0.0","mcpName":"io.github.example/test-server"}`))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	registries.ConfigureNPMRegistries([]string{server.URL})
+	defer registries.ConfigureNPMRegistries(nil)
+
+	req := apiv0.ServerJSON{
+		Name:        "io.github.example/test-server",
+		Description: "Searches and edits files in a workspace",
+		Version:     "1.0.0",
+	}
+	for _, name := range []string{"pkg-0", "pkg-1", "pkg-2", "pkg-3"} {
+		req.Packages = append(req.Packages, model.Package{
+			RegistryType:    model.RegistryTypeNPM,
+			RegistryBaseURL: server.URL,
+			Identifier:      name,
+			Version:         "1.0.0",
+			Transport:       model.Transport{Type: "stdio"},
+		})
+	}
+
+	cfg := &config.Config{EnableRegistryValidation: true, ValidatorPackageConcurrency: 4}
+	report, err := CheckPublishRequest(t.Context(), req, cfg)
+	require.Error(t, err)
+
+	locations := make([]string, 0, len(report.Errors))
+	for _, finding := range report.Errors {
+		locations = append(locations, finding.Location)
+	}
+	assert.Equal(t, []string{"packages[0]", "packages[2]", "packages[3]"}, locations)
+	assert.Greater(t, maxInFlight.Load(), int32(1), "packages should be validated concurrently")
+
+	t.Run("concurrency is bounded", func(t *testing.T) {
+		maxInFlight.Store(0)
+		serial := &config.Config{EnableRegistryValidation: true, ValidatorPackageConcurrency: 1}
+		_, err := CheckPublishRequest(t.Context(), req, serial)
+		require.Error(t, err)
+		assert.Equal(t, int32(1), maxInFlight.Load())
+	})
+}
+
+func TestCheckPublishRequest_UnknownFields(t *testing.T) {
+	var req apiv0.ServerJSON
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"name": "com.example/server",
+		"description": "Searches and edits files in a workspace",
+		"version": "1.0.0",
+		"website_url": "https://example.com",
+		"icons": [{"src": "https://example.com/icon.png"}],
+		"_meta": {"com.example/build": {"commit": "abc123"}}
+	}`), &req))
+
+	t.Run("rejected by default", func(t *testing.T) {
+		report, err := CheckPublishRequest(t.Context(), req, &config.Config{})
+		require.ErrorIs(t, err, ErrUnknownField)
+
+		locations := make([]string, 0, len(report.Errors))
+		for _, finding := range report.Errors {
+			locations = append(locations, finding.Location)
+		}
+		assert.Equal(t, []string{"_meta.com.example/build", "icons"}, locations)
+	})
+
+	t.Run("preserved when configured", func(t *testing.T) {
+		cfg := &config.Config{UnknownFields: config.UnknownFieldsPreserve}
+		_, err := CheckPublishRequest(t.Context(), req, cfg)
+		require.NoError(t, err)
+	})
+
+	t.Run("registry extensions can't be set", func(t *testing.T) {
+		reserved := req
+		meta := *req.Meta
+		meta.UnknownFields = map[string]json.RawMessage{"io.modelcontextprotocol.registry/future": []byte(`{}`)}
+		reserved.Meta = &meta
+
+		cfg := &config.Config{UnknownFields: config.UnknownFieldsPreserve}
+		_, err := CheckPublishRequest(t.Context(), reserved, cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "reserved for registry metadata")
+	})
+}
+
+func TestCheckPublishRequest_SchemaVersion(t *testing.T) {
+	req := apiv0.ServerJSON{
+		Name:        "com.example/server",
+		Description: "Searches and edits files in a workspace",
+		Version:     "1.0.0",
+		WebsiteURL:  "https://example.com",
+	}
+
+	for _, schema := range []string{"", apiv0.SchemaURL(model.CurrentSchemaVersion)} {
+		req.Schema = schema
+		_, err := CheckPublishRequest(t.Context(), req, &config.Config{})
+		require.NoError(t, err, schema)
+	}
+
+	for _, schema := range []string{apiv0.SchemaURL("2099-01-01"), "https://example.com/server.schema.json"} {
+		req.Schema = schema
+		report, err := CheckPublishRequest(t.Context(), req, &config.Config{})
+		require.ErrorIs(t, err, ErrUnsupportedSchemaVersion, schema)
+		require.Len(t, report.Errors, 1)
+		assert.Equal(t, "$schema", report.Errors[0].Location)
+		assert.Contains(t, report.Errors[0].Message, apiv0.SchemaURL(model.CurrentSchemaVersion))
+	}
+}
@@ -0,0 +1,156 @@
+package validators
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+var (
+	githubAPIBaseURL = "https://api.github.com"
+	gitlabAPIBaseURL = "https://gitlab.com/api/v4"
+)
+
+// ValidateRepositoryExists checks, via the GitHub or GitLab API, that the declared repository exists and is public.
+// githubToken is optional and only used to raise GitHub API rate limits.
+func ValidateRepositoryExists(ctx context.Context, repo model.Repository, githubToken string) error {
+	// Repository is optional
+	if repo.URL == "" {
+		return nil
+	}
+
+	owner, name, err := parseRepositoryPath(repo.URL)
+	if err != nil {
+		return err
+	}
+
+	switch RepositorySource(repo.Source) {
+	case SourceGitHub:
+		return validateGitHubRepository(ctx, owner, name, githubToken)
+	case SourceGitLab:
+		return validateGitLabRepository(ctx, owner, name)
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidRepositoryURL, repo.URL)
+	}
+}
+
+// ValidateRepositoryOwner checks that servers published from GitHub Actions declare a repository
+// owned by the same user or organization as the workflow that requested the token
+func ValidateRepositoryOwner(repo model.Repository, claims *auth.JWTClaims) error {
+	if repo.URL == "" || claims == nil || claims.AuthMethod != auth.MethodGitHubOIDC {
+		return nil
+	}
+	if RepositorySource(repo.Source) != SourceGitHub {
+		return fmt.Errorf("%w: servers published from GitHub Actions must declare a GitHub repository", ErrRepositoryOwnerMismatch)
+	}
+
+	// GitHub OIDC subjects look like "repo:octo-org/octo-repo:environment:prod"
+	workflowRepo, ok := strings.CutPrefix(claims.AuthMethodSubject, "repo:")
+	workflowOwner, _, hasSlash := strings.Cut(workflowRepo, "/")
+	if !ok || !hasSlash || workflowOwner == "" {
+		return fmt.Errorf("%w: unable to determine owner from token subject %q", ErrRepositoryOwnerMismatch, claims.AuthMethodSubject)
+	}
+
+	owner, _, err := parseRepositoryPath(repo.URL)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(owner, workflowOwner) {
+		return fmt.Errorf("%w: repository belongs to '%s', but the token was issued to a workflow owned by '%s'", ErrRepositoryOwnerMismatch, owner, workflowOwner)
+	}
+
+	return nil
+}
+
+// parseRepositoryPath extracts the owner and name from a repository URL such as https://github.com/owner/repo
+func parseRepositoryPath(repoURL string) (string, string, error) {
+	parsed, err := url.Parse(repoURL)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %s", ErrInvalidRepositoryURL, repoURL)
+	}
+	parts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("%w: %s", ErrInvalidRepositoryURL, repoURL)
+	}
+	return parts[0], strings.TrimSuffix(parts[1], ".git"), nil
+}
+
+func validateGitHubRepository(ctx context.Context, owner, name, token string) error {
+	requestURL := fmt.Sprintf("%s/repos/%s/%s", githubAPIBaseURL, url.PathEscape(owner), url.PathEscape(name))
+
+	var ghRepo struct {
+		Private bool `json:"private"`
+		License *struct {
+			SPDXID string `json:"spdx_id"`
+		} `json:"license"`
+	}
+	headers := map[string]string{"Accept": "application/vnd.github+json"}
+	if token != "" {
+		headers["Authorization"] = "Bearer " + token
+	}
+	if err := fetchRepositoryJSON(ctx, requestURL, headers, &ghRepo); err != nil {
+		return fmt.Errorf("GitHub repository '%s/%s': %w", owner, name, err)
+	}
+
+	if ghRepo.Private {
+		return fmt.Errorf("GitHub repository '%s/%s': %w", owner, name, ErrRepositoryNotPublic)
+	}
+	if ghRepo.License == nil {
+		registries.Warn(ctx, "GitHub repository '%s/%s' has no license", owner, name)
+	}
+	return nil
+}
+
+func validateGitLabRepository(ctx context.Context, owner, name string) error {
+	requestURL := fmt.Sprintf("%s/projects/%s", gitlabAPIBaseURL, url.PathEscape(owner+"/"+name))
+
+	var glProject struct {
+		Visibility string `json:"visibility"`
+	}
+	if err := fetchRepositoryJSON(ctx, requestURL, nil, &glProject); err != nil {
+		return fmt.Errorf("GitLab repository '%s/%s': %w", owner, name, err)
+	}
+
+	// Anonymous requests only see public projects, but check explicitly in case that changes
+	if glProject.Visibility != "" && glProject.Visibility != "public" {
+		return fmt.Errorf("GitLab repository '%s/%s': %w", owner, name, ErrRepositoryNotPublic)
+	}
+	return nil
+}
+
+func fetchRepositoryJSON(ctx context.Context, requestURL string, headers map[string]string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := registries.NewHTTPClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch repository metadata: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Private repositories are reported as not found to anonymous callers
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w (it may be private)", ErrRepositoryNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch repository metadata (status: %d)", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to parse repository metadata: %w", err)
+	}
+	return nil
+}
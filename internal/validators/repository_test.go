@@ -0,0 +1,137 @@
+//nolint:testpackage
+package validators
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRepositoryExists(t *testing.T) {
+	ctx := context.Background()
+
+	var gotAuthorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/repos/example/public-repo":
+			gotAuthorization = r.Header.Get("Authorization")
+			_, _ = w.Write([]byte(`{"private": false}`))
+		case "/repos/example/private-repo":
+			_, _ = w.Write([]byte(`{"private": true}`))
+		case "/projects/example%2Fpublic-project":
+			_, _ = w.Write([]byte(`{"visibility": "public"}`))
+		case "/projects/example%2Finternal-project":
+			_, _ = w.Write([]byte(`{"visibility": "internal"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	originalGitHub, originalGitLab := githubAPIBaseURL, gitlabAPIBaseURL
+	githubAPIBaseURL, gitlabAPIBaseURL = server.URL, server.URL
+	defer func() { githubAPIBaseURL, gitlabAPIBaseURL = originalGitHub, originalGitLab }()
+
+	tests := []struct {
+		name        string
+		repo        model.Repository
+		expectedErr error
+	}{
+		{
+			name: "empty repository is allowed",
+			repo: model.Repository{},
+		},
+		{
+			name: "public GitHub repository",
+			repo: model.Repository{URL: "https://github.com/example/public-repo", Source: "github"},
+		},
+		{
+			name:        "private GitHub repository",
+			repo:        model.Repository{URL: "https://github.com/example/private-repo", Source: "github"},
+			expectedErr: ErrRepositoryNotPublic,
+		},
+		{
+			name:        "missing GitHub repository",
+			repo:        model.Repository{URL: "https://github.com/example/spoofed-repo", Source: "github"},
+			expectedErr: ErrRepositoryNotFound,
+		},
+		{
+			name: "public GitLab project",
+			repo: model.Repository{URL: "https://gitlab.com/example/public-project", Source: "gitlab"},
+		},
+		{
+			name:        "internal GitLab project",
+			repo:        model.Repository{URL: "https://gitlab.com/example/internal-project", Source: "gitlab"},
+			expectedErr: ErrRepositoryNotPublic,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRepositoryExists(ctx, tt.repo, "test-token")
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			}
+		})
+	}
+
+	assert.Equal(t, "Bearer test-token", gotAuthorization)
+}
+
+func TestValidateRepositoryOwner(t *testing.T) {
+	repo := model.Repository{URL: "https://github.com/octo-org/octo-repo", Source: "github"}
+
+	tests := []struct {
+		name        string
+		repo        model.Repository
+		claims      *auth.JWTClaims
+		expectError bool
+	}{
+		{
+			name:   "matching GitHub OIDC owner",
+			repo:   repo,
+			claims: &auth.JWTClaims{AuthMethod: auth.MethodGitHubOIDC, AuthMethodSubject: "repo:octo-org/octo-repo:ref:refs/heads/main"},
+		},
+		{
+			name:   "owner comparison is case insensitive",
+			repo:   repo,
+			claims: &auth.JWTClaims{AuthMethod: auth.MethodGitHubOIDC, AuthMethodSubject: "repo:Octo-Org/other-repo:environment:prod"},
+		},
+		{
+			name:        "different GitHub OIDC owner",
+			repo:        repo,
+			claims:      &auth.JWTClaims{AuthMethod: auth.MethodGitHubOIDC, AuthMethodSubject: "repo:attacker/octo-repo:ref:refs/heads/main"},
+			expectError: true,
+		},
+		{
+			name:        "GitLab repository from GitHub Actions",
+			repo:        model.Repository{URL: "https://gitlab.com/octo-org/octo-repo", Source: "gitlab"},
+			claims:      &auth.JWTClaims{AuthMethod: auth.MethodGitHubOIDC, AuthMethodSubject: "repo:octo-org/octo-repo:ref:refs/heads/main"},
+			expectError: true,
+		},
+		{
+			name:   "other auth methods are not checked",
+			repo:   repo,
+			claims: &auth.JWTClaims{AuthMethod: auth.MethodDNS, AuthMethodSubject: "example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRepositoryOwner(tt.repo, tt.claims)
+			if tt.expectError {
+				assert.ErrorIs(t, err, ErrRepositoryOwnerMismatch)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package validators
+
+import apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+
+// ValidateServerName checks a server name has the 'dns-namespace/name' format and isn't blocked
+func ValidateServerName(name string) error {
+	if _, err := parseServerName(apiv0.ServerJSON{Name: name}); err != nil {
+		return err
+	}
+	return CheckBlockedName(name)
+}
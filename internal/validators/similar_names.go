@@ -0,0 +1,79 @@
+package validators
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// homoglyphs maps characters commonly substituted to imitate another name onto the character they imitate
+var homoglyphs = map[rune]rune{
+	'0': 'o', '1': 'l', '3': 'e', '4': 'a', '5': 's', '7': 't', '8': 'b', '9': 'g', 'i': 'l', '|': 'l',
+	// Cyrillic and Greek letters that render like Latin ones
+	'а': 'a', 'в': 'b', 'е': 'e', 'к': 'k', 'м': 'm', 'н': 'h', 'о': 'o', 'р': 'p', 'с': 'c', 'т': 't',
+	'у': 'y', 'х': 'x', 'і': 'l', 'ј': 'j', 'ѕ': 's', 'ԁ': 'd', 'ɡ': 'g',
+	'α': 'a', 'β': 'b', 'ε': 'e', 'ι': 'l', 'κ': 'k', 'ν': 'v', 'ο': 'o', 'ρ': 'p', 'τ': 't', 'υ': 'u', 'χ': 'x',
+}
+
+// multiCharHomoglyphs are letter sequences that read like a single letter
+var multiCharHomoglyphs = strings.NewReplacer("rn", "m", "vv", "w")
+
+// FindSimilarServerName returns the first name in existing that looks confusingly similar to name, or false if none does.
+// Names are similar when they read the same once homoglyphs and separators are normalized away, or when their normalized
+// Levenshtein similarity (1 - distance / length of the longer name) is at least threshold. Exact matches are ignored.
+func FindSimilarServerName(name string, existing []string, threshold float64) (string, bool) {
+	skeleton := nameSkeleton(name)
+	for _, other := range existing {
+		if other == name {
+			continue
+		}
+		otherSkeleton := nameSkeleton(other)
+		if skeleton == otherSkeleton || nameSimilarity(skeleton, otherSkeleton) >= threshold {
+			return other, true
+		}
+	}
+	return "", false
+}
+
+// nameSkeleton normalizes case, homoglyphs and separators so names that read the same compare equal
+func nameSkeleton(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if r == '-' || r == '_' || r == '.' {
+			continue
+		}
+		if replacement, ok := homoglyphs[r]; ok {
+			r = replacement
+		}
+		b.WriteRune(r)
+	}
+	return multiCharHomoglyphs.Replace(b.String())
+}
+
+// nameSimilarity returns 1 minus the Levenshtein distance between a and b divided by the longer length
+func nameSimilarity(a, b string) float64 {
+	longest := max(utf8.RuneCountInString(a), utf8.RuneCountInString(b))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein([]rune(a), []rune(b)))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
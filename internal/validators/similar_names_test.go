@@ -0,0 +1,47 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindSimilarServerName(t *testing.T) {
+	existing := []string{"com.example/server", "io.github.octocat/weather-mcp"}
+
+	tests := []struct {
+		name    string
+		similar string
+	}{
+		{"com.example/serv3r", "com.example/server"},
+		{"com.examp1e/server", "com.example/server"},
+		{"com.example/servers", "com.example/server"},
+		{"io.github.octocat/weather_mcp", "io.github.octocat/weather-mcp"},
+		{"io.github.octocat/weathermcp", "io.github.octocat/weather-mcp"},
+		{"io.github.octocat/vveather-mcp", "io.github.octocat/weather-mcp"},
+		{"com.еxample/server", "com.example/server"}, // Cyrillic е
+		{"com.example/server", ""},                   // exact matches are the same server
+		{"com.example/database", ""},
+		{"io.github.octocat/calendar-mcp", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			similar, found := validators.FindSimilarServerName(tt.name, existing, 0.9)
+			assert.Equal(t, tt.similar != "", found)
+			assert.Equal(t, tt.similar, similar)
+		})
+	}
+}
+
+func TestFindSimilarServerNameThreshold(t *testing.T) {
+	existing := []string{"com.example/server"}
+
+	_, found := validators.FindSimilarServerName("com.example/sorvor", existing, 0.9)
+	assert.False(t, found)
+
+	similar, found := validators.FindSimilarServerName("com.example/sorvor", existing, 0.8)
+	assert.True(t, found)
+	assert.Equal(t, "com.example/server", similar)
+}
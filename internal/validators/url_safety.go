@@ -0,0 +1,144 @@
+package validators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// maxURLRedirects caps how many redirects the registry follows when fetching publisher-supplied URLs
+const maxURLRedirects = 5
+
+// urlResolveTimeout bounds the DNS lookups made while validating publisher-supplied URLs
+const urlResolveTimeout = 5 * time.Second
+
+var (
+	// ErrNonPublicURL is returned when a URL points at a private, loopback, link-local or otherwise non-public address
+	ErrNonPublicURL = errors.New("URL must point to a public address")
+	// ErrInsecureURL is returned when a URL doesn't use HTTPS while HTTPS is required
+	ErrInsecureURL = errors.New("URL must use https")
+)
+
+// nonPublicPrefixes are ranges netip.Addr doesn't classify itself but which are never reachable on the public internet
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // "this network"
+	netip.MustParsePrefix("100.64.0.0/10"),   // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),    // TEST-NET-1
+	netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"), // TEST-NET-2
+	netip.MustParsePrefix("203.0.113.0/24"),  // TEST-NET-3
+	netip.MustParsePrefix("240.0.0.0/4"),     // reserved
+	netip.MustParsePrefix("64:ff9b:1::/48"),  // local-use NAT64
+	netip.MustParsePrefix("2001:db8::/32"),   // documentation
+}
+
+// IsPublicIP reports whether addr is a globally routable unicast address
+func IsPublicIP(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// validateURLHost rejects URLs with embedded credentials or a non-public IP address as their host.
+// It doesn't make network requests, so hostnames are checked by ValidatePublicURL at publish time.
+func validateURLHost(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	if u.User != nil {
+		return fmt.Errorf("URL must not contain credentials: %s", u.Redacted())
+	}
+	if addr, err := netip.ParseAddr(strings.Trim(u.Hostname(), "[]")); err == nil && !IsPublicIP(addr) {
+		return fmt.Errorf("%w: %s", ErrNonPublicURL, rawURL)
+	}
+	return nil
+}
+
+// ValidatePublicURL checks a URL that clients, and the registry itself, may fetch: it must use HTTPS if
+// requireHTTPS is set, and if resolve is set its hostname must not resolve to any non-public address.
+// Hostnames that fail to resolve are allowed, since they can't be used to reach internal services either.
+func ValidatePublicURL(ctx context.Context, rawURL string, requireHTTPS, resolve bool) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	if requireHTTPS && u.Scheme != "https" {
+		return fmt.Errorf("%w: %s", ErrInsecureURL, rawURL)
+	}
+	if err := validateURLHost(rawURL); err != nil {
+		return err
+	}
+	if !resolve {
+		return nil
+	}
+	if _, err := netip.ParseAddr(strings.Trim(u.Hostname(), "[]")); err == nil {
+		return nil // IP literals were checked above
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, urlResolveTimeout)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", u.Hostname())
+	if err != nil {
+		return nil
+	}
+	for _, addr := range addrs {
+		if !IsPublicIP(addr) {
+			return fmt.Errorf("%w: %s resolves to %s", ErrNonPublicURL, u.Hostname(), addr)
+		}
+	}
+	return nil
+}
+
+// NewPublicHTTPClient returns an HTTP client for fetching publisher-supplied URLs. It refuses to connect to
+// non-public addresses, checked when dialing so DNS rebinding and redirects can't bypass it, follows at most
+// maxURLRedirects redirects, and never follows a redirect from HTTPS to HTTP.
+func NewPublicHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !IsPublicIP(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s", ErrNonPublicURL, addrPort.Addr())
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+			MaxIdleConnsPerHost: 2,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxURLRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxURLRedirects)
+			}
+			if via[0].URL.Scheme == "https" && req.URL.Scheme != "https" {
+				return fmt.Errorf("refusing to follow redirect from https to %s", req.URL.Scheme)
+			}
+			return nil
+		},
+	}
+}
@@ -2,7 +2,6 @@ package validators
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/url"
 	"regexp"
@@ -10,8 +9,10 @@ import (
 	"strings"
 
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
+	"golang.org/x/sync/errgroup"
 )
 
 // Regexes to detect semver range syntaxes
@@ -41,52 +42,68 @@ var (
 )
 
 func ValidateServerJSON(serverJSON *apiv0.ServerJSON) error {
+	if findings := serverJSONFindings(serverJSON); len(findings) > 0 {
+		return findings[0].err
+	}
+	return nil
+}
+
+// serverJSONFindings runs every check of ValidateServerJSON, collecting each problem instead of stopping at the first
+func serverJSONFindings(serverJSON *apiv0.ServerJSON) []finding {
+	var findings []finding
+	check := func(location string, err error) {
+		if err != nil {
+			findings = append(findings, finding{location: location, err: err})
+		}
+	}
+
 	// Validate server name exists and format
-	if _, err := parseServerName(*serverJSON); err != nil {
-		return err
+	_, nameErr := parseServerName(*serverJSON)
+	check("name", nameErr)
+	if nameErr == nil {
+		check("name", CheckBlockedName(serverJSON.Name))
 	}
 
 	// Validate top-level server version is a specific version (not a range) & not "latest"
-	if err := validateVersion(serverJSON.Version); err != nil {
-		return err
-	}
+	check("version", validateVersion(serverJSON.Version))
 
 	// Validate repository
-	if err := validateRepository(&serverJSON.Repository); err != nil {
-		return err
-	}
+	check("repository", validateRepository(&serverJSON.Repository))
 
 	// Validate website URL if provided
-	if err := validateWebsiteURL(serverJSON.WebsiteURL); err != nil {
-		return err
-	}
+	websiteErr := validateWebsiteURL(serverJSON.WebsiteURL)
+	check("website_url", websiteErr)
+
+	// Validate category and tag limits and formats
+	check("", validateCategoriesAndTags(serverJSON))
 
 	// Validate all packages (basic field validation)
 	// Detailed package validation (including registry checks) is done during publish
-	for _, pkg := range serverJSON.Packages {
-		if err := validatePackageField(&pkg); err != nil {
-			return err
-		}
+	for i, pkg := range serverJSON.Packages {
+		check(fmt.Sprintf("packages[%d]", i), validatePackageField(&pkg))
 	}
 
 	// Validate all remotes
-	for _, remote := range serverJSON.Remotes {
-		if err := validateRemoteTransport(&remote); err != nil {
-			return err
+	remoteURLs := make(map[string]bool, len(serverJSON.Remotes))
+	for i, remote := range serverJSON.Remotes {
+		location := fmt.Sprintf("remotes[%d]", i)
+		if remoteURLs[remote.URL] {
+			check(location, fmt.Errorf("%w: %s; declare each transport of a server once", ErrDuplicateRemoteURL, remote.URL))
+			continue
 		}
+		remoteURLs[remote.URL] = true
+		check(location, validateRemoteTransport(&remote))
 	}
 
-	// Validate reverse-DNS namespace matching for remote URLs
-	if err := validateRemoteNamespaceMatch(*serverJSON); err != nil {
-		return err
-	}
-
-	// Validate reverse-DNS namespace matching for website URL
-	if err := validateWebsiteURLNamespaceMatch(*serverJSON); err != nil {
-		return err
+	// Reverse-DNS namespace matching for remote and website URLs needs a valid name
+	if nameErr == nil {
+		check("remotes", validateRemoteNamespaceMatch(*serverJSON))
+		if websiteErr == nil {
+			check("website_url", validateWebsiteURLNamespaceMatch(*serverJSON))
+		}
 	}
 
-	return nil
+	return findings
 }
 
 func validateRepository(obj *model.Repository) error {
@@ -131,6 +148,10 @@ func validateWebsiteURL(websiteURL string) error {
 		return fmt.Errorf("website URL must use http or https scheme: %s", websiteURL)
 	}
 
+	if err := validateURLHost(websiteURL); err != nil {
+		return fmt.Errorf("invalid website URL: %w", err)
+	}
+
 	return nil
 }
 
@@ -144,6 +165,26 @@ func validatePackageField(obj *model.Package) error {
 		return err
 	}
 
+	// Validate pinned image digest
+	if obj.Digest != "" {
+		if obj.RegistryType != model.RegistryTypeOCI {
+			return ErrDigestRequiresOCI
+		}
+		if !registries.IsOCIDigest(obj.Digest) {
+			return ErrInvalidPackageDigest
+		}
+	}
+
+	// Validate declared file hash
+	if obj.FileSHA256 != "" && !registries.IsFileSHA256(obj.FileSHA256) {
+		return ErrInvalidFileSHA256
+	}
+
+	// Validate environment variables
+	if err := validateEnvironmentVariables(obj.EnvironmentVariables); err != nil {
+		return err
+	}
+
 	// Validate runtime arguments
 	for _, arg := range obj.RuntimeArguments {
 		if err := validateArgument(&arg); err != nil {
@@ -164,6 +205,33 @@ func validatePackageField(obj *model.Package) error {
 		return fmt.Errorf("invalid transport: %w", err)
 	}
 
+	// Validate the transport is one the package can actually be started with
+	return validatePackageCapabilities(obj)
+}
+
+// registryTypesWithoutLauncher are registry types that clients have no default command to start over stdio
+var registryTypesWithoutLauncher = map[string]string{
+	model.RegistryTypeMaven: "e.g. runtime_hint \"java\" with a \"-jar\" runtime argument",
+	model.RegistryTypeGem:   "e.g. runtime_hint \"ruby\" or \"bundle\"",
+}
+
+// validatePackageCapabilities cross-checks a package's transport against how the package is started
+func validatePackageCapabilities(obj *model.Package) error {
+	if obj.Transport.Type != model.TransportTypeStdio {
+		return nil
+	}
+
+	// Headers are only sent over HTTP
+	if len(obj.Transport.Headers) > 0 {
+		return fmt.Errorf("%w: pass values to stdio servers with environment_variables or package_arguments, or use the %s transport",
+			ErrStdioTransportHeaders, model.TransportTypeStreamableHTTP)
+	}
+
+	// Clients need to know which command runs packages they have no launcher for
+	if example, ok := registryTypesWithoutLauncher[obj.RegistryType]; ok && obj.RunTimeHint == "" && len(obj.RuntimeArguments) == 0 {
+		return fmt.Errorf("%w: clients have no default command for %s packages over stdio (%s)", ErrPackageRuntimeRequired, obj.RegistryType, example)
+	}
+
 	return nil
 }
 
@@ -336,6 +404,9 @@ func validateRemoteTransport(obj *model.Transport) error {
 		if !IsValidRemoteURL(obj.URL) {
 			return fmt.Errorf("%w: %s", ErrInvalidRemoteURL, obj.URL)
 		}
+		if err := validateURLHost(obj.URL); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidRemoteURL, err)
+		}
 		return nil
 	default:
 		return fmt.Errorf("unsupported transport type for remotes: %s (only streamable-http and sse are supported)", obj.Type)
@@ -344,53 +415,204 @@ func validateRemoteTransport(obj *model.Transport) error {
 
 // ValidatePublishRequest validates a complete publish request including extensions
 func ValidatePublishRequest(req apiv0.ServerJSON, cfg *config.Config) error {
+	_, err := CheckPublishRequest(context.Background(), req, cfg)
+	return err
+}
+
+// CheckPublishRequest validates a complete publish request, collecting every problem instead of stopping at the
+// first. It returns a report of the findings, including non-blocking warnings from upstream checks and lint rules
+// along with the quality score, and a *ValidationError if any of them are errors. Upstream checks are skipped for fields that are already invalid.
+func CheckPublishRequest(ctx context.Context, req apiv0.ServerJSON, cfg *config.Config) (*apiv0.ValidationReport, error) {
+	report := &apiv0.ValidationReport{}
+	var findings []finding
+	invalid := make(map[string]bool)
+	check := func(location string, err error) {
+		if err != nil {
+			findings = append(findings, finding{location: location, err: err})
+			invalid[location] = true
+		}
+	}
+
 	// Validate publisher extensions in _meta
-	if err := validatePublisherExtensions(req); err != nil {
-		return err
+	check("_meta", validatePublisherExtensions(req))
+	if req.Meta != nil {
+		for _, f := range publisherMetaFindings(req.Meta.PublisherProvided, cfg) {
+			check(f.location, f.err)
+		}
+	}
+
+	// Documents are validated against the current schema version, which every supported version converts to
+	check("$schema", validateSpecVersion(req.Schema))
+
+	// Fields from a newer server.json schema are stored as they are if the registry preserves them
+	if cfg.UnknownFields != config.UnknownFieldsPreserve {
+		for _, location := range unknownFieldLocations(req) {
+			check(location, ErrUnknownField)
+		}
 	}
 
 	// Validate the server detail (includes all nested validation)
-	if err := ValidateServerJSON(&req); err != nil {
-		return err
+	for _, f := range serverJSONFindings(&req) {
+		check(f.location, f.err)
+	}
+
+	// Categories must come from the registry's taxonomy
+	check("categories", validateCategoryTaxonomy(req, cfg))
+
+	// Internationalized namespaces must be valid and not mix scripts to pass for another namespace
+	if !invalid["name"] {
+		warning, err := validateNamespaceIDN(req.Name, cfg.MixedScriptNamespaces)
+		check("name", err)
+		if warning != "" {
+			addWarnings(report, "name", []string{warning})
+		}
+	}
+
+	// Clients fetch remote and website URLs, so they must be public and, by default, use HTTPS
+	if req.Status != model.StatusDeleted {
+		for i, remote := range req.Remotes {
+			location := fmt.Sprintf("remotes[%d]", i)
+			if invalid[location] {
+				continue
+			}
+			if err := ValidatePublicURL(ctx, remote.URL, cfg.RequireHTTPSURLs, cfg.ResolveURLHosts); err != nil {
+				check(location, fmt.Errorf("%w: %w", ErrInvalidRemoteURL, err))
+			}
+		}
+		if req.WebsiteURL != "" && !invalid["website_url"] {
+			if err := ValidatePublicURL(ctx, req.WebsiteURL, cfg.RequireHTTPSURLs, cfg.ResolveURLHosts); err != nil {
+				check("website_url", fmt.Errorf("invalid website URL: %w", err))
+			}
+		}
+	}
+
+	// Validate the declared repository exists and is public
+	if cfg.EnableRepositoryValidation && req.Status != model.StatusDeleted && !invalid["repository"] {
+		repoCtx, warnings := registries.CollectWarnings(ctx)
+		if err := ValidateRepositoryExists(repoCtx, req.Repository, cfg.RepositoryValidationGitHubToken); err != nil {
+			check("repository", fmt.Errorf("repository validation failed: %w", err))
+		}
+		addWarnings(report, "repository", warnings())
 	}
 
 	// Validate registry ownership for all packages if validation is enabled and server is not deleted
 	if cfg.EnableRegistryValidation && req.Status != model.StatusDeleted {
-		ctx := context.Background()
-		for i, pkg := range req.Packages {
-			if err := ValidatePackage(ctx, pkg, req.Name); err != nil {
-				return fmt.Errorf("registry validation failed for package %d (%s): %w", i, pkg.Identifier, err)
-			}
+		for i, result := range validatePackages(ctx, req, cfg, invalid) {
+			location := fmt.Sprintf("packages[%d]", i)
+			check(location, result.err)
+			addWarnings(report, location, result.warnings)
 		}
 	}
 
-	return nil
+	// Lint rules flag quality problems without blocking the publish
+	lintWarnings, score := Lint(req)
+	report.Warnings = append(report.Warnings, lintWarnings...)
+	report.QualityScore = score
+
+	if len(findings) > 0 {
+		return report, newValidationError(findings, report)
+	}
+	return report, nil
 }
 
-func validatePublisherExtensions(req apiv0.ServerJSON) error {
-	const maxExtensionSize = 4 * 1024 // 4KB limit
+// packageResult is the outcome of validating one package against its registry
+type packageResult struct {
+	err      error
+	warnings []string
+}
 
-	// Check size limit for _meta publisher-provided extension
-	if req.Meta != nil && req.Meta.PublisherProvided != nil {
-		extensionsJSON, err := json.Marshal(req.Meta.PublisherProvided)
-		if err != nil {
-			return fmt.Errorf("failed to marshal _meta.io.modelcontextprotocol.registry/publisher-provided extension: %w", err)
-		}
-		if len(extensionsJSON) > maxExtensionSize {
-			return fmt.Errorf("_meta.io.modelcontextprotocol.registry/publisher-provided extension exceeds 4KB limit (%d bytes)", len(extensionsJSON))
+// validatePackages validates the server's packages against their registries, up to
+// cfg.ValidatorPackageConcurrency at a time. Results are indexed like req.Packages, so findings are
+// reported in package order whichever registry answers first. Packages that already failed are skipped.
+func validatePackages(ctx context.Context, req apiv0.ServerJSON, cfg *config.Config, invalid map[string]bool) []packageResult {
+	results := make([]packageResult, len(req.Packages))
+
+	var group errgroup.Group
+	group.SetLimit(max(cfg.ValidatorPackageConcurrency, 1))
+	for i, pkg := range req.Packages {
+		if invalid[fmt.Sprintf("packages[%d]", i)] {
+			continue
 		}
+		group.Go(func() error {
+			pkgCtx, warnings := registries.CollectWarnings(ctx)
+			if err := ValidatePackage(pkgCtx, pkg, req.Name); err != nil {
+				results[i].err = fmt.Errorf("%w for package %d (%s): %w", ErrRegistryValidationFailed, i, pkg.Identifier, err)
+			} else if cfg.RequireNPMProvenance && pkg.RegistryType == model.RegistryTypeNPM {
+				// Require NPM packages to be built from the server's declared repository
+				if err := registries.ValidateNPMProvenance(pkgCtx, pkg, req.Repository.URL); err != nil {
+					results[i].err = fmt.Errorf("%w for package %d (%s): %w", ErrProvenanceValidationFailed, i, pkg.Identifier, err)
+				}
+			}
+			results[i].warnings = warnings()
+			return nil
+		})
+	}
+	_ = group.Wait() // Failures are collected per package rather than cancelling the others
+
+	return results
+}
+
+func addWarnings(report *apiv0.ValidationReport, location string, messages []string) {
+	for _, message := range messages {
+		report.Warnings = append(report.Warnings, apiv0.ValidationFinding{Location: location, Message: message})
 	}
+}
 
+// validatePublisherExtensions checks _meta holds no registry metadata. The publisher-provided extension is
+// checked by publisherMetaFindings.
+func validatePublisherExtensions(req apiv0.ServerJSON) error {
 	if req.Meta != nil {
 		// Validate that only publisher-provided data is allowed in _meta during publish (no official registry metadata should be present)
 		if req.Meta.Official != nil {
 			return fmt.Errorf("official registry metadata '_meta.io.modelcontextprotocol.registry/official' is not allowed during publish")
 		}
+		if req.Meta.Mirror != nil {
+			return fmt.Errorf("mirror metadata '_meta.io.modelcontextprotocol.registry/mirror' is not allowed during publish")
+		}
+		if req.Meta.Validation != nil {
+			return fmt.Errorf("validation report '_meta.io.modelcontextprotocol.registry/validation' is not allowed during publish")
+		}
+		for name := range req.Meta.UnknownFields {
+			if strings.HasPrefix(name, registryMetaPrefix) {
+				return fmt.Errorf("'_meta.%s' is reserved for registry metadata and is not allowed during publish", name)
+			}
+		}
 	}
 
 	return nil
 }
 
+// validateSpecVersion checks a $schema URL names a server.json schema version the registry supports
+func validateSpecVersion(schema string) error {
+	if _, ok := apiv0.SpecVersionOf(schema); ok {
+		return nil
+	}
+	supported := make([]string, 0, len(apiv0.SupportedSpecVersions()))
+	for _, version := range apiv0.SupportedSpecVersions() {
+		supported = append(supported, version.SchemaURL)
+	}
+	return fmt.Errorf("%w %q; supported versions are %s", ErrUnsupportedSchemaVersion, schema, strings.Join(supported, ", "))
+}
+
+// registryMetaPrefix starts the names of the _meta extensions set by the registry
+const registryMetaPrefix = "io.modelcontextprotocol.registry/"
+
+// unknownFieldLocations returns the locations of the server's top-level and _meta fields this registry
+// doesn't know, in name order
+func unknownFieldLocations(req apiv0.ServerJSON) []string {
+	var locations []string
+	for name := range req.UnknownFields {
+		locations = append(locations, name)
+	}
+	if req.Meta != nil {
+		for name := range req.Meta.UnknownFields {
+			locations = append(locations, "_meta."+name)
+		}
+	}
+	slices.Sort(locations)
+	return locations
+}
+
 func parseServerName(serverJSON apiv0.ServerJSON) (string, error) {
 	name := serverJSON.Name
 	if name == "" {
@@ -0,0 +1,51 @@
+package validators_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func BenchmarkValidateServerJSON(b *testing.B) {
+	typical := apiv0.ServerJSON{
+		Name:        "io.github.example/weather",
+		Description: "Weather forecasts for MCP clients",
+		Version:     "1.2.3",
+		Repository:  model.Repository{URL: "https://github.com/example/weather", Source: "github"},
+		Packages: []model.Package{{
+			RegistryType: model.RegistryTypeNPM,
+			Identifier:   "@example/weather-mcp",
+			Version:      "1.2.3",
+			Transport:    model.Transport{Type: model.TransportTypeStdio},
+			EnvironmentVariables: []model.KeyValueInput{{
+				Name: "WEATHER_API_KEY",
+				InputWithVariables: model.InputWithVariables{
+					Input: model.Input{Description: "API key for the weather service", IsRequired: true, IsSecret: true},
+				},
+			}},
+		}},
+		Remotes: []model.Transport{{Type: model.TransportTypeStreamableHTTP, URL: "https://weather.example.com/mcp"}},
+	}
+	// Oversized names are rejected, but only after every check has run on them
+	longName := typical
+	longName.Name = "io.github.example/" + strings.Repeat("a", 10000)
+
+	for _, bc := range []struct {
+		name   string
+		server apiv0.ServerJSON
+	}{
+		{"typical", typical},
+		{"long-name", longName},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				server := bc.server
+				_ = validators.ValidateServerJSON(&server)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/validators"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 	"github.com/stretchr/testify/assert"
@@ -489,6 +490,55 @@ func TestValidate(t *testing.T) {
 			},
 			expectedError: validators.ErrReservedVersionString.Error(),
 		},
+		{
+			name: "package with malformed digest",
+			serverDetail: apiv0.ServerJSON{
+				Name:        "com.example/test-server",
+				Description: "A test server",
+				Repository: model.Repository{
+					URL:    "https://github.com/owner/repo",
+					Source: "github",
+				},
+				Version: "1.0.0",
+				Packages: []model.Package{
+					{
+						Identifier:   "example/test-image",
+						RegistryType: "oci",
+						Version:      "1.0.0",
+						Digest:       "sha256:not-a-digest",
+						Transport: model.Transport{
+							Type: "stdio",
+						},
+					},
+				},
+			},
+			expectedError: validators.ErrInvalidPackageDigest.Error(),
+		},
+		{
+			name: "digest on non-OCI package",
+			serverDetail: apiv0.ServerJSON{
+				Name:        "com.example/test-server",
+				Description: "A test server",
+				Repository: model.Repository{
+					URL:    "https://github.com/owner/repo",
+					Source: "github",
+				},
+				Version: "1.0.0",
+				Packages: []model.Package{
+					{
+						Identifier:      "test-package",
+						RegistryType:    "npm",
+						RegistryBaseURL: "https://registry.npmjs.org",
+						Version:         "1.0.0",
+						Digest:          "sha256:0000000000000000000000000000000000000000000000000000000000000000",
+						Transport: model.Transport{
+							Type: "stdio",
+						},
+					},
+				},
+			},
+			expectedError: validators.ErrDigestRequiresOCI.Error(),
+		},
 		{
 			name: "multiple packages with one invalid",
 			serverDetail: apiv0.ServerJSON{
@@ -1484,7 +1534,84 @@ func TestValidate_TransportValidation(t *testing.T) {
 	}
 }
 
+func TestValidate_TransportCapabilities(t *testing.T) {
+	stdioPackage := func(registryType string) model.Package {
+		return model.Package{
+			Identifier:   "test-package",
+			RegistryType: registryType,
+			Version:      "1.0.0",
+			Transport:    model.Transport{Type: "stdio"},
+		}
+	}
+	remote := model.Transport{Type: "streamable-http", URL: "https://example.com/mcp"}
+
+	tests := []struct {
+		name        string
+		packages    []model.Package
+		remotes     []model.Transport
+		expectedErr error
+	}{
+		{
+			name:     "stdio package with a default launcher",
+			packages: []model.Package{stdioPackage(model.RegistryTypeNPM)},
+		},
+		{
+			name: "stdio package with headers",
+			packages: []model.Package{func() model.Package {
+				pkg := stdioPackage(model.RegistryTypeNPM)
+				pkg.Transport.Headers = []model.KeyValueInput{{Name: "Authorization"}}
+				return pkg
+			}()},
+			expectedErr: validators.ErrStdioTransportHeaders,
+		},
+		{
+			name:        "stdio package without a launcher",
+			packages:    []model.Package{stdioPackage(model.RegistryTypeMaven)},
+			expectedErr: validators.ErrPackageRuntimeRequired,
+		},
+		{
+			name: "stdio package with a runtime hint",
+			packages: []model.Package{func() model.Package {
+				pkg := stdioPackage(model.RegistryTypeGem)
+				pkg.RunTimeHint = "bundle"
+				return pkg
+			}()},
+		},
+		{
+			name:    "distinct remotes",
+			remotes: []model.Transport{remote, {Type: "sse", URL: "https://example.com/sse"}},
+		},
+		{
+			name:        "duplicate remote URLs",
+			remotes:     []model.Transport{remote, {Type: "sse", URL: remote.URL}},
+			expectedErr: validators.ErrDuplicateRemoteURL,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := apiv0.ServerJSON{
+				Name:        "com.example/test-server",
+				Description: "A test server",
+				Version:     "1.0.0",
+				Packages:    tt.packages,
+				Remotes:     tt.remotes,
+			}
+			err := validators.ValidateServerJSON(&server)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
 func TestValidate_RegistryTypesAndUrls(t *testing.T) {
+	// Start from closed circuit breakers, and don't leave any opened here for later tests
+	previous := registries.SetTransport(registries.NewTransport())
+	defer registries.SetTransport(previous)
+
 	testCases := []struct {
 		tcName       string
 		name         string
@@ -1505,8 +1632,8 @@ func TestValidate_RegistryTypesAndUrls(t *testing.T) {
 		{"valid_nuget", "io.github.domdomegg/time-mcp-server", model.RegistryTypeNuGet, "", "TimeMcpServer", "1.0.2", "", false},
 		{"valid_mcpb_github", "io.github.domdomegg/airtable-mcp-server", model.RegistryTypeMCPB, model.RegistryURLGitHub, "https://github.com/domdomegg/airtable-mcp-server/releases/download/v1.7.2/airtable-mcp-server.mcpb", "1.7.2", "fe333e598595000ae021bd27117db32ec69af6987f507ba7a63c90638ff633ce", false},
 		{"valid_mcpb_github", "io.github.domdomegg/airtable-mcp-server", model.RegistryTypeMCPB, "", "https://github.com/domdomegg/airtable-mcp-server/releases/download/v1.7.2/airtable-mcp-server.mcpb", "1.7.2", "fe333e598595000ae021bd27117db32ec69af6987f507ba7a63c90638ff633ce", false},
-		{"valid_mcpb_gitlab", "io.gitlab.fforster/gitlab-mcp", model.RegistryTypeMCPB, model.RegistryURLGitLab, "https://gitlab.com/fforster/gitlab-mcp/-/releases/v1.31.0/downloads/gitlab-mcp_1.31.0_Linux_x86_64.tar.gz", "1.31.0", "abc123ef4567890abcdef1234567890abcdef1234567890abcdef1234567890", false}, // this is not actually a valid mcpb, but it's the closest I can get for testing for now
-		{"valid_mcpb_gitlab", "io.gitlab.fforster/gitlab-mcp", model.RegistryTypeMCPB, "", "https://gitlab.com/fforster/gitlab-mcp/-/releases/v1.31.0/downloads/gitlab-mcp_1.31.0_Linux_x86_64.tar.gz", "1.31.0", "abc123ef4567890abcdef1234567890abcdef1234567890abcdef1234567890", false},                      // this is not actually a valid mcpb, but it's the closest I can get for testing for now
+		{"valid_mcpb_gitlab", "io.gitlab.fforster/gitlab-mcp", model.RegistryTypeMCPB, model.RegistryURLGitLab, "https://gitlab.com/fforster/gitlab-mcp/-/releases/v1.31.0/downloads/gitlab-mcp_1.31.0_Linux_x86_64.tar.gz", "1.31.0", "abc123ef4567890abcdef1234567890abcdef1234567890abcdef12345678900", false}, // this is not actually a valid mcpb, but it's the closest I can get for testing for now
+		{"valid_mcpb_gitlab", "io.gitlab.fforster/gitlab-mcp", model.RegistryTypeMCPB, "", "https://gitlab.com/fforster/gitlab-mcp/-/releases/v1.31.0/downloads/gitlab-mcp_1.31.0_Linux_x86_64.tar.gz", "1.31.0", "abc123ef4567890abcdef1234567890abcdef1234567890abcdef12345678900", false},                      // this is not actually a valid mcpb, but it's the closest I can get for testing for now
 
 		// Test MCPB without file hash (should fail)
 		{"invalid_mcpb_no_hash", "io.github.domdomegg/airtable-mcp-server", model.RegistryTypeMCPB, model.RegistryURLGitHub, "https://github.com/domdomegg/airtable-mcp-server/releases/download/v1.7.2/airtable-mcp-server.mcpb", "1.7.2", "", true},
@@ -0,0 +1,176 @@
+// Package webhooks delivers change feed events to operator-configured webhook endpoints. Change feed
+// entries are written in the same transaction as the server records they describe, so the feed acts as a
+// transactional outbox: each endpoint has a cursor into it, advanced only after a delivery succeeds.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+const (
+	// batchSize is how many changes are read from the feed at a time for each endpoint
+	batchSize = 100
+	// leaseDuration is how long an instance may deliver to an endpoint before it must renew its lease;
+	// another instance takes over once it expires
+	leaseDuration = time.Minute
+	// maxDrainBody bounds how much of a webhook response is read so the connection can be reused
+	maxDrainBody = 64 << 10
+)
+
+// Headers sent with each delivery. The delivery ID is the change's sequence number, so receivers can
+// discard the occasional duplicate sent after a failure between delivering and advancing the cursor.
+const (
+	HeaderDelivery  = "X-Registry-Delivery"
+	HeaderEvent     = "X-Registry-Event"
+	HeaderSignature = "X-Registry-Signature"
+)
+
+// Dispatcher delivers each change feed event to every endpoint, in sequence order
+type Dispatcher struct {
+	db        database.Database
+	client    *http.Client
+	endpoints []string
+	secret    []byte
+	owner     string
+}
+
+// NewDispatcher creates a dispatcher delivering to endpoints. If secret is set, each delivery is signed
+// with an HMAC-SHA256 of the body in the X-Registry-Signature header.
+func NewDispatcher(db database.Database, endpoints []string, secret string, timeout time.Duration) *Dispatcher {
+	return &Dispatcher{
+		db:        db,
+		client:    &http.Client{Timeout: timeout},
+		endpoints: endpoints,
+		secret:    []byte(secret),
+		owner:     uuid.NewString(),
+	}
+}
+
+// Run delivers pending changes, then again every interval until ctx is cancelled
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if delivered, err := d.DispatchOnce(ctx); err != nil {
+			log.Printf("Webhook delivery failed: %v", err)
+		} else if delivered > 0 {
+			log.Printf("Delivered %d webhook events", delivered)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchOnce delivers pending changes to every endpoint this instance can lease, returning how many
+// deliveries succeeded. An endpoint that fails is retried from the same change on the next call.
+func (d *Dispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	var (
+		delivered int
+		errs      []error
+	)
+	for _, endpoint := range d.endpoints {
+		n, err := d.dispatchEndpoint(ctx, endpoint)
+		delivered += n
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", endpoint, err))
+		}
+	}
+	return delivered, errors.Join(errs...)
+}
+
+// dispatchEndpoint delivers pending changes to one endpoint until it is caught up, fails, or the lease runs out
+func (d *Dispatcher) dispatchEndpoint(ctx context.Context, endpoint string) (int, error) {
+	leasedUntil := time.Now().Add(leaseDuration)
+	cursor, err := d.db.ClaimWebhookCursor(ctx, endpoint, d.owner, leasedUntil)
+	if errors.Is(err, database.ErrLeaseHeld) {
+		// Another instance is delivering to this endpoint
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	since := cursor.LastSequence
+	for time.Now().Before(leasedUntil) {
+		changes, err := d.db.ListChanges(ctx, since, batchSize)
+		if err != nil {
+			return delivered, err
+		}
+
+		for _, change := range changes {
+			if err := d.deliver(ctx, endpoint, change); err != nil {
+				return delivered, fmt.Errorf("failed to deliver change %d: %w", change.Sequence, err)
+			}
+			if err := d.db.AdvanceWebhookCursor(ctx, endpoint, d.owner, change.Sequence); err != nil {
+				return delivered, err
+			}
+			delivered++
+			since = change.Sequence
+		}
+
+		if len(changes) < batchSize {
+			break
+		}
+	}
+	return delivered, nil
+}
+
+// deliver POSTs a change to an endpoint, succeeding on any 2xx response
+func (d *Dispatcher) deliver(ctx context.Context, endpoint string, change *apiv0.ChangeEvent) error {
+	body, err := json.Marshal(change)
+	if err != nil {
+		return fmt.Errorf("failed to encode change: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(change.Sequence, 10))
+	req.Header.Set(HeaderEvent, string(change.Type))
+	if len(d.secret) > 0 {
+		req.Header.Set(HeaderSignature, Sign(d.secret, body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBody))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the X-Registry-Signature value for body: "sha256=" followed by the hex HMAC-SHA256 of body
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
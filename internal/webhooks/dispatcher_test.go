@@ -0,0 +1,129 @@
+package webhooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/webhooks"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+func createServer(t *testing.T, db database.Database, name string) {
+	t.Helper()
+	_, err := db.CreateServer(context.Background(), &apiv0.ServerJSON{
+		Name:        name,
+		Description: "A server",
+		Version:     "1.0.0",
+		Meta: &apiv0.ServerMeta{
+			Official: &apiv0.RegistryExtensions{
+				ID:          uuid.New().String(),
+				PublishedAt: time.Now(),
+				UpdatedAt:   time.Now(),
+				IsLatest:    true,
+			},
+		},
+	})
+	require.NoError(t, err)
+}
+
+type receiver struct {
+	mu        sync.Mutex
+	failAfter int // fail every request once this many have been accepted; negative never fails
+	received  []apiv0.ChangeEvent
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.failAfter >= 0 && len(r.received) >= r.failAfter {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	body, _ := io.ReadAll(req.Body)
+	var change apiv0.ChangeEvent
+	if err := json.Unmarshal(body, &change); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if req.Header.Get(webhooks.HeaderDelivery) != strconv.FormatInt(change.Sequence, 10) ||
+		req.Header.Get(webhooks.HeaderEvent) != string(change.Type) ||
+		req.Header.Get(webhooks.HeaderSignature) != webhooks.Sign([]byte("secret"), body) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	r.received = append(r.received, change)
+}
+
+func TestDispatchOnce(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewMemoryDB()
+
+	// Changes from before an endpoint is first seen are not delivered to it
+	createServer(t, db, "com.example/before")
+
+	recv := &receiver{failAfter: 2}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	dispatcher := webhooks.NewDispatcher(db, []string{server.URL}, "secret", 5*time.Second)
+	delivered, err := dispatcher.DispatchOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+
+	for _, name := range []string{"com.example/one", "com.example/two", "com.example/three"} {
+		createServer(t, db, name)
+	}
+
+	// Delivery stops at the first failure without skipping the failed change
+	delivered, err = dispatcher.DispatchOnce(ctx)
+	require.Error(t, err)
+	assert.Equal(t, 2, delivered)
+
+	recv.mu.Lock()
+	recv.failAfter = -1
+	recv.mu.Unlock()
+
+	delivered, err = dispatcher.DispatchOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+
+	require.Len(t, recv.received, 3)
+	for i, name := range []string{"com.example/one", "com.example/two", "com.example/three"} {
+		assert.Equal(t, name, recv.received[i].Server.Name)
+		assert.Equal(t, apiv0.ChangeTypePublish, recv.received[i].Type)
+	}
+}
+
+func TestDispatchOnceSkipsLeasedEndpoints(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewMemoryDB()
+
+	recv := &receiver{failAfter: -1}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	// Another instance holds the lease on the endpoint
+	_, err := db.ClaimWebhookCursor(ctx, server.URL, "other-instance", time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	createServer(t, db, "com.example/leased")
+
+	dispatcher := webhooks.NewDispatcher(db, []string{server.URL}, "secret", 5*time.Second)
+	delivered, err := dispatcher.DispatchOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+	assert.Empty(t, recv.received)
+}
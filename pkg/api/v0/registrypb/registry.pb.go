@@ -0,0 +1,1520 @@
+// gRPC interface to the MCP registry. Messages mirror the server.json format served by the HTTP API
+// (https://github.com/modelcontextprotocol/registry/tree/main/docs), with the same field names.
+//
+// Regenerate the Go code with `make proto` after editing this file.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: pkg/api/v0/registrypb/registry.proto
+
+package registrypb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ChangeType int32
+
+const (
+	ChangeType_CHANGE_TYPE_UNSPECIFIED ChangeType = 0
+	ChangeType_CHANGE_TYPE_PUBLISH     ChangeType = 1
+	ChangeType_CHANGE_TYPE_UPDATE      ChangeType = 2
+	ChangeType_CHANGE_TYPE_DEPRECATE   ChangeType = 3
+	ChangeType_CHANGE_TYPE_DELETE      ChangeType = 4
+)
+
+// Enum value maps for ChangeType.
+var (
+	ChangeType_name = map[int32]string{
+		0: "CHANGE_TYPE_UNSPECIFIED",
+		1: "CHANGE_TYPE_PUBLISH",
+		2: "CHANGE_TYPE_UPDATE",
+		3: "CHANGE_TYPE_DEPRECATE",
+		4: "CHANGE_TYPE_DELETE",
+	}
+	ChangeType_value = map[string]int32{
+		"CHANGE_TYPE_UNSPECIFIED": 0,
+		"CHANGE_TYPE_PUBLISH":     1,
+		"CHANGE_TYPE_UPDATE":      2,
+		"CHANGE_TYPE_DEPRECATE":   3,
+		"CHANGE_TYPE_DELETE":      4,
+	}
+)
+
+func (x ChangeType) Enum() *ChangeType {
+	p := new(ChangeType)
+	*p = x
+	return p
+}
+
+func (x ChangeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChangeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_api_v0_registrypb_registry_proto_enumTypes[0].Descriptor()
+}
+
+func (ChangeType) Type() protoreflect.EnumType {
+	return &file_pkg_api_v0_registrypb_registry_proto_enumTypes[0]
+}
+
+func (x ChangeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChangeType.Descriptor instead.
+func (ChangeType) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_api_v0_registrypb_registry_proto_rawDescGZIP(), []int{0}
+}
+
+type PublishRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Server        *Server                `protobuf:"bytes,1,opt,name=server,proto3" json:"server,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishRequest) Reset() {
+	*x = PublishRequest{}
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishRequest) ProtoMessage() {}
+
+func (x *PublishRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishRequest.ProtoReflect.Descriptor instead.
+func (*PublishRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v0_registrypb_registry_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PublishRequest) GetServer() *Server {
+	if x != nil {
+		return x.Server
+	}
+	return nil
+}
+
+type GetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRequest) Reset() {
+	*x = GetRequest{}
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRequest) ProtoMessage() {}
+
+func (x *GetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
+func (*GetRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v0_registrypb_registry_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Cursor from a previous response's next_cursor
+	Cursor string `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Servers per page, 1 to 100; defaults to 30
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Only servers updated at or after this time
+	UpdatedSince *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_since,json=updatedSince,proto3" json:"updated_since,omitempty"`
+	// "latest" for latest versions only, or an exact version
+	Version string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	// Only servers with a remote or package using this transport
+	Transport string `protobuf:"bytes,5,opt,name=transport,proto3" json:"transport,omitempty"`
+	// Only servers with a package from this registry type
+	RegistryType  string `protobuf:"bytes,6,opt,name=registry_type,json=registryType,proto3" json:"registry_type,omitempty"`
+	Category      string `protobuf:"bytes,7,opt,name=category,proto3" json:"category,omitempty"`
+	Tag           string `protobuf:"bytes,8,opt,name=tag,proto3" json:"tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v0_registrypb_registry_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListRequest) GetUpdatedSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedSince
+	}
+	return nil
+}
+
+func (x *ListRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ListRequest) GetTransport() string {
+	if x != nil {
+		return x.Transport
+	}
+	return ""
+}
+
+func (x *ListRequest) GetRegistryType() string {
+	if x != nil {
+		return x.RegistryType
+	}
+	return ""
+}
+
+func (x *ListRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ListRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+type SearchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Substring of the server name to search for
+	Query  string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Cursor string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit  int32  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// "latest" for latest versions only, or an exact version
+	Version       string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v0_registrypb_registry_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type ListResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Servers []*Server              `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty"`
+	// Empty on the last page
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListResponse) Reset() {
+	*x = ListResponse{}
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResponse) ProtoMessage() {}
+
+func (x *ListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v0_registrypb_registry_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListResponse) GetServers() []*Server {
+	if x != nil {
+		return x.Servers
+	}
+	return nil
+}
+
+func (x *ListResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type WatchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Replay changes with a sequence number greater than this before streaming live changes
+	Since         int64 `protobuf:"varint,1,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v0_registrypb_registry_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *WatchRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+type ChangeEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sequence      int64                  `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Type          ChangeType             `protobuf:"varint,2,opt,name=type,proto3,enum=mcp.registry.v0.ChangeType" json:"type,omitempty"`
+	ServerId      string                 `protobuf:"bytes,3,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Server        *Server                `protobuf:"bytes,4,opt,name=server,proto3" json:"server,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeEvent) Reset() {
+	*x = ChangeEvent{}
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeEvent) ProtoMessage() {}
+
+func (x *ChangeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeEvent.ProtoReflect.Descriptor instead.
+func (*ChangeEvent) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v0_registrypb_registry_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ChangeEvent) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *ChangeEvent) GetType() ChangeType {
+	if x != nil {
+		return x.Type
+	}
+	return ChangeType_CHANGE_TYPE_UNSPECIFIED
+}
+
+func (x *ChangeEvent) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetServer() *Server {
+	if x != nil {
+		return x.Server
+	}
+	return nil
+}
+
+func (x *ChangeEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type Server struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// "active", "deprecated" or "deleted"
+	Status        string       `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Repository    *Repository  `protobuf:"bytes,4,opt,name=repository,proto3" json:"repository,omitempty"`
+	Version       string       `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	WebsiteUrl    string       `protobuf:"bytes,6,opt,name=website_url,json=websiteUrl,proto3" json:"website_url,omitempty"`
+	Packages      []*Package   `protobuf:"bytes,7,rep,name=packages,proto3" json:"packages,omitempty"`
+	Remotes       []*Transport `protobuf:"bytes,8,rep,name=remotes,proto3" json:"remotes,omitempty"`
+	Categories    []string     `protobuf:"bytes,9,rep,name=categories,proto3" json:"categories,omitempty"`
+	Tags          []string     `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	Meta          *ServerMeta  `protobuf:"bytes,11,opt,name=meta,proto3" json:"meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server) Reset() {
+	*x = Server{}
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server) ProtoMessage() {}
+
+func (x *Server) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server.ProtoReflect.Descriptor instead.
+func (*Server) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v0_registrypb_registry_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Server) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Server) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Server) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Server) GetRepository() *Repository {
+	if x != nil {
+		return x.Repository
+	}
+	return nil
+}
+
+func (x *Server) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Server) GetWebsiteUrl() string {
+	if x != nil {
+		return x.WebsiteUrl
+	}
+	return ""
+}
+
+func (x *Server) GetPackages() []*Package {
+	if x != nil {
+		return x.Packages
+	}
+	return nil
+}
+
+func (x *Server) GetRemotes() []*Transport {
+	if x != nil {
+		return x.Remotes
+	}
+	return nil
+}
+
+func (x *Server) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *Server) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Server) GetMeta() *ServerMeta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+type Repository struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Id            string                 `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	Subfolder     string                 `protobuf:"bytes,4,opt,name=subfolder,proto3" json:"subfolder,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Repository) Reset() {
+	*x = Repository{}
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Repository) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Repository) ProtoMessage() {}
+
+func (x *Repository) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Repository.ProtoReflect.Descriptor instead.
+func (*Repository) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v0_registrypb_registry_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Repository) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Repository) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Repository) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Repository) GetSubfolder() string {
+	if x != nil {
+		return x.Subfolder
+	}
+	return ""
+}
+
+type Package struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	RegistryType         string                 `protobuf:"bytes,1,opt,name=registry_type,json=registryType,proto3" json:"registry_type,omitempty"`
+	RegistryBaseUrl      string                 `protobuf:"bytes,2,opt,name=registry_base_url,json=registryBaseUrl,proto3" json:"registry_base_url,omitempty"`
+	Identifier           string                 `protobuf:"bytes,3,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	Version              string                 `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	FileSha256           string                 `protobuf:"bytes,5,opt,name=file_sha256,json=fileSha256,proto3" json:"file_sha256,omitempty"`
+	Digest               string                 `protobuf:"bytes,6,opt,name=digest,proto3" json:"digest,omitempty"`
+	RuntimeHint          string                 `protobuf:"bytes,7,opt,name=runtime_hint,json=runtimeHint,proto3" json:"runtime_hint,omitempty"`
+	Transport            *Transport             `protobuf:"bytes,8,opt,name=transport,proto3" json:"transport,omitempty"`
+	RuntimeArguments     []*Argument            `protobuf:"bytes,9,rep,name=runtime_arguments,json=runtimeArguments,proto3" json:"runtime_arguments,omitempty"`
+	PackageArguments     []*Argument            `protobuf:"bytes,10,rep,name=package_arguments,json=packageArguments,proto3" json:"package_arguments,omitempty"`
+	EnvironmentVariables []*KeyValueInput       `protobuf:"bytes,11,rep,name=environment_variables,json=environmentVariables,proto3" json:"environment_variables,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Package) Reset() {
+	*x = Package{}
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Package) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Package) ProtoMessage() {}
+
+func (x *Package) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Package.ProtoReflect.Descriptor instead.
+func (*Package) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v0_registrypb_registry_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Package) GetRegistryType() string {
+	if x != nil {
+		return x.RegistryType
+	}
+	return ""
+}
+
+func (x *Package) GetRegistryBaseUrl() string {
+	if x != nil {
+		return x.RegistryBaseUrl
+	}
+	return ""
+}
+
+func (x *Package) GetIdentifier() string {
+	if x != nil {
+		return x.Identifier
+	}
+	return ""
+}
+
+func (x *Package) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Package) GetFileSha256() string {
+	if x != nil {
+		return x.FileSha256
+	}
+	return ""
+}
+
+func (x *Package) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+func (x *Package) GetRuntimeHint() string {
+	if x != nil {
+		return x.RuntimeHint
+	}
+	return ""
+}
+
+func (x *Package) GetTransport() *Transport {
+	if x != nil {
+		return x.Transport
+	}
+	return nil
+}
+
+func (x *Package) GetRuntimeArguments() []*Argument {
+	if x != nil {
+		return x.RuntimeArguments
+	}
+	return nil
+}
+
+func (x *Package) GetPackageArguments() []*Argument {
+	if x != nil {
+		return x.PackageArguments
+	}
+	return nil
+}
+
+func (x *Package) GetEnvironmentVariables() []*KeyValueInput {
+	if x != nil {
+		return x.EnvironmentVariables
+	}
+	return nil
+}
+
+type Transport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Headers       []*KeyValueInput       `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transport) Reset() {
+	*x = Transport{}
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transport) ProtoMessage() {}
+
+func (x *Transport) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transport.ProtoReflect.Descriptor instead.
+func (*Transport) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v0_registrypb_registry_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Transport) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Transport) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Transport) GetHeaders() []*KeyValueInput {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+type Input struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Description   string                 `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	IsRequired    bool                   `protobuf:"varint,2,opt,name=is_required,json=isRequired,proto3" json:"is_required,omitempty"`
+	Format        string                 `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"`
+	Value         string                 `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	IsSecret      bool                   `protobuf:"varint,5,opt,name=is_secret,json=isSecret,proto3" json:"is_secret,omitempty"`
+	Default       string                 `protobuf:"bytes,6,opt,name=default,proto3" json:"default,omitempty"`
+	Choices       []string               `protobuf:"bytes,7,rep,name=choices,proto3" json:"choices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Input) Reset() {
+	*x = Input{}
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Input) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Input) ProtoMessage() {}
+
+func (x *Input) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Input.ProtoReflect.Descriptor instead.
+func (*Input) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v0_registrypb_registry_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Input) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Input) GetIsRequired() bool {
+	if x != nil {
+		return x.IsRequired
+	}
+	return false
+}
+
+func (x *Input) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *Input) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Input) GetIsSecret() bool {
+	if x != nil {
+		return x.IsSecret
+	}
+	return false
+}
+
+func (x *Input) GetDefault() string {
+	if x != nil {
+		return x.Default
+	}
+	return ""
+}
+
+func (x *Input) GetChoices() []string {
+	if x != nil {
+		return x.Choices
+	}
+	return nil
+}
+
+type KeyValueInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Input         *Input                 `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	Variables     map[string]*Input      `protobuf:"bytes,3,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyValueInput) Reset() {
+	*x = KeyValueInput{}
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyValueInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyValueInput) ProtoMessage() {}
+
+func (x *KeyValueInput) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyValueInput.ProtoReflect.Descriptor instead.
+func (*KeyValueInput) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v0_registrypb_registry_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *KeyValueInput) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *KeyValueInput) GetInput() *Input {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *KeyValueInput) GetVariables() map[string]*Input {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+type Argument struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "positional" or "named"
+	Type          string            `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Name          string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	IsRepeated    bool              `protobuf:"varint,3,opt,name=is_repeated,json=isRepeated,proto3" json:"is_repeated,omitempty"`
+	ValueHint     string            `protobuf:"bytes,4,opt,name=value_hint,json=valueHint,proto3" json:"value_hint,omitempty"`
+	Input         *Input            `protobuf:"bytes,5,opt,name=input,proto3" json:"input,omitempty"`
+	Variables     map[string]*Input `protobuf:"bytes,6,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Argument) Reset() {
+	*x = Argument{}
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Argument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Argument) ProtoMessage() {}
+
+func (x *Argument) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Argument.ProtoReflect.Descriptor instead.
+func (*Argument) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v0_registrypb_registry_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Argument) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Argument) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Argument) GetIsRepeated() bool {
+	if x != nil {
+		return x.IsRepeated
+	}
+	return false
+}
+
+func (x *Argument) GetValueHint() string {
+	if x != nil {
+		return x.ValueHint
+	}
+	return ""
+}
+
+func (x *Argument) GetInput() *Input {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *Argument) GetVariables() map[string]*Input {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+// ServerMeta holds the _meta extensions of a server
+type ServerMeta struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// io.modelcontextprotocol.registry/official
+	Official *RegistryExtensions `protobuf:"bytes,1,opt,name=official,proto3" json:"official,omitempty"`
+	// io.modelcontextprotocol.registry/publisher-provided
+	PublisherProvided *structpb.Struct `protobuf:"bytes,2,opt,name=publisher_provided,json=publisherProvided,proto3" json:"publisher_provided,omitempty"`
+	// The remaining registry extensions (mirror, health, stats, icon, advisories) as their JSON objects, keyed
+	// by extension name, so new extensions reach gRPC clients without a schema change
+	Extensions    *structpb.Struct `protobuf:"bytes,3,opt,name=extensions,proto3" json:"extensions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerMeta) Reset() {
+	*x = ServerMeta{}
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerMeta) ProtoMessage() {}
+
+func (x *ServerMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerMeta.ProtoReflect.Descriptor instead.
+func (*ServerMeta) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v0_registrypb_registry_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ServerMeta) GetOfficial() *RegistryExtensions {
+	if x != nil {
+		return x.Official
+	}
+	return nil
+}
+
+func (x *ServerMeta) GetPublisherProvided() *structpb.Struct {
+	if x != nil {
+		return x.PublisherProvided
+	}
+	return nil
+}
+
+func (x *ServerMeta) GetExtensions() *structpb.Struct {
+	if x != nil {
+		return x.Extensions
+	}
+	return nil
+}
+
+type RegistryExtensions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PublishedAt   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=published_at,json=publishedAt,proto3" json:"published_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	IsLatest      bool                   `protobuf:"varint,4,opt,name=is_latest,json=isLatest,proto3" json:"is_latest,omitempty"`
+	Revision      int64                  `protobuf:"varint,5,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegistryExtensions) Reset() {
+	*x = RegistryExtensions{}
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegistryExtensions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistryExtensions) ProtoMessage() {}
+
+func (x *RegistryExtensions) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v0_registrypb_registry_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistryExtensions.ProtoReflect.Descriptor instead.
+func (*RegistryExtensions) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v0_registrypb_registry_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RegistryExtensions) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RegistryExtensions) GetPublishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishedAt
+	}
+	return nil
+}
+
+func (x *RegistryExtensions) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *RegistryExtensions) GetIsLatest() bool {
+	if x != nil {
+		return x.IsLatest
+	}
+	return false
+}
+
+func (x *RegistryExtensions) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+var File_pkg_api_v0_registrypb_registry_proto protoreflect.FileDescriptor
+
+const file_pkg_api_v0_registrypb_registry_proto_rawDesc = "" +
+	"\n" +
+	"$pkg/api/v0/registrypb/registry.proto\x12\x0fmcp.registry.v0\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"A\n" +
+	"\x0ePublishRequest\x12/\n" +
+	"\x06server\x18\x01 \x01(\v2\x17.mcp.registry.v0.ServerR\x06server\"\x1c\n" +
+	"\n" +
+	"GetRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x87\x02\n" +
+	"\vListRequest\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12?\n" +
+	"\rupdated_since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\fupdatedSince\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion\x12\x1c\n" +
+	"\ttransport\x18\x05 \x01(\tR\ttransport\x12#\n" +
+	"\rregistry_type\x18\x06 \x01(\tR\fregistryType\x12\x1a\n" +
+	"\bcategory\x18\a \x01(\tR\bcategory\x12\x10\n" +
+	"\x03tag\x18\b \x01(\tR\x03tag\"m\n" +
+	"\rSearchRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion\"b\n" +
+	"\fListResponse\x121\n" +
+	"\aservers\x18\x01 \x03(\v2\x17.mcp.registry.v0.ServerR\aservers\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"$\n" +
+	"\fWatchRequest\x12\x14\n" +
+	"\x05since\x18\x01 \x01(\x03R\x05since\"\xe3\x01\n" +
+	"\vChangeEvent\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x03R\bsequence\x12/\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x1b.mcp.registry.v0.ChangeTypeR\x04type\x12\x1b\n" +
+	"\tserver_id\x18\x03 \x01(\tR\bserverId\x12/\n" +
+	"\x06server\x18\x04 \x01(\v2\x17.mcp.registry.v0.ServerR\x06server\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x9f\x03\n" +
+	"\x06Server\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12;\n" +
+	"\n" +
+	"repository\x18\x04 \x01(\v2\x1b.mcp.registry.v0.RepositoryR\n" +
+	"repository\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\tR\aversion\x12\x1f\n" +
+	"\vwebsite_url\x18\x06 \x01(\tR\n" +
+	"websiteUrl\x124\n" +
+	"\bpackages\x18\a \x03(\v2\x18.mcp.registry.v0.PackageR\bpackages\x124\n" +
+	"\aremotes\x18\b \x03(\v2\x1a.mcp.registry.v0.TransportR\aremotes\x12\x1e\n" +
+	"\n" +
+	"categories\x18\t \x03(\tR\n" +
+	"categories\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\x12/\n" +
+	"\x04meta\x18\v \x01(\v2\x1b.mcp.registry.v0.ServerMetaR\x04meta\"d\n" +
+	"\n" +
+	"Repository\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x0e\n" +
+	"\x02id\x18\x03 \x01(\tR\x02id\x12\x1c\n" +
+	"\tsubfolder\x18\x04 \x01(\tR\tsubfolder\"\x8f\x04\n" +
+	"\aPackage\x12#\n" +
+	"\rregistry_type\x18\x01 \x01(\tR\fregistryType\x12*\n" +
+	"\x11registry_base_url\x18\x02 \x01(\tR\x0fregistryBaseUrl\x12\x1e\n" +
+	"\n" +
+	"identifier\x18\x03 \x01(\tR\n" +
+	"identifier\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion\x12\x1f\n" +
+	"\vfile_sha256\x18\x05 \x01(\tR\n" +
+	"fileSha256\x12\x16\n" +
+	"\x06digest\x18\x06 \x01(\tR\x06digest\x12!\n" +
+	"\fruntime_hint\x18\a \x01(\tR\vruntimeHint\x128\n" +
+	"\ttransport\x18\b \x01(\v2\x1a.mcp.registry.v0.TransportR\ttransport\x12F\n" +
+	"\x11runtime_arguments\x18\t \x03(\v2\x19.mcp.registry.v0.ArgumentR\x10runtimeArguments\x12F\n" +
+	"\x11package_arguments\x18\n" +
+	" \x03(\v2\x19.mcp.registry.v0.ArgumentR\x10packageArguments\x12S\n" +
+	"\x15environment_variables\x18\v \x03(\v2\x1e.mcp.registry.v0.KeyValueInputR\x14environmentVariables\"k\n" +
+	"\tTransport\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x128\n" +
+	"\aheaders\x18\x03 \x03(\v2\x1e.mcp.registry.v0.KeyValueInputR\aheaders\"\xc9\x01\n" +
+	"\x05Input\x12 \n" +
+	"\vdescription\x18\x01 \x01(\tR\vdescription\x12\x1f\n" +
+	"\vis_required\x18\x02 \x01(\bR\n" +
+	"isRequired\x12\x16\n" +
+	"\x06format\x18\x03 \x01(\tR\x06format\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\tR\x05value\x12\x1b\n" +
+	"\tis_secret\x18\x05 \x01(\bR\bisSecret\x12\x18\n" +
+	"\adefault\x18\x06 \x01(\tR\adefault\x12\x18\n" +
+	"\achoices\x18\a \x03(\tR\achoices\"\xf4\x01\n" +
+	"\rKeyValueInput\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12,\n" +
+	"\x05input\x18\x02 \x01(\v2\x16.mcp.registry.v0.InputR\x05input\x12K\n" +
+	"\tvariables\x18\x03 \x03(\v2-.mcp.registry.v0.KeyValueInput.VariablesEntryR\tvariables\x1aT\n" +
+	"\x0eVariablesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12,\n" +
+	"\x05value\x18\x02 \x01(\v2\x16.mcp.registry.v0.InputR\x05value:\x028\x01\"\xbe\x02\n" +
+	"\bArgument\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
+	"\vis_repeated\x18\x03 \x01(\bR\n" +
+	"isRepeated\x12\x1d\n" +
+	"\n" +
+	"value_hint\x18\x04 \x01(\tR\tvalueHint\x12,\n" +
+	"\x05input\x18\x05 \x01(\v2\x16.mcp.registry.v0.InputR\x05input\x12F\n" +
+	"\tvariables\x18\x06 \x03(\v2(.mcp.registry.v0.Argument.VariablesEntryR\tvariables\x1aT\n" +
+	"\x0eVariablesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12,\n" +
+	"\x05value\x18\x02 \x01(\v2\x16.mcp.registry.v0.InputR\x05value:\x028\x01\"\xce\x01\n" +
+	"\n" +
+	"ServerMeta\x12?\n" +
+	"\bofficial\x18\x01 \x01(\v2#.mcp.registry.v0.RegistryExtensionsR\bofficial\x12F\n" +
+	"\x12publisher_provided\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x11publisherProvided\x127\n" +
+	"\n" +
+	"extensions\x18\x03 \x01(\v2\x17.google.protobuf.StructR\n" +
+	"extensions\"\xd7\x01\n" +
+	"\x12RegistryExtensions\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12=\n" +
+	"\fpublished_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vpublishedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1b\n" +
+	"\tis_latest\x18\x04 \x01(\bR\bisLatest\x12\x1a\n" +
+	"\brevision\x18\x05 \x01(\x03R\brevision*\x8d\x01\n" +
+	"\n" +
+	"ChangeType\x12\x1b\n" +
+	"\x17CHANGE_TYPE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13CHANGE_TYPE_PUBLISH\x10\x01\x12\x16\n" +
+	"\x12CHANGE_TYPE_UPDATE\x10\x02\x12\x19\n" +
+	"\x15CHANGE_TYPE_DEPRECATE\x10\x03\x12\x16\n" +
+	"\x12CHANGE_TYPE_DELETE\x10\x042\xe2\x02\n" +
+	"\bRegistry\x12C\n" +
+	"\aPublish\x12\x1f.mcp.registry.v0.PublishRequest\x1a\x17.mcp.registry.v0.Server\x12;\n" +
+	"\x03Get\x12\x1b.mcp.registry.v0.GetRequest\x1a\x17.mcp.registry.v0.Server\x12C\n" +
+	"\x04List\x12\x1c.mcp.registry.v0.ListRequest\x1a\x1d.mcp.registry.v0.ListResponse\x12G\n" +
+	"\x06Search\x12\x1e.mcp.registry.v0.SearchRequest\x1a\x1d.mcp.registry.v0.ListResponse\x12F\n" +
+	"\x05Watch\x12\x1d.mcp.registry.v0.WatchRequest\x1a\x1c.mcp.registry.v0.ChangeEvent0\x01B@Z>github.com/modelcontextprotocol/registry/pkg/api/v0/registrypbb\x06proto3"
+
+var (
+	file_pkg_api_v0_registrypb_registry_proto_rawDescOnce sync.Once
+	file_pkg_api_v0_registrypb_registry_proto_rawDescData []byte
+)
+
+func file_pkg_api_v0_registrypb_registry_proto_rawDescGZIP() []byte {
+	file_pkg_api_v0_registrypb_registry_proto_rawDescOnce.Do(func() {
+		file_pkg_api_v0_registrypb_registry_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_api_v0_registrypb_registry_proto_rawDesc), len(file_pkg_api_v0_registrypb_registry_proto_rawDesc)))
+	})
+	return file_pkg_api_v0_registrypb_registry_proto_rawDescData
+}
+
+var file_pkg_api_v0_registrypb_registry_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_pkg_api_v0_registrypb_registry_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_pkg_api_v0_registrypb_registry_proto_goTypes = []any{
+	(ChangeType)(0),               // 0: mcp.registry.v0.ChangeType
+	(*PublishRequest)(nil),        // 1: mcp.registry.v0.PublishRequest
+	(*GetRequest)(nil),            // 2: mcp.registry.v0.GetRequest
+	(*ListRequest)(nil),           // 3: mcp.registry.v0.ListRequest
+	(*SearchRequest)(nil),         // 4: mcp.registry.v0.SearchRequest
+	(*ListResponse)(nil),          // 5: mcp.registry.v0.ListResponse
+	(*WatchRequest)(nil),          // 6: mcp.registry.v0.WatchRequest
+	(*ChangeEvent)(nil),           // 7: mcp.registry.v0.ChangeEvent
+	(*Server)(nil),                // 8: mcp.registry.v0.Server
+	(*Repository)(nil),            // 9: mcp.registry.v0.Repository
+	(*Package)(nil),               // 10: mcp.registry.v0.Package
+	(*Transport)(nil),             // 11: mcp.registry.v0.Transport
+	(*Input)(nil),                 // 12: mcp.registry.v0.Input
+	(*KeyValueInput)(nil),         // 13: mcp.registry.v0.KeyValueInput
+	(*Argument)(nil),              // 14: mcp.registry.v0.Argument
+	(*ServerMeta)(nil),            // 15: mcp.registry.v0.ServerMeta
+	(*RegistryExtensions)(nil),    // 16: mcp.registry.v0.RegistryExtensions
+	nil,                           // 17: mcp.registry.v0.KeyValueInput.VariablesEntry
+	nil,                           // 18: mcp.registry.v0.Argument.VariablesEntry
+	(*timestamppb.Timestamp)(nil), // 19: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),       // 20: google.protobuf.Struct
+}
+var file_pkg_api_v0_registrypb_registry_proto_depIdxs = []int32{
+	8,  // 0: mcp.registry.v0.PublishRequest.server:type_name -> mcp.registry.v0.Server
+	19, // 1: mcp.registry.v0.ListRequest.updated_since:type_name -> google.protobuf.Timestamp
+	8,  // 2: mcp.registry.v0.ListResponse.servers:type_name -> mcp.registry.v0.Server
+	0,  // 3: mcp.registry.v0.ChangeEvent.type:type_name -> mcp.registry.v0.ChangeType
+	8,  // 4: mcp.registry.v0.ChangeEvent.server:type_name -> mcp.registry.v0.Server
+	19, // 5: mcp.registry.v0.ChangeEvent.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 6: mcp.registry.v0.Server.repository:type_name -> mcp.registry.v0.Repository
+	10, // 7: mcp.registry.v0.Server.packages:type_name -> mcp.registry.v0.Package
+	11, // 8: mcp.registry.v0.Server.remotes:type_name -> mcp.registry.v0.Transport
+	15, // 9: mcp.registry.v0.Server.meta:type_name -> mcp.registry.v0.ServerMeta
+	11, // 10: mcp.registry.v0.Package.transport:type_name -> mcp.registry.v0.Transport
+	14, // 11: mcp.registry.v0.Package.runtime_arguments:type_name -> mcp.registry.v0.Argument
+	14, // 12: mcp.registry.v0.Package.package_arguments:type_name -> mcp.registry.v0.Argument
+	13, // 13: mcp.registry.v0.Package.environment_variables:type_name -> mcp.registry.v0.KeyValueInput
+	13, // 14: mcp.registry.v0.Transport.headers:type_name -> mcp.registry.v0.KeyValueInput
+	12, // 15: mcp.registry.v0.KeyValueInput.input:type_name -> mcp.registry.v0.Input
+	17, // 16: mcp.registry.v0.KeyValueInput.variables:type_name -> mcp.registry.v0.KeyValueInput.VariablesEntry
+	12, // 17: mcp.registry.v0.Argument.input:type_name -> mcp.registry.v0.Input
+	18, // 18: mcp.registry.v0.Argument.variables:type_name -> mcp.registry.v0.Argument.VariablesEntry
+	16, // 19: mcp.registry.v0.ServerMeta.official:type_name -> mcp.registry.v0.RegistryExtensions
+	20, // 20: mcp.registry.v0.ServerMeta.publisher_provided:type_name -> google.protobuf.Struct
+	20, // 21: mcp.registry.v0.ServerMeta.extensions:type_name -> google.protobuf.Struct
+	19, // 22: mcp.registry.v0.RegistryExtensions.published_at:type_name -> google.protobuf.Timestamp
+	19, // 23: mcp.registry.v0.RegistryExtensions.updated_at:type_name -> google.protobuf.Timestamp
+	12, // 24: mcp.registry.v0.KeyValueInput.VariablesEntry.value:type_name -> mcp.registry.v0.Input
+	12, // 25: mcp.registry.v0.Argument.VariablesEntry.value:type_name -> mcp.registry.v0.Input
+	1,  // 26: mcp.registry.v0.Registry.Publish:input_type -> mcp.registry.v0.PublishRequest
+	2,  // 27: mcp.registry.v0.Registry.Get:input_type -> mcp.registry.v0.GetRequest
+	3,  // 28: mcp.registry.v0.Registry.List:input_type -> mcp.registry.v0.ListRequest
+	4,  // 29: mcp.registry.v0.Registry.Search:input_type -> mcp.registry.v0.SearchRequest
+	6,  // 30: mcp.registry.v0.Registry.Watch:input_type -> mcp.registry.v0.WatchRequest
+	8,  // 31: mcp.registry.v0.Registry.Publish:output_type -> mcp.registry.v0.Server
+	8,  // 32: mcp.registry.v0.Registry.Get:output_type -> mcp.registry.v0.Server
+	5,  // 33: mcp.registry.v0.Registry.List:output_type -> mcp.registry.v0.ListResponse
+	5,  // 34: mcp.registry.v0.Registry.Search:output_type -> mcp.registry.v0.ListResponse
+	7,  // 35: mcp.registry.v0.Registry.Watch:output_type -> mcp.registry.v0.ChangeEvent
+	31, // [31:36] is the sub-list for method output_type
+	26, // [26:31] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
+}
+
+func init() { file_pkg_api_v0_registrypb_registry_proto_init() }
+func file_pkg_api_v0_registrypb_registry_proto_init() {
+	if File_pkg_api_v0_registrypb_registry_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_api_v0_registrypb_registry_proto_rawDesc), len(file_pkg_api_v0_registrypb_registry_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_api_v0_registrypb_registry_proto_goTypes,
+		DependencyIndexes: file_pkg_api_v0_registrypb_registry_proto_depIdxs,
+		EnumInfos:         file_pkg_api_v0_registrypb_registry_proto_enumTypes,
+		MessageInfos:      file_pkg_api_v0_registrypb_registry_proto_msgTypes,
+	}.Build()
+	File_pkg_api_v0_registrypb_registry_proto = out.File
+	file_pkg_api_v0_registrypb_registry_proto_goTypes = nil
+	file_pkg_api_v0_registrypb_registry_proto_depIdxs = nil
+}
@@ -0,0 +1,138 @@
+package v0
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// SpecVersion is a server.json schema version the registry accepts
+type SpecVersion struct {
+	Version   string `json:"version" doc:"Date the schema version was published, e.g. 2025-07-09"`
+	SchemaURL string `json:"schema_url" doc:"URL documents of this version declare in $schema"`
+	Current   bool   `json:"current" doc:"Whether this is the version the registry validates and serves documents in"`
+}
+
+// supportedSpecVersions are the schema versions documents may declare in $schema, oldest first
+var supportedSpecVersions = []string{model.SchemaVersion20250709}
+
+const (
+	schemaURLPrefix = "https://static.modelcontextprotocol.io/schemas/"
+	schemaURLSuffix = "/server.schema.json"
+)
+
+// SchemaURL returns the $schema URL of a server.json schema version
+func SchemaURL(version string) string {
+	return schemaURLPrefix + version + schemaURLSuffix
+}
+
+// SupportedSpecVersions returns the schema versions the registry accepts, oldest first
+func SupportedSpecVersions() []SpecVersion {
+	versions := make([]SpecVersion, len(supportedSpecVersions))
+	for i, version := range supportedSpecVersions {
+		versions[i] = SpecVersion{
+			Version:   version,
+			SchemaURL: SchemaURL(version),
+			Current:   version == model.CurrentSchemaVersion,
+		}
+	}
+	return versions
+}
+
+// SpecVersionOf returns the schema version a $schema URL declares, if it names one, and whether the registry
+// supports it. Documents without $schema are in the current version.
+func SpecVersionOf(schema string) (string, bool) {
+	if schema == "" {
+		return model.CurrentSchemaVersion, true
+	}
+	version, ok := strings.CutPrefix(schema, schemaURLPrefix)
+	if !ok {
+		return "", false
+	}
+	version, ok = strings.CutSuffix(version, schemaURLSuffix)
+	if !ok || strings.Contains(version, "/") {
+		return "", false
+	}
+	return version, slices.Contains(supportedSpecVersions, version)
+}
+
+// specUpgrades convert documents written in earlier shapes of server.json to the current version, in order.
+// Each rewrites the members of a document in place and reports whether it changed anything. They leave
+// documents they don't apply to alone, so every document read goes through them.
+var specUpgrades = []func(members map[string]json.RawMessage) (bool, error){
+	upgradeUnversioned,
+}
+
+// upgradeSpecVersion converts the members of a document to the current schema version, declaring that
+// version in $schema if anything changed
+func upgradeSpecVersion(members map[string]json.RawMessage) (bool, error) {
+	upgraded := false
+	for _, upgrade := range specUpgrades {
+		changed, err := upgrade(members)
+		if err != nil {
+			return false, err
+		}
+		upgraded = upgraded || changed
+	}
+	if upgraded {
+		schema, err := json.Marshal(SchemaURL(model.CurrentSchemaVersion))
+		if err != nil {
+			return false, err
+		}
+		members["$schema"] = schema
+	}
+	return upgraded, nil
+}
+
+const (
+	// legacyRegistryMetaKey is the _meta key registry metadata was kept under before it moved to officialMetaKey
+	legacyRegistryMetaKey = "io.modelcontextprotocol.registry"
+	officialMetaKey       = "io.modelcontextprotocol.registry/official"
+)
+
+// upgradeUnversioned converts a document written before server.json declared a schema version, which nested
+// the version in version_detail and kept registry metadata under legacyRegistryMetaKey
+func upgradeUnversioned(members map[string]json.RawMessage) (bool, error) {
+	changed := false
+
+	if detail, ok := members["version_detail"]; ok {
+		if _, ok := members["version"]; !ok {
+			var versionDetail struct {
+				Version string `json:"version"`
+			}
+			if err := json.Unmarshal(detail, &versionDetail); err != nil {
+				return false, err
+			}
+			version, err := json.Marshal(versionDetail.Version)
+			if err != nil {
+				return false, err
+			}
+			members["version"] = version
+		}
+		delete(members, "version_detail")
+		changed = true
+	}
+
+	if raw, ok := members["_meta"]; ok {
+		var meta map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &meta); err != nil {
+			return false, err
+		}
+		if official, ok := meta[legacyRegistryMetaKey]; ok {
+			if _, ok := meta[officialMetaKey]; !ok {
+				meta[officialMetaKey] = official
+			}
+			delete(meta, legacyRegistryMetaKey)
+			upgraded, err := json.Marshal(meta)
+			if err != nil {
+				return false, err
+			}
+			members["_meta"] = upgraded
+			changed = true
+		}
+	}
+
+	return changed, nil
+}
@@ -1,6 +1,7 @@
 package v0
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/modelcontextprotocol/registry/pkg/model"
@@ -8,10 +9,93 @@ import (
 
 // RegistryExtensions represents registry-generated metadata
 type RegistryExtensions struct {
-	ID          string    `json:"id"`
-	PublishedAt time.Time `json:"published_at"`
-	UpdatedAt   time.Time `json:"updated_at,omitempty"`
-	IsLatest    bool      `json:"is_latest"`
+	ID           string    `json:"id"`
+	PublishedAt  time.Time `json:"published_at"`
+	UpdatedAt    time.Time `json:"updated_at,omitempty"`
+	IsLatest     bool      `json:"is_latest"`
+	Revision     int64     `json:"revision,omitempty" doc:"Incremented on every change to this record; send it back in If-Match when editing"`
+	QualityScore *int      `json:"quality_score,omitempty" doc:"Quality score from 0 to 100, computed from lint rules when the server was published or edited"`
+}
+
+// MirrorExtensions records where a mirrored server record was synced from
+type MirrorExtensions struct {
+	Source   string    `json:"source"`
+	SyncedAt time.Time `json:"synced_at"`
+}
+
+// HealthExtensions reports the liveness of a server's remotes, as last probed by the registry
+type HealthExtensions struct {
+	Remotes []RemoteHealth `json:"remotes"`
+}
+
+// RemoteHealth is the probe status of a single remote URL
+type RemoteHealth struct {
+	URL             string     `json:"url"`
+	Healthy         bool       `json:"healthy" doc:"Whether the most recent probe succeeded"`
+	LastCheckedAt   time.Time  `json:"last_checked_at"`
+	LastSeenHealthy *time.Time `json:"last_seen_healthy,omitempty" doc:"When a probe last succeeded; absent if none has"`
+	LastError       string     `json:"last_error,omitempty" doc:"Why the most recent probe failed"`
+}
+
+// StatsTrend is the direction of a server's weekly installs compared with the week before
+type StatsTrend string
+
+const (
+	StatsTrendUp   StatsTrend = "up"
+	StatsTrendDown StatsTrend = "down"
+	StatsTrendFlat StatsTrend = "flat"
+)
+
+// StatsExtensions reports how often a server has been installed and viewed, over rolling 7-day windows
+type StatsExtensions struct {
+	WeeklyInstalls         int64      `json:"weekly_installs" doc:"Installs reported in the last 7 days"`
+	PreviousWeeklyInstalls int64      `json:"previous_weekly_installs" doc:"Installs reported in the 7 days before that"`
+	WeeklyViews            int64      `json:"weekly_views" doc:"Views reported in the last 7 days"`
+	Trend                  StatsTrend `json:"trend" enum:"up,down,flat" doc:"Whether weekly installs rose or fell compared with the week before"`
+}
+
+// IconExtensions points at the icon uploaded for a server
+type IconExtensions struct {
+	URL         string `json:"url" doc:"Path of the icon image, relative to the registry's base URL"`
+	ContentType string `json:"content_type" enum:"image/png,image/svg+xml"`
+	SHA256      string `json:"sha256" doc:"Hex SHA256 digest of the icon image"`
+}
+
+// Advisory is a security advisory affecting a server version
+type Advisory struct {
+	ID               string    `json:"id"`
+	Severity         string    `json:"severity" enum:"low,medium,high,critical"`
+	Summary          string    `json:"summary" minLength:"1" maxLength:"200"`
+	Description      string    `json:"description,omitempty" maxLength:"10000"`
+	AffectedVersions string    `json:"affected_versions,omitempty" doc:"Affected versions as an npm-style range (e.g. '<1.4.2'); absent if every version is affected" maxLength:"200"`
+	Remediation      string    `json:"remediation,omitempty" doc:"What users should do, e.g. upgrade to a fixed version" maxLength:"2000"`
+	Aliases          []string  `json:"aliases,omitempty" doc:"Identifiers of the same issue elsewhere, such as CVE IDs" maxItems:"10"`
+	PublishedAt      time.Time `json:"published_at"`
+}
+
+// ValidationFinding is a single problem found while validating a publish
+type ValidationFinding struct {
+	Location string `json:"location,omitempty" doc:"Field the finding is about, e.g. packages[0]; absent for the server as a whole"`
+	Message  string `json:"message"`
+	Rule     string `json:"rule,omitempty" doc:"Lint rule that produced the finding, e.g. website-url"`
+}
+
+// ValidationReport lists the findings of validating a publish. Errors block the publish and warnings don't.
+type ValidationReport struct {
+	Errors         []ValidationFinding `json:"errors,omitempty"`
+	Warnings       []ValidationFinding `json:"warnings,omitempty"`
+	QualityScore   int                 `json:"quality_score" doc:"Quality score from 0 to 100 computed from lint rules"`
+	ValidatorCalls []ValidatorCall     `json:"validator_calls,omitempty" doc:"Requests validators sent upstream, in debug publishes only"`
+}
+
+// ValidatorCall records a request a validator sent to an upstream registry or repository host
+type ValidatorCall struct {
+	Method     string `json:"method"`
+	URL        string `json:"url" doc:"Request URL, without any credentials"`
+	Status     int    `json:"status,omitempty" doc:"Response status; absent if no response was received"`
+	DurationMs int64  `json:"duration_ms"`
+	Body       string `json:"body,omitempty" doc:"Start of the response body, for error responses only"`
+	Error      string `json:"error,omitempty" doc:"Why no response was received"`
 }
 
 // ServerListResponse represents the paginated server list response
@@ -24,6 +108,17 @@ type ServerListResponse struct {
 type ServerMeta struct {
 	Official         *RegistryExtensions    `json:"io.modelcontextprotocol.registry/official,omitempty"`
 	PublisherProvided map[string]interface{} `json:"io.modelcontextprotocol.registry/publisher-provided,omitempty"`
+	Mirror           *MirrorExtensions      `json:"io.modelcontextprotocol.registry/mirror,omitempty"`
+	Health           *HealthExtensions      `json:"io.modelcontextprotocol.registry/health,omitempty"`
+	Stats            *StatsExtensions       `json:"io.modelcontextprotocol.registry/stats,omitempty"`
+	Icon             *IconExtensions        `json:"io.modelcontextprotocol.registry/icon,omitempty"`
+	Advisories       []Advisory             `json:"io.modelcontextprotocol.registry/advisories,omitempty"`
+	Validation       *ValidationReport      `json:"io.modelcontextprotocol.registry/validation,omitempty" doc:"Findings of validating the server, in publish responses only"`
+
+	// UnknownFields holds the _meta members this registry doesn't know, so they round-trip unchanged
+	UnknownFields map[string]json.RawMessage `json:"-"`
+
+	_ struct{} `additionalProperties:"true"`
 }
 
 // ServerJSON represents complete server information as defined in the MCP spec, with extension support
@@ -37,7 +132,46 @@ type ServerJSON struct {
 	WebsiteURL    string              `json:"website_url,omitempty"`
 	Packages      []model.Package     `json:"packages,omitempty"`
 	Remotes       []model.Transport   `json:"remotes,omitempty"`
+	Categories    []string            `json:"categories,omitempty" doc:"Categories from the registry's curated taxonomy" maxItems:"3" uniqueItems:"true"`
+	Tags          []string            `json:"tags,omitempty" doc:"Free-form keywords: lowercase letters, digits and single hyphens" maxItems:"10" uniqueItems:"true"`
 	Meta          *ServerMeta         `json:"_meta,omitempty"`
+
+	// UnknownFields holds the members this registry doesn't know, e.g. fields added in a newer version of the
+	// server.json schema, so they round-trip unchanged
+	UnknownFields map[string]json.RawMessage `json:"-"`
+
+	_ struct{} `additionalProperties:"true"`
+}
+
+// ChangeType identifies the kind of write recorded in the change feed
+type ChangeType string
+
+const (
+	ChangeTypePublish   ChangeType = "publish"
+	ChangeTypeUpdate    ChangeType = "update"
+	ChangeTypeDeprecate ChangeType = "deprecate"
+	ChangeTypeDelete    ChangeType = "delete"
+)
+
+// ChangeEvent is a single entry in the registry change feed
+type ChangeEvent struct {
+	Sequence  int64      `json:"sequence"`
+	Type      ChangeType `json:"type"`
+	ServerID  string     `json:"server_id"`
+	Server    ServerJSON `json:"server"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// ChangeListResponse represents a page of the change feed
+type ChangeListResponse struct {
+	Changes  []ChangeEvent  `json:"changes"`
+	Metadata ChangeMetadata `json:"metadata"`
+}
+
+// ChangeMetadata represents change feed pagination metadata
+type ChangeMetadata struct {
+	NextSince int64 `json:"next_since"`
+	Count     int   `json:"count"`
 }
 
 // Metadata represents pagination metadata
@@ -0,0 +1,142 @@
+package v0
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// JSON names of the fields of ServerJSON and ServerMeta; other members are unknown fields
+var (
+	serverJSONFields = jsonFieldNames(reflect.TypeFor[ServerJSON]())
+	serverMetaFields = jsonFieldNames(reflect.TypeFor[ServerMeta]())
+)
+
+func jsonFieldNames(t reflect.Type) []string {
+	var names []string
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// isKnownField reports whether encoding/json decodes the member name into one of fields, which it matches
+// case-insensitively
+func isKnownField(fields []string, name string) bool {
+	return slices.ContainsFunc(fields, func(field string) bool { return strings.EqualFold(field, name) })
+}
+
+// UnmarshalJSON decodes a server, converting it to the current schema version and keeping the members it has
+// no field for in UnknownFields
+func (s *ServerJSON) UnmarshalJSON(data []byte) error {
+	type plain ServerJSON
+	var server plain
+	unknown, err := unmarshalWithUnknown(data, &server, serverJSONFields, upgradeSpecVersion)
+	if err != nil {
+		return err
+	}
+	*s = ServerJSON(server)
+	s.UnknownFields = unknown
+	return nil
+}
+
+// MarshalJSON encodes a server, including its UnknownFields
+func (s ServerJSON) MarshalJSON() ([]byte, error) {
+	type plain ServerJSON
+	return marshalWithUnknown(plain(s), s.UnknownFields, serverJSONFields)
+}
+
+// UnmarshalJSON decodes _meta, keeping the extensions it has no field for in UnknownFields
+func (m *ServerMeta) UnmarshalJSON(data []byte) error {
+	type plain ServerMeta
+	var meta plain
+	unknown, err := unmarshalWithUnknown(data, &meta, serverMetaFields, nil)
+	if err != nil {
+		return err
+	}
+	*m = ServerMeta(meta)
+	m.UnknownFields = unknown
+	return nil
+}
+
+// MarshalJSON encodes _meta, including its UnknownFields
+func (m ServerMeta) MarshalJSON() ([]byte, error) {
+	type plain ServerMeta
+	return marshalWithUnknown(plain(m), m.UnknownFields, serverMetaFields)
+}
+
+// unmarshalWithUnknown decodes the JSON object data into v and returns its members that aren't one of fields,
+// or nil if there are none. If upgrade is set, it may rewrite the members first.
+func unmarshalWithUnknown(data []byte, v any, fields []string, upgrade func(map[string]json.RawMessage) (bool, error)) (map[string]json.RawMessage, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+	if upgrade != nil {
+		changed, err := upgrade(members)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			if data, err = json.Marshal(members); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+
+	var unknown map[string]json.RawMessage
+	for name, value := range members {
+		if isKnownField(fields, name) {
+			continue
+		}
+		if unknown == nil {
+			unknown = make(map[string]json.RawMessage)
+		}
+		unknown[name] = value
+	}
+	return unknown, nil
+}
+
+// marshalWithUnknown encodes v, a struct with the given fields, and appends the unknown members to the object
+// in name order. Unknown members named like a field are dropped, so they can't shadow it.
+func marshalWithUnknown(v any, unknown map[string]json.RawMessage, fields []string) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(unknown) == 0 {
+		return data, err
+	}
+
+	names := make([]string, 0, len(unknown))
+	for name := range unknown {
+		if !isKnownField(fields, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1])
+	empty := len(bytes.TrimSpace(data[1:len(data)-1])) == 0
+	for _, name := range names {
+		if !empty {
+			buf.WriteByte(',')
+		}
+		empty = false
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(unknown[name])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -7,31 +7,38 @@ const (
 	RegistryTypeOCI   = "oci"
 	RegistryTypeNuGet = "nuget"
 	RegistryTypeMCPB  = "mcpb"
+	RegistryTypeMaven = "maven"
+	RegistryTypeGem   = "gem"
+	RegistryTypeVSIX  = "vsix"
 )
 
 // Registry Base URLs - supported package registry base URLs
 const (
-	RegistryURLNPM    = "https://registry.npmjs.org"
-	RegistryURLPyPI   = "https://pypi.org"
-	RegistryURLDocker = "https://docker.io"
-	RegistryURLNuGet  = "https://api.nuget.org"
-	RegistryURLGitHub = "https://github.com"
-	RegistryURLGitLab = "https://gitlab.com"
-	
+	RegistryURLNPM               = "https://registry.npmjs.org"
+	RegistryURLPyPI              = "https://pypi.org"
+	RegistryURLDocker            = "https://docker.io"
+	RegistryURLNuGet             = "https://api.nuget.org"
+	RegistryURLMaven             = "https://repo.maven.apache.org/maven2"
+	RegistryURLRubyGems          = "https://rubygems.org"
+	RegistryURLVSCodeMarketplace = "https://marketplace.visualstudio.com"
+	RegistryURLOpenVSX           = "https://open-vsx.org"
+	RegistryURLGitHub            = "https://github.com"
+	RegistryURLGitLab            = "https://gitlab.com"
+
 	// Additional OCI registries
-	RegistryURLGHCR          = "https://ghcr.io"
-	RegistryURLGAR           = "https://artifactregistry.googleapis.com"
-	RegistryURLGCR           = "https://gcr.io"
-	RegistryURLECR           = "https://public.ecr.aws"
-	RegistryURLACR           = "https://azurecr.io"
-	RegistryURLQuay          = "https://quay.io"
-	RegistryURLGitLabCR      = "https://registry.gitlab.com"
-	RegistryURLDockerHub     = "https://hub.docker.com"
-	RegistryURLJFrogCR       = "https://jfrog.io"
-	RegistryURLHarborCR      = "https://goharbor.io"
-	RegistryURLAlibabaACR    = "https://cr.console.aliyun.com"
-	RegistryURLIBMCR         = "https://icr.io"
-	RegistryURLOracleCR      = "https://container-registry.oracle.com"
+	RegistryURLGHCR           = "https://ghcr.io"
+	RegistryURLGAR            = "https://artifactregistry.googleapis.com"
+	RegistryURLGCR            = "https://gcr.io"
+	RegistryURLECR            = "https://public.ecr.aws"
+	RegistryURLACR            = "https://azurecr.io"
+	RegistryURLQuay           = "https://quay.io"
+	RegistryURLGitLabCR       = "https://registry.gitlab.com"
+	RegistryURLDockerHub      = "https://hub.docker.com"
+	RegistryURLJFrogCR        = "https://jfrog.io"
+	RegistryURLHarborCR       = "https://goharbor.io"
+	RegistryURLAlibabaACR     = "https://cr.console.aliyun.com"
+	RegistryURLIBMCR          = "https://icr.io"
+	RegistryURLOracleCR       = "https://container-registry.oracle.com"
 	RegistryURLDigitalOceanCR = "https://registry.digitalocean.com"
 )
 
@@ -48,4 +55,12 @@ const (
 	RuntimeHintUVX    = "uvx"
 	RuntimeHintDocker = "docker"
 	RuntimeHintDNX    = "dnx"
-)
\ No newline at end of file
+)
+
+// server.json schema versions, named by the date they were published
+const (
+	SchemaVersion20250709 = "2025-07-09"
+
+	// CurrentSchemaVersion is the version the registry validates and serves documents in
+	CurrentSchemaVersion = SchemaVersion20250709
+)
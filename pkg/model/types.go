@@ -23,9 +23,12 @@ type Package struct {
 	// RegistryBaseURL is the base URL of the package registry
 	RegistryBaseURL string `json:"registry_base_url,omitempty"`
 	// Identifier is the package identifier - either a package name (for registries) or URL (for direct downloads)
-	Identifier           string          `json:"identifier" minLength:"1"`
-	Version              string          `json:"version" minLength:"1"`
-	FileSHA256           string          `json:"file_sha256,omitempty"`
+	Identifier string `json:"identifier" minLength:"1"`
+	Version    string `json:"version" minLength:"1"`
+	FileSHA256 string `json:"file_sha256,omitempty"`
+	// Digest is the immutable content digest (e.g. "sha256:...") of an OCI image manifest.
+	// Publishers may declare it to pin an image; the registry resolves and stores it on publish.
+	Digest               string          `json:"digest,omitempty"`
 	RunTimeHint          string          `json:"runtime_hint,omitempty"`
 	Transport            Transport       `json:"transport,omitempty"`
 	RuntimeArguments     []Argument      `json:"runtime_arguments,omitempty"`
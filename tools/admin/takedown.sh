@@ -8,10 +8,11 @@ if [ -z "$SERVER_ID" ] || [ -z "$REGISTRY_TOKEN" ]; then
     exit 1
 fi
 
-# Get current server and update status to deleted
+# Get current server and update status to deleted; a takedown applies whatever revision the server is at
 curl -s "${REGISTRY_URL}/v0/servers/${SERVER_ID}" | \
 jq '.status = "deleted" | {server: .}' | \
 curl -X PUT "${REGISTRY_URL}/v0/servers/${SERVER_ID}" \
   -H "Authorization: Bearer ${REGISTRY_TOKEN}" \
+  -H "If-Match: *" \
   -H "Content-Type: application/json" \
   -d @-
\ No newline at end of file
@@ -0,0 +1,123 @@
+// bench-compare compares `go test -bench` results against a baseline and fails when the median time per
+// operation of any benchmark regressed by more than a threshold.
+//
+// Usage: go run ./tools/bench-compare [-threshold 20] baseline.txt current.txt
+//
+// Run benchmarks with -count of 5 or more, so the median isn't thrown off by a single noisy run.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// benchmarkLine matches a result line, e.g. "BenchmarkSearch-8   1500   810750 ns/op   165680 B/op"
+var benchmarkLine = regexp.MustCompile(`^(Benchmark\S+?)(?:-\d+)?\s+\d+\s+([0-9.]+) ns/op`)
+
+func main() {
+	log.SetFlags(0) // Remove timestamp from logs
+
+	threshold := flag.Float64("threshold", 20, "Maximum allowed slowdown of a benchmark's median, in percent")
+	flag.Parse()
+	if flag.NArg() != 2 {
+		log.Fatal("Usage: bench-compare [-threshold percent] baseline.txt current.txt")
+	}
+
+	baseline, err := readResults(flag.Arg(0))
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	current, err := readResults(flag.Arg(1))
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
+	if regressions := compare(baseline, current, *threshold); regressions > 0 {
+		log.Fatalf("%d benchmark(s) regressed by more than %.0f%%", regressions, *threshold)
+	}
+}
+
+// readResults reads the ns/op of each run of each benchmark in a `go test -bench` output file
+func readResults(path string) (map[string][]float64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open benchmark results: %w", err)
+	}
+	defer file.Close()
+
+	results := make(map[string][]float64)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		match := benchmarkLine.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if match == nil {
+			continue
+		}
+		nsPerOp, err := strconv.ParseFloat(match[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid result in %s: %w", path, err)
+		}
+		results[match[1]] = append(results[match[1]], nsPerOp)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("no benchmark results in %s", path)
+	}
+	return results, nil
+}
+
+// compare prints the median of each benchmark against its baseline and returns how many regressed beyond
+// threshold percent. Benchmarks missing from either side are reported but never fail the comparison.
+func compare(baseline, current map[string][]float64, threshold float64) int {
+	names := make([]string, 0, len(current))
+	for name := range current {
+		names = append(names, name)
+	}
+	for name := range baseline {
+		if _, ok := current[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	regressions := 0
+	fmt.Printf("%-50s %14s %14s %9s\n", "benchmark", "baseline ns/op", "current ns/op", "change")
+	for _, name := range names {
+		base, inBaseline := baseline[name]
+		runs, inCurrent := current[name]
+		switch {
+		case !inBaseline:
+			fmt.Printf("%-50s %14s %14.0f %9s\n", name, "-", median(runs), "new")
+		case !inCurrent:
+			fmt.Printf("%-50s %14.0f %14s %9s\n", name, median(base), "-", "missing")
+		default:
+			change := (median(runs) - median(base)) / median(base) * 100
+			status := ""
+			if change > threshold {
+				status = "  REGRESSED"
+				regressions++
+			}
+			fmt.Printf("%-50s %14.0f %14.0f %+8.1f%%%s\n", name, median(base), median(runs), change, status)
+		}
+	}
+	return regressions
+}
+
+func median(values []float64) float64 {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}